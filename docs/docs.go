@@ -371,6 +371,56 @@ const docTemplate = `{
                         "name": "apartment_type_id",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Поиск по адресу, названию ЖК и описанию (допускаются опечатки)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "ID удобств: квартира должна иметь все выбранные",
+                        "name": "amenity_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "ID правил проживания: квартира должна иметь все выбранные",
+                        "name": "house_rule_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальный рейтинг (от 1 до 5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию), relevance (по умолчанию при поиске), price_asc, price_desc, rating, reviews_count, distance",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Широта точки отсчета для sort_by=distance",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Долгота точки отсчета для sort_by=distance",
+                        "name": "longitude",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1929,26 +1979,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/concierges": {
+        "/admin/cleaning-logs": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список всех консьержей с фильтрацией и пагинацией (только для админов)",
+                "description": "Журнал уборок всех квартир с фильтрацией (только для админов)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge"
+                    "Admin - Cleaners"
                 ],
-                "summary": "Список консьержей",
+                "summary": "Журнал уборок",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Фильтр по активности",
-                        "name": "is_active",
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
                         "in": "query"
                     },
                     {
@@ -1959,67 +2020,97 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
+                        "description": "Фильтр по ID уборщицы",
+                        "name": "cleaner_id",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
+                        "description": "Фильтр по ID владельца",
+                        "name": "owner_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in_progress",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало уборки с даты (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало уборки по дату включительно (YYYY-MM-DD)",
+                        "name": "date_to",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список консьержей",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "array",
-                                    "items": {
-                                        "$ref": "#/definitions/domain.Concierge"
-                                    }
-                                },
-                                "pagination": {
-                                    "type": "object"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PaginatedResponse"
                                 },
-                                "success": {
-                                    "type": "boolean"
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CleaningLog"
+                                            }
+                                        }
+                                    }
                                 }
-                            }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/cleaning-logs/{id}/cancel": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает нового консьержа и привязывает его к квартире (только для админов)",
+                "description": "Отмена зависшей уборки в процессе (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2027,23 +2118,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "concierge"
+                    "Admin - Cleaners"
                 ],
-                "summary": "Создание консьержа",
+                "summary": "Отменить уборку (админ)",
                 "parameters": [
                     {
-                        "description": "Данные для создания консьержа",
+                        "type": "integer",
+                        "description": "ID записи уборки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отмены",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateConciergeRequest"
+                            "$ref": "#/definitions/domain.AdminCancelCleaningRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Консьерж успешно создан",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -2053,7 +2150,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Concierge"
+                                            "$ref": "#/definitions/domain.CleaningLog"
                                         }
                                     }
                                 }
@@ -2061,19 +2158,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверные данные запроса",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2081,63 +2184,121 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/concierges/apartment/{apartmentId}": {
+        "/admin/cleaning-tasks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает консьержа, назначенного на определенную квартиру (только для админов)",
+                "description": "Заявки на уборку всех квартир. Заявки без уборщицы передаются администраторам в статусе escalated",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge"
+                    "Admin - Cleaners"
                 ],
-                "summary": "Получение консьержа по квартире",
+                "summary": "Заявки на уборку (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "apartmentId",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID квартиры",
+                        "name": "apartment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID уборщицы",
+                        "name": "cleaner_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "offered",
+                            "accepted",
+                            "in_progress",
+                            "completed",
+                            "escalated",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только незакрытые заявки",
+                        "name": "open",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только просроченные уборки",
+                        "name": "is_late",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Консьерж найден",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                    "$ref": "#/definitions/domain.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Concierge"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CleaningTask"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Консьерж не найден",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2145,14 +2306,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/concierges/assign": {
+        "/admin/cleaning-tasks/{id}/assign": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Назначает консьержа для обслуживания определенной квартиры (только для админов)",
+                "description": "Предложить заявку выбранной уборщице квартиры вне очереди, в том числе после эскалации",
                 "consumes": [
                     "application/json"
                 ],
@@ -2160,31 +2321,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "concierge"
+                    "Admin - Cleaners"
                 ],
-                "summary": "Назначение консьержа на квартиру",
+                "summary": "Назначить уборщицу на заявку (админ)",
                 "parameters": [
                     {
-                        "description": "ID пользователя и квартиры",
+                        "type": "integer",
+                        "description": "ID заявки на уборку",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Уборщица",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "apartment_id": {
-                                    "type": "integer"
-                                },
-                                "user_id": {
-                                    "type": "integer"
-                                }
-                            }
+                            "$ref": "#/definitions/domain.AssignCleaningTaskRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Консьерж назначен",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -2194,7 +2354,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.Concierge"
+                                            "$ref": "#/definitions/domain.CleaningTask"
                                         }
                                     }
                                 }
@@ -2202,19 +2362,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Неверные данные запроса",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2222,50 +2388,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/concierges/owner/{ownerId}": {
+        "/admin/concierges": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список консьержей, назначенных на квартиры определенного владельца (только для админов)",
+                "description": "Получает список всех консьержей с фильтрацией и пагинацией (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "concierge"
                 ],
-                "summary": "Получение консьержей владельца",
+                "summary": "Список консьержей",
                 "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Фильтр по активности",
+                        "name": "is_active",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID владельца",
-                        "name": "ownerId",
-                        "in": "path",
-                        "required": true
+                        "description": "Фильтр по ID квартиры",
+                        "name": "apartment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Список консьержей",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Concierge"
-                                            }
-                                        }
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/domain.Concierge"
                                     }
+                                },
+                                "pagination": {
+                                    "type": "object"
+                                },
+                                "success": {
+                                    "type": "boolean"
                                 }
-                            ]
+                            }
                         }
                     },
                     "401": {
@@ -2287,35 +2471,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/concierges/user/{userId}/status": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Проверяет, является ли пользователь консьержем (только для админов)",
+                "description": "Создает нового консьержа и привязывает его к квартире (только для админов)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "concierge"
                 ],
-                "summary": "Проверка статуса консьержа",
+                "summary": "Создание консьержа",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "userId",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные для создания консьержа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateConciergeRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Статус пользователя",
+                    "201": {
+                        "description": "Консьерж успешно создан",
                         "schema": {
                             "allOf": [
                                 {
@@ -2325,35 +2512,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "is_concierge": {
-                                                    "type": "boolean"
-                                                },
-                                                "user_id": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/domain.Concierge"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "400": {
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "401": {
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2361,26 +2540,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/concierges/{id}": {
+        "/admin/concierges/apartment/{apartmentId}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о консьерже по его идентификатору (только для админов)",
+                "description": "Получает консьержа, назначенного на определенную квартиру (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "concierge"
                 ],
-                "summary": "Получение консьержа по ID",
+                "summary": "Получение консьержа по квартире",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID консьержа",
-                        "name": "id",
+                        "description": "ID квартиры",
+                        "name": "apartmentId",
                         "in": "path",
                         "required": true
                     }
@@ -2423,14 +2602,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/concierges/assign": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о консьерже (только для админов)",
+                "description": "Назначает консьержа для обслуживания определенной квартиры (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2440,30 +2621,43 @@ const docTemplate = `{
                 "tags": [
                     "concierge"
                 ],
-                "summary": "Обновление консьержа",
+                "summary": "Назначение консьержа на квартиру",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID консьержа",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления",
+                        "description": "ID пользователя и квартиры",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateConciergeRequest"
+                            "type": "object",
+                            "properties": {
+                                "apartment_id": {
+                                    "type": "integer"
+                                },
+                                "user_id": {
+                                    "type": "integer"
+                                }
+                            }
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Консьерж обновлен",
+                    "201": {
+                        "description": "Консьерж назначен",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Concierge"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2483,49 +2677,54 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Консьерж не найден",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/concierges/owner/{ownerId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет консьержа (только для админов)",
+                "description": "Получает список консьержей, назначенных на квартиры определенного владельца (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "concierge"
                 ],
-                "summary": "Удаление консьержа",
+                "summary": "Получение консьержей владельца",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID консьержа",
-                        "name": "id",
+                        "description": "ID владельца",
+                        "name": "ownerId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Консьерж удален",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Ошибка при удалении",
+                        "description": "Список консьержей",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Concierge"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -2540,8 +2739,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Консьерж не найден",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2549,57 +2748,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/concierges/{id}/apartments/{apartmentId}/remove": {
-            "delete": {
+        "/admin/concierges/user/{userId}/status": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отзывает консьержа с обслуживания квартиры (только для админов)",
+                "description": "Проверяет, является ли пользователь консьержем (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "concierge"
                 ],
-                "summary": "Отзыв консьержа с квартиры",
+                "summary": "Проверка статуса консьержа",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "apartmentId",
+                        "description": "ID пользователя",
+                        "name": "userId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Консьерж отозван",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Ошибка при отзыве",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Не авторизован",
+                        "description": "Статус пользователя",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "is_concierge": {
+                                                    "type": "boolean"
+                                                },
+                                                "user_id": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Не авторизован",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
                         "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Консьерж не найден",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2607,81 +2820,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/dashboard/statistics": {
+        "/admin/concierges/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает комплексную статистику для админского дашборда с фильтрацией по датам (только для админов)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает информацию о консьерже по его идентификатору (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "concierge"
                 ],
-                "summary": "Полная статистика дашборда (админ)",
+                "summary": "Получение консьержа по ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Дата начала (YYYY-MM-DD)",
-                        "name": "date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата окончания (YYYY-MM-DD)",
-                        "name": "date_to",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "day",
-                        "description": "Группировка данных (day, week, month)",
-                        "name": "period",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "ID консьержа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Консьерж найден",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Concierge"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Консьерж не найден",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/admin/locks": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех замков в системе с расширенной информацией и фильтрами (только для админов)",
+                "description": "Обновляет информацию о консьерже (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2689,217 +2897,168 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "concierge"
                 ],
-                "summary": "Получение всех замков (админ)",
+                "summary": "Обновление консьержа",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Статус замка (closed, open)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Минимальный уровень батареи (0-100)",
-                        "name": "min_battery_level",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Максимальный уровень батареи (0-100)",
-                        "name": "max_battery_level",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Статус онлайн (true - онлайн, false - офлайн)",
-                        "name": "is_online",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "apartment_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Замки без привязки к квартире (true - только непривязанные)",
-                        "name": "unbound",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
+                        "description": "ID консьержа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Данные для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateConciergeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Консьерж обновлен",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Неверные данные запроса",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Консьерж не найден",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/admin/locks/by-unique-id/{uniqueId}/passwords": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает все временные пароли замка с информацией о привязке (только для админов)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Удаляет консьержа (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "concierge"
                 ],
-                "summary": "Получение всех паролей замка (админ)",
+                "summary": "Удаление консьержа",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
+                        "type": "integer",
+                        "description": "ID консьержа",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Консьерж удален",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Ошибка при удалении",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Консьерж не найден",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/concierges/{id}/apartments/{apartmentId}/remove": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает временный пароль для замка с указанным периодом действия (только для админов)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Отзывает консьержа с обслуживания квартиры (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "concierge"
                 ],
-                "summary": "Генерация временного пароля для замка (админ)",
+                "summary": "Отзыв консьержа с квартиры",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "apartmentId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Параметры пароля",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.AdminGeneratePasswordRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Консьерж отозван",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Ошибка при отзыве",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Консьерж не найден",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -2907,14 +3066,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/locks/statistics": {
+        "/admin/dashboard/statistics": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает общую статистику по замкам в системе (только для админов)",
+                "description": "Возвращает комплексную статистику для админского дашборда с фильтрацией по датам (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2924,7 +3083,28 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Статистика замков (админ)",
+                "summary": "Полная статистика дашборда (админ)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Дата начала (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "Группировка данных (day, week, month)",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2953,14 +3133,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/locks/{id}": {
+        "/admin/locks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает детальную информацию о замке с расширенными данными (только для админов)",
+                "description": "Возвращает список всех замков в системе с расширенной информацией и фильтрами (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2970,14 +3150,57 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение замка по ID (админ)",
+                "summary": "Получение всех замков (админ)",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус замка (closed, open)",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID замка",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Минимальный уровень батареи (0-100)",
+                        "name": "min_battery_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальный уровень батареи (0-100)",
+                        "name": "max_battery_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Статус онлайн (true - онлайн, false - офлайн)",
+                        "name": "is_online",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "apartment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Замки без привязки к квартире (true - только непривязанные)",
+                        "name": "unbound",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2987,12 +3210,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3005,12 +3222,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3020,14 +3231,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/locks/{id}/bind-apartment": {
-            "post": {
+        "/admin/locks/by-unique-id/{uniqueId}/code-reveals": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Привязывает замок к квартире (только для админов)",
+                "description": "Возвращает последние раскрытия пароля владельца и временных паролей замка: кто, когда и с какого адреса (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3037,36 +3248,43 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Привязка замка к квартире (админ)",
+                "summary": "Журнал раскрытия кодов замка (админ)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID замка",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "ID квартиры",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.AdminBindLockRequest"
-                        }
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Количество записей",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.LockCodeReveal"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -3096,14 +3314,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/locks/{id}/emergency-reset": {
-            "put": {
+        "/admin/locks/by-unique-id/{uniqueId}/passwords": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Экстренный сброс замка с деактивацией всех паролей (только для админов)",
+                "description": "Возвращает все временные пароли замка с информацией о привязке (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3113,12 +3331,12 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Экстренный сброс замка (админ)",
+                "summary": "Получение всех паролей замка (админ)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID замка",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -3130,12 +3348,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3161,16 +3373,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/locks/{id}/unbind-apartment": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отвязывает замок от квартиры (только для админов)",
+                "description": "Создает временный пароль для замка с указанным периодом действия (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3180,19 +3390,28 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Отвязка замка от квартиры (админ)",
+                "summary": "Генерация временного пароля для замка (админ)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID замка",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Параметры пароля",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AdminGeneratePasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
@@ -3230,14 +3449,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/passwords/{passwordId}/deactivate": {
-            "post": {
+        "/admin/locks/statistics": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Деактивирует временный пароль замка (только для админов)",
+                "description": "Возвращает общую статистику по замкам в системе (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3247,16 +3466,7 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Деактивация пароля (админ)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID пароля",
-                        "name": "passwordId",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Статистика замков (админ)",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3264,12 +3474,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3282,12 +3486,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3297,14 +3495,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/renters/{id}/verification-status": {
-            "put": {
+        "/admin/locks/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет статус верификации арендатора (только для админов)",
+                "description": "Возвращает детальную информацию о замке с расширенными данными (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3314,22 +3512,14 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
+                "summary": "Получение замка по ID (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
+                        "description": "ID замка",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Новый статус верификации",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.UpdateVerificationStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -3372,14 +3562,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/scheduler/metrics": {
-            "get": {
+        "/admin/locks/{id}/bind-apartment": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает детальную информацию о производительности планировщика задач",
+                "description": "Привязывает замок к квартире (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3389,38 +3579,25 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение метрик производительности планировщика",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                "summary": "Привязка замка к квартире (админ)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ID квартиры",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/http.AdminBindLockRequest"
                         }
                     }
-                }
-            }
-        },
-        "/admin/scheduler/stats": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает информацию о состоянии Redis Scheduler",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin"
                 ],
-                "summary": "Получить статистику Scheduler'а",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3428,6 +3605,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3440,6 +3623,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3449,21 +3638,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/system/clear-token-cache": {
-            "post": {
+        "/admin/locks/{id}/device-secret/previous": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "ЭКСТРЕННАЯ ОЧИСТКА: удаляет все токены из Redis кэша (только для админов)",
+                "description": "Досрочно завершает льготный период после ротации: запросы, подписанные предыдущим секретом, перестают приниматься (только для админов)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "admin"
+                ],
+                "summary": "Отзыв предыдущего секрета устройства (админ)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Очистка кэша токенов",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3471,12 +3672,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3486,35 +3699,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/system/invalidate-token": {
+        "/admin/locks/{id}/device-secret/rotate": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет конкретный токен из кэша (только для админов)",
+                "description": "Выдает замку новый секрет для подписи запросов устройства. Секрет возвращается один раз; предыдущий секрет принимается в течение льготного периода (по умолчанию 24 часа). Если секрета не было, он создается (только для админов)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "admin"
                 ],
-                "summary": "Очистка конкретного токена",
+                "summary": "Ротация секрета устройства замка (админ)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Токен для удаления",
-                        "name": "token",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Льготный период",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.RotateLockDeviceSecretRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.LockDeviceSecretResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -3523,12 +3759,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3538,14 +3786,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users": {
-            "get": {
+        "/admin/locks/{id}/emergency-reset": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает список всех пользователей в системе с фильтрами (только для админов)",
+                "description": "Экстренный сброс замка с деактивацией всех паролей (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3555,51 +3803,14 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение всех пользователей (админ)",
+                "summary": "Экстренный сброс замка (админ)",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Роль пользователя (user, owner, moderator, admin)",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID города",
-                        "name": "city_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Статус верификации арендатора (pending, approved, rejected)",
-                        "name": "verification_status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Поиск по ФИО, телефону или email",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Активность пользователя (true - активен, false - неактивен)",
-                        "name": "is_active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3609,6 +3820,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3621,6 +3838,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3630,14 +3853,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/statistics": {
-            "get": {
+        "/admin/locks/{id}/unbind-apartment": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает детальную статистику по пользователям с разбивкой по ролям, статусам, городам и другим параметрам",
+                "description": "Отвязывает замок от квартиры (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3647,7 +3870,16 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Детальная статистика пользователей (админ)",
+                "summary": "Отвязка замка от квартиры (админ)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3655,6 +3887,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -3667,6 +3905,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3676,14 +3920,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}": {
-            "get": {
+        "/admin/passwords/{passwordId}/deactivate": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает детальную информацию о пользователе с расширенными данными (только для админов)",
+                "description": "Деактивирует временный пароль замка (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3693,12 +3937,12 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Получение пользователя по ID (админ)",
+                "summary": "Деактивация пароля (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
+                        "description": "ID пароля",
+                        "name": "passwordId",
                         "in": "path",
                         "required": true
                     }
@@ -3741,14 +3985,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/passwords/{passwordId}/reveal": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет аккаунт пользователя (только для админов)",
+                "description": "Возвращает временный пароль замка в открытом виде. В остальных ответах пароли маскируются, каждое раскрытие записывается в журнал (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3758,12 +4004,12 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Удаление аккаунта пользователя",
+                "summary": "Раскрытие временного пароля (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
+                        "description": "ID пароля",
+                        "name": "passwordId",
                         "in": "path",
                         "required": true
                     }
@@ -3808,14 +4054,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}/booking-history": {
-            "get": {
+        "/admin/renters/{id}/verification-status": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает детальную историю всех бронирований конкретного пользователя с аналитикой",
+                "description": "Обновляет статус верификации арендатора (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -3825,7 +4071,6 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "История бронирований пользователя (админ)",
                 "parameters": [
                     {
                         "type": "integer",
@@ -3835,47 +4080,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "default": "renter",
-                        "description": "Роль пользователя (renter, owner)",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Фильтр по статусу бронирования",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата начала периода (YYYY-MM-DD)",
-                        "name": "date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата окончания периода (YYYY-MM-DD)",
-                        "name": "date_to",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Новый статус верификации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateVerificationStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -3918,14 +4129,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}/password": {
-            "put": {
+        "/admin/scheduler/metrics": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Устанавливает новый пароль пользователю. Доступно только для админов.",
+                "description": "Возвращает детальную информацию о производительности планировщика задач",
                 "consumes": [
                     "application/json"
                 ],
@@ -3935,25 +4146,7 @@ const docTemplate = `{
                 "tags": [
                     "admin"
                 ],
-                "summary": "Установка пароля владельцу недвижимости (админ)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новый пароль",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.AdminSetPasswordRequest"
-                        }
-                    }
-                ],
+                "summary": "Получение метрик производительности планировщика",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -3961,30 +4154,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3994,53 +4163,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}/role": {
-            "put": {
+        "/admin/scheduler/payment-reconciliation": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Изменяет роль пользователя (только для админов)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает результаты последнего запуска сверки зависших платежей и неоплаченных бронирований с FreedomPay",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Изменение роли пользователя (админ)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новая роль",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.AdminUpdateUserRoleRequest"
-                        }
-                    }
-                ],
+                "summary": "Итоги последней сверки платежей",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PaymentReconciliationSummary"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -4055,12 +4209,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4070,42 +4218,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/{id}/status": {
-            "put": {
+        "/admin/scheduler/stats": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Блокировка/разблокировка пользователя (только для админов)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает информацию о состоянии Redis Scheduler",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Изменение статуса пользователя (админ)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новый статус",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.AdminUpdateUserStatusRequest"
-                        }
-                    }
-                ],
+                "summary": "Получить статистику Scheduler'а",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4113,12 +4240,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -4131,12 +4252,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4146,16 +4261,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartment-types": {
-            "get": {
-                "description": "Возвращает список всех типов квартир (включая неактивные)",
+        "/admin/system/clear-token-cache": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "ЭКСТРЕННАЯ ОЧИСТКА: удаляет все токены из Redis кэша (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartment-types"
+                    "system"
                 ],
-                "summary": "Получить все типы квартир",
+                "summary": "Очистка кэша токенов",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4163,6 +4283,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4172,22 +4298,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartment-types/{id}": {
-            "get": {
-                "description": "Возвращает информацию о типе квартиры по его ID",
+        "/admin/system/invalidate-token": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет конкретный токен из кэша (только для админов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartment-types"
+                    "system"
                 ],
-                "summary": "Получить тип квартиры по ID",
+                "summary": "Очистка конкретного токена",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID типа квартиры",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Токен для удаления",
+                        "name": "token",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -4204,8 +4335,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -4219,14 +4350,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments": {
+        "/admin/users": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список квартир с возможностью фильтрации и пагинации",
+                "description": "Возвращает список всех пользователей в системе с фильтрами (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4234,86 +4365,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Получение списка квартир",
+                "summary": "Получение всех пользователей (админ)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID города",
-                        "name": "city_id",
+                        "type": "string",
+                        "description": "Роль пользователя (user, owner, moderator, admin)",
+                        "name": "role",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "ID района",
-                        "name": "district_id",
+                        "description": "ID города",
+                        "name": "city_id",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "ID микрорайона",
-                        "name": "microdistrict_id",
+                        "type": "string",
+                        "description": "Статус верификации арендатора (pending, approved, rejected)",
+                        "name": "verification_status",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Количество комнат",
-                        "name": "room_count",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Минимальная площадь",
-                        "name": "min_area",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Максимальная площадь",
-                        "name": "max_area",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Минимальная цена",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Максимальная цена",
-                        "name": "max_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Поддержка почасовой аренды",
-                        "name": "rental_type_hourly",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Поддержка посуточной аренды",
-                        "name": "rental_type_daily",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Тип квартиры (ID)",
-                        "name": "apartment_type_id",
+                        "type": "string",
+                        "description": "Поиск по ФИО, телефону или email",
+                        "name": "search",
                         "in": "query"
                     },
                     {
                         "type": "boolean",
-                        "description": "Доступность квартиры (true - свободная, false - занятая)",
-                        "name": "is_free",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Статус квартиры",
-                        "name": "status",
+                        "description": "Активность пользователя (true - активен, false - неактивен)",
+                        "name": "is_active",
                         "in": "query"
                     },
                     {
@@ -4325,7 +4408,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "default": 10,
+                        "default": 20,
                         "description": "Размер страницы",
                         "name": "page_size",
                         "in": "query"
@@ -4338,8 +4421,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -4351,14 +4440,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/users/statistics": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новую квартиру для владельца",
+                "description": "Возвращает детальную статистику по пользователям с разбивкой по ролям, статусам, городам и другим параметрам",
                 "consumes": [
                     "application/json"
                 ],
@@ -4366,33 +4457,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
-                ],
-                "summary": "Создание новой квартиры",
-                "parameters": [
-                    {
-                        "description": "Данные квартиры",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.CreateApartmentRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "Детальная статистика пользователей (админ)",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -4414,14 +4488,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/owner/statistics": {
+        "/admin/users/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает комплексную статистику по квартирам и бронированиям владельца",
+                "description": "Возвращает детальную информацию о пользователе с расширенными данными (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4429,23 +4503,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Статистика для владельца квартир",
+                "summary": "Получение пользователя по ID (админ)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "default": "30 дней назад",
-                        "description": "Дата начала периода (YYYY-MM-DD)",
-                        "name": "date_from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "сегодня",
-                        "description": "Дата конца периода (YYYY-MM-DD)",
-                        "name": "date_to",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -4455,6 +4522,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -4467,6 +4540,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4474,16 +4553,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/apartments/photos/{photoId}": {
+            },
             "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет указанную фотографию квартиры",
+                "description": "Удаляет аккаунт пользователя (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4491,14 +4568,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Удаление фотографии квартиры",
+                "summary": "Удаление аккаунта пользователя",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID фотографии",
-                        "name": "photoId",
+                        "description": "ID пользователя",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -4528,6 +4605,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4537,9 +4620,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/search/geo": {
+        "/admin/users/{id}/booking-history": {
             "get": {
-                "description": "Возвращает квартиры в указанном прямоугольнике координат с возможностью фильтрации",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает детальную историю всех бронирований конкретного пользователя с аналитикой",
                 "consumes": [
                     "application/json"
                 ],
@@ -4547,124 +4635,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Поиск квартир по координатам",
+                "summary": "История бронирований пользователя (админ)",
                 "parameters": [
-                    {
-                        "type": "number",
-                        "format": "float64",
-                        "description": "Минимальная широта",
-                        "name": "min_lat",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "format": "float64",
-                        "description": "Максимальная широта",
-                        "name": "max_lat",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "format": "float64",
-                        "description": "Минимальная долгота",
-                        "name": "min_lng",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "format": "float64",
-                        "description": "Максимальная долгота",
-                        "name": "max_lng",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "ID города",
-                        "name": "city_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID района",
-                        "name": "district_id",
-                        "in": "query"
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "ID микрорайона",
-                        "name": "microdistrict_id",
+                        "type": "string",
+                        "default": "renter",
+                        "description": "Роль пользователя (renter, owner)",
+                        "name": "role",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Количество комнат",
-                        "name": "room_count",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Фильтр по статусу бронирования",
+                        "name": "status",
                         "in": "query"
                     },
                     {
-                        "type": "number",
-                        "description": "Минимальная площадь",
-                        "name": "min_area",
+                        "type": "string",
+                        "description": "Дата начала периода (YYYY-MM-DD)",
+                        "name": "date_from",
                         "in": "query"
                     },
                     {
-                        "type": "number",
-                        "description": "Максимальная площадь",
-                        "name": "max_area",
+                        "type": "string",
+                        "description": "Дата окончания периода (YYYY-MM-DD)",
+                        "name": "date_to",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Минимальная цена",
-                        "name": "min_price",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Максимальная цена",
-                        "name": "max_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Поддержка почасовой аренды",
-                        "name": "rental_type_hourly",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Поддержка посуточной аренды",
-                        "name": "rental_type_daily",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Доступность квартиры (true - свободная, false - занятая)",
-                        "name": "is_free",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Статус квартиры",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Тип объявления (owner, realtor)",
-                        "name": "listing_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Тип квартиры (ID)",
-                        "name": "apartment_type_id",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -4678,32 +4700,44 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/apartments/{id}": {
-            "get": {
+        "/admin/users/{id}/password": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о квартире по её идентификатору",
+                "description": "Устанавливает новый пароль пользователю. Доступно только для админов.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4711,16 +4745,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Получение квартиры по ID",
+                "summary": "Установка пароля владельцу недвижимости (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID пользователя",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новый пароль",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AdminSetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -4736,6 +4779,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4749,14 +4804,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/role": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию о квартире",
+                "description": "Изменяет роль пользователя (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4764,24 +4821,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Обновление квартиры",
+                "summary": "Изменение роли пользователя (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID пользователя",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления",
+                        "description": "Новая роль",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.UpdateApartmentRequest"
+                            "$ref": "#/definitions/http.AdminUpdateUserRoleRequest"
                         }
                     }
                 ],
@@ -4823,14 +4880,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/{id}/status": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет квартиру и все связанные файлы",
+                "description": "Блокировка/разблокировка пользователя (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4838,16 +4897,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "admin"
                 ],
-                "summary": "Удаление квартиры",
+                "summary": "Изменение статуса пользователя (админ)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID пользователя",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новый статус",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.AdminUpdateUserStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -4890,42 +4958,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/availability": {
+        "/apartment-types": {
             "get": {
-                "description": "Проверяет доступность квартиры на указанные даты",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает список всех типов квартир (включая неактивные)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
-                ],
-                "summary": "Проверка доступности квартиры",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата начала (формат RFC3339)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата окончания (формат RFC3339)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
-                    }
+                    "apartment-types"
                 ],
+                "summary": "Получить все типы квартир",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4933,12 +4975,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4948,32 +4984,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/available-durations": {
+        "/apartment-types/{id}": {
             "get": {
-                "description": "Возвращает массив доступных вариантов продолжительности бронирования для конкретной квартиры с учетом времени начала аренды",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает информацию о типе квартиры по его ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "apartment-types"
                 ],
-                "summary": "Получение доступных вариантов продолжительности бронирования",
+                "summary": "Получить тип квартиры по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID типа квартиры",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Время начала аренды (формат: 2006-01-02T15:04:05)",
-                        "name": "start_time",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -5004,9 +5031,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/available-slots": {
+        "/apartments": {
             "get": {
-                "description": "Получает список свободных временных слотов для бронирования квартиры на указанную дату с учетом времени уборки",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает список квартир с возможностью фильтрации и пагинации",
                 "consumes": [
                     "application/json"
                 ],
@@ -5016,78 +5048,148 @@ const docTemplate = `{
                 "tags": [
                     "apartments"
                 ],
-                "summary": "Получение доступных временных слотов с учетом длительности",
+                "summary": "Получение списка квартир",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ID города",
+                        "name": "city_id",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Дата в формате 2006-01-02",
-                        "name": "date",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "ID района",
+                        "name": "district_id",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Продолжительность бронирования в часах",
-                        "name": "duration",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
+                        "description": "ID микрорайона",
+                        "name": "microdistrict_id",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "Количество комнат",
+                        "name": "room_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная площадь",
+                        "name": "min_area",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная площадь",
+                        "name": "max_area",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/apartments/{id}/booked-dates": {
-            "get": {
-                "description": "Возвращает список дат, когда все временные слоты квартиры заняты",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "apartments"
-                ],
-                "summary": "Получение полностью забронированных дат",
-                "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Минимальная цена",
+                        "name": "min_price",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 30,
-                        "description": "Количество дней вперед для проверки",
-                        "name": "days_ahead",
+                        "description": "Максимальная цена",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Поддержка почасовой аренды",
+                        "name": "rental_type_hourly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Поддержка посуточной аренды",
+                        "name": "rental_type_daily",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Тип квартиры (ID)",
+                        "name": "apartment_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Доступность квартиры (true - свободная, false - занятая)",
+                        "name": "is_free",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поиск по адресу, названию ЖК и описанию (допускаются опечатки)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "ID удобств: квартира должна иметь все выбранные",
+                        "name": "amenity_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "ID правил проживания: квартира должна иметь все выбранные",
+                        "name": "house_rule_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальный рейтинг (от 1 до 5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию), relevance (по умолчанию при поиске), price_asc, price_desc, rating, reviews_count, distance",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Широта точки отсчета для sort_by=distance",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Долгота точки отсчета для sort_by=distance",
+                        "name": "longitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус квартиры",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "page_size",
                         "in": "query"
                     }
                 ],
@@ -5104,12 +5206,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5117,11 +5213,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/apartments/{id}/calculate-price": {
-            "get": {
-                "description": "Рассчитывает стоимость бронирования для указанной квартиры и продолжительности с учетом времени начала аренды",
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую квартиру для владельца",
                 "consumes": [
                     "application/json"
                 ],
@@ -5131,32 +5230,21 @@ const docTemplate = `{
                 "tags": [
                     "apartments"
                 ],
-                "summary": "Расчет цены бронирования",
+                "summary": "Создание новой квартиры",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Продолжительность бронирования в часах",
-                        "name": "duration",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Время начала аренды (формат: 2006-01-02T15:04:05)",
-                        "name": "start_time",
-                        "in": "query"
+                        "description": "Данные квартиры",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.CreateApartmentRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
@@ -5167,8 +5255,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -5182,50 +5276,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/can-book-now": {
+        "/apartments/calendar/{token}": {
             "get": {
-                "description": "Проверяет можно ли забронировать квартиру прямо сейчас на указанное время",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает занятые периоды квартиры в формате iCalendar (RFC 5545) для импорта на других площадках. Доступ по секретному токену",
                 "produces": [
-                    "application/json"
+                    "text/calendar"
                 ],
                 "tags": [
                     "apartments"
                 ],
-                "summary": "Проверка возможности немедленного бронирования",
+                "summary": "Публичный iCal-фид квартиры",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "Время бронирования (формат: HH:MM)",
-                        "name": "time",
-                        "in": "query",
+                        "description": "Секретный токен фида (допускается суффикс .ics)",
+                        "name": "token",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Календарь iCalendar",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -5233,14 +5317,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/confirm-agreement": {
-            "post": {
+        "/apartments/owner/statistics": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Владелец квартиры принимает договор с платформой для публикации",
+                "description": "Возвращает комплексную статистику по квартирам и бронированиям владельца",
                 "consumes": [
                     "application/json"
                 ],
@@ -5250,23 +5334,21 @@ const docTemplate = `{
                 "tags": [
                     "apartments"
                 ],
-                "summary": "Принятие договора публикации квартиры",
+                "summary": "Статистика для владельца квартир",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "default": "30 дней назад",
+                        "description": "Дата начала периода (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
                     },
                     {
-                        "description": "Данные согласия",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ConfirmApartmentAgreementRequest"
-                        }
+                        "type": "string",
+                        "default": "сегодня",
+                        "description": "Дата конца периода (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -5276,12 +5358,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -5303,14 +5379,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/contract": {
-            "post": {
+        "/apartments/photos/{photoId}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новый договор между компанией и арендодателем для квартиры",
+                "description": "Удаляет указанную фотографию квартиры",
                 "consumes": [
                     "application/json"
                 ],
@@ -5318,22 +5394,3993 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "apartments"
                 ],
-                "summary": "Создание apartment договора",
+                "summary": "Удаление фотографии квартиры",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "id",
+                        "description": "ID фотографии",
+                        "name": "photoId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/public-holidays": {
+            "get": {
+                "description": "Возвращает государственные праздники Казахстана, в которые применяется праздничная надбавка",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Государственные праздники",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Год (по умолчанию текущий)",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.PublicHoliday"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/search/geo": {
+            "get": {
+                "description": "Возвращает квартиры в указанном прямоугольнике координат с возможностью фильтрации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Поиск квартир по координатам",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Минимальная широта",
+                        "name": "min_lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная широта",
+                        "name": "max_lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная долгота",
+                        "name": "min_lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная долгота",
+                        "name": "max_lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID города",
+                        "name": "city_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID района",
+                        "name": "district_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID микрорайона",
+                        "name": "microdistrict_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество комнат",
+                        "name": "room_count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная площадь",
+                        "name": "min_area",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная площадь",
+                        "name": "max_area",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимальная цена",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальная цена",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Поддержка почасовой аренды",
+                        "name": "rental_type_hourly",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Поддержка посуточной аренды",
+                        "name": "rental_type_daily",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Доступность квартиры (true - свободная, false - занятая)",
+                        "name": "is_free",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус квартиры",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип объявления (owner, realtor)",
+                        "name": "listing_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Тип квартиры (ID)",
+                        "name": "apartment_type_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Поиск по адресу, названию ЖК и описанию (допускаются опечатки)",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "ID удобств: квартира должна иметь все выбранные",
+                        "name": "amenity_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "ID правил проживания: квартира должна иметь все выбранные",
+                        "name": "house_rule_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальный рейтинг (от 1 до 5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: newest (по умолчанию), relevance (по умолчанию при поиске), price_asc, price_desc, rating, reviews_count, distance (от центра области или от latitude/longitude)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Широта точки отсчета для sort_by=distance",
+                        "name": "latitude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Долгота точки отсчета для sort_by=distance",
+                        "name": "longitude",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает информацию о квартире по её идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Получение квартиры по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет информацию о квартире",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Обновление квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateApartmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет квартиру и все связанные файлы",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Удаление квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/availability": {
+            "get": {
+                "description": "Проверяет доступность квартиры на указанные даты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Проверка доступности квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата начала (формат RFC3339)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата окончания (формат RFC3339)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/availability-blocks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает периоды, закрытые владельцем для бронирования (ремонт, уборка, личное использование). По умолчанию - 90 дней начиная с сегодняшнего дня",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Блокировки квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Первый день периода в формате 2006-01-02",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода в формате 2006-01-02",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.AvailabilityBlock"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Закрывает период для бронирования. Причина: repair (ремонт), cleaning (уборка), personal (личное использование). Период не должен пересекаться с действующими бронированиями. Даты указываются по времени Казахстана",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Блокировка периода",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Блокировка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AvailabilityBlockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.AvailabilityBlock"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/availability-blocks/{blockId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменяет период, причину или комментарий блокировки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Изменение блокировки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID блокировки",
+                        "name": "blockId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Блокировка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.AvailabilityBlockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.AvailabilityBlock"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет блокировку, период снова становится доступным для бронирования",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Снятие блокировки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID блокировки",
+                        "name": "blockId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/available-durations": {
+            "get": {
+                "description": "Возвращает массив доступных вариантов продолжительности бронирования для конкретной квартиры с учетом времени начала аренды",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Получение доступных вариантов продолжительности бронирования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Время начала аренды (формат: 2006-01-02T15:04:05)",
+                        "name": "start_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/available-slots": {
+            "get": {
+                "description": "Получает список свободных временных слотов для бронирования квартиры на указанную дату с учетом времени уборки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Получение доступных временных слотов с учетом длительности",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата в формате 2006-01-02",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Продолжительность бронирования в часах",
+                        "name": "duration",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/booked-dates": {
+            "get": {
+                "description": "Возвращает список дат, когда все временные слоты квартиры заняты",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Получение полностью забронированных дат",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Количество дней вперед для проверки",
+                        "name": "days_ahead",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calculate-price": {
+            "get": {
+                "description": "Рассчитывает стоимость бронирования для указанной квартиры и продолжительности с учетом времени начала аренды",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Расчет цены бронирования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Продолжительность бронирования в часах (обязательна без check_in_date/check_out_date)",
+                        "name": "duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Время начала аренды (формат: 2006-01-02T15:04:05)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата заезда для посуточного бронирования на несколько ночей (формат: 2006-01-02)",
+                        "name": "check_in_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата выезда для посуточного бронирования на несколько ночей (формат: 2006-01-02)",
+                        "name": "check_out_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает бронирования, блокировки владельца и периоды из внешних календарей за выбранный период. По умолчанию - 90 дней начиная с сегодняшнего дня",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Календарь владельца",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Первый день периода в формате 2006-01-02",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Последний день периода в формате 2006-01-02",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ApartmentCalendar"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает секретную ссылку на iCal-фид квартиры для подключения на других площадках. Токен создается при первом запросе",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Ссылка на iCal-фид квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CalendarExportInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar/export/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новый секретный токен фида. Старая ссылка перестает работать",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Перевыпуск ссылки на iCal-фид",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CalendarExportInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar/external": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает подключенные iCal-календари других площадок и статус их последней синхронизации",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Внешние календари квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ExternalCalendar"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Подключает iCal-ссылку другой площадки. Календарь сразу импортируется, затем обновляется по расписанию. Импортированные события блокируют бронирование",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Подключение внешнего календаря",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Внешний календарь",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateExternalCalendarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar/external-events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает текущие и будущие периоды, импортированные из подключенных календарей других площадок",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Занятые периоды из внешних календарей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ExternalCalendarEvent"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar/external/{calendarId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Изменяет название календаря или приостанавливает его импорт. События отключенного календаря не блокируют бронирование",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Изменение внешнего календаря",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID внешнего календаря",
+                        "name": "calendarId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateExternalCalendarRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ExternalCalendar"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет внешний календарь вместе с импортированными из него периодами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Отключение внешнего календаря",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID внешнего календаря",
+                        "name": "calendarId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/calendar/external/{calendarId}/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Немедленно импортирует внешний календарь, не дожидаясь планового обновления",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Синхронизация внешнего календаря",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID внешнего календаря",
+                        "name": "calendarId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CalendarSyncResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/can-book-now": {
+            "get": {
+                "description": "Проверяет можно ли забронировать квартиру прямо сейчас на указанное время",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Проверка возможности немедленного бронирования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Время бронирования (формат: HH:MM)",
+                        "name": "time",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/cancellation-policy": {
+            "get": {
+                "description": "Возвращает действующую политику отмены квартиры (собственную или политику платформы по умолчанию)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Политика отмены квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CancellationPolicy"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Владелец выбирает политику отмены (flexible, moderate, strict). policy_code = null возвращает политику платформы по умолчанию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Изменение политики отмены квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Код политики отмены",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateApartmentCancellationPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CancellationPolicy"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/confirm-agreement": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Владелец квартиры принимает договор с платформой для публикации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Принятие договора публикации квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные согласия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ConfirmApartmentAgreementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/contract": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новый договор между компанией и арендодателем для квартиры",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contracts"
+                ],
+                "summary": "Создание apartment договора",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/documents": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет документы к указанной квартире",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Добавление документов квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "owner",
+                            "realtor"
+                        ],
+                        "type": "string",
+                        "description": "Тип документа",
+                        "name": "type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файлы документов",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/location": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет географические координаты указанной квартиры",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Обновление координат квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Координаты",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Добавляет географические координаты к указанной квартире",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Добавление координат квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Координаты",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/photos": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Загружает фотографии для квартиры",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Добавление фотографий",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файлы фотографий",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/pricing-rules": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает правила динамического ценообразования квартиры: периоды, дни недели, праздники и скидки за длительное проживание",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Правила ценообразования квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.PricingRule"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает правило ценообразования. date_range - цена (hourly_price/daily_price) и/или процент на период; weekday - процент для дней недели (0 - воскресенье); holiday - процент в государственные праздники; length_of_stay - скидка (отрицательный процент) от min_days суток",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Создание правила ценообразования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Правило ценообразования",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.PricingRule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/pricing-rules/{ruleId}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Полностью заменяет параметры правила ценообразования квартиры",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Изменение правила ценообразования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID правила",
+                        "name": "ruleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Правило ценообразования",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.PricingRule"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет правило ценообразования квартиры. Уже созданные бронирования сохраняют свой расчет стоимости",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Удаление правила ценообразования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID правила",
+                        "name": "ruleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/apartments/{id}/stay-settings": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Владелец задает время заезда и выезда (местное время) и минимальное/максимальное количество ночей",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "apartments"
+                ],
+                "summary": "Изменение параметров посуточного проживания",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры проживания",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateApartmentStaySettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Apartment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/check-phone/{phone}": {
+            "get": {
+                "description": "Проверяет, зарегистрирован ли пользователь с данным номером телефона",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Проверка существования телефона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Номер телефона",
+                        "name": "phone",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/complete-register": {
+            "post": {
+                "description": "Второй этап регистрации - проверка OTP и создание пользователя с автоматической авторизацией",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Завершение регистрации",
+                "parameters": [
+                    {
+                        "description": "Данные для завершения регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CompleteRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/http.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Авторизует пользователя по номеру телефона и паролю",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Авторизация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для входа",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Завершает сессию, к которой относится токен",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход из системы",
+                "parameters": [
+                    {
+                        "description": "Access токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/otp/request": {
+            "post": {
+                "description": "Отправляет OTP код на указанный номер телефона",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос OTP кода",
+                "parameters": [
+                    {
+                        "description": "Номер телефона для OTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OTPRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OTPRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/otp/status/{id}": {
+            "get": {
+                "description": "Проверяет статус OTP сессии",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Проверка статуса OTP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID OTP сессии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OTPStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/otp/verify": {
+            "post": {
+                "description": "Проверяет OTP код и выполняет аутентификацию пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Проверка OTP кода и аутентификация",
+                "parameters": [
+                    {
+                        "description": "Данные для проверки OTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.OTPVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OTPAuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный OTP код или неверные данные запроса",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Сессия OTP не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "OTP код уже был использован",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Время действия OTP кода истекло",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/pre-register": {
+            "post": {
+                "description": "Первый этап регистрации - отправка данных пользователя и запрос OTP",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Предварительная регистрация",
+                "parameters": [
+                    {
+                        "description": "Данные для предварительной регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PreRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Обновляет access и refresh токены. Refresh-токен одноразовый: повторное предъявление уже обмененного токена завершает сессию",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Обновление токенов",
+                "parameters": [
+                    {
+                        "description": "Refresh токен",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.RefreshTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Регистрирует нового пользователя в системе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Регистрация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает активные сессии текущего пользователя: устройство, IP-адрес и время последнего использования. Текущая сессия отмечена is_current",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Активные сессии",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Session"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Завершает все сессии текущего пользователя, включая текущую",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход на всех устройствах",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{sessionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Завершает одну из сессий текущего пользователя. Выданные по ней токены перестают действовать сразу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Завершение сессии",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID сессии",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает новое бронирование квартиры",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Создание бронирования",
+                "parameters": [
+                    {
+                        "description": "Данные бронирования",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много запросов, см. заголовок Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/my/lock-access": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает статус доступа к паролям замков для всех активных бронирований пользователя",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Получение информации о доступе к замкам для всех активных бронирований",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/number/{number}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает информацию о бронировании по его номеру",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Получить бронирование по номеру",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Номер бронирования",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает информацию о бронировании по его идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Получить бронирование по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Подтверждает бронирование (только владелец или админ)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Подтвердить бронирование",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/available-extensions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает список доступных вариантов продления для указанного бронирования с учетом следующих бронирований и времени уборки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Получить доступные варианты продления",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отменяет бронирование (для арендатора). Сумма возврата рассчитывается по политике отмены квартиры в зависимости от времени до начала (см. /bookings/{id}/cancellation-quote)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Отменить бронирование",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Причина отмены",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CancelBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/cancellation-quote": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Показывает, какая сумма будет возвращена при отмене бронирования прямо сейчас, по политике отмены квартиры",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Предварительный расчет возврата при отмене",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CancellationQuote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Подтверждает бронирование со статусом created и переводит его в pending",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Подтверждение бронирования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные подтверждения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ConfirmBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
@@ -5349,6 +9396,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5358,54 +9411,235 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/documents": {
+        "/bookings/{id}/contract": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет документы к указанной квартире",
+                "description": "Создает новый договор аренды для указанного бронирования",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "contracts"
                 ],
-                "summary": "Добавление документов квартиры",
+                "summary": "Создание rental договора для бронирования",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID бронирования",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/extend": {
+            "post": {
+                "security": [
                     {
-                        "enum": [
-                            "owner",
-                            "realtor"
-                        ],
-                        "type": "string",
-                        "description": "Тип документа",
-                        "name": "type",
-                        "in": "formData",
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Создает запрос на продление бронирования",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Продление аренды",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Файлы документов",
-                        "name": "files",
-                        "in": "formData",
+                        "description": "Данные продления",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ExtendBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/extensions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает список продлений для указанного бронирования",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Получить продления бронирования",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/extensions/{extensionId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Подтверждает запрос на продление бронирования",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Подтверждение продления",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID продления",
+                        "name": "extensionId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
@@ -5437,14 +9671,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/apartments/{id}/location": {
-            "put": {
+        "/bookings/{id}/extensions/{extensionId}/payment": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет географические координаты указанной квартиры",
+                "description": "Обрабатывает оплату для продления со статусом awaiting_payment. Можно передать либо payment_id, либо order_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -5452,24 +9686,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "bookings"
                 ],
-                "summary": "Обновление координат квартиры",
+                "summary": "Оплатить продление бронирования",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID бронирования",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Координаты",
+                        "type": "integer",
+                        "description": "ID продления",
+                        "name": "extensionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные платежа (payment_id или order_id)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/http.LocationRequest"
+                            "$ref": "#/definitions/domain.ProcessPaymentRequest"
                         }
                     }
                 ],
@@ -5505,47 +9746,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bookings/{id}/extensions/{extensionId}/payment/init": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет географические координаты к указанной квартире",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Создает платеж в FreedomPay для продления со статусом awaiting_payment и возвращает ссылку на платежную страницу",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "bookings"
                 ],
-                "summary": "Добавление координат квартиры",
+                "summary": "Инициализировать оплату продления",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID бронирования",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Координаты",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.LocationRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID продления",
+                        "name": "extensionId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.InitPaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -5559,53 +9809,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/apartments/{id}/photos": {
+        "/bookings/{id}/extensions/{extensionId}/reject": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Загружает фотографии для квартиры",
+                "description": "Отклоняет запрос на продление бронирования",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "apartments"
+                    "bookings"
                 ],
-                "summary": "Добавление фотографий",
+                "summary": "Отклонение продления",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
+                        "description": "ID бронирования",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Файлы фотографий",
-                        "name": "files",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "ID продления",
+                        "name": "extensionId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -5643,9 +9881,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/check-phone/{phone}": {
-            "get": {
-                "description": "Проверяет, зарегистрирован ли пользователь с данным номером телефона",
+        "/bookings/{id}/finish": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Позволяет арендатору завершить активное бронирование досрочно, деактивирует пароли замка и освобождает квартиру",
                 "consumes": [
                     "application/json"
                 ],
@@ -5653,14 +9896,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Проверка существования телефона",
+                "summary": "Завершение сеанса бронирования",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Номер телефона",
-                        "name": "phone",
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -5678,54 +9921,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/auth/complete-register": {
-            "post": {
-                "description": "Второй этап регистрации - проверка OTP и создание пользователя с автоматической авторизацией",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Завершение регистрации",
-                "parameters": [
-                    {
-                        "description": "Данные для завершения регистрации",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CompleteRegistrationRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/http.LoginResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -5739,9 +9948,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/bookings/{id}/generate-password": {
             "post": {
-                "description": "Авторизует пользователя по номеру телефона и паролю",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Генерирует временный пароль для замка квартиры по ID бронирования или возвращает действующий. Выдача пароля записывается в журнал раскрытий",
                 "consumes": [
                     "application/json"
                 ],
@@ -5749,25 +9963,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Авторизация пользователя",
+                "summary": "Генерация пароля для бронирования",
                 "parameters": [
                     {
-                        "description": "Данные для входа",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.LoginResponse"
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
@@ -5782,6 +9994,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5791,9 +10009,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
-                "description": "Аннулирует токен пользователя",
+        "/bookings/{id}/lock-access": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает детальную информацию о статусе доступа к паролю замка для указанного бронирования",
                 "consumes": [
                     "application/json"
                 ],
@@ -5801,18 +10024,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Выход из системы",
+                "summary": "Получение информации о доступе к замку для конкретного бронирования",
                 "parameters": [
                     {
-                        "description": "Access токен",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.LogoutRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -5828,6 +10049,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5837,9 +10070,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/otp/request": {
+        "/bookings/{id}/payment": {
             "post": {
-                "description": "Отправляет OTP код на указанный номер телефона",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Обрабатывает оплату для бронирования со статусом awaiting_payment. Можно передать либо payment_id, либо order_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -5847,17 +10085,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Запрос OTP кода",
+                "summary": "Обработать оплату бронирования",
                 "parameters": [
                     {
-                        "description": "Номер телефона для OTP",
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные платежа (payment_id или order_id)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.OTPRequest"
+                            "$ref": "#/definitions/domain.ProcessPaymentRequest"
                         }
                     }
                 ],
@@ -5865,7 +10110,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.OTPRequestResponse"
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
@@ -5874,8 +10119,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -5883,23 +10128,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/otp/status/{id}": {
-            "get": {
-                "description": "Проверяет статус OTP сессии",
-                "consumes": [
-                    "application/json"
+        "/bookings/{id}/payment/init": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Создает платеж в FreedomPay для бронирования со статусом awaiting_payment и возвращает ссылку на платежную страницу. Сумма берется из бронирования",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Проверка статуса OTP",
+                "summary": "Инициализировать оплату бронирования",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID OTP сессии",
+                        "type": "integer",
+                        "description": "ID бронирования",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -5909,7 +10156,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.OTPStatusResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.InitPaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -5918,8 +10177,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -5927,63 +10186,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/otp/verify": {
-            "post": {
-                "description": "Проверяет OTP код и выполняет аутентификацию пользователя",
-                "consumes": [
-                    "application/json"
+        "/bookings/{id}/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Возвращает чек об оплате бронирования в JSON формате",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Проверка OTP кода и аутентификация",
+                "summary": "Получить чек об оплате",
                 "parameters": [
                     {
-                        "description": "Данные для проверки OTP",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.OTPVerifyRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.OTPAuthResponse"
+                            "$ref": "#/definitions/domain.PaymentReceipt"
                         }
                     },
                     "400": {
-                        "description": "Неверный OTP код или неверные данные запроса",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Сессия OTP не найдена",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "OTP код уже был использован",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "410": {
-                        "description": "Время действия OTP кода истекло",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -5991,9 +10250,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/pre-register": {
+        "/bookings/{id}/reject": {
             "post": {
-                "description": "Первый этап регистрации - отправка данных пользователя и запрос OTP",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отклоняет бронирование (только владелец или админ)",
                 "consumes": [
                     "application/json"
                 ],
@@ -6001,17 +10265,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "bookings"
                 ],
-                "summary": "Предварительная регистрация",
+                "summary": "Отклонить бронирование",
                 "parameters": [
                     {
-                        "description": "Данные для предварительной регистрации",
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий к отклонению",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.PreRegistrationRequest"
+                            "$ref": "#/definitions/domain.RejectBookingRequest"
                         }
                     }
                 ],
@@ -6028,60 +10299,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/auth/refresh": {
-            "post": {
-                "description": "Обновляет access и refresh токены",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Обновление токенов",
-                "parameters": [
-                    {
-                        "description": "Refresh токен",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.RefreshTokenRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/http.RefreshTokenResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6095,9 +10326,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Регистрирует нового пользователя в системе",
+        "/cancellation-rules": {
+            "get": {
+                "description": "Получает список всех активных правил отмены бронирования",
                 "consumes": [
                     "application/json"
                 ],
@@ -6105,39 +10336,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Регистрация пользователя",
-                "parameters": [
-                    {
-                        "description": "Данные для регистрации",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.RegisterRequest"
-                        }
-                    }
+                    "cancellation-rules"
                 ],
+                "summary": "Получение всех активных правил отмены",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -6147,14 +10355,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Создает новое бронирование квартиры",
+        "/cancellation-rules/policies": {
+            "get": {
+                "description": "Получает список доступных политик отмены (flexible, moderate, strict) со ступенями возврата",
                 "consumes": [
                     "application/json"
                 ],
@@ -6162,37 +10365,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
-                ],
-                "summary": "Создание бронирования",
-                "parameters": [
-                    {
-                        "description": "Данные бронирования",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateBookingRequest"
-                        }
-                    }
+                    "cancellation-rules"
                 ],
+                "summary": "Получение политик отмены",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CancellationPolicy"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
@@ -6204,14 +10399,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/my/lock-access": {
+        "/cancellation-rules/type/{type}": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Возвращает статус доступа к паролям замков для всех активных бронирований пользователя",
+                "description": "Получает список правил отмены определенного типа (general, refund, conditions)",
                 "consumes": [
                     "application/json"
                 ],
@@ -6219,9 +10409,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "cancellation-rules"
+                ],
+                "summary": "Получение правил отмены по типу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Тип правил (general, refund, conditions)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение информации о доступе к замкам для всех активных бронирований",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -6229,8 +10428,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6244,14 +10443,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/number/{number}": {
-            "get": {
+        "/chat/messages/{messageId}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о бронировании по его номеру",
+                "description": "Редактирует содержимое сообщения (только автор может редактировать свои сообщения)",
                 "consumes": [
                     "application/json"
                 ],
@@ -6259,112 +10458,98 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Получить бронирование по номеру",
+                "summary": "Редактирование сообщения",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Номер бронирования",
-                        "name": "number",
+                        "type": "integer",
+                        "description": "ID сообщения",
+                        "name": "messageId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Новый текст сообщения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateMessageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Сообщение обновлено",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bookings/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о бронировании по его идентификатору",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Удаляет сообщение (только автор может удалить свое сообщение)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Получить бронирование по ID",
+                "summary": "Удаление сообщения",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
+                        "description": "ID сообщения",
+                        "name": "messageId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Сообщение удалено",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Ошибка при удалении",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6372,81 +10557,85 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/approve": {
-            "post": {
+        "/chat/rooms": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Подтверждает бронирование (только владелец или админ)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает список комнат чата пользователя с пагинацией",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Подтвердить бронирование",
+                "summary": "Список комнат чата",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по статусу комнаты (pending, active, closed)",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Список комнат чата",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/domain.ChatRoom"
+                                    }
+                                },
+                                "pagination": {
+                                    "type": "object"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bookings/{id}/available-extensions": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список доступных вариантов продления для указанного бронирования с учетом следующих бронирований и времени уборки",
+                "description": "Создает новую комнату чата для бронирования с консьержем",
                 "consumes": [
                     "application/json"
                 ],
@@ -6454,45 +10643,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Получить доступные варианты продления",
+                "summary": "Создание комнаты чата",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные для создания комнаты",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateChatRoomRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Комната чата создана",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatRoom"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6500,75 +10691,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/cancel": {
-            "post": {
+        "/chat/rooms/{roomId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отменяет бронирование (для арендатора). Возврат платежа зависит от статуса бронирования и времени до начала: если бронь approved/pending и до начала менее 6 часов - деньги не возвращаются",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает информацию о комнате чата по ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Отменить бронирование",
+                "summary": "Получение комнаты чата",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
+                        "description": "ID комнаты чата",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Причина отмены",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CancelBookingRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Комната чата",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatRoom"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6576,14 +10749,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/confirm": {
+        "/chat/rooms/{roomId}/activate": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Подтверждает бронирование со статусом created и переводит его в pending",
+                "description": "Активирует комнату чата (переводит из pending в active)",
                 "consumes": [
                     "application/json"
                 ],
@@ -6591,25 +10764,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Подтверждение бронирования",
+                "summary": "Активировать чат",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
+                        "description": "ID комнаты",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные подтверждения",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ConfirmBookingRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -6631,14 +10795,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6646,54 +10804,85 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/contract": {
+        "/chat/rooms/{roomId}/attachments": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новый договор аренды для указанного бронирования",
+                "description": "Загружает фото (jpeg, png, gif, webp, до 10 MB) или PDF-файл (до 20 MB) и отправляет его сообщением в комнату чата. Для изображений строится превью",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "chat"
                 ],
-                "summary": "Создание rental договора для бронирования",
+                "summary": "Отправка вложения",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
+                        "description": "ID комнаты чата",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл вложения",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подпись к вложению",
+                        "name": "caption",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID сообщения, на которое дается ответ",
+                        "name": "reply_to_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Вложение отправлено",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatMessage"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "413": {
+                        "description": "Файл слишком большой",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6701,14 +10890,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/extend": {
+        "/chat/rooms/{roomId}/close": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает запрос на продление бронирования",
+                "description": "Закрывает комнату чата (переводит в closed)",
                 "consumes": [
                     "application/json"
                 ],
@@ -6716,25 +10905,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Продление аренды",
+                "summary": "Закрыть чат",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
+                        "description": "ID комнаты",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные продления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.ExtendBookingRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -6756,96 +10936,134 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/bookings/{id}/extensions": {
+        "/chat/rooms/{roomId}/messages": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список продлений для указанного бронирования",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает список сообщений из указанной комнаты чата. С параметрами before/after работает курсорная пагинация по ID сообщения (ответ содержит cursor вместо pagination), иначе - постраничная",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Получить продления бронирования",
+                "summary": "Получение сообщений",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
+                        "description": "ID комнаты чата",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Вернуть сообщения старше сообщения с этим ID",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Вернуть сообщения новее сообщения с этим ID",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Количество сообщений для курсорной пагинации (максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Список сообщений",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "object",
+                            "properties": {
+                                "cursor": {
+                                    "type": "object",
+                                    "properties": {
+                                        "first_id": {
+                                            "type": "integer"
+                                        },
+                                        "has_more": {
+                                            "type": "boolean"
+                                        },
+                                        "last_id": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                },
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/domain.ChatMessage"
+                                    }
+                                },
+                                "pagination": {
+                                    "type": "object"
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Некорректный курсор",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/bookings/{id}/extensions/{extensionId}/approve": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Подтверждает запрос на продление бронирования",
+                "description": "Отправляет сообщение в указанную комнату чата",
                 "consumes": [
                     "application/json"
                 ],
@@ -6853,52 +11071,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Подтверждение продления",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
+                "summary": "Отправка сообщения",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID продления",
-                        "name": "extensionId",
+                        "description": "ID комнаты чата",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные сообщения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.SendMessageRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Сообщение отправлено",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ChatMessage"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6906,14 +11132,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/extensions/{extensionId}/payment": {
+        "/chat/rooms/{roomId}/read": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обрабатывает оплату для продления со статусом awaiting_payment. Можно передать либо payment_id, либо order_id",
+                "description": "Отмечает указанные сообщения в комнате как прочитанные",
                 "consumes": [
                     "application/json"
                 ],
@@ -6921,61 +11147,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Оплатить продление бронирования",
+                "summary": "Отметить сообщения как прочитанные",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID продления",
-                        "name": "extensionId",
+                        "description": "ID комнаты чата",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные платежа (payment_id или order_id)",
+                        "description": "Список ID сообщений",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ProcessPaymentRequest"
+                            "$ref": "#/definitions/domain.MarkMessagesReadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Сообщения отмечены как прочитанные",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -6983,67 +11196,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/extensions/{extensionId}/reject": {
-            "post": {
+        "/chat/rooms/{roomId}/unread-count": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отклоняет запрос на продление бронирования",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает количество непрочитанных сообщений в комнате чата",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "chat"
                 ],
-                "summary": "Отклонение продления",
+                "summary": "Получить количество непрочитанных сообщений",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID продления",
-                        "name": "extensionId",
+                        "description": "ID комнаты чата",
+                        "name": "roomId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                        "description": "Количество непрочитанных",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "unread_count": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Доступ запрещен",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7051,14 +11259,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/finish": {
-            "post": {
+        "/cleaner/apartments": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Позволяет арендатору завершить активное бронирование досрочно, деактивирует пароли замка и освобождает квартиру",
+                "description": "Получение списка квартир, назначенных уборщице",
                 "consumes": [
                     "application/json"
                 ],
@@ -7066,29 +11274,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
-                ],
-                "summary": "Завершение сеанса бронирования",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "cleaner"
                 ],
+                "summary": "Получить мои квартиры",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Apartment"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -7103,12 +11311,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7118,14 +11320,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/generate-password": {
-            "post": {
+        "/cleaner/apartments-for-cleaning": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Генерирует временный пароль для замка квартиры по ID бронирования",
+                "description": "Получение списка квартир, которые нуждаются в уборке",
                 "consumes": [
                     "application/json"
                 ],
@@ -7133,29 +11335,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
-                ],
-                "summary": "Генерация пароля для бронирования",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "cleaner"
                 ],
+                "summary": "Получить квартиры для уборки",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ApartmentForCleaning"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -7179,28 +11381,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/lock-access": {
+        "/cleaner/apartments/{id}/access-code": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает детальную информацию о статусе доступа к паролю замка для указанного бронирования",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получить временный код замка квартиры на время текущей уборки. Если код не был выдан при начале уборки или истек, выдается новый",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "cleaner"
                 ],
-                "summary": "Получение информации о доступе к замку для конкретного бронирования",
+                "summary": "Код замка для уборки",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
+                        "description": "ID квартиры",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -7210,7 +11409,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CleaningAccessCode"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -7240,14 +11451,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/payment": {
+        "/cleaner/cancel-cleaning": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обрабатывает оплату для бронирования со статусом awaiting_payment. Можно передать либо payment_id, либо order_id",
+                "description": "Отменить начатую уборку квартиры. Квартира остается в списке на уборку",
                 "consumes": [
                     "application/json"
                 ],
@@ -7255,24 +11466,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "cleaner"
                 ],
-                "summary": "Обработать оплату бронирования",
+                "summary": "Отменить уборку",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные платежа (payment_id или order_id)",
+                        "description": "Квартира и причина отмены",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ProcessPaymentRequest"
+                            "$ref": "#/definitions/domain.CancelCleaningRequest"
                         }
                     }
                 ],
@@ -7280,7 +11484,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CleaningLog"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -7294,39 +11510,68 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/bookings/{id}/receipt": {
-            "get": {
+        "/cleaner/complete-cleaning": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Возвращает чек об оплате бронирования в JSON формате",
+                "description": "Отметить завершение уборки квартиры и освободить её. Фотографии квартиры после уборки обязательны (от 1 до 10, JPEG, PNG или WebP в base64, до 10 МБ каждая), владелец получает уведомление",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "cleaner"
                 ],
-                "summary": "Получить чек об оплате",
+                "summary": "Завершить уборку",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные для завершения уборки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CompleteCleaningRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.PaymentReceipt"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CleaningLog"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -7347,12 +11592,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7362,14 +11601,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/reject": {
-            "post": {
+        "/cleaner/history": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отклоняет бронирование (только владелец или админ)",
+                "description": "Журнал уборок текущей уборщицы с фотографиями после уборки",
                 "consumes": [
                     "application/json"
                 ],
@@ -7377,32 +11616,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "cleaner"
                 ],
-                "summary": "Отклонить бронирование",
+                "summary": "История уборок",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Комментарий к отклонению",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.RejectBookingRequest"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID квартиры",
+                        "name": "apartment_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in_progress",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало уборки с даты (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало уборки по дату включительно (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CleaningLog"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -7423,12 +11704,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -7438,9 +11713,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cancellation-rules": {
+        "/cleaner/profile": {
             "get": {
-                "description": "Получает список всех активных правил отмены бронирования",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получение профиля уборщицы с расписанием",
                 "consumes": [
                     "application/json"
                 ],
@@ -7448,56 +11728,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cancellation-rules"
+                    "cleaner"
                 ],
-                "summary": "Получение всех активных правил отмены",
+                "summary": "Получить профиль уборщицы",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.Cleaner"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/cancellation-rules/type/{type}": {
-            "get": {
-                "description": "Получает список правил отмены определенного типа (general, refund, conditions)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "cancellation-rules"
-                ],
-                "summary": "Получение правил отмены по типу",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Тип правил (general, refund, conditions)",
-                        "name": "type",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7511,14 +11771,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/messages/{messageId}": {
+        "/cleaner/schedule": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Редактирует содержимое сообщения (только автор может редактировать свои сообщения)",
+                "description": "Полностью заменяет расписание работы уборщицы. ВНИМАНИЕ: Все дни, которые не переданы в запросе, будут очищены! Для частичного обновления используйте PATCH /cleaner/schedule/patch",
                 "consumes": [
                     "application/json"
                 ],
@@ -7526,98 +11786,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "cleaner"
                 ],
-                "summary": "Редактирование сообщения",
+                "summary": "Полное обновление расписания",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID сообщения",
-                        "name": "messageId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новый текст сообщения",
-                        "name": "request",
+                        "description": "Полное расписание на всю неделю",
+                        "name": "schedule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.UpdateMessageRequest"
+                            "$ref": "#/definitions/domain.CleanerSchedule"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Сообщение обновлено",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные запроса",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/cleaner/schedule/patch": {
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет сообщение (только автор может удалить свое сообщение)",
+                "description": "Обновляет только переданные дни недели в расписании, остальные дни остаются без изменений. Для очистки дня передайте пустой массив [], для игнорирования дня не передавайте поле вообще",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "cleaner"
                 ],
-                "summary": "Удаление сообщения",
+                "summary": "Частичное обновление расписания уборщицы",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID сообщения",
-                        "name": "messageId",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные расписания (только нужные дни). Используйте null для игнорирования дня, [] для очистки дня",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CleanerSchedulePatch"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Сообщение удалено",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Ошибка при удалении",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7625,85 +11897,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms": {
-            "get": {
+        "/cleaner/start-cleaning": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список комнат чата пользователя с пагинацией",
+                "description": "Отметить начало уборки квартиры. Если в квартире установлен замок, уборщице выдается временный код на время уборки (поле access_code), он отзывается при завершении или отмене уборки",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "cleaner"
                 ],
-                "summary": "Список комнат чата",
+                "summary": "Начать уборку",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Фильтр по статусу комнаты (pending, active, closed)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Данные для начала уборки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.StartCleaningRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список комнат чата",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "array",
-                                    "items": {
-                                        "$ref": "#/definitions/domain.ChatRoom"
-                                    }
-                                },
-                                "pagination": {
-                                    "type": "object"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
                                 },
-                                "success": {
-                                    "type": "boolean"
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CleaningLog"
+                                        }
+                                    }
                                 }
-                            }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/cleaner/stats": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новую комнату чата для бронирования с консьержем",
+                "description": "Получение статистики работы уборщицы",
                 "consumes": [
                     "application/json"
                 ],
@@ -7711,23 +11987,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
-                ],
-                "summary": "Создание комнаты чата",
-                "parameters": [
-                    {
-                        "description": "Данные для создания комнаты",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CreateChatRoomRequest"
-                        }
-                    }
+                    "cleaner"
                 ],
+                "summary": "Получить статистику уборщицы",
                 "responses": {
-                    "201": {
-                        "description": "Комната чата создана",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -7737,21 +12002,28 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ChatRoom"
+                                            "type": "object",
+                                            "additionalProperties": true
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Неверные данные запроса",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7759,57 +12031,106 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms/{roomId}": {
+        "/cleaner/tasks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о комнате чата по ID",
+                "description": "Заявки на уборку, предложенные текущей уборщице или принятые ею. Заявки создаются автоматически при выезде гостя",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "cleaner"
                 ],
-                "summary": "Получение комнаты чата",
+                "summary": "Заявки на уборку",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID комнаты чата",
-                        "name": "roomId",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID квартиры",
+                        "name": "apartment_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "offered",
+                            "accepted",
+                            "in_progress",
+                            "completed"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только незакрытые заявки",
+                        "name": "open",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Комната чата",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                    "$ref": "#/definitions/domain.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/domain.ChatRoom"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CleaningTask"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7817,14 +12138,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms/{roomId}/activate": {
+        "/cleaner/tasks/{id}/accept": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Активирует комнату чата (переводит из pending в active)",
+                "description": "Принять заявку, предложенную текущей уборщице. Принять можно до истечения времени на ответ",
                 "consumes": [
                     "application/json"
                 ],
@@ -7832,14 +12153,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "cleaner"
                 ],
-                "summary": "Активировать чат",
+                "summary": "Принять заявку на уборку",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID комнаты",
-                        "name": "roomId",
+                        "description": "ID заявки на уборку",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -7848,7 +12169,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.CleaningTask"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -7863,8 +12196,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7872,14 +12211,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms/{roomId}/close": {
+        "/cleaner/tasks/{id}/decline": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Закрывает комнату чата (переводит в closed)",
+                "description": "Отказаться от предложенной заявки. Заявка будет предложена следующей уборщице квартиры",
                 "consumes": [
                     "application/json"
                 ],
@@ -7887,16 +12226,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "cleaner"
                 ],
-                "summary": "Закрыть чат",
+                "summary": "Отказаться от заявки на уборку",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID комнаты",
-                        "name": "roomId",
+                        "description": "ID заявки на уборку",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Причина отказа",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/domain.DeclineCleaningTaskRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -7918,8 +12265,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -7927,63 +12280,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms/{roomId}/messages": {
+        "/concierge/apartments": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список сообщений из указанной комнаты чата с пагинацией",
+                "description": "Получает список квартир, закрепленных за консьержем",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
-                ],
-                "summary": "Получение сообщений",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID комнаты чата",
-                        "name": "roomId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "concierge-interface"
                 ],
+                "summary": "Получение квартир консьержа",
                 "responses": {
                     "200": {
-                        "description": "Список сообщений",
+                        "description": "Квартиры консьержа",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "array",
-                                    "items": {
-                                        "$ref": "#/definitions/domain.ChatMessage"
-                                    }
-                                },
-                                "pagination": {
-                                    "type": "object"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
                                 },
-                                "success": {
-                                    "type": "boolean"
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Apartment"
+                                            }
+                                        }
+                                    }
                                 }
-                            }
+                            ]
                         }
                     },
                     "401": {
@@ -7993,71 +12324,81 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/concierge/bookings": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отправляет сообщение в указанную комнату чата",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает список бронирований для квартир консьержа",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "concierge-interface"
                 ],
-                "summary": "Отправка сообщения",
+                "summary": "Получение броней консьержа",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только активные брони",
+                        "name": "active",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID комнаты чата",
-                        "name": "roomId",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Данные сообщения",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.SendMessageRequest"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Сообщение отправлено",
+                    "200": {
+                        "description": "Брони консьержа",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
+                            "type": "object",
+                            "properties": {
+                                "data": {
                                     "type": "object",
                                     "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.ChatMessage"
+                                        "bookings": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.Booking"
+                                            }
+                                        },
+                                        "total": {
+                                            "type": "integer"
                                         }
                                     }
+                                },
+                                "success": {
+                                    "type": "boolean"
                                 }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Неверные данные запроса",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            }
                         }
                     },
                     "401": {
@@ -8067,7 +12408,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8075,53 +12416,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms/{roomId}/read": {
-            "post": {
+        "/concierge/chat/rooms": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отмечает указанные сообщения в комнате как прочитанные",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает список чат-комнат для консьержа",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "concierge-interface"
                 ],
-                "summary": "Отметить сообщения как прочитанные",
+                "summary": "Получение чатов консьержа",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID комнаты чата",
-                        "name": "roomId",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "description": "Список ID сообщений",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.MarkMessagesReadRequest"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Сообщения отмечены как прочитанные",
-                        "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверные данные запроса",
+                        "description": "Чаты консьержа",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "rooms": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.ChatRoom"
+                                            }
+                                        },
+                                        "total": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                },
+                                "success": {
+                                    "type": "boolean"
+                                }
+                            }
                         }
                     },
                     "401": {
@@ -8131,7 +12486,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8139,33 +12494,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/rooms/{roomId}/unread-count": {
+        "/concierge/profile": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает количество непрочитанных сообщений в комнате чата",
+                "description": "Получает профиль текущего консьержа",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
-                ],
-                "summary": "Получить количество непрочитанных сообщений",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID комнаты чата",
-                        "name": "roomId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "concierge-interface"
                 ],
+                "summary": "Получение профиля консьержа",
                 "responses": {
                     "200": {
-                        "description": "Количество непрочитанных",
+                        "description": "Профиль консьержа",
                         "schema": {
                             "allOf": [
                                 {
@@ -8175,12 +12521,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "unread_count": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/domain.Concierge"
                                         }
                                     }
                                 }
@@ -8194,7 +12535,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Доступ запрещен",
+                        "description": "Недостаточно прав",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Консьерж не найден",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8202,14 +12549,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/apartments": {
-            "get": {
+        "/concierge/schedule": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение списка квартир, назначенных уборщице",
+                "description": "Обновляет рабочее расписание консьержа",
                 "consumes": [
                     "application/json"
                 ],
@@ -8217,45 +12564,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
+                    "concierge-interface"
+                ],
+                "summary": "Обновление расписания консьержа",
+                "parameters": [
+                    {
+                        "description": "Новое расписание",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ConciergeSchedule"
+                        }
+                    }
                 ],
-                "summary": "Получить мои квартиры",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Расписание обновлено",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Apartment"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Неверные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8263,27 +12606,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/apartments-for-cleaning": {
+        "/concierge/stats": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение списка квартир, которые нуждаются в уборке",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Получает статистику работы консьержа",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
+                    "concierge-interface"
                 ],
-                "summary": "Получить квартиры для уборки",
+                "summary": "Получение статистики консьержа",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Статистика консьержа",
                         "schema": {
                             "allOf": [
                                 {
@@ -8293,10 +12633,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.ApartmentForCleaning"
-                                            }
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -8304,19 +12641,13 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Не авторизован",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Недостаточно прав",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8324,14 +12655,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/complete-cleaning": {
-            "post": {
+        "/contracts": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отметить завершение уборки квартиры и освободить её",
+                "description": "Получает список всех договоров с пагинацией (только для администраторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -8339,18 +12670,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
+                    "contracts"
                 ],
-                "summary": "Завершить уборку",
+                "summary": "Получение всех договоров",
                 "parameters": [
                     {
-                        "description": "Данные для завершения уборки",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CompleteCleaningRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Количество элементов на странице",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -8360,12 +12696,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -8387,14 +12717,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/profile": {
+        "/contracts/apartment/{apartment_id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение профиля уборщицы с расписанием",
+                "description": "Получает договор между компанией и арендодателем для квартиры",
                 "consumes": [
                     "application/json"
                 ],
@@ -8402,26 +12732,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
+                    "contracts"
+                ],
+                "summary": "Получение договора квартиры",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "apartment_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить профиль уборщицы",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.Cleaner"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "401": {
@@ -8436,6 +12763,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8445,14 +12778,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/schedule": {
-            "put": {
+        "/contracts/booking/{booking_id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Полностью заменяет расписание работы уборщицы. ВНИМАНИЕ: Все дни, которые не переданы в запросе, будут очищены! Для частичного обновления используйте PATCH /cleaner/schedule/patch",
+                "description": "Получает договор аренды по идентификатору бронирования",
                 "consumes": [
                     "application/json"
                 ],
@@ -8460,18 +12793,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
+                    "contracts"
                 ],
-                "summary": "Полное обновление расписания",
+                "summary": "Получение договора по ID бронирования",
                 "parameters": [
                     {
-                        "description": "Полное расписание на всю неделю",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CleanerSchedule"
-                        }
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "booking_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -8481,12 +12812,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -8499,6 +12824,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8508,14 +12839,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/schedule/patch": {
-            "patch": {
+        "/contracts/my/apartment": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет только переданные дни недели в расписании, остальные дни остаются без изменений. Для очистки дня передайте пустой массив [], для игнорирования дня не передавайте поле вообще",
+                "description": "Получает список всех договоров квартир текущего пользователя как арендодателя",
                 "consumes": [
                     "application/json"
                 ],
@@ -8523,20 +12854,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
-                ],
-                "summary": "Частичное обновление расписания уборщицы",
-                "parameters": [
-                    {
-                        "description": "Данные расписания (только нужные дни). Используйте null для игнорирования дня, [] для очистки дня",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.CleanerSchedulePatch"
-                        }
-                    }
+                    "contracts"
                 ],
+                "summary": "Получение договоров квартир владельца",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -8544,24 +12864,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8571,35 +12879,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/start-cleaning": {
-            "post": {
+        "/contracts/my/rental": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отметить начало уборки квартиры",
+                "description": "Получает список договоров аренды текущего пользователя как арендатора",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "tags": [
-                    "cleaner"
-                ],
-                "summary": "Начать уборку",
-                "parameters": [
-                    {
-                        "description": "Данные для начала уборки",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.StartCleaningRequest"
-                        }
-                    }
-                ],
+                "tags": [
+                    "contracts"
+                ],
+                "summary": "Получение rental договоров пользователя",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -8607,24 +12904,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8634,14 +12919,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/cleaner/stats": {
+        "/contracts/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получение статистики работы уборщицы",
+                "description": "Получает договор аренды по его идентификатору с мета-данными",
                 "consumes": [
                     "application/json"
                 ],
@@ -8649,27 +12934,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "cleaner"
+                    "contracts"
+                ],
+                "summary": "Получение договора по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID договора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить статистику уборщицы",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "401": {
@@ -8684,6 +12965,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -8693,51 +12980,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/concierge/apartments": {
-            "get": {
+        "/contracts/{id}/confirm": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список квартир, закрепленных за консьержем",
+                "description": "Подтверждает договор (меняет статус на confirmed)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge-interface"
+                    "contracts"
+                ],
+                "summary": "Подтверждение договора",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID договора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение квартир консьержа",
                 "responses": {
                     "200": {
-                        "description": "Квартиры консьержа",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Apartment"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8745,83 +13035,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/concierge/bookings": {
+        "/contracts/{id}/html": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список бронирований для квартир консьержа",
+                "description": "Получает HTML содержимое договора для просмотра/печати",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge-interface"
+                    "contracts"
                 ],
-                "summary": "Получение броней консьержа",
+                "summary": "Получение HTML договора",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Фильтр по статусу",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Только активные брони",
-                        "name": "active",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "ID договора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Язык договора: ru, kk, en (по умолчанию из Accept-Language)",
+                        "name": "lang",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Предпочитаемый язык",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Брони консьержа",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "bookings": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.Booking"
-                                            }
-                                        },
-                                        "total": {
-                                            "type": "integer"
-                                        }
-                                    }
-                                },
-                                "success": {
-                                    "type": "boolean"
-                                }
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8829,77 +13108,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/concierge/chat/rooms": {
-            "get": {
+        "/contracts/{id}/status": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список чат-комнат для консьержа",
+                "description": "Обновляет статус договора (только для администраторов)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge-interface"
+                    "contracts"
                 ],
-                "summary": "Получение чатов консьержа",
+                "summary": "Обновление статуса договора",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Фильтр по статусу",
-                        "name": "status",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
+                        "description": "ID договора",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Новый статус",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.UpdateContractStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Чаты консьержа",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "data": {
-                                    "type": "object",
-                                    "properties": {
-                                        "rooms": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/domain.ChatRoom"
-                                            }
-                                        },
-                                        "total": {
-                                            "type": "integer"
-                                        }
-                                    }
-                                },
-                                "success": {
-                                    "type": "boolean"
-                                }
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8907,54 +13178,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/concierge/profile": {
-            "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+        "/device/locks/heartbeat": {
+            "post": {
+                "description": "Обновляет heartbeat и статус замка от устройства (для мониторинга онлайн/офлайн). Подпись запроса - как у /device/locks/status",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Получает профиль текущего консьержа",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge-interface"
+                    "device-locks"
+                ],
+                "summary": "Heartbeat от замка",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "X-Lock-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix-время запроса в секундах",
+                        "name": "X-Lock-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Одноразовое значение",
+                        "name": "X-Lock-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подпись запроса",
+                        "name": "X-Lock-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные heartbeat замка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.LockHeartbeatRequest"
+                        }
+                    }
                 ],
-                "summary": "Получение профиля консьержа",
                 "responses": {
                     "200": {
-                        "description": "Профиль консьержа",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/domain.Concierge"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Не авторизован",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Консьерж не найден",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -8962,14 +13258,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/concierge/schedule": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Обновляет рабочее расписание консьержа",
+        "/device/locks/status": {
+            "post": {
+                "description": "Обновляет статус умного замка от самого устройства (для интеграции с Tuya). Запрос должен быть подписан секретом устройства: заголовки X-Lock-ID, X-Lock-Timestamp (unix-время), X-Lock-Nonce (8-64 символа, одноразовый) и X-Lock-Signature = hex(HMAC-SHA256(секрет, \"METHOD\\nPATH\\nTIMESTAMP\\nNONCE\\nhex(SHA256(тело))\"))",
                 "consumes": [
                     "application/json"
                 ],
@@ -8977,41 +13268,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "concierge-interface"
+                    "device-locks"
                 ],
-                "summary": "Обновление расписания консьержа",
+                "summary": "Обновление статуса замка устройством",
                 "parameters": [
                     {
-                        "description": "Новое расписание",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "X-Lock-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix-время запроса в секундах",
+                        "name": "X-Lock-Timestamp",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Одноразовое значение",
+                        "name": "X-Lock-Nonce",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подпись запроса",
+                        "name": "X-Lock-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные статуса замка",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.ConciergeSchedule"
+                            "$ref": "#/definitions/domain.LockStatusUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Расписание обновлено",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные запроса",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав",
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -9019,48 +13338,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/concierge/stats": {
-            "get": {
+        "/devices/heartbeat": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает статистику работы консьержа",
+                "description": "Обновляет время последней активности устройства (heartbeat)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "concierge-interface"
+                    "devices"
+                ],
+                "summary": "Обновление активности устройства",
+                "parameters": [
+                    {
+                        "description": "Токен устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.DeviceHeartbeatRequest"
+                        }
+                    }
                 ],
-                "summary": "Получение статистики консьержа",
                 "responses": {
                     "200": {
-                        "description": "Статистика консьержа",
+                        "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/domain.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Не авторизован",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Недостаточно прав",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -9068,14 +13395,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts": {
-            "get": {
+        "/devices/register": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список всех договоров с пагинацией (только для администраторов)",
+                "description": "Регистрирует устройство пользователя для получения push уведомлений",
                 "consumes": [
                     "application/json"
                 ],
@@ -9083,23 +13410,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "devices"
                 ],
-                "summary": "Получение всех договоров",
+                "summary": "Регистрация устройства",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 50,
-                        "description": "Количество элементов на странице",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Данные устройства",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.RegisterDeviceRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -9109,14 +13431,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -9130,14 +13452,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/apartment/{apartment_id}": {
-            "get": {
+        "/devices/{token}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает договор между компанией и арендодателем для квартиры",
+                "description": "Деактивирует устройство в системе уведомлений",
                 "consumes": [
                     "application/json"
                 ],
@@ -9145,14 +13467,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "devices"
                 ],
-                "summary": "Получение договора квартиры",
+                "summary": "Удаление устройства",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "apartment_id",
+                        "type": "string",
+                        "description": "Токен устройства",
+                        "name": "token",
                         "in": "path",
                         "required": true
                     }
@@ -9164,22 +13486,45 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    }
+                }
+            }
+        },
+        "/dictionaries/amenities": {
+            "get": {
+                "description": "Получает список всех популярных удобств",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dictionaries"
+                ],
+                "summary": "Получение популярных удобств",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "500": {
@@ -9191,14 +13536,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/booking/{booking_id}": {
+        "/dictionaries/conditions": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Получает договор аренды по идентификатору бронирования",
+                "description": "Получает список всех доступных состояний квартир",
                 "consumes": [
                     "application/json"
                 ],
@@ -9206,18 +13546,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
-                ],
-                "summary": "Получение договора по ID бронирования",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "booking_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "dictionaries"
                 ],
+                "summary": "Получение состояний квартир",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -9225,24 +13556,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -9252,14 +13565,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/my/apartment": {
+        "/dictionaries/house-rules": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Получает список всех договоров квартир текущего пользователя как арендодателя",
+                "description": "Получает список всех доступных правил дома",
                 "consumes": [
                     "application/json"
                 ],
@@ -9267,9 +13575,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "dictionaries"
                 ],
-                "summary": "Получение договоров квартир владельца",
+                "summary": "Получение правил дома",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -9277,12 +13585,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -9292,14 +13594,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/my/rental": {
+        "/favorites": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список договоров аренды текущего пользователя как арендатора",
+                "description": "Получает список избранных квартир пользователя с пагинацией",
                 "consumes": [
                     "application/json"
                 ],
@@ -9307,39 +13609,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "favorites"
+                ],
+                "summary": "Получение избранных квартир",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получение rental договоров пользователя",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/contracts/{id}": {
-            "get": {
+        "/favorites/{apartment_id}": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает договор аренды по его идентификатору с мета-данными",
+                "description": "Добавляет квартиру в список избранных пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -9347,60 +13681,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "favorites"
                 ],
-                "summary": "Получение договора по ID",
+                "summary": "Добавление в избранное",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID договора",
-                        "name": "id",
+                        "description": "ID квартиры",
+                        "name": "apartment_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/contracts/{id}/confirm": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Подтверждает договор (меняет статус на confirmed)",
+                "description": "Удаляет указанную квартиру из избранного пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -9408,14 +13755,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "favorites"
                 ],
-                "summary": "Подтверждение договора",
+                "summary": "Удаление из избранного",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID договора",
-                        "name": "id",
+                        "description": "ID квартиры",
+                        "name": "apartment_id",
                         "in": "path",
                         "required": true
                     }
@@ -9427,14 +13774,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -9448,14 +13801,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/contracts/{id}/html": {
+        "/locations/cities": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
+                "description": "Получает список всех городов",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Получает HTML содержимое договора для просмотра/печати",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "locations"
+                ],
+                "summary": "Получение всех городов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.City"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/cities/{id}": {
+            "get": {
+                "description": "Получает информацию о городе по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -9463,13 +13846,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "locations"
                 ],
-                "summary": "Получение HTML договора",
+                "summary": "Получение города по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID договора",
+                        "description": "ID города",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -9479,44 +13862,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "$ref": "#/definitions/domain.City"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/contracts/{id}/status": {
-            "put": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Обновляет статус договора (только для администраторов)",
+        "/locations/cities/{id}/districts": {
+            "get": {
+                "description": "Получает список районов для указанного города",
                 "consumes": [
                     "application/json"
                 ],
@@ -9524,64 +13896,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "contracts"
+                    "locations"
                 ],
-                "summary": "Обновление статуса договора",
+                "summary": "Получение районов города",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID договора",
+                        "description": "ID города",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Новый статус",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.UpdateContractStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.District"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/device/locks/heartbeat": {
-            "post": {
-                "description": "Обновляет heartbeat и статус замка от устройства (для мониторинга онлайн/офлайн)",
+        "/locations/districts": {
+            "get": {
+                "description": "Получает список всех районов",
                 "consumes": [
                     "application/json"
                 ],
@@ -9589,39 +13949,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device-locks"
-                ],
-                "summary": "Heartbeat от замка",
-                "parameters": [
-                    {
-                        "description": "Данные heartbeat замка",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.LockHeartbeatRequest"
-                        }
-                    }
+                    "locations"
                 ],
+                "summary": "Получение всех районов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.District"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/device/locks/status": {
-            "post": {
-                "description": "Обновляет статус умного замка от самого устройства (для интеграции с Tuya)",
+        "/locations/districts/{id}": {
+            "get": {
+                "description": "Получает информацию о районе по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -9629,44 +13984,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "device-locks"
+                    "locations"
                 ],
-                "summary": "Обновление статуса замка устройством",
+                "summary": "Получение района по ID",
                 "parameters": [
                     {
-                        "description": "Данные статуса замка",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.LockStatusUpdateRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID района",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "$ref": "#/definitions/domain.District"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/devices/heartbeat": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Обновляет время последней активности устройства (heartbeat)",
+        "/locations/districts/{id}/microdistricts": {
+            "get": {
+                "description": "Получает список микрорайонов для указанного района",
                 "consumes": [
                     "application/json"
                 ],
@@ -9674,56 +14034,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "locations"
                 ],
-                "summary": "Обновление активности устройства",
+                "summary": "Получение микрорайонов района",
                 "parameters": [
                     {
-                        "description": "Токен устройства",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.DeviceHeartbeatRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID района",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Microdistrict"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/devices/register": {
-            "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Регистрирует устройство пользователя для получения push уведомлений",
+        "/locations/microdistricts": {
+            "get": {
+                "description": "Получает список всех микрорайонов",
                 "consumes": [
                     "application/json"
                 ],
@@ -9731,56 +14087,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
-                ],
-                "summary": "Регистрация устройства",
-                "parameters": [
-                    {
-                        "description": "Данные устройства",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.RegisterDeviceRequest"
-                        }
-                    }
+                    "locations"
                 ],
+                "summary": "Получение всех микрорайонов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Microdistrict"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/devices/{token}": {
-            "delete": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Деактивирует устройство в системе уведомлений",
+        "/locations/microdistricts/{id}": {
+            "get": {
+                "description": "Получает информацию о микрорайоне по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -9788,14 +14122,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "devices"
+                    "locations"
                 ],
-                "summary": "Удаление устройства",
+                "summary": "Получение микрорайона по ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Токен устройства",
-                        "name": "token",
+                        "type": "integer",
+                        "description": "ID микрорайона",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -9804,33 +14138,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "$ref": "#/definitions/domain.Microdistrict"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/dictionaries/amenities": {
+        "/locations/regions": {
             "get": {
-                "description": "Получает список всех популярных удобств",
+                "description": "Получает список всех регионов",
                 "consumes": [
                     "application/json"
                 ],
@@ -9838,28 +14172,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dictionaries"
+                    "locations"
                 ],
-                "summary": "Получение популярных удобств",
+                "summary": "Получение всех регионов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.Region"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/dictionaries/conditions": {
+        "/locations/regions/{id}": {
             "get": {
-                "description": "Получает список всех доступных состояний квартир",
+                "description": "Получает информацию о регионе по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -9867,28 +14207,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dictionaries"
+                    "locations"
+                ],
+                "summary": "Получение региона по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID региона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение состояний квартир",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "$ref": "#/definitions/domain.Region"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/dictionaries/house-rules": {
+        "/locations/regions/{id}/cities": {
             "get": {
-                "description": "Получает список всех доступных правил дома",
+                "description": "Получает список городов для указанного региона",
                 "consumes": [
                     "application/json"
                 ],
@@ -9896,33 +14257,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "dictionaries"
+                    "locations"
+                ],
+                "summary": "Получение городов региона",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID региона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение правил дома",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.City"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/favorites": {
+        "/locks": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список избранных квартир пользователя с пагинацией",
+                "description": "Получает список всех умных замков (только для админов и модераторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -9930,71 +14315,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
-                ],
-                "summary": "Получение избранных квартир",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "locks"
                 ],
+                "summary": "Получение всех замков",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/favorites/{apartment_id}": {
+            },
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Добавляет квартиру в список избранных пользователя",
+                "description": "Создает новый умный замок для квартиры. В ответе один раз возвращается device_secret для подписи запросов устройства",
                 "consumes": [
                     "application/json"
                 ],
@@ -10002,73 +14359,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "locks"
                 ],
-                "summary": "Добавление в избранное",
+                "summary": "Создание замка",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "apartment_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные замка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateLockRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/locks/apartment/{apartmentId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет указанную квартиру из избранного пользователя",
+                "description": "Получает информацию о замке для указанной квартиры",
                 "consumes": [
                     "application/json"
                 ],
@@ -10076,14 +14422,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "locks"
                 ],
-                "summary": "Удаление из избранного",
+                "summary": "Получение замка квартиры",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "ID квартиры",
-                        "name": "apartment_id",
+                        "name": "apartmentId",
                         "in": "path",
                         "required": true
                     }
@@ -10107,59 +14453,35 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/locations/cities": {
-            "get": {
-                "description": "Получает список всех городов",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "locations"
-                ],
-                "summary": "Получение всех городов",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.City"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/cities/{id}": {
-            "get": {
-                "description": "Получает информацию о городе по его идентификатору",
+        "/locks/password/booking/{bookingId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Деактивирует временный пароль для указанного бронирования",
                 "consumes": [
                     "application/json"
                 ],
@@ -10167,14 +14489,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "locks"
                 ],
-                "summary": "Получение города по ID",
+                "summary": "Деактивация пароля для бронирования",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID города",
-                        "name": "id",
+                        "description": "ID бронирования",
+                        "name": "bookingId",
                         "in": "path",
                         "required": true
                     }
@@ -10183,33 +14505,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.City"
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/cities/{id}/districts": {
+        "/locks/password/{uniqueId}/owner": {
             "get": {
-                "description": "Получает список районов для указанного города",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает постоянный пароль владельца замка. В остальных ответах пароль маскируется, каждое раскрытие записывается в журнал",
                 "consumes": [
                     "application/json"
                 ],
@@ -10217,14 +14550,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "locks"
                 ],
-                "summary": "Получение районов города",
+                "summary": "Получение пароля владельца",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID города",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -10233,71 +14566,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.District"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/locations/districts": {
-            "get": {
-                "description": "Получает список всех районов",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "locations"
-                ],
-                "summary": "Получение всех районов",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.District"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/districts/{id}": {
+        "/locks/status/{uniqueId}": {
             "get": {
-                "description": "Получает информацию о районе по его идентификатору",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает текущий статус умного замка",
                 "consumes": [
                     "application/json"
                 ],
@@ -10305,14 +14611,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "locks"
                 ],
-                "summary": "Получение района по ID",
+                "summary": "Получение статуса замка",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID района",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -10321,33 +14627,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.District"
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/districts/{id}/microdistricts": {
+        "/locks/unique/{uniqueId}": {
             "get": {
-                "description": "Получает список микрорайонов для указанного района",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает информацию о замке по его уникальному идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -10355,14 +14672,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "locks"
                 ],
-                "summary": "Получение микрорайонов района",
+                "summary": "Получение замка по уникальному ID",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID района",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -10371,36 +14688,44 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Microdistrict"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/microdistricts": {
-            "get": {
-                "description": "Получает список всех микрорайонов",
+        "/locks/unique/{uniqueId}/auto-update/disable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Отключает автоматическое обновление статуса и батареи для замка",
                 "consumes": [
                     "application/json"
                 ],
@@ -10408,34 +14733,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "lock-auto-update"
+                ],
+                "summary": "Отключить автообновление для замка",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение всех микрорайонов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Microdistrict"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/microdistricts/{id}": {
-            "get": {
-                "description": "Получает информацию о микрорайоне по его идентификатору",
+        "/locks/unique/{uniqueId}/auto-update/enable": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Включает автоматическое обновление статуса и батареи для замка",
                 "consumes": [
                     "application/json"
                 ],
@@ -10443,14 +14776,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "lock-auto-update"
                 ],
-                "summary": "Получение микрорайона по ID",
+                "summary": "Включить автообновление для замка",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID микрорайона",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -10459,33 +14792,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Microdistrict"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/regions": {
+        "/locks/unique/{uniqueId}/auto-update/status": {
             "get": {
-                "description": "Получает список всех регионов",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Получает информацию о состоянии автоматического обновления для замка",
                 "consumes": [
                     "application/json"
                 ],
@@ -10493,34 +14819,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "lock-auto-update"
+                ],
+                "summary": "Получить статус автообновления замка",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получение всех регионов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.Region"
-                            }
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/regions/{id}": {
-            "get": {
-                "description": "Получает информацию о регионе по его идентификатору",
+        "/locks/unique/{uniqueId}/configure-webhooks": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Настраивает Tuya webhooks для автоматического получения событий",
                 "consumes": [
                     "application/json"
                 ],
@@ -10528,14 +14863,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "lock-auto-update"
                 ],
-                "summary": "Получение региона по ID",
+                "summary": "Настроить webhooks для замка",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID региона",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -10544,33 +14879,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.Region"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locations/regions/{id}/cities": {
-            "get": {
-                "description": "Получает список городов для указанного региона",
+        "/locks/unique/{uniqueId}/sync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Выполняет ручную синхронизацию данных замка с Tuya API",
                 "consumes": [
                     "application/json"
                 ],
@@ -10578,14 +14906,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locations"
+                    "lock-auto-update"
                 ],
-                "summary": "Получение городов региона",
+                "summary": "Синхронизировать замок с Tuya",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID региона",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Уникальный ID замка",
+                        "name": "uniqueId",
                         "in": "path",
                         "required": true
                     }
@@ -10594,41 +14922,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/domain.City"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/locks": {
+        "/locks/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список всех умных замков (только для админов и модераторов)",
+                "description": "Получает информацию о замке по его идентификатору",
                 "consumes": [
                     "application/json"
                 ],
@@ -10638,7 +14951,16 @@ const docTemplate = `{
                 "tags": [
                     "locks"
                 ],
-                "summary": "Получение всех замков",
+                "summary": "Получение замка по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10658,6 +14980,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -10666,13 +14994,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Создает новый умный замок для квартиры",
+                "description": "Обновляет информацию об умном замке (только для админов и модераторов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -10682,21 +15010,28 @@ const docTemplate = `{
                 "tags": [
                     "locks"
                 ],
-                "summary": "Создание замка",
+                "summary": "Обновление замка",
                 "parameters": [
                     {
-                        "description": "Данные замка",
+                        "type": "integer",
+                        "description": "ID замка",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.CreateLockRequest"
+                            "$ref": "#/definitions/domain.UpdateLockRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
@@ -10719,6 +15054,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -10726,16 +15067,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/locks/apartment/{apartmentId}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о замке для указанной квартиры",
+                "description": "Удаляет умный замок (только для админов)",
                 "consumes": [
                     "application/json"
                 ],
@@ -10745,12 +15084,12 @@ const docTemplate = `{
                 "tags": [
                     "locks"
                 ],
-                "summary": "Получение замка квартиры",
+                "summary": "Удаление замка",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID квартиры",
-                        "name": "apartmentId",
+                        "description": "ID замка",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -10780,12 +15119,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -10795,14 +15128,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/password/booking/{bookingId}": {
-            "delete": {
+        "/metrics": {
+            "get": {
+                "description": "Возвращает текущие метрики производительности сервера: количество запросов, среднее время ответа, использование памяти, количество горутин, счетчики webhook Tuya (в том числе отклоненных при проверке) и другие показатели",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "monitoring"
+                ],
+                "summary": "Получение метрик производительности",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.MetricsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Деактивирует временный пароль для указанного бронирования",
+                "description": "Получает список уведомлений текущего пользователя с пагинацией",
                 "consumes": [
                     "application/json"
                 ],
@@ -10810,16 +15166,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
+                    "notifications"
                 ],
-                "summary": "Деактивация пароля для бронирования",
+                "summary": "Получение уведомлений с пагинацией",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID бронирования",
-                        "name": "bookingId",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -10829,24 +15192,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -10856,14 +15207,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/password/{uniqueId}/owner": {
-            "get": {
+        "/notifications/all": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает постоянный пароль владельца замка",
+                "description": "Удаляет все уведомления пользователя (прочитанные и непрочитанные)",
                 "consumes": [
                     "application/json"
                 ],
@@ -10871,18 +15222,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
-                ],
-                "summary": "Получение пароля владельца",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Удалить все уведомления",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10890,26 +15232,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -10917,14 +15247,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/status/{uniqueId}": {
+        "/notifications/count": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает текущий статус умного замка",
+                "description": "Получает количество непрочитанных уведомлений текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -10932,18 +15262,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
-                ],
-                "summary": "Получение статуса замка",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Получение количества непрочитанных уведомлений",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -10957,18 +15278,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -10978,38 +15287,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/unique/{uniqueId}": {
+        "/notifications/preferences": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о замке по его уникальному идентификатору",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает отключенные типы уведомлений, тихие часы и каналы доставки текущего пользователя",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
-                ],
-                "summary": "Получение замка по уникальному ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Получение настроек уведомлений",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NotificationPreferences"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
@@ -11018,18 +15327,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -11037,16 +15334,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/locks/unique/{uniqueId}/auto-update/disable": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Отключает автоматическое обновление статуса и батареи для замка",
+                "description": "Частично обновляет настройки уведомлений. В тихие часы несрочные push откладываются до их окончания, срочные уведомления (проблемы с замком) доставляются всегда",
                 "consumes": [
                     "application/json"
                 ],
@@ -11054,27 +15349,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lock-auto-update"
+                    "notifications"
                 ],
-                "summary": "Отключить автообновление для замка",
+                "summary": "Обновление настроек уведомлений",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
+                        "description": "Изменяемые настройки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.UpdateNotificationPreferencesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.NotificationPreferences"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11082,14 +15397,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/unique/{uniqueId}/auto-update/enable": {
-            "post": {
+        "/notifications/read": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Включает автоматическое обновление статуса и батареи для замка",
+                "description": "Удаляет все прочитанные уведомления пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -11097,18 +15412,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lock-auto-update"
-                ],
-                "summary": "Включить автообновление для замка",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Удалить прочитанные уведомления",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -11116,8 +15422,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11125,14 +15437,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/unique/{uniqueId}/auto-update/status": {
-            "get": {
+        "/notifications/read-all": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о состоянии автоматического обновления для замка",
+                "description": "Помечает все уведомления пользователя как прочитанные",
                 "consumes": [
                     "application/json"
                 ],
@@ -11140,28 +15452,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lock-auto-update"
-                ],
-                "summary": "Получить статус автообновления замка",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Пометить все уведомления как прочитанные",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11169,14 +15477,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/unique/{uniqueId}/configure-webhooks": {
+        "/notifications/read-multiple": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Настраивает Tuya webhooks для автоматического получения событий",
+                "description": "Помечает указанные уведомления как прочитанные",
                 "consumes": [
                     "application/json"
                 ],
@@ -11184,27 +15492,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lock-auto-update"
+                    "notifications"
                 ],
-                "summary": "Настроить webhooks для замка",
+                "summary": "Пометить несколько уведомлений как прочитанные",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
+                        "description": "Список ID уведомлений",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.MarkMultipleAsReadRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11212,14 +15534,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/unique/{uniqueId}/sync": {
-            "post": {
+        "/notifications/unread": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Выполняет ручную синхронизацию данных замка с Tuya API",
+                "description": "Получает список непрочитанных уведомлений текущего пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -11227,18 +15549,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "lock-auto-update"
-                ],
-                "summary": "Синхронизировать замок с Tuya",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Уникальный ID замка",
-                        "name": "uniqueId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notifications"
                 ],
+                "summary": "Получение непрочитанных уведомлений",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -11246,8 +15559,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11255,14 +15574,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/locks/{id}": {
-            "get": {
+        "/notifications/{id}": {
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает информацию о замке по его идентификатору",
+                "description": "Удаляет указанное уведомление пользователя",
                 "consumes": [
                     "application/json"
                 ],
@@ -11270,13 +15589,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
+                    "notifications"
                 ],
-                "summary": "Получение замка по ID",
+                "summary": "Удалить уведомление",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID замка",
+                        "description": "ID уведомления",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -11289,6 +15608,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -11314,14 +15639,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет информацию об умном замке (только для админов и модераторов)",
+                "description": "Помечает указанное уведомление как прочитанное",
                 "consumes": [
                     "application/json"
                 ],
@@ -11329,25 +15656,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
+                    "notifications"
                 ],
-                "summary": "Обновление замка",
+                "summary": "Пометить уведомление как прочитанное",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID замка",
+                        "description": "ID уведомления",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для обновления",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.UpdateLockRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -11388,14 +15706,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/owner/cleaning-logs": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет умный замок (только для админов)",
+                "description": "Журнал уборок квартир текущего владельца с фотографиями после уборки",
                 "consumes": [
                     "application/json"
                 ],
@@ -11403,23 +15723,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "locks"
+                    "apartments"
                 ],
-                "summary": "Удаление замка",
+                "summary": "История уборок квартир владельца",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID замка",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID квартиры",
+                        "name": "apartment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по ID уборщицы",
+                        "name": "cleaner_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "in_progress",
+                            "completed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Фильтр по статусу",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало уборки с даты (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало уборки по дату включительно (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/domain.CleaningLog"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -11449,9 +15826,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics": {
-            "get": {
-                "description": "Возвращает текущие метрики производительности сервера: количество запросов, среднее время ответа, использование памяти, количество горутин и другие показатели",
+        "/payments/check-status": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Проверяет статус платежа в системе FreedomPay по payment_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -11459,27 +15841,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "monitoring"
+                    "payments"
+                ],
+                "summary": "Проверка статуса платежа",
+                "parameters": [
+                    {
+                        "description": "Payment ID для проверки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PaymentStatusRequest"
+                        }
+                    }
                 ],
-                "summary": "Получение метрик производительности",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/services.MetricsResponse"
+                            "$ref": "#/definitions/domain.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/notifications": {
-            "get": {
+        "/payments/check-status-by-order": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список уведомлений текущего пользователя с пагинацией",
+                "description": "Проверяет статус платежа в системе FreedomPay по order_id",
                 "consumes": [
                     "application/json"
                 ],
@@ -11487,23 +15892,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "payments"
                 ],
-                "summary": "Получение уведомлений с пагинацией",
+                "summary": "Проверка статуса платежа по Order ID",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Размер страницы",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Order ID и Booking ID для проверки",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.PaymentOrderStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -11513,8 +15913,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11528,14 +15928,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/all": {
-            "delete": {
+        "/reviews/apartments": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет все уведомления пользователя (прочитанные и непрочитанные)",
+                "description": "Арендатор оценивает чистоту, соответствие описанию, расположение и заселение по завершенному бронированию. Отзыв публикуется после модерации",
                 "consumes": [
                     "application/json"
                 ],
@@ -11543,24 +15943,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
+                ],
+                "summary": "Отзыв о квартире",
+                "parameters": [
+                    {
+                        "description": "Отзыв",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.CreateApartmentReviewRequest"
+                        }
+                    }
                 ],
-                "summary": "Удалить все уведомления",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ApartmentReview"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11568,24 +15991,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/count": {
+        "/reviews/apartments/{apartmentId}": {
             "get": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Получает количество непрочитанных уведомлений текущего пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает опубликованные отзывы о квартире и ее агрегированный рейтинг",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
+                ],
+                "summary": "Отзывы о квартире",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID квартиры",
+                        "name": "apartmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Получение количества непрочитанных уведомлений",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -11593,14 +16031,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11608,14 +16046,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/read": {
-            "delete": {
+        "/reviews/apartments/{reviewId}/reply": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет все прочитанные уведомления пользователя",
+                "description": "Владелец публично отвечает на опубликованный отзыв о своей квартире. Повторный ответ заменяет предыдущий",
                 "consumes": [
                     "application/json"
                 ],
@@ -11623,24 +16061,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
+                ],
+                "summary": "Ответ владельца на отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ответ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ReviewReplyRequest"
+                        }
+                    }
                 ],
-                "summary": "Удалить прочитанные уведомления",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ApartmentReview"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11648,39 +16116,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/read-all": {
-            "post": {
+        "/reviews/bookings/{bookingId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает все уведомления пользователя как прочитанные",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Показывает участнику бронирования оставленные отзывы, срок и возможность оставить новый отзыв",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
+                ],
+                "summary": "Отзывы по бронированию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бронирования",
+                        "name": "bookingId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Пометить все уведомления как прочитанные",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.BookingReviewState"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11688,33 +16174,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/read-multiple": {
-            "post": {
+        "/reviews/moderation/apartments": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает указанные уведомления как прочитанные",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает отзывы о квартирах с указанным статусом, начиная с самых старых (только для администраторов и модераторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
                 ],
-                "summary": "Пометить несколько уведомлений как прочитанные",
+                "summary": "Очередь модерации отзывов о квартирах",
                 "parameters": [
                     {
-                        "description": "Список ID уведомлений",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/http.MarkMultipleAsReadRequest"
-                        }
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Статус отзыва (pending, published, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -11724,14 +16219,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11745,14 +16240,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/unread": {
-            "get": {
+        "/reviews/moderation/apartments/{reviewId}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Получает список непрочитанных уведомлений текущего пользователя",
+                "description": "Публикует или отклоняет отзыв о квартире. Рейтинг квартиры пересчитывается автоматически",
                 "consumes": [
                     "application/json"
                 ],
@@ -11760,14 +16255,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
+                ],
+                "summary": "Модерация отзыва о квартире",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID отзыва",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Решение модератора (published или rejected)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ModerateReviewRequest"
+                        }
+                    }
                 ],
-                "summary": "Получение непрочитанных уведомлений",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.ApartmentReview"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
                     "401": {
@@ -11776,8 +16307,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11785,31 +16316,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/{id}": {
-            "delete": {
+        "/reviews/moderation/renters": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Удаляет указанное уведомление пользователя",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает отзывы об арендаторах с указанным статусом, начиная с самых старых (только для администраторов и модераторов)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
                 ],
-                "summary": "Удалить уведомление",
+                "summary": "Очередь модерации отзывов об арендаторах",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Статус отзыва (pending, published, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID уведомления",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -11819,12 +16361,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.SuccessResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -11837,12 +16373,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -11852,14 +16382,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/{id}/read": {
-            "post": {
+        "/reviews/moderation/renters/{reviewId}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Помечает указанное уведомление как прочитанное",
+                "description": "Публикует или отклоняет отзыв владельца об арендаторе",
                 "consumes": [
                     "application/json"
                 ],
@@ -11867,23 +16397,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifications"
+                    "reviews"
                 ],
-                "summary": "Пометить уведомление как прочитанное",
+                "summary": "Модерация отзыва об арендаторе",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID уведомления",
-                        "name": "id",
+                        "description": "ID отзыва",
+                        "name": "reviewId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Решение модератора (published или rejected)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/domain.ModerateReviewRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RenterReview"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -11903,30 +16454,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/domain.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/payments/check-status": {
+        "/reviews/renters": {
             "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Проверяет статус платежа в системе FreedomPay по payment_id",
+                "description": "Владелец оценивает арендатора по завершенному бронированию. Отзыв публикуется после модерации",
                 "consumes": [
                     "application/json"
                 ],
@@ -11934,25 +16473,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "reviews"
                 ],
-                "summary": "Проверка статуса платежа",
+                "summary": "Отзыв об арендаторе",
                 "parameters": [
                     {
-                        "description": "Payment ID для проверки",
+                        "description": "Отзыв",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.PaymentStatusRequest"
+                            "$ref": "#/definitions/domain.CreateRenterReviewRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.RenterReview"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -11961,8 +16512,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
@@ -11970,33 +16521,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/payments/check-status-by-order": {
-            "post": {
+        "/reviews/renters/{renterId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Проверяет статус платежа в системе FreedomPay по order_id",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Возвращает опубликованные отзывы владельцев об арендаторе и его рейтинг",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "reviews"
                 ],
-                "summary": "Проверка статуса платежа по Order ID",
+                "summary": "Отзывы об арендаторе",
                 "parameters": [
                     {
-                        "description": "Order ID и Booking ID для проверки",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/domain.PaymentOrderStatusRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID арендатора",
+                        "name": "renterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -12012,6 +16572,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -12601,7 +17173,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Обновляет данные профиля пользователя",
+                "description": "Обновляет данные профиля пользователя, в том числе язык уведомлений (preferred_language: ru, kk, en)",
                 "consumes": [
                     "application/json"
                 ],
@@ -12845,9 +17417,75 @@ const docTemplate = `{
                 }
             }
         },
+        "/webhooks/freedompay": {
+            "post": {
+                "description": "Принимает check и result запросы FreedomPay, проверяет подпись pg_sig и подтверждает оплату бронирования или продления. Ответ в формате XML FreedomPay",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "payment-webhooks"
+                ],
+                "summary": "Callback FreedomPay (check/result)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа (booking_\u003cid\u003e_\u003cпопытка\u003e или extension_\u003cid\u003e_\u003cпопытка\u003e)",
+                        "name": "pg_order_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID платежа FreedomPay",
+                        "name": "pg_payment_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сумма платежа",
+                        "name": "pg_amount",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Результат платежа (1 - успех, 0 - ошибка), только для result",
+                        "name": "pg_result",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Случайная строка",
+                        "name": "pg_salt",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Подпись запроса",
+                        "name": "pg_sig",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.FreedomPayCallbackResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/webhooks/tuya": {
             "post": {
-                "description": "Обрабатывает webhook события от Tuya API (онлайн/оффлайн статус, heartbeat, данные батареи)",
+                "description": "Обрабатывает webhook события от Tuya API (онлайн/оффлайн статус, heartbeat, данные батареи). Сообщение должно быть подписано и зашифровано ключом ClientSecret и отправлено не раньше чем 12 часов назад. Повторная доставка того же события подтверждается без повторной обработки",
                 "consumes": [
                     "application/json"
                 ],
@@ -12860,12 +17498,12 @@ const docTemplate = `{
                 "summary": "Webhook для событий Tuya",
                 "parameters": [
                     {
-                        "description": "Событие от Tuya",
-                        "name": "event",
+                        "description": "Сообщение от Tuya",
+                        "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/domain.TuyaWebhookEvent"
+                            "$ref": "#/definitions/domain.TuyaWebhookMessage"
                         }
                     }
                 ],
@@ -12873,7 +17511,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/domain.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/domain.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/domain.TuyaWebhookResult"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -12881,12 +17531,32 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/domain.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ErrorResponse"
+                        }
                     }
                 }
             }
         }
     },
     "definitions": {
+        "domain.AdminCancelCleaningRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.AdminGeneratePasswordRequest": {
             "type": "object",
             "required": [
@@ -12952,6 +17622,12 @@ const docTemplate = `{
                 "building": {
                     "type": "string"
                 },
+                "check_in_time": {
+                    "type": "string"
+                },
+                "check_out_time": {
+                    "type": "string"
+                },
                 "city": {
                     "$ref": "#/definitions/domain.City"
                 },
@@ -13018,12 +17694,18 @@ const docTemplate = `{
                 "location": {
                     "$ref": "#/definitions/domain.ApartmentLocation"
                 },
+                "max_nights": {
+                    "type": "integer"
+                },
                 "microdistrict": {
                     "$ref": "#/definitions/domain.Microdistrict"
                 },
                 "microdistrict_id": {
                     "type": "integer"
                 },
+                "min_nights": {
+                    "type": "integer"
+                },
                 "moderator_comment": {
                     "type": "string"
                 },
@@ -13042,6 +17724,21 @@ const docTemplate = `{
                 "price": {
                     "type": "integer"
                 },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_accuracy": {
+                    "type": "number"
+                },
+                "rating_check_in": {
+                    "type": "number"
+                },
+                "rating_cleanliness": {
+                    "type": "number"
+                },
+                "rating_location": {
+                    "type": "number"
+                },
                 "rental_type_daily": {
                     "type": "boolean"
                 },
@@ -13051,32 +17748,97 @@ const docTemplate = `{
                 "residential_complex": {
                     "type": "string"
                 },
-                "room_count": {
-                    "type": "integer"
+                "reviews_count": {
+                    "type": "integer"
+                },
+                "room_count": {
+                    "type": "integer"
+                },
+                "service_fee_percentage": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ApartmentStatus"
+                },
+                "street": {
+                    "type": "string"
+                },
+                "total_area": {
+                    "type": "number"
+                },
+                "total_floors": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "view_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.ApartmentCalendar": {
+            "type": "object",
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.ApartmentCalendarEntry"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ApartmentCalendarEntry": {
+            "type": "object",
+            "properties": {
+                "booking_number": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
                 },
-                "service_fee_percentage": {
+                "id": {
                     "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.ApartmentStatus"
+                "reason": {
+                    "$ref": "#/definitions/domain.AvailabilityBlockReason"
                 },
-                "street": {
+                "start_date": {
                     "type": "string"
                 },
-                "total_area": {
-                    "type": "number"
-                },
-                "total_floors": {
-                    "type": "integer"
+                "status": {
+                    "$ref": "#/definitions/domain.BookingStatus"
                 },
-                "updated_at": {
+                "title": {
                     "type": "string"
                 },
-                "view_count": {
-                    "type": "integer"
+                "type": {
+                    "$ref": "#/definitions/domain.ApartmentCalendarEntryType"
                 }
             }
         },
+        "domain.ApartmentCalendarEntryType": {
+            "type": "string",
+            "enum": [
+                "booking",
+                "block",
+                "external"
+            ],
+            "x-enum-varnames": [
+                "CalendarEntryBooking",
+                "CalendarEntryBlock",
+                "CalendarEntryExternal"
+            ]
+        },
         "domain.ApartmentCondition": {
             "type": "object",
             "properties": {
@@ -13223,6 +17985,68 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ApartmentReview": {
+            "type": "object",
+            "properties": {
+                "accuracy_rating": {
+                    "type": "integer"
+                },
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "author_name": {
+                    "type": "string"
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "check_in_rating": {
+                    "type": "integer"
+                },
+                "cleanliness_rating": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "location_rating": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderator_comment": {
+                    "type": "string"
+                },
+                "moderator_id": {
+                    "type": "integer"
+                },
+                "owner_replied_at": {
+                    "type": "string"
+                },
+                "owner_reply": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "renter_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ReviewStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.ApartmentStatus": {
             "type": "string",
             "enum": [
@@ -13273,6 +18097,91 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.AssignCleaningTaskRequest": {
+            "type": "object",
+            "required": [
+                "cleaner_id"
+            ],
+            "properties": {
+                "cleaner_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.AvailabilityBlock": {
+            "type": "object",
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "$ref": "#/definitions/domain.AvailabilityBlockReason"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.AvailabilityBlockReason": {
+            "type": "string",
+            "enum": [
+                "repair",
+                "cleaning",
+                "personal"
+            ],
+            "x-enum-varnames": [
+                "AvailabilityBlockReasonRepair",
+                "AvailabilityBlockReasonCleaning",
+                "AvailabilityBlockReasonPersonal"
+            ]
+        },
+        "domain.AvailabilityBlockRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "reason",
+                "start_date"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-11-22T18:00:00"
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AvailabilityBlockReason"
+                        }
+                    ],
+                    "example": "repair"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-11-20T10:00:00"
+                }
+            }
+        },
         "domain.Booking": {
             "type": "object",
             "properties": {
@@ -13327,69 +18236,240 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "is_contract_accepted": {
-                    "type": "boolean"
-                },
-                "last_door_action": {
+                "is_contract_accepted": {
+                    "type": "boolean"
+                },
+                "last_door_action": {
+                    "type": "string"
+                },
+                "nights": {
+                    "type": "integer"
+                },
+                "owner_comment": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "price_breakdown": {
+                    "$ref": "#/definitions/domain.PriceBreakdown"
+                },
+                "renter": {
+                    "$ref": "#/definitions/domain.Renter"
+                },
+                "renter_id": {
+                    "type": "integer"
+                },
+                "service_fee": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.BookingStatus"
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BookingReviewState": {
+            "type": "object",
+            "properties": {
+                "apartment_review": {
+                    "$ref": "#/definitions/domain.ApartmentReview"
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "can_review_apartment": {
+                    "type": "boolean"
+                },
+                "can_review_renter": {
+                    "type": "boolean"
+                },
+                "renter_review": {
+                    "$ref": "#/definitions/domain.RenterReview"
+                },
+                "review_deadline": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.BookingStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "awaiting_payment",
+                "pending",
+                "approved",
+                "rejected",
+                "active",
+                "completed",
+                "canceled"
+            ],
+            "x-enum-varnames": [
+                "BookingStatusCreated",
+                "BookingStatusAwaitingPayment",
+                "BookingStatusPending",
+                "BookingStatusApproved",
+                "BookingStatusRejected",
+                "BookingStatusActive",
+                "BookingStatusCompleted",
+                "BookingStatusCanceled"
+            ]
+        },
+        "domain.CalendarExportInfo": {
+            "type": "object",
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CalendarSyncResult": {
+            "type": "object",
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "calendar_id": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "events_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ExternalCalendarSyncStatus"
+                },
+                "synced_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CancelBookingRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CancelCleaningRequest": {
+            "type": "object",
+            "required": [
+                "apartment_id"
+            ],
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
+                }
+            }
+        },
+        "domain.CancellationPolicy": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/domain.CancellationPolicyCode"
                 },
-                "owner_comment": {
+                "created_at": {
                     "type": "string"
                 },
-                "payment_id": {
-                    "type": "integer"
-                },
-                "renter": {
-                    "$ref": "#/definitions/domain.Renter"
+                "description": {
+                    "type": "string"
                 },
-                "renter_id": {
+                "id": {
                     "type": "integer"
                 },
-                "service_fee": {
-                    "type": "integer"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "start_date": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/domain.BookingStatus"
+                "service_fee_refundable": {
+                    "type": "boolean"
                 },
-                "total_price": {
-                    "type": "integer"
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.CancellationPolicyTier"
+                    }
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "domain.BookingStatus": {
+        "domain.CancellationPolicyCode": {
             "type": "string",
             "enum": [
-                "created",
-                "awaiting_payment",
-                "pending",
-                "approved",
-                "rejected",
-                "active",
-                "completed",
-                "canceled"
+                "flexible",
+                "moderate",
+                "strict"
             ],
             "x-enum-varnames": [
-                "BookingStatusCreated",
-                "BookingStatusAwaitingPayment",
-                "BookingStatusPending",
-                "BookingStatusApproved",
-                "BookingStatusRejected",
-                "BookingStatusActive",
-                "BookingStatusCompleted",
-                "BookingStatusCanceled"
+                "CancellationPolicyFlexible",
+                "CancellationPolicyModerate",
+                "CancellationPolicyStrict"
             ]
         },
-        "domain.CancelBookingRequest": {
+        "domain.CancellationPolicyTier": {
             "type": "object",
             "properties": {
-                "reason": {
+                "min_hours_before_start": {
+                    "type": "integer"
+                },
+                "refund_percentage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.CancellationQuote": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "currency": {
                     "type": "string"
+                },
+                "hours_until_start": {
+                    "type": "number"
+                },
+                "paid_amount": {
+                    "type": "integer"
+                },
+                "penalty_amount": {
+                    "type": "integer"
+                },
+                "policy": {
+                    "$ref": "#/definitions/domain.CancellationPolicy"
+                },
+                "refund_amount": {
+                    "type": "integer"
+                },
+                "refund_percentage": {
+                    "type": "integer"
+                },
+                "service_fee_refunded": {
+                    "type": "boolean"
                 }
             }
         },
@@ -13423,6 +18503,15 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "image_height": {
+                    "type": "integer"
+                },
+                "image_width": {
+                    "type": "integer"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
                 "read_at": {
                     "type": "string"
                 },
@@ -13441,6 +18530,9 @@ const docTemplate = `{
                 "status": {
                     "$ref": "#/definitions/domain.MessageStatus"
                 },
+                "thumbnail_url": {
+                    "type": "string"
+                },
                 "type": {
                     "$ref": "#/definitions/domain.MessageType"
                 },
@@ -13672,10 +18764,199 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CleaningAccessCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "482913"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CleaningLog": {
+            "type": "object",
+            "properties": {
+                "access_code": {
+                    "$ref": "#/definitions/domain.CleaningAccessCode"
+                },
+                "apartment": {
+                    "$ref": "#/definitions/domain.Apartment"
+                },
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "cancel_reason": {
+                    "type": "string"
+                },
+                "cancelled_at": {
+                    "type": "string"
+                },
+                "cleaner": {
+                    "$ref": "#/definitions/domain.Cleaner"
+                },
+                "cleaner_id": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "completion_notes": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "photos_urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_notes": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.CleaningLogStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CleaningLogStatus": {
+            "type": "string",
+            "enum": [
+                "in_progress",
+                "completed",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "CleaningLogStatusInProgress",
+                "CleaningLogStatusCompleted",
+                "CleaningLogStatusCancelled"
+            ]
+        },
+        "domain.CleaningTask": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string"
+                },
+                "apartment": {
+                    "$ref": "#/definitions/domain.Apartment"
+                },
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "checkout_at": {
+                    "type": "string"
+                },
+                "cleaner": {
+                    "$ref": "#/definitions/domain.Cleaner"
+                },
+                "cleaner_id": {
+                    "type": "integer"
+                },
+                "cleaning_duration": {
+                    "type": "integer"
+                },
+                "cleaning_log_id": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deadline_at": {
+                    "type": "string"
+                },
+                "declined_cleaner_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "escalated_at": {
+                    "type": "string"
+                },
+                "escalation_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_late": {
+                    "type": "boolean"
+                },
+                "next_booking_id": {
+                    "type": "integer"
+                },
+                "offer_attempt": {
+                    "type": "integer"
+                },
+                "offer_expires_at": {
+                    "type": "string"
+                },
+                "offered_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.CleaningTaskStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CleaningTaskStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "offered",
+                "accepted",
+                "in_progress",
+                "completed",
+                "escalated",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "CleaningTaskStatusPending",
+                "CleaningTaskStatusOffered",
+                "CleaningTaskStatusAccepted",
+                "CleaningTaskStatusInProgress",
+                "CleaningTaskStatusCompleted",
+                "CleaningTaskStatusEscalated",
+                "CleaningTaskStatusCancelled"
+            ]
+        },
         "domain.CompleteCleaningRequest": {
             "type": "object",
             "required": [
-                "apartment_id"
+                "apartment_id",
+                "photos_base64"
             ],
             "properties": {
                 "apartment_id": {
@@ -13686,6 +18967,7 @@ const docTemplate = `{
                 },
                 "photos_base64": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
@@ -13843,11 +19125,49 @@ const docTemplate = `{
                 "latitude": {
                     "type": "number"
                 },
-                "longitude": {
-                    "type": "number"
+                "longitude": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.CreateApartmentReviewRequest": {
+            "type": "object",
+            "required": [
+                "accuracy_rating",
+                "booking_id",
+                "check_in_rating",
+                "cleanliness_rating",
+                "location_rating"
+            ],
+            "properties": {
+                "accuracy_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "check_in_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "cleanliness_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 },
-                "updated_at": {
+                "comment": {
                     "type": "string"
+                },
+                "location_rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 }
             }
         },
@@ -13871,17 +19191,22 @@ const docTemplate = `{
         "domain.CreateBookingRequest": {
             "type": "object",
             "required": [
-                "apartment_id",
-                "duration",
-                "start_date"
+                "apartment_id"
             ],
             "properties": {
                 "apartment_id": {
                     "type": "integer"
                 },
+                "check_in_date": {
+                    "type": "string",
+                    "example": "2026-11-20"
+                },
+                "check_out_date": {
+                    "type": "string",
+                    "example": "2026-11-25"
+                },
                 "duration": {
-                    "type": "integer",
-                    "minimum": 1
+                    "type": "integer"
                 },
                 "start_date": {
                     "type": "string"
@@ -13943,6 +19268,22 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CreateExternalCalendarRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Airbnb"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.airbnb.com/calendar/ical/123.ics?s=abc"
+                }
+            }
+        },
         "domain.CreateLockRequest": {
             "type": "object",
             "required": [
@@ -13975,6 +19316,34 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CreateRenterReviewRequest": {
+            "type": "object",
+            "required": [
+                "booking_id",
+                "rating"
+            ],
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "domain.DeclineCleaningTaskRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.DeviceType": {
             "type": "string",
             "enum": [
@@ -13988,96 +19357,299 @@ const docTemplate = `{
                 "DeviceTypeWeb"
             ]
         },
-        "domain.District": {
+        "domain.District": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "$ref": "#/definitions/domain.City"
+                },
+                "city_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "microdistricts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Microdistrict"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.DocumentType": {
+            "type": "string",
+            "enum": [
+                "udv",
+                "passport"
+            ],
+            "x-enum-varnames": [
+                "DocTypeID",
+                "DocTypePassport"
+            ]
+        },
+        "domain.DoorStatus": {
+            "type": "string",
+            "enum": [
+                "closed",
+                "open"
+            ],
+            "x-enum-varnames": [
+                "DoorStatusClosed",
+                "DoorStatusOpen"
+            ]
+        },
+        "domain.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "booking_apartment_unavailable_period"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "domain.ExtendBookingRequest": {
+            "type": "object",
+            "required": [
+                "duration"
+            ],
+            "properties": {
+                "duration": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "domain.ExternalCalendar": {
+            "type": "object",
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_sync_error": {
+                    "type": "string"
+                },
+                "last_sync_status": {
+                    "$ref": "#/definitions/domain.ExternalCalendarSyncStatus"
+                },
+                "last_synced_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExternalCalendarEvent": {
+            "type": "object",
+            "properties": {
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "calendar_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ExternalCalendarSyncStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "success",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ExternalCalendarSyncPending",
+                "ExternalCalendarSyncSuccess",
+                "ExternalCalendarSyncFailed"
+            ]
+        },
+        "domain.FreedomPayCallbackResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "salt": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.HouseRules": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.InitPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "extension_id": {
+                    "type": "integer"
+                },
+                "fp_payment_id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "redirect_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.PaymentStatus"
+                }
+            }
+        },
+        "domain.LockCodeReveal": {
             "type": "object",
             "properties": {
-                "city": {
-                    "$ref": "#/definitions/domain.City"
-                },
-                "city_id": {
+                "booking_id": {
                     "type": "integer"
                 },
+                "code_type": {
+                    "$ref": "#/definitions/domain.LockCodeType"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "microdistricts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/domain.Microdistrict"
-                    }
+                "ip_address": {
+                    "type": "string"
                 },
-                "name": {
+                "lock_id": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "updated_at": {
+                "temp_password_id": {
+                    "type": "integer"
+                },
+                "user_agent": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "domain.DocumentType": {
-            "type": "string",
-            "enum": [
-                "udv",
-                "passport"
-            ],
-            "x-enum-varnames": [
-                "DocTypeID",
-                "DocTypePassport"
-            ]
-        },
-        "domain.DoorStatus": {
+        "domain.LockCodeType": {
             "type": "string",
             "enum": [
-                "closed",
-                "open"
+                "owner",
+                "temp"
             ],
             "x-enum-varnames": [
-                "DoorStatusClosed",
-                "DoorStatusOpen"
+                "LockCodeTypeOwner",
+                "LockCodeTypeTemp"
             ]
         },
-        "domain.ErrorResponse": {
+        "domain.LockDeviceSecretResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "device_secret": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "domain.ExtendBookingRequest": {
-            "type": "object",
-            "required": [
-                "duration"
-            ],
-            "properties": {
-                "duration": {
-                    "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "domain.HouseRules": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
+                "lock_id": {
+                    "type": "integer"
                 },
-                "description": {
+                "previous_expires_at": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
+                "rotated_at": {
                     "type": "string"
                 },
-                "updated_at": {
+                "unique_id": {
                     "type": "string"
                 }
             }
@@ -14225,6 +19797,139 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.ModerateReviewRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.ReviewStatus"
+                        }
+                    ],
+                    "example": "published"
+                }
+            }
+        },
+        "domain.NotificationChannel": {
+            "type": "string",
+            "enum": [
+                "push",
+                "in_app",
+                "sms",
+                "email"
+            ],
+            "x-enum-varnames": [
+                "NotificationChannelPush",
+                "NotificationChannelInApp",
+                "NotificationChannelSMS",
+                "NotificationChannelEmail"
+            ]
+        },
+        "domain.NotificationPreferences": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NotificationChannel"
+                    }
+                },
+                "muted_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NotificationType"
+                    }
+                },
+                "quiet_hours_enabled": {
+                    "type": "boolean"
+                },
+                "quiet_hours_end": {
+                    "type": "string",
+                    "example": "08:00"
+                },
+                "quiet_hours_start": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Almaty"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.NotificationType": {
+            "type": "string",
+            "enum": [
+                "booking_approved",
+                "booking_rejected",
+                "booking_canceled",
+                "booking_completed",
+                "password_ready",
+                "extension_request",
+                "extension_approved",
+                "extension_rejected",
+                "checkout_reminder",
+                "lock_issue",
+                "new_booking",
+                "session_finished",
+                "booking_starting_soon",
+                "booking_ending",
+                "payment_required",
+                "apartment_created",
+                "apartment_approved",
+                "apartment_rejected",
+                "apartment_updated",
+                "apartment_status_changed",
+                "review_request",
+                "review_published",
+                "review_rejected",
+                "cleaning_completed",
+                "cleaning_task_offered",
+                "cleaning_task_escalated",
+                "cleaning_late"
+            ],
+            "x-enum-varnames": [
+                "NotificationBookingApproved",
+                "NotificationBookingRejected",
+                "NotificationBookingCanceled",
+                "NotificationBookingCompleted",
+                "NotificationPasswordReady",
+                "NotificationExtensionRequest",
+                "NotificationExtensionApproved",
+                "NotificationExtensionRejected",
+                "NotificationCheckoutReminder",
+                "NotificationLockIssue",
+                "NotificationNewBooking",
+                "NotificationSessionFinished",
+                "NotificationBookingStartingSoon",
+                "NotificationBookingEnding",
+                "NotificationPaymentRequired",
+                "NotificationApartmentCreated",
+                "NotificationApartmentApproved",
+                "NotificationApartmentRejected",
+                "NotificationApartmentUpdated",
+                "NotificationApartmentStatusChanged",
+                "NotificationReviewRequest",
+                "NotificationReviewPublished",
+                "NotificationReviewRejected",
+                "NotificationCleaningCompleted",
+                "NotificationCleaningTaskOffered",
+                "NotificationCleaningTaskEscalated",
+                "NotificationCleaningLate"
+            ]
+        },
         "domain.OTPAuthResponse": {
             "type": "object",
             "properties": {
@@ -14399,6 +20104,25 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "success",
+                "failed",
+                "expired",
+                "canceled"
+            ],
+            "x-enum-varnames": [
+                "PaymentStatusPending",
+                "PaymentStatusProcessing",
+                "PaymentStatusSuccess",
+                "PaymentStatusFailed",
+                "PaymentStatusExpired",
+                "PaymentStatusCanceled"
+            ]
+        },
         "domain.PaymentStatusRequest": {
             "type": "object",
             "required": [
@@ -14441,26 +20165,209 @@ const docTemplate = `{
                 "phone"
             ],
             "properties": {
-                "city_id": {
+                "city_id": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "iin": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PriceAdjustment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "dates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "integer"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/domain.PricingRuleType"
+                }
+            }
+        },
+        "domain.PriceBreakdown": {
+            "type": "object",
+            "properties": {
+                "adjustments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.PriceAdjustment"
+                    }
+                },
+                "adjustments_total": {
+                    "type": "integer"
+                },
+                "base_price": {
+                    "type": "integer"
+                },
+                "calculated_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "final_price": {
+                    "type": "integer"
+                },
+                "rental_type": {
+                    "type": "string"
+                },
+                "service_fee": {
+                    "type": "integer"
+                },
+                "service_fee_percentage": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                }
+            }
+        },
+        "domain.PricingRule": {
+            "type": "object",
+            "properties": {
+                "adjustment_percentage": {
+                    "type": "integer"
+                },
+                "apartment_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_price": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "hourly_price": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "min_days": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rule_type": {
+                    "$ref": "#/definitions/domain.PricingRuleType"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "domain.PricingRuleRequest": {
+            "type": "object",
+            "required": [
+                "rule_type"
+            ],
+            "properties": {
+                "adjustment_percentage": {
                     "type": "integer"
                 },
-                "email": {
-                    "type": "string"
+                "daily_price": {
+                    "type": "integer"
                 },
-                "first_name": {
-                    "type": "string"
+                "end_date": {
+                    "type": "string",
+                    "example": "2027-01-08"
                 },
-                "iin": {
-                    "type": "string"
+                "hourly_price": {
+                    "type": "integer"
                 },
-                "last_name": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "phone": {
+                "min_days": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
+                },
+                "rule_type": {
+                    "$ref": "#/definitions/domain.PricingRuleType"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-12-25"
+                },
+                "weekdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
+        "domain.PricingRuleType": {
+            "type": "string",
+            "enum": [
+                "date_range",
+                "weekday",
+                "holiday",
+                "length_of_stay",
+                "extension"
+            ],
+            "x-enum-varnames": [
+                "PricingRuleTypeDateRange",
+                "PricingRuleTypeWeekday",
+                "PricingRuleTypeHoliday",
+                "PricingRuleTypeLengthOfStay",
+                "PriceAdjustmentTypeExtension"
+            ]
+        },
         "domain.ProcessPaymentRequest": {
             "type": "object",
             "properties": {
@@ -14492,6 +20399,23 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.PublicHoliday": {
+            "type": "object",
+            "properties": {
+                "country_code": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.ReceiptAmounts": {
             "type": "object",
             "properties": {
@@ -14501,6 +20425,9 @@ const docTemplate = `{
                 "final_price": {
                     "type": "integer"
                 },
+                "price_breakdown": {
+                    "$ref": "#/definitions/domain.PriceBreakdown"
+                },
                 "service_fee": {
                     "type": "integer"
                 },
@@ -14521,6 +20448,9 @@ const docTemplate = `{
                 "end_date": {
                     "type": "string"
                 },
+                "nights": {
+                    "type": "integer"
+                },
                 "rental_type": {
                     "type": "string"
                 },
@@ -14641,6 +20571,83 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.RenterReview": {
+            "type": "object",
+            "properties": {
+                "author_name": {
+                    "type": "string"
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderator_comment": {
+                    "type": "string"
+                },
+                "moderator_id": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "renter_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/domain.ReviewStatus"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReviewReplyRequest": {
+            "type": "object",
+            "required": [
+                "reply"
+            ],
+            "properties": {
+                "reply": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.ReviewStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "published",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ReviewStatusPending",
+                "ReviewStatusPublished",
+                "ReviewStatusRejected"
+            ]
+        },
+        "domain.RotateLockDeviceSecretRequest": {
+            "type": "object",
+            "properties": {
+                "grace_period_minutes": {
+                    "description": "GracePeriodMinutes сколько минут принимать предыдущий секрет (0 - отозвать сразу)",
+                    "type": "integer"
+                }
+            }
+        },
         "domain.SendMessageRequest": {
             "type": "object",
             "required": [
@@ -14659,6 +20666,44 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "revoke_reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.StartCleaningRequest": {
             "type": "object",
             "required": [
@@ -14685,30 +20730,77 @@ const docTemplate = `{
                 }
             }
         },
-        "domain.TuyaWebhookEvent": {
+        "domain.TuyaWebhookMessage": {
             "type": "object",
             "properties": {
-                "bizCode": {
+                "data": {
                     "type": "string"
                 },
-                "bizData": {
-                    "type": "object",
-                    "additionalProperties": true
+                "encryptModel": {
+                    "type": "string"
+                },
+                "protocol": {
+                    "type": "integer"
                 },
-                "devId": {
+                "pv": {
                     "type": "string"
                 },
-                "productKey": {
+                "sign": {
                     "type": "string"
                 },
-                "ts": {
+                "t": {
                     "type": "integer"
+                }
+            }
+        },
+        "domain.TuyaWebhookResult": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "type": "boolean"
                 },
-                "uuid": {
+                "event_id": {
                     "type": "string"
                 }
             }
         },
+        "domain.UpdateApartmentCancellationPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "policy_code": {
+                    "$ref": "#/definitions/domain.CancellationPolicyCode"
+                }
+            }
+        },
+        "domain.UpdateApartmentStaySettingsRequest": {
+            "type": "object",
+            "required": [
+                "check_in_time",
+                "check_out_time",
+                "max_nights",
+                "min_nights"
+            ],
+            "properties": {
+                "check_in_time": {
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "check_out_time": {
+                    "type": "string",
+                    "example": "12:00"
+                },
+                "max_nights": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 30
+                },
+                "min_nights": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
         "domain.UpdateApartmentTypeIDRequest": {
             "type": "object",
             "required": [
@@ -14759,6 +20851,17 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.UpdateExternalCalendarRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
         "domain.UpdateLockRequest": {
             "type": "object",
             "properties": {
@@ -14784,6 +20887,38 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.UpdateNotificationPreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NotificationChannel"
+                    }
+                },
+                "muted_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.NotificationType"
+                    }
+                },
+                "quiet_hours_enabled": {
+                    "type": "boolean"
+                },
+                "quiet_hours_end": {
+                    "type": "string",
+                    "example": "08:00"
+                },
+                "quiet_hours_start": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Almaty"
+                }
+            }
+        },
         "domain.User": {
             "type": "object",
             "properties": {
@@ -14817,6 +20952,9 @@ const docTemplate = `{
                 "phone": {
                     "type": "string"
                 },
+                "preferred_language": {
+                    "type": "string"
+                },
                 "role": {
                     "$ref": "#/definitions/domain.UserRole"
                 },
@@ -15337,6 +21475,10 @@ const docTemplate = `{
                 },
                 "last_name": {
                     "type": "string"
+                },
+                "preferred_language": {
+                    "type": "string",
+                    "example": "kk"
                 }
             }
         },
@@ -15457,6 +21599,9 @@ const docTemplate = `{
                 "total_memory_mb": {
                     "type": "integer"
                 },
+                "tuya_webhooks": {
+                    "$ref": "#/definitions/services.TuyaWebhookMetricsData"
+                },
                 "uptime": {
                     "type": "string"
                 }
@@ -15475,6 +21620,70 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "services.PaymentReconciliationSummary": {
+            "type": "object",
+            "properties": {
+                "bookings_checked": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "payments_checked": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.TuyaWebhookMetricsData": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "verification_failures": {
+                    "type": "integer"
+                },
+                "verification_failures_by_reason": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -29,6 +29,9 @@ type App struct {
 }
 
 func (a *App) Cleanup() {
+	if a.wsService != nil {
+		a.wsService.Close()
+	}
 	if a.db != nil {
 		a.db.Close()
 	}
@@ -61,6 +64,11 @@ func InitApp(cfg *config.Config) (*App, error) {
 
 	tokenManager := initTokenManager(cfg.JWT)
 
+	lockCodes, err := initLockCodesKeyring(cfg.LockCodes, cfg.App)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize lock codes encryption: %w", err)
+	}
+
 	roleRepo := postgres.NewRoleRepository(db)
 	locationRepo := postgres.NewLocationRepository(db)
 	userRepo := postgres.NewUserRepository(db, roleRepo, locationRepo)
@@ -69,7 +77,7 @@ func InitApp(cfg *config.Config) (*App, error) {
 	apartmentRepo := postgres.NewApartmentRepository(db)
 	bookingRepo := postgres.NewBookingRepository(db)
 	favoriteRepo := postgres.NewFavoriteRepository(db)
-	lockRepo := postgres.NewLockRepository(db)
+	lockRepo := postgres.NewLockRepository(db, lockCodes)
 	notificationRepo := postgres.NewNotificationRepository(db)
 
 	conciergeRepo := postgres.NewConciergeRepository(db)
@@ -78,11 +86,17 @@ func InitApp(cfg *config.Config) (*App, error) {
 	chatParticipantRepo := postgres.NewChatParticipantRepository(db)
 	chatMessageRepo := postgres.NewChatMessageRepository(db)
 	cancellationRuleRepo := postgres.NewCancellationRuleRepository(db)
+	cancellationPolicyRepo := postgres.NewCancellationPolicyRepository(db)
+	pricingRuleRepo := postgres.NewPricingRuleRepository(db)
+	calendarRepo := postgres.NewCalendarRepository(db)
+	availabilityBlockRepo := postgres.NewAvailabilityBlockRepository(db)
+	reviewRepo := postgres.NewReviewRepository(db)
 	contractRepo := postgres.NewContractRepository(db)
 	settingsRepo := postgres.NewPlatformSettingsRepository(db)
 	paymentRepo := postgres.NewPaymentRepository(db)
 	paymentLogRepo := postgres.NewPaymentLogRepository(db)
 	apartmentTypeRepo := postgres.NewApartmentTypeRepository(db)
+	authRepo := postgres.NewAuthRepository(db)
 
 	tuyaConfig := services.TuyaConfig{
 		ClientID:     cfg.Tuya.ClientID,
@@ -118,8 +132,9 @@ func InitApp(cfg *config.Config) (*App, error) {
 	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, cfg.App.PasswordSalt)
 	propertyOwnerUseCase := usecase.NewPropertyOwnerUseCase(propertyOwnerRepo, userRepo, roleRepo, s3Storage, cfg.App.PasswordSalt)
 	renterUseCase := usecase.NewRenterUseCase(renterRepo, userRepo, roleRepo, s3Storage, cfg.App.PasswordSalt)
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenManager, cfg.App.PasswordSalt, userCacheService)
-	otpUseCase := usecase.NewOTPUseCase(otpService, otpRepo, userRepo, tokenManager)
+	authUseCase := usecase.NewAuthUseCase(userRepo, authRepo, tokenManager, cfg.App.PasswordSalt, userCacheService)
+	userUseCase.SetAuthUseCase(authUseCase)
+	otpUseCase := usecase.NewOTPUseCase(otpService, otpRepo, userRepo, authUseCase)
 	locationUseCase := usecase.NewLocationUseCase(locationRepo)
 
 	lockAutoUpdateService := services.NewLockAutoUpdateService(
@@ -131,20 +146,15 @@ func InitApp(cfg *config.Config) (*App, error) {
 
 	lockUseCase := usecase.NewLockUseCase(lockRepo, apartmentRepo, bookingRepo, propertyOwnerRepo, renterRepo, userUseCase, tuyaService, lockAutoUpdateService)
 
-	freedomPayService := services.NewFreedomPayService(
-		cfg.FreedomPay.MerchantID,
-		cfg.FreedomPay.SecretKey,
-		cfg.FreedomPay.APIBase,
-	)
+	freedomPayService := services.NewFreedomPayService(&cfg.FreedomPay)
 
 	lockAutoUpdateService.SetLockUseCase(lockUseCase)
 	favoriteUseCase := usecase.NewFavoriteUseCase(favoriteRepo, apartmentRepo, userRepo, propertyOwnerRepo)
-	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo, pushService, queueService)
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo, pushService, queueService, userRepo, initNotificationSenders(cfg))
 	lockUseCase.SetNotificationUseCase(notificationUseCase)
+	rotateLockCodes(lockUseCase)
 
 	conciergeUseCase := usecase.NewConciergeUseCase(conciergeRepo, userRepo, apartmentRepo, roleRepo, bookingRepo, chatRoomRepo)
-	cleanerUseCase := usecase.NewCleanerUseCase(cleanerRepo, userUseCase, apartmentRepo, nil)
-	cancellationRuleUseCase := usecase.NewCancellationRuleUseCase(cancellationRuleRepo)
 
 	contractTemplateRepo := postgres.NewContractTemplateRepository(db)
 
@@ -161,6 +171,8 @@ func InitApp(cfg *config.Config) (*App, error) {
 		PoolTimeout:  cfg.Redis.PoolTimeout,
 	})
 
+	lockUseCase.SetNonceStore(services.NewRedisNonceStore(redisConn))
+
 	contractService := services.NewContractService(services.ContractServiceConfig{
 		ContractRepo:         contractRepo,
 		ContractTemplateRepo: contractTemplateRepo,
@@ -175,16 +187,22 @@ func InitApp(cfg *config.Config) (*App, error) {
 
 	contractUseCase := usecase.NewContractUseCase(contractRepo, contractService, bookingRepo, apartmentRepo, userRepo, renterRepo, propertyOwnerRepo)
 	settingsUseCase := usecase.NewPlatformSettingsUseCase(settingsRepo)
+	cleanerUseCase := usecase.NewCleanerUseCase(cleanerRepo, userUseCase, apartmentRepo, propertyOwnerRepo, bookingRepo, lockUseCase, notificationUseCase, settingsUseCase, s3Storage, nil)
+	cancellationRuleUseCase := usecase.NewCancellationRuleUseCase(cancellationRuleRepo, cancellationPolicyRepo, settingsUseCase)
+	pricingUseCase := usecase.NewPricingUseCase(pricingRuleRepo, settingsUseCase)
+	checkPublicHolidays(pricingUseCase)
 
-	wsService := services.NewChatWebSocketService(nil, userUseCase)
+	wsService := services.NewChatWebSocketService(nil, userUseCase, redisConn)
 
-	chatUseCase := usecase.NewChatUseCase(chatRoomRepo, chatMessageRepo, chatParticipantRepo, bookingRepo, conciergeRepo, renterRepo, wsService, notificationRepo)
+	chatUseCase := usecase.NewChatUseCase(chatRoomRepo, chatMessageRepo, chatParticipantRepo, bookingRepo, conciergeRepo, renterRepo, wsService, notificationRepo, s3Storage)
 
 	wsService.SetChatUseCase(chatUseCase)
 
 	paymentUseCase := usecase.NewPaymentUseCase(freedomPayService, paymentRepo, paymentLogRepo)
 
 	availabilityService := services.NewApartmentAvailabilityService(db, apartmentRepo)
+	calendarUseCase := usecase.NewCalendarUseCase(calendarRepo, bookingRepo, availabilityBlockRepo, apartmentRepo, availabilityService, cfg.Calendar.FetchTimeout, cfg.Calendar.PublicBaseURL)
+	availabilityBlockUseCase := usecase.NewAvailabilityBlockUseCase(availabilityBlockRepo, bookingRepo, calendarRepo, availabilityService)
 
 	redisScheduler := services.NewSchedulerService(
 		cfg.Redis,
@@ -200,10 +218,20 @@ func InitApp(cfg *config.Config) (*App, error) {
 		chatUseCase,
 		chatRoomRepo,
 		paymentRepo,
+		paymentLogRepo,
 		paymentUseCase,
+		freedomPayService,
 	)
 
-	bookingUseCase := usecase.NewBookingUseCase(bookingRepo, apartmentRepo, renterRepo, propertyOwnerRepo, lockUseCase, userUseCase, notificationUseCase, redisScheduler, chatUseCase, chatRoomRepo, conciergeRepo, contractUseCase, settingsUseCase, paymentUseCase, paymentRepo, paymentLogRepo, availabilityService)
+	bookingUseCase := usecase.NewBookingUseCase(bookingRepo, apartmentRepo, renterRepo, propertyOwnerRepo, lockUseCase, userUseCase, notificationUseCase, redisScheduler, chatUseCase, chatRoomRepo, conciergeRepo, contractUseCase, settingsUseCase, paymentUseCase, paymentRepo, paymentLogRepo, availabilityService, cancellationRuleUseCase, pricingUseCase)
+
+	redisScheduler.SetBookingUseCase(bookingUseCase)
+	redisScheduler.SetCalendarUseCase(calendarUseCase)
+
+	reviewUseCase := usecase.NewReviewUseCase(reviewRepo, bookingRepo, apartmentRepo, renterRepo, propertyOwnerRepo, notificationUseCase, settingsUseCase)
+	redisScheduler.SetReviewUseCase(reviewUseCase)
+	redisScheduler.SetCleanerUseCase(cleanerUseCase)
+	cleanerUseCase.SetCleaningScheduler(redisScheduler)
 
 	apartmentTypeUseCase := usecase.NewApartmentTypeUseCase(apartmentTypeRepo, userUseCase)
 	apartmentUseCase := usecase.NewApartmentUseCase(apartmentRepo, userRepo, propertyOwnerRepo, bookingUseCase, bookingRepo, contractUseCase, s3Storage)
@@ -215,8 +243,9 @@ func InitApp(cfg *config.Config) (*App, error) {
 
 	services.StartPerformanceMonitoring()
 
-	middleware := httpDelivery.NewMiddleware(tokenManager, authUseCase, userCacheService)
-	authHandler := httpDelivery.NewAuthHandler(authUseCase, userUseCase, otpUseCase, tokenManager, renterRepo, propertyOwnerRepo, renterUseCase)
+	rateLimiter := services.NewRedisRateLimiter(redisConn, settingsUseCase)
+	middleware := httpDelivery.NewMiddleware(tokenManager, authUseCase, userCacheService, rateLimiter)
+	authHandler := httpDelivery.NewAuthHandler(authUseCase, userUseCase, otpUseCase, tokenManager, renterRepo, propertyOwnerRepo, renterUseCase, rateLimiter)
 	userHandler := httpDelivery.NewUserHandler(userUseCase, propertyOwnerUseCase, renterUseCase, renterRepo, apartmentUseCase, bookingUseCase, otpUseCase, responseCacheService)
 
 	apartmentHandler := httpDelivery.NewApartmentHandler(
@@ -233,6 +262,10 @@ func InitApp(cfg *config.Config) (*App, error) {
 		propertyOwnerRepo,
 		roleRepo,
 		responseCacheService,
+		cancellationRuleUseCase,
+		pricingUseCase,
+		calendarUseCase,
+		availabilityBlockUseCase,
 	)
 	dictionaryHandler := httpDelivery.NewDictionaryHandler(apartmentUseCase)
 	bookingHandler := httpDelivery.NewBookingHandler(bookingUseCase, userUseCase, lockUseCase, responseCacheService)
@@ -244,6 +277,7 @@ func InitApp(cfg *config.Config) (*App, error) {
 	systemHandler := httpDelivery.NewSystemHandler(userCacheService)
 
 	tuyaWebhookHandler := httpDelivery.NewTuyaWebhookHandler(lockUseCase)
+	freedomPayWebhookHandler := httpDelivery.NewFreedomPayWebhookHandler(paymentUseCase, bookingUseCase)
 
 	conciergeHandler := httpDelivery.NewConciergeHandler(conciergeUseCase)
 	conciergeInterfaceHandler := httpDelivery.NewConciergeInterfaceHandler(conciergeUseCase, apartmentUseCase, bookingUseCase, chatUseCase)
@@ -253,6 +287,7 @@ func InitApp(cfg *config.Config) (*App, error) {
 	contractHandler := httpDelivery.NewContractHandler(contractUseCase, userUseCase)
 	settingsHandler := httpDelivery.NewPlatformSettingsHandler(settingsUseCase, middleware)
 	apartmentTypeHandler := httpDelivery.NewApartmentTypeHandler(apartmentTypeUseCase)
+	reviewHandler := httpDelivery.NewReviewHandler(reviewUseCase, middleware)
 
 	router := initRouter(
 		authHandler,
@@ -274,9 +309,11 @@ func InitApp(cfg *config.Config) (*App, error) {
 		contractHandler,
 		settingsHandler,
 		apartmentTypeHandler,
+		reviewHandler,
 		middleware,
 		locationUseCase,
 		tuyaWebhookHandler,
+		freedomPayWebhookHandler,
 		responseCacheService,
 	)
 
@@ -321,15 +358,18 @@ func initRouter(
 	contractHandler *httpDelivery.ContractHandler,
 	settingsHandler *httpDelivery.PlatformSettingsHandler,
 	apartmentTypeHandler *httpDelivery.ApartmentTypeHandler,
+	reviewHandler *httpDelivery.ReviewHandler,
 	middleware *httpDelivery.Middleware,
 	locationUseCase domain.LocationUseCase,
 	tuyaWebhookHandler *httpDelivery.TuyaWebhookHandler,
+	freedomPayWebhookHandler *httpDelivery.FreedomPayWebhookHandler,
 	responseCacheService *services.ResponseCacheService,
 ) *gin.Engine {
 	router := gin.Default()
 
 	router.Use(services.PerformanceMiddleware())
 	router.Use(middleware.CORS())
+	router.Use(httpDelivery.LanguageMiddleware())
 	router.Use(httpDelivery.ErrorMiddleware())
 
 	router.Static("/uploads", "./uploads")
@@ -340,7 +380,7 @@ func initRouter(
 
 	api := router.Group("/api")
 
-	authHandler.RegisterRoutes(api)
+	authHandler.RegisterRoutes(api, middleware)
 
 	apartments := api.Group("/apartments")
 	{
@@ -355,6 +395,9 @@ func initRouter(
 		apartments.GET("/:id/booked-dates", apartmentHandler.GetBookedDates)
 		apartments.GET("/:id/availability", apartmentHandler.CheckApartmentAvailability)
 		apartments.GET("/:id/available-slots", apartmentHandler.GetAvailableTimeSlots)
+		apartments.GET("/:id/cancellation-policy", apartmentHandler.GetCancellationPolicy)
+		apartments.GET("/public-holidays", httpDelivery.LongCacheMiddleware(responseCacheService), apartmentHandler.GetPublicHolidays)
+		apartments.GET("/calendar/:token", apartmentHandler.GetCalendarFeed)
 
 		authorized := apartments.Group("/", middleware.AuthMiddleware())
 		{
@@ -373,6 +416,27 @@ func initRouter(
 			authorized.DELETE("/documents/:documentId", apartmentHandler.DeleteDocument)
 
 			authorized.POST("/:id/confirm-agreement", apartmentHandler.ConfirmApartmentAgreement)
+			authorized.PUT("/:id/cancellation-policy", apartmentHandler.UpdateCancellationPolicy)
+			authorized.PUT("/:id/stay-settings", apartmentHandler.UpdateStaySettings)
+
+			authorized.GET("/:id/pricing-rules", apartmentHandler.GetPricingRules)
+			authorized.POST("/:id/pricing-rules", apartmentHandler.CreatePricingRule)
+			authorized.PUT("/:id/pricing-rules/:ruleId", apartmentHandler.UpdatePricingRule)
+			authorized.DELETE("/:id/pricing-rules/:ruleId", apartmentHandler.DeletePricingRule)
+
+			authorized.GET("/:id/calendar/export", apartmentHandler.GetCalendarExport)
+			authorized.POST("/:id/calendar/export/regenerate", apartmentHandler.RegenerateCalendarExport)
+			authorized.GET("/:id/calendar/external", apartmentHandler.GetExternalCalendars)
+			authorized.POST("/:id/calendar/external", apartmentHandler.AddExternalCalendar)
+			authorized.PUT("/:id/calendar/external/:calendarId", apartmentHandler.UpdateExternalCalendar)
+			authorized.DELETE("/:id/calendar/external/:calendarId", apartmentHandler.DeleteExternalCalendar)
+			authorized.POST("/:id/calendar/external/:calendarId/sync", apartmentHandler.SyncExternalCalendar)
+			authorized.GET("/:id/calendar/external-events", apartmentHandler.GetExternalCalendarEvents)
+			authorized.GET("/:id/calendar", apartmentHandler.GetOwnerCalendar)
+			authorized.GET("/:id/availability-blocks", apartmentHandler.GetAvailabilityBlocks)
+			authorized.POST("/:id/availability-blocks", apartmentHandler.CreateAvailabilityBlock)
+			authorized.PUT("/:id/availability-blocks/:blockId", apartmentHandler.UpdateAvailabilityBlock)
+			authorized.DELETE("/:id/availability-blocks/:blockId", apartmentHandler.DeleteAvailabilityBlock)
 
 			authorized.GET("/owner/statistics", httpDelivery.CacheMiddlewareWithTTL(responseCacheService, 2*time.Minute), apartmentHandler.GetOwnerStatistics)
 
@@ -395,8 +459,10 @@ func initRouter(
 
 	settingsHandler.RegisterRoutes(api)
 	apartmentTypeHandler.RegisterRoutes(api)
+	reviewHandler.RegisterRoutes(api)
 
 	tuyaWebhookHandler.RegisterRoutes(api)
+	freedomPayWebhookHandler.RegisterRoutes(api)
 
 	protected := api.Group("")
 	protected.Use(middleware.AuthMiddleware())
@@ -438,7 +504,13 @@ func initRouter(
 			cleanerHandler.RegisterCleanerRoutes(cleanerRoutes)
 		}
 
-		bookingHandler.RegisterRoutes(protected)
+		ownerRoutes := protected.Group("/owner")
+		ownerRoutes.Use(middleware.RoleMiddleware(domain.RoleOwner))
+		{
+			cleanerHandler.RegisterOwnerRoutes(ownerRoutes)
+		}
+
+		bookingHandler.RegisterRoutes(protected, middleware)
 
 		paymentHandler.RegisterRoutes(protected)
 
@@ -1,12 +1,19 @@
 package app
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/config"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/services"
+	"github.com/russo2642/renti_kz/internal/utils"
 	"github.com/russo2642/renti_kz/pkg/auth"
+	"github.com/russo2642/renti_kz/pkg/envelope"
 	"github.com/russo2642/renti_kz/pkg/logger"
 	"github.com/russo2642/renti_kz/pkg/storage/s3"
 )
@@ -69,6 +76,121 @@ func initTokenManager(cfg config.JWTConfig) auth.TokenManager {
 	return auth.NewJWTManager(cfg.AccessSecret, cfg.RefreshSecret, cfg.AccessTTL, cfg.RefreshTTL)
 }
 
+// initLockCodesKeyring собирает мастер-ключи шифрования паролей замков. Без ключей в production
+// приложение не запускается; в остальных окружениях используется фиксированный ключ для разработки.
+func initLockCodesKeyring(cfg config.LockCodesConfig, appCfg config.AppConfig) (*envelope.Keyring, error) {
+	keys, err := envelope.ParseKeys(cfg.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOCK_CODES_KEYS: %w", err)
+	}
+
+	activeID := cfg.ActiveKeyID
+	if len(keys) == 0 {
+		if appCfg.IsProduction() {
+			return nil, errors.New("LOCK_CODES_KEYS must be set in production")
+		}
+
+		logger.Warn("lock code encryption keys are not set, using development key")
+		devKey := sha256.Sum256([]byte("renti_kz development lock codes key"))
+		keys = map[string][]byte{"dev": devKey[:]}
+		activeID = "dev"
+	}
+
+	if activeID == "" && len(keys) == 1 {
+		for id := range keys {
+			activeID = id
+		}
+	}
+
+	keyring, err := envelope.NewKeyring(keys, activeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lock codes keyring: %w", err)
+	}
+
+	logger.Info("lock code encryption initialized",
+		slog.String("active_key", keyring.ActiveKeyID()),
+		slog.Int("keys", len(keys)))
+
+	return keyring, nil
+}
+
+// rotateLockCodes перешифровывает пароли замков активным мастер-ключом в фоне, не задерживая запуск.
+func rotateLockCodes(lockUseCase domain.LockUseCase) {
+	go func() {
+		if _, err := lockUseCase.RotateCodeEncryption(); err != nil {
+			logger.Error("failed to rotate lock codes encryption", slog.String("error", err.Error()))
+		}
+	}()
+}
+
+// checkPublicHolidays предупреждает, если в календаре праздников нет Курбан айта на текущий или следующий год:
+// его дата заводится вручную, и без нее праздничные правила цен молча перестанут применяться.
+func checkPublicHolidays(pricingUseCase domain.PricingUseCase) {
+	go func() {
+		currentYear := time.Now().In(utils.KazakhstanTZ).Year()
+		for _, year := range []int{currentYear, currentYear + 1} {
+			holidays, err := pricingUseCase.GetPublicHolidays(year)
+			if err != nil {
+				logger.Error("failed to check public holidays", slog.Int("year", year), slog.String("error", err.Error()))
+				return
+			}
+
+			if !hasHoliday(holidays, domain.KurbanAitHolidayName) {
+				logger.Warn("public holiday date is missing, holiday pricing will not apply",
+					slog.String("holiday", domain.KurbanAitHolidayName), slog.Int("year", year))
+			}
+		}
+	}()
+}
+
+func hasHoliday(holidays []*domain.PublicHoliday, name string) bool {
+	for _, holiday := range holidays {
+		if holiday.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// initNotificationSenders собирает драйверы SMS и email по настройкам NOTIFICATION_*_DRIVER.
+func initNotificationSenders(cfg *config.Config) []domain.NotificationSender {
+	var senders []domain.NotificationSender
+
+	switch cfg.Notification.SMSDriver {
+	case "isms":
+		if cfg.OTP.Token == "" || cfg.OTP.SMSAPIBase == "" {
+			logger.Warn("SMS notification channel is disabled: OTP provider is not configured")
+			break
+		}
+		senders = append(senders, services.NewSMSNotificationSender(&cfg.OTP))
+	case "log":
+		senders = append(senders, services.NewLogNotificationSender(domain.NotificationChannelSMS))
+	case "":
+	default:
+		logger.Warn("unknown SMS notification driver", slog.String("driver", cfg.Notification.SMSDriver))
+	}
+
+	switch cfg.Notification.EmailDriver {
+	case "smtp":
+		if cfg.SMTP.Host == "" {
+			logger.Warn("email notification channel is disabled: SMTP_HOST is not set")
+			break
+		}
+		senders = append(senders, services.NewEmailNotificationSender(&cfg.SMTP))
+	case "log":
+		senders = append(senders, services.NewLogNotificationSender(domain.NotificationChannelEmail))
+	case "":
+	default:
+		logger.Warn("unknown email notification driver", slog.String("driver", cfg.Notification.EmailDriver))
+	}
+
+	for _, sender := range senders {
+		logger.Info("notification channel enabled", slog.String("channel", string(sender.Channel())))
+	}
+
+	return senders
+}
+
 func maskCredential(credential string) string {
 	if len(credential) <= 8 {
 		return "***"
@@ -27,8 +27,11 @@ type Config struct {
 	Redis        RedisConfig
 	Notification NotificationConfig
 	OTP          OTPConfig
+	SMTP         SMTPConfig
 	FreedomPay   FreedomPayConfig
+	Calendar     CalendarConfig
 	Log          LogConfig
+	LockCodes    LockCodesConfig
 }
 
 type ServerConfig struct {
@@ -120,6 +123,11 @@ func (c *RedisConfig) Addr() string {
 type NotificationConfig struct {
 	RedisQueueName string
 	PollInterval   time.Duration
+
+	// SMSDriver и EmailDriver выбирают реализацию каналов: isms/smtp - реальная отправка,
+	// log - запись в лог для локальной разработки, пустое значение отключает канал
+	SMSDriver   string
+	EmailDriver string
 }
 
 type OTPConfig struct {
@@ -127,13 +135,47 @@ type OTPConfig struct {
 	APIBase  string
 	From     string
 	Template string
+
+	// SMSAPIBase адрес отправки произвольных SMS у того же провайдера (токен и отправитель общие с OTP)
+	SMSAPIBase string
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	FromName string
+}
+
+func (c *SMTPConfig) Addr() string {
+	return fmt.Sprintf("%s:%s", c.Host, c.Port)
 }
 
 type FreedomPayConfig struct {
-	MerchantID string
-	SecretKey  string
-	APIBase    string
-	WebhookURL string
+	MerchantID      string
+	SecretKey       string
+	APIBase         string
+	WebhookURL      string
+	SuccessURL      string
+	FailureURL      string
+	PaymentLifetime time.Duration
+	TestingMode     bool
+}
+
+type CalendarConfig struct {
+	// PublicBaseURL внешний адрес API, из которого строятся ссылки на iCal-фиды квартир
+	PublicBaseURL string
+	FetchTimeout  time.Duration
+}
+
+// LockCodesConfig мастер-ключи для шифрования паролей замков в БД.
+// Keys - список "<id>:<base64 32 байт>" через запятую. Для ротации новый ключ добавляется в список
+// и назначается активным; старые ключи остаются в списке, пока записи не перешифрованы при запуске.
+type LockCodesConfig struct {
+	Keys        string
+	ActiveKeyID string
 }
 
 type LogConfig struct {
@@ -211,18 +253,39 @@ func Load() (*Config, error) {
 		Notification: NotificationConfig{
 			RedisQueueName: getEnv("NOTIFICATION_QUEUE_NAME", "notification_queue"),
 			PollInterval:   time.Duration(getEnvAsInt("NOTIFICATION_POLL_INTERVAL", 10)) * time.Second,
+			SMSDriver:      getEnv("NOTIFICATION_SMS_DRIVER", "isms"),
+			EmailDriver:    getEnv("NOTIFICATION_EMAIL_DRIVER", "smtp"),
 		},
 		OTP: OTPConfig{
 			Token:    getEnv("OTP_TOKEN", "cifpabrnvzizpgboqgitteckjitevjqx"),
 			APIBase:  getEnv("OTP_API_BASE", "http://isms.center/v1/validation"),
 			From:     getEnv("OTP_FROM", "KiT_Notify"),
 			Template: getEnv("OTP_TEMPLATE", "Ваш код для renti.kz: [:pin]"),
+
+			SMSAPIBase: getEnv("OTP_SMS_API_BASE", "http://isms.center/v1/send"),
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "noreply@renti.kz"),
+			FromName: getEnv("SMTP_FROM_NAME", "renti.kz"),
 		},
 		FreedomPay: FreedomPayConfig{
 			MerchantID: getEnv("FREEDOMPAY_MERCHANT_ID", ""),
 			SecretKey:  getEnv("FREEDOMPAY_SECRET_KEY", ""),
 			APIBase:    getEnv("FREEDOMPAY_API_BASE", "https://api.freedompay.kz"),
 			WebhookURL: getEnv("FREEDOMPAY_WEBHOOK_URL", ""),
+			SuccessURL: getEnv("FREEDOMPAY_SUCCESS_URL", ""),
+			FailureURL: getEnv("FREEDOMPAY_FAILURE_URL", ""),
+			// Совпадает с окном очистки неоплаченных бронирований (30 минут)
+			PaymentLifetime: time.Duration(getEnvAsInt("FREEDOMPAY_PAYMENT_LIFETIME", 1800)) * time.Second,
+			TestingMode:     getEnvAsBool("FREEDOMPAY_TESTING_MODE", false),
+		},
+		Calendar: CalendarConfig{
+			PublicBaseURL: getEnv("CALENDAR_PUBLIC_BASE_URL", ""),
+			FetchTimeout:  time.Duration(getEnvAsInt("CALENDAR_FETCH_TIMEOUT", 15)) * time.Second,
 		},
 		Log: LogConfig{
 			Level:      getEnv("LOG_LEVEL", "debug"),
@@ -230,6 +293,10 @@ func Load() (*Config, error) {
 			Output:     getEnv("LOG_OUTPUT", "stdout"),
 			ShowSource: getEnvAsBool("LOG_SHOW_SOURCE", false),
 		},
+		LockCodes: LockCodesConfig{
+			Keys:        getEnv("LOCK_CODES_KEYS", ""),
+			ActiveKeyID: getEnv("LOCK_CODES_ACTIVE_KEY", ""),
+		},
 	}
 
 	return config, nil
@@ -0,0 +1,492 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+)
+
+// @Summary Публичный iCal-фид квартиры
+// @Description Возвращает занятые периоды квартиры в формате iCalendar (RFC 5545) для импорта на других площадках. Доступ по секретному токену
+// @Tags apartments
+// @Produce text/calendar
+// @Param token path string true "Секретный токен фида (допускается суффикс .ics)"
+// @Success 200 {string} string "Календарь iCalendar"
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /apartments/calendar/{token} [get]
+func (h *ApartmentHandler) GetCalendarFeed(c *gin.Context) {
+	feed, err := h.calendarUseCase.RenderExportFeed(c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка формирования календаря"))
+		return
+	}
+	if feed == nil {
+		c.JSON(http.StatusNotFound, domain.NewErrorResponse("календарь не найден"))
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache, must-revalidate")
+	c.Header("Content-Disposition", `inline; filename="renti.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
+
+// @Summary Ссылка на iCal-фид квартиры
+// @Description Возвращает секретную ссылку на iCal-фид квартиры для подключения на других площадках. Токен создается при первом запросе
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Success 200 {object} domain.SuccessResponse{data=domain.CalendarExportInfo}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/export [get]
+func (h *ApartmentHandler) GetCalendarExport(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	info, err := h.calendarUseCase.GetExportInfo(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("ссылка на календарь получена", info))
+}
+
+// @Summary Перевыпуск ссылки на iCal-фид
+// @Description Создает новый секретный токен фида. Старая ссылка перестает работать
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Success 200 {object} domain.SuccessResponse{data=domain.CalendarExportInfo}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/export/regenerate [post]
+func (h *ApartmentHandler) RegenerateCalendarExport(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	info, err := h.calendarUseCase.RegenerateExportToken(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("ссылка на календарь обновлена", info))
+}
+
+// @Summary Внешние календари квартиры
+// @Description Возвращает подключенные iCal-календари других площадок и статус их последней синхронизации
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.ExternalCalendar}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/external [get]
+func (h *ApartmentHandler) GetExternalCalendars(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	calendars, err := h.calendarUseCase.GetExternalCalendars(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("внешние календари получены", calendars))
+}
+
+// @Summary Подключение внешнего календаря
+// @Description Подключает iCal-ссылку другой площадки. Календарь сразу импортируется, затем обновляется по расписанию. Импортированные события блокируют бронирование
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param request body domain.CreateExternalCalendarRequest true "Внешний календарь"
+// @Success 201 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/external [post]
+func (h *ApartmentHandler) AddExternalCalendar(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	var request domain.CreateExternalCalendarRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	calendar, result, err := h.calendarUseCase.AddExternalCalendar(apartmentID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.NewSuccessResponse("внешний календарь подключен", gin.H{
+		"calendar": calendar,
+		"sync":     result,
+	}))
+}
+
+// @Summary Изменение внешнего календаря
+// @Description Изменяет название календаря или приостанавливает его импорт. События отключенного календаря не блокируют бронирование
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param calendarId path int true "ID внешнего календаря"
+// @Param request body domain.UpdateExternalCalendarRequest true "Изменения"
+// @Success 200 {object} domain.SuccessResponse{data=domain.ExternalCalendar}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/external/{calendarId} [put]
+func (h *ApartmentHandler) UpdateExternalCalendar(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	calendarID, ok := utils.ParseIDParam(c, "calendarId")
+	if !ok {
+		return
+	}
+
+	var request domain.UpdateExternalCalendarRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	calendar, err := h.calendarUseCase.UpdateExternalCalendar(apartmentID, calendarID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("внешний календарь обновлен", calendar))
+}
+
+// @Summary Отключение внешнего календаря
+// @Description Удаляет внешний календарь вместе с импортированными из него периодами
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param calendarId path int true "ID внешнего календаря"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/external/{calendarId} [delete]
+func (h *ApartmentHandler) DeleteExternalCalendar(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	calendarID, ok := utils.ParseIDParam(c, "calendarId")
+	if !ok {
+		return
+	}
+
+	if err := h.calendarUseCase.DeleteExternalCalendar(apartmentID, calendarID); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("внешний календарь отключен", nil))
+}
+
+// @Summary Синхронизация внешнего календаря
+// @Description Немедленно импортирует внешний календарь, не дожидаясь планового обновления
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param calendarId path int true "ID внешнего календаря"
+// @Success 200 {object} domain.SuccessResponse{data=domain.CalendarSyncResult}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/external/{calendarId}/sync [post]
+func (h *ApartmentHandler) SyncExternalCalendar(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	calendarID, ok := utils.ParseIDParam(c, "calendarId")
+	if !ok {
+		return
+	}
+
+	result, err := h.calendarUseCase.SyncExternalCalendar(apartmentID, calendarID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("синхронизация календаря выполнена", result))
+}
+
+// @Summary Занятые периоды из внешних календарей
+// @Description Возвращает текущие и будущие периоды, импортированные из подключенных календарей других площадок
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.ExternalCalendarEvent}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar/external-events [get]
+func (h *ApartmentHandler) GetExternalCalendarEvents(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.calendarUseCase.GetExternalEvents(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("импортированные периоды получены", events))
+}
+
+// @Summary Календарь владельца
+// @Description Возвращает бронирования, блокировки владельца и периоды из внешних календарей за выбранный период. По умолчанию - 90 дней начиная с сегодняшнего дня
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param from query string false "Первый день периода в формате 2006-01-02"
+// @Param to query string false "Последний день периода в формате 2006-01-02"
+// @Success 200 {object} domain.SuccessResponse{data=domain.ApartmentCalendar}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/calendar [get]
+func (h *ApartmentHandler) GetOwnerCalendar(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseCalendarRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.availabilityBlockUseCase.GetOwnerCalendar(apartmentID, from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("календарь квартиры получен", calendar))
+}
+
+// @Summary Блокировки квартиры
+// @Description Возвращает периоды, закрытые владельцем для бронирования (ремонт, уборка, личное использование). По умолчанию - 90 дней начиная с сегодняшнего дня
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param from query string false "Первый день периода в формате 2006-01-02"
+// @Param to query string false "Последний день периода в формате 2006-01-02"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.AvailabilityBlock}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/availability-blocks [get]
+func (h *ApartmentHandler) GetAvailabilityBlocks(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	from, to, ok := parseCalendarRangeQuery(c)
+	if !ok {
+		return
+	}
+
+	blocks, err := h.availabilityBlockUseCase.GetApartmentBlocks(apartmentID, from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("блокировки получены", blocks))
+}
+
+// @Summary Блокировка периода
+// @Description Закрывает период для бронирования. Причина: repair (ремонт), cleaning (уборка), personal (личное использование). Период не должен пересекаться с действующими бронированиями. Даты указываются по времени Казахстана
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param request body domain.AvailabilityBlockRequest true "Блокировка"
+// @Success 201 {object} domain.SuccessResponse{data=domain.AvailabilityBlock}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/availability-blocks [post]
+func (h *ApartmentHandler) CreateAvailabilityBlock(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var request domain.AvailabilityBlockRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	block, err := h.availabilityBlockUseCase.CreateBlock(apartmentID, userID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.NewSuccessResponse("период заблокирован", block))
+}
+
+// @Summary Изменение блокировки
+// @Description Изменяет период, причину или комментарий блокировки
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param blockId path int true "ID блокировки"
+// @Param request body domain.AvailabilityBlockRequest true "Блокировка"
+// @Success 200 {object} domain.SuccessResponse{data=domain.AvailabilityBlock}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/availability-blocks/{blockId} [put]
+func (h *ApartmentHandler) UpdateAvailabilityBlock(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	blockID, ok := utils.ParseIDParam(c, "blockId")
+	if !ok {
+		return
+	}
+
+	var request domain.AvailabilityBlockRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	block, err := h.availabilityBlockUseCase.UpdateBlock(apartmentID, blockID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("блокировка обновлена", block))
+}
+
+// @Summary Снятие блокировки
+// @Description Удаляет блокировку, период снова становится доступным для бронирования
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param blockId path int true "ID блокировки"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/availability-blocks/{blockId} [delete]
+func (h *ApartmentHandler) DeleteAvailabilityBlock(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	blockID, ok := utils.ParseIDParam(c, "blockId")
+	if !ok {
+		return
+	}
+
+	if err := h.availabilityBlockUseCase.DeleteBlock(apartmentID, blockID); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("блокировка снята", nil))
+}
+
+// parseCalendarRangeQuery разбирает параметры from/to (дни по времени Казахстана, to включительно).
+// Незаданные границы остаются нулевыми и заполняются значениями по умолчанию в usecase.
+func parseCalendarRangeQuery(c *gin.Context) (from, to time.Time, ok bool) {
+	if value := c.Query("from"); value != "" {
+		date, err := time.ParseInLocation(utils.StayDateLayout, value, utils.KazakhstanTZ)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("неверный формат from (используйте 2006-01-02)"))
+			return from, to, false
+		}
+		from = date.UTC()
+	}
+
+	if value := c.Query("to"); value != "" {
+		date, err := time.ParseInLocation(utils.StayDateLayout, value, utils.KazakhstanTZ)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("неверный формат to (используйте 2006-01-02)"))
+			return from, to, false
+		}
+		to = date.AddDate(0, 0, 1).UTC()
+	}
+
+	return from, to, true
+}
@@ -20,19 +20,23 @@ import (
 )
 
 type ApartmentHandler struct {
-	apartmentUseCase     domain.ApartmentUseCase
-	userUseCase          domain.UserUseCase
-	ownerUseCase         domain.PropertyOwnerUseCase
-	locationUseCase      domain.LocationUseCase
-	notificationUseCase  domain.NotificationUseCase
-	settingsUseCase      domain.PlatformSettingsUseCase
-	bookingUseCase       domain.BookingUseCase
-	lockUseCase          domain.LockUseCase
-	middleware           *Middleware
-	userRepo             domain.UserRepository
-	ownerRepo            domain.PropertyOwnerRepository
-	roleRepo             domain.RoleRepository
-	responseCacheService *services.ResponseCacheService
+	apartmentUseCase         domain.ApartmentUseCase
+	userUseCase              domain.UserUseCase
+	ownerUseCase             domain.PropertyOwnerUseCase
+	locationUseCase          domain.LocationUseCase
+	notificationUseCase      domain.NotificationUseCase
+	settingsUseCase          domain.PlatformSettingsUseCase
+	bookingUseCase           domain.BookingUseCase
+	lockUseCase              domain.LockUseCase
+	middleware               *Middleware
+	userRepo                 domain.UserRepository
+	ownerRepo                domain.PropertyOwnerRepository
+	roleRepo                 domain.RoleRepository
+	responseCacheService     *services.ResponseCacheService
+	cancellationUseCase      domain.CancellationRuleUseCase
+	pricingUseCase           domain.PricingUseCase
+	calendarUseCase          domain.CalendarUseCase
+	availabilityBlockUseCase domain.AvailabilityBlockUseCase
 }
 
 func NewApartmentHandler(
@@ -49,21 +53,29 @@ func NewApartmentHandler(
 	ownerRepo domain.PropertyOwnerRepository,
 	roleRepo domain.RoleRepository,
 	responseCacheService *services.ResponseCacheService,
+	cancellationUseCase domain.CancellationRuleUseCase,
+	pricingUseCase domain.PricingUseCase,
+	calendarUseCase domain.CalendarUseCase,
+	availabilityBlockUseCase domain.AvailabilityBlockUseCase,
 ) *ApartmentHandler {
 	return &ApartmentHandler{
-		apartmentUseCase:     apartmentUseCase,
-		userUseCase:          userUseCase,
-		ownerUseCase:         ownerUseCase,
-		locationUseCase:      locationUseCase,
-		notificationUseCase:  notificationUseCase,
-		settingsUseCase:      settingsUseCase,
-		bookingUseCase:       bookingUseCase,
-		lockUseCase:          lockUseCase,
-		middleware:           middleware,
-		userRepo:             userRepo,
-		ownerRepo:            ownerRepo,
-		roleRepo:             roleRepo,
-		responseCacheService: responseCacheService,
+		apartmentUseCase:         apartmentUseCase,
+		userUseCase:              userUseCase,
+		ownerUseCase:             ownerUseCase,
+		locationUseCase:          locationUseCase,
+		notificationUseCase:      notificationUseCase,
+		settingsUseCase:          settingsUseCase,
+		bookingUseCase:           bookingUseCase,
+		lockUseCase:              lockUseCase,
+		middleware:               middleware,
+		userRepo:                 userRepo,
+		ownerRepo:                ownerRepo,
+		roleRepo:                 roleRepo,
+		responseCacheService:     responseCacheService,
+		cancellationUseCase:      cancellationUseCase,
+		pricingUseCase:           pricingUseCase,
+		calendarUseCase:          calendarUseCase,
+		availabilityBlockUseCase: availabilityBlockUseCase,
 	}
 }
 
@@ -82,6 +94,9 @@ func (h *ApartmentHandler) RegisterRoutes(router *gin.RouterGroup) {
 		apartments.GET("/:id/booked-dates", h.GetBookedDates)
 		apartments.GET("/:id/availability", h.CheckApartmentAvailability)
 		apartments.GET("/:id/available-slots", h.GetAvailableTimeSlots)
+		apartments.GET("/:id/cancellation-policy", h.GetCancellationPolicy)
+		apartments.GET("/public-holidays", h.GetPublicHolidays)
+		apartments.GET("/calendar/:token", h.GetCalendarFeed)
 
 		authorized := apartments.Group("/", h.middleware.AuthMiddleware())
 		{
@@ -101,6 +116,27 @@ func (h *ApartmentHandler) RegisterRoutes(router *gin.RouterGroup) {
 			authorized.DELETE("/documents/:documentId", h.DeleteDocument)
 
 			authorized.POST("/:id/confirm-agreement", h.ConfirmApartmentAgreement)
+			authorized.PUT("/:id/cancellation-policy", h.UpdateCancellationPolicy)
+			authorized.PUT("/:id/stay-settings", h.UpdateStaySettings)
+
+			authorized.GET("/:id/pricing-rules", h.GetPricingRules)
+			authorized.POST("/:id/pricing-rules", h.CreatePricingRule)
+			authorized.PUT("/:id/pricing-rules/:ruleId", h.UpdatePricingRule)
+			authorized.DELETE("/:id/pricing-rules/:ruleId", h.DeletePricingRule)
+
+			authorized.GET("/:id/calendar/export", h.GetCalendarExport)
+			authorized.POST("/:id/calendar/export/regenerate", h.RegenerateCalendarExport)
+			authorized.GET("/:id/calendar/external", h.GetExternalCalendars)
+			authorized.POST("/:id/calendar/external", h.AddExternalCalendar)
+			authorized.PUT("/:id/calendar/external/:calendarId", h.UpdateExternalCalendar)
+			authorized.DELETE("/:id/calendar/external/:calendarId", h.DeleteExternalCalendar)
+			authorized.POST("/:id/calendar/external/:calendarId/sync", h.SyncExternalCalendar)
+			authorized.GET("/:id/calendar/external-events", h.GetExternalCalendarEvents)
+			authorized.GET("/:id/calendar", h.GetOwnerCalendar)
+			authorized.GET("/:id/availability-blocks", h.GetAvailabilityBlocks)
+			authorized.POST("/:id/availability-blocks", h.CreateAvailabilityBlock)
+			authorized.PUT("/:id/availability-blocks/:blockId", h.UpdateAvailabilityBlock)
+			authorized.DELETE("/:id/availability-blocks/:blockId", h.DeleteAvailabilityBlock)
 
 			authorized.GET("/owner/statistics", h.GetOwnerStatistics)
 		}
@@ -564,6 +600,13 @@ func (h *ApartmentHandler) GetByID(c *gin.Context) {
 // @Param rental_type_daily query bool false "Поддержка посуточной аренды"
 // @Param apartment_type_id query int false "Тип квартиры (ID)"
 // @Param is_free query bool false "Доступность квартиры (true - свободная, false - занятая)"
+// @Param search query string false "Поиск по адресу, названию ЖК и описанию (допускаются опечатки)"
+// @Param amenity_ids query []int false "ID удобств: квартира должна иметь все выбранные" collectionFormat(csv)
+// @Param house_rule_ids query []int false "ID правил проживания: квартира должна иметь все выбранные" collectionFormat(csv)
+// @Param min_rating query number false "Минимальный рейтинг (от 1 до 5)"
+// @Param sort_by query string false "Сортировка: newest (по умолчанию), relevance (по умолчанию при поиске), price_asc, price_desc, rating, reviews_count, distance"
+// @Param latitude query number false "Широта точки отсчета для sort_by=distance"
+// @Param longitude query number false "Долгота точки отсчета для sort_by=distance"
 // @Param status query string false "Статус квартиры"
 // @Param page query int false "Номер страницы" default(1)
 // @Param page_size query int false "Размер страницы" default(10)
@@ -666,6 +709,8 @@ func (h *ApartmentHandler) GetAll(c *gin.Context) {
 		}
 	}
 
+	addSearchQueryFilters(c, filters)
+
 	userID, exists := c.Get("user_id")
 	var userIDPtr *int
 	if exists {
@@ -2378,8 +2423,10 @@ func (h *ApartmentHandler) CanBookNow(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "ID квартиры"
-// @Param duration query int true "Продолжительность бронирования в часах"
+// @Param duration query int false "Продолжительность бронирования в часах (обязательна без check_in_date/check_out_date)"
 // @Param start_time query string false "Время начала аренды (формат: 2006-01-02T15:04:05)"
+// @Param check_in_date query string false "Дата заезда для посуточного бронирования на несколько ночей (формат: 2006-01-02)"
+// @Param check_out_date query string false "Дата выезда для посуточного бронирования на несколько ночей (формат: 2006-01-02)"
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 404 {object} domain.ErrorResponse
@@ -2393,18 +2440,6 @@ func (h *ApartmentHandler) CalculatePrice(c *gin.Context) {
 		return
 	}
 
-	durationParam := c.Query("duration")
-	if durationParam == "" {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("параметр duration обязателен"))
-		return
-	}
-
-	duration, err := strconv.Atoi(durationParam)
-	if err != nil || duration <= 0 {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректное значение продолжительности"))
-		return
-	}
-
 	apartment, err := h.apartmentUseCase.GetByID(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при получении данных квартиры"))
@@ -2416,84 +2451,100 @@ func (h *ApartmentHandler) CalculatePrice(c *gin.Context) {
 	}
 
 	var startTime time.Time
-	startTimeParam := c.Query("start_time")
-	if startTimeParam != "" {
-		parsedTime, err := utils.ParseUserInput(startTimeParam)
+	var duration int
+	var breakdown *domain.PriceBreakdown
+	var stay *domain.StayPeriod
+
+	checkInDate, checkOutDate := c.Query("check_in_date"), c.Query("check_out_date")
+	if checkInDate != "" || checkOutDate != "" {
+		stay, err = utils.ResolveStayPeriod(apartment, checkInDate, checkOutDate)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректный формат времени. Используйте формат: 2006-01-02T15:04:05"))
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
 			return
 		}
-		startTime = parsedTime
-	} else {
-		startTime = utils.GetCurrentTimeUTC()
-	}
 
-	if duration == 24 {
-		if !apartment.RentalTypeDaily {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("данная квартира не поддерживает посуточную аренду"))
-			return
-		}
-		if apartment.DailyPrice <= 0 {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("для данной квартиры не установлена цена за сутки"))
+		breakdown, err = h.pricingUseCase.CalculateStayPrice(apartment, stay)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка расчета стоимости"))
 			return
 		}
+		startTime = stay.CheckIn
+		duration = stay.Hours()
 	} else {
-		if !apartment.RentalTypeHourly {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("данная квартира не поддерживает почасовую аренду"))
-			return
-		}
-		if apartment.Price <= 0 {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("для данной квартиры не установлена почасовая цена"))
+		durationParam := c.Query("duration")
+		if durationParam == "" {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("параметр duration обязателен"))
 			return
 		}
-	}
 
-	if !utils.ValidateRentalTime(startTime, duration, apartment.RentalTypeHourly, apartment.RentalTypeDaily) {
-		timeInfo := utils.GetRentalTimeInfo(startTime)
-		isDaytime := timeInfo["is_daytime"].(bool)
-
-		if duration < 24 && !isDaytime {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("почасовая аренда доступна только с 10:00 до 22:00 (местное время)"))
+		duration, err = strconv.Atoi(durationParam)
+		if err != nil || duration <= 0 {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректное значение продолжительности"))
 			return
 		}
 
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(fmt.Sprintf("продолжительность %d часов недоступна для данной квартиры в указанное время", duration)))
-		return
-	}
+		startTimeParam := c.Query("start_time")
+		if startTimeParam != "" {
+			parsedTime, err := utils.ParseUserInput(startTimeParam)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректный формат времени. Используйте формат: 2006-01-02T15:04:05"))
+				return
+			}
+			startTime = parsedTime
+		} else {
+			startTime = utils.GetCurrentTimeUTC()
+		}
 
-	var basePrice int
-	if duration == 24 && apartment.RentalTypeDaily {
-		basePrice = apartment.DailyPrice
-	} else if duration < 24 && apartment.RentalTypeHourly {
-		basePrice = utils.CalculateHourlyPrice(apartment.Price, duration)
-	} else {
 		if duration == 24 {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("посуточная аренда недоступна для данной квартиры"))
+			if !apartment.RentalTypeDaily {
+				c.JSON(http.StatusBadRequest, domain.NewErrorResponse("данная квартира не поддерживает посуточную аренду"))
+				return
+			}
+			if apartment.DailyPrice <= 0 {
+				c.JSON(http.StatusBadRequest, domain.NewErrorResponse("для данной квартиры не установлена цена за сутки"))
+				return
+			}
 		} else {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("почасовая аренда недоступна для данной квартиры"))
+			if !apartment.RentalTypeHourly {
+				c.JSON(http.StatusBadRequest, domain.NewErrorResponse("данная квартира не поддерживает почасовую аренду"))
+				return
+			}
+			if apartment.Price <= 0 {
+				c.JSON(http.StatusBadRequest, domain.NewErrorResponse("для данной квартиры не установлена почасовая цена"))
+				return
+			}
 		}
-		return
-	}
-	var serviceFee int
-	if duration == 24 {
-		serviceFee = 3000
-	} else {
-		serviceFeePercentage := domain.ServiceFeePercentage
-		if h.settingsUseCase != nil {
-			if percentage, err := h.settingsUseCase.GetServiceFeePercentage(); err == nil {
-				serviceFeePercentage = percentage
+
+		if !utils.ValidateRentalTime(startTime, duration, apartment.RentalTypeHourly, apartment.RentalTypeDaily) {
+			timeInfo := utils.GetRentalTimeInfo(startTime)
+			isDaytime := timeInfo["is_daytime"].(bool)
+
+			if duration < 24 && !isDaytime {
+				c.JSON(http.StatusBadRequest, domain.NewErrorResponse("почасовая аренда доступна только с 10:00 до 22:00 (местное время)"))
+				return
 			}
+
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(fmt.Sprintf("продолжительность %d часов недоступна для данной квартиры в указанное время", duration)))
+			return
+		}
+
+		breakdown, err = h.pricingUseCase.CalculateBookingPrice(apartment, startTime, duration)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка расчета стоимости"))
+			return
 		}
-		serviceFee = basePrice * serviceFeePercentage / 100
 	}
-	finalPrice := basePrice + serviceFee
+
+	basePrice := breakdown.BasePrice
+	serviceFee := breakdown.ServiceFee
+	finalPrice := breakdown.FinalPrice
 
 	timeInfo := utils.GetRentalTimeInfo(startTime)
 
 	response := gin.H{
 		"apartment_id": apartment.ID,
 		"duration":     duration,
-		"base_price":   basePrice,
+		"base_price":   breakdown.TotalPrice,
 		"service_fee":  serviceFee,
 		"final_price":  finalPrice,
 		"hourly_price": apartment.Price,
@@ -2502,7 +2553,10 @@ func (h *ApartmentHandler) CalculatePrice(c *gin.Context) {
 		"time_info":    timeInfo,
 		"price_breakdown": gin.H{
 			"calculation": func() string {
-				if duration == 24 {
+				if stay != nil {
+					return fmt.Sprintf("Посуточная аренда: %d тг × %d ноч. = %d тг", apartment.DailyPrice, stay.Nights, basePrice)
+				}
+				if breakdown.RentalType == "daily" {
 					return fmt.Sprintf("Посуточная аренда: %d тг", apartment.DailyPrice)
 				}
 				if duration == utils.RentalDuration6Hours {
@@ -2517,17 +2571,16 @@ func (h *ApartmentHandler) CalculatePrice(c *gin.Context) {
 				return fmt.Sprintf("Почасовая аренда: %d тг × %d ч = %d тг", apartment.Price, duration, basePrice)
 			}(),
 			"service_fee_info": func() string {
-				if duration == 24 {
-					return "Фиксированный сервисный сбор: 3000 тг"
+				if breakdown.ServiceFeePercentage == 0 {
+					return fmt.Sprintf("Фиксированный сервисный сбор: %d тг", serviceFee)
 				}
-				serviceFeePercentage := domain.ServiceFeePercentage
-				if h.settingsUseCase != nil {
-					if percentage, err := h.settingsUseCase.GetServiceFeePercentage(); err == nil {
-						serviceFeePercentage = percentage
-					}
-				}
-				return fmt.Sprintf("Сервисный сбор (%d%%): %d тг", serviceFeePercentage, serviceFee)
+				return fmt.Sprintf("Сервисный сбор (%d%%): %d тг", breakdown.ServiceFeePercentage, serviceFee)
 			}(),
+			"base_price":        basePrice,
+			"adjustments":       breakdown.Adjustments,
+			"adjustments_total": breakdown.AdjustmentsTotal,
+			"total_price":       breakdown.TotalPrice,
+			"details":           breakdown,
 		},
 		"cancellation_policy": func() gin.H {
 			freeCancellationDeadline := startTime.Add(-6 * time.Hour)
@@ -2657,6 +2710,13 @@ func (h *ApartmentHandler) GetBookedDates(c *gin.Context) {
 // @Param listing_type query string false "Тип объявления (owner, realtor)"
 // @Param room_count query int false "Количество комнат"
 // @Param apartment_type_id query int false "Тип квартиры (ID)"
+// @Param search query string false "Поиск по адресу, названию ЖК и описанию (допускаются опечатки)"
+// @Param amenity_ids query []int false "ID удобств: квартира должна иметь все выбранные" collectionFormat(csv)
+// @Param house_rule_ids query []int false "ID правил проживания: квартира должна иметь все выбранные" collectionFormat(csv)
+// @Param min_rating query number false "Минимальный рейтинг (от 1 до 5)"
+// @Param sort_by query string false "Сортировка: newest (по умолчанию), relevance (по умолчанию при поиске), price_asc, price_desc, rating, reviews_count, distance"
+// @Param latitude query number false "Широта точки отсчета для sort_by=distance"
+// @Param longitude query number false "Долгота точки отсчета для sort_by=distance"
 // @Param page query int false "Номер страницы" default(1)
 // @Param page_size query int false "Размер страницы" default(20)
 // @Success 200 {object} domain.SuccessResponse
@@ -2709,6 +2769,8 @@ func (h *ApartmentHandler) AdminGetAllApartments(c *gin.Context) {
 		}
 	}
 
+	addSearchQueryFilters(c, filters)
+
 	apartments, total, err := h.apartmentUseCase.GetAll(filters, page, pageSize)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при получении квартир"))
@@ -2867,10 +2929,11 @@ func (h *ApartmentHandler) AdminDeleteApartment(c *gin.Context) {
 		}
 
 		notification := &domain.Notification{
-			UserID:  userID,
-			Type:    domain.NotificationApartmentStatusChanged,
-			Title:   "Квартира удалена",
-			Message: fmt.Sprintf("Ваша квартира \"%s, %s\" была удалена администратором", apartment.Street, apartment.Building),
+			UserID:     userID,
+			Type:       domain.NotificationApartmentStatusChanged,
+			TitleKey:   "notification.apartment_deleted.title",
+			MessageKey: "notification.apartment_deleted.message",
+			Params:     map[string]string{"address": fmt.Sprintf("%s, %s", apartment.Street, apartment.Building)},
 		}
 		h.notificationUseCase.CreateNotification(notification)
 	}()
@@ -3392,6 +3455,13 @@ func calculateRevenueGrowthRate(totalRevenue, periodRevenue int, dateFrom, dateT
 // @Param status query string false "Статус квартиры"
 // @Param listing_type query string false "Тип объявления (owner, realtor)"
 // @Param apartment_type_id query int false "Тип квартиры (ID)"
+// @Param search query string false "Поиск по адресу, названию ЖК и описанию (допускаются опечатки)"
+// @Param amenity_ids query []int false "ID удобств: квартира должна иметь все выбранные" collectionFormat(csv)
+// @Param house_rule_ids query []int false "ID правил проживания: квартира должна иметь все выбранные" collectionFormat(csv)
+// @Param min_rating query number false "Минимальный рейтинг (от 1 до 5)"
+// @Param sort_by query string false "Сортировка: newest (по умолчанию), relevance (по умолчанию при поиске), price_asc, price_desc, rating, reviews_count, distance (от центра области или от latitude/longitude)"
+// @Param latitude query number false "Широта точки отсчета для sort_by=distance"
+// @Param longitude query number false "Долгота точки отсчета для sort_by=distance"
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -3449,6 +3519,15 @@ func (h *ApartmentHandler) GetByCoordinates(c *gin.Context) {
 	if req.ApartmentTypeID != nil {
 		filters["apartment_type_id"] = *req.ApartmentTypeID
 	}
+	addSearchQueryFilters(c, filters)
+
+	// Без явной точки отсчета расстояние считается от центра области на карте
+	if filters["sort_by"] == domain.ApartmentSortDistance {
+		if _, ok := filters["latitude"]; !ok {
+			filters["latitude"] = (req.MinLat + req.MaxLat) / 2
+			filters["longitude"] = (req.MinLng + req.MaxLng) / 2
+		}
+	}
 
 	apartments, err := h.apartmentUseCase.GetFullApartmentsByCoordinatesWithFilters(req.MinLat, req.MaxLat, req.MinLng, req.MaxLng, filters)
 	if err != nil {
@@ -3724,7 +3803,7 @@ func (h *ApartmentHandler) GetAvailableTimeSlots(c *gin.Context) {
 
 	availableSlots, err := h.bookingUseCase.GetAvailableTimeSlots(apartmentID, date, duration)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -3770,6 +3849,8 @@ func (h *ApartmentHandler) enrichApartmentWithLocationData(apartment *domain.Apa
 		"contract_id":            apartment.ContractID,
 		"view_count":             apartment.ViewCount,
 		"booking_count":          apartment.BookingCount,
+		"rating":                 apartment.Rating,
+		"reviews_count":          apartment.ReviewsCount,
 		"created_at":             apartment.CreatedAt,
 		"updated_at":             apartment.UpdatedAt,
 	}
@@ -3917,6 +3998,8 @@ func (h *ApartmentHandler) enrichApartmentWithLocationDataOptimized(
 		"contract_id":            apartment.ContractID,
 		"view_count":             apartment.ViewCount,
 		"booking_count":          apartment.BookingCount,
+		"rating":                 apartment.Rating,
+		"reviews_count":          apartment.ReviewsCount,
 		"created_at":             apartment.CreatedAt,
 		"updated_at":             apartment.UpdatedAt,
 	}
@@ -4177,7 +4260,9 @@ func (h *ApartmentHandler) calculateOwnerFinancialStatistics(bookings []*domain.
 			monthlyRevenue[month] += booking.FinalPrice
 
 			var durationCategory string
-			if booking.Duration <= 3 {
+			if booking.Nights > 0 {
+				durationCategory = "daily"
+			} else if booking.Duration <= 3 {
 				durationCategory = "short"
 			} else if booking.Duration <= 12 {
 				durationCategory = "medium"
@@ -4669,3 +4754,378 @@ func (h *ApartmentHandler) AdminResetCounters(c *gin.Context) {
 	enrichedApartment := h.enrichApartmentWithLocationData(updatedApartment)
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("счетчики сброшены", enrichedApartment))
 }
+
+// @Summary Политика отмены квартиры
+// @Description Возвращает действующую политику отмены квартиры (собственную или политику платформы по умолчанию)
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Param id path int true "ID квартиры"
+// @Success 200 {object} domain.SuccessResponse{data=domain.CancellationPolicy}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/cancellation-policy [get]
+func (h *ApartmentHandler) GetCancellationPolicy(c *gin.Context) {
+	apartmentID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	policy, err := h.cancellationUseCase.GetApartmentCancellationPolicy(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("политика отмены получена", policy))
+}
+
+// @Summary Изменение политики отмены квартиры
+// @Description Владелец выбирает политику отмены (flexible, moderate, strict). policy_code = null возвращает политику платформы по умолчанию
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param request body domain.UpdateApartmentCancellationPolicyRequest true "Код политики отмены"
+// @Success 200 {object} domain.SuccessResponse{data=domain.CancellationPolicy}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/cancellation-policy [put]
+func (h *ApartmentHandler) UpdateCancellationPolicy(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	apartmentID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request domain.UpdateApartmentCancellationPolicyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	user, err := h.userUseCase.GetByID(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при получении данных пользователя"))
+		return
+	}
+
+	apartment, err := h.apartmentUseCase.GetByID(apartmentID)
+	if err != nil || apartment == nil {
+		c.JSON(http.StatusNotFound, domain.NewErrorResponse("квартира не найдена"))
+		return
+	}
+
+	if !utils.CheckOwnerPermission(c, userID, user, apartment.OwnerID, h.ownerUseCase) {
+		return
+	}
+
+	if err := h.cancellationUseCase.SetApartmentCancellationPolicy(apartmentID, request.PolicyCode); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	policy, err := h.cancellationUseCase.GetApartmentCancellationPolicy(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("политика отмены обновлена", policy))
+}
+
+// @Summary Изменение параметров посуточного проживания
+// @Description Владелец задает время заезда и выезда (местное время) и минимальное/максимальное количество ночей
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param request body domain.UpdateApartmentStaySettingsRequest true "Параметры проживания"
+// @Success 200 {object} domain.SuccessResponse{data=domain.Apartment}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/stay-settings [put]
+func (h *ApartmentHandler) UpdateStaySettings(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	var request domain.UpdateApartmentStaySettingsRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	apartment, err := h.apartmentUseCase.UpdateStaySettings(apartmentID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("параметры проживания обновлены", apartment))
+}
+
+// @Summary Государственные праздники
+// @Description Возвращает государственные праздники Казахстана, в которые применяется праздничная надбавка
+// @Tags apartments
+// @Produce json
+// @Param year query int false "Год (по умолчанию текущий)"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PublicHoliday}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /apartments/public-holidays [get]
+func (h *ApartmentHandler) GetPublicHolidays(c *gin.Context) {
+	year := utils.ConvertOutputFromUTC(utils.GetCurrentTimeUTC()).Year()
+	if yearParam := c.Query("year"); yearParam != "" {
+		parsedYear, err := strconv.Atoi(yearParam)
+		if err != nil || parsedYear < 2000 || parsedYear > 2100 {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректный год"))
+			return
+		}
+		year = parsedYear
+	}
+
+	holidays, err := h.pricingUseCase.GetPublicHolidays(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения праздничных дней"))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("праздничные дни получены", holidays))
+}
+
+// @Summary Правила ценообразования квартиры
+// @Description Возвращает правила динамического ценообразования квартиры: периоды, дни недели, праздники и скидки за длительное проживание
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.PricingRule}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/pricing-rules [get]
+func (h *ApartmentHandler) GetPricingRules(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	rules, err := h.pricingUseCase.GetApartmentPricingRules(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("правила ценообразования получены", rules))
+}
+
+// @Summary Создание правила ценообразования
+// @Description Создает правило ценообразования. date_range - цена (hourly_price/daily_price) и/или процент на период; weekday - процент для дней недели (0 - воскресенье); holiday - процент в государственные праздники; length_of_stay - скидка (отрицательный процент) от min_days суток
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param request body domain.PricingRuleRequest true "Правило ценообразования"
+// @Success 201 {object} domain.SuccessResponse{data=domain.PricingRule}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/pricing-rules [post]
+func (h *ApartmentHandler) CreatePricingRule(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	var request domain.PricingRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	rule, err := h.pricingUseCase.CreatePricingRule(apartmentID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.NewSuccessResponse("правило ценообразования создано", rule))
+}
+
+// @Summary Изменение правила ценообразования
+// @Description Полностью заменяет параметры правила ценообразования квартиры
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param ruleId path int true "ID правила"
+// @Param request body domain.PricingRuleRequest true "Правило ценообразования"
+// @Success 200 {object} domain.SuccessResponse{data=domain.PricingRule}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/pricing-rules/{ruleId} [put]
+func (h *ApartmentHandler) UpdatePricingRule(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	ruleID, ok := utils.ParseIDParam(c, "ruleId")
+	if !ok {
+		return
+	}
+
+	var request domain.PricingRuleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	rule, err := h.pricingUseCase.UpdatePricingRule(apartmentID, ruleID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("правило ценообразования обновлено", rule))
+}
+
+// @Summary Удаление правила ценообразования
+// @Description Удаляет правило ценообразования квартиры. Уже созданные бронирования сохраняют свой расчет стоимости
+// @Tags apartments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID квартиры"
+// @Param ruleId path int true "ID правила"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /apartments/{id}/pricing-rules/{ruleId} [delete]
+func (h *ApartmentHandler) DeletePricingRule(c *gin.Context) {
+	apartmentID, ok := h.requireApartmentOwner(c)
+	if !ok {
+		return
+	}
+
+	ruleID, ok := utils.ParseIDParam(c, "ruleId")
+	if !ok {
+		return
+	}
+
+	if err := h.pricingUseCase.DeletePricingRule(apartmentID, ruleID); err != nil {
+		c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("правило ценообразования удалено", nil))
+}
+
+func (h *ApartmentHandler) requireApartmentOwner(c *gin.Context) (int, bool) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return 0, false
+	}
+
+	apartmentID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return 0, false
+	}
+
+	user, err := h.userUseCase.GetByID(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при получении данных пользователя"))
+		return 0, false
+	}
+
+	apartment, err := h.apartmentUseCase.GetByID(apartmentID)
+	if err != nil || apartment == nil {
+		c.JSON(http.StatusNotFound, domain.NewErrorResponse("квартира не найдена"))
+		return 0, false
+	}
+
+	if !utils.CheckOwnerPermission(c, userID, user, apartment.OwnerID, h.ownerUseCase) {
+		return 0, false
+	}
+
+	return apartmentID, true
+}
+
+const maxApartmentSearchLength = 200
+
+// addSearchQueryFilters переносит в фильтры поисковый запрос, удобства и правила проживания,
+// минимальный рейтинг и сортировку списка квартир.
+func addSearchQueryFilters(c *gin.Context, filters map[string]interface{}) {
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		if runes := []rune(search); len(runes) > maxApartmentSearchLength {
+			search = string(runes[:maxApartmentSearchLength])
+		}
+		filters["search"] = search
+	}
+
+	if amenityIDs := parseIDListQuery(c, "amenity_ids"); len(amenityIDs) > 0 {
+		filters["amenity_ids"] = amenityIDs
+	}
+
+	if houseRuleIDs := parseIDListQuery(c, "house_rule_ids"); len(houseRuleIDs) > 0 {
+		filters["house_rule_ids"] = houseRuleIDs
+	}
+
+	if minRating := c.Query("min_rating"); minRating != "" {
+		if rating, err := strconv.ParseFloat(minRating, 64); err == nil && rating > 0 && rating <= domain.MaxReviewRating {
+			filters["min_rating"] = rating
+		}
+	}
+
+	switch sortBy := c.Query("sort_by"); sortBy {
+	case domain.ApartmentSortNewest, domain.ApartmentSortRating, domain.ApartmentSortReviewsCount,
+		domain.ApartmentSortPriceAsc, domain.ApartmentSortPriceDesc, domain.ApartmentSortRelevance:
+		filters["sort_by"] = sortBy
+	case domain.ApartmentSortDistance:
+		filters["sort_by"] = sortBy
+		latitude, errLat := strconv.ParseFloat(c.Query("latitude"), 64)
+		longitude, errLng := strconv.ParseFloat(c.Query("longitude"), 64)
+		if errLat == nil && errLng == nil && latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180 {
+			filters["latitude"] = latitude
+			filters["longitude"] = longitude
+		}
+	}
+}
+
+// parseIDListQuery читает список ID как повторяющимся параметром (?ids=1&ids=2), так и через запятую (?ids=1,2).
+func parseIDListQuery(c *gin.Context, paramName string) []int {
+	var ids []int
+	seen := make(map[int]bool)
+
+	for _, value := range c.QueryArray(paramName) {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || id <= 0 || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -1,12 +1,14 @@
 package http
 
 import (
+	"log"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
 	"github.com/russo2642/renti_kz/pkg/auth"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 )
 
 type LoginResponse struct {
@@ -23,6 +25,7 @@ type AuthHandler struct {
 	renterRepo        domain.RenterRepository
 	propertyOwnerRepo domain.PropertyOwnerRepository
 	renterUseCase     domain.RenterUseCase
+	rateLimiter       domain.RateLimiter
 }
 
 func NewAuthHandler(
@@ -33,6 +36,7 @@ func NewAuthHandler(
 	renterRepo domain.RenterRepository,
 	propertyOwnerRepo domain.PropertyOwnerRepository,
 	renterUseCase domain.RenterUseCase,
+	rateLimiter domain.RateLimiter,
 ) *AuthHandler {
 	return &AuthHandler{
 		authUseCase:       authUseCase,
@@ -42,24 +46,111 @@ func NewAuthHandler(
 		renterRepo:        renterRepo,
 		propertyOwnerRepo: propertyOwnerRepo,
 		renterUseCase:     renterUseCase,
+		rateLimiter:       rateLimiter,
 	}
 }
 
-func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
+func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup, middleware *Middleware) {
 	auth := router.Group("/auth")
 	{
 		auth.POST("/register", h.Register)
-		auth.POST("/pre-register", h.PreRegister)
-		auth.POST("/complete-register", h.CompleteRegistration)
-		auth.POST("/login", h.Login)
-		auth.POST("/refresh", h.RefreshToken)
+		auth.POST("/pre-register",
+			middleware.RateLimit(domain.RateLimitOTPRequestByIP, RateLimitByIP),
+			middleware.RateLimit(domain.RateLimitOTPRequestByPhone, RateLimitByPhone),
+			h.PreRegister)
+		auth.POST("/complete-register",
+			middleware.RateLimit(domain.RateLimitOTPVerifyByIP, RateLimitByIP),
+			middleware.RateLimit(domain.RateLimitOTPVerifyByPhone, RateLimitByPhone),
+			h.CompleteRegistration)
+		auth.POST("/login",
+			middleware.RateLimit(domain.RateLimitLoginByIP, RateLimitByIP),
+			middleware.RateLimit(domain.RateLimitLoginByPhone, RateLimitByPhone),
+			h.Login)
+		auth.POST("/refresh", middleware.RateLimit(domain.RateLimitRefreshTokenByIP, RateLimitByIP), h.RefreshToken)
 		auth.POST("/logout", h.Logout)
-		auth.GET("/check-phone/:phone", h.CheckPhoneExists)
-
-		auth.POST("/otp/request", h.RequestOTP)
-		auth.POST("/otp/verify", h.VerifyOTP)
+		auth.GET("/check-phone/:phone", middleware.RateLimit(domain.RateLimitCheckPhoneByIP, RateLimitByIP), h.CheckPhoneExists)
+
+		auth.POST("/otp/request",
+			middleware.RateLimit(domain.RateLimitOTPRequestByIP, RateLimitByIP),
+			middleware.RateLimit(domain.RateLimitOTPRequestByPhone, RateLimitByPhone),
+			h.RequestOTP)
+		auth.POST("/otp/verify",
+			middleware.RateLimit(domain.RateLimitOTPVerifyByIP, RateLimitByIP),
+			middleware.RateLimit(domain.RateLimitOTPVerifyByPhone, RateLimitByPhone),
+			h.VerifyOTP)
 		auth.GET("/otp/status/:id", h.CheckOTPStatus)
 	}
+
+	sessions := auth.Group("/sessions")
+	sessions.Use(middleware.AuthMiddleware())
+	{
+		sessions.GET("", h.GetSessions)
+		sessions.DELETE("", h.RevokeAllSessions)
+		sessions.DELETE("/:sessionId", h.RevokeSession)
+	}
+}
+
+// lockoutKey ключ блокировки перебора: телефон вместе с IP клиента. Телефон передает сам клиент,
+// и блокировка только по нему позволила бы любому закрыть вход чужому номеру с другого адреса.
+// Перебор одного номера с разных адресов ограничивает лимит попыток на телефон (см. RegisterRoutes).
+func lockoutKey(c *gin.Context, phone string) string {
+	return utils.NormalizePhone(phone) + "|" + c.ClientIP()
+}
+
+// lockedOut отвечает 429, если попытки для телефона с этого IP заблокированы после серии неудач.
+func (h *AuthHandler) lockedOut(c *gin.Context, scope domain.LockoutScope, phone string, definition *apperrors.Definition) bool {
+	if h.rateLimiter == nil {
+		return false
+	}
+
+	lockedFor, err := h.rateLimiter.LockedFor(scope, lockoutKey(c, phone))
+	if err != nil {
+		log.Printf("⚠️ Не удалось проверить блокировку %s: %v", scope, err)
+		return false
+	}
+	if lockedFor <= 0 {
+		return false
+	}
+
+	respondLocked(c, definition, lockedFor)
+	return true
+}
+
+// registerFailure учитывает неудачную попытку. Если она привела к блокировке, сразу отвечает 429.
+func (h *AuthHandler) registerFailure(c *gin.Context, scope domain.LockoutScope, phone string, definition *apperrors.Definition) bool {
+	if h.rateLimiter == nil {
+		return false
+	}
+
+	lockedFor, err := h.rateLimiter.RegisterFailure(scope, lockoutKey(c, phone))
+	if err != nil {
+		log.Printf("⚠️ Не удалось учесть неудачную попытку %s: %v", scope, err)
+		return false
+	}
+	if lockedFor <= 0 {
+		return false
+	}
+
+	respondLocked(c, definition, lockedFor)
+	return true
+}
+
+func (h *AuthHandler) resetFailures(c *gin.Context, scope domain.LockoutScope, phone string) {
+	if h.rateLimiter == nil {
+		return
+	}
+
+	if err := h.rateLimiter.ResetFailures(scope, lockoutKey(c, phone)); err != nil {
+		log.Printf("⚠️ Не удалось сбросить счетчик попыток %s: %v", scope, err)
+	}
+}
+
+// sessionClient данные клиента для новой или обновляемой сессии.
+func sessionClient(c *gin.Context) domain.SessionClient {
+	return domain.SessionClient{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
 }
 
 // @Summary Проверка существования телефона
@@ -70,6 +161,7 @@ func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
 // @Param phone path string true "Номер телефона"
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 400 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /auth/check-phone/{phone} [get]
 func (h *AuthHandler) CheckPhoneExists(c *gin.Context) {
@@ -104,6 +196,7 @@ func (h *AuthHandler) CheckPhoneExists(c *gin.Context) {
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 409 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /auth/pre-register [post]
 func (h *AuthHandler) PreRegister(c *gin.Context) {
@@ -147,6 +240,7 @@ func (h *AuthHandler) PreRegister(c *gin.Context) {
 // @Success 201 {object} LoginResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 409 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /auth/complete-register [post]
 func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
@@ -157,6 +251,10 @@ func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
 		return
 	}
 
+	if h.lockedOut(c, domain.LockoutScopeOTPVerify, req.Phone, apperrors.RateLimitOTPLocked) {
+		return
+	}
+
 	isValid, err := h.otpUseCase.VerifyOTP(req.Phone, req.OTPCode)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при проверке OTP"))
@@ -164,24 +262,29 @@ func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
 	}
 
 	if !isValid {
+		if h.registerFailure(c, domain.LockoutScopeOTPVerify, req.Phone, apperrors.RateLimitOTPLocked) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("неверный OTP код"))
 		return
 	}
+	h.resetFailures(c, domain.LockoutScopeOTPVerify, req.Phone)
 
 	user := &domain.User{
-		Phone:     req.Phone,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Email:     req.Email,
-		CityID:    req.CityID,
-		IIN:       req.IIN,
-		Role:      domain.RoleUser,
+		Phone:             req.Phone,
+		FirstName:         req.FirstName,
+		LastName:          req.LastName,
+		Email:             req.Email,
+		CityID:            req.CityID,
+		IIN:               req.IIN,
+		Role:              domain.RoleUser,
+		PreferredLanguage: string(utils.GetLanguage(c)),
 	}
 
 	err = h.userUseCase.RegisterWithoutPassword(user)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			c.JSON(http.StatusConflict, domain.NewErrorResponse(err.Error()))
+		if _, ok := apperrors.AsAppError(err); ok {
+			RespondWithErrorStatus(c, http.StatusBadRequest, err)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при регистрации пользователя"))
@@ -194,15 +297,15 @@ func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.tokenManager.GenerateTokenPair(registeredUser.ID, string(registeredUser.Role))
+	tokens, err := h.authUseCase.IssueTokens(registeredUser, sessionClient(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("пользователь зарегистрирован, но не удалось создать токены"))
 		return
 	}
 
 	response := LoginResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
 		User:         *registeredUser,
 	}
 
@@ -229,19 +332,20 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	user := &domain.User{
-		Phone:     req.Phone,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Email:     req.Email,
-		CityID:    req.CityID,
-		IIN:       req.IIN,
-		Role:      domain.RoleUser,
+		Phone:             req.Phone,
+		FirstName:         req.FirstName,
+		LastName:          req.LastName,
+		Email:             req.Email,
+		CityID:            req.CityID,
+		IIN:               req.IIN,
+		Role:              domain.RoleUser,
+		PreferredLanguage: string(utils.GetLanguage(c)),
 	}
 
 	err := h.userUseCase.RegisterWithoutPassword(user)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			c.JSON(http.StatusConflict, domain.NewErrorResponse(err.Error()))
+		if _, ok := apperrors.AsAppError(err); ok {
+			RespondWithErrorStatus(c, http.StatusBadRequest, err)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при регистрации пользователя"))
@@ -266,6 +370,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} LoginResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 401 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -276,16 +381,23 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	tokens, user, err := h.authUseCase.SignIn(req.Phone, req.Password)
-	if err != nil {
+	if h.lockedOut(c, domain.LockoutScopeLogin, req.Phone, apperrors.RateLimitLoginLocked) {
+		return
+	}
 
-		if strings.Contains(err.Error(), "invalid credentials") || strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusUnauthorized, domain.NewErrorResponse("неверные учетные данные"))
+	tokens, user, err := h.authUseCase.SignIn(req.Phone, req.Password, sessionClient(c))
+	if err != nil {
+		if apperrors.AuthInvalidCredentials.Is(err) {
+			if h.registerFailure(c, domain.LockoutScopeLogin, req.Phone, apperrors.RateLimitLoginLocked) {
+				return
+			}
+			RespondWithErrorStatus(c, http.StatusUnauthorized, err)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при входе в систему"))
 		return
 	}
+	h.resetFailures(c, domain.LockoutScopeLogin, req.Phone)
 
 	response := LoginResponse{
 		AccessToken:  tokens.AccessToken,
@@ -306,7 +418,7 @@ type RefreshTokenResponse struct {
 }
 
 // @Summary Обновление токенов
-// @Description Обновляет access и refresh токены
+// @Description Обновляет access и refresh токены. Refresh-токен одноразовый: повторное предъявление уже обмененного токена завершает сессию
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -314,6 +426,7 @@ type RefreshTokenResponse struct {
 // @Success 200 {object} RefreshTokenResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 401 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
@@ -324,11 +437,10 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	tokens, err := h.authUseCase.RefreshTokens(req.RefreshToken)
+	tokens, err := h.authUseCase.RefreshTokens(req.RefreshToken, sessionClient(c))
 	if err != nil {
-
-		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "expired") {
-			c.JSON(http.StatusUnauthorized, domain.NewErrorResponse("недействительный или истекший токен"))
+		if _, ok := apperrors.AsAppError(err); ok {
+			RespondWithErrorStatus(c, http.StatusUnauthorized, err)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при обновлении токена"))
@@ -348,7 +460,7 @@ type LogoutRequest struct {
 }
 
 // @Summary Выход из системы
-// @Description Аннулирует токен пользователя
+// @Description Завершает сессию, к которой относится токен
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -396,6 +508,7 @@ func isNumeric(s string) bool {
 // @Param request body domain.OTPRequest true "Номер телефона для OTP"
 // @Success 200 {object} domain.OTPRequestResponse
 // @Failure 400 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /auth/otp/request [post]
 func (h *AuthHandler) RequestOTP(c *gin.Context) {
@@ -431,6 +544,7 @@ func (h *AuthHandler) RequestOTP(c *gin.Context) {
 // @Failure 404 {object} domain.ErrorResponse "Сессия OTP не найдена"
 // @Failure 409 {object} domain.ErrorResponse "OTP код уже был использован"
 // @Failure 410 {object} domain.ErrorResponse "Время действия OTP кода истекло"
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse "Внутренняя ошибка сервера"
 // @Router /auth/otp/verify [post]
 func (h *AuthHandler) VerifyOTP(c *gin.Context) {
@@ -451,12 +565,24 @@ func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 		return
 	}
 
-	response, err := h.otpUseCase.VerifyOTPAndAuthenticate(req.ID, req.Phone, req.Code)
+	if h.lockedOut(c, domain.LockoutScopeOTPVerify, req.Phone, apperrors.RateLimitOTPLocked) {
+		return
+	}
+
+	response, err := h.otpUseCase.VerifyOTPAndAuthenticate(req.ID, req.Phone, req.Code, sessionClient(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при проверке OTP кода"))
 		return
 	}
 
+	if response.ErrorType == domain.OTPErrorInvalidCode {
+		if h.registerFailure(c, domain.LockoutScopeOTPVerify, req.Phone, apperrors.RateLimitOTPLocked) {
+			return
+		}
+	} else if response.ErrorType == domain.OTPErrorNone {
+		h.resetFailures(c, domain.LockoutScopeOTPVerify, req.Phone)
+	}
+
 	if response.RequiresRegistration {
 		c.JSON(http.StatusOK, domain.NewSuccessResponse(response.Message, gin.H{
 			"requires_registration": true,
@@ -520,3 +646,86 @@ func (h *AuthHandler) CheckOTPStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("статус получен", response))
 }
+
+// @Summary Активные сессии
+// @Description Возвращает активные сессии текущего пользователя: устройство, IP-адрес и время последнего использования. Текущая сессия отмечена is_current
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.Session}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/sessions [get]
+func (h *AuthHandler) GetSessions(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, domain.NewErrorResponse("пользователь не авторизован"))
+		return
+	}
+
+	sessions, err := h.authUseCase.GetUserSessions(userID, c.GetString("session_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при получении сессий"))
+		return
+	}
+
+	if sessions == nil {
+		sessions = []*domain.Session{}
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("сессии получены", sessions))
+}
+
+// @Summary Завершение сессии
+// @Description Завершает одну из сессий текущего пользователя. Выданные по ней токены перестают действовать сразу
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "ID сессии"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/sessions/{sessionId} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, domain.NewErrorResponse("пользователь не авторизован"))
+		return
+	}
+
+	if err := h.authUseCase.RevokeUserSession(userID, c.Param("sessionId")); err != nil {
+		if _, ok := apperrors.AsAppError(err); ok {
+			RespondWithErrorStatus(c, http.StatusNotFound, err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при завершении сессии"))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("сессия завершена", nil))
+}
+
+// @Summary Выход на всех устройствах
+// @Description Завершает все сессии текущего пользователя, включая текущую
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /auth/sessions [delete]
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, domain.NewErrorResponse("пользователь не авторизован"))
+		return
+	}
+
+	if err := h.authUseCase.SignOutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при завершении сессий"))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("все сессии завершены", nil))
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+)
+
+const (
+	testLockoutThreshold = 3
+	testLoginPhone       = "77010000001"
+	testLoginPassword    = "correct-password"
+)
+
+// memoryRateLimiter пропускает по правилу столько запросов, сколько задано в domain.DefaultRateLimits,
+// и блокирует ключ после testLockoutThreshold неудач подряд.
+type memoryRateLimiter struct {
+	requests map[string]int
+	failures map[string]int
+	locked   map[string]bool
+}
+
+func newMemoryRateLimiter() *memoryRateLimiter {
+	return &memoryRateLimiter{
+		requests: make(map[string]int),
+		failures: make(map[string]int),
+		locked:   make(map[string]bool),
+	}
+}
+
+func (l *memoryRateLimiter) Allow(policy domain.RateLimitPolicy, key string) (*domain.RateLimitDecision, error) {
+	limit := domain.DefaultRateLimits[policy]
+	key = string(policy) + ":" + key
+	l.requests[key]++
+
+	decision := &domain.RateLimitDecision{Allowed: true, Limit: limit.Requests, Remaining: limit.Requests - l.requests[key]}
+	if l.requests[key] > limit.Requests {
+		decision.Allowed = false
+		decision.Remaining = 0
+		decision.RetryAfter = limit.Period
+	}
+	return decision, nil
+}
+
+func (l *memoryRateLimiter) LockedFor(scope domain.LockoutScope, key string) (time.Duration, error) {
+	if l.locked[string(scope)+":"+key] {
+		return time.Minute, nil
+	}
+	return 0, nil
+}
+
+func (l *memoryRateLimiter) RegisterFailure(scope domain.LockoutScope, key string) (time.Duration, error) {
+	key = string(scope) + ":" + key
+	l.failures[key]++
+	if l.failures[key] < testLockoutThreshold {
+		return 0, nil
+	}
+	l.locked[key] = true
+	return time.Minute, nil
+}
+
+func (l *memoryRateLimiter) ResetFailures(scope domain.LockoutScope, key string) error {
+	delete(l.failures, string(scope)+":"+key)
+	return nil
+}
+
+type loginAuthUseCaseStub struct {
+	domain.AuthUseCase
+}
+
+func (uc *loginAuthUseCaseStub) SignIn(phone, password string, client domain.SessionClient) (*domain.Tokens, *domain.User, error) {
+	if utils.NormalizePhone(phone) != testLoginPhone || password != testLoginPassword {
+		return nil, nil, apperrors.AuthInvalidCredentials.New(nil)
+	}
+	return &domain.Tokens{AccessToken: "access", RefreshToken: "refresh"}, &domain.User{ID: 1, Phone: phone}, nil
+}
+
+func newTestLoginRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	rateLimiter := newMemoryRateLimiter()
+	handler := NewAuthHandler(&loginAuthUseCaseStub{}, nil, nil, nil, nil, nil, nil, rateLimiter)
+	router := gin.New()
+	handler.RegisterRoutes(router.Group(""), NewMiddleware(nil, nil, nil, rateLimiter))
+
+	return router
+}
+
+func login(t *testing.T, router *gin.Engine, remoteAddr, password string) int {
+	t.Helper()
+
+	return loginAs(t, router, remoteAddr, testLoginPhone, password)
+}
+
+func loginAs(t *testing.T, router *gin.Engine, remoteAddr, phone, password string) int {
+	t.Helper()
+
+	body, err := json.Marshal(domain.LoginRequest{Phone: phone, Password: password})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	request.RemoteAddr = remoteAddr
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	return recorder.Code
+}
+
+func TestLoginFailuresFromAnotherIPDoNotLockOutUser(t *testing.T) {
+	router := newTestLoginRouter()
+	attacker := "203.0.113.7:40000"
+	victim := "198.51.100.20:50000"
+
+	for i := 0; i < testLockoutThreshold+2; i++ {
+		login(t, router, attacker, "wrong-password")
+	}
+	if status := login(t, router, attacker, "wrong-password"); status != http.StatusTooManyRequests {
+		t.Fatalf("attacker: expected 429 after repeated failures, got %d", status)
+	}
+
+	if status := login(t, router, victim, testLoginPassword); status != http.StatusOK {
+		t.Fatalf("victim: expected 200, got %d", status)
+	}
+}
+
+func TestLoginLocksOutAfterRepeatedFailuresFromSameIP(t *testing.T) {
+	router := newTestLoginRouter()
+	client := "198.51.100.20:50000"
+
+	for i := 0; i < testLockoutThreshold-1; i++ {
+		if status := login(t, router, client, "wrong-password"); status != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, status)
+		}
+	}
+	if status := login(t, router, client, "wrong-password"); status != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the threshold is reached, got %d", status)
+	}
+	if status := login(t, router, client, testLoginPassword); status != http.StatusTooManyRequests {
+		t.Fatalf("expected lockout to hold for the correct password, got %d", status)
+	}
+}
+
+func TestLoginLimitsFailuresForOnePhoneAcrossIPs(t *testing.T) {
+	router := newTestLoginRouter()
+	phoneLimit := domain.DefaultRateLimits[domain.RateLimitLoginByPhone].Requests
+
+	// Каждый адрес остается ниже порога блокировки, но попытки на номер считаются вместе,
+	// в том числе записанные через 8 вместо 7
+	phones := []string{testLoginPhone, "8" + testLoginPhone[1:]}
+	for i := 0; i < phoneLimit; i++ {
+		remoteAddr := fmt.Sprintf("203.0.113.%d:40000", i+1)
+		if status := loginAs(t, router, remoteAddr, phones[i%len(phones)], "wrong-password"); status != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, status)
+		}
+	}
+
+	if status := loginAs(t, router, "203.0.113.200:40000", phones[1], "wrong-password"); status != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the per-phone limit is spent, got %d", status)
+	}
+}
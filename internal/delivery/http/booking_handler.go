@@ -1,15 +1,16 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/services"
 	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 )
 
 type BookingHandler struct {
@@ -28,21 +29,23 @@ func NewBookingHandler(bookingUseCase domain.BookingUseCase, userUseCase domain.
 	}
 }
 
-func (h *BookingHandler) RegisterRoutes(router *gin.RouterGroup) {
+func (h *BookingHandler) RegisterRoutes(router *gin.RouterGroup, middleware *Middleware) {
 
 	bookings := router.Group("/bookings")
 	bookings.Use()
 	{
 
-		bookings.POST("", h.CreateBooking)
+		bookings.POST("", middleware.RateLimit(domain.RateLimitBookingCreateByUser, RateLimitByUserID), h.CreateBooking)
 		bookings.POST("/:id/confirm", h.ConfirmBooking)
 		bookings.POST("/:id/payment", h.ProcessPayment)
+		bookings.POST("/:id/payment/init", h.InitBookingPayment)
 		bookings.GET("/:id", h.GetBookingByID)
 		bookings.GET("/number/:number", h.GetBookingByNumber)
 
 		bookings.POST("/:id/approve", h.ApproveBooking)
 		bookings.POST("/:id/reject", h.RejectBooking)
 		bookings.POST("/:id/cancel", h.CancelBooking)
+		bookings.GET("/:id/cancellation-quote", h.GetCancellationQuote)
 		bookings.GET("/:id/receipt", h.GetPaymentReceipt)
 		bookings.POST("/:id/finish", h.FinishSession)
 		bookings.POST("/:id/extend", h.ExtendBooking)
@@ -50,6 +53,7 @@ func (h *BookingHandler) RegisterRoutes(router *gin.RouterGroup) {
 		bookings.GET("/:id/extensions", h.GetBookingExtensions)
 		bookings.GET("/:id/available-extensions", h.GetAvailableExtensions)
 		bookings.POST("/:id/extensions/:extensionId/payment", h.ProcessExtensionPayment)
+		bookings.POST("/:id/extensions/:extensionId/payment/init", h.InitExtensionPayment)
 		bookings.POST("/:id/extensions/:extensionId/approve", h.ApproveExtension)
 		bookings.POST("/:id/extensions/:extensionId/reject", h.RejectExtension)
 
@@ -79,6 +83,7 @@ func (h *BookingHandler) RegisterAdminRoutes(router *gin.RouterGroup) {
 // @Success 201 {object} domain.SuccessResponse
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 401 {object} domain.ErrorResponse
+// @Failure 429 {object} domain.ErrorResponse "Слишком много запросов, см. заголовок Retry-After"
 // @Failure 500 {object} domain.ErrorResponse
 // @Router /bookings [post]
 func (h *BookingHandler) CreateBooking(c *gin.Context) {
@@ -95,13 +100,13 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 	}
 
 	if err := utils.ValidateCreateBookingRequest(&request); err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
 	booking, err := h.bookingUseCase.CreateBooking(userID, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -143,7 +148,7 @@ func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
 
 	booking, err := h.bookingUseCase.ConfirmBooking(bookingID, userID, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -196,11 +201,7 @@ func (h *BookingHandler) ProcessPayment(c *gin.Context) {
 	}
 
 	if err != nil {
-		if strings.Contains(err.Error(), "уже использован") || strings.Contains(err.Error(), "уже привязан") {
-			c.JSON(http.StatusConflict, domain.NewErrorResponse(err.Error()))
-			return
-		}
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -209,6 +210,36 @@ func (h *BookingHandler) ProcessPayment(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("оплата успешно обработана", response))
 }
 
+// @Summary Инициализировать оплату бронирования
+// @Description Создает платеж в FreedomPay для бронирования со статусом awaiting_payment и возвращает ссылку на платежную страницу. Сумма берется из бронирования
+// @Tags bookings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID бронирования"
+// @Success 200 {object} domain.SuccessResponse{data=domain.InitPaymentResponse}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /bookings/{id}/payment/init [post]
+func (h *BookingHandler) InitBookingPayment(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	response, err := h.bookingUseCase.InitBookingPayment(bookingID, userID)
+	if err != nil {
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("платеж инициализирован", response))
+}
+
 // @Summary Получить бронирование по ID
 // @Description Получает информацию о бронировании по его идентификатору
 // @Tags bookings
@@ -330,7 +361,7 @@ func (h *BookingHandler) ApproveBooking(c *gin.Context) {
 
 	err := h.bookingUseCase.ApproveBooking(bookingID, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -371,7 +402,7 @@ func (h *BookingHandler) RejectBooking(c *gin.Context) {
 
 	err := h.bookingUseCase.RejectBooking(bookingID, userID, request.Comment)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -379,7 +410,7 @@ func (h *BookingHandler) RejectBooking(c *gin.Context) {
 }
 
 // @Summary Отменить бронирование
-// @Description Отменяет бронирование (для арендатора). Возврат платежа зависит от статуса бронирования и времени до начала: если бронь approved/pending и до начала менее 6 часов - деньги не возвращаются
+// @Description Отменяет бронирование (для арендатора). Сумма возврата рассчитывается по политике отмены квартиры в зависимости от времени до начала (см. /bookings/{id}/cancellation-quote)
 // @Tags bookings
 // @Accept json
 // @Produce json
@@ -412,13 +443,43 @@ func (h *BookingHandler) CancelBooking(c *gin.Context) {
 
 	err := h.bookingUseCase.CancelBooking(bookingID, userID, request.Reason)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("бронирование отменено", nil))
 }
 
+// @Summary Предварительный расчет возврата при отмене
+// @Description Показывает, какая сумма будет возвращена при отмене бронирования прямо сейчас, по политике отмены квартиры
+// @Tags bookings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID бронирования"
+// @Success 200 {object} domain.SuccessResponse{data=domain.CancellationQuote}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /bookings/{id}/cancellation-quote [get]
+func (h *BookingHandler) GetCancellationQuote(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	quote, err := h.bookingUseCase.GetCancellationQuote(bookingID, userID)
+	if err != nil {
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("расчет возврата получен", quote))
+}
+
 // @Summary Получить чек об оплате
 // @Description Возвращает чек об оплате бронирования в JSON формате
 // @Tags bookings
@@ -445,20 +506,10 @@ func (h *BookingHandler) GetPaymentReceipt(c *gin.Context) {
 
 	receipt, err := h.bookingUseCase.GetPaymentReceipt(bookingID, userID)
 	if err != nil {
-		errorMsg := err.Error()
-
-		if strings.Contains(errorMsg, "не найдено") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(errorMsg))
-		} else if strings.Contains(errorMsg, "отказано в доступе") ||
-			strings.Contains(errorMsg, "недостаточно прав") ||
-			strings.Contains(errorMsg, "не являетесь владельцем") {
-			c.JSON(http.StatusForbidden, domain.NewErrorResponse(errorMsg))
-		} else if strings.Contains(errorMsg, "пользователь не найден") {
-			c.JSON(http.StatusUnauthorized, domain.NewErrorResponse(errorMsg))
-		} else if strings.Contains(errorMsg, "платеж") {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(errorMsg))
+		if _, ok := apperrors.AsAppError(err); ok {
+			RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения чека: "+errorMsg))
+			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения чека: "+err.Error()))
 		}
 		return
 	}
@@ -509,7 +560,7 @@ func (h *BookingHandler) ExtendBooking(c *gin.Context) {
 
 	err = h.bookingUseCase.RequestExtension(bookingID, userIDInt, &request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -585,17 +636,50 @@ func (h *BookingHandler) ProcessExtensionPayment(c *gin.Context) {
 	}
 
 	if err != nil {
-		if strings.Contains(err.Error(), "уже использован") || strings.Contains(err.Error(), "уже привязан") {
-			c.JSON(http.StatusConflict, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("оплата продления обработана", extension))
 }
 
+// InitExtensionPayment инициализирует оплату продления
+// @Summary Инициализировать оплату продления
+// @Description Создает платеж в FreedomPay для продления со статусом awaiting_payment и возвращает ссылку на платежную страницу
+// @Tags bookings
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID бронирования"
+// @Param extensionId path int true "ID продления"
+// @Success 200 {object} domain.SuccessResponse{data=domain.InitPaymentResponse}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /bookings/{id}/extensions/{extensionId}/payment/init [post]
+func (h *BookingHandler) InitExtensionPayment(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	extensionID, ok := utils.ParseIDParam(c, "extensionId")
+	if !ok {
+		return
+	}
+
+	response, err := h.bookingUseCase.InitExtensionPayment(bookingID, extensionID, userID)
+	if err != nil {
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("платеж продления инициализирован", response))
+}
+
 // GetBookingExtensions получает продления бронирования
 // @Summary Получить продления бронирования
 // @Description Получает список продлений для указанного бронирования
@@ -627,7 +711,7 @@ func (h *BookingHandler) GetBookingExtensions(c *gin.Context) {
 
 	canAccess, err := h.bookingUseCase.CanUserAccessBooking(bookingID, userIDInt)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
 		return
 	}
 	if !canAccess {
@@ -637,7 +721,7 @@ func (h *BookingHandler) GetBookingExtensions(c *gin.Context) {
 
 	extensions, err := h.bookingUseCase.GetBookingExtensions(bookingID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -674,7 +758,7 @@ func (h *BookingHandler) GetAvailableExtensions(c *gin.Context) {
 
 	availableExtensions, err := h.bookingUseCase.GetAvailableExtensions(bookingID, userIDInt)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -712,7 +796,7 @@ func (h *BookingHandler) ApproveExtension(c *gin.Context) {
 
 	err = h.bookingUseCase.ApproveExtension(extensionID, userIDInt)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -750,7 +834,7 @@ func (h *BookingHandler) RejectExtension(c *gin.Context) {
 
 	err = h.bookingUseCase.RejectExtension(extensionID, userIDInt)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -784,7 +868,7 @@ func (h *BookingHandler) FinishSession(c *gin.Context) {
 
 	err := h.bookingUseCase.FinishSession(bookingID, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -950,13 +1034,7 @@ func (h *BookingHandler) AdminUpdateBookingStatus(c *gin.Context) {
 	status := domain.BookingStatus(req.Status)
 	err := h.bookingUseCase.AdminUpdateBookingStatus(bookingID, status, req.Reason, adminID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse("бронирование не найдено"))
-		} else if strings.Contains(err.Error(), "only admins") {
-			c.JSON(http.StatusForbidden, domain.NewErrorResponse("недостаточно прав"))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка изменения статуса: "+err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, fmt.Errorf("ошибка изменения статуса: %w", err))
 		return
 	}
 
@@ -1000,17 +1078,7 @@ func (h *BookingHandler) AdminCancelBooking(c *gin.Context) {
 
 	err := h.bookingUseCase.AdminCancelBooking(bookingID, reason, adminID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse("бронирование не найдено"))
-		} else if strings.Contains(err.Error(), "only admins") {
-			c.JSON(http.StatusForbidden, domain.NewErrorResponse("недостаточно прав"))
-		} else if strings.Contains(err.Error(), "already canceled") {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("бронирование уже отменено"))
-		} else if strings.Contains(err.Error(), "cannot cancel completed") {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("нельзя отменить завершенное бронирование"))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка отмены бронирования: "+err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, fmt.Errorf("ошибка отмены бронирования: %w", err))
 		return
 	}
 
@@ -1131,7 +1199,7 @@ func (h *BookingHandler) GetMyBookingsLockAccess(c *gin.Context) {
 
 	response, err := h.bookingUseCase.GetMyBookingsLockAccess(userID.(int))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -1166,7 +1234,7 @@ func (h *BookingHandler) GetBookingLockAccess(c *gin.Context) {
 
 	response, err := h.bookingUseCase.GetBookingLockAccess(bookingID, userID.(int))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -1174,7 +1242,7 @@ func (h *BookingHandler) GetBookingLockAccess(c *gin.Context) {
 }
 
 // @Summary Генерация пароля для бронирования
-// @Description Генерирует временный пароль для замка квартиры по ID бронирования
+// @Description Генерирует временный пароль для замка квартиры по ID бронирования или возвращает действующий. Выдача пароля записывается в журнал раскрытий
 // @Tags bookings
 // @Accept json
 // @Produce json
@@ -1199,9 +1267,9 @@ func (h *BookingHandler) GeneratePasswordForBooking(c *gin.Context) {
 		return
 	}
 
-	password, err := h.lockUseCase.GeneratePasswordForBookingByID(bookingID, userID.(int))
+	password, err := h.lockUseCase.GeneratePasswordForBookingByID(bookingID, codeRevealActor(c, userID.(int)))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -24,6 +24,7 @@ func (h *CancellationRuleHandler) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		cancellationRules.GET("", h.GetCancellationRules)
 		cancellationRules.GET("/type/:type", h.GetCancellationRulesByType)
+		cancellationRules.GET("/policies", h.GetCancellationPolicies)
 	}
 }
 
@@ -74,3 +75,21 @@ func (h *CancellationRuleHandler) GetCancellationRulesByType(c *gin.Context) {
 
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("правила отмены получены", rules))
 }
+
+// @Summary Получение политик отмены
+// @Description Получает список доступных политик отмены (flexible, moderate, strict) со ступенями возврата
+// @Tags cancellation-rules
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.CancellationPolicy}
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cancellation-rules/policies [get]
+func (h *CancellationRuleHandler) GetCancellationPolicies(c *gin.Context) {
+	policies, err := h.cancellationRuleUseCase.GetCancellationPolicies()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения политик отмены: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("политики отмены получены", policies))
+}
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"errors"
+	"io"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +37,7 @@ func (h *ChatHandler) RegisterRoutes(router *gin.RouterGroup) {
 
 		chat.POST("/rooms/:roomId/messages", h.SendMessage)
 		chat.GET("/rooms/:roomId/messages", h.GetMessages)
+		chat.POST("/rooms/:roomId/attachments", h.UploadAttachment)
 		chat.PUT("/messages/:messageId", h.UpdateMessage)
 		chat.DELETE("/messages/:messageId", h.DeleteMessage)
 
@@ -213,14 +217,100 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 	c.JSON(201, domain.NewSuccessResponse("Message sent successfully", message))
 }
 
+// @Summary Отправка вложения
+// @Description Загружает фото (jpeg, png, gif, webp, до 10 MB) или PDF-файл (до 20 MB) и отправляет его сообщением в комнату чата. Для изображений строится превью
+// @Tags chat
+// @Accept multipart/form-data
+// @Produce json
+// @Param roomId path integer true "ID комнаты чата"
+// @Param file formData file true "Файл вложения"
+// @Param caption formData string false "Подпись к вложению"
+// @Param reply_to_id formData integer false "ID сообщения, на которое дается ответ"
+// @Success 201 {object} domain.SuccessResponse{data=domain.ChatMessage} "Вложение отправлено"
+// @Failure 400 {object} domain.ErrorResponse "Неверные данные запроса"
+// @Failure 401 {object} domain.ErrorResponse "Не авторизован"
+// @Failure 413 {object} domain.ErrorResponse "Файл слишком большой"
+// @Security ApiKeyAuth
+// @Router /chat/rooms/{roomId}/attachments [post]
+func (h *ChatHandler) UploadAttachment(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	roomID, ok := utils.ParseIDParam(c, "roomId")
+	if !ok {
+		return
+	}
+
+	// Запас на подпись и служебные поля multipart-формы
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, domain.ChatFileMaxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(413, domain.NewErrorResponse("Attachment is too large"))
+			return
+		}
+		c.JSON(400, domain.NewErrorResponse("File is required"))
+		return
+	}
+
+	if fileHeader.Size > domain.ChatFileMaxSize {
+		c.JSON(413, domain.NewErrorResponse("Attachment is too large"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(400, domain.NewErrorResponse("Failed to read file"))
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(400, domain.NewErrorResponse("Failed to read file"))
+		return
+	}
+
+	upload := &domain.ChatAttachmentUpload{
+		FileName: fileHeader.Filename,
+		Data:     data,
+		Caption:  c.PostForm("caption"),
+	}
+
+	if replyTo := c.PostForm("reply_to_id"); replyTo != "" {
+		replyToID, err := strconv.Atoi(replyTo)
+		if err != nil || replyToID <= 0 {
+			c.JSON(400, domain.NewErrorResponse("Invalid reply_to_id"))
+			return
+		}
+		upload.ReplyToID = &replyToID
+	}
+
+	message, err := h.chatUseCase.SendAttachment(roomID, upload, userID)
+	if err != nil {
+		c.JSON(400, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(201, domain.NewSuccessResponse("Attachment sent successfully", message))
+}
+
 // @Summary Получение сообщений
-// @Description Получает список сообщений из указанной комнаты чата с пагинацией
+// @Description Получает список сообщений из указанной комнаты чата. С параметрами before/after работает курсорная пагинация по ID сообщения (ответ содержит cursor вместо pagination), иначе - постраничная
 // @Tags chat
 // @Produce json
 // @Param roomId path integer true "ID комнаты чата"
+// @Param before query integer false "Вернуть сообщения старше сообщения с этим ID"
+// @Param after query integer false "Вернуть сообщения новее сообщения с этим ID"
+// @Param limit query integer false "Количество сообщений для курсорной пагинации (максимум 100)" default(50)
 // @Param page query integer false "Номер страницы" default(1)
 // @Param page_size query integer false "Размер страницы" default(50)
-// @Success 200 {object} object{success=boolean,data=[]domain.ChatMessage,pagination=object} "Список сообщений"
+// @Success 200 {object} object{success=boolean,data=[]domain.ChatMessage,pagination=object,cursor=object{has_more=boolean,first_id=integer,last_id=integer}} "Список сообщений"
+// @Failure 400 {object} domain.ErrorResponse "Некорректный курсор"
 // @Failure 401 {object} domain.ErrorResponse "Не авторизован"
 // @Failure 403 {object} domain.ErrorResponse "Доступ запрещен"
 // @Security ApiKeyAuth
@@ -236,6 +326,11 @@ func (h *ChatHandler) GetMessages(c *gin.Context) {
 		return
 	}
 
+	if c.Query("before") != "" || c.Query("after") != "" {
+		h.getMessagesByCursor(c, roomID, userID)
+		return
+	}
+
 	page, pageSize := utils.ParsePagination(c)
 
 	messages, total, err := h.chatUseCase.GetMessages(roomID, userID, page, pageSize)
@@ -256,6 +351,58 @@ func (h *ChatHandler) GetMessages(c *gin.Context) {
 	})
 }
 
+func (h *ChatHandler) getMessagesByCursor(c *gin.Context, roomID, userID int) {
+	var cursor domain.ChatMessageCursor
+	var ok bool
+
+	if cursor.BeforeID, ok = parseMessageCursor(c, "before"); !ok {
+		return
+	}
+	if cursor.AfterID, ok = parseMessageCursor(c, "after"); !ok {
+		return
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.JSON(400, domain.NewErrorResponse("Invalid limit"))
+			return
+		}
+		cursor.Limit = limit
+	}
+
+	page, err := h.chatUseCase.GetMessagesByCursor(roomID, userID, cursor)
+	if err != nil {
+		c.JSON(403, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    page.Messages,
+		"cursor": gin.H{
+			"has_more": page.HasMore,
+			"first_id": page.FirstID,
+			"last_id":  page.LastID,
+		},
+	})
+}
+
+func parseMessageCursor(c *gin.Context, name string) (*int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 0 {
+		c.JSON(400, domain.NewErrorResponse("Invalid "+name+" cursor"))
+		return nil, false
+	}
+
+	return &id, true
+}
+
 // @Summary Редактирование сообщения
 // @Description Редактирует содержимое сообщения (только автор может редактировать свои сообщения)
 // @Tags chat
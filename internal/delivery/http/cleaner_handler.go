@@ -1,12 +1,17 @@
 package http
 
 import (
+	"encoding/base64"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/imaging"
 )
 
 type CleanerHandler struct {
@@ -490,13 +495,13 @@ func (h *CleanerHandler) GetCleanerStats(c *gin.Context) {
 }
 
 // @Summary Начать уборку
-// @Description Отметить начало уборки квартиры
+// @Description Отметить начало уборки квартиры. Если в квартире установлен замок, уборщице выдается временный код на время уборки (поле access_code), он отзывается при завершении или отмене уборки
 // @Tags cleaner
 // @Accept json
 // @Produce json
 // @Param request body domain.StartCleaningRequest true "Данные для начала уборки"
 // @Security ApiKeyAuth
-// @Success 200 {object} domain.SuccessResponse
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningLog}
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 401 {object} domain.ErrorResponse
 // @Failure 403 {object} domain.ErrorResponse
@@ -521,7 +526,7 @@ func (h *CleanerHandler) StartCleaning(c *gin.Context) {
 		return
 	}
 
-	err := h.cleanerUseCase.StartCleaning(userID, &request)
+	cleaningLog, err := h.cleanerUseCase.StartCleaning(userID, &request, codeRevealActor(c, userID))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Success: false,
@@ -533,17 +538,64 @@ func (h *CleanerHandler) StartCleaning(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.SuccessResponse{
 		Success: true,
 		Message: "Уборка начата",
+		Data:    cleaningLog,
+	})
+}
+
+// @Summary Код замка для уборки
+// @Description Получить временный код замка квартиры на время текущей уборки. Если код не был выдан при начале уборки или истек, выдается новый
+// @Tags cleaner
+// @Produce json
+// @Param id path int true "ID квартиры"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningAccessCode}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cleaner/apartments/{id}/access-code [get]
+func (h *CleanerHandler) GetCleaningAccessCode(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	apartmentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный ID квартиры",
+		})
+		return
+	}
+
+	accessCode, err := h.cleanerUseCase.GetCleaningAccessCode(userID, apartmentID, codeRevealActor(c, userID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка получения кода замка: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Data:    accessCode,
 	})
 }
 
 // @Summary Завершить уборку
-// @Description Отметить завершение уборки квартиры и освободить её
+// @Description Отметить завершение уборки квартиры и освободить её. Фотографии квартиры после уборки обязательны (от 1 до 10, JPEG, PNG или WebP в base64, до 10 МБ каждая), владелец получает уведомление
 // @Tags cleaner
 // @Accept json
 // @Produce json
 // @Param request body domain.CompleteCleaningRequest true "Данные для завершения уборки"
 // @Security ApiKeyAuth
-// @Success 200 {object} domain.SuccessResponse
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningLog}
 // @Failure 400 {object} domain.ErrorResponse
 // @Failure 401 {object} domain.ErrorResponse
 // @Failure 403 {object} domain.ErrorResponse
@@ -568,7 +620,16 @@ func (h *CleanerHandler) CompleteCleaning(c *gin.Context) {
 		return
 	}
 
-	err := h.cleanerUseCase.CompleteCleaning(userID, &request)
+	photos, err := decodeCleaningPhotos(request.PhotosBase64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	cleaningLog, err := h.cleanerUseCase.CompleteCleaning(userID, &request, photos)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
 			Success: false,
@@ -580,6 +641,545 @@ func (h *CleanerHandler) CompleteCleaning(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.SuccessResponse{
 		Success: true,
 		Message: "Уборка завершена, квартира освобождена",
+		Data:    cleaningLog,
+	})
+}
+
+// @Summary Отменить уборку
+// @Description Отменить начатую уборку квартиры. Квартира остается в списке на уборку
+// @Tags cleaner
+// @Accept json
+// @Produce json
+// @Param request body domain.CancelCleaningRequest true "Квартира и причина отмены"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cleaner/cancel-cleaning [post]
+func (h *CleanerHandler) CancelCleaning(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	var request domain.CancelCleaningRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный формат данных: " + err.Error(),
+		})
+		return
+	}
+
+	cleaningLog, err := h.cleanerUseCase.CancelCleaning(userID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка отмены уборки: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Уборка отменена",
+		Data:    cleaningLog,
+	})
+}
+
+// @Summary История уборок
+// @Description Журнал уборок текущей уборщицы с фотографиями после уборки
+// @Tags cleaner
+// @Accept json
+// @Produce json
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(20)
+// @Param apartment_id query int false "Фильтр по ID квартиры"
+// @Param status query string false "Фильтр по статусу" Enums(in_progress, completed, cancelled)
+// @Param date_from query string false "Начало уборки с даты (YYYY-MM-DD)"
+// @Param date_to query string false "Начало уборки по дату включительно (YYYY-MM-DD)"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.CleaningLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cleaner/history [get]
+func (h *CleanerHandler) GetCleaningHistory(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	filters, page, pageSize, err := parseCleaningLogQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	cleaningLogs, total, err := h.cleanerUseCase.GetCleaningHistory(userID, filters, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка получения истории уборок: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.PaginatedResponse{
+		Success: true,
+		Data:    cleaningLogs,
+		Page:    page,
+		PerPage: pageSize,
+		Total:   total,
+	})
+}
+
+// @Summary История уборок квартир владельца
+// @Description Журнал уборок квартир текущего владельца с фотографиями после уборки
+// @Tags apartments
+// @Accept json
+// @Produce json
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(20)
+// @Param apartment_id query int false "Фильтр по ID квартиры"
+// @Param cleaner_id query int false "Фильтр по ID уборщицы"
+// @Param status query string false "Фильтр по статусу" Enums(in_progress, completed, cancelled)
+// @Param date_from query string false "Начало уборки с даты (YYYY-MM-DD)"
+// @Param date_to query string false "Начало уборки по дату включительно (YYYY-MM-DD)"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.CleaningLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /owner/cleaning-logs [get]
+func (h *CleanerHandler) GetOwnerCleaningHistory(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	filters, page, pageSize, err := parseCleaningLogQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	cleaningLogs, total, err := h.cleanerUseCase.GetOwnerCleaningHistory(userID, filters, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка получения истории уборок: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.PaginatedResponse{
+		Success: true,
+		Data:    cleaningLogs,
+		Page:    page,
+		PerPage: pageSize,
+		Total:   total,
+	})
+}
+
+// @Summary Журнал уборок
+// @Description Журнал уборок всех квартир с фильтрацией (только для админов)
+// @Tags Admin - Cleaners
+// @Accept json
+// @Produce json
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(20)
+// @Param apartment_id query int false "Фильтр по ID квартиры"
+// @Param cleaner_id query int false "Фильтр по ID уборщицы"
+// @Param owner_id query int false "Фильтр по ID владельца"
+// @Param status query string false "Фильтр по статусу" Enums(in_progress, completed, cancelled)
+// @Param date_from query string false "Начало уборки с даты (YYYY-MM-DD)"
+// @Param date_to query string false "Начало уборки по дату включительно (YYYY-MM-DD)"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.CleaningLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/cleaning-logs [get]
+func (h *CleanerHandler) AdminGetCleaningLogs(c *gin.Context) {
+	filters, page, pageSize, err := parseCleaningLogQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if ownerID := c.Query("owner_id"); ownerID != "" {
+		id, err := strconv.Atoi(ownerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Success: false,
+				Error:   "Неверный ID владельца",
+			})
+			return
+		}
+		filters["owner_id"] = id
+	}
+
+	cleaningLogs, total, err := h.cleanerUseCase.GetAllCleaningLogs(filters, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка получения журнала уборок: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.PaginatedResponse{
+		Success: true,
+		Data:    cleaningLogs,
+		Page:    page,
+		PerPage: pageSize,
+		Total:   total,
+	})
+}
+
+// @Summary Отменить уборку (админ)
+// @Description Отмена зависшей уборки в процессе (только для админов)
+// @Tags Admin - Cleaners
+// @Accept json
+// @Produce json
+// @Param id path int true "ID записи уборки"
+// @Param request body domain.AdminCancelCleaningRequest false "Причина отмены"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningLog}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/cleaning-logs/{id}/cancel [post]
+func (h *CleanerHandler) AdminCancelCleaning(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный ID записи уборки",
+		})
+		return
+	}
+
+	var request domain.AdminCancelCleaningRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Success: false,
+				Error:   "Неверный формат данных: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	cleaningLog, err := h.cleanerUseCase.AdminCancelCleaning(id, request.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка отмены уборки: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Уборка отменена",
+		Data:    cleaningLog,
+	})
+}
+
+// @Summary Заявки на уборку
+// @Description Заявки на уборку, предложенные текущей уборщице или принятые ею. Заявки создаются автоматически при выезде гостя
+// @Tags cleaner
+// @Accept json
+// @Produce json
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(20)
+// @Param apartment_id query int false "Фильтр по ID квартиры"
+// @Param status query string false "Фильтр по статусу" Enums(offered, accepted, in_progress, completed)
+// @Param open query bool false "Только незакрытые заявки"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.CleaningTask}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cleaner/tasks [get]
+func (h *CleanerHandler) GetMyCleaningTasks(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	filters, page, pageSize, err := parseCleaningTaskQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tasks, total, err := h.cleanerUseCase.GetMyCleaningTasks(userID, filters, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusForbidden, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка получения заявок на уборку: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.PaginatedResponse{
+		Success: true,
+		Data:    tasks,
+		Page:    page,
+		PerPage: pageSize,
+		Total:   total,
+	})
+}
+
+// @Summary Принять заявку на уборку
+// @Description Принять заявку, предложенную текущей уборщице. Принять можно до истечения времени на ответ
+// @Tags cleaner
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки на уборку"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningTask}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cleaner/tasks/{id}/accept [post]
+func (h *CleanerHandler) AcceptCleaningTask(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный ID заявки на уборку",
+		})
+		return
+	}
+
+	task, err := h.cleanerUseCase.AcceptCleaningTask(userID, id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка принятия заявки на уборку: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Заявка на уборку принята",
+		Data:    task,
+	})
+}
+
+// @Summary Отказаться от заявки на уборку
+// @Description Отказаться от предложенной заявки. Заявка будет предложена следующей уборщице квартиры
+// @Tags cleaner
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки на уборку"
+// @Param request body domain.DeclineCleaningTaskRequest false "Причина отказа"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /cleaner/tasks/{id}/decline [post]
+func (h *CleanerHandler) DeclineCleaningTask(c *gin.Context) {
+	userID, _ := utils.GetUserIDFromContext(c)
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, domain.ErrorResponse{
+			Success: false,
+			Error:   "Необходима авторизация",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный ID заявки на уборку",
+		})
+		return
+	}
+
+	var request domain.DeclineCleaningTaskRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Success: false,
+				Error:   "Неверный формат данных: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	if _, err := h.cleanerUseCase.DeclineCleaningTask(userID, id, request.Reason); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка отказа от заявки на уборку: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Вы отказались от заявки на уборку",
+	})
+}
+
+// @Summary Заявки на уборку (админ)
+// @Description Заявки на уборку всех квартир. Заявки без уборщицы передаются администраторам в статусе escalated
+// @Tags Admin - Cleaners
+// @Accept json
+// @Produce json
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(20)
+// @Param apartment_id query int false "Фильтр по ID квартиры"
+// @Param cleaner_id query int false "Фильтр по ID уборщицы"
+// @Param status query string false "Фильтр по статусу" Enums(pending, offered, accepted, in_progress, completed, escalated, cancelled)
+// @Param open query bool false "Только незакрытые заявки"
+// @Param is_late query bool false "Только просроченные уборки"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.PaginatedResponse{data=[]domain.CleaningTask}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/cleaning-tasks [get]
+func (h *CleanerHandler) AdminGetCleaningTasks(c *gin.Context) {
+	filters, page, pageSize, err := parseCleaningTaskQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if cleanerID := c.Query("cleaner_id"); cleanerID != "" {
+		id, err := strconv.Atoi(cleanerID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+				Success: false,
+				Error:   "Неверный ID уборщицы",
+			})
+			return
+		}
+		filters["cleaner_id"] = id
+	}
+
+	tasks, total, err := h.cleanerUseCase.GetCleaningTasks(filters, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка получения заявок на уборку: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.PaginatedResponse{
+		Success: true,
+		Data:    tasks,
+		Page:    page,
+		PerPage: pageSize,
+		Total:   total,
+	})
+}
+
+// @Summary Назначить уборщицу на заявку (админ)
+// @Description Предложить заявку выбранной уборщице квартиры вне очереди, в том числе после эскалации
+// @Tags Admin - Cleaners
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки на уборку"
+// @Param request body domain.AssignCleaningTaskRequest true "Уборщица"
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=domain.CleaningTask}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/cleaning-tasks/{id}/assign [post]
+func (h *CleanerHandler) AdminAssignCleaningTask(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный ID заявки на уборку",
+		})
+		return
+	}
+
+	var request domain.AssignCleaningTaskRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Неверный формат данных: " + err.Error(),
+		})
+		return
+	}
+
+	task, err := h.cleanerUseCase.AdminAssignCleaningTask(id, request.CleanerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
+			Success: false,
+			Error:   "Ошибка назначения уборщицы: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.SuccessResponse{
+		Success: true,
+		Message: "Заявка предложена уборщице",
+		Data:    task,
 	})
 }
 
@@ -746,6 +1346,10 @@ func (h *CleanerHandler) RegisterAdminRoutes(group *gin.RouterGroup) {
 	group.POST("/cleaners/remove", h.AdminRemoveCleanerFromApartment)
 	group.GET("/cleaners/apartments-needing-cleaning", h.AdminGetApartmentsNeedingCleaning)
 	group.PATCH("/cleaners/:id/schedule", h.AdminUpdateCleanerSchedulePatch)
+	group.GET("/cleaning-logs", h.AdminGetCleaningLogs)
+	group.POST("/cleaning-logs/:id/cancel", h.AdminCancelCleaning)
+	group.GET("/cleaning-tasks", h.AdminGetCleaningTasks)
+	group.POST("/cleaning-tasks/:id/assign", h.AdminAssignCleaningTask)
 }
 
 func (h *CleanerHandler) RegisterCleanerRoutes(group *gin.RouterGroup) {
@@ -754,7 +1358,133 @@ func (h *CleanerHandler) RegisterCleanerRoutes(group *gin.RouterGroup) {
 	group.GET("/apartments-for-cleaning", h.GetApartmentsForCleaning)
 	group.GET("/stats", h.GetCleanerStats)
 	group.POST("/start-cleaning", h.StartCleaning)
+	group.GET("/apartments/:id/access-code", h.GetCleaningAccessCode)
 	group.POST("/complete-cleaning", h.CompleteCleaning)
+	group.POST("/cancel-cleaning", h.CancelCleaning)
+	group.GET("/history", h.GetCleaningHistory)
+	group.GET("/tasks", h.GetMyCleaningTasks)
+	group.POST("/tasks/:id/accept", h.AcceptCleaningTask)
+	group.POST("/tasks/:id/decline", h.DeclineCleaningTask)
 	group.PUT("/schedule", h.UpdateCleanerSchedule)
 	group.PATCH("/schedule/patch", h.UpdateCleanerSchedulePatch)
 }
+
+func (h *CleanerHandler) RegisterOwnerRoutes(group *gin.RouterGroup) {
+	group.GET("/cleaning-logs", h.GetOwnerCleaningHistory)
+}
+
+// parseCleaningLogQuery разбирает пагинацию и фильтры журнала уборок. date_to включает весь указанный день.
+func parseCleaningLogQuery(c *gin.Context) (map[string]interface{}, int, int, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filters := make(map[string]interface{})
+	for _, key := range []string{"apartment_id", "cleaner_id"} {
+		if value := c.Query(key); value != "" {
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("неверное значение %s", key)
+			}
+			filters[key] = id
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		if !domain.IsValidCleaningLogStatus(domain.CleaningLogStatus(status)) {
+			return nil, 0, 0, fmt.Errorf("неверный статус уборки: %s", status)
+		}
+		filters["status"] = status
+	}
+
+	if dateFrom := c.Query("date_from"); dateFrom != "" {
+		parsed, err := time.Parse("2006-01-02", dateFrom)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("неверный формат date_from (используйте YYYY-MM-DD)")
+		}
+		filters["date_from"] = parsed
+	}
+
+	if dateTo := c.Query("date_to"); dateTo != "" {
+		parsed, err := time.Parse("2006-01-02", dateTo)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("неверный формат date_to (используйте YYYY-MM-DD)")
+		}
+		filters["date_to"] = parsed.AddDate(0, 0, 1)
+	}
+
+	return filters, page, pageSize, nil
+}
+
+// parseCleaningTaskQuery разбирает пагинацию и фильтры заявок на уборку.
+func parseCleaningTaskQuery(c *gin.Context) (map[string]interface{}, int, int, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filters := make(map[string]interface{})
+	if value := c.Query("apartment_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("неверное значение apartment_id")
+		}
+		filters["apartment_id"] = id
+	}
+
+	if status := c.Query("status"); status != "" {
+		if !domain.IsValidCleaningTaskStatus(domain.CleaningTaskStatus(status)) {
+			return nil, 0, 0, fmt.Errorf("неверный статус заявки на уборку: %s", status)
+		}
+		filters["status"] = status
+	}
+
+	for _, key := range []string{"open", "is_late"} {
+		if value := c.Query(key); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("неверное значение %s", key)
+			}
+			filters[key] = parsed
+		}
+	}
+
+	return filters, page, pageSize, nil
+}
+
+// decodeCleaningPhotos декодирует фотографии после уборки. В отличие от фото объявлений битые
+// фотографии и файлы, которые не являются изображениями, не пропускаются: это подтверждение уборки для владельца.
+func decodeCleaningPhotos(photosBase64 []string) ([][]byte, error) {
+	photos := make([][]byte, 0, len(photosBase64))
+	for i, photoBase64 := range photosBase64 {
+		if _, data, found := strings.Cut(photoBase64, ","); found {
+			photoBase64 = data
+		}
+
+		photoData, err := base64.StdEncoding.DecodeString(strings.TrimSpace(photoBase64))
+		if err != nil {
+			return nil, fmt.Errorf("некорректные данные base64 в фотографии %d", i+1)
+		}
+
+		if len(photoData) > domain.MaxCleaningPhotoBytes {
+			return nil, fmt.Errorf("фотография %d превышает %d МБ", i+1, domain.MaxCleaningPhotoBytes/(1024*1024))
+		}
+
+		if _, ok := imaging.PhotoExtension(photoData); !ok {
+			return nil, fmt.Errorf("фотография %d должна быть в формате JPEG, PNG или WebP", i+1)
+		}
+
+		photos = append(photos, photoData)
+	}
+
+	return photos, nil
+}
@@ -99,6 +99,8 @@ func (h *ContractHandler) GetContractByID(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "ID договора"
+// @Param lang query string false "Язык договора: ru, kk, en (по умолчанию из Accept-Language)"
+// @Param Accept-Language header string false "Предпочитаемый язык"
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 401 {object} domain.ErrorResponse
 // @Failure 403 {object} domain.ErrorResponse
@@ -133,7 +135,8 @@ func (h *ContractHandler) GetContractHTML(c *gin.Context) {
 		return
 	}
 
-	html, err := h.contractUseCase.GetContractHTML(contractID)
+	lang := string(utils.GetLanguage(c))
+	html, err := h.contractUseCase.GetContractHTML(contractID, lang)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
 		return
@@ -143,6 +146,7 @@ func (h *ContractHandler) GetContractHTML(c *gin.Context) {
 		ContractID: contractID,
 		HTML:       html,
 		Status:     contract.Status,
+		Language:   lang,
 		CachedAt:   "", // TODO: добавить время кэширования из Redis если нужно
 	}
 
@@ -2,9 +2,11 @@ package http
 
 import (
 	"log"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
 	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 )
 
@@ -26,10 +28,7 @@ func ErrorMiddleware() gin.HandlerFunc {
 			}
 
 
-			log.Printf("Error: %s", apperrors.FormatError(appErr))
-
-
-			c.JSON(appErr.StatusCode, domain.NewErrorResponse(appErr.Message))
+			renderAppError(c, appErr)
 			c.Abort()
 		}
 	}
@@ -50,8 +49,25 @@ func RespondWithError(c *gin.Context, err error) {
 	}
 
 
-	log.Printf("Error: %s", apperrors.FormatError(appErr))
+	renderAppError(c, appErr)
+}
+
+// RespondWithErrorStatus отвечает ошибкой каталога с ее кодом и статусом. Ошибки вне каталога
+// отдаются с переданным статусом и общим кодом для него (bad_request, not_found и т.д.).
+func RespondWithErrorStatus(c *gin.Context, statusCode int, err error) {
+	appErr, ok := apperrors.AsAppError(err)
+	if !ok {
+		appErr = apperrors.FromStatus(statusCode, err)
+	}
+
+	renderAppError(c, appErr)
+}
 
+// renderAppError единый формат ответа с ошибкой: текст на языке клиента, код и детали.
+func renderAppError(c *gin.Context, appErr *apperrors.AppError) {
+	if appErr.StatusCode >= http.StatusInternalServerError {
+		log.Printf("Error: %s", apperrors.FormatError(appErr))
+	}
 
-	c.JSON(appErr.StatusCode, domain.NewErrorResponse(appErr.Message))
+	c.JSON(appErr.StatusCode, domain.NewCodedErrorResponse(appErr.Type, appErr.Localize(utils.GetLanguage(c)), appErr.Details))
 }
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+type FreedomPayWebhookHandler struct {
+	paymentUseCase domain.PaymentUseCase
+	bookingUseCase domain.BookingUseCase
+}
+
+func NewFreedomPayWebhookHandler(paymentUseCase domain.PaymentUseCase, bookingUseCase domain.BookingUseCase) *FreedomPayWebhookHandler {
+	return &FreedomPayWebhookHandler{
+		paymentUseCase: paymentUseCase,
+		bookingUseCase: bookingUseCase,
+	}
+}
+
+func (h *FreedomPayWebhookHandler) RegisterRoutes(router *gin.RouterGroup) {
+	webhooks := router.Group("/webhooks")
+	{
+		webhooks.GET("/freedompay", h.HandleFreedomPayCallback)
+		webhooks.POST("/freedompay", h.HandleFreedomPayCallback)
+	}
+}
+
+// @Summary Callback FreedomPay (check/result)
+// @Description Принимает check и result запросы FreedomPay, проверяет подпись pg_sig и подтверждает оплату бронирования или продления. Ответ в формате XML FreedomPay
+// @Tags payment-webhooks
+// @Accept x-www-form-urlencoded
+// @Produce xml
+// @Param pg_order_id formData string true "ID заказа (booking_<id>_<попытка> или extension_<id>_<попытка>)"
+// @Param pg_payment_id formData string true "ID платежа FreedomPay"
+// @Param pg_amount formData string true "Сумма платежа"
+// @Param pg_result formData string false "Результат платежа (1 - успех, 0 - ошибка), только для result"
+// @Param pg_salt formData string true "Случайная строка"
+// @Param pg_sig formData string true "Подпись запроса"
+// @Success 200 {object} domain.FreedomPayCallbackResponse
+// @Router /webhooks/freedompay [post]
+func (h *FreedomPayWebhookHandler) HandleFreedomPayCallback(c *gin.Context) {
+	scriptName := path.Base(c.Request.URL.Path)
+
+	if err := c.Request.ParseForm(); err != nil {
+		logger.Warn("failed to parse freedompay callback", slog.String("error", err.Error()))
+		c.XML(http.StatusOK, h.paymentUseCase.BuildCallbackResponse(scriptName, domain.FreedomPayResponseError, "неверный формат запроса"))
+		return
+	}
+
+	params := make(map[string]string, len(c.Request.Form))
+	for key, values := range c.Request.Form {
+		if len(values) > 0 {
+			params[key] = values[0]
+		}
+	}
+
+	if !h.paymentUseCase.VerifyCallbackSignature(scriptName, params) {
+		logger.Warn("freedompay callback signature mismatch",
+			slog.String("order_id", params["pg_order_id"]),
+			slog.String("payment_id", params["pg_payment_id"]),
+			slog.String("ip", c.ClientIP()))
+		c.XML(http.StatusOK, h.paymentUseCase.BuildCallbackResponse(scriptName, domain.FreedomPayResponseError, "неверная подпись"))
+		return
+	}
+
+	callback := &domain.FreedomPayCallback{
+		Type:               domain.FreedomPayCallbackCheck,
+		OrderID:            params["pg_order_id"],
+		PaymentID:          params["pg_payment_id"],
+		Amount:             params["pg_amount"],
+		Currency:           params["pg_currency"],
+		Result:             params["pg_result"],
+		PaymentMethod:      params["pg_payment_method"],
+		CardPan:            params["pg_card_pan"],
+		Captured:           params["pg_captured"],
+		PaymentDate:        params["pg_payment_date"],
+		FailureCode:        params["pg_failure_code"],
+		FailureDescription: params["pg_failure_description"],
+		IPAddress:          c.ClientIP(),
+		UserAgent:          c.Request.UserAgent(),
+	}
+	if _, ok := params["pg_result"]; ok {
+		callback.Type = domain.FreedomPayCallbackResult
+	}
+
+	status, description := h.bookingUseCase.HandlePaymentCallback(callback)
+
+	c.XML(http.StatusOK, h.paymentUseCase.BuildCallbackResponse(scriptName, status, description))
+}
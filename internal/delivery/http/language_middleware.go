@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/i18n"
+)
+
+// LanguageMiddleware выбирает язык ответа по заголовку Accept-Language. Параметр запроса lang имеет
+// приоритет: он нужен для ссылок, открываемых в браузере (например, HTML договора).
+func LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		if queryLang, ok := i18n.Parse(c.Query("lang")); ok {
+			lang = queryLang
+		}
+
+		utils.SetLanguage(c, lang)
+		c.Header("Content-Language", string(lang))
+		c.Next()
+	}
+}
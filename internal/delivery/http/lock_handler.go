@@ -1,14 +1,22 @@
 package http
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+)
+
+const (
+	// deviceLockContextKey замок, подпись которого проверена для текущего запроса устройства
+	deviceLockContextKey = "device_lock_unique_id"
+	maxDeviceRequestBody = 64 << 10
 )
 
 type LockHandler struct {
@@ -49,6 +57,7 @@ func (h *LockHandler) RegisterRoutes(router *gin.RouterGroup, middleware *Middle
 	}
 
 	deviceAPI := router.Group("/device/locks")
+	deviceAPI.Use(h.DeviceAuthMiddleware())
 	{
 		deviceAPI.POST("/status", h.UpdateLockStatus)
 		deviceAPI.POST("/heartbeat", h.ProcessHeartbeat)
@@ -63,14 +72,18 @@ func (h *LockHandler) RegisterAdminRoutes(router *gin.RouterGroup) {
 	router.DELETE("/locks/:id/unbind-apartment", h.AdminUnbindLockFromApartment)
 	router.PUT("/locks/:id/emergency-reset", h.AdminEmergencyResetLock)
 	router.GET("/locks/statistics", h.AdminGetLocksStatistics)
+	router.POST("/locks/:id/device-secret/rotate", h.AdminRotateDeviceSecret)
+	router.DELETE("/locks/:id/device-secret/previous", h.AdminRevokePreviousDeviceSecret)
 
 	router.POST("/locks/by-unique-id/:uniqueId/passwords", h.AdminGeneratePassword)
 	router.GET("/locks/by-unique-id/:uniqueId/passwords", h.AdminGetAllLockPasswords)
+	router.GET("/locks/by-unique-id/:uniqueId/code-reveals", h.AdminGetCodeReveals)
 	router.POST("/passwords/:passwordId/deactivate", h.AdminDeactivatePassword)
+	router.POST("/passwords/:passwordId/reveal", h.AdminRevealPassword)
 }
 
 // @Summary Создание замка
-// @Description Создает новый умный замок для квартиры
+// @Description Создает новый умный замок для квартиры. В ответе один раз возвращается device_secret для подписи запросов устройства
 // @Tags locks
 // @Accept json
 // @Produce json
@@ -337,7 +350,7 @@ func (h *LockHandler) DeleteLock(c *gin.Context) {
 }
 
 // @Summary Получение пароля владельца
-// @Description Получает постоянный пароль владельца замка
+// @Description Получает постоянный пароль владельца замка. В остальных ответах пароль маскируется, каждое раскрытие записывается в журнал
 // @Tags locks
 // @Accept json
 // @Produce json
@@ -357,7 +370,7 @@ func (h *LockHandler) GetOwnerPassword(c *gin.Context) {
 
 	uniqueID := c.Param("uniqueId")
 
-	password, err := h.lockUseCase.GetOwnerPassword(uniqueID, userID)
+	password, err := h.lockUseCase.GetOwnerPassword(uniqueID, codeRevealActor(c, userID))
 	if err != nil {
 		if err.Error() == "только владелец квартиры может получить постоянный пароль" {
 			c.JSON(http.StatusForbidden, domain.NewErrorResponse(err.Error()))
@@ -505,14 +518,62 @@ func (h *LockHandler) GetLockHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("история замка", history))
 }
 
+// DeviceAuthMiddleware пропускает только запросы, подписанные секретом устройства замка.
+// Подпись - hex(HMAC-SHA256(секрет, "METHOD\nPATH\nTIMESTAMP\nNONCE\nhex(SHA256(тело))")),
+// PATH - путь запроса целиком, например /api/device/locks/status.
+func (h *LockHandler) DeviceAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxDeviceRequestBody))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, domain.NewErrorResponse("слишком большой запрос"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		lock, err := h.lockUseCase.AuthenticateDeviceRequest(&domain.LockDeviceRequest{
+			UniqueID:  c.GetHeader(domain.LockDeviceIDHeader),
+			Timestamp: c.GetHeader(domain.LockDeviceTimestampHeader),
+			Nonce:     c.GetHeader(domain.LockDeviceNonceHeader),
+			Signature: c.GetHeader(domain.LockDeviceSignatureHeader),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Body:      body,
+			IPAddress: c.ClientIP(),
+		})
+		if err != nil {
+			RespondWithErrorStatus(c, http.StatusInternalServerError, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(deviceLockContextKey, lock.UniqueID)
+		c.Next()
+	}
+}
+
+// requireDeviceLock проверяет, что тело запроса относится к замку, чьей подписью он подписан.
+func requireDeviceLock(c *gin.Context, uniqueID string) bool {
+	if uniqueID != c.GetString(deviceLockContextKey) {
+		RespondWithErrorStatus(c, http.StatusForbidden, apperrors.LockDeviceMismatch.New(nil))
+		return false
+	}
+	return true
+}
+
 // @Summary Обновление статуса замка устройством
-// @Description Обновляет статус умного замка от самого устройства (для интеграции с Tuya)
+// @Description Обновляет статус умного замка от самого устройства (для интеграции с Tuya). Запрос должен быть подписан секретом устройства: заголовки X-Lock-ID, X-Lock-Timestamp (unix-время), X-Lock-Nonce (8-64 символа, одноразовый) и X-Lock-Signature = hex(HMAC-SHA256(секрет, "METHOD\nPATH\nTIMESTAMP\nNONCE\nhex(SHA256(тело))"))
 // @Tags device-locks
 // @Accept json
 // @Produce json
+// @Param X-Lock-ID header string true "Уникальный ID замка"
+// @Param X-Lock-Timestamp header string true "Unix-время запроса в секундах"
+// @Param X-Lock-Nonce header string true "Одноразовое значение"
+// @Param X-Lock-Signature header string true "Подпись запроса"
 // @Param request body domain.LockStatusUpdateRequest true "Данные статуса замка"
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
 // @Router /device/locks/status [post]
 func (h *LockHandler) UpdateLockStatus(c *gin.Context) {
 	var request domain.LockStatusUpdateRequest
@@ -521,6 +582,10 @@ func (h *LockHandler) UpdateLockStatus(c *gin.Context) {
 		return
 	}
 
+	if !requireDeviceLock(c, request.UniqueID) {
+		return
+	}
+
 	if request.Timestamp.IsZero() {
 		request.Timestamp = utils.GetCurrentTimeUTC()
 	}
@@ -535,13 +600,19 @@ func (h *LockHandler) UpdateLockStatus(c *gin.Context) {
 }
 
 // @Summary Heartbeat от замка
-// @Description Обновляет heartbeat и статус замка от устройства (для мониторинга онлайн/офлайн)
+// @Description Обновляет heartbeat и статус замка от устройства (для мониторинга онлайн/офлайн). Подпись запроса - как у /device/locks/status
 // @Tags device-locks
 // @Accept json
 // @Produce json
+// @Param X-Lock-ID header string true "Уникальный ID замка"
+// @Param X-Lock-Timestamp header string true "Unix-время запроса в секундах"
+// @Param X-Lock-Nonce header string true "Одноразовое значение"
+// @Param X-Lock-Signature header string true "Подпись запроса"
 // @Param request body domain.LockHeartbeatRequest true "Данные heartbeat замка"
 // @Success 200 {object} domain.SuccessResponse
 // @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
 // @Router /device/locks/heartbeat [post]
 func (h *LockHandler) ProcessHeartbeat(c *gin.Context) {
 	var request domain.LockHeartbeatRequest
@@ -550,6 +621,10 @@ func (h *LockHandler) ProcessHeartbeat(c *gin.Context) {
 		return
 	}
 
+	if !requireDeviceLock(c, request.UniqueID) {
+		return
+	}
+
 	if request.Timestamp.IsZero() {
 		request.Timestamp = utils.GetCurrentTimeUTC()
 	}
@@ -819,11 +894,7 @@ func (h *LockHandler) AdminBindLockToApartment(c *gin.Context) {
 
 	err := h.lockUseCase.BindLockToApartment(lockID, req.ApartmentID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -855,11 +926,7 @@ func (h *LockHandler) AdminUnbindLockFromApartment(c *gin.Context) {
 
 	err := h.lockUseCase.UnbindLockFromApartment(lockID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -890,11 +957,7 @@ func (h *LockHandler) AdminEmergencyResetLock(c *gin.Context) {
 
 	err := h.lockUseCase.EmergencyResetLock(lockID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -989,11 +1052,7 @@ func (h *LockHandler) AdminGeneratePassword(c *gin.Context) {
 
 	password, err := h.lockUseCase.AdminGeneratePassword(uniqueID, &request)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -1018,11 +1077,7 @@ func (h *LockHandler) AdminGetAllLockPasswords(c *gin.Context) {
 
 	passwords, err := h.lockUseCase.AdminGetAllLockPasswords(uniqueID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -1098,11 +1153,7 @@ func (h *LockHandler) AdminDeactivatePassword(c *gin.Context) {
 
 	err := h.lockUseCase.AdminDeactivatePassword(passwordID)
 	if err != nil {
-		if strings.Contains(err.Error(), "не найден") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
-		} else {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusBadRequest, err)
 		return
 	}
 
@@ -1110,3 +1161,143 @@ func (h *LockHandler) AdminDeactivatePassword(c *gin.Context) {
 		"password_id": passwordID,
 	}))
 }
+
+// @Summary Раскрытие временного пароля (админ)
+// @Description Возвращает временный пароль замка в открытом виде. В остальных ответах пароли маскируются, каждое раскрытие записывается в журнал (только для админов)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param passwordId path int true "ID пароля"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/passwords/{passwordId}/reveal [post]
+func (h *LockHandler) AdminRevealPassword(c *gin.Context) {
+	adminID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	passwordID, ok := utils.ParseIDParam(c, "passwordId")
+	if !ok {
+		return
+	}
+
+	password, err := h.lockUseCase.AdminRevealPassword(passwordID, codeRevealActor(c, adminID))
+	if err != nil {
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("пароль раскрыт", gin.H{
+		"password_id": passwordID,
+		"password":    password,
+	}))
+}
+
+// @Summary Журнал раскрытия кодов замка (админ)
+// @Description Возвращает последние раскрытия пароля владельца и временных паролей замка: кто, когда и с какого адреса (только для админов)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param uniqueId path string true "Уникальный ID замка"
+// @Param limit query int false "Количество записей" default(50)
+// @Success 200 {object} domain.SuccessResponse{data=[]domain.LockCodeReveal}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/locks/by-unique-id/{uniqueId}/code-reveals [get]
+func (h *LockHandler) AdminGetCodeReveals(c *gin.Context) {
+	uniqueID := c.Param("uniqueId")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+
+	reveals, err := h.lockUseCase.AdminGetCodeReveals(uniqueID, limit)
+	if err != nil {
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("журнал раскрытия кодов", reveals))
+}
+
+// @Summary Ротация секрета устройства замка (админ)
+// @Description Выдает замку новый секрет для подписи запросов устройства. Секрет возвращается один раз; предыдущий секрет принимается в течение льготного периода (по умолчанию 24 часа). Если секрета не было, он создается (только для админов)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID замка"
+// @Param request body domain.RotateLockDeviceSecretRequest false "Льготный период"
+// @Success 200 {object} domain.SuccessResponse{data=domain.LockDeviceSecretResponse}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/locks/{id}/device-secret/rotate [post]
+func (h *LockHandler) AdminRotateDeviceSecret(c *gin.Context) {
+	lockID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var request domain.RotateLockDeviceSecretRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("неверный формат данных"))
+			return
+		}
+	}
+
+	response, err := h.lockUseCase.AdminRotateDeviceSecret(lockID, &request)
+	if err != nil {
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("секрет устройства обновлен", response))
+}
+
+// @Summary Отзыв предыдущего секрета устройства (админ)
+// @Description Досрочно завершает льготный период после ротации: запросы, подписанные предыдущим секретом, перестают приниматься (только для админов)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID замка"
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/locks/{id}/device-secret/previous [delete]
+func (h *LockHandler) AdminRevokePreviousDeviceSecret(c *gin.Context) {
+	lockID, ok := utils.ParseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.lockUseCase.AdminRevokePreviousDeviceSecret(lockID); err != nil {
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("предыдущий секрет устройства отозван", gin.H{
+		"lock_id": lockID,
+	}))
+}
+
+// codeRevealActor собирает данные о том, кто раскрывает код, для журнала раскрытий.
+func codeRevealActor(c *gin.Context, userID int) *domain.LockCodeRevealActor {
+	return &domain.LockCodeRevealActor{
+		UserID:    userID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
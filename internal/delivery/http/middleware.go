@@ -8,19 +8,22 @@ import (
 	"github.com/russo2642/renti_kz/internal/services"
 	"github.com/russo2642/renti_kz/internal/utils"
 	"github.com/russo2642/renti_kz/pkg/auth"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 )
 
 type Middleware struct {
 	tokenManager     auth.TokenManager
 	authUseCase      domain.AuthUseCase
 	userCacheService *services.UserCacheService
+	rateLimiter      domain.RateLimiter
 }
 
-func NewMiddleware(tokenManager auth.TokenManager, authUseCase domain.AuthUseCase, userCacheService *services.UserCacheService) *Middleware {
+func NewMiddleware(tokenManager auth.TokenManager, authUseCase domain.AuthUseCase, userCacheService *services.UserCacheService, rateLimiter domain.RateLimiter) *Middleware {
 	return &Middleware{
 		tokenManager:     tokenManager,
 		authUseCase:      authUseCase,
 		userCacheService: userCacheService,
+		rateLimiter:      rateLimiter,
 	}
 }
 
@@ -32,24 +35,30 @@ func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		claims, err := m.tokenManager.ParseAccessToken(accessToken)
+		if err != nil {
+			utils.AbortWithUnauthorized(c, "недействительный токен")
+			return
+		}
+
+		if err := m.checkSession(claims); err != nil {
+			RespondWithErrorStatus(c, http.StatusUnauthorized, err)
+			c.Abort()
+			return
+		}
+
 		if m.userCacheService != nil {
 			if cachedUser, err := m.userCacheService.GetCachedTokenValidation(accessToken); err == nil && cachedUser != nil {
 				if !cachedUser.IsActive {
 					utils.AbortWithUnauthorized(c, "аккаунт заблокирован")
 					return
 				}
-				utils.SetUserContext(c, cachedUser.ID, cachedUser.Role)
+				setAuthContext(c, cachedUser.ID, cachedUser.Role, claims)
 				c.Next()
 				return
 			}
 		}
 
-		_, err := m.tokenManager.ParseAccessToken(accessToken)
-		if err != nil {
-			utils.AbortWithUnauthorized(c, "недействительный токен")
-			return
-		}
-
 		userID, err := m.authUseCase.GetUserFromToken(accessToken)
 		if err != nil {
 			utils.AbortWithUnauthorized(c, "недействительный токен")
@@ -65,7 +74,7 @@ func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
 			_ = m.userCacheService.CacheTokenValidation(accessToken, userID)
 		}
 
-		utils.SetUserContext(c, userID.ID, userID.Role)
+		setAuthContext(c, userID.ID, userID.Role, claims)
 		c.Next()
 	}
 }
@@ -78,24 +87,29 @@ func (m *Middleware) OptionalAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		claims, err := m.tokenManager.ParseAccessToken(accessToken)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		if err := m.checkSession(claims); err != nil {
+			c.Next()
+			return
+		}
+
 		if m.userCacheService != nil {
 			if cachedUser, err := m.userCacheService.GetCachedTokenValidation(accessToken); err == nil && cachedUser != nil {
 				if !cachedUser.IsActive {
 					c.Next()
 					return
 				}
-				utils.SetUserContext(c, cachedUser.ID, cachedUser.Role)
+				setAuthContext(c, cachedUser.ID, cachedUser.Role, claims)
 				c.Next()
 				return
 			}
 		}
 
-		_, err := m.tokenManager.ParseAccessToken(accessToken)
-		if err != nil {
-			c.Next()
-			return
-		}
-
 		userID, err := m.authUseCase.GetUserFromToken(accessToken)
 		if err != nil {
 			c.Next()
@@ -111,11 +125,34 @@ func (m *Middleware) OptionalAuthMiddleware() gin.HandlerFunc {
 			_ = m.userCacheService.CacheTokenValidation(accessToken, userID)
 		}
 
-		utils.SetUserContext(c, userID.ID, userID.Role)
+		setAuthContext(c, userID.ID, userID.Role, claims)
 		c.Next()
 	}
 }
 
+// checkSession отклоняет access-токены отозванных сессий. Токены, выданные до появления сессий,
+// проверяются только по статусу пользователя до истечения срока их действия.
+func (m *Middleware) checkSession(claims *auth.TokenClaims) error {
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	active, err := m.authUseCase.IsSessionActive(claims.SessionID)
+	if err != nil {
+		return apperrors.NewInternalError("ошибка проверки сессии", err)
+	}
+	if !active {
+		return apperrors.AuthSessionRevoked.New(nil)
+	}
+
+	return nil
+}
+
+func setAuthContext(c *gin.Context, userID int, role domain.UserRole, claims *auth.TokenClaims) {
+	utils.SetUserContext(c, userID, role)
+	c.Set("session_id", claims.SessionID)
+}
+
 func (m *Middleware) RoleMiddleware(roles ...domain.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 
@@ -28,6 +28,8 @@ func (h *NotificationHandler) RegisterRoutes(router *gin.RouterGroup) {
 		notifications.GET("/unread", h.GetUnreadNotifications)
 		notifications.GET("", h.GetNotifications)
 		notifications.GET("/count", h.GetUnreadCount)
+		notifications.GET("/preferences", h.GetPreferences)
+		notifications.PUT("/preferences", h.UpdatePreferences)
 		notifications.POST("/:id/read", h.MarkAsRead)
 		notifications.POST("/read-multiple", h.MarkMultipleAsRead)
 		notifications.POST("/read-all", h.MarkAllAsRead)
@@ -160,6 +162,61 @@ func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("количество получено", result))
 }
 
+// @Summary Получение настроек уведомлений
+// @Description Возвращает отключенные типы уведомлений, тихие часы и каналы доставки текущего пользователя
+// @Tags notifications
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=domain.NotificationPreferences}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.notificationUseCase.GetPreferences(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при получении настроек: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("настройки уведомлений получены", preferences))
+}
+
+// @Summary Обновление настроек уведомлений
+// @Description Частично обновляет настройки уведомлений. В тихие часы несрочные push откладываются до их окончания, срочные уведомления (проблемы с замком) доставляются всегда
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body domain.UpdateNotificationPreferencesRequest true "Изменяемые настройки"
+// @Success 200 {object} domain.SuccessResponse{data=domain.NotificationPreferences}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /notifications/preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var request domain.UpdateNotificationPreferencesRequest
+	if !utils.ShouldBindJSON(c, &request) {
+		return
+	}
+
+	preferences, err := h.notificationUseCase.UpdatePreferences(userID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("настройки уведомлений обновлены", preferences))
+}
+
 // @Summary Пометить уведомление как прочитанное
 // @Description Помечает указанное уведомление как прочитанное
 // @Tags notifications
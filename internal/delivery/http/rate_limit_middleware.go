@@ -0,0 +1,135 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/i18n"
+)
+
+// rateLimitBodyLimit тело больше этого размера не разбирается при поиске ключа лимита
+const rateLimitBodyLimit = 64 << 10
+
+// RateLimitKeyFunc возвращает ключ, по которому считается лимит. false - ключа нет, лимит не применяется.
+type RateLimitKeyFunc func(c *gin.Context) (string, bool)
+
+// RateLimitByIP ключ - IP-адрес клиента.
+func RateLimitByIP(c *gin.Context) (string, bool) {
+	ip := c.ClientIP()
+	return ip, ip != ""
+}
+
+// RateLimitByUserID ключ - авторизованный пользователь. Используется за AuthMiddleware.
+func RateLimitByUserID(c *gin.Context) (string, bool) {
+	userID, ok := utils.GetUserIDFromContext(c)
+	if !ok {
+		return "", false
+	}
+	return strconv.Itoa(userID), true
+}
+
+// RateLimitByPhone ключ - номер телефона из параметра пути phone или поля phone тела запроса,
+// приведенный к одному виду, чтобы лимит нельзя было обойти другой записью того же номера.
+func RateLimitByPhone(c *gin.Context) (string, bool) {
+	phone := c.Param("phone")
+	if phone == "" {
+		var body struct {
+			Phone string `json:"phone"`
+		}
+		if !peekJSONBody(c, &body) {
+			return "", false
+		}
+		phone = body.Phone
+	}
+
+	phone = utils.NormalizePhone(phone)
+	return phone, phone != ""
+}
+
+// peekJSONBody разбирает тело запроса, оставляя его доступным обработчику.
+func peekJSONBody(c *gin.Context, target interface{}) bool {
+	if c.Request.Body == nil {
+		return false
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, rateLimitBodyLimit+1))
+	rest := c.Request.Body
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), rest), rest}
+	if err != nil || len(data) > rateLimitBodyLimit {
+		return false
+	}
+
+	return json.Unmarshal(data, target) == nil
+}
+
+// RateLimit ограничивает частоту запросов по правилу policy для ключа keyFunc. При превышении
+// отвечает 429 с заголовком Retry-After. Если Redis недоступен, запрос пропускается.
+func (m *Middleware) RateLimit(policy domain.RateLimitPolicy, keyFunc RateLimitKeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.rateLimiter == nil {
+			c.Next()
+			return
+		}
+
+		key, ok := keyFunc(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		decision, err := m.rateLimiter.Allow(policy, key)
+		if err != nil {
+			log.Printf("⚠️ Лимит %s не проверен: %v", policy, err)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+
+		if !decision.Allowed {
+			seconds := setRetryAfter(c, decision.RetryAfter)
+			RespondWithErrorStatus(c, http.StatusTooManyRequests, apperrors.RateLimitExceeded.New(i18n.Params{
+				"seconds": strconv.Itoa(seconds),
+			}))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// setRetryAfter выставляет заголовок Retry-After (целые секунды, с округлением вверх) и возвращает его значение.
+func setRetryAfter(c *gin.Context, retryAfter time.Duration) int {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	return seconds
+}
+
+// respondLocked отвечает 429 на попытку входа или проверки OTP во время блокировки перебора.
+func respondLocked(c *gin.Context, definition *apperrors.Definition, lockedFor time.Duration) {
+	seconds := setRetryAfter(c, lockedFor)
+	minutes := (seconds + 59) / 60
+
+	RespondWithErrorStatus(c, http.StatusTooManyRequests, definition.New(i18n.Params{
+		"minutes": strconv.Itoa(minutes),
+	}))
+}
@@ -0,0 +1,399 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+)
+
+type ReviewHandler struct {
+	reviewUseCase domain.ReviewUseCase
+	middleware    *Middleware
+}
+
+func NewReviewHandler(reviewUseCase domain.ReviewUseCase, middleware *Middleware) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUseCase: reviewUseCase,
+		middleware:    middleware,
+	}
+}
+
+func (h *ReviewHandler) RegisterRoutes(router *gin.RouterGroup) {
+	reviews := router.Group("/reviews")
+	{
+		reviews.GET("/apartments/:apartmentId", h.GetApartmentReviews)
+	}
+
+	authorized := reviews.Group("/", h.middleware.AuthMiddleware())
+	{
+		authorized.POST("/apartments", h.CreateApartmentReview)
+		authorized.POST("/apartments/:reviewId/reply", h.ReplyToApartmentReview)
+		authorized.POST("/renters", h.CreateRenterReview)
+		authorized.GET("/bookings/:bookingId", h.GetBookingReviewState)
+		authorized.GET("/renters/:renterId", h.middleware.RoleMiddleware(domain.RoleOwner, domain.RoleAdmin, domain.RoleModerator), h.GetRenterReviews)
+	}
+
+	moderation := reviews.Group("/moderation", h.middleware.AuthMiddleware(), h.middleware.RoleMiddleware(domain.RoleAdmin, domain.RoleModerator))
+	{
+		moderation.GET("/apartments", h.GetApartmentReviewsForModeration)
+		moderation.PUT("/apartments/:reviewId", h.ModerateApartmentReview)
+		moderation.GET("/renters", h.GetRenterReviewsForModeration)
+		moderation.PUT("/renters/:reviewId", h.ModerateRenterReview)
+	}
+}
+
+// @Summary Отзывы о квартире
+// @Description Возвращает опубликованные отзывы о квартире и ее агрегированный рейтинг
+// @Tags reviews
+// @Produce json
+// @Param apartmentId path int true "ID квартиры"
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(10)
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 404 {object} domain.ErrorResponse
+// @Router /reviews/apartments/{apartmentId} [get]
+func (h *ReviewHandler) GetApartmentReviews(c *gin.Context) {
+	apartmentID, ok := utils.ParseIDParam(c, "apartmentId")
+	if !ok {
+		return
+	}
+
+	rating, err := h.reviewUseCase.GetApartmentRating(apartmentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	page, pageSize := utils.ParsePagination(c)
+	reviews, total, err := h.reviewUseCase.GetApartmentReviews(apartmentID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения отзывов: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("отзывы получены", gin.H{
+		"rating":     rating,
+		"reviews":    reviews,
+		"pagination": reviewsPagination(total, page, pageSize),
+	}))
+}
+
+// @Summary Отзыв о квартире
+// @Description Арендатор оценивает чистоту, соответствие описанию, расположение и заселение по завершенному бронированию. Отзыв публикуется после модерации
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body domain.CreateApartmentReviewRequest true "Отзыв"
+// @Success 201 {object} domain.SuccessResponse{data=domain.ApartmentReview}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /reviews/apartments [post]
+func (h *ReviewHandler) CreateApartmentReview(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var request domain.CreateApartmentReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	review, err := h.reviewUseCase.CreateApartmentReview(userID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.NewSuccessResponse("отзыв отправлен на модерацию", review))
+}
+
+// @Summary Ответ владельца на отзыв
+// @Description Владелец публично отвечает на опубликованный отзыв о своей квартире. Повторный ответ заменяет предыдущий
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param reviewId path int true "ID отзыва"
+// @Param request body domain.ReviewReplyRequest true "Ответ"
+// @Success 200 {object} domain.SuccessResponse{data=domain.ApartmentReview}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /reviews/apartments/{reviewId}/reply [post]
+func (h *ReviewHandler) ReplyToApartmentReview(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	reviewID, ok := utils.ParseIDParam(c, "reviewId")
+	if !ok {
+		return
+	}
+
+	var request domain.ReviewReplyRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	review, err := h.reviewUseCase.ReplyToApartmentReview(userID, reviewID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("ответ сохранен", review))
+}
+
+// @Summary Отзыв об арендаторе
+// @Description Владелец оценивает арендатора по завершенному бронированию. Отзыв публикуется после модерации
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body domain.CreateRenterReviewRequest true "Отзыв"
+// @Success 201 {object} domain.SuccessResponse{data=domain.RenterReview}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /reviews/renters [post]
+func (h *ReviewHandler) CreateRenterReview(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var request domain.CreateRenterReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	review, err := h.reviewUseCase.CreateRenterReview(userID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.NewSuccessResponse("отзыв отправлен на модерацию", review))
+}
+
+// @Summary Отзывы об арендаторе
+// @Description Возвращает опубликованные отзывы владельцев об арендаторе и его рейтинг
+// @Tags reviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param renterId path int true "ID арендатора"
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(10)
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /reviews/renters/{renterId} [get]
+func (h *ReviewHandler) GetRenterReviews(c *gin.Context) {
+	renterID, ok := utils.ParseIDParam(c, "renterId")
+	if !ok {
+		return
+	}
+
+	rating, err := h.reviewUseCase.GetRenterRating(renterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения рейтинга: "+err.Error()))
+		return
+	}
+
+	page, pageSize := utils.ParsePagination(c)
+	reviews, total, err := h.reviewUseCase.GetRenterReviews(renterID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения отзывов: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("отзывы получены", gin.H{
+		"rating":     rating,
+		"reviews":    reviews,
+		"pagination": reviewsPagination(total, page, pageSize),
+	}))
+}
+
+// @Summary Отзывы по бронированию
+// @Description Показывает участнику бронирования оставленные отзывы, срок и возможность оставить новый отзыв
+// @Tags reviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param bookingId path int true "ID бронирования"
+// @Success 200 {object} domain.SuccessResponse{data=domain.BookingReviewState}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Router /reviews/bookings/{bookingId} [get]
+func (h *ReviewHandler) GetBookingReviewState(c *gin.Context) {
+	userID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	bookingID, ok := utils.ParseIDParam(c, "bookingId")
+	if !ok {
+		return
+	}
+
+	state, err := h.reviewUseCase.GetBookingReviewState(userID, bookingID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("состояние отзывов получено", state))
+}
+
+// @Summary Очередь модерации отзывов о квартирах
+// @Description Возвращает отзывы о квартирах с указанным статусом, начиная с самых старых (только для администраторов и модераторов)
+// @Tags reviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Статус отзыва (pending, published, rejected)" default(pending)
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(10)
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /reviews/moderation/apartments [get]
+func (h *ReviewHandler) GetApartmentReviewsForModeration(c *gin.Context) {
+	page, pageSize := utils.ParsePagination(c)
+	status := domain.ReviewStatus(c.DefaultQuery("status", string(domain.ReviewStatusPending)))
+
+	reviews, total, err := h.reviewUseCase.GetApartmentReviewsForModeration(status, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения отзывов: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("отзывы получены", gin.H{
+		"reviews":    reviews,
+		"pagination": reviewsPagination(total, page, pageSize),
+	}))
+}
+
+// @Summary Модерация отзыва о квартире
+// @Description Публикует или отклоняет отзыв о квартире. Рейтинг квартиры пересчитывается автоматически
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param reviewId path int true "ID отзыва"
+// @Param request body domain.ModerateReviewRequest true "Решение модератора (published или rejected)"
+// @Success 200 {object} domain.SuccessResponse{data=domain.ApartmentReview}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Router /reviews/moderation/apartments/{reviewId} [put]
+func (h *ReviewHandler) ModerateApartmentReview(c *gin.Context) {
+	moderatorID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	reviewID, ok := utils.ParseIDParam(c, "reviewId")
+	if !ok {
+		return
+	}
+
+	var request domain.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	review, err := h.reviewUseCase.ModerateApartmentReview(moderatorID, reviewID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("отзыв промодерирован", review))
+}
+
+// @Summary Очередь модерации отзывов об арендаторах
+// @Description Возвращает отзывы об арендаторах с указанным статусом, начиная с самых старых (только для администраторов и модераторов)
+// @Tags reviews
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "Статус отзыва (pending, published, rejected)" default(pending)
+// @Param page query int false "Номер страницы" default(1)
+// @Param page_size query int false "Размер страницы" default(10)
+// @Success 200 {object} domain.SuccessResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /reviews/moderation/renters [get]
+func (h *ReviewHandler) GetRenterReviewsForModeration(c *gin.Context) {
+	page, pageSize := utils.ParsePagination(c)
+	status := domain.ReviewStatus(c.DefaultQuery("status", string(domain.ReviewStatusPending)))
+
+	reviews, total, err := h.reviewUseCase.GetRenterReviewsForModeration(status, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка получения отзывов: "+err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("отзывы получены", gin.H{
+		"reviews":    reviews,
+		"pagination": reviewsPagination(total, page, pageSize),
+	}))
+}
+
+// @Summary Модерация отзыва об арендаторе
+// @Description Публикует или отклоняет отзыв владельца об арендаторе
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param reviewId path int true "ID отзыва"
+// @Param request body domain.ModerateReviewRequest true "Решение модератора (published или rejected)"
+// @Success 200 {object} domain.SuccessResponse{data=domain.RenterReview}
+// @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Router /reviews/moderation/renters/{reviewId} [put]
+func (h *ReviewHandler) ModerateRenterReview(c *gin.Context) {
+	moderatorID, ok := utils.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	reviewID, ok := utils.ParseIDParam(c, "reviewId")
+	if !ok {
+		return
+	}
+
+	var request domain.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("некорректные данные запроса"))
+		return
+	}
+
+	review, err := h.reviewUseCase.ModerateRenterReview(moderatorID, reviewID, &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("отзыв промодерирован", review))
+}
+
+func reviewsPagination(total, page, pageSize int) gin.H {
+	return gin.H{
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+		"pages":     (total + pageSize - 1) / pageSize,
+	}
+}
@@ -75,6 +75,31 @@ func (h *SchedulerHandler) GetSchedulerMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, domain.NewSuccessResponse("метрики планировщика получены", response))
 }
 
+// @Summary Итоги последней сверки платежей
+// @Description Возвращает результаты последнего запуска сверки зависших платежей и неоплаченных бронирований с FreedomPay
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} domain.SuccessResponse{data=services.PaymentReconciliationSummary}
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 403 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
+// @Router /admin/scheduler/payment-reconciliation [get]
+func (h *SchedulerHandler) GetPaymentReconciliation(c *gin.Context) {
+	summary, err := h.schedulerService.GetLastPaymentReconciliation(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse(err.Error()))
+		return
+	}
+
+	if summary == nil {
+		c.JSON(http.StatusOK, domain.NewSuccessResponse("сверка платежей еще не выполнялась", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.NewSuccessResponse("итоги сверки платежей получены", summary))
+}
+
 func (h *SchedulerHandler) calculateTasksPerSecond(metrics *services.SchedulerMetrics) float64 {
 	if metrics.ProcessingTimeMs == 0 {
 		return 0
@@ -132,5 +157,6 @@ func (h *SchedulerHandler) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		scheduler.GET("/stats", h.GetStats)
 		scheduler.GET("/metrics", h.GetSchedulerMetrics)
+		scheduler.GET("/payment-reconciliation", h.GetPaymentReconciliation)
 	}
 }
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/services"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 
 	"github.com/gin-gonic/gin"
 )
@@ -38,37 +40,62 @@ func (h *TuyaWebhookHandler) RegisterProtectedRoutes(router *gin.RouterGroup) {
 }
 
 // @Summary Webhook для событий Tuya
-// @Description Обрабатывает webhook события от Tuya API (онлайн/оффлайн статус, heartbeat, данные батареи)
+// @Description Обрабатывает webhook события от Tuya API (онлайн/оффлайн статус, heartbeat, данные батареи). Сообщение должно быть подписано и зашифровано ключом ClientSecret и отправлено не раньше чем 12 часов назад. Повторная доставка того же события подтверждается без повторной обработки
 // @Tags tuya-webhooks
 // @Accept json
 // @Produce json
-// @Param event body domain.TuyaWebhookEvent true "Событие от Tuya"
-// @Success 200 {object} domain.SuccessResponse
+// @Param message body domain.TuyaWebhookMessage true "Сообщение от Tuya"
+// @Success 200 {object} domain.SuccessResponse{data=domain.TuyaWebhookResult}
 // @Failure 400 {object} domain.ErrorResponse
+// @Failure 401 {object} domain.ErrorResponse
+// @Failure 500 {object} domain.ErrorResponse
 // @Router /webhooks/tuya [post]
 func (h *TuyaWebhookHandler) HandleTuyaWebhook(c *gin.Context) {
-	var event domain.TuyaWebhookEvent
-	if err := c.ShouldBindJSON(&event); err != nil {
+	services.RecordTuyaWebhookReceived()
+
+	var message domain.TuyaWebhookMessage
+	if err := c.ShouldBindJSON(&message); err != nil {
 		log.Printf("Ошибка парсинга Tuya webhook: %v", err)
-		c.JSON(http.StatusBadRequest, domain.ErrorResponse{
-			Error: "Неверный формат данных",
-		})
+		services.RecordTuyaWebhookVerificationFailure(apperrors.TuyaWebhookMalformed.Code)
+		RespondWithErrorStatus(c, http.StatusBadRequest, apperrors.TuyaWebhookMalformed.Wrap(err, nil))
 		return
 	}
 
-	log.Printf("Получен Tuya webhook: BizCode=%s, DevID=%s", event.BizCode, event.DevID)
-
-	if err := h.lockUseCase.ProcessTuyaWebhookEvent(&event); err != nil {
-		log.Printf("Ошибка обработки Tuya webhook: %v", err)
-		c.JSON(http.StatusInternalServerError, domain.ErrorResponse{
-			Error: "Ошибка обработки события",
-		})
+	result, err := h.lockUseCase.ReceiveTuyaWebhook(&message)
+	if err != nil {
+		if code, ok := tuyaVerificationFailure(err); ok {
+			log.Printf("🚫 Tuya webhook с %s отклонен: %s", c.ClientIP(), code)
+			services.RecordTuyaWebhookVerificationFailure(code)
+		} else {
+			log.Printf("Ошибка обработки Tuya webhook: %v", err)
+			services.RecordTuyaWebhookFailed()
+		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, domain.SuccessResponse{
-		Message: "Событие успешно обработано",
-	})
+	services.RecordTuyaWebhookProcessed(result.Duplicate)
+
+	responseMessage := "Событие успешно обработано"
+	if result.Duplicate {
+		responseMessage = "Событие уже обработано"
+	}
+	c.JSON(http.StatusOK, domain.NewSuccessResponse(responseMessage, result))
+}
+
+// tuyaVerificationFailure возвращает код ошибки, если сообщение отклонено при проверке, а не при обработке.
+func tuyaVerificationFailure(err error) (string, bool) {
+	for _, definition := range []*apperrors.Definition{
+		apperrors.TuyaWebhookMalformed,
+		apperrors.TuyaWebhookSignatureInvalid,
+		apperrors.TuyaWebhookExpired,
+		apperrors.TuyaWebhookDecryptFailed,
+	} {
+		if definition.Is(err) {
+			return definition.Code, true
+		}
+	}
+	return "", false
 }
 
 // @Summary Получить статус автообновления замка
@@ -14,6 +14,8 @@ import (
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/services"
 	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/i18n"
 )
 
 type UserHandler struct {
@@ -127,14 +129,15 @@ func (h *UserHandler) GetMe(c *gin.Context) {
 }
 
 type UpdateProfileRequest struct {
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Email     string `json:"email"`
-	CityID    int    `json:"city_id"`
+	FirstName         string `json:"first_name"`
+	LastName          string `json:"last_name"`
+	Email             string `json:"email"`
+	CityID            int    `json:"city_id"`
+	PreferredLanguage string `json:"preferred_language" example:"kk"`
 }
 
 // @Summary Обновление профиля
-// @Description Обновляет данные профиля пользователя
+// @Description Обновляет данные профиля пользователя, в том числе язык уведомлений (preferred_language: ru, kk, en)
 // @Tags users
 // @Accept json
 // @Produce json
@@ -191,6 +194,16 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		updated = true
 	}
 
+	if req.PreferredLanguage != "" {
+		lang, ok := i18n.Parse(req.PreferredLanguage)
+		if !ok {
+			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("неподдерживаемый язык: допустимы ru, kk, en"))
+			return
+		}
+		user.PreferredLanguage = string(lang)
+		updated = true
+	}
+
 	if !updated {
 		c.JSON(http.StatusBadRequest, domain.NewErrorResponse("не указаны поля для обновления"))
 		return
@@ -565,7 +578,10 @@ func (h *UserHandler) enrichBookingsWithPriceDetails(bookings []*domain.Booking)
 
 			var basePrice int
 			var calculationInfo string
-			if booking.Duration == 24 && apartment.RentalTypeDaily {
+			if booking.Nights > 0 {
+				basePrice = apartment.DailyPrice * booking.Nights
+				calculationInfo = fmt.Sprintf("Посуточная аренда: %d тг × %d ноч. = %d тг", apartment.DailyPrice, booking.Nights, basePrice)
+			} else if booking.Duration == 24 && apartment.RentalTypeDaily {
 				basePrice = apartment.DailyPrice
 				calculationInfo = fmt.Sprintf("Посуточная аренда: %d тг", apartment.DailyPrice)
 			} else {
@@ -584,7 +600,9 @@ func (h *UserHandler) enrichBookingsWithPriceDetails(bookings []*domain.Booking)
 			}
 
 			var serviceFeeInfo string
-			if booking.Duration == 24 {
+			if booking.Nights > 0 {
+				serviceFeeInfo = fmt.Sprintf("Фиксированный сервисный сбор: %d тг", booking.ServiceFee)
+			} else if booking.Duration == 24 {
 				serviceFeeInfo = "Фиксированный сервисный сбор: 3000 тг"
 			} else {
 				serviceFeePercentage := domain.ServiceFeePercentage
@@ -834,8 +852,8 @@ func (h *UserHandler) DeleteUserByAdmin(c *gin.Context) {
 	}
 
 	if err := h.userUseCase.DeleteUser(userID, adminID.(int)); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse("пользователь не найден"))
+		if _, ok := apperrors.AsAppError(err); ok {
+			RespondWithErrorStatus(c, http.StatusBadRequest, err)
 			return
 		}
 		c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка при удалении пользователя"))
@@ -1153,13 +1171,7 @@ func (h *UserHandler) AdminUpdateUserRole(c *gin.Context) {
 	newRole := domain.UserRole(req.Role)
 	err = h.userUseCase.UpdateUserRole(userID, newRole, adminID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse("пользователь не найден"))
-		} else if strings.Contains(err.Error(), "only admins") {
-			c.JSON(http.StatusForbidden, domain.NewErrorResponse("недостаточно прав"))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка изменения роли: "+err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, fmt.Errorf("ошибка изменения роли: %w", err))
 		return
 	}
 
@@ -1210,15 +1222,7 @@ func (h *UserHandler) AdminUpdateUserStatus(c *gin.Context) {
 
 	err = h.userUseCase.UpdateUserStatus(userID, req.IsActive, req.Reason, adminID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse("пользователь не найден"))
-		} else if strings.Contains(err.Error(), "only admins") {
-			c.JSON(http.StatusForbidden, domain.NewErrorResponse("недостаточно прав"))
-		} else if strings.Contains(err.Error(), "not yet implemented") {
-			c.JSON(http.StatusNotImplemented, domain.NewErrorResponse("функция изменения статуса пользователя пока не реализована"))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка изменения статуса: "+err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, fmt.Errorf("ошибка изменения статуса: %w", err))
 		return
 	}
 
@@ -1274,17 +1278,7 @@ func (h *UserHandler) AdminSetUserPassword(c *gin.Context) {
 
 	err = h.userUseCase.AdminSetPassword(userID, req.Password, adminID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, domain.NewErrorResponse("пользователь не найден"))
-		} else if strings.Contains(err.Error(), "only admins") {
-			c.JSON(http.StatusForbidden, domain.NewErrorResponse("недостаточно прав"))
-		} else if strings.Contains(err.Error(), "cannot be set for regular users") {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("пароль нельзя установить обычным пользователям"))
-		} else if strings.Contains(err.Error(), "at least 6 characters") {
-			c.JSON(http.StatusBadRequest, domain.NewErrorResponse("пароль должен содержать минимум 6 символов"))
-		} else {
-			c.JSON(http.StatusInternalServerError, domain.NewErrorResponse("ошибка установки пароля: "+err.Error()))
-		}
+		RespondWithErrorStatus(c, http.StatusInternalServerError, fmt.Errorf("ошибка установки пароля: %w", err))
 		return
 	}
 
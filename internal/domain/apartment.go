@@ -13,6 +13,17 @@ const (
 	ListingTypeRealtor = "realtor"
 )
 
+// Сортировка списка квартир (параметр sort_by)
+const (
+	ApartmentSortNewest       = "newest"
+	ApartmentSortRating       = "rating"
+	ApartmentSortReviewsCount = "reviews_count"
+	ApartmentSortPriceAsc     = "price_asc"
+	ApartmentSortPriceDesc    = "price_desc"
+	ApartmentSortDistance     = "distance"
+	ApartmentSortRelevance    = "relevance"
+)
+
 type ApartmentStatus string
 
 const (
@@ -94,6 +105,10 @@ type Apartment struct {
 	ServiceFeePercentage int                  `json:"service_fee_percentage"`
 	RentalTypeHourly     bool                 `json:"rental_type_hourly"`
 	RentalTypeDaily      bool                 `json:"rental_type_daily"`
+	CheckInTime          string               `json:"check_in_time"`
+	CheckOutTime         string               `json:"check_out_time"`
+	MinNights            int                  `json:"min_nights"`
+	MaxNights            int                  `json:"max_nights"`
 	IsFree               bool                 `json:"is_free"`
 	IsFavorite           bool                 `json:"is_favorite"`
 	Status               ApartmentStatus      `json:"status"`
@@ -110,6 +125,12 @@ type Apartment struct {
 	Location             *ApartmentLocation   `json:"location,omitempty"`
 	ViewCount            int                  `json:"view_count"`
 	BookingCount         int                  `json:"booking_count"`
+	Rating               float64              `json:"rating"`
+	ReviewsCount         int                  `json:"reviews_count"`
+	RatingCleanliness    float64              `json:"rating_cleanliness"`
+	RatingAccuracy       float64              `json:"rating_accuracy"`
+	RatingLocation       float64              `json:"rating_location"`
+	RatingCheckIn        float64              `json:"rating_check_in"`
 	CreatedAt            time.Time            `json:"created_at"`
 	UpdatedAt            time.Time            `json:"updated_at"`
 }
@@ -166,6 +187,8 @@ type ApartmentRepository interface {
 	AddAmenitiesToApartment(apartmentID int, amenityIDs []int) error
 	GetHouseRulesByApartmentID(apartmentID int) ([]*HouseRules, error)
 	GetAmenitiesByApartmentID(apartmentID int) ([]*PopularAmenities, error)
+
+	UpdateStaySettings(apartmentID int, settings *UpdateApartmentStaySettingsRequest) error
 }
 
 type ApartmentUseCase interface {
@@ -219,6 +242,8 @@ type ApartmentUseCase interface {
 
 	GetBookedDates(apartmentID int, daysAhead int) ([]string, error)
 
+	UpdateStaySettings(apartmentID int, request *UpdateApartmentStaySettingsRequest) (*Apartment, error)
+
 	ConfirmApartmentAgreement(apartmentID, userID int, request *ConfirmApartmentAgreementRequest) (*Apartment, error)
 
 	IncrementViewCount(apartmentID int) error
@@ -283,6 +308,10 @@ type ApartmentResponse struct {
 	ServiceFeePercentage int                  `json:"service_fee_percentage"`
 	RentalTypeHourly     bool                 `json:"rental_type_hourly"`
 	RentalTypeDaily      bool                 `json:"rental_type_daily"`
+	CheckInTime          string               `json:"check_in_time"`
+	CheckOutTime         string               `json:"check_out_time"`
+	MinNights            int                  `json:"min_nights"`
+	MaxNights            int                  `json:"max_nights"`
 	IsFree               bool                 `json:"is_free"`
 	IsFavorite           bool                 `json:"is_favorite"`
 	Status               ApartmentStatus      `json:"status"`
@@ -299,6 +328,12 @@ type ApartmentResponse struct {
 	Location             *ApartmentLocation   `json:"location,omitempty"`
 	ViewCount            int                  `json:"view_count"`
 	BookingCount         int                  `json:"booking_count"`
+	Rating               float64              `json:"rating"`
+	ReviewsCount         int                  `json:"reviews_count"`
+	RatingCleanliness    float64              `json:"rating_cleanliness"`
+	RatingAccuracy       float64              `json:"rating_accuracy"`
+	RatingLocation       float64              `json:"rating_location"`
+	RatingCheckIn        float64              `json:"rating_check_in"`
 	CreatedAt            string               `json:"created_at"`
 	UpdatedAt            string               `json:"updated_at"`
 }
@@ -307,6 +342,14 @@ type UpdateApartmentTypeIDRequest struct {
 	ApartmentTypeID *int `json:"apartment_type_id" validate:"required"`
 }
 
+// UpdateApartmentStaySettingsRequest время заезда/выезда и ограничения по количеству ночей для посуточной аренды
+type UpdateApartmentStaySettingsRequest struct {
+	CheckInTime  string `json:"check_in_time" binding:"required" example:"14:00"`
+	CheckOutTime string `json:"check_out_time" binding:"required" example:"12:00"`
+	MinNights    int    `json:"min_nights" binding:"required,min=1" example:"1"`
+	MaxNights    int    `json:"max_nights" binding:"required,min=1" example:"30"`
+}
+
 type AdminUpdateCountersRequest struct {
 	ViewCount    *int `json:"view_count,omitempty" validate:"omitempty,min=0"`
 	BookingCount *int `json:"booking_count,omitempty" validate:"omitempty,min=0"`
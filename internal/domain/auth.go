@@ -46,24 +46,69 @@ type Tokens struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+// Причины отзыва сессии.
+const (
+	SessionRevokeReasonLogout      = "logout"
+	SessionRevokeReasonLogoutAll   = "logout_all"
+	SessionRevokeReasonUserRevoked = "revoked_by_user"
+	SessionRevokeReasonTokenReuse  = "refresh_token_reuse"
+	SessionRevokeReasonUserBlocked = "user_blocked"
+)
+
+// Session серверная сессия входа (устройство пользователя). Refresh-токены сессии образуют одно семейство:
+// при каждом обновлении токен ротируется, а предъявление уже использованного токена отзывает сессию целиком.
 type Session struct {
-	RefreshToken string    `json:"refresh_token"`
-	UserID       int       `json:"user_id"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID               string     `json:"id"`
+	UserID           int        `json:"user_id"`
+	RefreshTokenHash string     `json:"-"`
+	Device           string     `json:"device"`
+	UserAgent        string     `json:"user_agent"`
+	IPAddress        string     `json:"ip_address"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LastUsedAt       time.Time  `json:"last_used_at"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+	RevokeReason     string     `json:"revoke_reason,omitempty"`
+	IsCurrent        bool       `json:"is_current"`
+}
+
+// IsActive сессия не отозвана и не истекла.
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// SessionClient данные клиента, от которого пришел запрос входа или обновления токенов.
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
 }
 
 type AuthUseCase interface {
-	SignIn(phone, password string) (*Tokens, *User, error)
-	RefreshTokens(refreshToken string) (*Tokens, error)
-	SignOut(refreshToken string) error
+	SignIn(phone, password string, client SessionClient) (*Tokens, *User, error)
+	// IssueTokens открывает новую сессию для уже аутентифицированного пользователя (OTP, регистрация).
+	IssueTokens(user *User, client SessionClient) (*Tokens, error)
+	RefreshTokens(refreshToken string, client SessionClient) (*Tokens, error)
+	SignOut(accessToken string) error
 	SignOutAll(userID int) error
 	GetUserFromToken(accessToken string) (*User, error)
+	// IsSessionActive проверяет, что сессия access-токена не отозвана.
+	IsSessionActive(sessionID string) (bool, error)
+	GetUserSessions(userID int, currentSessionID string) ([]*Session, error)
+	RevokeUserSession(userID int, sessionID string) error
+	// RevokeAllUserSessions отзывает все сессии пользователя, в том числе уже выданные access-токены.
+	RevokeAllUserSessions(userID int, reason string) error
 }
 
 type AuthRepository interface {
-	CreateSession(session Session) error
-	GetSessionByToken(refreshToken string) (*Session, error)
-	DeleteSession(refreshToken string) error
-	DeleteAllUserSessions(userID int) error
+	CreateSession(session *Session) error
+	// GetSessionByID возвращает nil, nil, если сессии нет.
+	GetSessionByID(sessionID string) (*Session, error)
+	// RotateSession заменяет хеш refresh-токена, только если текущий хеш равен previousHash.
+	// Возвращает false, если токен уже ротирован параллельным запросом.
+	RotateSession(sessionID, previousHash, newHash string, client SessionClient, expiresAt time.Time) (bool, error)
+	GetActiveSessionsByUserID(userID int) ([]*Session, error)
+	// RevokeSession возвращает false, если сессия не найдена или уже отозвана.
+	RevokeSession(sessionID, reason string) (bool, error)
+	// RevokeAllUserSessions возвращает идентификаторы отозванных сессий.
+	RevokeAllUserSessions(userID int, reason string) ([]string, error)
 }
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+)
+
+type AvailabilityBlockReason string
+
+const (
+	AvailabilityBlockReasonRepair   AvailabilityBlockReason = "repair"
+	AvailabilityBlockReasonCleaning AvailabilityBlockReason = "cleaning"
+	AvailabilityBlockReasonPersonal AvailabilityBlockReason = "personal"
+)
+
+func (r AvailabilityBlockReason) IsValid() bool {
+	switch r {
+	case AvailabilityBlockReasonRepair, AvailabilityBlockReasonCleaning, AvailabilityBlockReasonPersonal:
+		return true
+	}
+	return false
+}
+
+// AvailabilityBlock период, закрытый владельцем для бронирования без создания брони.
+type AvailabilityBlock struct {
+	ID          int                     `json:"id"`
+	ApartmentID int                     `json:"apartment_id"`
+	StartDate   time.Time               `json:"start_date"`
+	EndDate     time.Time               `json:"end_date"`
+	Reason      AvailabilityBlockReason `json:"reason"`
+	Comment     string                  `json:"comment,omitempty"`
+	CreatedBy   *int                    `json:"created_by,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+type AvailabilityBlockRequest struct {
+	StartDate string                  `json:"start_date" binding:"required" example:"2026-11-20T10:00:00"`
+	EndDate   string                  `json:"end_date" binding:"required" example:"2026-11-22T18:00:00"`
+	Reason    AvailabilityBlockReason `json:"reason" binding:"required" example:"repair"`
+	Comment   string                  `json:"comment,omitempty"`
+}
+
+type ApartmentCalendarEntryType string
+
+const (
+	CalendarEntryBooking  ApartmentCalendarEntryType = "booking"
+	CalendarEntryBlock    ApartmentCalendarEntryType = "block"
+	CalendarEntryExternal ApartmentCalendarEntryType = "external"
+)
+
+// ApartmentCalendarEntry занятый период в календаре владельца: бронирование, блокировка или период с другой площадки.
+type ApartmentCalendarEntry struct {
+	Type          ApartmentCalendarEntryType `json:"type"`
+	ID            int                        `json:"id"`
+	StartDate     time.Time                  `json:"start_date"`
+	EndDate       time.Time                  `json:"end_date"`
+	BookingNumber string                     `json:"booking_number,omitempty"`
+	Status        BookingStatus              `json:"status,omitempty"`
+	Reason        AvailabilityBlockReason    `json:"reason,omitempty"`
+	Title         string                     `json:"title,omitempty"`
+}
+
+type ApartmentCalendar struct {
+	ApartmentID int                       `json:"apartment_id"`
+	From        time.Time                 `json:"from"`
+	To          time.Time                 `json:"to"`
+	Entries     []*ApartmentCalendarEntry `json:"entries"`
+}
+
+type AvailabilityBlockRepository interface {
+	Create(block *AvailabilityBlock) error
+	GetByID(id int) (*AvailabilityBlock, error)
+	GetByApartmentID(apartmentID int, from, to time.Time) ([]*AvailabilityBlock, error)
+	Update(block *AvailabilityBlock) error
+	Delete(id int) error
+}
+
+type AvailabilityBlockUseCase interface {
+	GetApartmentBlocks(apartmentID int, from, to time.Time) ([]*AvailabilityBlock, error)
+	CreateBlock(apartmentID, userID int, request *AvailabilityBlockRequest) (*AvailabilityBlock, error)
+	UpdateBlock(apartmentID, blockID int, request *AvailabilityBlockRequest) (*AvailabilityBlock, error)
+	DeleteBlock(apartmentID, blockID int) error
+
+	GetOwnerCalendar(apartmentID int, from, to time.Time) (*ApartmentCalendar, error)
+}
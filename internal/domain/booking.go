@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"math"
 	"time"
 )
 
@@ -25,48 +26,51 @@ const (
 )
 
 type Booking struct {
-	ID                 int           `json:"id"`
-	RenterID           int           `json:"renter_id"`
-	Renter             *Renter       `json:"renter,omitempty"`
-	ApartmentID        int           `json:"apartment_id"`
-	Apartment          *Apartment    `json:"apartment,omitempty"`
-	ContractID         *int          `json:"contract_id,omitempty"`
-	StartDate          time.Time     `json:"start_date"`
-	EndDate            time.Time     `json:"end_date"`
-	Duration           int           `json:"duration"`
-	CleaningDuration   int           `json:"cleaning_duration"`
-	Status             BookingStatus `json:"status"`
-	TotalPrice         int           `json:"total_price"`
-	ServiceFee         int           `json:"service_fee"`
-	FinalPrice         int           `json:"final_price"`
-	IsContractAccepted bool          `json:"is_contract_accepted"`
-	PaymentID          *int64        `json:"payment_id,omitempty"`
-	CancellationReason *string       `json:"cancellation_reason"`
-	OwnerComment       *string       `json:"owner_comment"`
-	BookingNumber      string        `json:"booking_number"`
-	DoorStatus         DoorStatus    `json:"door_status"`
-	LastDoorAction     *time.Time    `json:"last_door_action"`
-	CanExtend          bool          `json:"can_extend"`
-	ExtensionRequested bool          `json:"extension_requested"`
-	ExtensionEndDate   *time.Time    `json:"extension_end_date"`
-	ExtensionDuration  int           `json:"extension_duration"`
-	ExtensionPrice     int           `json:"extension_price"`
-	CreatedAt          time.Time     `json:"created_at"`
-	UpdatedAt          time.Time     `json:"updated_at"`
+	ID                 int             `json:"id"`
+	RenterID           int             `json:"renter_id"`
+	Renter             *Renter         `json:"renter,omitempty"`
+	ApartmentID        int             `json:"apartment_id"`
+	Apartment          *Apartment      `json:"apartment,omitempty"`
+	ContractID         *int            `json:"contract_id,omitempty"`
+	StartDate          time.Time       `json:"start_date"`
+	EndDate            time.Time       `json:"end_date"`
+	Duration           int             `json:"duration"`
+	Nights             int             `json:"nights"`
+	CleaningDuration   int             `json:"cleaning_duration"`
+	Status             BookingStatus   `json:"status"`
+	TotalPrice         int             `json:"total_price"`
+	ServiceFee         int             `json:"service_fee"`
+	FinalPrice         int             `json:"final_price"`
+	PriceBreakdown     *PriceBreakdown `json:"price_breakdown,omitempty"`
+	IsContractAccepted bool            `json:"is_contract_accepted"`
+	PaymentID          *int64          `json:"payment_id,omitempty"`
+	CancellationReason *string         `json:"cancellation_reason"`
+	OwnerComment       *string         `json:"owner_comment"`
+	BookingNumber      string          `json:"booking_number"`
+	DoorStatus         DoorStatus      `json:"door_status"`
+	LastDoorAction     *time.Time      `json:"last_door_action"`
+	CanExtend          bool            `json:"can_extend"`
+	ExtensionRequested bool            `json:"extension_requested"`
+	ExtensionEndDate   *time.Time      `json:"extension_end_date"`
+	ExtensionDuration  int             `json:"extension_duration"`
+	ExtensionPrice     int             `json:"extension_price"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
 }
 
 type BookingExtension struct {
-	ID          int           `json:"id"`
-	BookingID   int           `json:"booking_id"`
-	Booking     *Booking      `json:"booking,omitempty"`
-	Duration    int           `json:"duration"`
-	Price       int           `json:"price"`
-	Status      BookingStatus `json:"status"`
-	PaymentID   *int64        `json:"payment_id,omitempty"`
-	RequestedAt time.Time     `json:"requested_at"`
-	ApprovedAt  *time.Time    `json:"approved_at"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
+	ID             int             `json:"id"`
+	BookingID      int             `json:"booking_id"`
+	Booking        *Booking        `json:"booking,omitempty"`
+	Duration       int             `json:"duration"`
+	Price          int             `json:"price"`
+	PriceBreakdown *PriceBreakdown `json:"price_breakdown,omitempty"`
+	Status         BookingStatus   `json:"status"`
+	PaymentID      *int64          `json:"payment_id,omitempty"`
+	RequestedAt    time.Time       `json:"requested_at"`
+	ApprovedAt     *time.Time      `json:"approved_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
 }
 
 type DoorAction struct {
@@ -81,10 +85,30 @@ type DoorAction struct {
 	CreatedAt time.Time  `json:"created_at"`
 }
 
+// CreateBookingRequest почасовое бронирование задается start_date и duration,
+// посуточное на несколько ночей - check_in_date и check_out_date (время заезда и выезда берется из настроек квартиры).
 type CreateBookingRequest struct {
-	ApartmentID int    `json:"apartment_id" validate:"required"`
-	StartDate   string `json:"start_date" validate:"required"`
-	Duration    int    `json:"duration" validate:"required,min=1"`
+	ApartmentID  int    `json:"apartment_id" validate:"required"`
+	StartDate    string `json:"start_date"`
+	Duration     int    `json:"duration"`
+	CheckInDate  string `json:"check_in_date,omitempty" example:"2026-11-20"`
+	CheckOutDate string `json:"check_out_date,omitempty" example:"2026-11-25"`
+}
+
+func (r *CreateBookingRequest) IsNightly() bool {
+	return r.CheckInDate != "" || r.CheckOutDate != ""
+}
+
+// StayPeriod период посуточного проживания с учетом времени заезда и выезда квартиры
+type StayPeriod struct {
+	CheckIn  time.Time `json:"check_in"`
+	CheckOut time.Time `json:"check_out"`
+	Nights   int       `json:"nights"`
+}
+
+// Hours продолжительность проживания в часах, округленная вверх
+func (p *StayPeriod) Hours() int {
+	return int(math.Ceil(p.CheckOut.Sub(p.CheckIn).Hours()))
 }
 
 type ConfirmBookingRequest struct {
@@ -146,7 +170,10 @@ type BookingRepository interface {
 	GetAll(filters map[string]interface{}, page, pageSize int) ([]*Booking, int, error)
 	GetStatusStatistics() (map[string]int, error)
 
-	CleanupExpiredBookings(batchSize int) (int, error)
+	// GetExpiredAwaitingPaymentBookingIDs неоплаченные бронирования, которые пора удалить.
+	GetExpiredAwaitingPaymentBookingIDs(batchSize int) ([]int, error)
+	// CleanupExpiredBookings удаляет просроченные бронирования; неоплаченные - только из reconciledIDs.
+	CleanupExpiredBookings(batchSize int, reconciledIDs []int) (int, error)
 	CleanupExpiredExtensions(batchSize int) (int, error)
 }
 
@@ -155,6 +182,8 @@ type BookingUseCase interface {
 	ConfirmBooking(bookingID, userID int, request *ConfirmBookingRequest) (*Booking, error)
 	ProcessPayment(bookingID int, paymentID string) (*Booking, error)
 	ProcessPaymentWithOrder(bookingID int, orderID string) (*Booking, error)
+	InitBookingPayment(bookingID, userID int) (*InitPaymentResponse, error)
+	HandlePaymentCallback(callback *FreedomPayCallback) (string, string)
 	GetBookingByID(bookingID int) (*Booking, error)
 	GetBookingByNumber(bookingNumber string) (*Booking, error)
 	GetRenterBookings(userID int, status []BookingStatus, dateFrom, dateTo *time.Time, page, pageSize int) ([]*Booking, int, error)
@@ -163,12 +192,14 @@ type BookingUseCase interface {
 	ApproveBooking(bookingID, userID int) error
 	RejectBooking(bookingID, userID int, comment string) error
 	CancelBooking(bookingID, userID int, reason string) error
+	GetCancellationQuote(bookingID, userID int) (*CancellationQuote, error)
 	FinishSession(bookingID, userID int) error
 
 	RequestExtension(bookingID, userID int, request *ExtendBookingRequest) error
 	GetAvailableExtensions(bookingID, userID int) (*AvailableExtensionsResponse, error)
 	ProcessExtensionPayment(extensionID int, paymentID string) (*BookingExtension, error)
 	ProcessExtensionPaymentWithOrder(extensionID int, orderID string) (*BookingExtension, error)
+	InitExtensionPayment(bookingID, extensionID, userID int) (*InitPaymentResponse, error)
 
 	ApproveExtension(extensionID, userID int) error
 	RejectExtension(extensionID, userID int) error
@@ -197,34 +228,36 @@ type BookingUseCase interface {
 }
 
 type BookingResponse struct {
-	ID                 int           `json:"id"`
-	RenterID           int           `json:"renter_id"`
-	Renter             *Renter       `json:"renter,omitempty"`
-	ApartmentID        int           `json:"apartment_id"`
-	Apartment          *Apartment    `json:"apartment,omitempty"`
-	ContractID         *int          `json:"contract_id,omitempty"`
-	LockUniqueID       *string       `json:"lock_unique_id,omitempty"`
-	StartDate          string        `json:"start_date"`
-	EndDate            string        `json:"end_date"`
-	Duration           int           `json:"duration"`
-	CleaningDuration   int           `json:"cleaning_duration"`
-	Status             BookingStatus `json:"status"`
-	TotalPrice         int           `json:"total_price"`
-	ServiceFee         int           `json:"service_fee"`
-	FinalPrice         int           `json:"final_price"`
-	IsContractAccepted bool          `json:"is_contract_accepted"`
-	CancellationReason *string       `json:"cancellation_reason"`
-	OwnerComment       *string       `json:"owner_comment"`
-	BookingNumber      string        `json:"booking_number"`
-	DoorStatus         DoorStatus    `json:"door_status"`
-	LastDoorAction     *string       `json:"last_door_action"`
-	CanExtend          bool          `json:"can_extend"`
-	ExtensionRequested bool          `json:"extension_requested"`
-	ExtensionEndDate   *string       `json:"extension_end_date"`
-	ExtensionDuration  int           `json:"extension_duration"`
-	ExtensionPrice     int           `json:"extension_price"`
-	CreatedAt          string        `json:"created_at"`
-	UpdatedAt          string        `json:"updated_at"`
+	ID                 int             `json:"id"`
+	RenterID           int             `json:"renter_id"`
+	Renter             *Renter         `json:"renter,omitempty"`
+	ApartmentID        int             `json:"apartment_id"`
+	Apartment          *Apartment      `json:"apartment,omitempty"`
+	ContractID         *int            `json:"contract_id,omitempty"`
+	LockUniqueID       *string         `json:"lock_unique_id,omitempty"`
+	StartDate          string          `json:"start_date"`
+	EndDate            string          `json:"end_date"`
+	Duration           int             `json:"duration"`
+	Nights             int             `json:"nights"`
+	CleaningDuration   int             `json:"cleaning_duration"`
+	Status             BookingStatus   `json:"status"`
+	TotalPrice         int             `json:"total_price"`
+	ServiceFee         int             `json:"service_fee"`
+	FinalPrice         int             `json:"final_price"`
+	PriceBreakdown     *PriceBreakdown `json:"price_breakdown,omitempty"`
+	IsContractAccepted bool            `json:"is_contract_accepted"`
+	CancellationReason *string         `json:"cancellation_reason"`
+	OwnerComment       *string         `json:"owner_comment"`
+	BookingNumber      string          `json:"booking_number"`
+	DoorStatus         DoorStatus      `json:"door_status"`
+	LastDoorAction     *string         `json:"last_door_action"`
+	CanExtend          bool            `json:"can_extend"`
+	ExtensionRequested bool            `json:"extension_requested"`
+	ExtensionEndDate   *string         `json:"extension_end_date"`
+	ExtensionDuration  int             `json:"extension_duration"`
+	ExtensionPrice     int             `json:"extension_price"`
+	CreatedAt          string          `json:"created_at"`
+	UpdatedAt          string          `json:"updated_at"`
 }
 
 type LockAccessStatus string
@@ -257,7 +290,7 @@ type LockAccess struct {
 	Status             LockAccessStatus `json:"status"`
 	CanGenerateNow     bool             `json:"can_generate_now"`
 	PasswordExists     bool             `json:"password_exists"`
-	Password           *string          `json:"password,omitempty"`
+	Password           *Secret          `json:"password,omitempty" swaggertype:"string" example:"******"`
 	PasswordValidFrom  *time.Time       `json:"password_valid_from,omitempty"`
 	PasswordValidUntil *time.Time       `json:"password_valid_until,omitempty"`
 	AvailableAt        *time.Time       `json:"available_at,omitempty"`
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"time"
+)
+
+type ExternalCalendarSyncStatus string
+
+const (
+	ExternalCalendarSyncPending ExternalCalendarSyncStatus = "pending"
+	ExternalCalendarSyncSuccess ExternalCalendarSyncStatus = "success"
+	ExternalCalendarSyncFailed  ExternalCalendarSyncStatus = "failed"
+)
+
+// ExternalCalendar iCal-календарь квартиры на другой площадке, события которого блокируют бронирование.
+type ExternalCalendar struct {
+	ID             int                        `json:"id"`
+	ApartmentID    int                        `json:"apartment_id"`
+	Name           string                     `json:"name"`
+	URL            string                     `json:"url"`
+	IsActive       bool                       `json:"is_active"`
+	LastSyncedAt   *time.Time                 `json:"last_synced_at,omitempty"`
+	LastSyncStatus ExternalCalendarSyncStatus `json:"last_sync_status"`
+	LastSyncError  *string                    `json:"last_sync_error,omitempty"`
+	EventsCount    int                        `json:"events_count"`
+	CreatedAt      time.Time                  `json:"created_at"`
+	UpdatedAt      time.Time                  `json:"updated_at"`
+}
+
+// ExternalCalendarEvent занятый период, импортированный из внешнего календаря.
+type ExternalCalendarEvent struct {
+	ID          int       `json:"id"`
+	CalendarID  int       `json:"calendar_id"`
+	ApartmentID int       `json:"apartment_id"`
+	UID         string    `json:"uid"`
+	Summary     string    `json:"summary"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type CreateExternalCalendarRequest struct {
+	Name string `json:"name" example:"Airbnb"`
+	URL  string `json:"url" binding:"required" example:"https://www.airbnb.com/calendar/ical/123.ics?s=abc"`
+}
+
+type UpdateExternalCalendarRequest struct {
+	Name     *string `json:"name,omitempty"`
+	IsActive *bool   `json:"is_active,omitempty"`
+}
+
+// CalendarExportInfo ссылка на публичный iCal-фид квартиры для подключения на других площадках.
+type CalendarExportInfo struct {
+	ApartmentID int    `json:"apartment_id"`
+	Token       string `json:"token"`
+	URL         string `json:"url"`
+}
+
+// CalendarSyncResult результат импорта одного внешнего календаря.
+type CalendarSyncResult struct {
+	CalendarID  int                        `json:"calendar_id"`
+	ApartmentID int                        `json:"apartment_id"`
+	Status      ExternalCalendarSyncStatus `json:"status"`
+	EventsCount int                        `json:"events_count"`
+	Error       string                     `json:"error,omitempty"`
+	SyncedAt    time.Time                  `json:"synced_at"`
+}
+
+type CalendarRepository interface {
+	GetExportToken(apartmentID int) (*string, error)
+	SetExportToken(apartmentID int, token string) error
+	GetApartmentIDByExportToken(token string) (int, error)
+
+	CreateExternalCalendar(calendar *ExternalCalendar) error
+	GetExternalCalendarByID(id int) (*ExternalCalendar, error)
+	GetExternalCalendarsByApartmentID(apartmentID int) ([]*ExternalCalendar, error)
+	GetActiveExternalCalendars() ([]*ExternalCalendar, error)
+	UpdateExternalCalendar(calendar *ExternalCalendar) error
+	UpdateSyncStatus(calendar *ExternalCalendar) error
+	DeleteExternalCalendar(id int) error
+
+	ReplaceExternalEvents(calendarID int, events []*ExternalCalendarEvent) error
+	GetExternalEventsByApartmentID(apartmentID int, from time.Time) ([]*ExternalCalendarEvent, error)
+}
+
+type CalendarUseCase interface {
+	GetExportInfo(apartmentID int) (*CalendarExportInfo, error)
+	RegenerateExportToken(apartmentID int) (*CalendarExportInfo, error)
+	RenderExportFeed(token string) ([]byte, error)
+
+	GetExternalCalendars(apartmentID int) ([]*ExternalCalendar, error)
+	AddExternalCalendar(apartmentID int, request *CreateExternalCalendarRequest) (*ExternalCalendar, *CalendarSyncResult, error)
+	UpdateExternalCalendar(apartmentID, calendarID int, request *UpdateExternalCalendarRequest) (*ExternalCalendar, error)
+	DeleteExternalCalendar(apartmentID, calendarID int) error
+	GetExternalEvents(apartmentID int) ([]*ExternalCalendarEvent, error)
+
+	SyncExternalCalendar(apartmentID, calendarID int) (*CalendarSyncResult, error)
+	SyncAllExternalCalendars() []*CalendarSyncResult
+}
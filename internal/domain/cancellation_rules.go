@@ -21,6 +21,66 @@ type CancellationRule struct {
 	UpdatedAt    time.Time            `json:"updated_at" db:"updated_at"`
 }
 
+type CancellationPolicyCode string
+
+const (
+	CancellationPolicyFlexible CancellationPolicyCode = "flexible"
+	CancellationPolicyModerate CancellationPolicyCode = "moderate"
+	CancellationPolicyStrict   CancellationPolicyCode = "strict"
+)
+
+func (c CancellationPolicyCode) IsValid() bool {
+	return c == CancellationPolicyFlexible || c == CancellationPolicyModerate || c == CancellationPolicyStrict
+}
+
+type CancellationPolicyTier struct {
+	MinHoursBeforeStart int `json:"min_hours_before_start"`
+	RefundPercentage    int `json:"refund_percentage"`
+}
+
+type CancellationPolicy struct {
+	ID                   int                      `json:"id"`
+	Code                 CancellationPolicyCode   `json:"code"`
+	Name                 string                   `json:"name"`
+	Description          string                   `json:"description"`
+	Tiers                []CancellationPolicyTier `json:"tiers"`
+	ServiceFeeRefundable bool                     `json:"service_fee_refundable"`
+	IsActive             bool                     `json:"is_active"`
+	CreatedAt            time.Time                `json:"created_at"`
+	UpdatedAt            time.Time                `json:"updated_at"`
+}
+
+// RefundPercentage возвращает процент возврата для самой строгой ступени, условие которой выполнено.
+func (p *CancellationPolicy) RefundPercentage(hoursUntilStart float64) int {
+	percentage := 0
+	matchedHours := -1
+
+	for _, tier := range p.Tiers {
+		if hoursUntilStart >= float64(tier.MinHoursBeforeStart) && tier.MinHoursBeforeStart > matchedHours {
+			matchedHours = tier.MinHoursBeforeStart
+			percentage = tier.RefundPercentage
+		}
+	}
+
+	return percentage
+}
+
+type CancellationQuote struct {
+	BookingID          int                 `json:"booking_id"`
+	Policy             *CancellationPolicy `json:"policy"`
+	HoursUntilStart    float64             `json:"hours_until_start"`
+	PaidAmount         int                 `json:"paid_amount"`
+	RefundPercentage   int                 `json:"refund_percentage"`
+	ServiceFeeRefunded bool                `json:"service_fee_refunded"`
+	RefundAmount       int                 `json:"refund_amount"`
+	PenaltyAmount      int                 `json:"penalty_amount"`
+	Currency           string              `json:"currency"`
+}
+
+type UpdateApartmentCancellationPolicyRequest struct {
+	PolicyCode *CancellationPolicyCode `json:"policy_code"`
+}
+
 type CancellationRuleRepository interface {
 	GetAll() ([]*CancellationRule, error)
 	GetByType(ruleType CancellationRuleType) ([]*CancellationRule, error)
@@ -30,7 +90,19 @@ type CancellationRuleRepository interface {
 	Delete(id int) error
 }
 
+type CancellationPolicyRepository interface {
+	GetAll() ([]*CancellationPolicy, error)
+	GetByCode(code CancellationPolicyCode) (*CancellationPolicy, error)
+	GetApartmentPolicyCode(apartmentID int) (*CancellationPolicyCode, error)
+	SetApartmentPolicyCode(apartmentID int, code *CancellationPolicyCode) error
+}
+
 type CancellationRuleUseCase interface {
 	GetActiveCancellationRules() ([]*CancellationRule, error)
 	GetCancellationRulesByType(ruleType CancellationRuleType) ([]*CancellationRule, error)
+
+	GetCancellationPolicies() ([]*CancellationPolicy, error)
+	GetApartmentCancellationPolicy(apartmentID int) (*CancellationPolicy, error)
+	SetApartmentCancellationPolicy(apartmentID int, code *CancellationPolicyCode) error
+	QuoteCancellation(booking *Booking, at time.Time) (*CancellationQuote, error)
 }
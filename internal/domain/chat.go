@@ -51,23 +51,27 @@ type ChatRoom struct {
 }
 
 type ChatMessage struct {
-	ID         int           `json:"id"`
-	ChatRoomID int           `json:"chat_room_id"`
-	ChatRoom   *ChatRoom     `json:"chat_room,omitempty"`
-	SenderID   int           `json:"sender_id"`
-	Sender     *User         `json:"sender,omitempty"`
-	Type       MessageType   `json:"type"`
-	Content    string        `json:"content"`
-	FileURL    *string       `json:"file_url,omitempty"`
-	FileName   *string       `json:"file_name,omitempty"`
-	FileSize   *int64        `json:"file_size,omitempty"`
-	Status     MessageStatus `json:"status"`
-	ReadAt     *time.Time    `json:"read_at"`
-	ReplyToID  *int          `json:"reply_to_id,omitempty"`
-	ReplyTo    *ChatMessage  `json:"reply_to,omitempty"`
-	EditedAt   *time.Time    `json:"edited_at"`
-	CreatedAt  time.Time     `json:"created_at"`
-	UpdatedAt  time.Time     `json:"updated_at"`
+	ID           int           `json:"id"`
+	ChatRoomID   int           `json:"chat_room_id"`
+	ChatRoom     *ChatRoom     `json:"chat_room,omitempty"`
+	SenderID     int           `json:"sender_id"`
+	Sender       *User         `json:"sender,omitempty"`
+	Type         MessageType   `json:"type"`
+	Content      string        `json:"content"`
+	FileURL      *string       `json:"file_url,omitempty"`
+	FileName     *string       `json:"file_name,omitempty"`
+	FileSize     *int64        `json:"file_size,omitempty"`
+	MimeType     *string       `json:"mime_type,omitempty"`
+	ThumbnailURL *string       `json:"thumbnail_url,omitempty"`
+	ImageWidth   *int          `json:"image_width,omitempty"`
+	ImageHeight  *int          `json:"image_height,omitempty"`
+	Status       MessageStatus `json:"status"`
+	ReadAt       *time.Time    `json:"read_at"`
+	ReplyToID    *int          `json:"reply_to_id,omitempty"`
+	ReplyTo      *ChatMessage  `json:"reply_to,omitempty"`
+	EditedAt     *time.Time    `json:"edited_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
 }
 
 type ChatParticipant struct {
@@ -104,6 +108,10 @@ const (
 	WSEventChatClosed  WSEventType = "chat_closed"
 	WSEventError       WSEventType = "error"
 	WSEventHeartbeat   WSEventType = "heartbeat"
+	// WSEventResume клиент сообщает последнее полученное сообщение после переподключения
+	WSEventResume WSEventType = "resume"
+	// WSEventSync ответ на resume: пропущенные сообщения и отметки о прочтении
+	WSEventSync WSEventType = "sync"
 )
 
 type WSMessage struct {
@@ -124,6 +132,20 @@ type SendMessageRequest struct {
 	ReplyToID *int        `json:"reply_to_id,omitempty"`
 }
 
+const (
+	ChatImageMaxSize = 10 << 20
+	ChatFileMaxSize  = 20 << 20
+)
+
+// ChatAttachmentUpload файл, отправляемый в чат вложением. Тип сообщения (image/file)
+// определяется по содержимому файла, Caption - необязательная подпись.
+type ChatAttachmentUpload struct {
+	FileName  string
+	Data      []byte
+	Caption   string
+	ReplyToID *int
+}
+
 type UpdateMessageRequest struct {
 	Content string `json:"content" validate:"required"`
 }
@@ -132,6 +154,47 @@ type MarkMessagesReadRequest struct {
 	MessageIDs []int `json:"message_ids" validate:"required"`
 }
 
+const (
+	ChatMessagesCursorDefaultLimit = 50
+	ChatMessagesCursorMaxLimit     = 100
+	ChatSyncMaxMessages            = 200
+)
+
+// ChatMessageCursor курсор истории сообщений по ID. С AfterID сообщения идут от курсора
+// вперед, иначе - самые новые сообщения до BeforeID (или до конца истории).
+type ChatMessageCursor struct {
+	BeforeID *int
+	AfterID  *int
+	Limit    int
+}
+
+type ChatMessagesPage struct {
+	Messages []*ChatMessage `json:"messages"`
+	HasMore  bool           `json:"has_more"`
+	// FirstID и LastID - курсоры для запроса соседних страниц (before/after)
+	FirstID *int `json:"first_id,omitempty"`
+	LastID  *int `json:"last_id,omitempty"`
+}
+
+type ChatReadReceipt struct {
+	MessageID int       `json:"message_id"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
+// ChatSyncRequest данные события resume. LastEventAt - время последнего полученного события,
+// по нему отбираются отметки о прочтении уже полученных сообщений.
+type ChatSyncRequest struct {
+	LastMessageID int        `json:"last_message_id"`
+	LastEventAt   *time.Time `json:"last_event_at,omitempty"`
+}
+
+type ChatSyncResponse struct {
+	Messages      []*ChatMessage    `json:"messages"`
+	ReadReceipts  []ChatReadReceipt `json:"read_receipts"`
+	HasMore       bool              `json:"has_more"`
+	LastMessageID int               `json:"last_message_id"`
+}
+
 type ChatRoomRepository interface {
 	Create(room *ChatRoom) error
 	GetByID(id int) (*ChatRoom, error)
@@ -150,6 +213,8 @@ type ChatMessageRepository interface {
 	Create(message *ChatMessage) error
 	GetByID(id int) (*ChatMessage, error)
 	GetByRoomID(roomID int, page, pageSize int) ([]*ChatMessage, int, error)
+	GetByRoomIDCursor(roomID int, cursor ChatMessageCursor) ([]*ChatMessage, bool, error)
+	GetReadReceipts(roomID int, readSince time.Time, upToID int) ([]ChatReadReceipt, error)
 	GetUnreadCount(roomID, userID int) (int, error)
 	GetUnreadMessages(roomID, userID int) ([]*ChatMessage, error)
 	Update(message *ChatMessage) error
@@ -177,7 +242,10 @@ type ChatUseCase interface {
 	CanUserAccessRoom(roomID, userID int) (bool, error)
 
 	SendMessage(roomID int, request *SendMessageRequest, userID int) (*ChatMessage, error)
+	SendAttachment(roomID int, upload *ChatAttachmentUpload, userID int) (*ChatMessage, error)
 	GetMessages(roomID, userID int, page, pageSize int) ([]*ChatMessage, int, error)
+	GetMessagesByCursor(roomID, userID int, cursor ChatMessageCursor) (*ChatMessagesPage, error)
+	SyncMessages(roomID, userID int, request *ChatSyncRequest) (*ChatSyncResponse, error)
 	UpdateMessage(messageID int, request *UpdateMessageRequest, userID int) error
 	DeleteMessage(messageID, userID int) error
 	MarkMessagesAsRead(roomID int, request *MarkMessagesReadRequest, userID int) error
@@ -225,20 +293,24 @@ type ChatRoomResponse struct {
 }
 
 type ChatMessageResponse struct {
-	ID         int           `json:"id"`
-	ChatRoomID int           `json:"chat_room_id"`
-	SenderID   int           `json:"sender_id"`
-	Sender     *User         `json:"sender,omitempty"`
-	Type       MessageType   `json:"type"`
-	Content    string        `json:"content"`
-	FileURL    *string       `json:"file_url,omitempty"`
-	FileName   *string       `json:"file_name,omitempty"`
-	FileSize   *int64        `json:"file_size,omitempty"`
-	Status     MessageStatus `json:"status"`
-	ReadAt     *string       `json:"read_at"`
-	ReplyToID  *int          `json:"reply_to_id,omitempty"`
-	ReplyTo    *ChatMessage  `json:"reply_to,omitempty"`
-	EditedAt   *string       `json:"edited_at"`
-	CreatedAt  string        `json:"created_at"`
-	UpdatedAt  string        `json:"updated_at"`
+	ID           int           `json:"id"`
+	ChatRoomID   int           `json:"chat_room_id"`
+	SenderID     int           `json:"sender_id"`
+	Sender       *User         `json:"sender,omitempty"`
+	Type         MessageType   `json:"type"`
+	Content      string        `json:"content"`
+	FileURL      *string       `json:"file_url,omitempty"`
+	FileName     *string       `json:"file_name,omitempty"`
+	FileSize     *int64        `json:"file_size,omitempty"`
+	MimeType     *string       `json:"mime_type,omitempty"`
+	ThumbnailURL *string       `json:"thumbnail_url,omitempty"`
+	ImageWidth   *int          `json:"image_width,omitempty"`
+	ImageHeight  *int          `json:"image_height,omitempty"`
+	Status       MessageStatus `json:"status"`
+	ReadAt       *string       `json:"read_at"`
+	ReplyToID    *int          `json:"reply_to_id,omitempty"`
+	ReplyTo      *ChatMessage  `json:"reply_to,omitempty"`
+	EditedAt     *string       `json:"edited_at"`
+	CreatedAt    string        `json:"created_at"`
+	UpdatedAt    string        `json:"updated_at"`
 }
@@ -39,6 +39,90 @@ type CleanerSchedule struct {
 	Sunday    []WorkingHours `json:"sunday,omitempty"`
 }
 
+// HoursFor возвращает рабочие интервалы на день недели.
+func (cs *CleanerSchedule) HoursFor(weekday time.Weekday) []WorkingHours {
+	switch weekday {
+	case time.Monday:
+		return cs.Monday
+	case time.Tuesday:
+		return cs.Tuesday
+	case time.Wednesday:
+		return cs.Wednesday
+	case time.Thursday:
+		return cs.Thursday
+	case time.Friday:
+		return cs.Friday
+	case time.Saturday:
+		return cs.Saturday
+	default:
+		return cs.Sunday
+	}
+}
+
+// IsEmpty сообщает, что ни на один день не задано рабочее время.
+func (cs *CleanerSchedule) IsEmpty() bool {
+	if cs == nil {
+		return true
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if len(cs.HoursFor(weekday)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NextWorkingTime возвращает ближайший момент не раньше from, когда уборщица работает по расписанию
+// (расписание задается в часовом поясе loc, поиск на неделю вперед). Интервал вида 20:00-02:00
+// переходит через полночь. Пустое расписание не ограничивает время работы.
+func (cs *CleanerSchedule) NextWorkingTime(from time.Time, loc *time.Location) (time.Time, bool) {
+	if cs.IsEmpty() {
+		return from, true
+	}
+
+	local := from.In(loc)
+	var next time.Time
+	found := false
+
+	// Начинаем с предыдущего дня: его ночной интервал может еще продолжаться
+	for day := -1; day <= 7; day++ {
+		date := time.Date(local.Year(), local.Month(), local.Day()+day, 0, 0, 0, 0, loc)
+
+		for _, hours := range cs.HoursFor(date.Weekday()) {
+			start, errStart := ParseClockMinutes(hours.Start)
+			end, errEnd := ParseClockMinutes(hours.End)
+			if errStart != nil || errEnd != nil || start == end {
+				continue
+			}
+			if end < start {
+				end += 24 * 60
+			}
+
+			startsAt := date.Add(time.Duration(start) * time.Minute)
+			endsAt := date.Add(time.Duration(end) * time.Minute)
+			if !endsAt.After(local) {
+				continue
+			}
+
+			candidate := startsAt
+			if candidate.Before(local) {
+				candidate = local
+			}
+			if !found || candidate.Before(next) {
+				next = candidate
+				found = true
+			}
+		}
+
+		if found {
+			return next.In(from.Location()), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
 type CleanerSchedulePatch struct {
 	Monday    *[]WorkingHours `json:"monday,omitempty"`
 	Tuesday   *[]WorkingHours `json:"tuesday,omitempty"`
@@ -124,7 +208,162 @@ type StartCleaningRequest struct {
 type CompleteCleaningRequest struct {
 	ApartmentID  int      `json:"apartment_id" validate:"required"`
 	Notes        string   `json:"notes,omitempty"`
-	PhotosBase64 []string `json:"photos_base64,omitempty"`
+	PhotosBase64 []string `json:"photos_base64" validate:"required,min=1"`
+}
+
+type CancelCleaningRequest struct {
+	ApartmentID int    `json:"apartment_id" validate:"required"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type AdminCancelCleaningRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type CleaningLogStatus string
+
+const (
+	CleaningLogStatusInProgress CleaningLogStatus = "in_progress"
+	CleaningLogStatusCompleted  CleaningLogStatus = "completed"
+	CleaningLogStatusCancelled  CleaningLogStatus = "cancelled"
+)
+
+func IsValidCleaningLogStatus(status CleaningLogStatus) bool {
+	switch status {
+	case CleaningLogStatusInProgress, CleaningLogStatusCompleted, CleaningLogStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Фотографии после уборки обязательны: по ним владелец убеждается, что квартира убрана
+const (
+	MinCleaningPhotos     = 1
+	MaxCleaningPhotos     = 10
+	MaxCleaningPhotoBytes = 10 * 1024 * 1024
+)
+
+// CleaningLog запись журнала уборки квартиры. Завершенная уборка содержит фотографии квартиры после уборки.
+type CleaningLog struct {
+	ID              int                 `json:"id"`
+	ApartmentID     int                 `json:"apartment_id"`
+	Apartment       *Apartment          `json:"apartment,omitempty"`
+	CleanerID       int                 `json:"cleaner_id"`
+	Cleaner         *Cleaner            `json:"cleaner,omitempty"`
+	Status          CleaningLogStatus   `json:"status"`
+	StartedAt       time.Time           `json:"started_at"`
+	CompletedAt     *time.Time          `json:"completed_at,omitempty"`
+	CancelledAt     *time.Time          `json:"cancelled_at,omitempty"`
+	StartNotes      *string             `json:"start_notes,omitempty"`
+	CompletionNotes *string             `json:"completion_notes,omitempty"`
+	CancelReason    *string             `json:"cancel_reason,omitempty"`
+	PhotosURLs      []string            `json:"photos_urls"`
+	DurationMinutes *int                `json:"duration_minutes,omitempty"`
+	AccessCode      *CleaningAccessCode `json:"access_code,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+// CleaningAccessCode временный пароль замка, выданный уборщице на время уборки. В cleaning_logs
+// не хранится: возвращается только уборщице при начале уборки и по отдельному запросу.
+type CleaningAccessCode struct {
+	Code       string    `json:"code" example:"482913"`
+	ValidFrom  time.Time `json:"valid_from"`
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+// CleaningTaskStatus статус заявки на уборку. Заявка создается при выезде гостя, предлагается
+// уборщицам квартиры по очереди и, если никто не принял ее вовремя, передается администратору.
+type CleaningTaskStatus string
+
+const (
+	CleaningTaskStatusPending    CleaningTaskStatus = "pending"
+	CleaningTaskStatusOffered    CleaningTaskStatus = "offered"
+	CleaningTaskStatusAccepted   CleaningTaskStatus = "accepted"
+	CleaningTaskStatusInProgress CleaningTaskStatus = "in_progress"
+	CleaningTaskStatusCompleted  CleaningTaskStatus = "completed"
+	CleaningTaskStatusEscalated  CleaningTaskStatus = "escalated"
+	CleaningTaskStatusCancelled  CleaningTaskStatus = "cancelled"
+)
+
+// OpenCleaningTaskStatuses статусы незакрытой заявки: квартира еще не убрана.
+var OpenCleaningTaskStatuses = []CleaningTaskStatus{
+	CleaningTaskStatusPending,
+	CleaningTaskStatusOffered,
+	CleaningTaskStatusAccepted,
+	CleaningTaskStatusInProgress,
+	CleaningTaskStatusEscalated,
+}
+
+func IsValidCleaningTaskStatus(status CleaningTaskStatus) bool {
+	switch status {
+	case CleaningTaskStatusPending, CleaningTaskStatusOffered, CleaningTaskStatusAccepted, CleaningTaskStatusInProgress,
+		CleaningTaskStatusCompleted, CleaningTaskStatusEscalated, CleaningTaskStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (s CleaningTaskStatus) IsOpen() bool {
+	for _, status := range OpenCleaningTaskStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CleaningTask заявка на уборку квартиры после выезда гостя. DeadlineAt - выезд плюс время уборки
+// из бронирования, но не позже начала следующего бронирования.
+type CleaningTask struct {
+	ID                 int                `json:"id"`
+	ApartmentID        int                `json:"apartment_id"`
+	Apartment          *Apartment         `json:"apartment,omitempty"`
+	BookingID          *int               `json:"booking_id,omitempty"`
+	NextBookingID      *int               `json:"next_booking_id,omitempty"`
+	CleanerID          *int               `json:"cleaner_id,omitempty"`
+	Cleaner            *Cleaner           `json:"cleaner,omitempty"`
+	CleaningLogID      *int               `json:"cleaning_log_id,omitempty"`
+	Status             CleaningTaskStatus `json:"status"`
+	CheckoutAt         time.Time          `json:"checkout_at"`
+	CleaningDuration   int                `json:"cleaning_duration"`
+	DeadlineAt         time.Time          `json:"deadline_at"`
+	OfferAttempt       int                `json:"offer_attempt"`
+	OfferedAt          *time.Time         `json:"offered_at,omitempty"`
+	OfferExpiresAt     *time.Time         `json:"offer_expires_at,omitempty"`
+	DeclinedCleanerIDs []int              `json:"declined_cleaner_ids"`
+	AcceptedAt         *time.Time         `json:"accepted_at,omitempty"`
+	StartedAt          *time.Time         `json:"started_at,omitempty"`
+	CompletedAt        *time.Time         `json:"completed_at,omitempty"`
+	EscalatedAt        *time.Time         `json:"escalated_at,omitempty"`
+	EscalationReason   *string            `json:"escalation_reason,omitempty"`
+	IsLate             bool               `json:"is_late"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// HasDeclined сообщает, что уборщица уже отказалась от заявки или не ответила вовремя.
+func (t *CleaningTask) HasDeclined(cleanerID int) bool {
+	for _, id := range t.DeclinedCleanerIDs {
+		if id == cleanerID {
+			return true
+		}
+	}
+	return false
+}
+
+type DeclineCleaningTaskRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type AssignCleaningTaskRequest struct {
+	CleanerID int `json:"cleaner_id" validate:"required"`
+}
+
+// CleaningScheduler отложенные проверки заявок на уборку: истечение предложения уборщице и срок уборки.
+type CleaningScheduler interface {
+	ScheduleCleaningOfferTimeout(taskID, attempt int, executeAt time.Time)
+	ScheduleCleaningDeadline(taskID int, deadlineAt time.Time)
 }
 
 type CleanerRepository interface {
@@ -148,6 +387,24 @@ type CleanerRepository interface {
 
 	GetApartmentsForCleaning(cleanerID int) ([]*ApartmentForCleaning, error)
 	GetApartmentsNeedingCleaning() ([]*ApartmentForCleaning, error)
+
+	CreateCleaningLog(cleaningLog *CleaningLog) error
+	GetCleaningLogByID(id int) (*CleaningLog, error)
+	GetActiveCleaningLog(apartmentID int) (*CleaningLog, error)
+	CompleteCleaningLog(cleaningLog *CleaningLog) error
+	CancelCleaningLog(cleaningLog *CleaningLog) error
+	// GetCleaningLogs фильтры: cleaner_id, apartment_id, owner_id, status, date_from, date_to (по started_at)
+	GetCleaningLogs(filters map[string]interface{}, page, pageSize int) ([]*CleaningLog, int, error)
+
+	// CreateCleaningTask возвращает false, если заявка на это бронирование или незакрытая заявка на квартиру уже есть
+	CreateCleaningTask(task *CleaningTask) (bool, error)
+	GetCleaningTaskByID(id int) (*CleaningTask, error)
+	GetOpenCleaningTask(apartmentID int) (*CleaningTask, error)
+	// UpdateCleaningTask сохраняет заявку, если ее не изменили после загрузки. false - заявку
+	// параллельно приняли, отклонили или закрыли, ее нужно перечитать.
+	UpdateCleaningTask(task *CleaningTask) (bool, error)
+	// GetCleaningTasks фильтры: cleaner_id, apartment_id, status, open, is_late
+	GetCleaningTasks(filters map[string]interface{}, page, pageSize int) ([]*CleaningTask, int, error)
 }
 
 type CleanerUseCase interface {
@@ -169,10 +426,25 @@ type CleanerUseCase interface {
 	UpdateCleanerSchedule(userID int, schedule *CleanerSchedule) error
 	UpdateCleanerSchedulePatch(userID int, schedule *CleanerSchedulePatch) error
 
-	StartCleaning(userID int, request *StartCleaningRequest) error
-	CompleteCleaning(userID int, request *CompleteCleaningRequest) error
-	GetCleaningHistory(userID int, filters map[string]interface{}, page, pageSize int) ([]map[string]interface{}, int, error)
+	StartCleaning(userID int, request *StartCleaningRequest, actor *LockCodeRevealActor) (*CleaningLog, error)
+	GetCleaningAccessCode(userID, apartmentID int, actor *LockCodeRevealActor) (*CleaningAccessCode, error)
+	CompleteCleaning(userID int, request *CompleteCleaningRequest, photos [][]byte) (*CleaningLog, error)
+	CancelCleaning(userID int, request *CancelCleaningRequest) (*CleaningLog, error)
+	GetCleaningHistory(userID int, filters map[string]interface{}, page, pageSize int) ([]*CleaningLog, int, error)
+	GetOwnerCleaningHistory(ownerUserID int, filters map[string]interface{}, page, pageSize int) ([]*CleaningLog, int, error)
+	GetAllCleaningLogs(filters map[string]interface{}, page, pageSize int) ([]*CleaningLog, int, error)
+	AdminCancelCleaning(logID int, reason string) (*CleaningLog, error)
 	GetApartmentsNeedingCleaning() ([]*ApartmentForCleaning, error)
+
+	SetCleaningScheduler(scheduler CleaningScheduler)
+	DispatchCleaningForBooking(bookingID int, checkoutAt time.Time) error
+	HandleCleaningOfferTimeout(taskID, attempt int) error
+	CheckCleaningDeadline(taskID int) error
+	GetMyCleaningTasks(userID int, filters map[string]interface{}, page, pageSize int) ([]*CleaningTask, int, error)
+	AcceptCleaningTask(userID, taskID int) (*CleaningTask, error)
+	DeclineCleaningTask(userID, taskID int, reason string) (*CleaningTask, error)
+	GetCleaningTasks(filters map[string]interface{}, page, pageSize int) ([]*CleaningTask, int, error)
+	AdminAssignCleaningTask(taskID, cleanerID int) (*CleaningTask, error)
 }
 
 type CleanerResponse struct {
@@ -38,13 +38,15 @@ type Contract struct {
 }
 
 type RentalContractSnapshot struct {
-	BookingNumber string    `json:"booking_number"`
-	StartDate     time.Time `json:"start_date"`
-	EndDate       time.Time `json:"end_date"`
-	Duration      int       `json:"duration"`
-	TotalPrice    int       `json:"total_price"`
-	ServiceFee    int       `json:"service_fee"`
-	FinalPrice    int       `json:"final_price"`
+	BookingNumber  string          `json:"booking_number"`
+	StartDate      time.Time       `json:"start_date"`
+	EndDate        time.Time       `json:"end_date"`
+	Duration       int             `json:"duration"`
+	Nights         int             `json:"nights,omitempty"`
+	TotalPrice     int             `json:"total_price"`
+	ServiceFee     int             `json:"service_fee"`
+	FinalPrice     int             `json:"final_price"`
+	PriceBreakdown *PriceBreakdown `json:"price_breakdown,omitempty"`
 
 	RenterName string `json:"renter_name"`
 	RenterIIN  string `json:"renter_iin"`
@@ -119,13 +121,13 @@ type ContractTemplateRepository interface {
 }
 
 type ContractService interface {
-	GenerateContractHTML(contractID int) (string, error)
-	GetOrGenerateContractHTML(contractID int) (string, error)
+	GenerateContractHTML(contractID int, lang string) (string, error)
+	GetOrGenerateContractHTML(contractID int, lang string) (string, error)
 
 	InvalidateContractCache(contractID int) error
 	WarmupContractCache(contractID int) error
 
-	RenderTemplate(contractType ContractType, data *ContractTemplateData) (string, error)
+	RenderTemplate(contractType ContractType, data *ContractTemplateData, lang string) (string, error)
 }
 
 type ContractUseCase interface {
@@ -135,7 +137,7 @@ type ContractUseCase interface {
 	GetContractByID(id int) (*Contract, error)
 	GetContractByBookingID(bookingID int) (*Contract, error)
 	GetApartmentContract(apartmentID int) (*Contract, error)
-	GetContractHTML(contractID int) (string, error)
+	GetContractHTML(contractID int, lang string) (string, error)
 
 	UpdateContractStatus(contractID int, status ContractStatus) error
 	ConfirmContract(contractID int) error
@@ -169,6 +171,7 @@ type ContractHTMLResponse struct {
 	ContractID int            `json:"contract_id"`
 	HTML       string         `json:"html"`
 	Status     ContractStatus `json:"status"`
+	Language   string         `json:"language"`
 	CachedAt   string         `json:"cached_at"`
 }
 
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"encoding/json"
+	"log/slog"
 	"time"
 )
 
@@ -35,8 +37,126 @@ const (
 	LockChangeSourceSystem  LockChangeSource = "system"
 	LockChangeSourceTuya    LockChangeSource = "tuya"
 	LockChangeSourceWebhook LockChangeSource = "webhook"
+	LockChangeSourceDevice  LockChangeSource = "device"
 )
 
+// Secret код доступа к замку. В JSON, логах и fmt выводится замаскированным;
+// исходное значение доступно только через Reveal - в эндпоинтах раскрытия кода.
+type Secret string
+
+const secretMask = "******"
+
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+func (s Secret) IsEmpty() bool {
+	return s == ""
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return secretMask
+}
+
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+type LockCodeType string
+
+const (
+	LockCodeTypeOwner LockCodeType = "owner"
+	LockCodeTypeTemp  LockCodeType = "temp"
+)
+
+// Причины раскрытия кода в журнале
+const (
+	LockCodeRevealReasonOwner    = "owner_request"
+	LockCodeRevealReasonBooking  = "booking_password"
+	LockCodeRevealReasonCleaning = "cleaning_password"
+	LockCodeRevealReasonAdmin    = "admin_reveal"
+)
+
+// LockCodeRevealActor кто запрашивает код замка. Передается из HTTP-слоя и попадает в журнал раскрытий.
+type LockCodeRevealActor struct {
+	UserID    int
+	IPAddress string
+	UserAgent string
+}
+
+// LockCodeReveal запись журнала раскрытия кода замка.
+type LockCodeReveal struct {
+	ID             int          `json:"id"`
+	LockID         int          `json:"lock_id"`
+	TempPasswordID *int         `json:"temp_password_id"`
+	CodeType       LockCodeType `json:"code_type"`
+	UserID         *int         `json:"user_id"`
+	BookingID      *int         `json:"booking_id"`
+	Reason         string       `json:"reason"`
+	IPAddress      string       `json:"ip_address"`
+	UserAgent      string       `json:"user_agent"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+// Заголовки подписанных запросов устройства замка. Подпись - hex(HMAC-SHA256(секрет устройства, строка)),
+// где строка: "METHOD\nPATH\nTIMESTAMP\nNONCE\nhex(SHA256(тело запроса))", TIMESTAMP - unix-время в секундах.
+const (
+	LockDeviceIDHeader        = "X-Lock-ID"
+	LockDeviceTimestampHeader = "X-Lock-Timestamp"
+	LockDeviceNonceHeader     = "X-Lock-Nonce"
+	LockDeviceSignatureHeader = "X-Lock-Signature"
+)
+
+// LockDeviceRequest подписанный запрос устройства замка для проверки подписи.
+type LockDeviceRequest struct {
+	UniqueID  string
+	Timestamp string
+	Nonce     string
+	Signature string
+	Method    string
+	Path      string
+	Body      []byte
+	IPAddress string
+}
+
+// LockDeviceCredentials секрет устройства замка. После ротации предыдущий секрет принимается
+// до PreviousExpiresAt, чтобы устройство успело получить новый.
+type LockDeviceCredentials struct {
+	ID                int        `json:"id"`
+	LockID            int        `json:"lock_id"`
+	Secret            Secret     `json:"-"`
+	PreviousSecret    Secret     `json:"-"`
+	PreviousExpiresAt *time.Time `json:"previous_expires_at"`
+	RotatedAt         time.Time  `json:"rotated_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// LockDeviceSecretResponse новый секрет устройства. Секрет возвращается в открытом виде только
+// при создании замка и ротации, после этого его нельзя получить повторно.
+type LockDeviceSecretResponse struct {
+	LockID            int        `json:"lock_id"`
+	UniqueID          string     `json:"unique_id"`
+	DeviceSecret      string     `json:"device_secret"`
+	PreviousExpiresAt *time.Time `json:"previous_expires_at"`
+	RotatedAt         time.Time  `json:"rotated_at"`
+}
+
+type RotateLockDeviceSecretRequest struct {
+	// GracePeriodMinutes сколько минут принимать предыдущий секрет (0 - отозвать сразу)
+	GracePeriodMinutes *int `json:"grace_period_minutes,omitempty"`
+}
+
 type Lock struct {
 	ID               int        `json:"id"`
 	UniqueID         string     `json:"unique_id"`
@@ -62,9 +182,13 @@ type Lock struct {
 	AutoUpdateEnabled bool       `json:"auto_update_enabled"`
 	WebhookConfigured bool       `json:"webhook_configured"`
 
-	OwnerPassword string    `json:"owner_password"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	OwnerPassword Secret `json:"owner_password" swaggertype:"string" example:"******"`
+
+	// DeviceSecret заполняется только в ответе на создание замка
+	DeviceSecret string `json:"device_secret,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type LockStatusLog struct {
@@ -88,9 +212,10 @@ type LockTempPassword struct {
 	Lock           *Lock     `json:"lock,omitempty"`
 	BookingID      *int      `json:"booking_id"`
 	Booking        *Booking  `json:"booking,omitempty"`
+	CleaningLogID  *int      `json:"cleaning_log_id,omitempty"`
 	UserID         *int      `json:"user_id"`
 	User           *User     `json:"user,omitempty"`
-	Password       string    `json:"password"`
+	Password       Secret    `json:"password" swaggertype:"string" example:"******"`
 	TuyaPasswordID int64     `json:"tuya_password_id"`
 	Name           string    `json:"name"`
 	ValidFrom      time.Time `json:"valid_from"`
@@ -152,6 +277,23 @@ type TuyaWebhookEvent struct {
 	BizData    map[string]interface{} `json:"bizData"`
 }
 
+// TuyaWebhookMessage сообщение webhook Tuya. Data - зашифрованное событие TuyaWebhookEvent (base64),
+// Sign - подпись сообщения ключом ClientSecret, T - время отправки в миллисекундах.
+type TuyaWebhookMessage struct {
+	Protocol     int    `json:"protocol"`
+	PV           string `json:"pv"`
+	T            int64  `json:"t"`
+	Sign         string `json:"sign"`
+	Data         string `json:"data"`
+	EncryptModel string `json:"encryptModel,omitempty"`
+}
+
+// TuyaWebhookResult итог приема webhook Tuya. Duplicate - событие уже было обработано ранее.
+type TuyaWebhookResult struct {
+	EventID   string `json:"event_id"`
+	Duplicate bool   `json:"duplicate"`
+}
+
 type LockRepository interface {
 	Create(lock *Lock) error
 	GetByID(id int) (*Lock, error)
@@ -180,10 +322,22 @@ type LockRepository interface {
 	CreateTempPassword(tempPassword *LockTempPassword) error
 	GetTempPasswordsByLockID(lockID int) ([]*LockTempPassword, error)
 	GetTempPasswordsByBookingID(bookingID int) ([]*LockTempPassword, error)
+	GetTempPasswordsByCleaningLogID(cleaningLogID int) ([]*LockTempPassword, error)
 	GetTempPasswordByID(id int) (*LockTempPassword, error)
 	UpdateTempPassword(tempPassword *LockTempPassword) error
 	DeleteTempPassword(id int) error
 	DeactivateTempPassword(id int) error
+
+	CreateDeviceCredentials(credentials *LockDeviceCredentials) error
+	GetDeviceCredentials(lockID int) (*LockDeviceCredentials, error)
+	UpdateDeviceCredentials(credentials *LockDeviceCredentials) error
+
+	CreateCodeReveal(reveal *LockCodeReveal) error
+	GetCodeRevealsByLockID(lockID int, limit int) ([]*LockCodeReveal, error)
+
+	// RotateCodeEncryption перешифровывает коды и секреты устройств активным мастер-ключом и шифрует коды,
+	// сохраненные до включения шифрования. Возвращает число обновленных записей.
+	RotateCodeEncryption() (int, error)
 }
 
 type LockUseCase interface {
@@ -198,7 +352,9 @@ type LockUseCase interface {
 
 	UpdateLockStatus(request *LockStatusUpdateRequest) error
 	ProcessHeartbeat(request *LockHeartbeatRequest) error
+	AuthenticateDeviceRequest(request *LockDeviceRequest) (*Lock, error)
 
+	ReceiveTuyaWebhook(message *TuyaWebhookMessage) (*TuyaWebhookResult, error)
 	ProcessTuyaWebhookEvent(event *TuyaWebhookEvent) error
 	SyncAllLocksWithTuya() error
 	SyncLockWithTuya(uniqueID string) error
@@ -206,12 +362,17 @@ type LockUseCase interface {
 	DisableAutoUpdate(uniqueID string) error
 	ConfigureTuyaWebhooks(uniqueID string) error
 
-	GeneratePasswordForBooking(uniqueID string, userID int, bookingID int) (string, error)
-	GeneratePasswordForBookingByID(bookingID, userID int) (string, error)
-	GetOwnerPassword(uniqueID string, userID int) (string, error)
+	GeneratePasswordForBooking(uniqueID string, actor *LockCodeRevealActor, bookingID int) (string, error)
+	GeneratePasswordForBookingByID(bookingID int, actor *LockCodeRevealActor) (string, error)
+	GetOwnerPassword(uniqueID string, actor *LockCodeRevealActor) (string, error)
 	DeactivatePasswordForBooking(bookingID int) error
 	ExtendPasswordForBooking(bookingID int, newEndDate time.Time) error
 
+	// GeneratePasswordForCleaning выдает уборщице временный пароль на время уборки. Если действующий
+	// пароль уже выдан, возвращает его.
+	GeneratePasswordForCleaning(uniqueID string, actor *LockCodeRevealActor, cleaningLogID int, validUntil time.Time) (*LockTempPassword, error)
+	DeactivatePasswordForCleaning(cleaningLogID int) error
+
 	GetLockStatus(uniqueID string) (*Lock, error)
 	GetLockHistory(uniqueID string, limit int) ([]*LockStatusLog, error)
 
@@ -233,11 +394,27 @@ type LockUseCase interface {
 	AdminGeneratePassword(uniqueID string, request *AdminGeneratePasswordRequest) (*LockTempPassword, error)
 	AdminGetAllLockPasswords(uniqueID string) ([]*LockTempPassword, error)
 	AdminDeactivatePassword(passwordID int) error
+	AdminRevealPassword(passwordID int, actor *LockCodeRevealActor) (string, error)
+	AdminGetCodeReveals(uniqueID string, limit int) ([]*LockCodeReveal, error)
+	AdminRotateDeviceSecret(lockID int, request *RotateLockDeviceSecretRequest) (*LockDeviceSecretResponse, error)
+	AdminRevokePreviousDeviceSecret(lockID int) error
+
+	RotateCodeEncryption() (int, error)
 
 	SetNotificationUseCase(notificationUseCase NotificationUseCase)
+	SetNonceStore(nonceStore NonceStore)
+}
+
+// NonceStore одноразовые ключи для защиты от повторной отправки запросов и повторной обработки событий.
+type NonceStore interface {
+	// Claim помечает ключ использованным на ttl. Возвращает false, если ключ уже использован.
+	Claim(key string, ttl time.Duration) (bool, error)
+	// Release освобождает ключ, например если событие не удалось обработать и его нужно принять повторно.
+	Release(key string) error
 }
 
 type TuyaLockService interface {
 	GenerateTemporaryPasswordWithTimes(deviceID, name, rawPassword string, validFrom, validUntil time.Time) (string, int64, error)
 	DeleteTempPassword(deviceID string, passwordID int64) error
+	OpenWebhookMessage(message *TuyaWebhookMessage) (*TuyaWebhookEvent, error)
 }
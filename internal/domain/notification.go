@@ -30,6 +30,15 @@ const (
 	NotificationApartmentRejected      NotificationType = "apartment_rejected"
 	NotificationApartmentUpdated       NotificationType = "apartment_updated"
 	NotificationApartmentStatusChanged NotificationType = "apartment_status_changed"
+
+	NotificationReviewRequest   NotificationType = "review_request"
+	NotificationReviewPublished NotificationType = "review_published"
+	NotificationReviewRejected  NotificationType = "review_rejected"
+
+	NotificationCleaningCompleted     NotificationType = "cleaning_completed"
+	NotificationCleaningTaskOffered   NotificationType = "cleaning_task_offered"
+	NotificationCleaningTaskEscalated NotificationType = "cleaning_task_escalated"
+	NotificationCleaningLate          NotificationType = "cleaning_late"
 )
 
 type NotificationPriority string
@@ -41,6 +50,181 @@ const (
 	NotificationPriorityUrgent NotificationPriority = "urgent"
 )
 
+var notificationTypes = map[NotificationType]bool{
+	NotificationBookingApproved:        true,
+	NotificationBookingRejected:        true,
+	NotificationBookingCanceled:        true,
+	NotificationBookingCompleted:       true,
+	NotificationPasswordReady:          true,
+	NotificationExtensionRequest:       true,
+	NotificationExtensionApproved:      true,
+	NotificationExtensionRejected:      true,
+	NotificationCheckoutReminder:       true,
+	NotificationLockIssue:              true,
+	NotificationNewBooking:             true,
+	NotificationSessionFinished:        true,
+	NotificationBookingStartingSoon:    true,
+	NotificationBookingEnding:          true,
+	NotificationPaymentRequired:        true,
+	NotificationApartmentCreated:       true,
+	NotificationApartmentApproved:      true,
+	NotificationApartmentRejected:      true,
+	NotificationApartmentUpdated:       true,
+	NotificationApartmentStatusChanged: true,
+	NotificationReviewRequest:          true,
+	NotificationReviewPublished:        true,
+	NotificationReviewRejected:         true,
+	NotificationCleaningCompleted:      true,
+	NotificationCleaningTaskOffered:    true,
+	NotificationCleaningTaskEscalated:  true,
+	NotificationCleaningLate:           true,
+}
+
+func IsValidNotificationType(notificationType NotificationType) bool {
+	return notificationTypes[notificationType]
+}
+
+type NotificationChannel string
+
+const (
+	NotificationChannelPush  NotificationChannel = "push"
+	NotificationChannelInApp NotificationChannel = "in_app"
+	NotificationChannelSMS   NotificationChannel = "sms"
+	NotificationChannelEmail NotificationChannel = "email"
+)
+
+func IsValidNotificationChannel(channel NotificationChannel) bool {
+	switch channel {
+	case NotificationChannelPush, NotificationChannelInApp, NotificationChannelSMS, NotificationChannelEmail:
+		return true
+	}
+	return false
+}
+
+// NotificationDelivery уведомление, подготовленное по шаблону для внешнего канала.
+// Recipient - телефон для SMS или адрес для email; HTMLBody заполняется только для email.
+type NotificationDelivery struct {
+	UserID           int
+	NotificationType NotificationType
+	Recipient        string
+	Subject          string
+	Body             string
+	HTMLBody         string
+}
+
+// NotificationSender драйвер внешнего канала доставки (SMS, email). Драйверы подключаются
+// к NotificationUseCase при сборке приложения и могут заменяться локальными реализациями.
+type NotificationSender interface {
+	Channel() NotificationChannel
+	Send(delivery *NotificationDelivery) error
+}
+
+const (
+	DefaultQuietHoursStart      = "22:00"
+	DefaultQuietHoursEnd        = "08:00"
+	DefaultNotificationTimezone = "Asia/Almaty"
+)
+
+// NotificationPreferences настройки доставки уведомлений пользователя. Срочные уведомления
+// (NotificationPriorityUrgent) доставляются по всем каналам независимо от настроек.
+type NotificationPreferences struct {
+	UserID            int                   `json:"user_id"`
+	MutedTypes        []NotificationType    `json:"muted_types"`
+	QuietHoursEnabled bool                  `json:"quiet_hours_enabled"`
+	QuietHoursStart   string                `json:"quiet_hours_start" example:"22:00"`
+	QuietHoursEnd     string                `json:"quiet_hours_end" example:"08:00"`
+	Timezone          string                `json:"timezone" example:"Asia/Almaty"`
+	Channels          []NotificationChannel `json:"channels"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+}
+
+func DefaultNotificationPreferences(userID int) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:          userID,
+		MutedTypes:      []NotificationType{},
+		QuietHoursStart: DefaultQuietHoursStart,
+		QuietHoursEnd:   DefaultQuietHoursEnd,
+		Timezone:        DefaultNotificationTimezone,
+		Channels:        []NotificationChannel{NotificationChannelPush, NotificationChannelInApp},
+	}
+}
+
+func (p *NotificationPreferences) IsMuted(notificationType NotificationType) bool {
+	for _, muted := range p.MutedTypes {
+		if muted == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *NotificationPreferences) HasChannel(channel NotificationChannel) bool {
+	for _, enabled := range p.Channels {
+		if enabled == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// QuietHoursDelay возвращает время до окончания тихих часов в часовом поясе пользователя
+// или 0, если now не попадает в тихие часы. Интервал может переходить через полночь.
+func (p *NotificationPreferences) QuietHoursDelay(now time.Time) time.Duration {
+	if !p.QuietHoursEnabled {
+		return 0
+	}
+
+	start, errStart := ParseClockMinutes(p.QuietHoursStart)
+	end, errEnd := ParseClockMinutes(p.QuietHoursEnd)
+	if errStart != nil || errEnd != nil || start == end {
+		return 0
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	minutes := local.Hour()*60 + local.Minute()
+
+	var inQuietHours bool
+	if start < end {
+		inQuietHours = minutes >= start && minutes < end
+	} else {
+		inQuietHours = minutes >= start || minutes < end
+	}
+	if !inQuietHours {
+		return 0
+	}
+
+	endsAt := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !endsAt.After(local) {
+		endsAt = endsAt.AddDate(0, 0, 1)
+	}
+
+	return endsAt.Sub(now)
+}
+
+// ParseClockMinutes разбирает время суток в формате HH:MM и возвращает число минут от полуночи.
+func ParseClockMinutes(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("время должно быть в формате HH:MM")
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// UpdateNotificationPreferencesRequest частичное обновление настроек: незаданные поля не меняются.
+type UpdateNotificationPreferencesRequest struct {
+	MutedTypes        *[]NotificationType    `json:"muted_types,omitempty"`
+	QuietHoursEnabled *bool                  `json:"quiet_hours_enabled,omitempty"`
+	QuietHoursStart   *string                `json:"quiet_hours_start,omitempty" example:"22:00"`
+	QuietHoursEnd     *string                `json:"quiet_hours_end,omitempty" example:"08:00"`
+	Timezone          *string                `json:"timezone,omitempty" example:"Asia/Almaty"`
+	Channels          *[]NotificationChannel `json:"channels,omitempty"`
+}
+
 type DeviceType string
 
 const (
@@ -63,6 +247,12 @@ type Notification struct {
 	ApartmentID *int                   `json:"apartment_id,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
 	ReadAt      *time.Time             `json:"read_at,omitempty"`
+
+	// TitleKey и MessageKey коды сообщений каталога pkg/i18n. Если они заданы, заголовок и текст
+	// формируются на языке получателя из PreferredLanguage, а Title и Message заполняются при создании.
+	TitleKey   string            `json:"-"`
+	MessageKey string            `json:"-"`
+	Params     map[string]string `json:"-"`
 }
 
 type UserDevice struct {
@@ -87,6 +277,10 @@ type PushMessage struct {
 	Data             map[string]interface{} `json:"data,omitempty"`
 	BookingID        *int                   `json:"booking_id,omitempty"`
 	ApartmentID      *int                   `json:"apartment_id,omitempty"`
+	// Коды сообщений каталога: push, SMS и письма переводятся на язык получателя в момент отправки
+	TitleKey string            `json:"title_key,omitempty"`
+	BodyKey  string            `json:"body_key,omitempty"`
+	Params   map[string]string `json:"params,omitempty"`
 }
 
 type CreateNotificationRequest struct {
@@ -128,6 +322,9 @@ type NotificationRepository interface {
 	UpdateDevice(device *UserDevice) error
 	DeactivateDevice(token string) error
 	UpdateDeviceHeartbeat(token string) error
+
+	GetPreferences(userID int) (*NotificationPreferences, error)
+	UpsertPreferences(preferences *NotificationPreferences) error
 }
 
 type NotificationUseCase interface {
@@ -148,6 +345,9 @@ type NotificationUseCase interface {
 	DeactivateDevice(deviceToken string) error
 	GetDevicesByUserID(userID int) ([]*UserDevice, error)
 
+	GetPreferences(userID int) (*NotificationPreferences, error)
+	UpdatePreferences(userID int, request *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error)
+
 	NotifyBookingApproved(userID int, bookingID int, apartmentTitle string) error
 	NotifyBookingRejected(userID int, bookingID int, apartmentTitle, reason string) error
 	NotifyBookingCanceled(userID int, bookingID int, apartmentTitle string, reason string) error
@@ -172,6 +372,16 @@ type NotificationUseCase interface {
 	NotifyApartmentUpdated(ownerUserID int, apartmentID int, apartmentTitle string) error
 	NotifyApartmentStatusChanged(ownerUserID int, apartmentID int, apartmentTitle string, oldStatus, newStatus string) error
 
+	NotifyApartmentReviewRequested(renterUserID int, bookingID int, apartmentTitle string, deadline time.Time) error
+	NotifyRenterReviewRequested(ownerUserID int, bookingID int, renterName string, deadline time.Time) error
+	NotifyReviewPublished(userID int, apartmentID int, title string, rating float64) error
+	NotifyReviewRejected(userID int, bookingID int, reason string) error
+
+	NotifyCleaningCompleted(ownerUserID int, apartmentID int, cleaningLogID int, apartmentTitle string, photosCount int) error
+	NotifyCleaningTaskOffered(cleanerUserID int, apartmentID int, cleaningTaskID int, apartmentTitle string, deadline time.Time, timeoutMinutes int) error
+	NotifyCleaningTaskEscalated(adminUserID int, apartmentID int, cleaningTaskID int, apartmentTitle string, deadline time.Time) error
+	NotifyCleaningLate(userID int, apartmentID int, cleaningTaskID int, apartmentTitle string, deadline time.Time) error
+
 	StartNotificationConsumer()
 }
 
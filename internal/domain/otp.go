@@ -72,7 +72,7 @@ type OTPService interface {
 type OTPUseCase interface {
 	RequestOTP(phone string) (*OTPRequestResponse, error)
 	VerifyOTP(phone, code string) (bool, error)
-	VerifyOTPAndAuthenticate(id, phone, code string) (*OTPAuthResponse, error)
+	VerifyOTPAndAuthenticate(id, phone, code string, client SessionClient) (*OTPAuthResponse, error)
 	CheckStatus(id string) (*OTPStatusResponse, error)
 }
 
@@ -1,6 +1,12 @@
 package domain
 
-import "time"
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 type PaymentStatusRequest struct {
 	PaymentID string `json:"payment_id" binding:"required"`
@@ -16,6 +22,18 @@ type ProcessPaymentRequest struct {
 	OrderID   string `json:"order_id,omitempty"`
 }
 
+type InitPaymentResponse struct {
+	PaymentID   int64         `json:"payment_id"`
+	FPPaymentID string        `json:"fp_payment_id"`
+	OrderID     string        `json:"order_id"`
+	BookingID   int64         `json:"booking_id"`
+	ExtensionID *int64        `json:"extension_id,omitempty"`
+	Amount      int           `json:"amount"`
+	Currency    string        `json:"currency"`
+	Status      PaymentStatus `json:"status"`
+	RedirectURL string        `json:"redirect_url"`
+}
+
 type PaymentStatus string
 
 const (
@@ -27,6 +45,41 @@ const (
 	PaymentStatusCanceled   PaymentStatus = "canceled"
 )
 
+// Префиксы order_id FreedomPay: booking_<id>_<попытка> для бронирований, extension_<id>_<попытка> для продлений.
+// Заказы, созданные до появления суффикса попытки, имеют вид booking_<id> и extension_<id>.
+const (
+	BookingPaymentOrderPrefix   = "booking_"
+	ExtensionPaymentOrderPrefix = "extension_"
+)
+
+// NewPaymentOrderID формирует уникальный order_id для очередной попытки оплаты.
+// FreedomPay не принимает повторный pg_order_id, поэтому каждая инициализация получает свой суффикс.
+func NewPaymentOrderID(prefix string, id int) string {
+	return fmt.Sprintf("%s%d_%s", prefix, id, strconv.FormatInt(time.Now().UnixNano(), 36))
+}
+
+// ParsePaymentOrderID извлекает ID бронирования или продления из order_id с указанным префиксом.
+// Поддерживает как order_id с суффиксом попытки, так и старый формат без него.
+func ParsePaymentOrderID(orderID, prefix string) (int, bool) {
+	if !strings.HasPrefix(orderID, prefix) {
+		return 0, false
+	}
+
+	idPart := strings.TrimPrefix(orderID, prefix)
+	if separator := strings.IndexByte(idPart, '_'); separator >= 0 {
+		if separator == len(idPart)-1 {
+			return 0, false
+		}
+		idPart = idPart[:separator]
+	}
+
+	id, err := strconv.Atoi(idPart)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
 type PaymentLogAction string
 
 const (
@@ -49,7 +102,9 @@ const (
 type Payment struct {
 	ID                 int64                     `json:"id"`
 	BookingID          int64                     `json:"booking_id"`
+	ExtensionID        *int64                    `json:"extension_id,omitempty"`
 	PaymentID          string                    `json:"payment_id"`
+	OrderID            *string                   `json:"order_id,omitempty"`
 	Amount             int                       `json:"amount"`
 	Currency           string                    `json:"currency"`
 	Status             PaymentStatus             `json:"status"`
@@ -57,6 +112,7 @@ type Payment struct {
 	ProviderStatus     *string                   `json:"provider_status,omitempty"`
 	ProviderResponse   *FreedomPayStatusResponse `json:"provider_response,omitempty"`
 	FinalBookingStatus *string                   `json:"final_booking_status,omitempty"`
+	RedirectURL        *string                   `json:"redirect_url,omitempty"`
 	ProcessedAt        *time.Time                `json:"processed_at,omitempty"`
 	CreatedAt          time.Time                 `json:"created_at"`
 	UpdatedAt          time.Time                 `json:"updated_at"`
@@ -115,6 +171,36 @@ type PaymentStatusResponse struct {
 	ErrorMessage  string `json:"error_message,omitempty"`
 }
 
+type FreedomPayInitPaymentRequest struct {
+	OrderID     string
+	Amount      int
+	Description string
+	UserPhone   string
+	UserEmail   string
+}
+
+type FreedomPayInitPaymentResponse struct {
+	Status           string `xml:"pg_status" json:"pg_status"`
+	PaymentID        string `xml:"pg_payment_id" json:"pg_payment_id"`
+	RedirectURL      string `xml:"pg_redirect_url" json:"pg_redirect_url"`
+	RedirectURLType  string `xml:"pg_redirect_url_type" json:"pg_redirect_url_type"`
+	Salt             string `xml:"pg_salt" json:"pg_salt"`
+	Signature        string `xml:"pg_sig" json:"pg_sig"`
+	ErrorCode        string `xml:"pg_error_code" json:"pg_error_code"`
+	ErrorDescription string `xml:"pg_error_description" json:"pg_error_description"`
+}
+
+type InitPaymentRequest struct {
+	BookingID   int64
+	ExtensionID *int64
+	UserID      *int64
+	OrderID     string
+	Amount      int
+	Description string
+	UserPhone   string
+	UserEmail   string
+}
+
 type FreedomPayRefundResponse struct {
 	Status           string `xml:"pg_status"`
 	ErrorCode        string `xml:"pg_error_code"`
@@ -123,6 +209,48 @@ type FreedomPayRefundResponse struct {
 	Signature        string `xml:"pg_sig"`
 }
 
+type FreedomPayCallbackType string
+
+const (
+	FreedomPayCallbackCheck  FreedomPayCallbackType = "check"
+	FreedomPayCallbackResult FreedomPayCallbackType = "result"
+)
+
+const (
+	FreedomPayResponseOK       = "ok"
+	FreedomPayResponseRejected = "rejected"
+	FreedomPayResponseError    = "error"
+)
+
+type FreedomPayCallback struct {
+	Type               FreedomPayCallbackType
+	OrderID            string
+	PaymentID          string
+	Amount             string
+	Currency           string
+	Result             string
+	PaymentMethod      string
+	CardPan            string
+	Captured           string
+	PaymentDate        string
+	FailureCode        string
+	FailureDescription string
+	IPAddress          string
+	UserAgent          string
+}
+
+func (c *FreedomPayCallback) IsSuccessful() bool {
+	return c.Result == "1"
+}
+
+type FreedomPayCallbackResponse struct {
+	XMLName     xml.Name `xml:"response" swaggerignore:"true"`
+	Status      string   `xml:"pg_status"`
+	Description string   `xml:"pg_description,omitempty"`
+	Salt        string   `xml:"pg_salt"`
+	Signature   string   `xml:"pg_sig"`
+}
+
 type RefundRequest struct {
 	PaymentID    string `json:"payment_id" binding:"required"`
 	RefundAmount *int   `json:"refund_amount,omitempty"`
@@ -135,10 +263,11 @@ type RefundResponse struct {
 }
 
 type ReceiptAmounts struct {
-	TotalPrice int    `json:"total_price"`
-	ServiceFee int    `json:"service_fee"`
-	FinalPrice int    `json:"final_price"`
-	Currency   string `json:"currency"`
+	TotalPrice     int             `json:"total_price"`
+	ServiceFee     int             `json:"service_fee"`
+	FinalPrice     int             `json:"final_price"`
+	Currency       string          `json:"currency"`
+	PriceBreakdown *PriceBreakdown `json:"price_breakdown,omitempty"`
 }
 
 type ReceiptBookingDetails struct {
@@ -146,6 +275,7 @@ type ReceiptBookingDetails struct {
 	StartDate        string `json:"start_date"`
 	EndDate          string `json:"end_date"`
 	DurationHours    int    `json:"duration_hours"`
+	Nights           int    `json:"nights,omitempty"`
 	RentalType       string `json:"rental_type"`
 }
 
@@ -164,16 +294,21 @@ type PaymentReceipt struct {
 }
 
 type FreedomPayService interface {
+	InitPayment(request *FreedomPayInitPaymentRequest) (*FreedomPayInitPaymentResponse, error)
 	GetPaymentStatus(paymentID string) (*FreedomPayStatusResponse, error)
 	GetPaymentStatusByOrderID(orderID string) (*FreedomPayStatusResponse, error)
 	RefundPayment(paymentID string, refundAmount *int) (*FreedomPayRefundResponse, error)
+	GenerateParamsSignature(scriptName string, data map[string]string) string
 }
 
 type PaymentUseCase interface {
+	InitPayment(request *InitPaymentRequest) (*Payment, error)
 	CheckPaymentStatus(paymentID string) (*PaymentStatusResponse, error)
 	CheckPaymentStatusByOrderID(orderID string, bookingID int64) (*PaymentStatusResponse, error)
 	CheckPaymentStatusWithBooking(paymentID string, bookingID int64) (*PaymentStatusResponse, error)
 	RefundPayment(paymentID string, refundAmount *int) (*RefundResponse, error)
+	VerifyCallbackSignature(scriptName string, params map[string]string) bool
+	BuildCallbackResponse(scriptName, status, description string) *FreedomPayCallbackResponse
 }
 
 type PaymentRepository interface {
@@ -181,6 +316,8 @@ type PaymentRepository interface {
 	GetByID(id int64) (*Payment, error)
 	GetByPaymentID(paymentID string) (*Payment, error)
 	GetByBookingID(bookingID int64) ([]*Payment, error)
+	GetByExtensionID(extensionID int64) ([]*Payment, error)
+	GetStaleByStatuses(statuses []PaymentStatus, createdBefore time.Time, limit int) ([]*Payment, error)
 	Update(payment *Payment) error
 	GetAll(filters map[string]interface{}, page, pageSize int) ([]*Payment, int, error)
 }
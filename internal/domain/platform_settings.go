@@ -38,6 +38,11 @@ type PlatformSettingsUseCase interface {
 	GetDefaultCleaningDurationMinutes() (int, error)
 	GetPlatformCommissionPercentage() (int, error)
 	GetMaxAdvanceBookingDays() (int, error)
+	GetDefaultCancellationPolicy() (CancellationPolicyCode, error)
+	GetReviewWindowDays() (int, error)
+	GetRateLimit(policy RateLimitPolicy) (RateLimit, error)
+	GetLockoutPolicy() (LockoutPolicy, error)
+	GetCleaningOfferTimeoutMinutes() (int, error)
 }
 
 const (
@@ -48,4 +53,12 @@ const (
 	SettingKeyPlatformCommissionPercentage   = "platform_commission_percentage"
 
 	SettingKeyMaxAdvanceBookingDays          = "max_advance_booking_days"
+	SettingKeyDefaultCancellationPolicy      = "default_cancellation_policy"
+	SettingKeyReviewWindowDays               = "review_window_days"
+
+	SettingKeyAuthLockoutThreshold   = "auth_lockout_threshold"
+	SettingKeyAuthLockoutBaseMinutes = "auth_lockout_base_minutes"
+	SettingKeyAuthLockoutMaxMinutes  = "auth_lockout_max_minutes"
+
+	SettingKeyCleaningOfferTimeoutMinutes = "cleaning_offer_timeout_minutes"
 )
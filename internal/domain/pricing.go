@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DailyServiceFee фиксированный сервисный сбор за каждые сутки посуточной аренды
+	DailyServiceFee = 3000
+
+	PricingDateLayout = "2006-01-02"
+	PricingCurrency   = "KZT"
+
+	MinPricingAdjustmentPercentage = -90
+	MaxPricingAdjustmentPercentage = 300
+)
+
+type PricingRuleType string
+
+const (
+	PricingRuleTypeDateRange    PricingRuleType = "date_range"
+	PricingRuleTypeWeekday      PricingRuleType = "weekday"
+	PricingRuleTypeHoliday      PricingRuleType = "holiday"
+	PricingRuleTypeLengthOfStay PricingRuleType = "length_of_stay"
+
+	// PriceAdjustmentTypeExtension строка расчета для подтвержденного продления, не является правилом
+	PriceAdjustmentTypeExtension PricingRuleType = "extension"
+)
+
+func (t PricingRuleType) IsValid() bool {
+	switch t {
+	case PricingRuleTypeDateRange, PricingRuleTypeWeekday, PricingRuleTypeHoliday, PricingRuleTypeLengthOfStay:
+		return true
+	}
+	return false
+}
+
+// PricingRule правило ценообразования квартиры.
+// date_range - переопределение цены и/или надбавка на период, weekday - надбавка по дням недели,
+// holiday - надбавка в государственные праздники, length_of_stay - скидка за длительное проживание.
+type PricingRule struct {
+	ID                   int             `json:"id"`
+	ApartmentID          int             `json:"apartment_id"`
+	Type                 PricingRuleType `json:"rule_type"`
+	Name                 string          `json:"name"`
+	StartDate            *time.Time      `json:"start_date,omitempty"`
+	EndDate              *time.Time      `json:"end_date,omitempty"`
+	Weekdays             []int           `json:"weekdays,omitempty"`
+	MinDays              *int            `json:"min_days,omitempty"`
+	HourlyPrice          *int            `json:"hourly_price,omitempty"`
+	DailyPrice           *int            `json:"daily_price,omitempty"`
+	AdjustmentPercentage int             `json:"adjustment_percentage"`
+	IsActive             bool            `json:"is_active"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+}
+
+// CoversDate проверяет, что календарная дата попадает в период правила date_range.
+func (r *PricingRule) CoversDate(date time.Time) bool {
+	if r.StartDate == nil || r.EndDate == nil {
+		return false
+	}
+	day := date.Format(PricingDateLayout)
+	return day >= r.StartDate.Format(PricingDateLayout) && day <= r.EndDate.Format(PricingDateLayout)
+}
+
+// MatchesWeekday проверяет, что день недели даты входит в правило weekday.
+func (r *PricingRule) MatchesWeekday(date time.Time) bool {
+	for _, weekday := range r.Weekdays {
+		if time.Weekday(weekday) == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// KurbanAitHolidayName название Курбан айта в public_holidays. Дата праздника определяется по лунному
+// календарю и объявляется ежегодно, поэтому не вычисляется, а заводится миграцией (засеяны 2025-2026).
+// TODO: добавлять дату Курбан айта на следующий год после ее официального объявления.
+const KurbanAitHolidayName = "Курбан айт"
+
+type PublicHoliday struct {
+	ID          int       `json:"id"`
+	Date        time.Time `json:"date"`
+	Name        string    `json:"name"`
+	CountryCode string    `json:"country_code"`
+}
+
+// PriceAdjustment корректировка цены, примененная одним правилом.
+type PriceAdjustment struct {
+	RuleID     *int            `json:"rule_id,omitempty"`
+	Type       PricingRuleType `json:"type"`
+	Name       string          `json:"name"`
+	Percentage int             `json:"percentage,omitempty"`
+	Amount     int             `json:"amount"`
+	Dates      []string        `json:"dates,omitempty"`
+}
+
+// PriceBreakdown расчет стоимости: базовая цена, корректировки по правилам и сервисный сбор.
+type PriceBreakdown struct {
+	RentalType           string            `json:"rental_type"`
+	Duration             int               `json:"duration"`
+	Units                int               `json:"units"`
+	UnitPrice            int               `json:"unit_price"`
+	BasePrice            int               `json:"base_price"`
+	Adjustments          []PriceAdjustment `json:"adjustments"`
+	AdjustmentsTotal     int               `json:"adjustments_total"`
+	TotalPrice           int               `json:"total_price"`
+	ServiceFee           int               `json:"service_fee"`
+	ServiceFeePercentage int               `json:"service_fee_percentage,omitempty"`
+	FinalPrice           int               `json:"final_price"`
+	Currency             string            `json:"currency"`
+	CalculatedAt         time.Time         `json:"calculated_at"`
+}
+
+// ApplyExtension добавляет подтвержденное продление в расчет бронирования.
+func (b *PriceBreakdown) ApplyExtension(extension *BookingExtension, totalPrice, finalPrice int) {
+	b.Adjustments = append(b.Adjustments, PriceAdjustment{
+		Type:   PriceAdjustmentTypeExtension,
+		Name:   fmt.Sprintf("Продление на %d ч", extension.Duration),
+		Amount: extension.Price,
+	})
+	b.Duration += extension.Duration
+	b.AdjustmentsTotal += extension.Price
+	b.TotalPrice = totalPrice
+	b.FinalPrice = finalPrice
+}
+
+type PricingRuleRequest struct {
+	Type                 PricingRuleType `json:"rule_type" binding:"required"`
+	Name                 string          `json:"name"`
+	StartDate            *string         `json:"start_date,omitempty" example:"2026-12-25"`
+	EndDate              *string         `json:"end_date,omitempty" example:"2027-01-08"`
+	Weekdays             []int           `json:"weekdays,omitempty"`
+	MinDays              *int            `json:"min_days,omitempty"`
+	HourlyPrice          *int            `json:"hourly_price,omitempty"`
+	DailyPrice           *int            `json:"daily_price,omitempty"`
+	AdjustmentPercentage int             `json:"adjustment_percentage"`
+	IsActive             *bool           `json:"is_active,omitempty"`
+}
+
+type PricingRuleRepository interface {
+	Create(rule *PricingRule) error
+	GetByID(id int) (*PricingRule, error)
+	GetByApartmentID(apartmentID int, activeOnly bool) ([]*PricingRule, error)
+	Update(rule *PricingRule) error
+	Delete(id int) error
+
+	GetPublicHolidays(from, to time.Time) ([]*PublicHoliday, error)
+}
+
+type PricingUseCase interface {
+	GetApartmentPricingRules(apartmentID int) ([]*PricingRule, error)
+	CreatePricingRule(apartmentID int, request *PricingRuleRequest) (*PricingRule, error)
+	UpdatePricingRule(apartmentID, ruleID int, request *PricingRuleRequest) (*PricingRule, error)
+	DeletePricingRule(apartmentID, ruleID int) error
+	GetPublicHolidays(year int) ([]*PublicHoliday, error)
+
+	CalculateBookingPrice(apartment *Apartment, startDate time.Time, duration int) (*PriceBreakdown, error)
+	CalculateStayPrice(apartment *Apartment, stay *StayPeriod) (*PriceBreakdown, error)
+	CalculateExtensionPrice(apartment *Apartment, startDate time.Time, duration int) (*PriceBreakdown, error)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitPolicy правило ограничения частоты запросов для группы маршрутов и ключа (IP, телефон, пользователь).
+// Лимит правила хранится в настройках платформы под ключом RateLimitSettingKey(policy).
+type RateLimitPolicy string
+
+const (
+	RateLimitOTPRequestByPhone   RateLimitPolicy = "otp_request_phone"
+	RateLimitOTPRequestByIP      RateLimitPolicy = "otp_request_ip"
+	RateLimitOTPVerifyByIP       RateLimitPolicy = "otp_verify_ip"
+	RateLimitOTPVerifyByPhone    RateLimitPolicy = "otp_verify_phone"
+	RateLimitLoginByIP           RateLimitPolicy = "login_ip"
+	RateLimitLoginByPhone        RateLimitPolicy = "login_phone"
+	RateLimitCheckPhoneByIP      RateLimitPolicy = "check_phone_ip"
+	RateLimitBookingCreateByUser RateLimitPolicy = "booking_create_user"
+	RateLimitRefreshTokenByIP    RateLimitPolicy = "refresh_token_ip"
+)
+
+const rateLimitSettingKeyPrefix = "rate_limit_"
+
+// DefaultRateLimits лимиты, действующие, пока в настройках платформы нет своего значения.
+var DefaultRateLimits = map[RateLimitPolicy]RateLimit{
+	RateLimitOTPRequestByPhone:   {Requests: 3, Period: 10 * time.Minute},
+	RateLimitOTPRequestByIP:      {Requests: 10, Period: time.Hour},
+	RateLimitOTPVerifyByIP:       {Requests: 20, Period: 10 * time.Minute},
+	RateLimitOTPVerifyByPhone:    {Requests: 10, Period: 10 * time.Minute},
+	RateLimitLoginByIP:           {Requests: 20, Period: 10 * time.Minute},
+	RateLimitLoginByPhone:        {Requests: 10, Period: 30 * time.Minute},
+	RateLimitCheckPhoneByIP:      {Requests: 30, Period: time.Minute},
+	RateLimitBookingCreateByUser: {Requests: 10, Period: time.Hour},
+	RateLimitRefreshTokenByIP:    {Requests: 60, Period: 10 * time.Minute},
+}
+
+// RateLimitSettingKey ключ настройки платформы с лимитом правила.
+func RateLimitSettingKey(policy RateLimitPolicy) string {
+	return rateLimitSettingKeyPrefix + string(policy)
+}
+
+// IsRateLimitSettingKey сообщает, что настройка задает лимит одного из правил.
+func IsRateLimitSettingKey(key string) bool {
+	policy, found := strings.CutPrefix(key, rateLimitSettingKeyPrefix)
+	if !found {
+		return false
+	}
+
+	_, ok := DefaultRateLimits[RateLimitPolicy(policy)]
+	return ok
+}
+
+// RateLimit емкость корзины токенов и период, за который она полностью восстанавливается.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRateLimit разбирает лимит вида "5/10m" (5 запросов за 10 минут). Период в формате time.ParseDuration.
+func ParseRateLimit(value string) (RateLimit, error) {
+	requests, period, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("лимит должен быть в формате <запросов>/<период>, например 5/10m: %q", value)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || count < 1 {
+		return RateLimit{}, fmt.Errorf("количество запросов должно быть положительным числом: %q", requests)
+	}
+
+	duration, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || duration < time.Second {
+		return RateLimit{}, fmt.Errorf("период должен быть не меньше секунды, например 30s, 10m, 1h: %q", period)
+	}
+
+	return RateLimit{Requests: count, Period: duration}, nil
+}
+
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// RateLimitDecision результат проверки лимита.
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// LockoutScope вид попыток, после серии неудач которых ключ временно блокируется.
+type LockoutScope string
+
+const (
+	LockoutScopeLogin     LockoutScope = "login"
+	LockoutScopeOTPVerify LockoutScope = "otp_verify"
+)
+
+// LockoutPolicy прогрессивная блокировка: после Threshold неудач подряд ключ блокируется на BaseDuration,
+// каждая следующая блокировка в течение суток вдвое длиннее предыдущей, но не длиннее MaxDuration.
+type LockoutPolicy struct {
+	Threshold    int
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+}
+
+// RateLimiter ограничение частоты запросов и блокировка перебора.
+type RateLimiter interface {
+	Allow(policy RateLimitPolicy, key string) (*RateLimitDecision, error)
+	// LockedFor возвращает оставшееся время блокировки ключа или 0.
+	LockedFor(scope LockoutScope, key string) (time.Duration, error)
+	// RegisterFailure учитывает неудачную попытку и возвращает длительность блокировки, если она началась.
+	RegisterFailure(scope LockoutScope, key string) (time.Duration, error)
+	ResetFailures(scope LockoutScope, key string) error
+}
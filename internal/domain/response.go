@@ -5,6 +5,8 @@ type ApiResponse struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -14,8 +16,10 @@ type SuccessResponse struct {
 }
 
 type ErrorResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error"`
+	Success bool                   `json:"success"`
+	Error   string                 `json:"error"`
+	Code    string                 `json:"code,omitempty" example:"booking_apartment_unavailable_period"`
+	Details map[string]interface{} `json:"details,omitempty"`
 }
 
 type PaginatedResponse struct {
@@ -40,3 +44,17 @@ func NewErrorResponse(message string) ApiResponse {
 		Error:   message,
 	}
 }
+
+// NewCodedErrorResponse ответ с ошибкой, у которой есть машиночитаемый код. Клиентам следует
+// ориентироваться на code, текст в error зависит от языка и может меняться.
+func NewCodedErrorResponse(code, message string, details map[string]interface{}) ApiResponse {
+	response := ApiResponse{
+		Success: false,
+		Error:   message,
+		Code:    code,
+	}
+	if len(details) > 0 {
+		response.Details = details
+	}
+	return response
+}
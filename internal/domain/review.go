@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"time"
+)
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending   ReviewStatus = "pending"
+	ReviewStatusPublished ReviewStatus = "published"
+	ReviewStatusRejected  ReviewStatus = "rejected"
+)
+
+const (
+	MinReviewRating = 1
+	MaxReviewRating = 5
+)
+
+// ApartmentReview отзыв арендатора о квартире. Публикуется после модерации.
+type ApartmentReview struct {
+	ID                int          `json:"id"`
+	BookingID         int          `json:"booking_id"`
+	ApartmentID       int          `json:"apartment_id"`
+	RenterID          int          `json:"renter_id"`
+	AuthorName        string       `json:"author_name"`
+	CleanlinessRating int          `json:"cleanliness_rating"`
+	AccuracyRating    int          `json:"accuracy_rating"`
+	LocationRating    int          `json:"location_rating"`
+	CheckInRating     int          `json:"check_in_rating"`
+	Rating            float64      `json:"rating"`
+	Comment           string       `json:"comment"`
+	Status            ReviewStatus `json:"status"`
+	ModeratorID       *int         `json:"moderator_id,omitempty"`
+	ModeratorComment  *string      `json:"moderator_comment,omitempty"`
+	ModeratedAt       *time.Time   `json:"moderated_at,omitempty"`
+	OwnerReply        *string      `json:"owner_reply,omitempty"`
+	OwnerRepliedAt    *time.Time   `json:"owner_replied_at,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+// RenterReview отзыв владельца об арендаторе. Публикуется после модерации.
+type RenterReview struct {
+	ID               int          `json:"id"`
+	BookingID        int          `json:"booking_id"`
+	RenterID         int          `json:"renter_id"`
+	OwnerID          int          `json:"owner_id"`
+	AuthorName       string       `json:"author_name"`
+	Rating           int          `json:"rating"`
+	Comment          string       `json:"comment"`
+	Status           ReviewStatus `json:"status"`
+	ModeratorID      *int         `json:"moderator_id,omitempty"`
+	ModeratorComment *string      `json:"moderator_comment,omitempty"`
+	ModeratedAt      *time.Time   `json:"moderated_at,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+type CreateApartmentReviewRequest struct {
+	BookingID         int    `json:"booking_id" binding:"required"`
+	CleanlinessRating int    `json:"cleanliness_rating" binding:"required,min=1,max=5"`
+	AccuracyRating    int    `json:"accuracy_rating" binding:"required,min=1,max=5"`
+	LocationRating    int    `json:"location_rating" binding:"required,min=1,max=5"`
+	CheckInRating     int    `json:"check_in_rating" binding:"required,min=1,max=5"`
+	Comment           string `json:"comment"`
+}
+
+type CreateRenterReviewRequest struct {
+	BookingID int    `json:"booking_id" binding:"required"`
+	Rating    int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment   string `json:"comment"`
+}
+
+type ReviewReplyRequest struct {
+	Reply string `json:"reply" binding:"required"`
+}
+
+type ModerateReviewRequest struct {
+	Status  ReviewStatus `json:"status" binding:"required" example:"published"`
+	Comment string       `json:"comment,omitempty"`
+}
+
+// ApartmentRating агрегированный рейтинг квартиры по опубликованным отзывам.
+type ApartmentRating struct {
+	Rating       float64 `json:"rating"`
+	ReviewsCount int     `json:"reviews_count"`
+	Cleanliness  float64 `json:"cleanliness"`
+	Accuracy     float64 `json:"accuracy"`
+	Location     float64 `json:"location"`
+	CheckIn      float64 `json:"check_in"`
+}
+
+type RenterRating struct {
+	RenterID     int     `json:"renter_id"`
+	Rating       float64 `json:"rating"`
+	ReviewsCount int     `json:"reviews_count"`
+}
+
+// BookingReviewState возможность оставить отзывы по бронированию для текущего пользователя.
+type BookingReviewState struct {
+	BookingID          int              `json:"booking_id"`
+	ReviewDeadline     time.Time        `json:"review_deadline"`
+	CanReviewApartment bool             `json:"can_review_apartment"`
+	CanReviewRenter    bool             `json:"can_review_renter"`
+	ApartmentReview    *ApartmentReview `json:"apartment_review,omitempty"`
+	RenterReview       *RenterReview    `json:"renter_review,omitempty"`
+}
+
+type ReviewRepository interface {
+	CreateApartmentReview(review *ApartmentReview) error
+	GetApartmentReviewByID(id int) (*ApartmentReview, error)
+	GetApartmentReviewByBookingID(bookingID int) (*ApartmentReview, error)
+	GetApartmentReviews(apartmentID int, status ReviewStatus, page, pageSize int) ([]*ApartmentReview, int, error)
+	GetApartmentReviewsByStatus(status ReviewStatus, page, pageSize int) ([]*ApartmentReview, int, error)
+	UpdateApartmentReviewModeration(review *ApartmentReview) error
+	UpdateOwnerReply(review *ApartmentReview) error
+	RecalculateApartmentRating(apartmentID int) error
+
+	CreateRenterReview(review *RenterReview) error
+	GetRenterReviewByID(id int) (*RenterReview, error)
+	GetRenterReviewByBookingID(bookingID int) (*RenterReview, error)
+	GetRenterReviews(renterID int, status ReviewStatus, page, pageSize int) ([]*RenterReview, int, error)
+	GetRenterReviewsByStatus(status ReviewStatus, page, pageSize int) ([]*RenterReview, int, error)
+	UpdateRenterReviewModeration(review *RenterReview) error
+	GetRenterRating(renterID int) (*RenterRating, error)
+}
+
+type ReviewUseCase interface {
+	CreateApartmentReview(userID int, request *CreateApartmentReviewRequest) (*ApartmentReview, error)
+	ReplyToApartmentReview(userID, reviewID int, request *ReviewReplyRequest) (*ApartmentReview, error)
+	GetApartmentReviews(apartmentID, page, pageSize int) ([]*ApartmentReview, int, error)
+	GetApartmentRating(apartmentID int) (*ApartmentRating, error)
+
+	CreateRenterReview(userID int, request *CreateRenterReviewRequest) (*RenterReview, error)
+	GetRenterReviews(renterID, page, pageSize int) ([]*RenterReview, int, error)
+	GetRenterRating(renterID int) (*RenterRating, error)
+
+	GetBookingReviewState(userID, bookingID int) (*BookingReviewState, error)
+
+	GetApartmentReviewsForModeration(status ReviewStatus, page, pageSize int) ([]*ApartmentReview, int, error)
+	GetRenterReviewsForModeration(status ReviewStatus, page, pageSize int) ([]*RenterReview, int, error)
+	ModerateApartmentReview(moderatorID, reviewID int, request *ModerateReviewRequest) (*ApartmentReview, error)
+	ModerateRenterReview(moderatorID, reviewID int, request *ModerateReviewRequest) (*RenterReview, error)
+
+	SendReviewReminders(bookingID int) error
+}
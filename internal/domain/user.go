@@ -38,20 +38,21 @@ const (
 )
 
 type User struct {
-	ID           int       `json:"id"`
-	Phone        string    `json:"phone"`
-	FirstName    string    `json:"first_name"`
-	LastName     string    `json:"last_name"`
-	Email        string    `json:"email"`
-	CityID       int       `json:"city_id"`
-	City         *City     `json:"city,omitempty"`
-	IIN          string    `json:"iin"`
-	RoleID       int       `json:"role_id"`
-	Role         UserRole  `json:"role"`
-	IsActive     bool      `json:"is_active"`
-	PasswordHash string    `json:"-"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID                int       `json:"id"`
+	Phone             string    `json:"phone"`
+	FirstName         string    `json:"first_name"`
+	LastName          string    `json:"last_name"`
+	Email             string    `json:"email"`
+	CityID            int       `json:"city_id"`
+	City              *City     `json:"city,omitempty"`
+	IIN               string    `json:"iin"`
+	RoleID            int       `json:"role_id"`
+	Role              UserRole  `json:"role"`
+	IsActive          bool      `json:"is_active"`
+	PreferredLanguage string    `json:"preferred_language"`
+	PasswordHash      string    `json:"-"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type PropertyOwner struct {
@@ -8,6 +8,7 @@ import (
 
 	"sync"
 
+	"github.com/lib/pq"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
 )
@@ -111,6 +112,8 @@ func (r *ApartmentRepository) GetByID(id int) (*domain.Apartment, error) {
 			a.is_free, a.status, a.moderator_comment, a.description, a.listing_type,
 			a.is_agreement_accepted, a.agreement_accepted_at, a.contract_id, a.apartment_type_id,
 			a.view_count, a.booking_count, a.created_at, a.updated_at,
+			to_char(a.check_in_time, 'HH24:MI'), to_char(a.check_out_time, 'HH24:MI'), a.min_nights, a.max_nights,
+			a.rating, a.reviews_count, a.rating_cleanliness, a.rating_accuracy, a.rating_location, a.rating_check_in,
 			po.id as owner_id, u.first_name as owner_first_name, u.last_name as owner_last_name,
 			u.phone as owner_phone, u.email as owner_email, u.iin as owner_iin,
 			c.name as city_name, d.name as district_name, m.name as microdistrict_name,
@@ -145,6 +148,8 @@ func (r *ApartmentRepository) GetByID(id int) (*domain.Apartment, error) {
 		&apartment.IsFree, &apartment.Status, &apartment.ModeratorComment, &apartment.Description, &apartment.ListingType,
 		&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartmentTypeID,
 		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt,
+		&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+		&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn,
 		&apartment.OwnerID, &ownerFirstName, &ownerLastName,
 		&ownerPhone, &ownerEmail, &ownerIIN,
 		&cityName, &districtName, &microdistrictName,
@@ -239,7 +244,8 @@ func (r *ApartmentRepository) GetByOwnerID(ownerID int) ([]*domain.Apartment, er
 			a.floor, a.total_floors, a.condition_id, a.price, a.daily_price, a.rental_type_hourly, 
 			a.rental_type_daily, a.is_free, a.status, a.description, a.listing_type,
 			a.is_agreement_accepted, a.agreement_accepted_at, a.contract_id, a.apartment_type_id,
-			a.created_at, a.updated_at
+			a.created_at, a.updated_at, ` + utils.ApartmentStaySelectFields + `,
+			` + utils.ApartmentRatingSelectFields + `
 		FROM apartments a
 		WHERE a.owner_id = $1
 		ORDER BY a.created_at DESC
@@ -268,6 +274,8 @@ func (r *ApartmentRepository) GetByOwnerID(ownerID int) ([]*domain.Apartment, er
 			&apartment.Status, &apartment.Description, &apartment.ListingType,
 			&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartmentTypeID,
 			&apartment.CreatedAt, &apartment.UpdatedAt,
+			&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+			&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn,
 		)
 
 		if err != nil {
@@ -312,9 +320,15 @@ func (r *ApartmentRepository) GetByOwnerID(ownerID int) ([]*domain.Apartment, er
 }
 
 func (r *ApartmentRepository) buildFilterConditions(filters map[string]interface{}) ([]string, []interface{}, int) {
+	return r.buildFilterConditionsFrom(filters, 1)
+}
+
+// buildFilterConditionsFrom строит условия фильтрации, нумеруя параметры начиная с startIndex,
+// чтобы их можно было дописать к запросу с уже занятыми параметрами (например, границами карты).
+func (r *ApartmentRepository) buildFilterConditionsFrom(filters map[string]interface{}, startIndex int) ([]string, []interface{}, int) {
 	var conditions []string
 	var params []interface{}
-	paramIndex := 1
+	paramIndex := startIndex
 
 	for key, value := range filters {
 		switch key {
@@ -378,12 +392,104 @@ func (r *ApartmentRepository) buildFilterConditions(filters map[string]interface
 			conditions = append(conditions, fmt.Sprintf("a.listing_type = $%d", paramIndex))
 			params = append(params, value)
 			paramIndex++
+		case "min_rating":
+			conditions = append(conditions, fmt.Sprintf("a.rating >= $%d", paramIndex))
+			params = append(params, value)
+			paramIndex++
+		case "search":
+			conditions = append(conditions, apartmentSearchCondition(paramIndex))
+			params = append(params, value)
+			paramIndex++
+		case "amenity_ids":
+			conditions = append(conditions, apartmentAmenitiesCondition(paramIndex))
+			params = append(params, intArrayParam(value))
+			paramIndex++
+		case "house_rule_ids":
+			conditions = append(conditions, apartmentHouseRulesCondition(paramIndex))
+			params = append(params, intArrayParam(value))
+			paramIndex++
 		}
 	}
 
 	return conditions, params, paramIndex
 }
 
+// apartmentSearchCondition полнотекстовый поиск по адресу, ЖК и описанию (русская и казахская конфигурации)
+// плюс нечеткое совпадение с улицей и названием ЖК, чтобы находить квартиры и при опечатках.
+func apartmentSearchCondition(paramIndex int) string {
+	return fmt.Sprintf(`(a.search_vector @@ (websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('kazakh', $%[1]d))
+		OR $%[1]d <%% a.street OR $%[1]d <%% a.residential_complex)`, paramIndex)
+}
+
+// apartmentAmenitiesCondition квартира должна иметь все выбранные удобства.
+func apartmentAmenitiesCondition(paramIndex int) string {
+	return fmt.Sprintf(`(SELECT COUNT(DISTINCT aa.amenity_id) FROM apartment_amenities aa
+		WHERE aa.apartment_id = a.id AND aa.amenity_id = ANY($%[1]d::int[])) = cardinality($%[1]d::int[])`, paramIndex)
+}
+
+// apartmentHouseRulesCondition квартира должна иметь все выбранные правила проживания.
+func apartmentHouseRulesCondition(paramIndex int) string {
+	return fmt.Sprintf(`(SELECT COUNT(DISTINCT ahr.house_rule_id) FROM apartment_house_rules ahr
+		WHERE ahr.apartment_id = a.id AND ahr.house_rule_id = ANY($%[1]d::int[])) = cardinality($%[1]d::int[])`, paramIndex)
+}
+
+func intArrayParam(value interface{}) interface{} {
+	ids, ok := value.([]int)
+	if !ok {
+		return value
+	}
+
+	array := make(pq.Int64Array, len(ids))
+	for i, id := range ids {
+		array[i] = int64(id)
+	}
+	return array
+}
+
+// buildOrderClause сортировка списка квартир по параметру sort_by. При поисковом запросе без явной
+// сортировки квартиры упорядочиваются по релевантности, иначе - сначала новые. Параметры сортировки
+// (поисковый запрос, точка отсчета расстояния) дописываются к params начиная с paramIndex.
+func (r *ApartmentRepository) buildOrderClause(filters map[string]interface{}, params []interface{}, paramIndex int) (string, []interface{}, int) {
+	sortBy, _ := filters["sort_by"].(string)
+	search, hasSearch := filters["search"]
+	if sortBy == "" && hasSearch {
+		sortBy = domain.ApartmentSortRelevance
+	}
+
+	switch sortBy {
+	case domain.ApartmentSortRating:
+		return "ORDER BY a.rating DESC, a.reviews_count DESC, a.created_at DESC", params, paramIndex
+	case domain.ApartmentSortReviewsCount:
+		return "ORDER BY a.reviews_count DESC, a.rating DESC, a.created_at DESC", params, paramIndex
+	case domain.ApartmentSortPriceAsc:
+		return "ORDER BY a.price ASC, a.created_at DESC", params, paramIndex
+	case domain.ApartmentSortPriceDesc:
+		return "ORDER BY a.price DESC, a.created_at DESC", params, paramIndex
+	case domain.ApartmentSortRelevance:
+		if !hasSearch {
+			break
+		}
+		orderClause := fmt.Sprintf(`ORDER BY ts_rank(a.search_vector, websearch_to_tsquery('russian', $%[1]d) || websearch_to_tsquery('kazakh', $%[1]d))
+			+ GREATEST(word_similarity($%[1]d, a.street), word_similarity($%[1]d, COALESCE(a.residential_complex, ''))) DESC,
+			a.created_at DESC`, paramIndex)
+		return orderClause, append(params, search), paramIndex + 1
+	case domain.ApartmentSortDistance:
+		latitude, hasLatitude := filters["latitude"]
+		longitude, hasLongitude := filters["longitude"]
+		if !hasLatitude || !hasLongitude {
+			break
+		}
+		// Для сортировки достаточно квадрата расстояния в локальной проекции, без тригонометрии по сфере
+		orderClause := fmt.Sprintf(`ORDER BY (
+				SELECT POWER(al.latitude - $%[1]d::float8, 2) + POWER((al.longitude - $%[2]d::float8) * COS(RADIANS($%[1]d::float8)), 2)
+				FROM apartment_locations al WHERE al.apartment_id = a.id
+			) ASC NULLS LAST, a.created_at DESC`, paramIndex, paramIndex+1)
+		return orderClause, append(params, latitude, longitude), paramIndex + 2
+	}
+
+	return "ORDER BY a.created_at DESC", params, paramIndex
+}
+
 func (r *ApartmentRepository) buildSmartAvailabilityCondition() string {
 	return `NOT EXISTS (
 		SELECT 1 
@@ -474,13 +580,15 @@ func (r *ApartmentRepository) GetAll(filters map[string]interface{}, page, pageS
 		return nil, 0, err
 	}
 
+	orderClause, params, paramIndex := r.buildOrderClause(filters, params, paramIndex)
+
 	offset := (page - 1) * pageSize
 	dataQuery := fmt.Sprintf(`
 		SELECT `+utils.ApartmentWithConditionAndOwnerSelectFields+`
 		%s %s
-		ORDER BY a.created_at DESC
+		%s
 		LIMIT $%d OFFSET $%d
-	`, baseQuery, whereClause, paramIndex, paramIndex+1)
+	`, baseQuery, whereClause, orderClause, paramIndex, paramIndex+1)
 
 	params = append(params, pageSize, offset)
 
@@ -1441,6 +1549,30 @@ func (r *ApartmentRepository) GetAllWithUserContext(filters map[string]interface
 		argIndex++
 	}
 
+	if minRating, ok := filters["min_rating"]; ok {
+		conditions = append(conditions, fmt.Sprintf("a.rating >= $%d", argIndex))
+		args = append(args, minRating)
+		argIndex++
+	}
+
+	if search, ok := filters["search"]; ok {
+		conditions = append(conditions, apartmentSearchCondition(argIndex))
+		args = append(args, search)
+		argIndex++
+	}
+
+	if amenityIDs, ok := filters["amenity_ids"]; ok {
+		conditions = append(conditions, apartmentAmenitiesCondition(argIndex))
+		args = append(args, intArrayParam(amenityIDs))
+		argIndex++
+	}
+
+	if houseRuleIDs, ok := filters["house_rule_ids"]; ok {
+		conditions = append(conditions, apartmentHouseRulesCondition(argIndex))
+		args = append(args, intArrayParam(houseRuleIDs))
+		argIndex++
+	}
+
 	whereClause = "WHERE " + strings.Join(conditions, " AND ")
 
 	offset := (page - 1) * pageSize
@@ -1469,6 +1601,8 @@ func (r *ApartmentRepository) GetAllWithUserContext(filters map[string]interface
 		return nil, 0, utils.HandleSQLError(err, "apartment", "count")
 	}
 
+	orderClause, args, argIndex := r.buildOrderClause(filters, args, argIndex)
+
 	var query string
 	if userID != nil {
 		query = `
@@ -1480,7 +1614,7 @@ func (r *ApartmentRepository) GetAllWithUserContext(filters map[string]interface
 			LEFT JOIN apartment_types at ON a.apartment_type_id = at.id
 			LEFT JOIN favorites f ON a.id = f.apartment_id AND f.user_id = $` + fmt.Sprintf("%d", argIndex) + `
 			` + whereClause + `
-			ORDER BY a.created_at DESC
+			` + orderClause + `
 			LIMIT $` + fmt.Sprintf("%d", argIndex+1) + ` OFFSET $` + fmt.Sprintf("%d", argIndex+2)
 		args = append(args, *userID, pageSize, offset)
 	} else {
@@ -1492,7 +1626,7 @@ func (r *ApartmentRepository) GetAllWithUserContext(filters map[string]interface
 			LEFT JOIN users u ON po.user_id = u.id
 			LEFT JOIN apartment_types at ON a.apartment_type_id = at.id
 			` + whereClause + `
-			ORDER BY a.created_at DESC
+			` + orderClause + `
 			LIMIT $` + fmt.Sprintf("%d", argIndex) + ` OFFSET $` + fmt.Sprintf("%d", argIndex+1)
 		args = append(args, pageSize, offset)
 	}
@@ -1549,7 +1683,7 @@ func (r *ApartmentRepository) GetByCoordinates(minLat, maxLat, minLng, maxLng fl
 }
 
 func (r *ApartmentRepository) GetByCoordinatesWithFilters(minLat, maxLat, minLng, maxLng float64, filters map[string]interface{}) ([]*domain.ApartmentCoordinates, error) {
-	filterConditions, filterParams, _ := r.buildFilterConditions(filters)
+	filterConditions, filterParams, _ := r.buildFilterConditionsFrom(filters, 5)
 
 	query := `
 		SELECT 
@@ -1564,18 +1698,7 @@ func (r *ApartmentRepository) GetByCoordinatesWithFilters(minLat, maxLat, minLng
 	params := []interface{}{minLat, maxLat, minLng, maxLng}
 
 	if len(filterConditions) > 0 {
-		adjustedConditions := make([]string, len(filterConditions))
-		for i, condition := range filterConditions {
-			adjustedCondition := condition
-			for j := len(filterParams); j >= 1; j-- {
-				oldParam := fmt.Sprintf("$%d", j)
-				newParam := fmt.Sprintf("$%d", j+4)
-				adjustedCondition = strings.Replace(adjustedCondition, oldParam, newParam, 1)
-			}
-			adjustedConditions[i] = adjustedCondition
-		}
-
-		query += " AND " + strings.Join(adjustedConditions, " AND ")
+		query += " AND " + strings.Join(filterConditions, " AND ")
 		params = append(params, filterParams...)
 	}
 
@@ -1619,32 +1742,19 @@ func (r *ApartmentRepository) GetFullApartmentsByCoordinatesWithFilters(minLat,
 	}
 	coordinateParams := []interface{}{minLat, maxLat, minLng, maxLng}
 
-	filterConditions, filterParams, _ := r.buildFilterConditions(filters)
+	filterConditions, filterParams, paramIndex := r.buildFilterConditionsFrom(filters, 5)
 
 	allConditions := append(coordinateConditions, filterConditions...)
 	allParams := append(coordinateParams, filterParams...)
 
-	if len(filterConditions) > 0 {
-		adjustedFilterConditions := make([]string, len(filterConditions))
-		for i, condition := range filterConditions {
-			adjustedCondition := condition
-			for j := len(filterParams); j >= 1; j-- {
-				oldParam := fmt.Sprintf("$%d", j)
-				newParam := fmt.Sprintf("$%d", j+4)
-				adjustedCondition = strings.Replace(adjustedCondition, oldParam, newParam, 1)
-			}
-			adjustedFilterConditions[i] = adjustedCondition
-		}
-		allConditions = append(coordinateConditions, adjustedFilterConditions...)
-	}
-
 	whereClause := r.buildWhereClause(allConditions)
+	orderClause, allParams, _ := r.buildOrderClause(filters, allParams, paramIndex)
 
 	dataQuery := fmt.Sprintf(`
 		SELECT `+utils.ApartmentWithConditionAndOwnerSelectFields+`
 		%s %s
-		ORDER BY a.created_at DESC
-	`, baseQuery, whereClause)
+		%s
+	`, baseQuery, whereClause, orderClause)
 
 	rows, err := r.db.Query(dataQuery, allParams...)
 	if err != nil {
@@ -2068,6 +2178,20 @@ func (r *ApartmentRepository) AdminUpdateBookingCount(apartmentID int, bookingCo
 	return nil
 }
 
+func (r *ApartmentRepository) UpdateStaySettings(apartmentID int, settings *domain.UpdateApartmentStaySettingsRequest) error {
+	query := `
+		UPDATE apartments
+		SET check_in_time = $2::time, check_out_time = $3::time, min_nights = $4, max_nights = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`
+
+	_, err := r.db.Exec(query, apartmentID, settings.CheckInTime, settings.CheckOutTime, settings.MinNights, settings.MaxNights)
+	if err != nil {
+		return utils.HandleSQLErrorWithID(err, "apartment stay settings", "update", apartmentID)
+	}
+
+	return nil
+}
+
 func (r *ApartmentRepository) AdminResetCounters(apartmentID int) error {
 	query := `UPDATE apartments SET view_count = 0, booking_count = 0 WHERE id = $1`
 
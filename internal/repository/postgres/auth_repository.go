@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type authRepository struct {
+	db *sql.DB
+}
+
+func NewAuthRepository(db *sql.DB) domain.AuthRepository {
+	return &authRepository{
+		db: db,
+	}
+}
+
+const sessionColumns = `id, user_id, refresh_token_hash, user_agent, ip_address,
+	created_at, last_used_at, expires_at, revoked_at, revoke_reason`
+
+func (r *authRepository) CreateSession(session *domain.Session) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id, refresh_token_hash, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at, last_used_at`
+
+	err := r.db.QueryRow(
+		query,
+		session.ID, session.UserID, session.RefreshTokenHash,
+		session.UserAgent, session.IPAddress, session.ExpiresAt,
+	).Scan(&session.CreatedAt, &session.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *authRepository) GetSessionByID(sessionID string) (*domain.Session, error) {
+	query := `SELECT ` + sessionColumns + ` FROM user_sessions WHERE id = $1`
+
+	session, err := scanSession(r.db.QueryRow(query, sessionID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+func (r *authRepository) RotateSession(sessionID, previousHash, newHash string, client domain.SessionClient, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE user_sessions
+		SET refresh_token_hash = $3,
+			user_agent = COALESCE(NULLIF($4, ''), user_agent),
+			ip_address = COALESCE(NULLIF($5, ''), ip_address),
+			expires_at = $6,
+			last_used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.Exec(query, sessionID, previousHash, newHash, client.UserAgent, client.IPAddress, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *authRepository) GetActiveSessionsByUserID(userID int) ([]*domain.Session, error) {
+	query := `
+		SELECT ` + sessionColumns + `
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY last_used_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *authRepository) RevokeSession(sessionID, reason string) (bool, error) {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = CURRENT_TIMESTAMP, revoke_reason = $2
+		WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.Exec(query, sessionID, reason)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *authRepository) RevokeAllUserSessions(userID int, reason string) ([]string, error) {
+	query := `
+		UPDATE user_sessions
+		SET revoked_at = CURRENT_TIMESTAMP, revoke_reason = $2
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING id`
+
+	rows, err := r.db.Query(query, userID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessionIDs []string
+	for rows.Next() {
+		var sessionID string
+		if err := rows.Scan(&sessionID); err != nil {
+			return nil, fmt.Errorf("failed to scan session id: %w", err)
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate revoked sessions: %w", err)
+	}
+
+	return sessionIDs, nil
+}
+
+// sessionScanner общий интерфейс *sql.Row и *sql.Rows.
+type sessionScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSession(row sessionScanner) (*domain.Session, error) {
+	session := &domain.Session{}
+	var revokedAt sql.NullTime
+	var revokeReason sql.NullString
+
+	err := row.Scan(
+		&session.ID, &session.UserID, &session.RefreshTokenHash, &session.UserAgent, &session.IPAddress,
+		&session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &revokedAt, &revokeReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	session.RevokeReason = revokeReason.String
+
+	return session, nil
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type availabilityBlockRepository struct {
+	db *sql.DB
+}
+
+func NewAvailabilityBlockRepository(db *sql.DB) domain.AvailabilityBlockRepository {
+	return &availabilityBlockRepository{db: db}
+}
+
+const availabilityBlockSelectFields = `
+	id, apartment_id, start_date, end_date, reason, comment, created_by, created_at, updated_at`
+
+func (r *availabilityBlockRepository) Create(block *domain.AvailabilityBlock) error {
+	query := `
+		INSERT INTO availability_blocks (apartment_id, start_date, end_date, reason, comment, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query, block.ApartmentID, block.StartDate, block.EndDate, block.Reason, block.Comment, block.CreatedBy).
+		Scan(&block.ID, &block.CreatedAt, &block.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания блокировки: %w", err)
+	}
+
+	return nil
+}
+
+func (r *availabilityBlockRepository) GetByID(id int) (*domain.AvailabilityBlock, error) {
+	query := fmt.Sprintf(`SELECT %s FROM availability_blocks WHERE id = $1`, availabilityBlockSelectFields)
+
+	block, err := scanAvailabilityBlock(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// GetByApartmentID возвращает блокировки квартиры, пересекающиеся с периодом [from, to).
+func (r *availabilityBlockRepository) GetByApartmentID(apartmentID int, from, to time.Time) ([]*domain.AvailabilityBlock, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM availability_blocks
+		WHERE apartment_id = $1 AND start_date < $3 AND end_date > $2
+		ORDER BY start_date ASC`, availabilityBlockSelectFields)
+
+	rows, err := r.db.Query(query, apartmentID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения блокировок: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []*domain.AvailabilityBlock{}
+	for rows.Next() {
+		block, err := scanAvailabilityBlock(rows)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return blocks, nil
+}
+
+func (r *availabilityBlockRepository) Update(block *domain.AvailabilityBlock) error {
+	query := `
+		UPDATE availability_blocks
+		SET start_date = $2, end_date = $3, reason = $4, comment = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, block.ID, block.StartDate, block.EndDate, block.Reason, block.Comment).Scan(&block.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("блокировка с ID %d не найдена", block.ID)
+		}
+		return fmt.Errorf("ошибка обновления блокировки: %w", err)
+	}
+
+	return nil
+}
+
+func (r *availabilityBlockRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM availability_blocks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления блокировки: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("блокировка с ID %d не найдена", id)
+	}
+
+	return nil
+}
+
+func scanAvailabilityBlock(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.AvailabilityBlock, error) {
+	block := &domain.AvailabilityBlock{}
+	var createdBy sql.NullInt64
+
+	err := scanner.Scan(
+		&block.ID,
+		&block.ApartmentID,
+		&block.StartDate,
+		&block.EndDate,
+		&block.Reason,
+		&block.Comment,
+		&createdBy,
+		&block.CreatedAt,
+		&block.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сканирования блокировки: %w", err)
+	}
+
+	if createdBy.Valid {
+		id := int(createdBy.Int64)
+		block.CreatedBy = &id
+	}
+
+	return block, nil
+}
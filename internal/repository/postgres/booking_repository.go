@@ -2,12 +2,15 @@ package postgres
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 )
 
 type bookingRepository struct {
@@ -19,16 +22,21 @@ func NewBookingRepository(db *sql.DB) domain.BookingRepository {
 }
 
 func (r *bookingRepository) Create(booking *domain.Booking) error {
+	priceBreakdown, err := utils.PriceBreakdownToJSON(booking.PriceBreakdown)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO bookings (
 			renter_id, apartment_id, start_date, end_date, duration, cleaning_duration, status,
 			total_price, service_fee, final_price, is_contract_accepted, 
-			door_status, can_extend
+			door_status, can_extend, price_breakdown, nights
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
 		) RETURNING id, created_at, updated_at`
 
-	err := r.db.QueryRow(
+	err = r.db.QueryRow(
 		query,
 		booking.RenterID,
 		booking.ApartmentID,
@@ -43,9 +51,14 @@ func (r *bookingRepository) Create(booking *domain.Booking) error {
 		booking.IsContractAccepted,
 		booking.DoorStatus,
 		booking.CanExtend,
+		priceBreakdown,
+		booking.Nights,
 	).Scan(&booking.ID, &booking.CreatedAt, &booking.UpdatedAt)
 
 	if err != nil {
+		if isBookingOverlapError(err) {
+			return apperrors.BookingApartmentUnavailableOverlap.Wrap(err, nil)
+		}
 		return utils.HandleSQLError(err, "booking", "create")
 	}
 
@@ -230,16 +243,22 @@ func (r *bookingRepository) GetByOwnerID(ownerID int, status []domain.BookingSta
 }
 
 func (r *bookingRepository) Update(booking *domain.Booking) error {
+	priceBreakdown, err := utils.PriceBreakdownToJSON(booking.PriceBreakdown)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE bookings SET 
 			end_date = $2, duration = $3, status = $4, cleaning_duration = $5, total_price = $6, service_fee = $7, final_price = $8,
 			is_contract_accepted = $9, cancellation_reason = $10, 
 			owner_comment = $11, door_status = $12, last_door_action = $13, 
 			can_extend = $14, extension_requested = $15, extension_end_date = $16, 
-			extension_duration = $17, extension_price = $18, payment_id = $19, updated_at = CURRENT_TIMESTAMP
+			extension_duration = $17, extension_price = $18, payment_id = $19, price_breakdown = $20,
+			updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1`
 
-	_, err := r.db.Exec(
+	_, err = r.db.Exec(
 		query,
 		booking.ID,
 		booking.EndDate,
@@ -260,6 +279,7 @@ func (r *bookingRepository) Update(booking *domain.Booking) error {
 		booking.ExtensionDuration,
 		booking.ExtensionPrice,
 		utils.Int64ToSQLNullInt64(booking.PaymentID),
+		priceBreakdown,
 	)
 
 	if err != nil {
@@ -319,7 +339,70 @@ func (r *bookingRepository) CheckApartmentAvailability(apartmentID int, startDat
 		return false, utils.HandleSQLError(err, "apartment availability", "check")
 	}
 
-	return count == 0, nil
+	if count > 0 {
+		return false, nil
+	}
+
+	// Периоды, занятые на других площадках (импорт iCal)
+	externalQuery := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM apartment_external_events e
+			JOIN apartment_external_calendars c ON c.id = e.calendar_id AND c.is_active = true
+			WHERE e.apartment_id = $1
+			AND e.start_date < $3 + INTERVAL '60 minutes'
+			AND e.end_date > $2
+		)`
+
+	var blockedExternally bool
+	err = r.db.QueryRow(externalQuery, apartmentID, startDate, endDate).Scan(&blockedExternally)
+	if err != nil {
+		return false, utils.HandleSQLError(err, "apartment external calendar availability", "check")
+	}
+
+	if blockedExternally {
+		return false, nil
+	}
+
+	// Периоды, закрытые владельцем (ремонт, уборка, личное использование)
+	blockQuery := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM availability_blocks bl
+			WHERE bl.apartment_id = $1
+			AND bl.start_date < $3 + INTERVAL '60 minutes'
+			AND bl.end_date > $2
+		)`
+
+	var blockedByOwner bool
+	err = r.db.QueryRow(blockQuery, apartmentID, startDate, endDate).Scan(&blockedByOwner)
+	if err != nil {
+		return false, utils.HandleSQLError(err, "apartment availability blocks", "check")
+	}
+
+	if blockedByOwner {
+		return false, nil
+	}
+
+	// Незакрытые заявки на уборку после выезда: квартира занята на время уборки из бронирования,
+	// а если уборка задерживается - до ее завершения
+	cleaningQuery := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM cleaning_tasks t
+			WHERE t.apartment_id = $1
+			AND t.status IN ('pending', 'offered', 'accepted', 'in_progress', 'escalated')
+			AND t.checkout_at < $3
+			AND GREATEST(t.checkout_at + INTERVAL '1 minute' * t.cleaning_duration, NOW()) > $2
+		)`
+
+	var blockedByCleaning bool
+	err = r.db.QueryRow(cleaningQuery, apartmentID, startDate, endDate).Scan(&blockedByCleaning)
+	if err != nil {
+		return false, utils.HandleSQLError(err, "apartment cleaning availability", "check")
+	}
+
+	return !blockedByCleaning, nil
 }
 
 func (r *bookingRepository) GetNextBookingAfterDate(apartmentID int, afterDate time.Time, excludeBookingID *int) (*domain.Booking, error) {
@@ -358,17 +441,23 @@ func (r *bookingRepository) GetNextBookingAfterDate(apartmentID int, afterDate t
 }
 
 func (r *bookingRepository) CreateExtension(extension *domain.BookingExtension) error {
+	priceBreakdown, err := utils.PriceBreakdownToJSON(extension.PriceBreakdown)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO booking_extensions (booking_id, duration, price, status, requested_at)
-		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+		INSERT INTO booking_extensions (booking_id, duration, price, status, price_breakdown, requested_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
 		RETURNING id, created_at, updated_at`
 
-	err := r.db.QueryRow(
+	err = r.db.QueryRow(
 		query,
 		extension.BookingID,
 		extension.Duration,
 		extension.Price,
 		extension.Status,
+		priceBreakdown,
 	).Scan(&extension.ID, &extension.CreatedAt, &extension.UpdatedAt)
 
 	if err != nil {
@@ -718,7 +807,47 @@ func (r *bookingRepository) GetBookingsForDateRange(apartmentID int, startDate,
 	return bookings, nil
 }
 
-func (r *bookingRepository) CleanupExpiredBookings(batchSize int) (int, error) {
+// awaitingPaymentExpiredCondition неоплаченные бронирования, которые пора удалить: через 30 минут
+// после создания или через 2 часа после окончания брони.
+const awaitingPaymentExpiredCondition = `status = 'awaiting_payment' AND (
+	created_at < NOW() - INTERVAL '30 minutes'
+	OR end_date < NOW() - INTERVAL '2 hours'
+)`
+
+func (r *bookingRepository) GetExpiredAwaitingPaymentBookingIDs(batchSize int) ([]int, error) {
+	query := `
+		SELECT id
+		FROM bookings
+		WHERE ` + awaitingPaymentExpiredCondition + `
+		ORDER BY id
+		LIMIT $1`
+
+	rows, err := r.db.Query(query, batchSize)
+	if err != nil {
+		return nil, utils.HandleSQLError(err, "expired awaiting payment bookings", "get")
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, utils.HandleSQLError(err, "expired awaiting payment bookings", "scan")
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, utils.HandleSQLError(err, "expired awaiting payment bookings", "iterate")
+	}
+
+	return ids, nil
+}
+
+// CleanupExpiredBookings удаляет просроченные бронирования. Неоплаченные удаляются только из
+// reconciledIDs - их оплату уже сверили с FreedomPay, - и только если по ним нет незавершенного
+// платежа: платежи удаляются каскадом вместе с бронированием.
+func (r *bookingRepository) CleanupExpiredBookings(batchSize int, reconciledIDs []int) (int, error) {
 	query := `
 		WITH expired_bookings AS (
 			SELECT id 
@@ -727,14 +856,11 @@ func (r *bookingRepository) CleanupExpiredBookings(batchSize int) (int, error) {
 				-- Обычные статусы - через день после start_date
 				(status IN ('created', 'pending') AND start_date < NOW() - INTERVAL '1 day')
 				OR
-				-- awaiting_payment - более агрессивная очистка
-				(status = 'awaiting_payment' AND (
-					-- Через 30 минут после создания
-					created_at < NOW() - INTERVAL '30 minutes'
-					OR
-					-- Через 2 часа после окончания брони
-					end_date < NOW() - INTERVAL '2 hours'
-				))
+				(` + awaitingPaymentExpiredCondition + ` AND id = ANY($2))
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM payments p
+				WHERE p.booking_id = bookings.id AND p.status IN ('pending', 'processing')
 			)
 			LIMIT $1
 		)
@@ -742,7 +868,7 @@ func (r *bookingRepository) CleanupExpiredBookings(batchSize int) (int, error) {
 		WHERE id IN (SELECT id FROM expired_bookings)
 	`
 
-	result, err := r.db.Exec(query, batchSize)
+	result, err := r.db.Exec(query, batchSize, pq.Array(reconciledIDs))
 	if err != nil {
 		return 0, utils.HandleSQLError(err, "expired bookings", "cleanup")
 	}
@@ -780,3 +906,13 @@ func (r *bookingRepository) CleanupExpiredExtensions(batchSize int) (int, error)
 
 	return int(rowsAffected), nil
 }
+
+// isBookingOverlapError распознает исключение триггера проверки пересечения бронирований
+// (migrations/048_add_awaiting_payment_status.up.sql): RAISE EXCEPTION без ERRCODE дает SQLSTATE P0001.
+func isBookingOverlapError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == "P0001" && strings.HasPrefix(pqErr.Message, "Apartment is not available for the selected period")
+}
@@ -0,0 +1,298 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type calendarRepository struct {
+	db *sql.DB
+}
+
+func NewCalendarRepository(db *sql.DB) domain.CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+const externalCalendarSelectFields = `
+	id, apartment_id, name, url, is_active, last_synced_at, last_sync_status, last_sync_error,
+	events_count, created_at, updated_at`
+
+func (r *calendarRepository) GetExportToken(apartmentID int) (*string, error) {
+	var token sql.NullString
+	err := r.db.QueryRow(`SELECT ical_export_token FROM apartments WHERE id = $1`, apartmentID).Scan(&token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("квартира с ID %d не найдена", apartmentID)
+		}
+		return nil, fmt.Errorf("ошибка получения токена календаря: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, nil
+	}
+	return &token.String, nil
+}
+
+func (r *calendarRepository) SetExportToken(apartmentID int, token string) error {
+	result, err := r.db.Exec(`UPDATE apartments SET ical_export_token = $2 WHERE id = $1`, apartmentID, token)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения токена календаря: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("квартира с ID %d не найдена", apartmentID)
+	}
+
+	return nil
+}
+
+func (r *calendarRepository) GetApartmentIDByExportToken(token string) (int, error) {
+	var apartmentID int
+	err := r.db.QueryRow(`SELECT id FROM apartments WHERE ical_export_token = $1`, token).Scan(&apartmentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("ошибка поиска календаря по токену: %w", err)
+	}
+
+	return apartmentID, nil
+}
+
+func (r *calendarRepository) CreateExternalCalendar(calendar *domain.ExternalCalendar) error {
+	query := `
+		INSERT INTO apartment_external_calendars (apartment_id, name, url, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, last_sync_status, created_at, updated_at`
+
+	err := r.db.QueryRow(query, calendar.ApartmentID, calendar.Name, calendar.URL, calendar.IsActive).
+		Scan(&calendar.ID, &calendar.LastSyncStatus, &calendar.CreatedAt, &calendar.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка добавления внешнего календаря: %w", err)
+	}
+
+	return nil
+}
+
+func (r *calendarRepository) GetExternalCalendarByID(id int) (*domain.ExternalCalendar, error) {
+	query := fmt.Sprintf(`SELECT %s FROM apartment_external_calendars WHERE id = $1`, externalCalendarSelectFields)
+
+	calendar, err := scanExternalCalendar(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return calendar, nil
+}
+
+func (r *calendarRepository) GetExternalCalendarsByApartmentID(apartmentID int) ([]*domain.ExternalCalendar, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM apartment_external_calendars
+		WHERE apartment_id = $1
+		ORDER BY id ASC`, externalCalendarSelectFields)
+
+	return r.queryExternalCalendars(query, apartmentID)
+}
+
+func (r *calendarRepository) GetActiveExternalCalendars() ([]*domain.ExternalCalendar, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM apartment_external_calendars
+		WHERE is_active = true
+		ORDER BY last_synced_at ASC NULLS FIRST, id ASC`, externalCalendarSelectFields)
+
+	return r.queryExternalCalendars(query)
+}
+
+func (r *calendarRepository) queryExternalCalendars(query string, args ...interface{}) ([]*domain.ExternalCalendar, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer rows.Close()
+
+	calendars := []*domain.ExternalCalendar{}
+	for rows.Next() {
+		calendar, err := scanExternalCalendar(rows)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, calendar)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return calendars, nil
+}
+
+func (r *calendarRepository) UpdateExternalCalendar(calendar *domain.ExternalCalendar) error {
+	query := `
+		UPDATE apartment_external_calendars
+		SET name = $2, is_active = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, calendar.ID, calendar.Name, calendar.IsActive).Scan(&calendar.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("внешний календарь с ID %d не найден", calendar.ID)
+		}
+		return fmt.Errorf("ошибка обновления внешнего календаря: %w", err)
+	}
+
+	return nil
+}
+
+func (r *calendarRepository) UpdateSyncStatus(calendar *domain.ExternalCalendar) error {
+	query := `
+		UPDATE apartment_external_calendars
+		SET last_synced_at = $2, last_sync_status = $3, last_sync_error = $4, events_count = $5, updated_at = NOW()
+		WHERE id = $1`
+
+	_, err := r.db.Exec(query, calendar.ID, calendar.LastSyncedAt, calendar.LastSyncStatus, calendar.LastSyncError, calendar.EventsCount)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления статуса синхронизации календаря: %w", err)
+	}
+
+	return nil
+}
+
+func (r *calendarRepository) DeleteExternalCalendar(id int) error {
+	result, err := r.db.Exec(`DELETE FROM apartment_external_calendars WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления внешнего календаря: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("внешний календарь с ID %d не найден", id)
+	}
+
+	return nil
+}
+
+// ReplaceExternalEvents атомарно заменяет импортированные события календаря новым снимком.
+func (r *calendarRepository) ReplaceExternalEvents(calendarID int, events []*domain.ExternalCalendarEvent) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("ошибка начала транзакции: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM apartment_external_events WHERE calendar_id = $1`, calendarID); err != nil {
+		return fmt.Errorf("ошибка удаления импортированных событий: %w", err)
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO apartment_external_events (calendar_id, apartment_id, uid, summary, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (calendar_id, uid) DO UPDATE
+		SET summary = EXCLUDED.summary, start_date = EXCLUDED.start_date, end_date = EXCLUDED.end_date`)
+	if err != nil {
+		return fmt.Errorf("ошибка подготовки запроса: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, event := range events {
+		if _, err := stmt.Exec(calendarID, event.ApartmentID, event.UID, event.Summary, event.StartDate, event.EndDate); err != nil {
+			return fmt.Errorf("ошибка сохранения события %s: %w", event.UID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("ошибка фиксации транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func (r *calendarRepository) GetExternalEventsByApartmentID(apartmentID int, from time.Time) ([]*domain.ExternalCalendarEvent, error) {
+	query := `
+		SELECT e.id, e.calendar_id, e.apartment_id, e.uid, e.summary, e.start_date, e.end_date, e.created_at
+		FROM apartment_external_events e
+		JOIN apartment_external_calendars c ON c.id = e.calendar_id AND c.is_active = true
+		WHERE e.apartment_id = $1 AND e.end_date > $2
+		ORDER BY e.start_date ASC`
+
+	rows, err := r.db.Query(query, apartmentID, from)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения импортированных событий: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.ExternalCalendarEvent{}
+	for rows.Next() {
+		event := &domain.ExternalCalendarEvent{}
+		err := rows.Scan(
+			&event.ID,
+			&event.CalendarID,
+			&event.ApartmentID,
+			&event.UID,
+			&event.Summary,
+			&event.StartDate,
+			&event.EndDate,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка сканирования события календаря: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return events, nil
+}
+
+func scanExternalCalendar(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.ExternalCalendar, error) {
+	calendar := &domain.ExternalCalendar{}
+	var lastSyncedAt sql.NullTime
+	var lastSyncError sql.NullString
+
+	err := scanner.Scan(
+		&calendar.ID,
+		&calendar.ApartmentID,
+		&calendar.Name,
+		&calendar.URL,
+		&calendar.IsActive,
+		&lastSyncedAt,
+		&calendar.LastSyncStatus,
+		&lastSyncError,
+		&calendar.EventsCount,
+		&calendar.CreatedAt,
+		&calendar.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сканирования внешнего календаря: %w", err)
+	}
+
+	if lastSyncedAt.Valid {
+		calendar.LastSyncedAt = &lastSyncedAt.Time
+	}
+	if lastSyncError.Valid {
+		calendar.LastSyncError = &lastSyncError.String
+	}
+
+	return calendar, nil
+}
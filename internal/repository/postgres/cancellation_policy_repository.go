@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type cancellationPolicyRepository struct {
+	db *sql.DB
+}
+
+func NewCancellationPolicyRepository(db *sql.DB) domain.CancellationPolicyRepository {
+	return &cancellationPolicyRepository{db: db}
+}
+
+func (r *cancellationPolicyRepository) GetAll() ([]*domain.CancellationPolicy, error) {
+	query := `
+		SELECT id, code, name, description, tiers, service_fee_refundable, is_active, created_at, updated_at
+		FROM cancellation_policies
+		WHERE is_active = true
+		ORDER BY id ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*domain.CancellationPolicy
+	for rows.Next() {
+		policy, err := scanCancellationPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (r *cancellationPolicyRepository) GetByCode(code domain.CancellationPolicyCode) (*domain.CancellationPolicy, error) {
+	query := `
+		SELECT id, code, name, description, tiers, service_fee_refundable, is_active, created_at, updated_at
+		FROM cancellation_policies
+		WHERE code = $1
+	`
+
+	policy, err := scanCancellationPolicy(r.db.QueryRow(query, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (r *cancellationPolicyRepository) GetApartmentPolicyCode(apartmentID int) (*domain.CancellationPolicyCode, error) {
+	query := `SELECT cancellation_policy_code FROM apartments WHERE id = $1`
+
+	var code sql.NullString
+	if err := r.db.QueryRow(query, apartmentID).Scan(&code); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("квартира с ID %d не найдена", apartmentID)
+		}
+		return nil, fmt.Errorf("ошибка получения политики отмены квартиры: %w", err)
+	}
+
+	if !code.Valid {
+		return nil, nil
+	}
+
+	policyCode := domain.CancellationPolicyCode(code.String)
+	return &policyCode, nil
+}
+
+func (r *cancellationPolicyRepository) SetApartmentPolicyCode(apartmentID int, code *domain.CancellationPolicyCode) error {
+	query := `UPDATE apartments SET cancellation_policy_code = $2, updated_at = NOW() WHERE id = $1`
+
+	result, err := r.db.Exec(query, apartmentID, code)
+	if err != nil {
+		return fmt.Errorf("ошибка обновления политики отмены квартиры: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("квартира с ID %d не найдена", apartmentID)
+	}
+
+	return nil
+}
+
+func scanCancellationPolicy(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.CancellationPolicy, error) {
+	policy := &domain.CancellationPolicy{}
+	var tiersJSON []byte
+
+	err := scanner.Scan(
+		&policy.ID,
+		&policy.Code,
+		&policy.Name,
+		&policy.Description,
+		&tiersJSON,
+		&policy.ServiceFeeRefundable,
+		&policy.IsActive,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сканирования политики отмены: %w", err)
+	}
+
+	if len(tiersJSON) > 0 {
+		if err := json.Unmarshal(tiersJSON, &policy.Tiers); err != nil {
+			return nil, fmt.Errorf("ошибка разбора ступеней политики отмены: %w", err)
+		}
+	}
+
+	return policy, nil
+}
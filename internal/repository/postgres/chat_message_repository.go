@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
 )
@@ -20,8 +21,9 @@ func NewChatMessageRepository(db *sql.DB) domain.ChatMessageRepository {
 
 func (r *chatMessageRepository) Create(message *domain.ChatMessage) error {
 	query := `
-		INSERT INTO chat_messages (chat_room_id, sender_id, type, content, file_url, file_name, file_size, status, reply_to_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		INSERT INTO chat_messages (chat_room_id, sender_id, type, content, file_url, file_name, file_size,
+			mime_type, thumbnail_url, image_width, image_height, status, reply_to_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
 		RETURNING id, created_at, updated_at`
 
 	err := r.db.QueryRow(
@@ -33,6 +35,10 @@ func (r *chatMessageRepository) Create(message *domain.ChatMessage) error {
 		message.FileURL,
 		message.FileName,
 		message.FileSize,
+		message.MimeType,
+		message.ThumbnailURL,
+		message.ImageWidth,
+		message.ImageHeight,
 		message.Status,
 		message.ReplyToID,
 	).Scan(&message.ID, &message.CreatedAt, &message.UpdatedAt)
@@ -47,6 +53,7 @@ func (r *chatMessageRepository) Create(message *domain.ChatMessage) error {
 func (r *chatMessageRepository) GetByID(id int) (*domain.ChatMessage, error) {
 	query := `
 		SELECT cm.id, cm.chat_room_id, cm.sender_id, cm.type, cm.content, cm.file_url, cm.file_name, cm.file_size,
+			   cm.mime_type, cm.thumbnail_url, cm.image_width, cm.image_height,
 			   cm.status, cm.read_at, cm.reply_to_id, cm.edited_at, cm.created_at, cm.updated_at,
 			   u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, u.role_id, u.created_at, u.updated_at,
 			   COALESCE(r.name, '') as role_name,
@@ -67,7 +74,8 @@ func (r *chatMessageRepository) GetByID(id int) (*domain.ChatMessage, error) {
 
 	err := r.db.QueryRow(query, id).Scan(
 		&message.ID, &message.ChatRoomID, &message.SenderID, &message.Type, &message.Content,
-		&message.FileURL, &message.FileName, &message.FileSize, &message.Status, &message.ReadAt,
+		&message.FileURL, &message.FileName, &message.FileSize,
+		&message.MimeType, &message.ThumbnailURL, &message.ImageWidth, &message.ImageHeight, &message.Status, &message.ReadAt,
 		&message.ReplyToID, &message.EditedAt, &message.CreatedAt, &message.UpdatedAt,
 		&sender.ID, &sender.Phone, &sender.FirstName, &sender.LastName, &sender.Email,
 		&sender.CityID, &sender.IIN, &sender.RoleID, &sender.CreatedAt, &sender.UpdatedAt,
@@ -110,6 +118,7 @@ func (r *chatMessageRepository) GetByRoomID(roomID int, page, pageSize int) ([]*
 
 	query := `
 		SELECT cm.id, cm.chat_room_id, cm.sender_id, cm.type, cm.content, cm.file_url, cm.file_name, cm.file_size,
+			   cm.mime_type, cm.thumbnail_url, cm.image_width, cm.image_height,
 			   cm.status, cm.read_at, cm.reply_to_id, cm.edited_at, cm.created_at, cm.updated_at,
 			   u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, u.role_id, u.created_at, u.updated_at,
 			   COALESCE(r.name, '') as role_name
@@ -135,7 +144,8 @@ func (r *chatMessageRepository) GetByRoomID(roomID int, page, pageSize int) ([]*
 
 		err := rows.Scan(
 			&message.ID, &message.ChatRoomID, &message.SenderID, &message.Type, &message.Content,
-			&message.FileURL, &message.FileName, &message.FileSize, &message.Status, &message.ReadAt,
+			&message.FileURL, &message.FileName, &message.FileSize,
+			&message.MimeType, &message.ThumbnailURL, &message.ImageWidth, &message.ImageHeight, &message.Status, &message.ReadAt,
 			&message.ReplyToID, &message.EditedAt, &message.CreatedAt, &message.UpdatedAt,
 			&sender.ID, &sender.Phone, &sender.FirstName, &sender.LastName, &sender.Email,
 			&sender.CityID, &sender.IIN, &sender.RoleID, &sender.CreatedAt, &sender.UpdatedAt,
@@ -157,6 +167,122 @@ func (r *chatMessageRepository) GetByRoomID(roomID int, page, pageSize int) ([]*
 	return messages, total, nil
 }
 
+func (r *chatMessageRepository) GetByRoomIDCursor(roomID int, cursor domain.ChatMessageCursor) ([]*domain.ChatMessage, bool, error) {
+	conditions := []string{"cm.chat_room_id = $1"}
+	args := []interface{}{roomID}
+
+	if cursor.AfterID != nil {
+		args = append(args, *cursor.AfterID)
+		conditions = append(conditions, fmt.Sprintf("cm.id > $%d", len(args)))
+	}
+	if cursor.BeforeID != nil {
+		args = append(args, *cursor.BeforeID)
+		conditions = append(conditions, fmt.Sprintf("cm.id < $%d", len(args)))
+	}
+
+	// С курсором after читаем вперед от него, иначе - последние сообщения перед before
+	order := "DESC"
+	if cursor.AfterID != nil {
+		order = "ASC"
+	}
+
+	// Лишняя строка показывает, есть ли сообщения за пределами страницы
+	args = append(args, cursor.Limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT cm.id, cm.chat_room_id, cm.sender_id, cm.type, cm.content, cm.file_url, cm.file_name, cm.file_size,
+			   cm.mime_type, cm.thumbnail_url, cm.image_width, cm.image_height,
+			   cm.status, cm.read_at, cm.reply_to_id, cm.edited_at, cm.created_at, cm.updated_at,
+			   u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, u.role_id, u.created_at, u.updated_at,
+			   COALESCE(r.name, '') as role_name
+		FROM chat_messages cm
+		LEFT JOIN users u ON cm.sender_id = u.id
+		LEFT JOIN user_roles r ON u.role_id = r.id
+		WHERE %s
+		ORDER BY cm.id %s
+		LIMIT $%d`, strings.Join(conditions, " AND "), order, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get chat messages by cursor: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*domain.ChatMessage, 0, cursor.Limit+1)
+
+	for rows.Next() {
+		message := &domain.ChatMessage{}
+		sender := &domain.User{}
+		var roleName string
+
+		err := rows.Scan(
+			&message.ID, &message.ChatRoomID, &message.SenderID, &message.Type, &message.Content,
+			&message.FileURL, &message.FileName, &message.FileSize,
+			&message.MimeType, &message.ThumbnailURL, &message.ImageWidth, &message.ImageHeight, &message.Status, &message.ReadAt,
+			&message.ReplyToID, &message.EditedAt, &message.CreatedAt, &message.UpdatedAt,
+			&sender.ID, &sender.Phone, &sender.FirstName, &sender.LastName, &sender.Email,
+			&sender.CityID, &sender.IIN, &sender.RoleID, &sender.CreatedAt, &sender.UpdatedAt,
+			&roleName,
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to scan chat message: %w", err)
+		}
+
+		sender.Role = domain.UserRole(roleName)
+		message.Sender = sender
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to iterate chat messages: %w", err)
+	}
+
+	hasMore := len(messages) > cursor.Limit
+	if hasMore {
+		messages = messages[:cursor.Limit]
+	}
+
+	if order == "DESC" {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	return messages, hasMore, nil
+}
+
+func (r *chatMessageRepository) GetReadReceipts(roomID int, readSince time.Time, upToID int) ([]domain.ChatReadReceipt, error) {
+	query := `
+		SELECT id, read_at
+		FROM chat_messages
+		WHERE chat_room_id = $1
+		  AND id <= $2
+		  AND status = 'read'
+		  AND read_at > $3
+		ORDER BY id ASC`
+
+	rows, err := r.db.Query(query, roomID, upToID, readSince)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read receipts: %w", err)
+	}
+	defer rows.Close()
+
+	receipts := make([]domain.ChatReadReceipt, 0)
+	for rows.Next() {
+		var receipt domain.ChatReadReceipt
+		if err := rows.Scan(&receipt.MessageID, &receipt.ReadAt); err != nil {
+			return nil, fmt.Errorf("failed to scan read receipt: %w", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate read receipts: %w", err)
+	}
+
+	return receipts, nil
+}
+
 func (r *chatMessageRepository) GetUnreadCount(roomID, userID int) (int, error) {
 	query := `
 		SELECT COUNT(*) 
@@ -183,6 +309,7 @@ func (r *chatMessageRepository) GetUnreadCount(roomID, userID int) (int, error)
 func (r *chatMessageRepository) GetUnreadMessages(roomID, userID int) ([]*domain.ChatMessage, error) {
 	query := `
 		SELECT cm.id, cm.chat_room_id, cm.sender_id, cm.type, cm.content, cm.file_url, cm.file_name, cm.file_size,
+			   cm.mime_type, cm.thumbnail_url, cm.image_width, cm.image_height,
 			   cm.status, cm.read_at, cm.reply_to_id, cm.edited_at, cm.created_at, cm.updated_at,
 			   u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, u.role_id, u.created_at, u.updated_at,
 			   COALESCE(r.name, '') as role_name
@@ -215,7 +342,8 @@ func (r *chatMessageRepository) GetUnreadMessages(roomID, userID int) ([]*domain
 
 		err := rows.Scan(
 			&message.ID, &message.ChatRoomID, &message.SenderID, &message.Type, &message.Content,
-			&message.FileURL, &message.FileName, &message.FileSize, &message.Status, &message.ReadAt,
+			&message.FileURL, &message.FileName, &message.FileSize,
+			&message.MimeType, &message.ThumbnailURL, &message.ImageWidth, &message.ImageHeight, &message.Status, &message.ReadAt,
 			&message.ReplyToID, &message.EditedAt, &message.CreatedAt, &message.UpdatedAt,
 			&sender.ID, &sender.Phone, &sender.FirstName, &sender.LastName, &sender.Email,
 			&sender.CityID, &sender.IIN, &sender.RoleID, &sender.CreatedAt, &sender.UpdatedAt,
@@ -318,6 +446,7 @@ func (r *chatMessageRepository) MarkRoomAsRead(roomID, userID int) error {
 func (r *chatMessageRepository) GetLastMessage(roomID int) (*domain.ChatMessage, error) {
 	query := `
 		SELECT cm.id, cm.chat_room_id, cm.sender_id, cm.type, cm.content, cm.file_url, cm.file_name, cm.file_size,
+			   cm.mime_type, cm.thumbnail_url, cm.image_width, cm.image_height,
 			   cm.status, cm.read_at, cm.reply_to_id, cm.edited_at, cm.created_at, cm.updated_at,
 			   u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, u.role_id, u.created_at, u.updated_at,
 			   COALESCE(r.name, '') as role_name
@@ -334,7 +463,8 @@ func (r *chatMessageRepository) GetLastMessage(roomID int) (*domain.ChatMessage,
 
 	err := r.db.QueryRow(query, roomID).Scan(
 		&message.ID, &message.ChatRoomID, &message.SenderID, &message.Type, &message.Content,
-		&message.FileURL, &message.FileName, &message.FileSize, &message.Status, &message.ReadAt,
+		&message.FileURL, &message.FileName, &message.FileSize,
+		&message.MimeType, &message.ThumbnailURL, &message.ImageWidth, &message.ImageHeight, &message.Status, &message.ReadAt,
 		&message.ReplyToID, &message.EditedAt, &message.CreatedAt, &message.UpdatedAt,
 		&sender.ID, &sender.Phone, &sender.FirstName, &sender.LastName, &sender.Email,
 		&sender.CityID, &sender.IIN, &sender.RoleID, &sender.CreatedAt, &sender.UpdatedAt,
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
 )
@@ -551,11 +552,14 @@ func (r *CleanerRepository) GetApartmentsForCleaning(cleanerID int) ([]*domain.A
 			a.id, a.street, a.building, a.apartment_number, a.room_count, a.total_area, a.is_free,
 			lb.end_date as last_booking_end_date,
 			c.name as city_name,
-			d.name as district_name
+			d.name as district_name,
+			cl.id as cleaning_log_id,
+			cl.start_notes as cleaning_notes
 		FROM apartments a
 		INNER JOIN cleaner_apartments ca ON a.id = ca.apartment_id
 		LEFT JOIN cities c ON a.city_id = c.id
 		LEFT JOIN districts d ON a.district_id = d.id
+		LEFT JOIN cleaning_logs cl ON cl.apartment_id = a.id AND cl.status = 'in_progress'
 		LEFT JOIN (
 			SELECT DISTINCT ON (apartment_id) 
 				apartment_id, end_date
@@ -601,11 +605,12 @@ func (r *CleanerRepository) GetApartmentsForCleaning(cleanerID int) ([]*domain.A
 	for rows.Next() {
 		apt := &domain.ApartmentForCleaning{}
 		var lastBookingEndDate sql.NullTime
-		var cityName, districtName sql.NullString
+		var cityName, districtName, cleaningNotes sql.NullString
+		var cleaningLogID sql.NullInt64
 
 		err := rows.Scan(
 			&apt.ID, &apt.Street, &apt.Building, &apt.ApartmentNumber, &apt.RoomCount, &apt.TotalArea, &apt.IsFree,
-			&lastBookingEndDate, &cityName, &districtName,
+			&lastBookingEndDate, &cityName, &districtName, &cleaningLogID, &cleaningNotes,
 		)
 		if err != nil {
 			return nil, utils.HandleSQLError(err, "apartment for cleaning", "scan")
@@ -627,6 +632,10 @@ func (r *CleanerRepository) GetApartmentsForCleaning(cleanerID int) ([]*domain.A
 		}
 
 		apt.CleaningStatus = "needs_cleaning"
+		if cleaningLogID.Valid {
+			apt.CleaningStatus = string(domain.CleaningLogStatusInProgress)
+			apt.CleaningNotes = utils.HandleSQLNullString(cleaningNotes)
+		}
 
 		if cityName.Valid {
 			apt.City = &domain.City{Name: cityName.String}
@@ -651,10 +660,13 @@ func (r *CleanerRepository) GetApartmentsNeedingCleaning() ([]*domain.ApartmentF
 			a.id, a.street, a.building, a.apartment_number, a.room_count, a.total_area, a.is_free,
 			lb.end_date as last_booking_end_date,
 			c.name as city_name,
-			d.name as district_name
+			d.name as district_name,
+			cl.id as cleaning_log_id,
+			cl.start_notes as cleaning_notes
 		FROM apartments a
 		LEFT JOIN cities c ON a.city_id = c.id
 		LEFT JOIN districts d ON a.district_id = d.id
+		LEFT JOIN cleaning_logs cl ON cl.apartment_id = a.id AND cl.status = 'in_progress'
 		LEFT JOIN (
 			SELECT DISTINCT ON (apartment_id) 
 				apartment_id, end_date
@@ -688,11 +700,12 @@ func (r *CleanerRepository) GetApartmentsNeedingCleaning() ([]*domain.ApartmentF
 	for rows.Next() {
 		apt := &domain.ApartmentForCleaning{}
 		var lastBookingEndDate sql.NullTime
-		var cityName, districtName sql.NullString
+		var cityName, districtName, cleaningNotes sql.NullString
+		var cleaningLogID sql.NullInt64
 
 		err := rows.Scan(
 			&apt.ID, &apt.Street, &apt.Building, &apt.ApartmentNumber, &apt.RoomCount, &apt.TotalArea, &apt.IsFree,
-			&lastBookingEndDate, &cityName, &districtName,
+			&lastBookingEndDate, &cityName, &districtName, &cleaningLogID, &cleaningNotes,
 		)
 		if err != nil {
 			return nil, utils.HandleSQLError(err, "apartment needing cleaning", "scan")
@@ -714,6 +727,10 @@ func (r *CleanerRepository) GetApartmentsNeedingCleaning() ([]*domain.ApartmentF
 		}
 
 		apt.CleaningStatus = "needs_cleaning"
+		if cleaningLogID.Valid {
+			apt.CleaningStatus = string(domain.CleaningLogStatusInProgress)
+			apt.CleaningNotes = utils.HandleSQLNullString(cleaningNotes)
+		}
 
 		if cityName.Valid {
 			apt.City = &domain.City{Name: cityName.String}
@@ -891,3 +908,492 @@ func (r *CleanerRepository) loadCleanerApartments(cleanerID int) ([]*domain.Apar
 
 	return apartments, nil
 }
+
+const cleaningLogSelect = `
+	SELECT
+		cl.id, cl.apartment_id, cl.cleaner_id, cl.status, cl.started_at, cl.completed_at, cl.cancelled_at,
+		cl.start_notes, cl.completion_notes, cl.cancel_reason, cl.photos_urls, cl.created_at, cl.updated_at,
+		a.owner_id, a.street, a.building, a.apartment_number,
+		c.user_id, u.phone, u.first_name, u.last_name
+	FROM cleaning_logs cl
+	INNER JOIN apartments a ON cl.apartment_id = a.id
+	INNER JOIN cleaners c ON cl.cleaner_id = c.id
+	LEFT JOIN users u ON c.user_id = u.id`
+
+func (r *CleanerRepository) CreateCleaningLog(cleaningLog *domain.CleaningLog) error {
+	query := `
+		INSERT INTO cleaning_logs (apartment_id, cleaner_id, status, started_at, start_notes)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, $4)
+		RETURNING id, started_at, created_at, updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		cleaningLog.ApartmentID,
+		cleaningLog.CleanerID,
+		domain.CleaningLogStatusInProgress,
+		utils.StringToSQLNullString(cleaningLog.StartNotes),
+	).Scan(&cleaningLog.ID, &cleaningLog.StartedAt, &cleaningLog.CreatedAt, &cleaningLog.UpdatedAt)
+	if err != nil {
+		return utils.HandleSQLError(err, "cleaning log", "create")
+	}
+
+	cleaningLog.Status = domain.CleaningLogStatusInProgress
+	return nil
+}
+
+func (r *CleanerRepository) GetCleaningLogByID(id int) (*domain.CleaningLog, error) {
+	cleaningLog, err := scanCleaningLog(r.db.QueryRow(cleaningLogSelect+` WHERE cl.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, utils.HandleSQLError(err, "cleaning log", "get by id")
+	}
+
+	return cleaningLog, nil
+}
+
+func (r *CleanerRepository) GetActiveCleaningLog(apartmentID int) (*domain.CleaningLog, error) {
+	query := cleaningLogSelect + ` WHERE cl.apartment_id = $1 AND cl.status = $2`
+
+	cleaningLog, err := scanCleaningLog(r.db.QueryRow(query, apartmentID, domain.CleaningLogStatusInProgress))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, utils.HandleSQLError(err, "active cleaning log", "get")
+	}
+
+	return cleaningLog, nil
+}
+
+// CompleteCleaningLog завершает уборку, если она еще идет. Иначе возвращает ошибку - уборку
+// могли отменить параллельно.
+func (r *CleanerRepository) CompleteCleaningLog(cleaningLog *domain.CleaningLog) error {
+	query := `
+		UPDATE cleaning_logs SET
+			status = $2, completed_at = CURRENT_TIMESTAMP, completion_notes = $3, photos_urls = $4
+		WHERE id = $1 AND status = $5
+		RETURNING completed_at, updated_at`
+
+	var completedAt time.Time
+	err := r.db.QueryRow(
+		query,
+		cleaningLog.ID,
+		domain.CleaningLogStatusCompleted,
+		utils.StringToSQLNullString(cleaningLog.CompletionNotes),
+		pq.Array(cleaningLog.PhotosURLs),
+		domain.CleaningLogStatusInProgress,
+	).Scan(&completedAt, &cleaningLog.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("cleaning log %d is not in progress", cleaningLog.ID)
+		}
+		return utils.HandleSQLError(err, "cleaning log", "complete")
+	}
+
+	cleaningLog.Status = domain.CleaningLogStatusCompleted
+	cleaningLog.CompletedAt = &completedAt
+	setCleaningDuration(cleaningLog)
+	return nil
+}
+
+func (r *CleanerRepository) CancelCleaningLog(cleaningLog *domain.CleaningLog) error {
+	query := `
+		UPDATE cleaning_logs SET
+			status = $2, cancelled_at = CURRENT_TIMESTAMP, cancel_reason = $3
+		WHERE id = $1 AND status = $4
+		RETURNING cancelled_at, updated_at`
+
+	var cancelledAt time.Time
+	err := r.db.QueryRow(
+		query,
+		cleaningLog.ID,
+		domain.CleaningLogStatusCancelled,
+		utils.StringToSQLNullString(cleaningLog.CancelReason),
+		domain.CleaningLogStatusInProgress,
+	).Scan(&cancelledAt, &cleaningLog.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("cleaning log %d is not in progress", cleaningLog.ID)
+		}
+		return utils.HandleSQLError(err, "cleaning log", "cancel")
+	}
+
+	cleaningLog.Status = domain.CleaningLogStatusCancelled
+	cleaningLog.CancelledAt = &cancelledAt
+	return nil
+}
+
+func (r *CleanerRepository) GetCleaningLogs(filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningLog, int, error) {
+	var conditions []string
+	var params []interface{}
+	paramIndex := 1
+
+	addCondition := func(condition string, value interface{}) {
+		conditions = append(conditions, fmt.Sprintf(condition, paramIndex))
+		params = append(params, value)
+		paramIndex++
+	}
+
+	if value, ok := filters["cleaner_id"]; ok {
+		addCondition("cl.cleaner_id = $%d", value)
+	}
+	if value, ok := filters["apartment_id"]; ok {
+		addCondition("cl.apartment_id = $%d", value)
+	}
+	if value, ok := filters["owner_id"]; ok {
+		addCondition("a.owner_id = $%d", value)
+	}
+	if value, ok := filters["status"]; ok {
+		addCondition("cl.status = $%d", value)
+	}
+	if value, ok := filters["date_from"]; ok {
+		addCondition("cl.started_at >= $%d", value)
+	}
+	if value, ok := filters["date_to"]; ok {
+		addCondition("cl.started_at < $%d", value)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	countQuery := `
+		SELECT COUNT(*)
+		FROM cleaning_logs cl
+		INNER JOIN apartments a ON cl.apartment_id = a.id` + whereClause
+
+	var total int
+	if err := r.db.QueryRow(countQuery, params...).Scan(&total); err != nil {
+		return nil, 0, utils.HandleSQLError(err, "cleaning logs count", "query")
+	}
+
+	offset := (page - 1) * pageSize
+	query := cleaningLogSelect + whereClause +
+		fmt.Sprintf(" ORDER BY cl.started_at DESC LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
+	params = append(params, pageSize, offset)
+
+	rows, err := r.db.Query(query, params...)
+	if err != nil {
+		return nil, 0, utils.HandleSQLError(err, "cleaning logs", "query")
+	}
+	defer utils.CloseRows(rows)
+
+	cleaningLogs := make([]*domain.CleaningLog, 0)
+	for rows.Next() {
+		cleaningLog, err := scanCleaningLog(rows)
+		if err != nil {
+			return nil, 0, utils.HandleSQLError(err, "cleaning log", "scan")
+		}
+		cleaningLogs = append(cleaningLogs, cleaningLog)
+	}
+
+	if err = utils.CheckRowsError(rows, "cleaning logs iteration"); err != nil {
+		return nil, 0, err
+	}
+
+	return cleaningLogs, total, nil
+}
+
+// cleaningScanner общий интерфейс *sql.Row и *sql.Rows.
+type cleaningScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCleaningLog(row cleaningScanner) (*domain.CleaningLog, error) {
+	cleaningLog := &domain.CleaningLog{}
+	apartment := &domain.Apartment{}
+	user := &domain.User{}
+	var completedAt, cancelledAt sql.NullTime
+	var startNotes, completionNotes, cancelReason sql.NullString
+	var photosURLs pq.StringArray
+	var userPhone, userFirstName, userLastName sql.NullString
+
+	err := row.Scan(
+		&cleaningLog.ID, &cleaningLog.ApartmentID, &cleaningLog.CleanerID, &cleaningLog.Status,
+		&cleaningLog.StartedAt, &completedAt, &cancelledAt,
+		&startNotes, &completionNotes, &cancelReason, &photosURLs, &cleaningLog.CreatedAt, &cleaningLog.UpdatedAt,
+		&apartment.OwnerID, &apartment.Street, &apartment.Building, &apartment.ApartmentNumber,
+		&user.ID, &userPhone, &userFirstName, &userLastName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaningLog.CompletedAt = utils.HandleSQLNullTime(completedAt)
+	cleaningLog.CancelledAt = utils.HandleSQLNullTime(cancelledAt)
+	cleaningLog.StartNotes = utils.HandleSQLNullString(startNotes)
+	cleaningLog.CompletionNotes = utils.HandleSQLNullString(completionNotes)
+	cleaningLog.CancelReason = utils.HandleSQLNullString(cancelReason)
+	cleaningLog.PhotosURLs = []string(photosURLs)
+	if cleaningLog.PhotosURLs == nil {
+		cleaningLog.PhotosURLs = []string{}
+	}
+
+	apartment.ID = cleaningLog.ApartmentID
+	cleaningLog.Apartment = apartment
+
+	user.Phone = userPhone.String
+	user.FirstName = userFirstName.String
+	user.LastName = userLastName.String
+	cleaningLog.Cleaner = &domain.Cleaner{
+		ID:     cleaningLog.CleanerID,
+		UserID: user.ID,
+		User:   user,
+	}
+
+	setCleaningDuration(cleaningLog)
+	return cleaningLog, nil
+}
+
+func setCleaningDuration(cleaningLog *domain.CleaningLog) {
+	if cleaningLog.CompletedAt == nil {
+		return
+	}
+
+	minutes := int(cleaningLog.CompletedAt.Sub(cleaningLog.StartedAt).Minutes())
+	cleaningLog.DurationMinutes = &minutes
+}
+
+const cleaningTaskSelect = `
+	SELECT
+		t.id, t.apartment_id, t.booking_id, t.next_booking_id, t.cleaner_id, t.cleaning_log_id, t.status,
+		t.checkout_at, t.cleaning_duration, t.deadline_at, t.offer_attempt, t.offered_at, t.offer_expires_at,
+		t.declined_cleaner_ids, t.accepted_at, t.started_at, t.completed_at, t.escalated_at, t.escalation_reason,
+		t.is_late, t.created_at, t.updated_at,
+		a.owner_id, a.street, a.building, a.apartment_number,
+		c.user_id, u.phone, u.first_name, u.last_name
+	FROM cleaning_tasks t
+	INNER JOIN apartments a ON t.apartment_id = a.id
+	LEFT JOIN cleaners c ON t.cleaner_id = c.id
+	LEFT JOIN users u ON c.user_id = u.id`
+
+func (r *CleanerRepository) CreateCleaningTask(task *domain.CleaningTask) (bool, error) {
+	query := `
+		INSERT INTO cleaning_tasks (apartment_id, booking_id, next_booking_id, status, checkout_at, cleaning_duration, deadline_at, is_late)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT DO NOTHING
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		task.ApartmentID,
+		utils.IntToSQLNullInt64(task.BookingID),
+		utils.IntToSQLNullInt64(task.NextBookingID),
+		task.Status,
+		task.CheckoutAt,
+		task.CleaningDuration,
+		task.DeadlineAt,
+		task.IsLate,
+	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, utils.HandleSQLError(err, "cleaning task", "create")
+	}
+
+	if task.DeclinedCleanerIDs == nil {
+		task.DeclinedCleanerIDs = []int{}
+	}
+	return true, nil
+}
+
+func (r *CleanerRepository) GetCleaningTaskByID(id int) (*domain.CleaningTask, error) {
+	task, err := scanCleaningTask(r.db.QueryRow(cleaningTaskSelect+` WHERE t.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, utils.HandleSQLError(err, "cleaning task", "get by id")
+	}
+
+	return task, nil
+}
+
+func (r *CleanerRepository) GetOpenCleaningTask(apartmentID int) (*domain.CleaningTask, error) {
+	query := cleaningTaskSelect + ` WHERE t.apartment_id = $1 AND t.status = ANY($2)`
+
+	task, err := scanCleaningTask(r.db.QueryRow(query, apartmentID, pq.Array(cleaningTaskStatusStrings(domain.OpenCleaningTaskStatuses))))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, utils.HandleSQLError(err, "open cleaning task", "get")
+	}
+
+	return task, nil
+}
+
+// UpdateCleaningTask сохраняет заявку, если ее не изменили после загрузки (сверяется updated_at).
+func (r *CleanerRepository) UpdateCleaningTask(task *domain.CleaningTask) (bool, error) {
+	query := `
+		UPDATE cleaning_tasks SET
+			cleaner_id = $2, cleaning_log_id = $3, status = $4, offer_attempt = $5, offered_at = $6,
+			offer_expires_at = $7, declined_cleaner_ids = $8, accepted_at = $9, started_at = $10,
+			completed_at = $11, escalated_at = $12, escalation_reason = $13, is_late = $14
+		WHERE id = $1 AND updated_at = $15
+		RETURNING updated_at`
+
+	declinedCleanerIDs := make([]int64, 0, len(task.DeclinedCleanerIDs))
+	for _, id := range task.DeclinedCleanerIDs {
+		declinedCleanerIDs = append(declinedCleanerIDs, int64(id))
+	}
+
+	err := r.db.QueryRow(
+		query,
+		task.ID,
+		utils.IntToSQLNullInt64(task.CleanerID),
+		utils.IntToSQLNullInt64(task.CleaningLogID),
+		task.Status,
+		task.OfferAttempt,
+		utils.TimeToSQLNullTime(task.OfferedAt),
+		utils.TimeToSQLNullTime(task.OfferExpiresAt),
+		pq.Array(declinedCleanerIDs),
+		utils.TimeToSQLNullTime(task.AcceptedAt),
+		utils.TimeToSQLNullTime(task.StartedAt),
+		utils.TimeToSQLNullTime(task.CompletedAt),
+		utils.TimeToSQLNullTime(task.EscalatedAt),
+		utils.StringToSQLNullString(task.EscalationReason),
+		task.IsLate,
+		task.UpdatedAt,
+	).Scan(&task.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, utils.HandleSQLError(err, "cleaning task", "update")
+	}
+
+	return true, nil
+}
+
+func (r *CleanerRepository) GetCleaningTasks(filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningTask, int, error) {
+	var conditions []string
+	var params []interface{}
+	paramIndex := 1
+
+	addCondition := func(condition string, value interface{}) {
+		conditions = append(conditions, fmt.Sprintf(condition, paramIndex))
+		params = append(params, value)
+		paramIndex++
+	}
+
+	if value, ok := filters["cleaner_id"]; ok {
+		addCondition("t.cleaner_id = $%d", value)
+	}
+	if value, ok := filters["apartment_id"]; ok {
+		addCondition("t.apartment_id = $%d", value)
+	}
+	if value, ok := filters["status"]; ok {
+		addCondition("t.status = $%d", value)
+	}
+	if open, ok := filters["open"].(bool); ok && open {
+		addCondition("t.status = ANY($%d)", pq.Array(cleaningTaskStatusStrings(domain.OpenCleaningTaskStatuses)))
+	}
+	if value, ok := filters["is_late"]; ok {
+		addCondition("t.is_late = $%d", value)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM cleaning_tasks t`+whereClause, params...).Scan(&total); err != nil {
+		return nil, 0, utils.HandleSQLError(err, "cleaning tasks count", "query")
+	}
+
+	offset := (page - 1) * pageSize
+	query := cleaningTaskSelect + whereClause +
+		fmt.Sprintf(" ORDER BY t.deadline_at DESC LIMIT $%d OFFSET $%d", paramIndex, paramIndex+1)
+	params = append(params, pageSize, offset)
+
+	rows, err := r.db.Query(query, params...)
+	if err != nil {
+		return nil, 0, utils.HandleSQLError(err, "cleaning tasks", "query")
+	}
+	defer utils.CloseRows(rows)
+
+	tasks := make([]*domain.CleaningTask, 0)
+	for rows.Next() {
+		task, err := scanCleaningTask(rows)
+		if err != nil {
+			return nil, 0, utils.HandleSQLError(err, "cleaning task", "scan")
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = utils.CheckRowsError(rows, "cleaning tasks iteration"); err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, total, nil
+}
+
+func scanCleaningTask(row cleaningScanner) (*domain.CleaningTask, error) {
+	task := &domain.CleaningTask{}
+	apartment := &domain.Apartment{}
+	var bookingID, nextBookingID, cleanerID, cleaningLogID, cleanerUserID sql.NullInt64
+	var offeredAt, offerExpiresAt, acceptedAt, startedAt, completedAt, escalatedAt sql.NullTime
+	var escalationReason, userPhone, userFirstName, userLastName sql.NullString
+	var declinedCleanerIDs pq.Int64Array
+
+	err := row.Scan(
+		&task.ID, &task.ApartmentID, &bookingID, &nextBookingID, &cleanerID, &cleaningLogID, &task.Status,
+		&task.CheckoutAt, &task.CleaningDuration, &task.DeadlineAt, &task.OfferAttempt, &offeredAt, &offerExpiresAt,
+		&declinedCleanerIDs, &acceptedAt, &startedAt, &completedAt, &escalatedAt, &escalationReason,
+		&task.IsLate, &task.CreatedAt, &task.UpdatedAt,
+		&apartment.OwnerID, &apartment.Street, &apartment.Building, &apartment.ApartmentNumber,
+		&cleanerUserID, &userPhone, &userFirstName, &userLastName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	task.BookingID = utils.HandleSQLNullInt64(bookingID)
+	task.NextBookingID = utils.HandleSQLNullInt64(nextBookingID)
+	task.CleanerID = utils.HandleSQLNullInt64(cleanerID)
+	task.CleaningLogID = utils.HandleSQLNullInt64(cleaningLogID)
+	task.OfferedAt = utils.HandleSQLNullTime(offeredAt)
+	task.OfferExpiresAt = utils.HandleSQLNullTime(offerExpiresAt)
+	task.AcceptedAt = utils.HandleSQLNullTime(acceptedAt)
+	task.StartedAt = utils.HandleSQLNullTime(startedAt)
+	task.CompletedAt = utils.HandleSQLNullTime(completedAt)
+	task.EscalatedAt = utils.HandleSQLNullTime(escalatedAt)
+	task.EscalationReason = utils.HandleSQLNullString(escalationReason)
+
+	task.DeclinedCleanerIDs = make([]int, 0, len(declinedCleanerIDs))
+	for _, id := range declinedCleanerIDs {
+		task.DeclinedCleanerIDs = append(task.DeclinedCleanerIDs, int(id))
+	}
+
+	apartment.ID = task.ApartmentID
+	task.Apartment = apartment
+
+	if task.CleanerID != nil && cleanerUserID.Valid {
+		task.Cleaner = &domain.Cleaner{
+			ID:     *task.CleanerID,
+			UserID: int(cleanerUserID.Int64),
+			User: &domain.User{
+				ID:        int(cleanerUserID.Int64),
+				Phone:     userPhone.String,
+				FirstName: userFirstName.String,
+				LastName:  userLastName.String,
+			},
+		}
+	}
+
+	return task, nil
+}
+
+func cleaningTaskStatusStrings(statuses []domain.CleaningTaskStatus) []string {
+	result := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, string(status))
+	}
+	return result
+}
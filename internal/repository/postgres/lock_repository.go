@@ -7,23 +7,65 @@ import (
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/envelope"
 )
 
 type lockRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	codes *envelope.Keyring
 }
 
-func NewLockRepository(db *sql.DB) domain.LockRepository {
-	return &lockRepository{db: db}
+// NewLockRepository создает репозиторий замков. Постоянные и временные коды хранятся
+// зашифрованными ключами codes и расшифровываются при чтении.
+func NewLockRepository(db *sql.DB, codes *envelope.Keyring) domain.LockRepository {
+	return &lockRepository{db: db, codes: codes}
+}
+
+// encryptedCode приемник Scan, расшифровывающий код замка.
+type encryptedCode struct {
+	codes *envelope.Keyring
+	dest  *domain.Secret
+}
+
+func (c encryptedCode) Scan(src interface{}) error {
+	var raw sql.NullString
+	if err := raw.Scan(src); err != nil {
+		return err
+	}
+
+	plaintext, err := c.codes.Decrypt(raw.String)
+	if err != nil {
+		return fmt.Errorf("ошибка расшифровки кода замка: %w", err)
+	}
+
+	*c.dest = domain.Secret(plaintext)
+	return nil
+}
+
+func (r *lockRepository) code(dest *domain.Secret) sql.Scanner {
+	return encryptedCode{codes: r.codes, dest: dest}
+}
+
+func (r *lockRepository) encryptCode(code domain.Secret) (string, error) {
+	encrypted, err := r.codes.Encrypt(code.Reveal())
+	if err != nil {
+		return "", fmt.Errorf("ошибка шифрования кода замка: %w", err)
+	}
+	return encrypted, nil
 }
 
 func (r *lockRepository) Create(lock *domain.Lock) error {
+	ownerPassword, err := r.encryptCode(lock.OwnerPassword)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO locks (unique_id, apartment_id, name, description, current_status, firmware_version, tuya_device_id, owner_password)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at`
 
-	err := r.db.QueryRow(
+	err = r.db.QueryRow(
 		query,
 		lock.UniqueID,
 		lock.ApartmentID,
@@ -32,7 +74,7 @@ func (r *lockRepository) Create(lock *domain.Lock) error {
 		lock.CurrentStatus,
 		lock.FirmwareVersion,
 		lock.TuyaDeviceID,
-		lock.OwnerPassword,
+		ownerPassword,
 	).Scan(&lock.ID, &lock.CreatedAt, &lock.UpdatedAt)
 
 	return err
@@ -68,7 +110,7 @@ func (r *lockRepository) GetByID(id int) (*domain.Lock, error) {
 		&lock.BatteryLevel,
 		&lock.SignalStrength,
 		&lock.TuyaDeviceID,
-		&lock.OwnerPassword,
+		r.code(&lock.OwnerPassword),
 		&lock.BatteryType,
 		&lock.ChargingStatus,
 		&lock.LastBatteryCheck,
@@ -116,7 +158,7 @@ func (r *lockRepository) GetByUniqueID(uniqueID string) (*domain.Lock, error) {
 		&lock.BatteryLevel,
 		&lock.SignalStrength,
 		&lock.TuyaDeviceID,
-		&lock.OwnerPassword,
+		r.code(&lock.OwnerPassword),
 		&lock.BatteryType,
 		&lock.ChargingStatus,
 		&lock.LastBatteryCheck,
@@ -165,7 +207,7 @@ func (r *lockRepository) GetByApartmentID(apartmentID int) (*domain.Lock, error)
 		&lock.BatteryLevel,
 		&lock.SignalStrength,
 		&lock.TuyaDeviceID,
-		&lock.OwnerPassword,
+		r.code(&lock.OwnerPassword),
 		&lock.BatteryType,
 		&lock.ChargingStatus,
 		&lock.LastBatteryCheck,
@@ -222,7 +264,7 @@ func (r *lockRepository) GetLocksByApartmentID(apartmentID int) ([]*domain.Lock,
 			&lock.BatteryLevel,
 			&lock.SignalStrength,
 			&lock.TuyaDeviceID,
-			&lock.OwnerPassword,
+			r.code(&lock.OwnerPassword),
 			&lock.BatteryType,
 			&lock.ChargingStatus,
 			&lock.LastBatteryCheck,
@@ -283,7 +325,7 @@ func (r *lockRepository) GetAll() ([]*domain.Lock, error) {
 			&lock.BatteryLevel,
 			&lock.SignalStrength,
 			&lock.TuyaDeviceID,
-			&lock.OwnerPassword,
+			r.code(&lock.OwnerPassword),
 			&lock.BatteryType,
 			&lock.ChargingStatus,
 			&lock.LastBatteryCheck,
@@ -396,7 +438,7 @@ func (r *lockRepository) GetAllWithFilters(filters map[string]interface{}, page,
 			&lock.BatteryLevel,
 			&lock.SignalStrength,
 			&lock.TuyaDeviceID,
-			&lock.OwnerPassword,
+			r.code(&lock.OwnerPassword),
 			&lock.BatteryType,
 			&lock.ChargingStatus,
 			&lock.LastBatteryCheck,
@@ -584,17 +626,23 @@ func (r *lockRepository) GetStatusLogsByUniqueID(uniqueID string, limit int) ([]
 }
 
 func (r *lockRepository) CreateTempPassword(tempPassword *domain.LockTempPassword) error {
+	password, err := r.encryptCode(tempPassword.Password)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO lock_temp_passwords (lock_id, booking_id, user_id, password, tuya_password_id, name, valid_from, valid_until, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO lock_temp_passwords (lock_id, booking_id, cleaning_log_id, user_id, password, tuya_password_id, name, valid_from, valid_until, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, created_at, updated_at`
 
-	err := r.db.QueryRow(
+	err = r.db.QueryRow(
 		query,
 		tempPassword.LockID,
 		tempPassword.BookingID,
+		tempPassword.CleaningLogID,
 		tempPassword.UserID,
-		tempPassword.Password,
+		password,
 		tempPassword.TuyaPasswordID,
 		tempPassword.Name,
 		tempPassword.ValidFrom,
@@ -607,7 +655,7 @@ func (r *lockRepository) CreateTempPassword(tempPassword *domain.LockTempPasswor
 
 func (r *lockRepository) GetTempPasswordsByLockID(lockID int) ([]*domain.LockTempPassword, error) {
 	query := `
-		SELECT id, lock_id, booking_id, user_id, password, tuya_password_id, name, 
+		SELECT id, lock_id, booking_id, cleaning_log_id, user_id, password, tuya_password_id, name, 
 		       valid_from, valid_until, is_active, created_at, updated_at
 		FROM lock_temp_passwords
 		WHERE lock_id = $1
@@ -626,8 +674,9 @@ func (r *lockRepository) GetTempPasswordsByLockID(lockID int) ([]*domain.LockTem
 			&password.ID,
 			&password.LockID,
 			&password.BookingID,
+			&password.CleaningLogID,
 			&password.UserID,
-			&password.Password,
+			r.code(&password.Password),
 			&password.TuyaPasswordID,
 			&password.Name,
 			&password.ValidFrom,
@@ -647,7 +696,7 @@ func (r *lockRepository) GetTempPasswordsByLockID(lockID int) ([]*domain.LockTem
 
 func (r *lockRepository) GetTempPasswordsByBookingID(bookingID int) ([]*domain.LockTempPassword, error) {
 	query := `
-		SELECT id, lock_id, booking_id, user_id, password, tuya_password_id, name, 
+		SELECT id, lock_id, booking_id, cleaning_log_id, user_id, password, tuya_password_id, name, 
 		       valid_from, valid_until, is_active, created_at, updated_at
 		FROM lock_temp_passwords
 		WHERE booking_id = $1
@@ -666,8 +715,50 @@ func (r *lockRepository) GetTempPasswordsByBookingID(bookingID int) ([]*domain.L
 			&password.ID,
 			&password.LockID,
 			&password.BookingID,
+			&password.CleaningLogID,
+			&password.UserID,
+			r.code(&password.Password),
+			&password.TuyaPasswordID,
+			&password.Name,
+			&password.ValidFrom,
+			&password.ValidUntil,
+			&password.IsActive,
+			&password.CreatedAt,
+			&password.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, password)
+	}
+
+	return passwords, nil
+}
+
+func (r *lockRepository) GetTempPasswordsByCleaningLogID(cleaningLogID int) ([]*domain.LockTempPassword, error) {
+	query := `
+		SELECT id, lock_id, booking_id, cleaning_log_id, user_id, password, tuya_password_id, name, 
+		       valid_from, valid_until, is_active, created_at, updated_at
+		FROM lock_temp_passwords
+		WHERE cleaning_log_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, cleaningLogID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var passwords []*domain.LockTempPassword
+	for rows.Next() {
+		password := &domain.LockTempPassword{}
+		err := rows.Scan(
+			&password.ID,
+			&password.LockID,
+			&password.BookingID,
+			&password.CleaningLogID,
 			&password.UserID,
-			&password.Password,
+			r.code(&password.Password),
 			&password.TuyaPasswordID,
 			&password.Name,
 			&password.ValidFrom,
@@ -687,7 +778,7 @@ func (r *lockRepository) GetTempPasswordsByBookingID(bookingID int) ([]*domain.L
 
 func (r *lockRepository) GetTempPasswordByID(id int) (*domain.LockTempPassword, error) {
 	query := `
-		SELECT id, lock_id, booking_id, user_id, password, tuya_password_id, name, 
+		SELECT id, lock_id, booking_id, cleaning_log_id, user_id, password, tuya_password_id, name, 
 		       valid_from, valid_until, is_active, created_at, updated_at
 		FROM lock_temp_passwords
 		WHERE id = $1`
@@ -697,8 +788,9 @@ func (r *lockRepository) GetTempPasswordByID(id int) (*domain.LockTempPassword,
 		&password.ID,
 		&password.LockID,
 		&password.BookingID,
+		&password.CleaningLogID,
 		&password.UserID,
-		&password.Password,
+		r.code(&password.Password),
 		&password.TuyaPasswordID,
 		&password.Name,
 		&password.ValidFrom,
@@ -716,15 +808,20 @@ func (r *lockRepository) GetTempPasswordByID(id int) (*domain.LockTempPassword,
 }
 
 func (r *lockRepository) UpdateTempPassword(tempPassword *domain.LockTempPassword) error {
+	password, err := r.encryptCode(tempPassword.Password)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE lock_temp_passwords 
 		SET password = $2, name = $3, valid_from = $4, valid_until = $5, is_active = $6, updated_at = NOW()
 		WHERE id = $1`
 
-	_, err := r.db.Exec(
+	_, err = r.db.Exec(
 		query,
 		tempPassword.ID,
-		tempPassword.Password,
+		password,
 		tempPassword.Name,
 		tempPassword.ValidFrom,
 		tempPassword.ValidUntil,
@@ -814,7 +911,7 @@ func (r *lockRepository) GetLocksForAutoUpdate() ([]*domain.Lock, error) {
 			&lock.BatteryLevel,
 			&lock.SignalStrength,
 			&lock.TuyaDeviceID,
-			&lock.OwnerPassword,
+			r.code(&lock.OwnerPassword),
 			&lock.BatteryType,
 			&lock.ChargingStatus,
 			&lock.LastBatteryCheck,
@@ -832,3 +929,197 @@ func (r *lockRepository) GetLocksForAutoUpdate() ([]*domain.Lock, error) {
 
 	return locks, nil
 }
+
+func (r *lockRepository) CreateDeviceCredentials(credentials *domain.LockDeviceCredentials) error {
+	secret, err := r.encryptCode(credentials.Secret)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO lock_device_credentials (lock_id, secret, rotated_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP)
+		RETURNING id, rotated_at, created_at`
+
+	return r.db.QueryRow(query, credentials.LockID, secret).
+		Scan(&credentials.ID, &credentials.RotatedAt, &credentials.CreatedAt)
+}
+
+func (r *lockRepository) GetDeviceCredentials(lockID int) (*domain.LockDeviceCredentials, error) {
+	query := `
+		SELECT id, lock_id, secret, previous_secret, previous_expires_at, rotated_at, created_at
+		FROM lock_device_credentials
+		WHERE lock_id = $1`
+
+	credentials := &domain.LockDeviceCredentials{}
+	err := r.db.QueryRow(query, lockID).Scan(
+		&credentials.ID,
+		&credentials.LockID,
+		r.code(&credentials.Secret),
+		r.code(&credentials.PreviousSecret),
+		&credentials.PreviousExpiresAt,
+		&credentials.RotatedAt,
+		&credentials.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+func (r *lockRepository) UpdateDeviceCredentials(credentials *domain.LockDeviceCredentials) error {
+	secret, err := r.encryptCode(credentials.Secret)
+	if err != nil {
+		return err
+	}
+	previousSecret, err := r.encryptCode(credentials.PreviousSecret)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE lock_device_credentials
+		SET secret = $2, previous_secret = NULLIF($3, ''), previous_expires_at = $4, rotated_at = $5
+		WHERE lock_id = $1`
+
+	result, err := r.db.Exec(query, credentials.LockID, secret, previousSecret, credentials.PreviousExpiresAt, credentials.RotatedAt)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("секрет устройства замка %d не найден", credentials.LockID)
+	}
+
+	return nil
+}
+
+func (r *lockRepository) CreateCodeReveal(reveal *domain.LockCodeReveal) error {
+	query := `
+		INSERT INTO lock_code_reveals (lock_id, temp_password_id, code_type, user_id, booking_id, reason, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at`
+
+	return r.db.QueryRow(
+		query,
+		reveal.LockID,
+		reveal.TempPasswordID,
+		reveal.CodeType,
+		reveal.UserID,
+		reveal.BookingID,
+		reveal.Reason,
+		reveal.IPAddress,
+		reveal.UserAgent,
+	).Scan(&reveal.ID, &reveal.CreatedAt)
+}
+
+func (r *lockRepository) GetCodeRevealsByLockID(lockID int, limit int) ([]*domain.LockCodeReveal, error) {
+	query := `
+		SELECT id, lock_id, temp_password_id, code_type, user_id, booking_id, reason,
+		       COALESCE(ip_address, '') as ip_address, COALESCE(user_agent, '') as user_agent, created_at
+		FROM lock_code_reveals
+		WHERE lock_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, lockID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reveals []*domain.LockCodeReveal
+	for rows.Next() {
+		reveal := &domain.LockCodeReveal{}
+		err := rows.Scan(
+			&reveal.ID,
+			&reveal.LockID,
+			&reveal.TempPasswordID,
+			&reveal.CodeType,
+			&reveal.UserID,
+			&reveal.BookingID,
+			&reveal.Reason,
+			&reveal.IPAddress,
+			&reveal.UserAgent,
+			&reveal.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reveals = append(reveals, reveal)
+	}
+
+	return reveals, rows.Err()
+}
+
+func (r *lockRepository) RotateCodeEncryption() (int, error) {
+	owners, err := r.rotateCodeColumn("locks", "owner_password")
+	if err != nil {
+		return owners, err
+	}
+
+	temps, err := r.rotateCodeColumn("lock_temp_passwords", "password")
+	if err != nil {
+		return owners + temps, err
+	}
+
+	secrets, err := r.rotateCodeColumn("lock_device_credentials", "secret")
+	if err != nil {
+		return owners + temps + secrets, err
+	}
+
+	previousSecrets, err := r.rotateCodeColumn("lock_device_credentials", "previous_secret")
+	return owners + temps + secrets + previousSecrets, err
+}
+
+// rotateCodeColumn перешифровывает коды колонки, не зашифрованные активным ключом. Запись
+// обновляется, только если код не изменился с момента чтения.
+func (r *lockRepository) rotateCodeColumn(table, column string) (int, error) {
+	query := fmt.Sprintf(`
+		SELECT id, %[1]s FROM %[2]s
+		WHERE %[1]s IS NOT NULL AND %[1]s <> '' AND %[1]s NOT LIKE $1 || '%%'`, column, table)
+
+	rows, err := r.db.Query(query, r.codes.ActivePrefix())
+	if err != nil {
+		return 0, fmt.Errorf("ошибка выборки кодов %s: %w", table, err)
+	}
+
+	stored := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		stored[id] = value
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	updateQuery := fmt.Sprintf(`UPDATE %[1]s SET %[2]s = $2 WHERE id = $1 AND %[2]s = $3`, table, column)
+
+	rotated := 0
+	for id, value := range stored {
+		encrypted, err := r.codes.Rotate(value)
+		if err != nil {
+			return rotated, fmt.Errorf("ошибка перешифровки кода %s #%d: %w", table, id, err)
+		}
+
+		result, err := r.db.Exec(updateQuery, id, encrypted, value)
+		if err != nil {
+			return rotated, fmt.Errorf("ошибка сохранения кода %s #%d: %w", table, id, err)
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			rotated++
+		}
+	}
+
+	return rotated, nil
+}
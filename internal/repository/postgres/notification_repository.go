@@ -364,6 +364,91 @@ func (r *NotificationRepository) UpdateDeviceHeartbeat(token string) error {
 	return nil
 }
 
+func (r *NotificationRepository) GetPreferences(userID int) (*domain.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, muted_types, quiet_hours_enabled, quiet_hours_start, quiet_hours_end,
+			   timezone, channels, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1`
+
+	var preferences domain.NotificationPreferences
+	var mutedTypes, channels pq.StringArray
+
+	err := r.db.QueryRow(query, userID).Scan(
+		&preferences.UserID,
+		&mutedTypes,
+		&preferences.QuietHoursEnabled,
+		&preferences.QuietHoursStart,
+		&preferences.QuietHoursEnd,
+		&preferences.Timezone,
+		&channels,
+		&preferences.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, utils.HandleSQLErrorWithID(err, "notification preferences", "get", userID)
+	}
+
+	preferences.MutedTypes = make([]domain.NotificationType, 0, len(mutedTypes))
+	for _, mutedType := range mutedTypes {
+		preferences.MutedTypes = append(preferences.MutedTypes, domain.NotificationType(mutedType))
+	}
+
+	preferences.Channels = make([]domain.NotificationChannel, 0, len(channels))
+	for _, channel := range channels {
+		preferences.Channels = append(preferences.Channels, domain.NotificationChannel(channel))
+	}
+
+	return &preferences, nil
+}
+
+func (r *NotificationRepository) UpsertPreferences(preferences *domain.NotificationPreferences) error {
+	mutedTypes := make(pq.StringArray, 0, len(preferences.MutedTypes))
+	for _, mutedType := range preferences.MutedTypes {
+		mutedTypes = append(mutedTypes, string(mutedType))
+	}
+
+	channels := make(pq.StringArray, 0, len(preferences.Channels))
+	for _, channel := range preferences.Channels {
+		channels = append(channels, string(channel))
+	}
+
+	query := `
+		INSERT INTO notification_preferences (
+			user_id, muted_types, quiet_hours_enabled, quiet_hours_start,
+			quiet_hours_end, timezone, channels
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id)
+		DO UPDATE SET
+			muted_types = EXCLUDED.muted_types,
+			quiet_hours_enabled = EXCLUDED.quiet_hours_enabled,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			timezone = EXCLUDED.timezone,
+			channels = EXCLUDED.channels,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		preferences.UserID,
+		mutedTypes,
+		preferences.QuietHoursEnabled,
+		preferences.QuietHoursStart,
+		preferences.QuietHoursEnd,
+		preferences.Timezone,
+		channels,
+	).Scan(&preferences.UpdatedAt)
+
+	if err != nil {
+		return utils.HandleSQLErrorWithID(err, "notification preferences", "save", preferences.UserID)
+	}
+
+	return nil
+}
+
 func (r *NotificationRepository) scanNotification(row *sql.Row) (*domain.Notification, error) {
 	var notification domain.Notification
 	var dataJSON []byte
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/russo2642/renti_kz/internal/domain"
 )
 
@@ -35,16 +37,18 @@ func (r *paymentRepository) Create(payment *domain.Payment) error {
 
 	query := `
 		INSERT INTO payments (
-			booking_id, payment_id, amount, currency, status,
+			booking_id, extension_id, payment_id, order_id, amount, currency, status,
 			payment_method, provider_status, provider_response,
-			final_booking_status, processed_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			final_booking_status, redirect_url, processed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		RETURNING id, created_at, updated_at`
 
 	err = r.db.QueryRow(
 		query,
 		payment.BookingID,
+		payment.ExtensionID,
 		payment.PaymentID,
+		payment.OrderID,
 		payment.Amount,
 		payment.Currency,
 		payment.Status,
@@ -52,6 +56,7 @@ func (r *paymentRepository) Create(payment *domain.Payment) error {
 		payment.ProviderStatus,
 		providerResponseJSON,
 		payment.FinalBookingStatus,
+		payment.RedirectURL,
 		payment.ProcessedAt,
 	).Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
 
@@ -69,16 +74,18 @@ func (r *paymentRepository) GetByID(id int64) (*domain.Payment, error) {
 	var providerResponseJSON []byte
 
 	query := `
-		SELECT id, booking_id, payment_id, amount, currency, status,
+		SELECT id, booking_id, extension_id, payment_id, order_id, amount, currency, status,
 			   payment_method, provider_status, provider_response,
-			   final_booking_status, processed_at, created_at, updated_at
+			   final_booking_status, redirect_url, processed_at, created_at, updated_at
 		FROM payments
 		WHERE id = $1`
 
 	err := r.db.QueryRow(query, id).Scan(
 		&payment.ID,
 		&payment.BookingID,
+		&payment.ExtensionID,
 		&payment.PaymentID,
+		&payment.OrderID,
 		&payment.Amount,
 		&payment.Currency,
 		&payment.Status,
@@ -86,6 +93,7 @@ func (r *paymentRepository) GetByID(id int64) (*domain.Payment, error) {
 		&payment.ProviderStatus,
 		&providerResponseJSON,
 		&payment.FinalBookingStatus,
+		&payment.RedirectURL,
 		&payment.ProcessedAt,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
@@ -111,16 +119,18 @@ func (r *paymentRepository) GetByPaymentID(paymentID string) (*domain.Payment, e
 	var providerResponseJSON []byte
 
 	query := `
-		SELECT id, booking_id, payment_id, amount, currency, status,
+		SELECT id, booking_id, extension_id, payment_id, order_id, amount, currency, status,
 			   payment_method, provider_status, provider_response,
-			   final_booking_status, processed_at, created_at, updated_at
+			   final_booking_status, redirect_url, processed_at, created_at, updated_at
 		FROM payments
 		WHERE payment_id = $1`
 
 	err := r.db.QueryRow(query, paymentID).Scan(
 		&payment.ID,
 		&payment.BookingID,
+		&payment.ExtensionID,
 		&payment.PaymentID,
+		&payment.OrderID,
 		&payment.Amount,
 		&payment.Currency,
 		&payment.Status,
@@ -128,6 +138,7 @@ func (r *paymentRepository) GetByPaymentID(paymentID string) (*domain.Payment, e
 		&payment.ProviderStatus,
 		&providerResponseJSON,
 		&payment.FinalBookingStatus,
+		&payment.RedirectURL,
 		&payment.ProcessedAt,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
@@ -150,9 +161,9 @@ func (r *paymentRepository) GetByPaymentID(paymentID string) (*domain.Payment, e
 
 func (r *paymentRepository) GetByBookingID(bookingID int64) ([]*domain.Payment, error) {
 	query := `
-		SELECT id, booking_id, payment_id, amount, currency, status,
+		SELECT id, booking_id, extension_id, payment_id, order_id, amount, currency, status,
 			   payment_method, provider_status, provider_response,
-			   final_booking_status, processed_at, created_at, updated_at
+			   final_booking_status, redirect_url, processed_at, created_at, updated_at
 		FROM payments
 		WHERE booking_id = $1
 		ORDER BY created_at DESC`
@@ -171,7 +182,9 @@ func (r *paymentRepository) GetByBookingID(bookingID int64) ([]*domain.Payment,
 		err := rows.Scan(
 			&payment.ID,
 			&payment.BookingID,
+			&payment.ExtensionID,
 			&payment.PaymentID,
+			&payment.OrderID,
 			&payment.Amount,
 			&payment.Currency,
 			&payment.Status,
@@ -179,6 +192,129 @@ func (r *paymentRepository) GetByBookingID(bookingID int64) ([]*domain.Payment,
 			&payment.ProviderStatus,
 			&providerResponseJSON,
 			&payment.FinalBookingStatus,
+			&payment.RedirectURL,
+			&payment.ProcessedAt,
+			&payment.CreatedAt,
+			&payment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(providerResponseJSON) > 0 {
+			var providerResponse domain.FreedomPayStatusResponse
+			if err := json.Unmarshal(providerResponseJSON, &providerResponse); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal provider response: %w", err)
+			}
+			payment.ProviderResponse = &providerResponse
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+func (r *paymentRepository) GetByExtensionID(extensionID int64) ([]*domain.Payment, error) {
+	query := `
+		SELECT id, booking_id, extension_id, payment_id, order_id, amount, currency, status,
+			   payment_method, provider_status, provider_response,
+			   final_booking_status, redirect_url, processed_at, created_at, updated_at
+		FROM payments
+		WHERE extension_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query, extensionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*domain.Payment
+	for rows.Next() {
+		payment := &domain.Payment{}
+		var providerResponseJSON []byte
+
+		err := rows.Scan(
+			&payment.ID,
+			&payment.BookingID,
+			&payment.ExtensionID,
+			&payment.PaymentID,
+			&payment.OrderID,
+			&payment.Amount,
+			&payment.Currency,
+			&payment.Status,
+			&payment.PaymentMethod,
+			&payment.ProviderStatus,
+			&providerResponseJSON,
+			&payment.FinalBookingStatus,
+			&payment.RedirectURL,
+			&payment.ProcessedAt,
+			&payment.CreatedAt,
+			&payment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(providerResponseJSON) > 0 {
+			var providerResponse domain.FreedomPayStatusResponse
+			if err := json.Unmarshal(providerResponseJSON, &providerResponse); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal provider response: %w", err)
+			}
+			payment.ProviderResponse = &providerResponse
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+func (r *paymentRepository) GetStaleByStatuses(statuses []domain.PaymentStatus, createdBefore time.Time, limit int) ([]*domain.Payment, error) {
+	if len(statuses) == 0 {
+		return []*domain.Payment{}, nil
+	}
+
+	statusValues := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusValues[i] = string(status)
+	}
+
+	query := `
+		SELECT id, booking_id, extension_id, payment_id, order_id, amount, currency, status,
+			   payment_method, provider_status, provider_response,
+			   final_booking_status, redirect_url, processed_at, created_at, updated_at
+		FROM payments
+		WHERE status = ANY($1) AND created_at < $2
+		ORDER BY created_at ASC
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, pq.Array(statusValues), createdBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []*domain.Payment
+	for rows.Next() {
+		payment := &domain.Payment{}
+		var providerResponseJSON []byte
+
+		err := rows.Scan(
+			&payment.ID,
+			&payment.BookingID,
+			&payment.ExtensionID,
+			&payment.PaymentID,
+			&payment.OrderID,
+			&payment.Amount,
+			&payment.Currency,
+			&payment.Status,
+			&payment.PaymentMethod,
+			&payment.ProviderStatus,
+			&providerResponseJSON,
+			&payment.FinalBookingStatus,
+			&payment.RedirectURL,
 			&payment.ProcessedAt,
 			&payment.CreatedAt,
 			&payment.UpdatedAt,
@@ -222,7 +358,8 @@ func (r *paymentRepository) Update(payment *domain.Payment) error {
 			provider_status = $4,
 			provider_response = $5,
 			final_booking_status = $6,
-			processed_at = $7
+			processed_at = $7,
+			redirect_url = $8
 		WHERE id = $1
 		RETURNING updated_at`
 
@@ -235,6 +372,7 @@ func (r *paymentRepository) Update(payment *domain.Payment) error {
 		providerResponseJSON,
 		payment.FinalBookingStatus,
 		payment.ProcessedAt,
+		payment.RedirectURL,
 	).Scan(&payment.UpdatedAt)
 
 	return err
@@ -252,9 +390,9 @@ func (r *paymentRepository) GetAll(filters map[string]interface{}, page, pageSiz
 
 	offset := (page - 1) * pageSize
 	query := `
-		SELECT id, booking_id, payment_id, amount, currency, status,
+		SELECT id, booking_id, extension_id, payment_id, order_id, amount, currency, status,
 			   payment_method, provider_status, provider_response,
-			   final_booking_status, processed_at, created_at, updated_at
+			   final_booking_status, redirect_url, processed_at, created_at, updated_at
 		FROM payments` + whereClause + `
 		ORDER BY created_at DESC
 		LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
@@ -275,7 +413,9 @@ func (r *paymentRepository) GetAll(filters map[string]interface{}, page, pageSiz
 		err := rows.Scan(
 			&payment.ID,
 			&payment.BookingID,
+			&payment.ExtensionID,
 			&payment.PaymentID,
+			&payment.OrderID,
 			&payment.Amount,
 			&payment.Currency,
 			&payment.Status,
@@ -283,6 +423,7 @@ func (r *paymentRepository) GetAll(filters map[string]interface{}, page, pageSiz
 			&payment.ProviderStatus,
 			&providerResponseJSON,
 			&payment.FinalBookingStatus,
+			&payment.RedirectURL,
 			&payment.ProcessedAt,
 			&payment.CreatedAt,
 			&payment.UpdatedAt,
@@ -328,6 +469,14 @@ func (r *paymentRepository) buildWhereClause(filters map[string]interface{}) (st
 			conditions = append(conditions, fmt.Sprintf("payment_id = $%d", argIndex))
 			args = append(args, value)
 			argIndex++
+		case "order_id":
+			conditions = append(conditions, fmt.Sprintf("order_id = $%d", argIndex))
+			args = append(args, value)
+			argIndex++
+		case "extension_id":
+			conditions = append(conditions, fmt.Sprintf("extension_id = $%d", argIndex))
+			args = append(args, value)
+			argIndex++
 		}
 	}
 
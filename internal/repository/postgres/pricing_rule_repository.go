@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type pricingRuleRepository struct {
+	db *sql.DB
+}
+
+func NewPricingRuleRepository(db *sql.DB) domain.PricingRuleRepository {
+	return &pricingRuleRepository{db: db}
+}
+
+const pricingRuleSelectFields = `
+	id, apartment_id, rule_type, name, start_date, end_date, weekdays, min_days,
+	hourly_price, daily_price, adjustment_percentage, is_active, created_at, updated_at`
+
+func (r *pricingRuleRepository) Create(rule *domain.PricingRule) error {
+	query := `
+		INSERT INTO apartment_pricing_rules (
+			apartment_id, rule_type, name, start_date, end_date, weekdays, min_days,
+			hourly_price, daily_price, adjustment_percentage, is_active
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		rule.ApartmentID,
+		rule.Type,
+		rule.Name,
+		rule.StartDate,
+		rule.EndDate,
+		weekdaysToArray(rule.Weekdays),
+		rule.MinDays,
+		rule.HourlyPrice,
+		rule.DailyPrice,
+		rule.AdjustmentPercentage,
+		rule.IsActive,
+	).Scan(&rule.ID, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания правила ценообразования: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pricingRuleRepository) GetByID(id int) (*domain.PricingRule, error) {
+	query := fmt.Sprintf(`SELECT %s FROM apartment_pricing_rules WHERE id = $1`, pricingRuleSelectFields)
+
+	rule, err := scanPricingRule(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (r *pricingRuleRepository) GetByApartmentID(apartmentID int, activeOnly bool) ([]*domain.PricingRule, error) {
+	query := fmt.Sprintf(`SELECT %s FROM apartment_pricing_rules WHERE apartment_id = $1`, pricingRuleSelectFields)
+	if activeOnly {
+		query += ` AND is_active = true`
+	}
+	query += ` ORDER BY rule_type ASC, start_date DESC NULLS LAST, id DESC`
+
+	rows, err := r.db.Query(query, apartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выполнения запроса: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*domain.PricingRule{}
+	for rows.Next() {
+		rule, err := scanPricingRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *pricingRuleRepository) Update(rule *domain.PricingRule) error {
+	query := `
+		UPDATE apartment_pricing_rules SET
+			rule_type = $2, name = $3, start_date = $4, end_date = $5, weekdays = $6, min_days = $7,
+			hourly_price = $8, daily_price = $9, adjustment_percentage = $10, is_active = $11,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(
+		query,
+		rule.ID,
+		rule.Type,
+		rule.Name,
+		rule.StartDate,
+		rule.EndDate,
+		weekdaysToArray(rule.Weekdays),
+		rule.MinDays,
+		rule.HourlyPrice,
+		rule.DailyPrice,
+		rule.AdjustmentPercentage,
+		rule.IsActive,
+	).Scan(&rule.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("правило ценообразования с ID %d не найдено", rule.ID)
+		}
+		return fmt.Errorf("ошибка обновления правила ценообразования: %w", err)
+	}
+
+	return nil
+}
+
+func (r *pricingRuleRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM apartment_pricing_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления правила ценообразования: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("ошибка получения количества затронутых строк: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("правило ценообразования с ID %d не найдено", id)
+	}
+
+	return nil
+}
+
+func (r *pricingRuleRepository) GetPublicHolidays(from, to time.Time) ([]*domain.PublicHoliday, error) {
+	query := `
+		SELECT id, holiday_date, name, country_code
+		FROM public_holidays
+		WHERE holiday_date BETWEEN $1::date AND $2::date
+		ORDER BY holiday_date ASC`
+
+	rows, err := r.db.Query(query, from.Format(domain.PricingDateLayout), to.Format(domain.PricingDateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения праздничных дней: %w", err)
+	}
+	defer rows.Close()
+
+	holidays := []*domain.PublicHoliday{}
+	for rows.Next() {
+		holiday := &domain.PublicHoliday{}
+		if err := rows.Scan(&holiday.ID, &holiday.Date, &holiday.Name, &holiday.CountryCode); err != nil {
+			return nil, fmt.Errorf("ошибка сканирования праздничного дня: %w", err)
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return holidays, nil
+}
+
+func scanPricingRule(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.PricingRule, error) {
+	rule := &domain.PricingRule{}
+	var startDate, endDate sql.NullTime
+	var weekdays pq.Int64Array
+	var minDays, hourlyPrice, dailyPrice sql.NullInt64
+
+	err := scanner.Scan(
+		&rule.ID,
+		&rule.ApartmentID,
+		&rule.Type,
+		&rule.Name,
+		&startDate,
+		&endDate,
+		&weekdays,
+		&minDays,
+		&hourlyPrice,
+		&dailyPrice,
+		&rule.AdjustmentPercentage,
+		&rule.IsActive,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сканирования правила ценообразования: %w", err)
+	}
+
+	if startDate.Valid {
+		rule.StartDate = &startDate.Time
+	}
+	if endDate.Valid {
+		rule.EndDate = &endDate.Time
+	}
+	for _, weekday := range weekdays {
+		rule.Weekdays = append(rule.Weekdays, int(weekday))
+	}
+	if minDays.Valid {
+		value := int(minDays.Int64)
+		rule.MinDays = &value
+	}
+	if hourlyPrice.Valid {
+		value := int(hourlyPrice.Int64)
+		rule.HourlyPrice = &value
+	}
+	if dailyPrice.Valid {
+		value := int(dailyPrice.Int64)
+		rule.DailyPrice = &value
+	}
+
+	return rule, nil
+}
+
+func weekdaysToArray(weekdays []int) interface{} {
+	if len(weekdays) == 0 {
+		return nil
+	}
+
+	values := make(pq.Int64Array, len(weekdays))
+	for i, weekday := range weekdays {
+		values[i] = int64(weekday)
+	}
+	return values
+}
@@ -0,0 +1,408 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type reviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) domain.ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+const apartmentReviewSelectFields = `
+	r.id, r.booking_id, r.apartment_id, r.renter_id, COALESCE(u.first_name, ''),
+	r.cleanliness_rating, r.accuracy_rating, r.location_rating, r.check_in_rating, r.rating,
+	r.comment, r.status, r.moderator_id, r.moderator_comment, r.moderated_at,
+	r.owner_reply, r.owner_replied_at, r.created_at, r.updated_at`
+
+const apartmentReviewFrom = `
+	FROM apartment_reviews r
+	LEFT JOIN renters rn ON rn.id = r.renter_id
+	LEFT JOIN users u ON u.id = rn.user_id`
+
+const renterReviewSelectFields = `
+	r.id, r.booking_id, r.renter_id, r.owner_id, COALESCE(u.first_name, ''),
+	r.rating, r.comment, r.status, r.moderator_id, r.moderator_comment, r.moderated_at,
+	r.created_at, r.updated_at`
+
+const renterReviewFrom = `
+	FROM renter_reviews r
+	LEFT JOIN property_owners po ON po.id = r.owner_id
+	LEFT JOIN users u ON u.id = po.user_id`
+
+func (r *reviewRepository) CreateApartmentReview(review *domain.ApartmentReview) error {
+	query := `
+		INSERT INTO apartment_reviews (
+			booking_id, apartment_id, renter_id, cleanliness_rating, accuracy_rating,
+			location_rating, check_in_rating, rating, comment, status
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query,
+		review.BookingID, review.ApartmentID, review.RenterID, review.CleanlinessRating, review.AccuracyRating,
+		review.LocationRating, review.CheckInRating, review.Rating, review.Comment, review.Status,
+	).Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания отзыва о квартире: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) GetApartmentReviewByID(id int) (*domain.ApartmentReview, error) {
+	query := fmt.Sprintf(`SELECT %s %s WHERE r.id = $1`, apartmentReviewSelectFields, apartmentReviewFrom)
+
+	review, err := scanApartmentReview(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) GetApartmentReviewByBookingID(bookingID int) (*domain.ApartmentReview, error) {
+	query := fmt.Sprintf(`SELECT %s %s WHERE r.booking_id = $1`, apartmentReviewSelectFields, apartmentReviewFrom)
+
+	review, err := scanApartmentReview(r.db.QueryRow(query, bookingID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) GetApartmentReviews(apartmentID int, status domain.ReviewStatus, page, pageSize int) ([]*domain.ApartmentReview, int, error) {
+	return r.queryApartmentReviews(`WHERE r.apartment_id = $1 AND r.status = $2`, []interface{}{apartmentID, status}, "DESC", page, pageSize)
+}
+
+// GetApartmentReviewsByStatus очередь модерации: сначала самые старые отзывы.
+func (r *reviewRepository) GetApartmentReviewsByStatus(status domain.ReviewStatus, page, pageSize int) ([]*domain.ApartmentReview, int, error) {
+	return r.queryApartmentReviews(`WHERE r.status = $1`, []interface{}{status}, "ASC", page, pageSize)
+}
+
+func (r *reviewRepository) queryApartmentReviews(whereClause string, args []interface{}, order string, page, pageSize int) ([]*domain.ApartmentReview, int, error) {
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM apartment_reviews r %s`, whereClause)
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета отзывов: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s %s %s ORDER BY r.created_at %s LIMIT $%d OFFSET $%d`,
+		apartmentReviewSelectFields, apartmentReviewFrom, whereClause, order, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(query, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения отзывов: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*domain.ApartmentReview{}
+	for rows.Next() {
+		review, err := scanApartmentReview(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return reviews, total, nil
+}
+
+func (r *reviewRepository) UpdateApartmentReviewModeration(review *domain.ApartmentReview) error {
+	query := `
+		UPDATE apartment_reviews
+		SET status = $2, moderator_id = $3, moderator_comment = $4, moderated_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, review.ID, review.Status, review.ModeratorID, review.ModeratorComment, review.ModeratedAt).
+		Scan(&review.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("отзыв с ID %d не найден", review.ID)
+		}
+		return fmt.Errorf("ошибка модерации отзыва: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) UpdateOwnerReply(review *domain.ApartmentReview) error {
+	query := `
+		UPDATE apartment_reviews
+		SET owner_reply = $2, owner_replied_at = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, review.ID, review.OwnerReply, review.OwnerRepliedAt).Scan(&review.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("отзыв с ID %d не найден", review.ID)
+		}
+		return fmt.Errorf("ошибка сохранения ответа на отзыв: %w", err)
+	}
+
+	return nil
+}
+
+// RecalculateApartmentRating пересчитывает агрегированный рейтинг квартиры по опубликованным отзывам.
+func (r *reviewRepository) RecalculateApartmentRating(apartmentID int) error {
+	query := `
+		UPDATE apartments a
+		SET rating = s.rating,
+			reviews_count = s.reviews_count,
+			rating_cleanliness = s.cleanliness,
+			rating_accuracy = s.accuracy,
+			rating_location = s.location,
+			rating_check_in = s.check_in
+		FROM (
+			SELECT
+				COALESCE(ROUND(AVG(rating), 2), 0) AS rating,
+				COUNT(*) AS reviews_count,
+				COALESCE(ROUND(AVG(cleanliness_rating), 2), 0) AS cleanliness,
+				COALESCE(ROUND(AVG(accuracy_rating), 2), 0) AS accuracy,
+				COALESCE(ROUND(AVG(location_rating), 2), 0) AS location,
+				COALESCE(ROUND(AVG(check_in_rating), 2), 0) AS check_in
+			FROM apartment_reviews
+			WHERE apartment_id = $1 AND status = 'published'
+		) s
+		WHERE a.id = $1`
+
+	if _, err := r.db.Exec(query, apartmentID); err != nil {
+		return fmt.Errorf("ошибка пересчета рейтинга квартиры: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) CreateRenterReview(review *domain.RenterReview) error {
+	query := `
+		INSERT INTO renter_reviews (booking_id, renter_id, owner_id, rating, comment, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRow(query, review.BookingID, review.RenterID, review.OwnerID, review.Rating, review.Comment, review.Status).
+		Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("ошибка создания отзыва об арендаторе: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) GetRenterReviewByID(id int) (*domain.RenterReview, error) {
+	query := fmt.Sprintf(`SELECT %s %s WHERE r.id = $1`, renterReviewSelectFields, renterReviewFrom)
+
+	review, err := scanRenterReview(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) GetRenterReviewByBookingID(bookingID int) (*domain.RenterReview, error) {
+	query := fmt.Sprintf(`SELECT %s %s WHERE r.booking_id = $1`, renterReviewSelectFields, renterReviewFrom)
+
+	review, err := scanRenterReview(r.db.QueryRow(query, bookingID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) GetRenterReviews(renterID int, status domain.ReviewStatus, page, pageSize int) ([]*domain.RenterReview, int, error) {
+	return r.queryRenterReviews(`WHERE r.renter_id = $1 AND r.status = $2`, []interface{}{renterID, status}, "DESC", page, pageSize)
+}
+
+func (r *reviewRepository) GetRenterReviewsByStatus(status domain.ReviewStatus, page, pageSize int) ([]*domain.RenterReview, int, error) {
+	return r.queryRenterReviews(`WHERE r.status = $1`, []interface{}{status}, "ASC", page, pageSize)
+}
+
+func (r *reviewRepository) queryRenterReviews(whereClause string, args []interface{}, order string, page, pageSize int) ([]*domain.RenterReview, int, error) {
+	var total int
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM renter_reviews r %s`, whereClause)
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета отзывов: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s %s %s ORDER BY r.created_at %s LIMIT $%d OFFSET $%d`,
+		renterReviewSelectFields, renterReviewFrom, whereClause, order, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(query, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения отзывов: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []*domain.RenterReview{}
+	for rows.Next() {
+		review, err := scanRenterReview(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("ошибка обработки строк: %w", err)
+	}
+
+	return reviews, total, nil
+}
+
+func (r *reviewRepository) UpdateRenterReviewModeration(review *domain.RenterReview) error {
+	query := `
+		UPDATE renter_reviews
+		SET status = $2, moderator_id = $3, moderator_comment = $4, moderated_at = $5, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, review.ID, review.Status, review.ModeratorID, review.ModeratorComment, review.ModeratedAt).
+		Scan(&review.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("отзыв с ID %d не найден", review.ID)
+		}
+		return fmt.Errorf("ошибка модерации отзыва: %w", err)
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) GetRenterRating(renterID int) (*domain.RenterRating, error) {
+	query := `
+		SELECT COALESCE(ROUND(AVG(rating), 2), 0), COUNT(*)
+		FROM renter_reviews
+		WHERE renter_id = $1 AND status = 'published'`
+
+	rating := &domain.RenterRating{RenterID: renterID}
+	if err := r.db.QueryRow(query, renterID).Scan(&rating.Rating, &rating.ReviewsCount); err != nil {
+		return nil, fmt.Errorf("ошибка получения рейтинга арендатора: %w", err)
+	}
+
+	return rating, nil
+}
+
+func scanApartmentReview(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.ApartmentReview, error) {
+	review := &domain.ApartmentReview{}
+	var moderatorID sql.NullInt64
+	var moderatorComment, ownerReply sql.NullString
+	var moderatedAt, ownerRepliedAt sql.NullTime
+
+	err := scanner.Scan(
+		&review.ID,
+		&review.BookingID,
+		&review.ApartmentID,
+		&review.RenterID,
+		&review.AuthorName,
+		&review.CleanlinessRating,
+		&review.AccuracyRating,
+		&review.LocationRating,
+		&review.CheckInRating,
+		&review.Rating,
+		&review.Comment,
+		&review.Status,
+		&moderatorID,
+		&moderatorComment,
+		&moderatedAt,
+		&ownerReply,
+		&ownerRepliedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сканирования отзыва о квартире: %w", err)
+	}
+
+	if moderatorID.Valid {
+		id := int(moderatorID.Int64)
+		review.ModeratorID = &id
+	}
+	if moderatorComment.Valid {
+		review.ModeratorComment = &moderatorComment.String
+	}
+	if moderatedAt.Valid {
+		review.ModeratedAt = &moderatedAt.Time
+	}
+	if ownerReply.Valid {
+		review.OwnerReply = &ownerReply.String
+	}
+	if ownerRepliedAt.Valid {
+		review.OwnerRepliedAt = &ownerRepliedAt.Time
+	}
+
+	return review, nil
+}
+
+func scanRenterReview(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.RenterReview, error) {
+	review := &domain.RenterReview{}
+	var moderatorID sql.NullInt64
+	var moderatorComment sql.NullString
+	var moderatedAt sql.NullTime
+
+	err := scanner.Scan(
+		&review.ID,
+		&review.BookingID,
+		&review.RenterID,
+		&review.OwnerID,
+		&review.AuthorName,
+		&review.Rating,
+		&review.Comment,
+		&review.Status,
+		&moderatorID,
+		&moderatorComment,
+		&moderatedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("ошибка сканирования отзыва об арендаторе: %w", err)
+	}
+
+	if moderatorID.Valid {
+		id := int(moderatorID.Int64)
+		review.ModeratorID = &id
+	}
+	if moderatorComment.Valid {
+		review.ModeratorComment = &moderatorComment.String
+	}
+	if moderatedAt.Valid {
+		review.ModeratedAt = &moderatedAt.Time
+	}
+
+	return review, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/i18n"
 )
 
 type UserRepository struct {
@@ -34,6 +35,8 @@ func (r *UserRepository) Create(user *domain.User) error {
 		user.IsActive = true
 	}
 
+	user.PreferredLanguage = string(i18n.Normalize(user.PreferredLanguage))
+
 	if err := utils.ResolveUserRoleID(user, r.roleRepo); err != nil {
 		return err
 	}
@@ -41,15 +44,15 @@ func (r *UserRepository) Create(user *domain.User) error {
 	query := `
 		INSERT INTO users (
 			phone, first_name, last_name, email, city_id, iin, role_id, 
-			is_active, password_hash, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) 
+			is_active, password_hash, created_at, updated_at, preferred_language
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) 
 		RETURNING id`
 
 	err := r.db.QueryRow(
 		query,
 		user.Phone, user.FirstName, user.LastName, user.Email,
 		user.CityID, user.IIN, user.RoleID, user.IsActive, user.PasswordHash,
-		user.CreatedAt, user.UpdatedAt,
+		user.CreatedAt, user.UpdatedAt, user.PreferredLanguage,
 	).Scan(&user.ID)
 
 	if err != nil {
@@ -109,6 +112,7 @@ func (r *UserRepository) GetByEmail(email string) (*domain.User, error) {
 func (r *UserRepository) Update(user *domain.User) error {
 
 	user.UpdatedAt = time.Now()
+	user.PreferredLanguage = string(i18n.Normalize(user.PreferredLanguage))
 
 	if err := utils.ResolveUserRoleID(user, r.roleRepo); err != nil {
 		return err
@@ -119,14 +123,14 @@ func (r *UserRepository) Update(user *domain.User) error {
 		SET 
 			phone = $2, first_name = $3, last_name = $4, email = $5,
 			city_id = $6, iin = $7, role_id = $8, is_active = $9, 
-			password_hash = $10, updated_at = $11
+			password_hash = $10, updated_at = $11, preferred_language = $12
 		WHERE id = $1`
 
 	_, err := r.db.Exec(
 		query,
 		user.ID, user.Phone, user.FirstName, user.LastName, user.Email,
 		user.CityID, user.IIN, user.RoleID, user.IsActive, user.PasswordHash,
-		user.UpdatedAt,
+		user.UpdatedAt, user.PreferredLanguage,
 	)
 
 	if err != nil {
@@ -222,7 +226,7 @@ func (r *UserRepository) GetAll(filters map[string]interface{}, page, pageSize i
 
 	query := fmt.Sprintf(`
 		SELECT u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, 
-			u.role_id, u.is_active, u.password_hash, u.created_at, u.updated_at, r.name
+			u.role_id, u.is_active, u.preferred_language, u.password_hash, u.created_at, u.updated_at, r.name
 		FROM users u
 		JOIN user_roles r ON u.role_id = r.id
 		LEFT JOIN renters rnt ON u.id = rnt.user_id%s
@@ -65,6 +65,39 @@ func (s *ApartmentAvailabilityService) calculateIsFree(apartmentID int) (bool, e
 	if err != nil {
 		return false, fmt.Errorf("failed to check apartment availability: %w", err)
 	}
+	if conflictCount > 0 {
+		return false, nil
+	}
+
+	// Квартира занята на другой площадке прямо сейчас или в ближайшие 2 часа
+	externalQuery := `
+		SELECT COUNT(*)
+		FROM apartment_external_events e
+		JOIN apartment_external_calendars c ON c.id = e.calendar_id AND c.is_active = true
+		WHERE e.apartment_id = $1
+		AND e.start_date <= $2 + INTERVAL '2 hours'
+		AND e.end_date > $2`
+
+	err = s.db.QueryRow(externalQuery, apartmentID, now).Scan(&conflictCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to check external calendar availability: %w", err)
+	}
+	if conflictCount > 0 {
+		return false, nil
+	}
+
+	// Квартира закрыта владельцем прямо сейчас или в ближайшие 2 часа
+	blockQuery := `
+		SELECT COUNT(*)
+		FROM availability_blocks
+		WHERE apartment_id = $1
+		AND start_date <= $2 + INTERVAL '2 hours'
+		AND end_date > $2`
+
+	err = s.db.QueryRow(blockQuery, apartmentID, now).Scan(&conflictCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to check availability blocks: %w", err)
+	}
 
 	return conflictCount == 0, nil
 }
@@ -85,6 +118,18 @@ func (s *ApartmentAvailabilityService) RecalculateAllApartments() error {
 			(end_date BETWEEN NOW() - INTERVAL '2 hours' AND NOW() + INTERVAL '2 hours')
 		)
 		UNION
+		-- Периоды, занятые на других площадках, которые начинаются или заканчиваются рядом с текущим временем
+		SELECT DISTINCT apartment_id
+		FROM apartment_external_events
+		WHERE (start_date <= NOW() + INTERVAL '2 hours' AND end_date > NOW())
+		OR end_date BETWEEN NOW() - INTERVAL '2 hours' AND NOW()
+		UNION
+		-- Блокировки владельца, которые начинаются или заканчиваются рядом с текущим временем
+		SELECT DISTINCT apartment_id
+		FROM availability_blocks
+		WHERE (start_date <= NOW() + INTERVAL '2 hours' AND end_date > NOW())
+		OR end_date BETWEEN NOW() - INTERVAL '2 hours' AND NOW()
+		UNION
 		-- Добавляем квартиры которые могут стать свободными
 		SELECT DISTINCT apartment_id
 		FROM apartments 
@@ -233,6 +278,21 @@ func (s *ApartmentAvailabilityService) calculateMultipleIsFree(apartmentIDs []in
 	}
 	apartmentIDsArray += "}"
 
+	query += `
+		UNION
+		SELECT DISTINCT e.apartment_id
+		FROM apartment_external_events e
+		JOIN apartment_external_calendars c ON c.id = e.calendar_id AND c.is_active = true
+		WHERE e.apartment_id = ANY($1)
+		AND e.start_date <= $2 + INTERVAL '2 hours'
+		AND e.end_date > $2
+		UNION
+		SELECT DISTINCT apartment_id
+		FROM availability_blocks
+		WHERE apartment_id = ANY($1)
+		AND start_date <= $2 + INTERVAL '2 hours'
+		AND end_date > $2`
+
 	rows, err := s.db.Query(query, apartmentIDsArray, now)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check multiple apartments availability: %w", err)
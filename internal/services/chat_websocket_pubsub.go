@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+const (
+	chatRoomChannelPrefix  = "chat:room:"
+	chatUserChannelPrefix  = "chat:user:"
+	chatRoomPresencePrefix = "chat:presence:room:"
+	chatUserPresencePrefix = "chat:presence:user:"
+
+	// chatPresenceTTL запись о подключении считается живой, пока узел продлевает ее по тикеру хаба
+	chatPresenceTTL = 90 * time.Second
+
+	chatRedisTimeout = 2 * time.Second
+	// chatFanoutCloseTimeout сколько при остановке ждать отправки накопленных операций в Redis
+	chatFanoutCloseTimeout = 5 * time.Second
+	// chatFanoutMaxOps предел очереди операций с Redis: пока Redis недоступен, самые старые операции отбрасываются
+	chatFanoutMaxOps = 10000
+)
+
+// chatFanoutOp операция с Redis в очереди узла. refresh - продление онлайн-статуса, в очереди хранится только последнее.
+type chatFanoutOp struct {
+	run     func()
+	refresh bool
+}
+
+// chatFanoutEnvelope событие чата, пересылаемое между узлами через Redis.
+type chatFanoutEnvelope struct {
+	NodeID  string            `json:"node_id"`
+	Message *domain.WSMessage `json:"message"`
+}
+
+// chatFanout рассылает события комнат и пользователей между экземплярами API через Redis pub/sub
+// и ведет общий реестр онлайн-пользователей. Узел подписан только на каналы комнат и пользователей,
+// у которых есть локальные подключения; собственные события узел получает напрямую, минуя Redis.
+type chatFanout struct {
+	redisClient *redis.Client
+	pubsub      *redis.PubSub
+	nodeID      string
+	hub         *Hub
+
+	// Подписки и онлайн-статус меняются в фоне, в порядке постановки в очередь: хаб ставит операции
+	// под своей блокировкой, но не ждет Redis, поэтому медленный Redis не останавливает чат
+	opsMutex   sync.Mutex
+	ops        []chatFanoutOp
+	droppedOps int
+	opsReady   chan struct{}
+	closed     bool
+}
+
+func newChatFanout(redisClient *redis.Client, hub *Hub) *chatFanout {
+	f := &chatFanout{
+		redisClient: redisClient,
+		pubsub:      redisClient.Subscribe(context.Background()),
+		nodeID:      uuid.NewString(),
+		hub:         hub,
+		opsReady:    make(chan struct{}, 1),
+	}
+
+	go f.listen()
+	go f.runOps()
+
+	log.Printf("💬 Чат: межузловая рассылка через Redis включена (узел %s)", f.nodeID)
+
+	return f
+}
+
+func chatRoomChannel(roomID int) string {
+	return fmt.Sprintf("%s%d", chatRoomChannelPrefix, roomID)
+}
+
+func chatUserChannel(userID int) string {
+	return fmt.Sprintf("%s%d", chatUserChannelPrefix, userID)
+}
+
+func chatRoomPresenceKey(roomID int) string {
+	return fmt.Sprintf("%s%d", chatRoomPresencePrefix, roomID)
+}
+
+func chatUserPresenceKey(userID int) string {
+	return fmt.Sprintf("%s%d", chatUserPresencePrefix, userID)
+}
+
+func (f *chatFanout) presenceMember(userID int) string {
+	return fmt.Sprintf("%d:%s", userID, f.nodeID)
+}
+
+func (f *chatFanout) publishToRoom(roomID int, message *domain.WSMessage) {
+	f.publish(chatRoomChannel(roomID), message)
+}
+
+func (f *chatFanout) publishToUser(userID int, message *domain.WSMessage) {
+	f.publish(chatUserChannel(userID), message)
+}
+
+func (f *chatFanout) publish(channel string, message *domain.WSMessage) {
+	payload, err := json.Marshal(&chatFanoutEnvelope{NodeID: f.nodeID, Message: message})
+	if err != nil {
+		log.Printf("❌ Чат: ошибка сериализации события для %s: %v", channel, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	if err := f.redisClient.Publish(ctx, channel, payload).Err(); err != nil {
+		log.Printf("❌ Чат: ошибка публикации события в %s: %v", channel, err)
+	}
+}
+
+func (f *chatFanout) subscribe(channels ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	if err := f.pubsub.Subscribe(ctx, channels...); err != nil {
+		log.Printf("❌ Чат: ошибка подписки на %v: %v", channels, err)
+	}
+}
+
+func (f *chatFanout) unsubscribe(channels ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	if err := f.pubsub.Unsubscribe(ctx, channels...); err != nil {
+		log.Printf("❌ Чат: ошибка отписки от %v: %v", channels, err)
+	}
+}
+
+// enqueue ставит операцию с Redis в очередь фонового обработчика. Не блокируется.
+func (f *chatFanout) enqueue(op func()) {
+	f.enqueueOp(chatFanoutOp{run: op})
+}
+
+// enqueueOp добавляет операцию в конец очереди. Новое продление онлайн-статуса заменяет ждущее в очереди:
+// оно собрано по текущим подключениям и перекрывает предыдущее. При переполнении очереди отбрасываются
+// самые старые операции.
+func (f *chatFanout) enqueueOp(op chatFanoutOp) {
+	f.opsMutex.Lock()
+	defer f.opsMutex.Unlock()
+
+	if f.closed {
+		return
+	}
+
+	if op.refresh {
+		pending := f.ops[:0]
+		for _, queued := range f.ops {
+			if !queued.refresh {
+				pending = append(pending, queued)
+			}
+		}
+		clear(f.ops[len(pending):])
+		f.ops = pending
+	}
+
+	if len(f.ops) >= chatFanoutMaxOps {
+		if f.droppedOps == 0 {
+			log.Printf("⚠️ Чат: очередь операций с Redis переполнена (%d), старые операции отбрасываются", chatFanoutMaxOps)
+		}
+		dropped := len(f.ops) - chatFanoutMaxOps + 1
+		f.droppedOps += dropped
+		clear(f.ops[:dropped])
+		f.ops = f.ops[dropped:]
+	}
+
+	f.ops = append(f.ops, op)
+	select {
+	case f.opsReady <- struct{}{}:
+	default:
+	}
+}
+
+func (f *chatFanout) runOps() {
+	for range f.opsReady {
+		for {
+			f.opsMutex.Lock()
+			ops := f.ops
+			f.ops = nil
+			droppedOps := f.droppedOps
+			f.droppedOps = 0
+			f.opsMutex.Unlock()
+
+			if droppedOps > 0 {
+				log.Printf("⚠️ Чат: отброшено операций с Redis из-за переполнения очереди: %d", droppedOps)
+			}
+			if len(ops) == 0 {
+				break
+			}
+			for _, op := range ops {
+				op.run()
+			}
+		}
+	}
+}
+
+// clientConnected подписывает узел на каналы клиента и отмечает его онлайн.
+func (f *chatFanout) clientConnected(client *Client) {
+	f.enqueue(func() {
+		f.subscribe(chatRoomChannel(client.RoomID), chatUserChannel(client.UserID))
+		f.markOnline(client)
+	})
+}
+
+// clientDisconnected отписывает узел от каналов, где не осталось локальных подключений, и снимает онлайн-статус.
+func (f *chatFanout) clientDisconnected(client *Client, channels []string, userDisconnected bool) {
+	f.enqueue(func() {
+		if len(channels) > 0 {
+			f.unsubscribe(channels...)
+		}
+		f.markOffline(client, userDisconnected)
+	})
+}
+
+// refreshOnline продлевает онлайн-статус подключений узла.
+func (f *chatFanout) refreshOnline(clients []*Client) {
+	f.enqueueOp(chatFanoutOp{
+		run: func() {
+			f.markOnline(clients...)
+		},
+		refresh: true,
+	})
+}
+
+// listen доставляет локальным клиентам события, опубликованные другими узлами.
+func (f *chatFanout) listen() {
+	for msg := range f.pubsub.Channel() {
+		var envelope chatFanoutEnvelope
+		if err := json.Unmarshal([]byte(msg.Payload), &envelope); err != nil {
+			log.Printf("❌ Чат: ошибка разбора события из %s: %v", msg.Channel, err)
+			continue
+		}
+
+		if envelope.NodeID == f.nodeID || envelope.Message == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(msg.Channel, chatRoomChannelPrefix):
+			roomID, err := strconv.Atoi(strings.TrimPrefix(msg.Channel, chatRoomChannelPrefix))
+			if err != nil {
+				continue
+			}
+			f.hub.broadcast <- &BroadcastMessage{
+				RoomID:  roomID,
+				Message: envelope.Message,
+			}
+
+		case strings.HasPrefix(msg.Channel, chatUserChannelPrefix):
+			userID, err := strconv.Atoi(strings.TrimPrefix(msg.Channel, chatUserChannelPrefix))
+			if err != nil {
+				continue
+			}
+			f.hub.deliverToUser(userID, envelope.Message)
+		}
+	}
+}
+
+// markOnline записывает подключение клиента в общий реестр. Score записи - момент ее истечения.
+func (f *chatFanout) markOnline(clients ...*Client) {
+	if len(clients) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	now := time.Now()
+	expiresAt := float64(now.Add(chatPresenceTTL).Unix())
+	// Записи узлов, упавших без снятия статуса, вычищаются при следующем обновлении ключа
+	expiredBefore := strconv.FormatInt(now.Unix(), 10)
+
+	pipe := f.redisClient.Pipeline()
+	for _, client := range clients {
+		roomKey := chatRoomPresenceKey(client.RoomID)
+		pipe.ZAdd(ctx, roomKey, redis.Z{Score: expiresAt, Member: f.presenceMember(client.UserID)})
+		pipe.ZRemRangeByScore(ctx, roomKey, "-inf", "("+expiredBefore)
+		pipe.Expire(ctx, roomKey, chatPresenceTTL)
+
+		userKey := chatUserPresenceKey(client.UserID)
+		pipe.ZAdd(ctx, userKey, redis.Z{Score: expiresAt, Member: f.nodeID})
+		pipe.ZRemRangeByScore(ctx, userKey, "-inf", "("+expiredBefore)
+		pipe.Expire(ctx, userKey, chatPresenceTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("❌ Чат: ошибка обновления онлайн-статуса: %v", err)
+	}
+}
+
+func (f *chatFanout) markOffline(client *Client, userDisconnected bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	pipe := f.redisClient.Pipeline()
+	pipe.ZRem(ctx, chatRoomPresenceKey(client.RoomID), f.presenceMember(client.UserID))
+	if userDisconnected {
+		pipe.ZRem(ctx, chatUserPresenceKey(client.UserID), f.nodeID)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("❌ Чат: ошибка снятия онлайн-статуса пользователя %d: %v", client.UserID, err)
+	}
+}
+
+// onlineUsers возвращает пользователей комнаты, подключенных к любому узлу.
+func (f *chatFanout) onlineUsers(roomID int) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	members, err := f.redisClient.ZRangeByScore(ctx, chatRoomPresenceKey(roomID), &redis.ZRangeBy{
+		Min: strconv.FormatInt(time.Now().Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения онлайн-пользователей комнаты %d: %w", roomID, err)
+	}
+
+	seen := make(map[int]bool)
+	var userIDs []int
+	for _, member := range members {
+		userIDStr, _, _ := strings.Cut(member, ":")
+		userID, err := strconv.Atoi(userIDStr)
+		if err != nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+func (f *chatFanout) isUserOnline(userID int) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), chatRedisTimeout)
+	defer cancel()
+
+	count, err := f.redisClient.ZCount(ctx, chatUserPresenceKey(userID), strconv.FormatInt(time.Now().Unix(), 10), "+inf").Result()
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки онлайн-статуса пользователя %d: %w", userID, err)
+	}
+
+	return count > 0, nil
+}
+
+// close дожидается отправки накопленных операций (не дольше chatFanoutCloseTimeout) и отписывает узел от Redis.
+func (f *chatFanout) close() error {
+	drained := make(chan struct{})
+	f.enqueue(func() {
+		close(drained)
+	})
+
+	select {
+	case <-drained:
+	case <-time.After(chatFanoutCloseTimeout):
+		log.Printf("⚠️ Чат: не все операции с Redis выполнены до остановки")
+	}
+
+	f.opsMutex.Lock()
+	f.closed = true
+	f.ops = nil
+	close(f.opsReady)
+	f.opsMutex.Unlock()
+
+	return f.pubsub.Close()
+}
@@ -0,0 +1,64 @@
+package services
+
+import "testing"
+
+func newTestChatFanout() *chatFanout {
+	return &chatFanout{opsReady: make(chan struct{}, 1)}
+}
+
+func runQueuedOps(f *chatFanout) {
+	for _, op := range f.ops {
+		op.run()
+	}
+}
+
+func TestChatFanoutKeepsOnlyLatestRefresh(t *testing.T) {
+	f := newTestChatFanout()
+	var order []int
+	track := func(id int) func() {
+		return func() { order = append(order, id) }
+	}
+
+	f.enqueueOp(chatFanoutOp{run: track(1), refresh: true})
+	f.enqueue(track(2))
+	f.enqueueOp(chatFanoutOp{run: track(3), refresh: true})
+	f.enqueue(track(4))
+	f.enqueueOp(chatFanoutOp{run: track(5), refresh: true})
+
+	runQueuedOps(f)
+
+	expected := []int{2, 4, 5}
+	if len(order) != len(expected) {
+		t.Fatalf("expected ops %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected ops %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestChatFanoutDropsOldestOpsWhenFull(t *testing.T) {
+	f := newTestChatFanout()
+	var first int
+	for i := 0; i < chatFanoutMaxOps+10; i++ {
+		id := i
+		f.enqueue(func() {
+			if first == 0 {
+				first = id
+			}
+		})
+	}
+
+	if len(f.ops) != chatFanoutMaxOps {
+		t.Fatalf("expected queue to be capped at %d, got %d", chatFanoutMaxOps, len(f.ops))
+	}
+	if f.droppedOps != 10 {
+		t.Fatalf("expected 10 dropped ops, got %d", f.droppedOps)
+	}
+
+	runQueuedOps(f)
+	if first != 10 {
+		t.Fatalf("expected oldest ops to be dropped, first remaining is %d", first)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
 	"github.com/russo2642/renti_kz/internal/domain"
 )
 
@@ -30,6 +31,12 @@ type Client struct {
 	Send         chan *domain.WSMessage
 	Hub          *Hub
 	LastActivity time.Time
+
+	// Пока идет синхронизация после переподключения, живые события копятся в pending
+	// и отправляются клиенту после пропущенных сообщений
+	syncMutex sync.Mutex
+	syncing   bool
+	pending   []*domain.WSMessage
 }
 
 type Hub struct {
@@ -49,6 +56,9 @@ type Hub struct {
 
 	chatUseCase domain.ChatUseCase
 	userUseCase domain.UserUseCase
+
+	// fanout пересылает события на другие экземпляры API; nil при работе на одном узле
+	fanout *chatFanout
 }
 
 type BroadcastMessage struct {
@@ -63,7 +73,7 @@ type ChatWebSocketService struct {
 	userUseCase domain.UserUseCase
 }
 
-func NewChatWebSocketService(chatUseCase domain.ChatUseCase, userUseCase domain.UserUseCase) *ChatWebSocketService {
+func NewChatWebSocketService(chatUseCase domain.ChatUseCase, userUseCase domain.UserUseCase, redisClient *redis.Client) *ChatWebSocketService {
 	hub := &Hub{
 		clients:     make(map[*Client]bool),
 		rooms:       make(map[int]map[*Client]bool),
@@ -75,6 +85,10 @@ func NewChatWebSocketService(chatUseCase domain.ChatUseCase, userUseCase domain.
 		userUseCase: userUseCase,
 	}
 
+	if redisClient != nil {
+		hub.fanout = newChatFanout(redisClient, hub)
+	}
+
 	go hub.run()
 
 	return &ChatWebSocketService{
@@ -137,10 +151,41 @@ func (s *ChatWebSocketService) HandleWebSocket(c interface{}) {
 		LastActivity: time.Now(),
 	}
 
+	resumeRequest, resume := parseResumeQuery(ginCtx)
+	if resume {
+		// Буферизация включается до регистрации, чтобы не потерять события между выборкой истории и подпиской
+		client.syncing = true
+	}
+
 	s.hub.register <- client
 
 	go client.writePump()
 	go client.readPump()
+
+	if resume {
+		go client.resume(resumeRequest)
+	}
+}
+
+// parseResumeQuery разбирает параметры возобновления сессии при подключении:
+// last_message_id и необязательный last_event_at (RFC3339).
+func parseResumeQuery(c *gin.Context) (*domain.ChatSyncRequest, bool) {
+	lastMessageIDStr := c.Query("last_message_id")
+	if lastMessageIDStr == "" {
+		return nil, false
+	}
+
+	lastMessageID, err := strconv.Atoi(lastMessageIDStr)
+	if err != nil || lastMessageID < 0 {
+		return nil, false
+	}
+
+	request := &domain.ChatSyncRequest{LastMessageID: lastMessageID}
+	if lastEventAt, err := time.Parse(time.RFC3339, c.Query("last_event_at")); err == nil {
+		request.LastEventAt = &lastEventAt
+	}
+
+	return request, true
 }
 
 func (s *ChatWebSocketService) HandleConnection(userID int, roomID int) error {
@@ -152,21 +197,18 @@ func (s *ChatWebSocketService) BroadcastToRoom(roomID int, message *domain.WSMes
 		RoomID:  roomID,
 		Message: message,
 	}
+
+	if s.hub.fanout != nil {
+		s.hub.fanout.publishToRoom(roomID, message)
+	}
 	return nil
 }
 
 func (s *ChatWebSocketService) SendToUser(userID int, message *domain.WSMessage) error {
-	s.hub.mutex.RLock()
-	client, exists := s.hub.users[userID]
-	s.hub.mutex.RUnlock()
+	s.hub.deliverToUser(userID, message)
 
-	if exists && client != nil {
-		select {
-		case client.Send <- message:
-		default:
-			close(client.Send)
-			delete(s.hub.users, userID)
-		}
+	if s.hub.fanout != nil {
+		s.hub.fanout.publishToUser(userID, message)
 	}
 	return nil
 }
@@ -194,6 +236,14 @@ func (s *ChatWebSocketService) UserLeaveRoom(userID, roomID int) error {
 }
 
 func (s *ChatWebSocketService) GetOnlineUsers(roomID int) ([]int, error) {
+	if s.hub.fanout != nil {
+		userIDs, err := s.hub.fanout.onlineUsers(roomID)
+		if err == nil {
+			return userIDs, nil
+		}
+		log.Printf("⚠️ Чат: %v, используем локальные подключения", err)
+	}
+
 	s.hub.mutex.RLock()
 	defer s.hub.mutex.RUnlock()
 
@@ -207,6 +257,14 @@ func (s *ChatWebSocketService) GetOnlineUsers(roomID int) ([]int, error) {
 }
 
 func (s *ChatWebSocketService) IsUserOnline(userID int) bool {
+	if s.hub.fanout != nil {
+		online, err := s.hub.fanout.isUserOnline(userID)
+		if err == nil {
+			return online
+		}
+		log.Printf("⚠️ Чат: %v, используем локальные подключения", err)
+	}
+
 	s.hub.mutex.RLock()
 	defer s.hub.mutex.RUnlock()
 
@@ -214,6 +272,21 @@ func (s *ChatWebSocketService) IsUserOnline(userID int) bool {
 	return exists
 }
 
+// Close отписывает узел от Redis и снимает онлайн-статус его клиентов.
+func (s *ChatWebSocketService) Close() error {
+	if s.hub.fanout == nil {
+		return nil
+	}
+
+	s.hub.mutex.RLock()
+	for client := range s.hub.clients {
+		s.hub.fanout.clientDisconnected(client, nil, true)
+	}
+	s.hub.mutex.RUnlock()
+
+	return s.hub.fanout.close()
+}
+
 func (h *Hub) run() {
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
@@ -231,6 +304,7 @@ func (h *Hub) run() {
 
 		case <-ticker.C:
 			h.cleanupInactiveConnections()
+			h.refreshPresence()
 		}
 	}
 }
@@ -252,6 +326,10 @@ func (h *Hub) registerClient(client *Client) {
 
 	h.users[client.UserID] = client
 
+	if h.fanout != nil {
+		h.fanout.clientConnected(client)
+	}
+
 	log.Printf("Client registered: User %d in room %d", client.UserID, client.RoomID)
 
 	welcomeMsg := &domain.WSMessage{
@@ -279,15 +357,30 @@ func (h *Hub) unregisterClientUnsafe(client *Client) {
 	if _, ok := h.clients[client]; ok {
 		delete(h.clients, client)
 
+		roomEmptied := false
 		if room, exists := h.rooms[client.RoomID]; exists {
 			delete(room, client)
 			if len(room) == 0 {
 				delete(h.rooms, client.RoomID)
+				roomEmptied = true
 			}
 		}
 
+		userDisconnected := false
 		if h.users[client.UserID] == client {
 			delete(h.users, client.UserID)
+			userDisconnected = true
+		}
+
+		if h.fanout != nil {
+			var channels []string
+			if roomEmptied {
+				channels = append(channels, chatRoomChannel(client.RoomID))
+			}
+			if userDisconnected {
+				channels = append(channels, chatUserChannel(client.UserID))
+			}
+			h.fanout.clientDisconnected(client, channels, userDisconnected)
 		}
 
 		close(client.Send)
@@ -299,24 +392,69 @@ func (h *Hub) unregisterClientUnsafe(client *Client) {
 
 func (h *Hub) broadcastToRoom(message *BroadcastMessage) {
 	h.mutex.RLock()
-	room, exists := h.rooms[message.RoomID]
-	h.mutex.RUnlock()
-
-	if !exists {
-		return
-	}
-
-	for client := range room {
+	var recipients []*Client
+	for client := range h.rooms[message.RoomID] {
 		if message.Exclude != nil && client == message.Exclude {
 			continue
 		}
+		recipients = append(recipients, client)
+	}
 
-		select {
-		case client.Send <- message.Message:
-		default:
-			h.unregister <- client
+	// Медленные клиенты отключаются после рассылки: хаб сам обрабатывает канал unregister
+	var slowClients []*Client
+	for _, client := range recipients {
+		if !client.enqueue(message.Message) {
+			slowClients = append(slowClients, client)
 		}
 	}
+	h.mutex.RUnlock()
+
+	for _, client := range slowClients {
+		h.unregisterClient(client)
+	}
+}
+
+// deliverToUser отправляет событие пользователю, если он подключен к этому узлу.
+func (h *Hub) deliverToUser(userID int, message *domain.WSMessage) {
+	h.mutex.RLock()
+	client, exists := h.users[userID]
+	delivered := true
+	if exists && client != nil {
+		delivered = client.enqueue(message)
+	}
+	h.mutex.RUnlock()
+
+	if !delivered {
+		h.unregisterClient(client)
+	}
+}
+
+// sendToClient отправляет событие конкретному подключению, если оно еще зарегистрировано.
+func (h *Hub) sendToClient(client *Client, message *domain.WSMessage) {
+	h.mutex.RLock()
+	_, registered := h.clients[client]
+	delivered := !registered || client.enqueue(message)
+	h.mutex.RUnlock()
+
+	if !delivered {
+		h.unregisterClient(client)
+	}
+}
+
+// refreshPresence продлевает записи о подключениях этого узла в общем реестре.
+func (h *Hub) refreshPresence() {
+	if h.fanout == nil {
+		return
+	}
+
+	h.mutex.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mutex.RUnlock()
+
+	h.fanout.refreshOnline(clients)
 }
 
 func (h *Hub) cleanupInactiveConnections() {
@@ -333,6 +471,125 @@ func (h *Hub) cleanupInactiveConnections() {
 	}
 }
 
+// enqueue ставит событие в очередь отправки клиента. Возвращает false, если клиент не успевает
+// читать события. Вызывается под блокировкой хаба, поэтому канал Send не может быть закрыт.
+func (c *Client) enqueue(message *domain.WSMessage) bool {
+	c.syncMutex.Lock()
+	defer c.syncMutex.Unlock()
+
+	if c.syncing {
+		if len(c.pending) >= cap(c.Send) {
+			return false
+		}
+		c.pending = append(c.pending, message)
+		return true
+	}
+
+	select {
+	case c.Send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+// resume отправляет клиенту сообщения и отметки о прочтении, пропущенные после LastMessageID,
+// а затем накопленные за время синхронизации живые события без дублей.
+func (c *Client) resume(request *domain.ChatSyncRequest) {
+	c.syncMutex.Lock()
+	c.syncing = true
+	c.syncMutex.Unlock()
+
+	var syncMsg *domain.WSMessage
+	if c.Hub.chatUseCase == nil {
+		syncMsg = newWSErrorMessage(c, "chat is not available")
+	} else if response, err := c.Hub.chatUseCase.SyncMessages(c.RoomID, c.UserID, request); err != nil {
+		log.Printf("Chat sync error for user %d in room %d: %v", c.UserID, c.RoomID, err)
+		syncMsg = newWSErrorMessage(c, "failed to sync messages")
+	} else {
+		syncMsg = &domain.WSMessage{
+			Type:      domain.WSEventSync,
+			Data:      response,
+			Timestamp: time.Now(),
+			UserID:    c.UserID,
+			RoomID:    c.RoomID,
+		}
+	}
+
+	c.Hub.finishSync(c, syncMsg)
+}
+
+func (h *Hub) finishSync(client *Client, syncMsg *domain.WSMessage) {
+	h.mutex.RLock()
+	if _, registered := h.clients[client]; !registered {
+		h.mutex.RUnlock()
+		return
+	}
+
+	lastSyncedID := 0
+	if response, ok := syncMsg.Data.(*domain.ChatSyncResponse); ok {
+		lastSyncedID = response.LastMessageID
+	}
+
+	client.syncMutex.Lock()
+	pending := client.pending
+	client.pending = nil
+	client.syncing = false
+
+	queue := make([]*domain.WSMessage, 0, len(pending)+1)
+	queue = append(queue, syncMsg)
+	for _, message := range pending {
+		if message.Type == domain.WSEventNewMessage {
+			if messageID, ok := wsMessageID(message); ok && messageID <= lastSyncedID {
+				continue
+			}
+		}
+		queue = append(queue, message)
+	}
+
+	delivered := true
+	for _, message := range queue {
+		select {
+		case client.Send <- message:
+		default:
+			delivered = false
+		}
+		if !delivered {
+			break
+		}
+	}
+	client.syncMutex.Unlock()
+	h.mutex.RUnlock()
+
+	if !delivered {
+		h.unregisterClient(client)
+	}
+}
+
+// wsMessageID извлекает ID сообщения чата из события new_message. События с других узлов
+// приходят через Redis уже в виде map.
+func wsMessageID(message *domain.WSMessage) (int, bool) {
+	switch data := message.Data.(type) {
+	case *domain.ChatMessage:
+		return data.ID, true
+	case map[string]interface{}:
+		if id, ok := data["id"].(float64); ok {
+			return int(id), true
+		}
+	}
+	return 0, false
+}
+
+func newWSErrorMessage(c *Client, text string) *domain.WSMessage {
+	return &domain.WSMessage{
+		Type:      domain.WSEventError,
+		Data:      map[string]interface{}{"message": text},
+		Timestamp: time.Now(),
+		UserID:    c.UserID,
+		RoomID:    c.RoomID,
+	}
+}
+
 const (
 	writeWait      = 10 * time.Second
 	pongWait       = 60 * time.Second
@@ -442,7 +699,27 @@ func (c *Client) handleIncomingMessage(msg *domain.WSMessage) {
 		}
 		c.Hub.broadcast <- broadcastMsg
 
+		if c.Hub.fanout != nil {
+			c.Hub.fanout.publishToRoom(c.RoomID, msg)
+		}
+
+	case domain.WSEventResume:
+		var request domain.ChatSyncRequest
+		if err := decodeWSData(msg.Data, &request); err != nil {
+			c.Hub.sendToClient(c, newWSErrorMessage(c, "invalid resume request"))
+			return
+		}
+		go c.resume(&request)
+
 	default:
 		log.Printf("Unknown WebSocket message type: %s", msg.Type)
 	}
 }
+
+func decodeWSData(data interface{}, target interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, target)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/i18n"
 )
 
 type contractService struct {
@@ -57,8 +59,12 @@ const (
 	cacheExpiration        = 7 * 24 * time.Hour
 )
 
-func (s *contractService) GetOrGenerateContractHTML(contractID int) (string, error) {
-	cacheKey := fmt.Sprintf("%s%d", contractCacheKeyPrefix, contractID)
+func contractCacheKey(contractID int, lang i18n.Language) string {
+	return fmt.Sprintf("%s%d:%s", contractCacheKeyPrefix, contractID, lang)
+}
+
+func (s *contractService) GetOrGenerateContractHTML(contractID int, lang string) (string, error) {
+	cacheKey := contractCacheKey(contractID, i18n.Normalize(lang))
 	ctx := context.Background()
 
 	cachedHTML, err := s.redisClient.Get(ctx, cacheKey).Result()
@@ -66,7 +72,7 @@ func (s *contractService) GetOrGenerateContractHTML(contractID int) (string, err
 		return cachedHTML, nil
 	}
 
-	html, err := s.GenerateContractHTML(contractID)
+	html, err := s.GenerateContractHTML(contractID, lang)
 	if err != nil {
 		return "", err
 	}
@@ -79,7 +85,7 @@ func (s *contractService) GetOrGenerateContractHTML(contractID int) (string, err
 	return html, nil
 }
 
-func (s *contractService) GenerateContractHTML(contractID int) (string, error) {
+func (s *contractService) GenerateContractHTML(contractID int, lang string) (string, error) {
 	contract, err := s.contractRepo.GetByID(contractID)
 	if err != nil {
 		return "", fmt.Errorf("договор не найден: %w", err)
@@ -100,7 +106,7 @@ func (s *contractService) GenerateContractHTML(contractID int) (string, error) {
 		return "", fmt.Errorf("ошибка подготовки данных: %w", err)
 	}
 
-	html, err := s.RenderTemplate(contract.Type, templateData)
+	html, err := s.RenderTemplate(contract.Type, templateData, lang)
 	if err != nil {
 		return "", fmt.Errorf("ошибка рендеринга шаблона: %w", err)
 	}
@@ -193,19 +199,19 @@ func (s *contractService) getCurrentContactInfo(bookingID, apartmentID int) (*do
 	}, nil
 }
 
-func (s *contractService) RenderTemplate(contractType domain.ContractType, data *domain.ContractTemplateData) (string, error) {
-	var templateFileName string
+func (s *contractService) RenderTemplate(contractType domain.ContractType, data *domain.ContractTemplateData, lang string) (string, error) {
+	var templateName string
 
 	switch contractType {
 	case domain.ContractTypeRental:
-		templateFileName = "rental_contract.html"
+		templateName = "rental_contract"
 	case domain.ContractTypeApartment:
-		templateFileName = "apartment_contract.html"
+		templateName = "apartment_contract"
 	default:
 		return "", fmt.Errorf("неподдерживаемый тип договора: %s", contractType)
 	}
 
-	templatePath := filepath.Join(s.templatesPath, templateFileName)
+	templateFileName, templatePath := s.resolveTemplate(templateName, i18n.Normalize(lang))
 
 	tmpl := template.New(templateFileName).Funcs(template.FuncMap{
 		"formatDate": func(t time.Time) string {
@@ -230,6 +236,21 @@ func (s *contractService) RenderTemplate(contractType domain.ContractType, data
 	return buf.String(), nil
 }
 
+// resolveTemplate возвращает шаблон на нужном языке: "<name>.<lang>.html".
+// Русский шаблон хранится без суффикса и используется, если перевода нет.
+func (s *contractService) resolveTemplate(name string, lang i18n.Language) (string, string) {
+	if lang != i18n.Default {
+		fileName := fmt.Sprintf("%s.%s.html", name, lang)
+		path := filepath.Join(s.templatesPath, fileName)
+		if _, err := os.Stat(path); err == nil {
+			return fileName, path
+		}
+	}
+
+	fileName := name + ".html"
+	return fileName, filepath.Join(s.templatesPath, fileName)
+}
+
 func (s *contractService) minifyHTML(html string) string {
 	lines := strings.Split(html, "\n")
 	var cleanLines []string
@@ -245,10 +266,14 @@ func (s *contractService) minifyHTML(html string) string {
 }
 
 func (s *contractService) InvalidateContractCache(contractID int) error {
-	cacheKey := fmt.Sprintf("%s%d", contractCacheKeyPrefix, contractID)
+	// Ключ без языка остался от версий до локализации договоров
+	cacheKeys := []string{fmt.Sprintf("%s%d", contractCacheKeyPrefix, contractID)}
+	for _, lang := range i18n.Languages {
+		cacheKeys = append(cacheKeys, contractCacheKey(contractID, lang))
+	}
 	ctx := context.Background()
 
-	err := s.redisClient.Del(ctx, cacheKey).Err()
+	err := s.redisClient.Del(ctx, cacheKeys...).Err()
 	if err != nil {
 		return fmt.Errorf("ошибка удаления из кэша: %w", err)
 	}
@@ -257,7 +282,7 @@ func (s *contractService) InvalidateContractCache(contractID int) error {
 }
 
 func (s *contractService) WarmupContractCache(contractID int) error {
-	_, err := s.GetOrGenerateContractHTML(contractID)
+	_, err := s.GetOrGenerateContractHTML(contractID, string(i18n.Default))
 	return err
 }
 
@@ -1,15 +1,19 @@
 package services
 
 import (
+	"bytes"
 	"crypto/md5"
+	"crypto/subtle"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/russo2642/renti_kz/internal/config"
 	"github.com/russo2642/renti_kz/internal/domain"
 )
 
@@ -17,18 +21,75 @@ type FreedomPayService struct {
 	merchantID string
 	secretKey  string
 	apiURL     string
+	config     *config.FreedomPayConfig
 	client     *http.Client
 }
 
-func NewFreedomPayService(merchantID, secretKey, apiURL string) domain.FreedomPayService {
+func NewFreedomPayService(cfg *config.FreedomPayConfig) domain.FreedomPayService {
 	return &FreedomPayService{
-		merchantID: merchantID,
-		secretKey:  secretKey,
-		apiURL:     apiURL,
+		merchantID: cfg.MerchantID,
+		secretKey:  cfg.SecretKey,
+		apiURL:     cfg.APIBase,
+		config:     cfg,
 		client:     GetFastClient(),
 	}
 }
 
+func (s *FreedomPayService) InitPayment(request *domain.FreedomPayInitPaymentRequest) (*domain.FreedomPayInitPaymentResponse, error) {
+	data := map[string]string{
+		"pg_merchant_id": s.merchantID,
+		"pg_order_id":    request.OrderID,
+		"pg_amount":      fmt.Sprintf("%d", request.Amount),
+		"pg_currency":    "KZT",
+		"pg_description": request.Description,
+		"pg_salt":        uuid.New().String(),
+		"pg_language":    "ru",
+	}
+
+	if s.config.WebhookURL != "" {
+		data["pg_result_url"] = s.config.WebhookURL
+		data["pg_check_url"] = s.config.WebhookURL
+		data["pg_request_method"] = "POST"
+	}
+
+	if s.config.SuccessURL != "" {
+		data["pg_success_url"] = s.config.SuccessURL
+	}
+	if s.config.FailureURL != "" {
+		data["pg_failure_url"] = s.config.FailureURL
+	}
+	if s.config.PaymentLifetime > 0 {
+		data["pg_lifetime"] = fmt.Sprintf("%d", int(s.config.PaymentLifetime.Seconds()))
+	}
+	if s.config.TestingMode {
+		data["pg_testing_mode"] = "1"
+	}
+	if request.UserPhone != "" {
+		data["pg_user_phone"] = strings.TrimPrefix(request.UserPhone, "+")
+	}
+	if request.UserEmail != "" {
+		data["pg_user_contact_email"] = request.UserEmail
+	}
+
+	data["pg_sig"] = s.GenerateParamsSignature("init_payment.php", data)
+
+	body, err := s.postForm("init_payment.php", data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifyResponseSignature("init_payment.php", body); err != nil {
+		return nil, err
+	}
+
+	var response domain.FreedomPayInitPaymentResponse
+	if err := xml.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("ошибка парсинга XML ответа инициализации платежа: %w", err)
+	}
+
+	return &response, nil
+}
+
 func (s *FreedomPayService) GetPaymentStatus(paymentID string) (*domain.FreedomPayStatusResponse, error) {
 	salt := uuid.New().String()
 
@@ -111,6 +172,118 @@ func (s *FreedomPayService) generateSignatureByOrderID(data map[string]string, e
 	return fmt.Sprintf("%x", hash)
 }
 
+// GenerateParamsSignature формирует pg_sig по общему правилу FreedomPay:
+// 'script_name;значения параметров, отсортированных по имени;secret_key'
+func (s *FreedomPayService) GenerateParamsSignature(scriptName string, data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if key == "pg_sig" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys)+2)
+	parts = append(parts, scriptName)
+	for _, key := range keys {
+		parts = append(parts, data[key])
+	}
+	parts = append(parts, s.secretKey)
+
+	hash := md5.Sum([]byte(strings.Join(parts, ";")))
+	return fmt.Sprintf("%x", hash)
+}
+
+// verifyResponseSignature проверяет pg_sig ответа FreedomPay. Подпись считается по тем же правилам,
+// что и в запросе, от значений всех полей первого уровня XML ответа
+func (s *FreedomPayService) verifyResponseSignature(scriptName string, body []byte) error {
+	params, err := parseXMLResponseParams(body)
+	if err != nil {
+		return fmt.Errorf("ошибка парсинга XML ответа %s: %w", scriptName, err)
+	}
+
+	signature := params["pg_sig"]
+	if signature == "" {
+		return fmt.Errorf("ответ %s не содержит подписи pg_sig", scriptName)
+	}
+
+	expected := s.GenerateParamsSignature(scriptName, params)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return fmt.Errorf("неверная подпись ответа %s", scriptName)
+	}
+
+	return nil
+}
+
+// parseXMLResponseParams собирает значения полей первого уровня XML ответа FreedomPay
+func parseXMLResponseParams(body []byte) (map[string]string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	params := make(map[string]string)
+
+	depth := 0
+	var field string
+	var value strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 {
+				field = t.Name.Local
+				value.Reset()
+			}
+		case xml.CharData:
+			if depth == 2 {
+				value.Write(t)
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				params[field] = value.String()
+			}
+			depth--
+		}
+	}
+
+	return params, nil
+}
+
+func (s *FreedomPayService) postForm(endpoint string, data map[string]string) ([]byte, error) {
+	requestURL := fmt.Sprintf("%s/%s", s.apiURL, endpoint)
+
+	formData := url.Values{}
+	for key, value := range data {
+		formData.Set(key, value)
+	}
+
+	req, err := http.NewRequest("POST", requestURL, strings.NewReader(formData.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения ответа: %w", err)
+	}
+
+	return body, nil
+}
+
 func (s *FreedomPayService) makeRequest(endpoint string, data map[string]string) (*domain.FreedomPayStatusResponse, error) {
 	requestURL := fmt.Sprintf("%s/%s", s.apiURL, endpoint)
 
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/russo2642/renti_kz/internal/config"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+const smtpImplicitTLSPort = "465"
+
+// EmailNotificationSender отправляет уведомления письмами через SMTP. На порту 465 используется
+// неявный TLS, на остальных - STARTTLS, если сервер его поддерживает.
+type EmailNotificationSender struct {
+	config *config.SMTPConfig
+}
+
+func NewEmailNotificationSender(cfg *config.SMTPConfig) domain.NotificationSender {
+	return &EmailNotificationSender{
+		config: cfg,
+	}
+}
+
+func (s *EmailNotificationSender) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelEmail
+}
+
+func (s *EmailNotificationSender) Send(delivery *domain.NotificationDelivery) error {
+	if delivery.Recipient == "" {
+		return fmt.Errorf("не указан email получателя")
+	}
+
+	if _, err := mail.ParseAddress(delivery.Recipient); err != nil {
+		return fmt.Errorf("некорректный email получателя: %w", err)
+	}
+
+	message, err := s.buildMessage(delivery)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	if s.config.Port == smtpImplicitTLSPort {
+		err = s.sendWithImplicitTLS(auth, delivery.Recipient, message)
+	} else {
+		err = smtp.SendMail(s.config.Addr(), auth, s.config.From, []string{delivery.Recipient}, message)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка отправки email: %w", err)
+	}
+
+	logger.Info("email notification sent",
+		slog.Int("user_id", delivery.UserID),
+		slog.String("type", string(delivery.NotificationType)))
+
+	return nil
+}
+
+func (s *EmailNotificationSender) sendWithImplicitTLS(auth smtp.Auth, recipient string, message []byte) error {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", s.config.Addr(), &tls.Config{ServerName: s.config.Host})
+	if err != nil {
+		return fmt.Errorf("ошибка TLS-подключения к SMTP: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("ошибка создания SMTP клиента: %w", err)
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("ошибка авторизации SMTP: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(recipient); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMessage собирает письмо multipart/alternative с текстовой и HTML-версией.
+func (s *EmailNotificationSender) buildMessage(delivery *domain.NotificationDelivery) ([]byte, error) {
+	var buf bytes.Buffer
+
+	from := mail.Address{Name: s.config.FromName, Address: s.config.From}
+	writer := multipart.NewWriter(&buf)
+
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + delivery.Recipient,
+		"Subject: " + mime.BEncoding.Encode("UTF-8", delivery.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		fmt.Sprintf("Message-ID: <%s@%s>", uuid.NewString(), s.config.Host),
+		"MIME-Version: 1.0",
+		fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q", writer.Boundary()),
+	}
+	for _, header := range headers {
+		buf.WriteString(header + "\r\n")
+	}
+	buf.WriteString("\r\n")
+
+	if err := writeQuotedPrintablePart(writer, "text/plain; charset=UTF-8", delivery.Body); err != nil {
+		return nil, err
+	}
+
+	if delivery.HTMLBody != "" {
+		if err := writeQuotedPrintablePart(writer, "text/html; charset=UTF-8", delivery.HTMLBody); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("ошибка формирования письма: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintablePart(writer *multipart.Writer, contentType, content string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка формирования письма: %w", err)
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return fmt.Errorf("ошибка формирования письма: %w", err)
+	}
+
+	return qp.Close()
+}
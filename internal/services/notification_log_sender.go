@@ -0,0 +1,36 @@
+package services
+
+import (
+	"log/slog"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+// LogNotificationSender вместо отправки пишет уведомление в лог. Используется при локальной
+// разработке, чтобы не расходовать SMS и не слать реальные письма.
+type LogNotificationSender struct {
+	channel domain.NotificationChannel
+}
+
+func NewLogNotificationSender(channel domain.NotificationChannel) domain.NotificationSender {
+	return &LogNotificationSender{
+		channel: channel,
+	}
+}
+
+func (s *LogNotificationSender) Channel() domain.NotificationChannel {
+	return s.channel
+}
+
+func (s *LogNotificationSender) Send(delivery *domain.NotificationDelivery) error {
+	logger.Info("notification delivered to log sender",
+		slog.String("channel", string(s.channel)),
+		slog.Int("user_id", delivery.UserID),
+		slog.String("type", string(delivery.NotificationType)),
+		slog.String("recipient", delivery.Recipient),
+		slog.String("subject", delivery.Subject),
+		slog.String("body", delivery.Body))
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/russo2642/renti_kz/internal/config"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+// SMSNotificationSender отправляет уведомления по SMS через провайдера OTP-кодов (isms.center).
+type SMSNotificationSender struct {
+	config *config.OTPConfig
+	client *http.Client
+}
+
+func NewSMSNotificationSender(cfg *config.OTPConfig) domain.NotificationSender {
+	return &SMSNotificationSender{
+		config: cfg,
+		client: GetFastClient(),
+	}
+}
+
+type smsSendPayload struct {
+	To   string `json:"to"`
+	From string `json:"from"`
+	Text string `json:"text"`
+}
+
+func (s *SMSNotificationSender) Channel() domain.NotificationChannel {
+	return domain.NotificationChannelSMS
+}
+
+func (s *SMSNotificationSender) Send(delivery *domain.NotificationDelivery) error {
+	if delivery.Recipient == "" {
+		return fmt.Errorf("не указан номер телефона получателя")
+	}
+
+	body, err := json.Marshal(smsSendPayload{
+		To:   delivery.Recipient,
+		From: s.config.From,
+		Text: delivery.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка маршалинга SMS: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/sms", s.config.SMSAPIBase), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса SMS: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Token", s.config.Token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка отправки SMS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("SMS провайдер вернул HTTP %d: %s", resp.StatusCode, string(responseBody))
+	}
+
+	logger.Info("sms notification sent",
+		slog.Int("user_id", delivery.UserID),
+		slog.String("type", string(delivery.NotificationType)),
+		slog.String("phone", maskPhone(delivery.Recipient)))
+
+	return nil
+}
+
+func maskPhone(phone string) string {
+	if len(phone) <= 4 {
+		return "***"
+	}
+	return "***" + phone[len(phone)-4:]
+}
@@ -66,10 +66,12 @@ type MetricsData struct {
 	GCCycles            uint32 `json:"gc_cycles"`
 	Uptime              string `json:"uptime"`
 	LastUpdated         string `json:"last_updated"`
+
+	TuyaWebhooks TuyaWebhookMetricsData `json:"tuya_webhooks"`
 }
 
 // @Summary Получение метрик производительности
-// @Description Возвращает текущие метрики производительности сервера: количество запросов, среднее время ответа, использование памяти, количество горутин и другие показатели
+// @Description Возвращает текущие метрики производительности сервера: количество запросов, среднее время ответа, использование памяти, количество горутин, счетчики webhook Tuya (в том числе отклоненных при проверке) и другие показатели
 // @Tags monitoring
 // @Accept json
 // @Produce json
@@ -90,6 +92,7 @@ func MetricsHandler() gin.HandlerFunc {
 			GCCycles:            m.NumGC,
 			Uptime:              time.Since(globalMetrics.LastUpdated).String(),
 			LastUpdated:         globalMetrics.LastUpdated.Format(time.RFC3339),
+			TuyaWebhooks:        GetTuyaWebhookMetrics(),
 		}
 
 		response := MetricsResponse{
@@ -65,6 +65,10 @@ func (s *PushNotificationService) SendPush(userID int, message *domain.PushMessa
 		slog.Int("user_id", userID),
 		slog.Int("success_count", successCount),
 		slog.Int("error_count", errorCount))
+
+	if successCount == 0 && errorCount > 0 {
+		return fmt.Errorf("push не доставлен ни на одно устройство пользователя %d", userID)
+	}
 	return nil
 }
 
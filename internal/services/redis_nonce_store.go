@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+const nonceStoreTimeout = 2 * time.Second
+
+// RedisNonceStore хранит использованные nonce в Redis, поэтому повтор запроса отклоняется
+// на любом экземпляре API.
+type RedisNonceStore struct {
+	client *redis.Client
+}
+
+func NewRedisNonceStore(client *redis.Client) domain.NonceStore {
+	return &RedisNonceStore{client: client}
+}
+
+func (s *RedisNonceStore) Claim(key string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), nonceStoreTimeout)
+	defer cancel()
+
+	return s.client.SetNX(ctx, "nonce:"+key, 1, ttl).Result()
+}
+
+func (s *RedisNonceStore) Release(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), nonceStoreTimeout)
+	defer cancel()
+
+	return s.client.Del(ctx, "nonce:"+key).Err()
+}
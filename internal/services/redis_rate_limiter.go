@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+const (
+	rateLimiterTimeout = 2 * time.Second
+
+	// rateLimitSettingsTTL как часто перечитываются лимиты из настроек платформы
+	rateLimitSettingsTTL = time.Minute
+	// lockoutFailureWindow неудачные попытки старше окна не учитываются
+	lockoutFailureWindow = time.Hour
+	// lockoutMemory в течение этого времени блокировки считаются повторными и удлиняются
+	lockoutMemory = 24 * time.Hour
+)
+
+// tokenBucketScript атомарно пополняет корзину по прошедшему времени и забирает из нее токен.
+// Возвращает {разрешено, осталось токенов, через сколько мс появится токен}.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], ttl)
+
+return {allowed, math.floor(tokens), retry}
+`)
+
+// failureScript учитывает неудачную попытку. После threshold неудач подряд ставит блокировку,
+// длительность которой удваивается с каждой повторной блокировкой. Возвращает длительность в мс или 0.
+var failureScript = redis.NewScript(`
+local failures = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+if failures < tonumber(ARGV[1]) then
+	return 0
+end
+
+redis.call('DEL', KEYS[1])
+local lockouts = redis.call('INCR', KEYS[3])
+redis.call('PEXPIRE', KEYS[3], ARGV[5])
+
+local duration = math.min(tonumber(ARGV[2]) * 2 ^ (lockouts - 1), tonumber(ARGV[3]))
+duration = math.floor(duration)
+redis.call('SET', KEYS[2], 1, 'PX', duration)
+
+return duration
+`)
+
+type cachedRateLimit struct {
+	limit    domain.RateLimit
+	loadedAt time.Time
+}
+
+// RedisRateLimiter ограничивает частоту запросов корзиной токенов в Redis, поэтому лимит общий
+// для всех экземпляров API. Лимиты берутся из настроек платформы и перечитываются раз в минуту.
+type RedisRateLimiter struct {
+	client   *redis.Client
+	settings domain.PlatformSettingsUseCase
+
+	mu              sync.Mutex
+	limits          map[domain.RateLimitPolicy]cachedRateLimit
+	lockout         domain.LockoutPolicy
+	lockoutLoadedAt time.Time
+}
+
+func NewRedisRateLimiter(client *redis.Client, settings domain.PlatformSettingsUseCase) domain.RateLimiter {
+	return &RedisRateLimiter{
+		client:   client,
+		settings: settings,
+		limits:   make(map[domain.RateLimitPolicy]cachedRateLimit),
+	}
+}
+
+func (l *RedisRateLimiter) Allow(policy domain.RateLimitPolicy, key string) (*domain.RateLimitDecision, error) {
+	limit := l.limit(policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimiterTimeout)
+	defer cancel()
+
+	rate := float64(limit.Requests) / float64(limit.Period.Milliseconds())
+	result, err := tokenBucketScript.Run(ctx, l.client,
+		[]string{"ratelimit:" + string(policy) + ":" + key},
+		limit.Requests, rate, time.Now().UnixMilli(), limit.Period.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RateLimitDecision{
+		Allowed:    result[0] == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(result[1]),
+		RetryAfter: time.Duration(result[2]) * time.Millisecond,
+	}, nil
+}
+
+func (l *RedisRateLimiter) LockedFor(scope domain.LockoutScope, key string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimiterTimeout)
+	defer cancel()
+
+	ttl, err := l.client.PTTL(ctx, lockKey(scope, key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// PTTL возвращает отрицательное значение, если ключа нет
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (l *RedisRateLimiter) RegisterFailure(scope domain.LockoutScope, key string) (time.Duration, error) {
+	policy := l.lockoutPolicy()
+
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimiterTimeout)
+	defer cancel()
+
+	durationMs, err := failureScript.Run(ctx, l.client,
+		[]string{failuresKey(scope, key), lockKey(scope, key), lockoutsKey(scope, key)},
+		policy.Threshold, policy.BaseDuration.Milliseconds(), policy.MaxDuration.Milliseconds(),
+		lockoutFailureWindow.Milliseconds(), lockoutMemory.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	duration := time.Duration(durationMs) * time.Millisecond
+	if duration > 0 {
+		log.Printf("🔒 Блокировка %s для %s на %s после %d неудачных попыток", scope, key, duration, policy.Threshold)
+	}
+
+	return duration, nil
+}
+
+func (l *RedisRateLimiter) ResetFailures(scope domain.LockoutScope, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimiterTimeout)
+	defer cancel()
+
+	return l.client.Del(ctx, failuresKey(scope, key), lockoutsKey(scope, key)).Err()
+}
+
+func (l *RedisRateLimiter) limit(policy domain.RateLimitPolicy) domain.RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if cached, ok := l.limits[policy]; ok && time.Since(cached.loadedAt) < rateLimitSettingsTTL {
+		return cached.limit
+	}
+
+	limit, err := l.settings.GetRateLimit(policy)
+	if err != nil {
+		log.Printf("⚠️ Лимит %s: %v, используется %s", policy, err, limit)
+	}
+
+	l.limits[policy] = cachedRateLimit{limit: limit, loadedAt: time.Now()}
+	return limit
+}
+
+func (l *RedisRateLimiter) lockoutPolicy() domain.LockoutPolicy {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.lockoutLoadedAt.IsZero() && time.Since(l.lockoutLoadedAt) < rateLimitSettingsTTL {
+		return l.lockout
+	}
+
+	policy, err := l.settings.GetLockoutPolicy()
+	if err != nil {
+		log.Printf("⚠️ Настройки блокировки перебора: %v", err)
+	}
+
+	l.lockout = policy
+	l.lockoutLoadedAt = time.Now()
+	return policy
+}
+
+func failuresKey(scope domain.LockoutScope, key string) string {
+	return "lockout:failures:" + string(scope) + ":" + key
+}
+
+func lockKey(scope domain.LockoutScope, key string) string {
+	return "lockout:lock:" + string(scope) + ":" + key
+}
+
+func lockoutsKey(scope domain.LockoutScope, key string) string {
+	return "lockout:count:" + string(scope) + ":" + key
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+const calendarSyncInterval = 30 * time.Minute
+
+func calendarSyncTaskKey(at time.Time) string {
+	return fmt.Sprintf("%s_%s", TaskSyncCalendars, at.Truncate(calendarSyncInterval).Format("200601021504"))
+}
+
+// SetCalendarUseCase подключает импорт внешних iCal-календарей к планировщику.
+func (s *SchedulerService) SetCalendarUseCase(calendarUseCase domain.CalendarUseCase) {
+	s.calendarUseCase = calendarUseCase
+}
+
+func (s *SchedulerService) scheduleCalendarSyncTask(ctx context.Context, processedSet map[string]bool) {
+	if s.calendarUseCase == nil {
+		return
+	}
+
+	now := time.Now()
+
+	if now.Sub(now.Truncate(calendarSyncInterval)) >= time.Minute {
+		return
+	}
+
+	if processedSet[calendarSyncTaskKey(now)] {
+		return
+	}
+
+	task := ScheduledTask{
+		Type:        TaskSyncCalendars,
+		BookingID:   0,
+		ScheduledAt: now,
+	}
+	s.scheduleTask(ctx, task, now)
+	log.Printf("📅 Запланирован импорт внешних календарей на %s", now.Format("15:04:05"))
+}
+
+func (s *SchedulerService) executeSyncCalendars(_ context.Context, _ ScheduledTask) {
+	if s.calendarUseCase == nil {
+		log.Printf("⚠️ Импорт календарей пропущен: CalendarUseCase не настроен")
+		return
+	}
+
+	start := time.Now()
+	results := s.calendarUseCase.SyncAllExternalCalendars()
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == domain.ExternalCalendarSyncFailed {
+			failed++
+		}
+	}
+
+	log.Printf("📅 Импорт внешних календарей завершен за %v: всего %d, с ошибкой %d", time.Since(start), len(results), failed)
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+// SetCleanerUseCase подключает автоматические заявки на уборку после выезда гостя.
+func (s *SchedulerService) SetCleanerUseCase(cleanerUseCase domain.CleanerUseCase) {
+	s.cleanerUseCase = cleanerUseCase
+}
+
+// ScheduleCleaningDispatch ставит в очередь создание заявки на уборку после выезда. Заявка
+// создается планировщиком, чтобы не задерживать ответ на завершение бронирования.
+func (s *SchedulerService) ScheduleCleaningDispatch(bookingID int, checkoutAt time.Time) {
+	if s.cleanerUseCase == nil {
+		return
+	}
+
+	executeAt := time.Now()
+	task := ScheduledTask{
+		Type:        TaskDispatchCleaning,
+		BookingID:   bookingID,
+		ScheduledAt: executeAt,
+		Data: map[string]interface{}{
+			"booking_id":  bookingID,
+			"checkout_at": checkoutAt.Format(time.RFC3339),
+		},
+	}
+	s.scheduleTask(context.Background(), task, executeAt)
+}
+
+// ScheduleCleaningOfferTimeout проверяет, ответила ли уборщица на предложение attempt.
+// Задача не привязана к бронированию, чтобы ее не сняло удаление задач бронирования.
+func (s *SchedulerService) ScheduleCleaningOfferTimeout(taskID, attempt int, executeAt time.Time) {
+	task := ScheduledTask{
+		Type:        TaskCleaningOfferTimeout,
+		ScheduledAt: executeAt,
+		Data: map[string]interface{}{
+			"cleaning_task_id": taskID,
+			"attempt":          attempt,
+		},
+	}
+	s.scheduleTask(context.Background(), task, executeAt)
+}
+
+// ScheduleCleaningDeadline проверяет, завершена ли уборка к сроку.
+func (s *SchedulerService) ScheduleCleaningDeadline(taskID int, deadlineAt time.Time) {
+	task := ScheduledTask{
+		Type:        TaskCleaningDeadline,
+		ScheduledAt: deadlineAt,
+		Data: map[string]interface{}{
+			"cleaning_task_id": taskID,
+		},
+	}
+	s.scheduleTask(context.Background(), task, deadlineAt)
+}
+
+// cleaningTaskKey ключ выполненной задачи: у задач заявок на уборку нет бронирования.
+func cleaningTaskKey(task ScheduledTask) string {
+	taskID, _ := task.Data["cleaning_task_id"].(float64)
+	if task.Type == TaskCleaningOfferTimeout {
+		attempt, _ := task.Data["attempt"].(float64)
+		return fmt.Sprintf("%s_%d_%d", task.Type, int(taskID), int(attempt))
+	}
+	return fmt.Sprintf("%s_%d", task.Type, int(taskID))
+}
+
+func (s *SchedulerService) executeDispatchCleaning(_ context.Context, task ScheduledTask) {
+	if s.cleanerUseCase == nil {
+		log.Printf("⚠️ Заявка на уборку пропущена: CleanerUseCase не настроен")
+		return
+	}
+
+	checkoutAt := task.ScheduledAt
+	if value, ok := task.Data["checkout_at"].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			checkoutAt = parsed
+		}
+	}
+
+	if err := s.cleanerUseCase.DispatchCleaningForBooking(task.BookingID, checkoutAt); err != nil {
+		log.Printf("❌ Ошибка создания заявки на уборку после бронирования %d: %v", task.BookingID, err)
+	}
+}
+
+func (s *SchedulerService) executeCleaningOfferTimeout(_ context.Context, task ScheduledTask) {
+	if s.cleanerUseCase == nil {
+		return
+	}
+
+	taskID, _ := task.Data["cleaning_task_id"].(float64)
+	attempt, _ := task.Data["attempt"].(float64)
+
+	if err := s.cleanerUseCase.HandleCleaningOfferTimeout(int(taskID), int(attempt)); err != nil {
+		log.Printf("❌ Ошибка передачи заявки на уборку %d следующей уборщице: %v", int(taskID), err)
+	}
+}
+
+func (s *SchedulerService) executeCleaningDeadline(_ context.Context, task ScheduledTask) {
+	if s.cleanerUseCase == nil {
+		return
+	}
+
+	taskID, _ := task.Data["cleaning_task_id"].(float64)
+
+	if err := s.cleanerUseCase.CheckCleaningDeadline(int(taskID)); err != nil {
+		log.Printf("❌ Ошибка проверки срока уборки по заявке %d: %v", int(taskID), err)
+	}
+}
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+)
+
+const (
+	PaymentReconciliationSummaryKey = "scheduler:payment_reconciliation:last"
+
+	paymentReconciliationInterval = 10 * time.Minute
+	// Даем webhook FreedomPay время прийти раньше сверки
+	paymentReconciliationGrace = 5 * time.Minute
+	// Неоплаченная ссылка живет 30 минут, после этого платеж считается просроченным
+	paymentReconciliationExpireAfter = 45 * time.Minute
+	paymentReconciliationBatchSize   = 200
+)
+
+type PaymentReconciliationSummary struct {
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+	DurationMs      int64     `json:"duration_ms"`
+	PaymentsChecked int       `json:"payments_checked"`
+	BookingsChecked int       `json:"bookings_checked"`
+	Succeeded       int       `json:"succeeded"`
+	Failed          int       `json:"failed"`
+	Expired         int       `json:"expired"`
+	Unchanged       int       `json:"unchanged"`
+	Errors          int       `json:"errors"`
+	LastError       string    `json:"last_error,omitempty"`
+}
+
+func (s *PaymentReconciliationSummary) addError(err error) {
+	s.Errors++
+	s.LastError = err.Error()
+}
+
+func reconciliationTaskKey(at time.Time) string {
+	return fmt.Sprintf("%s_%s", TaskReconcilePayments, at.Truncate(paymentReconciliationInterval).Format("200601021504"))
+}
+
+func (s *SchedulerService) scheduleReconciliationTask(ctx context.Context, processedSet map[string]bool) {
+	now := time.Now()
+
+	if now.Sub(now.Truncate(paymentReconciliationInterval)) >= time.Minute {
+		return
+	}
+
+	if processedSet[reconciliationTaskKey(now)] {
+		return
+	}
+
+	task := ScheduledTask{
+		Type:        TaskReconcilePayments,
+		BookingID:   0,
+		ScheduledAt: now,
+	}
+	s.scheduleTask(ctx, task, now)
+	log.Printf("💳 Запланирована сверка платежей на %s", now.Format("15:04:05"))
+}
+
+func (s *SchedulerService) executeReconcilePayments(ctx context.Context, _ ScheduledTask) {
+	s.reconcilePayments(ctx)
+}
+
+// reconcilePayments сверяет зависшие платежи и неоплаченные бронирования с FreedomPay.
+func (s *SchedulerService) reconcilePayments(ctx context.Context) *PaymentReconciliationSummary {
+	summary := &PaymentReconciliationSummary{StartedAt: time.Now()}
+
+	if s.freedomPayService == nil || s.bookingUseCase == nil {
+		log.Printf("⚠️ Сверка платежей пропущена: FreedomPay или BookingUseCase не настроены")
+		return summary
+	}
+
+	log.Printf("💳 Начинаем сверку платежей с FreedomPay...")
+
+	now := utils.GetCurrentTimeUTC()
+
+	payments, err := s.paymentRepo.GetStaleByStatuses(
+		[]domain.PaymentStatus{domain.PaymentStatusPending, domain.PaymentStatusProcessing},
+		now.Add(-paymentReconciliationGrace),
+		paymentReconciliationBatchSize,
+	)
+	s.metrics.DatabaseQueriesCount++
+	if err != nil {
+		log.Printf("❌ Ошибка получения зависших платежей: %v", err)
+		summary.addError(fmt.Errorf("ошибка получения зависших платежей: %w", err))
+	}
+
+	for _, payment := range payments {
+		summary.PaymentsChecked++
+		s.reconcilePaymentRecord(payment, now, summary)
+	}
+
+	bookings, err := s.getBookingsBatch([]domain.BookingStatus{domain.BookingStatusAwaitingPayment}, paymentReconciliationBatchSize)
+	s.metrics.DatabaseQueriesCount++
+	if err != nil {
+		log.Printf("❌ Ошибка получения бронирований, ожидающих оплаты: %v", err)
+		summary.addError(fmt.Errorf("ошибка получения бронирований, ожидающих оплаты: %w", err))
+	}
+
+	for _, booking := range bookings {
+		if booking.CreatedAt.After(now.Add(-paymentReconciliationGrace)) || s.hasOpenBookingPayment(booking.ID) {
+			continue
+		}
+		summary.BookingsChecked++
+		s.reconcileAwaitingBooking(booking.ID, summary)
+	}
+
+	summary.FinishedAt = time.Now()
+	summary.DurationMs = summary.FinishedAt.Sub(summary.StartedAt).Milliseconds()
+
+	if summaryJSON, err := json.Marshal(summary); err == nil {
+		s.redisClient.Set(ctx, PaymentReconciliationSummaryKey, summaryJSON, 24*time.Hour)
+	}
+
+	if summary.Errors > 0 {
+		s.metrics.TotalErrors += int64(summary.Errors)
+	}
+
+	log.Printf("✅ Сверка платежей завершена за %dms (платежей: %d, бронирований: %d, успешных: %d, неуспешных: %d, просроченных: %d, ошибок: %d)",
+		summary.DurationMs, summary.PaymentsChecked, summary.BookingsChecked,
+		summary.Succeeded, summary.Failed, summary.Expired, summary.Errors)
+
+	return summary
+}
+
+func (s *SchedulerService) reconcilePaymentRecord(payment *domain.Payment, now time.Time, summary *PaymentReconciliationSummary) {
+	orderID := paymentOrderID(payment)
+
+	startTime := time.Now()
+	fpResponse, err := s.freedomPayService.GetPaymentStatusByOrderID(orderID)
+	processingDuration := int(time.Since(startTime).Milliseconds())
+
+	oldStatus := string(payment.Status)
+
+	if err != nil {
+		log.Printf("❌ Ошибка сверки платежа %d (order_id: %s): %v", payment.ID, orderID, err)
+		summary.addError(fmt.Errorf("платеж %d: %w", payment.ID, err))
+		s.logReconciliation(payment.BookingID, &payment.ID, payment.PaymentID, nil, &oldStatus, nil, processingDuration, err)
+		return
+	}
+
+	newStatus := resolveReconciledStatus(payment, fpResponse, now)
+
+	switch newStatus {
+	case domain.PaymentStatusSuccess:
+		if err := s.completeReconciledPayment(payment.BookingID, payment.ExtensionID, fpResponse.PaymentID); err != nil {
+			log.Printf("❌ Не удалось подтвердить оплаченный платеж %s для бронирования %d: %v", fpResponse.PaymentID, payment.BookingID, err)
+			summary.addError(fmt.Errorf("платеж %d: %w", payment.ID, err))
+			s.logReconciliation(payment.BookingID, &payment.ID, fpResponse.PaymentID, fpResponse, &oldStatus, nil, processingDuration, err)
+			return
+		}
+
+		// FreedomPay вернул другой платеж по тому же заказу - старая ссылка больше не нужна
+		if fpResponse.PaymentID != payment.PaymentID {
+			s.updateReconciledPayment(payment, domain.PaymentStatusCanceled, fpResponse)
+		}
+
+		summary.Succeeded++
+		log.Printf("💸 Платеж %s подтвержден сверкой (бронирование %d)", fpResponse.PaymentID, payment.BookingID)
+	case domain.PaymentStatusFailed, domain.PaymentStatusExpired:
+		if err := s.updateReconciledPayment(payment, newStatus, fpResponse); err != nil {
+			summary.addError(fmt.Errorf("платеж %d: %w", payment.ID, err))
+			s.logReconciliation(payment.BookingID, &payment.ID, payment.PaymentID, fpResponse, &oldStatus, nil, processingDuration, err)
+			return
+		}
+
+		if newStatus == domain.PaymentStatusFailed {
+			summary.Failed++
+		} else {
+			summary.Expired++
+		}
+	default:
+		summary.Unchanged++
+		return
+	}
+
+	resolvedStatus := string(newStatus)
+	s.logReconciliation(payment.BookingID, &payment.ID, fpResponse.PaymentID, fpResponse, &oldStatus, &resolvedStatus, processingDuration, nil)
+}
+
+// reconcileAwaitingBooking ищет в FreedomPay оплату бронирования, по которому нет платежа в базе.
+// Платежи, инициализированные сервером, сверяются по сохраненному order_id с суффиксом попытки,
+// поэтому здесь проверяется только order_id старого формата booking_<id>.
+// Возвращает ошибку, если статус оплаты узнать или применить не удалось.
+func (s *SchedulerService) reconcileAwaitingBooking(bookingID int, summary *PaymentReconciliationSummary) error {
+	orderID := fmt.Sprintf("%s%d", domain.BookingPaymentOrderPrefix, bookingID)
+
+	startTime := time.Now()
+	fpResponse, err := s.freedomPayService.GetPaymentStatusByOrderID(orderID)
+	processingDuration := int(time.Since(startTime).Milliseconds())
+
+	if err != nil {
+		log.Printf("❌ Ошибка сверки бронирования %d (order_id: %s): %v", bookingID, orderID, err)
+		summary.addError(fmt.Errorf("бронирование %d: %w", bookingID, err))
+		return err
+	}
+
+	if !providerPaymentExists(fpResponse) || fpResponse.PaymentStatus != "success" {
+		summary.Unchanged++
+		return nil
+	}
+
+	if err := s.completeReconciledPayment(int64(bookingID), nil, fpResponse.PaymentID); err != nil {
+		log.Printf("❌ Не удалось подтвердить оплату бронирования %d: %v", bookingID, err)
+		summary.addError(fmt.Errorf("бронирование %d: %w", bookingID, err))
+		s.logReconciliation(int64(bookingID), nil, fpResponse.PaymentID, fpResponse, nil, nil, processingDuration, err)
+		return err
+	}
+
+	summary.Succeeded++
+	log.Printf("💸 Оплата бронирования %d найдена сверкой (платеж %s)", bookingID, fpResponse.PaymentID)
+
+	newStatus := string(domain.PaymentStatusSuccess)
+	s.logReconciliation(int64(bookingID), nil, fpResponse.PaymentID, fpResponse, nil, &newStatus, processingDuration, nil)
+	return nil
+}
+
+// reconcileBeforeCleanup сверяет с FreedomPay неоплаченные бронирования, которые очистка собирается
+// удалить, и возвращает те, что можно удалять. Бронирование, которое сверить не удалось, остается
+// до следующей очистки: вместе с ним каскадом удалились бы и данные о возможной оплате.
+func (s *SchedulerService) reconcileBeforeCleanup(bookingIDs []int) []int {
+	if s.freedomPayService == nil || s.bookingUseCase == nil {
+		return bookingIDs
+	}
+
+	summary := &PaymentReconciliationSummary{StartedAt: time.Now()}
+	reconciled := make([]int, 0, len(bookingIDs))
+	for _, bookingID := range bookingIDs {
+		// Бронирование с незавершенным платежом сверяется по платежу, очистка его не удалит
+		if s.hasOpenBookingPayment(bookingID) {
+			continue
+		}
+
+		summary.BookingsChecked++
+		if err := s.reconcileAwaitingBooking(bookingID, summary); err != nil {
+			continue
+		}
+		reconciled = append(reconciled, bookingID)
+	}
+
+	if summary.BookingsChecked > 0 {
+		log.Printf("💳 Сверка перед очисткой: бронирований %d, оплачено %d, ошибок %d",
+			summary.BookingsChecked, summary.Succeeded, summary.Errors)
+	}
+
+	return reconciled
+}
+
+func (s *SchedulerService) completeReconciledPayment(bookingID int64, extensionID *int64, fpPaymentID string) error {
+	if extensionID != nil {
+		_, err := s.bookingUseCase.ProcessExtensionPayment(int(*extensionID), fpPaymentID)
+		return err
+	}
+
+	_, err := s.bookingUseCase.ProcessPayment(int(bookingID), fpPaymentID)
+	return err
+}
+
+func (s *SchedulerService) updateReconciledPayment(payment *domain.Payment, status domain.PaymentStatus, fpResponse *domain.FreedomPayStatusResponse) error {
+	payment.Status = status
+	if fpResponse.PaymentStatus != "" {
+		payment.ProviderStatus = &fpResponse.PaymentStatus
+	}
+	payment.ProviderResponse = fpResponse
+
+	if err := s.paymentRepo.Update(payment); err != nil {
+		log.Printf("❌ Ошибка обновления платежа %d: %v", payment.ID, err)
+		return fmt.Errorf("ошибка обновления платежа: %w", err)
+	}
+
+	log.Printf("💳 Платеж %d переведен в статус %s по результатам сверки", payment.ID, status)
+	return nil
+}
+
+func (s *SchedulerService) hasOpenBookingPayment(bookingID int) bool {
+	payments, err := s.paymentRepo.GetByBookingID(int64(bookingID))
+	if err != nil {
+		return false
+	}
+
+	for _, payment := range payments {
+		if payment.ExtensionID == nil &&
+			(payment.Status == domain.PaymentStatusPending || payment.Status == domain.PaymentStatusProcessing) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *SchedulerService) logReconciliation(bookingID int64, paymentDBID *int64, fpPaymentID string, fpResponse *domain.FreedomPayStatusResponse, oldStatus, newStatus *string, processingDuration int, err error) {
+	if s.paymentLogRepo == nil {
+		return
+	}
+
+	logEntry := &domain.PaymentLog{
+		PaymentID:          paymentDBID,
+		BookingID:          bookingID,
+		FPPaymentID:        fpPaymentID,
+		Action:             domain.PaymentLogActionCheckStatus,
+		OldStatus:          oldStatus,
+		NewStatus:          newStatus,
+		FPResponse:         fpResponse,
+		ProcessingDuration: &processingDuration,
+		Source:             domain.PaymentLogSourceScheduler,
+		Success:            err == nil,
+	}
+
+	if err != nil {
+		errorMessage := err.Error()
+		logEntry.ErrorMessage = &errorMessage
+	}
+
+	if logErr := s.paymentLogRepo.Create(logEntry); logErr != nil {
+		log.Printf("⚠️ Ошибка сохранения лога сверки платежа %s: %v", fpPaymentID, logErr)
+	}
+}
+
+// GetLastPaymentReconciliation возвращает итоги последней сверки платежей или nil, если сверка еще не выполнялась.
+func (s *SchedulerService) GetLastPaymentReconciliation(ctx context.Context) (*PaymentReconciliationSummary, error) {
+	summaryJSON, err := s.redisClient.Get(ctx, PaymentReconciliationSummaryKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения итогов сверки платежей: %w", err)
+	}
+
+	var summary PaymentReconciliationSummary
+	if err := json.Unmarshal([]byte(summaryJSON), &summary); err != nil {
+		return nil, fmt.Errorf("ошибка чтения итогов сверки платежей: %w", err)
+	}
+
+	return &summary, nil
+}
+
+func paymentOrderID(payment *domain.Payment) string {
+	if payment.OrderID != nil && *payment.OrderID != "" {
+		return *payment.OrderID
+	}
+
+	if payment.ExtensionID != nil {
+		return fmt.Sprintf("%s%d", domain.ExtensionPaymentOrderPrefix, *payment.ExtensionID)
+	}
+
+	return fmt.Sprintf("%s%d", domain.BookingPaymentOrderPrefix, payment.BookingID)
+}
+
+func providerPaymentExists(fpResponse *domain.FreedomPayStatusResponse) bool {
+	return fpResponse != nil && fpResponse.Status == "ok" && fpResponse.PaymentID != "" && fpResponse.PaymentID != "0"
+}
+
+func resolveReconciledStatus(payment *domain.Payment, fpResponse *domain.FreedomPayStatusResponse, now time.Time) domain.PaymentStatus {
+	expired := now.Sub(payment.CreatedAt) > paymentReconciliationExpireAfter
+
+	if !providerPaymentExists(fpResponse) {
+		if expired {
+			return domain.PaymentStatusExpired
+		}
+		return payment.Status
+	}
+
+	switch fpResponse.PaymentStatus {
+	case "success":
+		return domain.PaymentStatusSuccess
+	case "error", "failed", "revoked", "refunded", "withdrawn":
+		return domain.PaymentStatusFailed
+	case "incomplete":
+		return domain.PaymentStatusExpired
+	}
+
+	if expired {
+		return domain.PaymentStatusExpired
+	}
+	return payment.Status
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+// reviewReminderDelay пауза после выезда, чтобы напоминание об отзыве не приходило в момент сдачи ключей.
+const reviewReminderDelay = 3 * time.Hour
+
+// SetReviewUseCase подключает напоминания об отзывах после завершения бронирования.
+func (s *SchedulerService) SetReviewUseCase(reviewUseCase domain.ReviewUseCase) {
+	s.reviewUseCase = reviewUseCase
+}
+
+// ScheduleReviewReminder планирует напоминание арендатору и владельцу оставить отзыв после выезда.
+func (s *SchedulerService) ScheduleReviewReminder(bookingID int, checkoutAt time.Time) {
+	if s.reviewUseCase == nil {
+		return
+	}
+
+	executeAt := checkoutAt.Add(reviewReminderDelay)
+	if executeAt.Before(time.Now()) {
+		executeAt = time.Now().Add(reviewReminderDelay)
+	}
+
+	task := ScheduledTask{
+		Type:        TaskReviewReminder,
+		BookingID:   bookingID,
+		ScheduledAt: executeAt,
+		Data: map[string]interface{}{
+			"booking_id": bookingID,
+		},
+	}
+	s.scheduleTask(context.Background(), task, executeAt)
+}
+
+func (s *SchedulerService) executeReviewReminder(_ context.Context, task ScheduledTask) {
+	if s.reviewUseCase == nil {
+		log.Printf("⚠️ Напоминание об отзыве пропущено: ReviewUseCase не настроен")
+		return
+	}
+
+	if err := s.reviewUseCase.SendReviewReminders(task.BookingID); err != nil {
+		log.Printf("❌ Ошибка отправки напоминания об отзыве для бронирования %d: %v", task.BookingID, err)
+		return
+	}
+
+	log.Printf("⭐ Напоминание об отзыве для бронирования %d отправлено", task.BookingID)
+}
@@ -32,7 +32,13 @@ type SchedulerService struct {
 	chatUseCase         domain.ChatUseCase
 	chatRoomRepo        domain.ChatRoomRepository
 	paymentRepo         domain.PaymentRepository
+	paymentLogRepo      domain.PaymentLogRepository
 	paymentUseCase      domain.PaymentUseCase
+	freedomPayService   domain.FreedomPayService
+	bookingUseCase      domain.BookingUseCase
+	calendarUseCase     domain.CalendarUseCase
+	reviewUseCase       domain.ReviewUseCase
+	cleanerUseCase      domain.CleanerUseCase
 	config              config.RedisConfig
 	isRunning           bool
 	stopChan            chan struct{}
@@ -66,6 +72,13 @@ const (
 	TaskCloseChat         = "close_chat"
 	TaskCleanupBookings   = "cleanup_expired_bookings"
 	TaskCleanupExtensions = "cleanup_expired_extensions"
+	TaskReconcilePayments = "reconcile_payments"
+	TaskSyncCalendars     = "sync_external_calendars"
+	TaskReviewReminder    = "review_reminder"
+
+	TaskDispatchCleaning     = "dispatch_cleaning"
+	TaskCleaningOfferTimeout = "cleaning_offer_timeout"
+	TaskCleaningDeadline     = "cleaning_deadline"
 
 	SchedulerLockKey     = "scheduler:lock"
 	SchedulerInstanceKey = "scheduler:instance"
@@ -87,7 +100,9 @@ func NewSchedulerService(
 	chatUseCase domain.ChatUseCase,
 	chatRoomRepo domain.ChatRoomRepository,
 	paymentRepo domain.PaymentRepository,
+	paymentLogRepo domain.PaymentLogRepository,
 	paymentUseCase domain.PaymentUseCase,
+	freedomPayService domain.FreedomPayService,
 ) *SchedulerService {
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     redisConfig.Addr(),
@@ -109,7 +124,9 @@ func NewSchedulerService(
 		chatUseCase:         chatUseCase,
 		chatRoomRepo:        chatRoomRepo,
 		paymentRepo:         paymentRepo,
+		paymentLogRepo:      paymentLogRepo,
 		paymentUseCase:      paymentUseCase,
+		freedomPayService:   freedomPayService,
 		config:              redisConfig,
 		stopChan:            make(chan struct{}),
 		workerPool:          make(chan struct{}, 50),
@@ -117,6 +134,11 @@ func NewSchedulerService(
 	}
 }
 
+// SetBookingUseCase задается после создания BookingUseCase, который сам зависит от scheduler.
+func (s *SchedulerService) SetBookingUseCase(bookingUseCase domain.BookingUseCase) {
+	s.bookingUseCase = bookingUseCase
+}
+
 func (s *SchedulerService) StartScheduler() {
 	if s.isRunning {
 		log.Println("⚠️ Scheduler уже запущен")
@@ -276,6 +298,10 @@ func (s *SchedulerService) scheduleNewTasks(ctx context.Context) {
 
 	s.scheduleCleanupTasks(ctx, processedSet)
 
+	s.scheduleReconciliationTask(ctx, processedSet)
+
+	s.scheduleCalendarSyncTask(ctx, processedSet)
+
 	log.Printf("📊 Планирование задач завершено за %v (approved: %d, active: %d)",
 		time.Since(startTime), len(approvedBookings), len(activeBookings))
 }
@@ -397,7 +423,7 @@ func (s *SchedulerService) scheduleReminderTasks(ctx context.Context, booking *d
 
 	duration := booking.EndDate.Sub(booking.StartDate)
 
-	if duration.Hours() >= 24 {
+	if booking.Nights > 0 || duration.Hours() >= 24 {
 		endReminderTime := booking.EndDate.Add(-2 * time.Hour)
 		if endReminderTime.After(now) {
 			task := ScheduledTask{
@@ -574,6 +600,12 @@ func (s *SchedulerService) executeTask(ctx context.Context, task ScheduledTask)
 
 	if task.Type == TaskCleanupBookings || task.Type == TaskCleanupExtensions {
 		taskKey = fmt.Sprintf("%s_%s", task.Type, task.ScheduledAt.Format("2006010215"))
+	} else if task.Type == TaskReconcilePayments {
+		taskKey = reconciliationTaskKey(task.ScheduledAt)
+	} else if task.Type == TaskSyncCalendars {
+		taskKey = calendarSyncTaskKey(task.ScheduledAt)
+	} else if task.Type == TaskCleaningOfferTimeout || task.Type == TaskCleaningDeadline {
+		taskKey = cleaningTaskKey(task)
 	} else {
 		taskKey = fmt.Sprintf("%s_%d", task.Type, task.BookingID)
 	}
@@ -584,8 +616,8 @@ func (s *SchedulerService) executeTask(ctx context.Context, task ScheduledTask)
 		return
 	}
 
-	if task.Type == TaskCleanupBookings || task.Type == TaskCleanupExtensions {
-		log.Printf("⚡ Выполняем задачу очистки: %s", task.Type)
+	if task.Type == TaskCleanupBookings || task.Type == TaskCleanupExtensions || task.Type == TaskReconcilePayments || task.Type == TaskSyncCalendars {
+		log.Printf("⚡ Выполняем служебную задачу: %s", task.Type)
 	} else {
 		log.Printf("⚡ Выполняем задачу: %s для бронирования %d", task.Type, task.BookingID)
 	}
@@ -605,6 +637,18 @@ func (s *SchedulerService) executeTask(ctx context.Context, task ScheduledTask)
 		s.executeCleanupBookings(ctx, task)
 	case TaskCleanupExtensions:
 		s.executeCleanupExtensions(ctx, task)
+	case TaskReconcilePayments:
+		s.executeReconcilePayments(ctx, task)
+	case TaskSyncCalendars:
+		s.executeSyncCalendars(ctx, task)
+	case TaskReviewReminder:
+		s.executeReviewReminder(ctx, task)
+	case TaskDispatchCleaning:
+		s.executeDispatchCleaning(ctx, task)
+	case TaskCleaningOfferTimeout:
+		s.executeCleaningOfferTimeout(ctx, task)
+	case TaskCleaningDeadline:
+		s.executeCleaningDeadline(ctx, task)
 	default:
 		log.Printf("⚠️ Неизвестный тип задачи: %s", task.Type)
 		return
@@ -769,6 +813,9 @@ func (s *SchedulerService) executeCompleteBooking(_ context.Context, task Schedu
 		}
 	}
 
+	s.ScheduleReviewReminder(booking.ID, booking.EndDate)
+	s.ScheduleCleaningDispatch(booking.ID, booking.EndDate)
+
 	log.Printf("✅ Бронирование %d успешно завершено", task.BookingID)
 }
 
@@ -1043,7 +1090,7 @@ func (s *SchedulerService) scheduleReminderTasksOptimized(ctx context.Context, b
 	duration := booking.EndDate.Sub(booking.StartDate)
 	var endReminderTime time.Time
 
-	if duration.Hours() >= 24 {
+	if booking.Nights > 0 || duration.Hours() >= 24 {
 		endReminderTime = booking.EndDate.Add(-2 * time.Hour)
 	} else if duration.Hours() >= 10 {
 		endReminderTime = booking.EndDate.Add(-time.Hour)
@@ -1193,7 +1240,15 @@ func (s *SchedulerService) executeCleanupBookings(_ context.Context, task Schedu
 
 	log.Printf("🧹 Начинаем очистку просроченных бронирований (batch size: %d)...", int(batchSize))
 
-	deletedCount, err := s.bookingRepo.CleanupExpiredBookings(int(batchSize))
+	// Перед удалением проверяем, не прошла ли оплата, о которой не дошел webhook
+	awaitingIDs, err := s.bookingRepo.GetExpiredAwaitingPaymentBookingIDs(int(batchSize))
+	if err != nil {
+		log.Printf("❌ Ошибка получения неоплаченных бронирований для очистки: %v", err)
+		return
+	}
+	reconciledIDs := s.reconcileBeforeCleanup(awaitingIDs)
+
+	deletedCount, err := s.bookingRepo.CleanupExpiredBookings(int(batchSize), reconciledIDs)
 	if err != nil {
 		log.Printf("❌ Ошибка очистки просроченных бронирований: %v", err)
 		return
@@ -1422,5 +1477,8 @@ func (s *SchedulerService) executeCompleteBookingFallback(bookingID int) {
 		}
 	}
 
+	s.ScheduleReviewReminder(bookingID, booking.EndDate)
+	s.ScheduleCleaningDispatch(bookingID, booking.EndDate)
+
 	log.Printf("✅ Аварийное завершение бронирования %d выполнено", bookingID)
 }
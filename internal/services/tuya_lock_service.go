@@ -3,8 +3,10 @@ package services
 import (
 	"bytes"
 	"crypto/aes"
+	"crypto/cipher"
 	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -15,10 +17,16 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
 )
 
 const (
 	EmptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// tuyaEncryptModelGCM сообщения с этим encryptModel зашифрованы AES-GCM, остальные - AES-ECB
+	tuyaEncryptModelGCM = "aes_gcm"
 )
 
 type TuyaConfig struct {
@@ -455,3 +463,69 @@ func (t *TuyaLockService) DeleteTempPassword(deviceID string, passwordID int64)
 
 	return nil
 }
+
+// OpenWebhookMessage проверяет подпись сообщения webhook и расшифровывает событие.
+// Подпись - hex(HMAC-SHA256(ClientSecret, "data=<data>||pv=<pv>||t=<t>")), регистр не важен.
+// Данные шифруются ключом из символов 8-24 ClientSecret: AES-GCM (nonce в первых 12 байтах)
+// при encryptModel=aes_gcm, иначе AES-ECB с PKCS7.
+func (t *TuyaLockService) OpenWebhookMessage(message *domain.TuyaWebhookMessage) (*domain.TuyaWebhookEvent, error) {
+	if message.Data == "" || message.Sign == "" || message.T == 0 {
+		return nil, apperrors.TuyaWebhookMalformed.New(nil)
+	}
+
+	payload := fmt.Sprintf("data=%s||pv=%s||t=%d", message.Data, message.PV, message.T)
+	expected := t.hmacSHA256Hex(t.config.ClientSecret, payload)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToUpper(message.Sign))) {
+		return nil, apperrors.TuyaWebhookSignatureInvalid.New(nil)
+	}
+
+	plaintext, err := t.decryptWebhookData(message.Data, message.EncryptModel)
+	if err != nil {
+		return nil, apperrors.TuyaWebhookDecryptFailed.Wrap(err, nil)
+	}
+
+	var event domain.TuyaWebhookEvent
+	if err := json.Unmarshal(plaintext, &event); err != nil {
+		return nil, apperrors.TuyaWebhookDecryptFailed.Wrap(err, nil)
+	}
+
+	return &event, nil
+}
+
+func (t *TuyaLockService) decryptWebhookData(data, encryptModel string) ([]byte, error) {
+	if len(t.config.ClientSecret) < 24 {
+		return nil, fmt.Errorf("ClientSecret короче 24 символов")
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка декодирования base64: %w", err)
+	}
+
+	block, err := aes.NewCipher([]byte(t.config.ClientSecret[8:24]))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания AES cipher: %w", err)
+	}
+
+	if encryptModel == tuyaEncryptModelGCM {
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания AES-GCM: %w", err)
+		}
+		if len(encrypted) < aead.NonceSize() {
+			return nil, fmt.Errorf("зашифрованные данные короче nonce")
+		}
+		return aead.Open(nil, encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():], nil)
+	}
+
+	if len(encrypted) == 0 || len(encrypted)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("зашифрованные данные не кратны размеру блока")
+	}
+
+	decrypted := make([]byte, len(encrypted))
+	for i := 0; i < len(encrypted); i += aes.BlockSize {
+		block.Decrypt(decrypted[i:i+aes.BlockSize], encrypted[i:i+aes.BlockSize])
+	}
+
+	return t.removePKCS7Padding(decrypted), nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+)
+
+const testTuyaClientSecret = "0123456789abcdefghijklmnopqrstuv"
+
+var testTuyaEvent = domain.TuyaWebhookEvent{
+	BizCode: "online",
+	DevID:   "lock-1",
+	Ts:      1760000000000,
+	UUID:    "event-1",
+}
+
+// sealTestTuyaMessage шифрует и подписывает событие так же, как это делает Tuya
+func sealTestTuyaMessage(t *testing.T, event domain.TuyaWebhookEvent, encryptModel string, sentAt time.Time) *domain.TuyaWebhookMessage {
+	t.Helper()
+
+	plaintext, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal event: %v", err)
+	}
+
+	block, err := aes.NewCipher([]byte(testTuyaClientSecret[8:24]))
+	if err != nil {
+		t.Fatalf("create cipher: %v", err)
+	}
+
+	var encrypted []byte
+	if encryptModel == tuyaEncryptModelGCM {
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatalf("create gcm: %v", err)
+		}
+		nonce := bytes.Repeat([]byte{7}, aead.NonceSize())
+		encrypted = aead.Seal(nonce, nonce, plaintext, nil)
+	} else {
+		padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+		padded := append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+		encrypted = make([]byte, len(padded))
+		for i := 0; i < len(padded); i += aes.BlockSize {
+			block.Encrypt(encrypted[i:i+aes.BlockSize], padded[i:i+aes.BlockSize])
+		}
+	}
+
+	message := &domain.TuyaWebhookMessage{
+		Protocol:     4,
+		PV:           "2.0",
+		T:            sentAt.UnixMilli(),
+		Data:         base64.StdEncoding.EncodeToString(encrypted),
+		EncryptModel: encryptModel,
+	}
+	service := NewTuyaLockService(TuyaConfig{ClientSecret: testTuyaClientSecret})
+	message.Sign = service.hmacSHA256Hex(testTuyaClientSecret, fmt.Sprintf("data=%s||pv=%s||t=%d", message.Data, message.PV, message.T))
+
+	return message
+}
+
+func TestOpenWebhookMessageDecryptsBothModes(t *testing.T) {
+	service := NewTuyaLockService(TuyaConfig{ClientSecret: testTuyaClientSecret})
+
+	for _, encryptModel := range []string{"", tuyaEncryptModelGCM} {
+		message := sealTestTuyaMessage(t, testTuyaEvent, encryptModel, time.Now())
+		// Tuya может прислать подпись в нижнем регистре
+		message.Sign = strings.ToLower(message.Sign)
+
+		event, err := service.OpenWebhookMessage(message)
+		if err != nil {
+			t.Fatalf("encryptModel %q: unexpected error: %v", encryptModel, err)
+		}
+		if event.UUID != testTuyaEvent.UUID || event.DevID != testTuyaEvent.DevID || event.BizCode != testTuyaEvent.BizCode {
+			t.Fatalf("encryptModel %q: unexpected event %+v", encryptModel, event)
+		}
+	}
+}
+
+func TestOpenWebhookMessageRejectsTamperedMessage(t *testing.T) {
+	service := NewTuyaLockService(TuyaConfig{ClientSecret: testTuyaClientSecret})
+
+	tampered := sealTestTuyaMessage(t, testTuyaEvent, tuyaEncryptModelGCM, time.Now())
+	other := sealTestTuyaMessage(t, domain.TuyaWebhookEvent{BizCode: "offline", DevID: "lock-2"}, tuyaEncryptModelGCM, time.Now())
+	tampered.Data = other.Data
+
+	shifted := sealTestTuyaMessage(t, testTuyaEvent, "", time.Now())
+	shifted.T += int64(time.Hour / time.Millisecond)
+
+	for name, message := range map[string]*domain.TuyaWebhookMessage{"data": tampered, "t": shifted} {
+		if _, err := service.OpenWebhookMessage(message); !apperrors.TuyaWebhookSignatureInvalid.Is(err) {
+			t.Fatalf("tampered %s: expected invalid signature, got %v", name, err)
+		}
+	}
+}
+
+func TestOpenWebhookMessageRejectsWrongEncryptModel(t *testing.T) {
+	service := NewTuyaLockService(TuyaConfig{ClientSecret: testTuyaClientSecret})
+
+	message := sealTestTuyaMessage(t, testTuyaEvent, "", time.Now())
+	message.EncryptModel = tuyaEncryptModelGCM
+
+	if _, err := service.OpenWebhookMessage(message); !apperrors.TuyaWebhookDecryptFailed.Is(err) {
+		t.Fatalf("expected decrypt failure, got %v", err)
+	}
+}
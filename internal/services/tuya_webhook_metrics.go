@@ -0,0 +1,72 @@
+package services
+
+import "sync"
+
+// tuyaWebhookMetrics счетчики приема webhook Tuya с момента запуска, отдаются в /metrics.
+type tuyaWebhookMetrics struct {
+	mu                   sync.Mutex
+	received             uint64
+	processed            uint64
+	duplicates           uint64
+	failed               uint64
+	verificationFailures map[string]uint64
+}
+
+type TuyaWebhookMetricsData struct {
+	Received             uint64            `json:"received"`
+	Processed            uint64            `json:"processed"`
+	Duplicates           uint64            `json:"duplicates"`
+	Failed               uint64            `json:"failed"`
+	VerificationFailures uint64            `json:"verification_failures"`
+	FailuresByReason     map[string]uint64 `json:"verification_failures_by_reason"`
+}
+
+var tuyaWebhookStats = &tuyaWebhookMetrics{verificationFailures: make(map[string]uint64)}
+
+func RecordTuyaWebhookReceived() {
+	tuyaWebhookStats.mu.Lock()
+	defer tuyaWebhookStats.mu.Unlock()
+	tuyaWebhookStats.received++
+}
+
+func RecordTuyaWebhookProcessed(duplicate bool) {
+	tuyaWebhookStats.mu.Lock()
+	defer tuyaWebhookStats.mu.Unlock()
+	if duplicate {
+		tuyaWebhookStats.duplicates++
+	} else {
+		tuyaWebhookStats.processed++
+	}
+}
+
+func RecordTuyaWebhookFailed() {
+	tuyaWebhookStats.mu.Lock()
+	defer tuyaWebhookStats.mu.Unlock()
+	tuyaWebhookStats.failed++
+}
+
+// RecordTuyaWebhookVerificationFailure учитывает отклоненное сообщение; reason - код ошибки каталога.
+func RecordTuyaWebhookVerificationFailure(reason string) {
+	tuyaWebhookStats.mu.Lock()
+	defer tuyaWebhookStats.mu.Unlock()
+	tuyaWebhookStats.verificationFailures[reason]++
+}
+
+func GetTuyaWebhookMetrics() TuyaWebhookMetricsData {
+	tuyaWebhookStats.mu.Lock()
+	defer tuyaWebhookStats.mu.Unlock()
+
+	data := TuyaWebhookMetricsData{
+		Received:         tuyaWebhookStats.received,
+		Processed:        tuyaWebhookStats.processed,
+		Duplicates:       tuyaWebhookStats.duplicates,
+		Failed:           tuyaWebhookStats.failed,
+		FailuresByReason: make(map[string]uint64, len(tuyaWebhookStats.verificationFailures)),
+	}
+	for reason, count := range tuyaWebhookStats.verificationFailures {
+		data.FailuresByReason[reason] = count
+		data.VerificationFailures += count
+	}
+
+	return data
+}
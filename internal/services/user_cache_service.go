@@ -20,6 +20,13 @@ type UserCacheService struct {
 	tokenKeyPrefix string
 }
 
+const (
+	// Метки отозванных сессий: access-токены отозванной сессии отклоняются до истечения срока их действия
+	revokedSessionKeyPrefix = "session:revoked:"
+	// Refresh-токены, выданные до появления сессий, обмениваются на сессию один раз
+	legacyRefreshKeyPrefix = "session:legacy_refresh:"
+)
+
 type CachedUser struct {
 	ID       int             `json:"id"`
 	Role     domain.UserRole `json:"role"`
@@ -169,6 +176,50 @@ func (s *UserCacheService) InvalidateAllTokens() error {
 	return iter.Err()
 }
 
+// MarkSessionsRevoked помечает сессии отозванными на ttl (срок жизни access-токена).
+func (s *UserCacheService) MarkSessionsRevoked(sessionIDs []string, ttl time.Duration) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	pipe := s.client.Pipeline()
+	for _, sessionID := range sessionIDs {
+		pipe.Set(ctx, revokedSessionKeyPrefix+sessionID, 1, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("ошибка сохранения отзыва сессий: %w", err)
+	}
+
+	return nil
+}
+
+func (s *UserCacheService) IsSessionRevoked(sessionID string) (bool, error) {
+	ctx := context.Background()
+
+	count, err := s.client.Exists(ctx, revokedSessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки отзыва сессии: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// ClaimLegacyRefreshToken отмечает refresh-токен без сессии использованным. Возвращает false,
+// если токен уже был обменян.
+func (s *UserCacheService) ClaimLegacyRefreshToken(token string, ttl time.Duration) (bool, error) {
+	ctx := context.Background()
+	hash := sha256.Sum256([]byte(token))
+
+	claimed, err := s.client.SetNX(ctx, fmt.Sprintf("%s%x", legacyRefreshKeyPrefix, hash), 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("ошибка проверки refresh-токена: %w", err)
+	}
+
+	return claimed, nil
+}
+
 func (s *UserCacheService) getUserKey(userID int) string {
 	return fmt.Sprintf("%s%d", s.userKeyPrefix, userID)
 }
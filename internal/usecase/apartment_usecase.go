@@ -671,6 +671,27 @@ func (uc *ApartmentUseCase) UpdateApartmentType(apartmentID int, apartmentTypeID
 	return nil
 }
 
+func (uc *ApartmentUseCase) UpdateStaySettings(apartmentID int, request *domain.UpdateApartmentStaySettingsRequest) (*domain.Apartment, error) {
+	if _, err := time.Parse(utils.StayTimeLayout, request.CheckInTime); err != nil {
+		return nil, fmt.Errorf("неверный формат времени заезда: %s (ожидается 15:04)", request.CheckInTime)
+	}
+	if _, err := time.Parse(utils.StayTimeLayout, request.CheckOutTime); err != nil {
+		return nil, fmt.Errorf("неверный формат времени выезда: %s (ожидается 15:04)", request.CheckOutTime)
+	}
+	if request.MaxNights < request.MinNights {
+		return nil, fmt.Errorf("максимальное количество ночей не может быть меньше минимального")
+	}
+	if request.MaxNights > utils.MaxStayNights {
+		return nil, fmt.Errorf("максимальное количество ночей не может превышать %d", utils.MaxStayNights)
+	}
+
+	if err := uc.apartmentRepo.UpdateStaySettings(apartmentID, request); err != nil {
+		return nil, err
+	}
+
+	return uc.apartmentRepo.GetByID(apartmentID)
+}
+
 func (uc *ApartmentUseCase) GetAllHouseRules() ([]*domain.HouseRules, error) {
 	return uc.apartmentRepo.GetAllHouseRules()
 }
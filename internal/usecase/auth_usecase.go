@@ -1,17 +1,27 @@
 package usecase
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/services"
 	"github.com/russo2642/renti_kz/pkg/auth"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/logger"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthUseCase struct {
 	userRepo         domain.UserRepository
+	authRepo         domain.AuthRepository
 	tokenManager     auth.TokenManager
 	passwordSalt     string
 	userCacheService *services.UserCacheService
@@ -19,25 +29,27 @@ type AuthUseCase struct {
 
 func NewAuthUseCase(
 	userRepo domain.UserRepository,
+	authRepo domain.AuthRepository,
 	tokenManager auth.TokenManager,
 	passwordSalt string,
 	userCacheService *services.UserCacheService,
 ) *AuthUseCase {
 	return &AuthUseCase{
 		userRepo:         userRepo,
+		authRepo:         authRepo,
 		tokenManager:     tokenManager,
 		passwordSalt:     passwordSalt,
 		userCacheService: userCacheService,
 	}
 }
 
-func (uc *AuthUseCase) SignIn(phone, password string) (*domain.Tokens, *domain.User, error) {
+func (uc *AuthUseCase) SignIn(phone, password string, client domain.SessionClient) (*domain.Tokens, *domain.User, error) {
 	user, err := uc.userRepo.GetByPhone(phone)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get user by phone: %w", err)
 	}
 	if user == nil {
-		return nil, nil, errors.New("user not found")
+		return nil, nil, apperrors.AuthInvalidCredentials.New(nil)
 	}
 
 	// _, err = uc.hashPassword(password)
@@ -46,56 +58,160 @@ func (uc *AuthUseCase) SignIn(phone, password string) (*domain.Tokens, *domain.U
 	// }
 
 	if err := uc.comparePasswords(user.PasswordHash, password); err != nil {
-		return nil, nil, errors.New("invalid credentials")
+		return nil, nil, apperrors.AuthInvalidCredentials.New(nil)
 	}
 
-	accessToken, refreshToken, err := uc.tokenManager.GenerateTokenPair(user.ID, string(user.Role))
+	tokens, err := uc.IssueTokens(user, client)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to generate tokens: %w", err)
+		return nil, nil, err
 	}
 
-	tokens := &domain.Tokens{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
+	return tokens, user, nil
+}
+
+func (uc *AuthUseCase) IssueTokens(user *domain.User, client domain.SessionClient) (*domain.Tokens, error) {
+	sessionID := uuid.NewString()
+
+	accessToken, refreshToken, err := uc.tokenManager.GenerateTokenPair(user.ID, string(user.Role), sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
-	return tokens, user, nil
+	session := &domain.Session{
+		ID:               sessionID,
+		UserID:           user.ID,
+		RefreshTokenHash: hashToken(refreshToken),
+		UserAgent:        client.UserAgent,
+		IPAddress:        client.IPAddress,
+		ExpiresAt:        time.Now().Add(uc.tokenManager.GetRefreshTokenTTL()),
+	}
+	if err := uc.authRepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	return &domain.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
-func (uc *AuthUseCase) RefreshTokens(refreshToken string) (*domain.Tokens, error) {
+// RefreshTokens ротирует refresh-токен сессии. Предъявление токена, который уже был заменен,
+// означает его утечку: сессия отзывается целиком вместе с выданными по ней access-токенами.
+func (uc *AuthUseCase) RefreshTokens(refreshToken string, client domain.SessionClient) (*domain.Tokens, error) {
 	claims, err := uc.tokenManager.ParseRefreshToken(refreshToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid refresh token: %w", err)
+		return nil, apperrors.AuthRefreshTokenInvalid.Wrap(err, nil)
 	}
 
-	userID := claims.UserID
+	if claims.SessionID == "" {
+		return uc.exchangeLegacyRefreshToken(refreshToken, claims, client)
+	}
 
-	user, err := uc.userRepo.GetByID(userID)
+	session, err := uc.authRepo.GetSessionByID(claims.SessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, err
 	}
-	if user == nil {
-		return nil, errors.New("user not found")
+	if session == nil || session.UserID != claims.UserID || !session.IsActive() {
+		return nil, apperrors.AuthRefreshTokenInvalid.New(nil)
 	}
 
-	if uc.userCacheService != nil {
-		_ = uc.userCacheService.InvalidateUser(userID)
+	tokenHash := hashToken(refreshToken)
+	if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(session.RefreshTokenHash)) != 1 {
+		return nil, uc.revokeReusedSession(session, client)
 	}
 
-	accessToken, newRefreshToken, err := uc.tokenManager.GenerateTokenPair(user.ID, string(user.Role))
+	user, err := uc.userRepo.GetByID(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		return nil, apperrors.AuthRefreshTokenInvalid.New(nil)
+	}
+
+	accessToken, newRefreshToken, err := uc.tokenManager.GenerateTokenPair(user.ID, string(user.Role), session.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
 
-	tokens := &domain.Tokens{
+	expiresAt := time.Now().Add(uc.tokenManager.GetRefreshTokenTTL())
+	rotated, err := uc.authRepo.RotateSession(session.ID, session.RefreshTokenHash, hashToken(newRefreshToken), client, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Тот же токен только что был обменян другим запросом
+		return nil, uc.revokeReusedSession(session, client)
+	}
+
+	if uc.userCacheService != nil {
+		_ = uc.userCacheService.InvalidateUser(user.ID)
+	}
+
+	return &domain.Tokens{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
+	}, nil
+}
+
+// exchangeLegacyRefreshToken обменивает refresh-токен, выданный до появления серверных сессий,
+// на новую сессию. Каждый такой токен принимается только один раз.
+func (uc *AuthUseCase) exchangeLegacyRefreshToken(refreshToken string, claims *auth.TokenClaims, client domain.SessionClient) (*domain.Tokens, error) {
+	if uc.userCacheService == nil {
+		return nil, apperrors.AuthRefreshTokenInvalid.New(nil)
 	}
 
-	return tokens, nil
+	claimed, err := uc.userCacheService.ClaimLegacyRefreshToken(refreshToken, uc.tokenManager.GetRefreshTokenTTL())
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, apperrors.AuthRefreshTokenReused.New(nil)
+	}
+
+	user, err := uc.userRepo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		return nil, apperrors.AuthRefreshTokenInvalid.New(nil)
+	}
+
+	return uc.IssueTokens(user, client)
+}
+
+func (uc *AuthUseCase) revokeReusedSession(session *domain.Session, client domain.SessionClient) error {
+	logger.Warn("refresh token reuse detected, revoking session",
+		slog.String("session_id", session.ID),
+		slog.Int("user_id", session.UserID),
+		slog.String("ip", client.IPAddress))
+
+	revoked, err := uc.authRepo.RevokeSession(session.ID, domain.SessionRevokeReasonTokenReuse)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		uc.markSessionsRevoked([]string{session.ID})
+	}
+
+	return apperrors.AuthRefreshTokenReused.New(nil)
 }
 
 func (uc *AuthUseCase) SignOut(accessToken string) error {
+	claims, err := uc.tokenManager.ParseAccessToken(accessToken)
+	if err != nil {
+		return fmt.Errorf("invalid access token: %w", err)
+	}
+
+	if claims.SessionID != "" {
+		revoked, err := uc.authRepo.RevokeSession(claims.SessionID, domain.SessionRevokeReasonLogout)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			uc.markSessionsRevoked([]string{claims.SessionID})
+		}
+	}
+
 	if uc.userCacheService != nil {
 		return uc.userCacheService.InvalidateToken(accessToken)
 	}
@@ -103,17 +219,103 @@ func (uc *AuthUseCase) SignOut(accessToken string) error {
 }
 
 func (uc *AuthUseCase) SignOutAll(userID int) error {
+	return uc.RevokeAllUserSessions(userID, domain.SessionRevokeReasonLogoutAll)
+}
+
+func (uc *AuthUseCase) RevokeAllUserSessions(userID int, reason string) error {
+	sessionIDs, err := uc.authRepo.RevokeAllUserSessions(userID, reason)
+	if err != nil {
+		return err
+	}
+
+	uc.markSessionsRevoked(sessionIDs)
+
 	if uc.userCacheService != nil {
-		// Инвалидируем кэш пользователя
 		if err := uc.userCacheService.InvalidateUser(userID); err != nil {
 			return fmt.Errorf("ошибка инвалидации кэша пользователя: %w", err)
 		}
-		// Можно также очистить все токены, но это радикальная мера
-		// return uc.userCacheService.InvalidateAllTokens()
+		// Кэш валидации токенов хранит признак активности пользователя, а токены, выданные до появления
+		// сессий, не отзываются метками. При блокировке кэш сбрасывается, чтобы статус перепроверился по БД
+		if reason == domain.SessionRevokeReasonUserBlocked {
+			if err := uc.userCacheService.InvalidateAllTokens(); err != nil {
+				return fmt.Errorf("ошибка очистки кэша токенов: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (uc *AuthUseCase) IsSessionActive(sessionID string) (bool, error) {
+	if uc.userCacheService != nil {
+		revoked, err := uc.userCacheService.IsSessionRevoked(sessionID)
+		if err == nil {
+			return !revoked, nil
+		}
+		logger.Warn("failed to check session revocation in cache, falling back to database",
+			slog.String("session_id", sessionID),
+			slog.String("error", err.Error()))
+	}
+
+	session, err := uc.authRepo.GetSessionByID(sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	return session != nil && session.RevokedAt == nil, nil
+}
+
+func (uc *AuthUseCase) GetUserSessions(userID int, currentSessionID string) ([]*domain.Session, error) {
+	sessions, err := uc.authRepo.GetActiveSessionsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, session := range sessions {
+		session.Device = describeDevice(session.UserAgent)
+		session.IsCurrent = session.ID == currentSessionID
 	}
+
+	return sessions, nil
+}
+
+func (uc *AuthUseCase) RevokeUserSession(userID int, sessionID string) error {
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return apperrors.AuthSessionNotFound.New(nil)
+	}
+
+	session, err := uc.authRepo.GetSessionByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID || session.RevokedAt != nil {
+		return apperrors.AuthSessionNotFound.New(nil)
+	}
+
+	revoked, err := uc.authRepo.RevokeSession(sessionID, domain.SessionRevokeReasonUserRevoked)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		uc.markSessionsRevoked([]string{sessionID})
+	}
+
 	return nil
 }
 
+// markSessionsRevoked сообщает middleware об отзыве, чтобы уже выданные access-токены сессий
+// перестали приниматься сразу, а не по истечении срока действия.
+func (uc *AuthUseCase) markSessionsRevoked(sessionIDs []string) {
+	if uc.userCacheService == nil || len(sessionIDs) == 0 {
+		return
+	}
+
+	if err := uc.userCacheService.MarkSessionsRevoked(sessionIDs, uc.tokenManager.GetAccessTokenTTL()); err != nil {
+		logger.Error("failed to mark sessions as revoked",
+			slog.Any("session_ids", sessionIDs),
+			slog.String("error", err.Error()))
+	}
+}
+
 func (uc *AuthUseCase) GetUserFromToken(accessToken string) (*domain.User, error) {
 	claims, err := uc.tokenManager.ParseAccessToken(accessToken)
 	if err != nil {
@@ -148,3 +350,46 @@ func (uc *AuthUseCase) comparePasswords(hashedPassword, password string) error {
 
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(saltedPassword))
 }
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// describeDevice краткое описание устройства сессии по User-Agent: платформа и клиент.
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	var parts []string
+	switch {
+	case strings.Contains(ua, "iphone"):
+		parts = append(parts, "iPhone")
+	case strings.Contains(ua, "ipad"):
+		parts = append(parts, "iPad")
+	case strings.Contains(ua, "android"):
+		parts = append(parts, "Android")
+	case strings.Contains(ua, "windows"):
+		parts = append(parts, "Windows")
+	case strings.Contains(ua, "macintosh"), strings.Contains(ua, "mac os"):
+		parts = append(parts, "macOS")
+	case strings.Contains(ua, "linux"):
+		parts = append(parts, "Linux")
+	}
+
+	switch {
+	case strings.Contains(ua, "edg/"):
+		parts = append(parts, "Edge")
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		parts = append(parts, "Opera")
+	case strings.Contains(ua, "yabrowser/"):
+		parts = append(parts, "Yandex Browser")
+	case strings.Contains(ua, "firefox/"):
+		parts = append(parts, "Firefox")
+	case strings.Contains(ua, "chrome/"):
+		parts = append(parts, "Chrome")
+	case strings.Contains(ua, "safari/"):
+		parts = append(parts, "Safari")
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+const (
+	maxAvailabilityBlockDays     = 366
+	maxAvailabilityCommentLength = 500
+	defaultOwnerCalendarDays     = 90
+	maxOwnerCalendarDays         = 366
+)
+
+type availabilityBlockUseCase struct {
+	blockRepo           domain.AvailabilityBlockRepository
+	bookingRepo         domain.BookingRepository
+	calendarRepo        domain.CalendarRepository
+	availabilityService domain.ApartmentAvailabilityService
+}
+
+func NewAvailabilityBlockUseCase(
+	blockRepo domain.AvailabilityBlockRepository,
+	bookingRepo domain.BookingRepository,
+	calendarRepo domain.CalendarRepository,
+	availabilityService domain.ApartmentAvailabilityService,
+) domain.AvailabilityBlockUseCase {
+	return &availabilityBlockUseCase{
+		blockRepo:           blockRepo,
+		bookingRepo:         bookingRepo,
+		calendarRepo:        calendarRepo,
+		availabilityService: availabilityService,
+	}
+}
+
+func (u *availabilityBlockUseCase) GetApartmentBlocks(apartmentID int, from, to time.Time) ([]*domain.AvailabilityBlock, error) {
+	from, to, err := resolveCalendarRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.blockRepo.GetByApartmentID(apartmentID, from, to)
+}
+
+func (u *availabilityBlockUseCase) CreateBlock(apartmentID, userID int, request *domain.AvailabilityBlockRequest) (*domain.AvailabilityBlock, error) {
+	block := &domain.AvailabilityBlock{
+		ApartmentID: apartmentID,
+		CreatedBy:   &userID,
+	}
+	if err := u.applyBlockRequest(block, request); err != nil {
+		return nil, err
+	}
+
+	if err := u.blockRepo.Create(block); err != nil {
+		return nil, err
+	}
+
+	u.recalculateAvailability(apartmentID)
+
+	return block, nil
+}
+
+func (u *availabilityBlockUseCase) UpdateBlock(apartmentID, blockID int, request *domain.AvailabilityBlockRequest) (*domain.AvailabilityBlock, error) {
+	block, err := u.getApartmentBlock(apartmentID, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyBlockRequest(block, request); err != nil {
+		return nil, err
+	}
+
+	if err := u.blockRepo.Update(block); err != nil {
+		return nil, err
+	}
+
+	u.recalculateAvailability(apartmentID)
+
+	return block, nil
+}
+
+func (u *availabilityBlockUseCase) DeleteBlock(apartmentID, blockID int) error {
+	if _, err := u.getApartmentBlock(apartmentID, blockID); err != nil {
+		return err
+	}
+
+	if err := u.blockRepo.Delete(blockID); err != nil {
+		return err
+	}
+
+	u.recalculateAvailability(apartmentID)
+
+	return nil
+}
+
+// GetOwnerCalendar собирает в один список бронирования, блокировки владельца и периоды
+// из внешних календарей за выбранный период.
+func (u *availabilityBlockUseCase) GetOwnerCalendar(apartmentID int, from, to time.Time) (*domain.ApartmentCalendar, error) {
+	from, to, err := resolveCalendarRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &domain.ApartmentCalendar{
+		ApartmentID: apartmentID,
+		From:        from,
+		To:          to,
+		Entries:     []*domain.ApartmentCalendarEntry{},
+	}
+
+	bookings, err := u.bookingRepo.GetByApartmentID(apartmentID, exportedBookingStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирований: %w", err)
+	}
+	for _, booking := range bookings {
+		if !booking.StartDate.Before(to) || !booking.EndDate.After(from) {
+			continue
+		}
+		calendar.Entries = append(calendar.Entries, &domain.ApartmentCalendarEntry{
+			Type:          domain.CalendarEntryBooking,
+			ID:            booking.ID,
+			StartDate:     booking.StartDate,
+			EndDate:       booking.EndDate,
+			BookingNumber: booking.BookingNumber,
+			Status:        booking.Status,
+		})
+	}
+
+	blocks, err := u.blockRepo.GetByApartmentID(apartmentID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		calendar.Entries = append(calendar.Entries, &domain.ApartmentCalendarEntry{
+			Type:      domain.CalendarEntryBlock,
+			ID:        block.ID,
+			StartDate: block.StartDate,
+			EndDate:   block.EndDate,
+			Reason:    block.Reason,
+			Title:     block.Comment,
+		})
+	}
+
+	events, err := u.calendarRepo.GetExternalEventsByApartmentID(apartmentID, from)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if !event.StartDate.Before(to) {
+			continue
+		}
+		calendar.Entries = append(calendar.Entries, &domain.ApartmentCalendarEntry{
+			Type:      domain.CalendarEntryExternal,
+			ID:        event.ID,
+			StartDate: event.StartDate,
+			EndDate:   event.EndDate,
+			Title:     event.Summary,
+		})
+	}
+
+	sort.SliceStable(calendar.Entries, func(i, j int) bool {
+		return calendar.Entries[i].StartDate.Before(calendar.Entries[j].StartDate)
+	})
+
+	return calendar, nil
+}
+
+func (u *availabilityBlockUseCase) getApartmentBlock(apartmentID, blockID int) (*domain.AvailabilityBlock, error) {
+	block, err := u.blockRepo.GetByID(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.ApartmentID != apartmentID {
+		return nil, fmt.Errorf("блокировка не найдена")
+	}
+
+	return block, nil
+}
+
+// applyBlockRequest проверяет период блокировки и переносит данные запроса в блокировку.
+// Закрыть можно только период без действующих бронирований: отменять их владелец должен явно.
+func (u *availabilityBlockUseCase) applyBlockRequest(block *domain.AvailabilityBlock, request *domain.AvailabilityBlockRequest) error {
+	if !request.Reason.IsValid() {
+		return fmt.Errorf("некорректная причина блокировки: допустимы repair, cleaning, personal")
+	}
+
+	startDate, err := utils.ParseUserInput(request.StartDate)
+	if err != nil {
+		return fmt.Errorf("некорректная дата начала: %w", err)
+	}
+	endDate, err := utils.ParseUserInput(request.EndDate)
+	if err != nil {
+		return fmt.Errorf("некорректная дата окончания: %w", err)
+	}
+
+	if !endDate.After(startDate) {
+		return fmt.Errorf("дата окончания должна быть позже даты начала")
+	}
+	if !endDate.After(utils.GetCurrentTimeUTC()) {
+		return fmt.Errorf("нельзя заблокировать прошедший период")
+	}
+	if endDate.Sub(startDate) > maxAvailabilityBlockDays*24*time.Hour {
+		return fmt.Errorf("блокировка не может быть длиннее %d дней", maxAvailabilityBlockDays)
+	}
+
+	comment := strings.TrimSpace(request.Comment)
+	if utf8.RuneCountInString(comment) > maxAvailabilityCommentLength {
+		return fmt.Errorf("комментарий не может быть длиннее %d символов", maxAvailabilityCommentLength)
+	}
+
+	bookings, err := u.bookingRepo.GetByApartmentID(block.ApartmentID, exportedBookingStatuses)
+	if err != nil {
+		return fmt.Errorf("ошибка получения бронирований: %w", err)
+	}
+	for _, booking := range bookings {
+		if booking.StartDate.Before(endDate) && booking.EndDate.After(startDate) {
+			return fmt.Errorf("период пересекается с бронированием %s (%s - %s)",
+				booking.BookingNumber, utils.FormatForUser(booking.StartDate), utils.FormatForUser(booking.EndDate))
+		}
+	}
+
+	block.StartDate = startDate
+	block.EndDate = endDate
+	block.Reason = request.Reason
+	block.Comment = comment
+
+	return nil
+}
+
+func (u *availabilityBlockUseCase) recalculateAvailability(apartmentID int) {
+	if u.availabilityService == nil {
+		return
+	}
+
+	if err := u.availabilityService.RecalculateApartmentAvailability(apartmentID); err != nil {
+		logger.Warn("failed to recalculate apartment availability after block change",
+			slog.Int("apartment_id", apartmentID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// resolveCalendarRange подставляет период по умолчанию (с текущего момента на 90 дней вперед)
+// и ограничивает длину запрашиваемого периода.
+func resolveCalendarRange(from, to time.Time) (time.Time, time.Time, error) {
+	if from.IsZero() {
+		from = utils.GetCurrentTimeUTC()
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultOwnerCalendarDays)
+	}
+
+	if !to.After(from) {
+		return from, to, fmt.Errorf("конец периода должен быть позже начала")
+	}
+	if to.Sub(from) > maxOwnerCalendarDays*24*time.Hour {
+		return from, to, fmt.Errorf("период не может быть длиннее %d дней", maxOwnerCalendarDays)
+	}
+
+	return from, to, nil
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/services"
 	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/i18n"
 	"github.com/russo2642/renti_kz/pkg/logger"
 )
 
@@ -31,10 +35,17 @@ type bookingUseCase struct {
 	paymentRepo         domain.PaymentRepository
 	paymentLogRepo      domain.PaymentLogRepository
 	availabilityService domain.ApartmentAvailabilityService
+	cancellationUseCase domain.CancellationRuleUseCase
+	pricingUseCase      domain.PricingUseCase
 }
 
+// Платежная ссылка FreedomPay переиспользуется, пока бронирование не удалено очисткой (30 минут)
+const pendingPaymentReuseWindow = 25 * time.Minute
+
 type SchedulerServiceInterface interface {
 	RemoveScheduledTasksForBooking(bookingID int) error
+	ScheduleReviewReminder(bookingID int, checkoutAt time.Time)
+	ScheduleCleaningDispatch(bookingID int, checkoutAt time.Time)
 	RescheduleCompletionTask(bookingID int, newEndDate time.Time) error
 	GetSchedulerStats(ctx context.Context) map[string]interface{}
 	GetMetrics() *SchedulerMetrics
@@ -60,6 +71,8 @@ func NewBookingUseCase(
 	paymentRepo domain.PaymentRepository,
 	paymentLogRepo domain.PaymentLogRepository,
 	availabilityService domain.ApartmentAvailabilityService,
+	cancellationUseCase domain.CancellationRuleUseCase,
+	pricingUseCase domain.PricingUseCase,
 ) domain.BookingUseCase {
 	return &bookingUseCase{
 		bookingRepo:         bookingRepo,
@@ -79,6 +92,8 @@ func NewBookingUseCase(
 		paymentRepo:         paymentRepo,
 		paymentLogRepo:      paymentLogRepo,
 		availabilityService: availabilityService,
+		cancellationUseCase: cancellationUseCase,
+		pricingUseCase:      pricingUseCase,
 	}
 }
 
@@ -105,20 +120,20 @@ func validateRenterVerification(renter *domain.Renter) error {
 		}
 
 		if len(missing) == 1 {
-			return fmt.Errorf("для создания бронирования необходимо завершить верификацию личности")
+			return apperrors.BookingVerificationRequired.New(nil)
 		}
-		return fmt.Errorf("для создания бронирования необходимо завершить верификацию личности")
+		return apperrors.BookingVerificationRequired.New(nil)
 	}
 
 	switch renter.VerificationStatus {
 	case domain.VerificationPending:
-		return fmt.Errorf("ваша верификация находится на рассмотрении. Дождитесь подтверждения для создания бронирования")
+		return apperrors.BookingVerificationPending.New(nil)
 	case domain.VerificationRejected:
-		return fmt.Errorf("ваша верификация была отклонена. Обратитесь в поддержку или повторите процедуру верификации")
+		return apperrors.BookingVerificationRejected.New(nil)
 	case domain.VerificationApproved:
 		return nil
 	default:
-		return fmt.Errorf("статус верификации неизвестен. Обратитесь в поддержку")
+		return apperrors.BookingVerificationUnknown.New(nil)
 	}
 }
 
@@ -131,9 +146,9 @@ func (u *bookingUseCase) CreateBooking(userID int, request *domain.CreateBooking
 		}
 
 		if user.Role == domain.RoleOwner {
-			return nil, fmt.Errorf("для создания бронирования владелец недвижимости должен пройти верификацию как арендатор")
+			return nil, apperrors.BookingOwnerVerificationRequired.New(nil)
 		} else {
-			return nil, fmt.Errorf("для создания бронирования необходимо завершить верификацию личности")
+			return nil, apperrors.BookingVerificationRequired.New(nil)
 		}
 	}
 
@@ -144,7 +159,7 @@ func (u *bookingUseCase) CreateBooking(userID int, request *domain.CreateBooking
 
 	if user.Role == domain.RoleOwner {
 		if renter.VerificationStatus != domain.VerificationApproved {
-			return nil, fmt.Errorf("для создания бронирования владелец недвижимости должен пройти верификацию как арендатор")
+			return nil, apperrors.BookingOwnerVerificationRequired.New(nil)
 		}
 	} else {
 		if err := validateRenterVerification(renter); err != nil {
@@ -154,84 +169,33 @@ func (u *bookingUseCase) CreateBooking(userID int, request *domain.CreateBooking
 
 	apartment, err := u.apartmentRepo.GetByID(request.ApartmentID)
 	if err != nil {
-		return nil, fmt.Errorf("квартира не найдена: %w", err)
+		return nil, apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return nil, fmt.Errorf("квартира с ID %d не найдена", request.ApartmentID)
+		return nil, apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(request.ApartmentID)})
 	}
 
 	if apartment.Status != domain.AptStatusApproved {
-		return nil, fmt.Errorf("квартира недоступна для бронирования")
-	}
-
-	minDuration, err := u.settingsUseCase.GetMinBookingDurationHours()
-	if err != nil {
-		minDuration = 1
-	}
-	maxDuration, err := u.settingsUseCase.GetMaxBookingDurationHours()
-	if err != nil {
-		maxDuration = 720
-	}
-
-	if err := utils.ValidateRange(request.Duration, minDuration, maxDuration, "продолжительность аренды"); err != nil {
-		return nil, err
-	}
-
-	startDate, err := utils.ParseUserInput(request.StartDate)
-	if err != nil {
-		return nil, fmt.Errorf("неверный формат даты: %s (ожидается 2006-01-02T15:04:05)", request.StartDate)
-	}
-
-	if err := utils.ValidateFutureDate(startDate); err != nil {
-		return nil, fmt.Errorf("время бронирования не может быть в прошлом")
-	}
-
-	maxAdvanceDays, err := u.settingsUseCase.GetMaxAdvanceBookingDays()
-	if err != nil {
-		maxAdvanceDays = 90
-	}
-
-	if err := utils.ValidateDateNotTooFar(startDate, maxAdvanceDays); err != nil {
-		return nil, fmt.Errorf("бронирование нельзя создать более чем на %d дней вперед", maxAdvanceDays)
+		return nil, apperrors.BookingApartmentUnavailable.New(nil)
 	}
 
-	if request.Duration == 24 {
-		if !apartment.RentalTypeDaily {
-			return nil, fmt.Errorf("данная квартира не поддерживает посуточную аренду")
+	var startDate, endDate time.Time
+	var stay *domain.StayPeriod
+	if request.IsNightly() {
+		stay, err = utils.ResolveStayPeriod(apartment, request.CheckInDate, request.CheckOutDate)
+		if err != nil {
+			return nil, err
 		}
-		if apartment.DailyPrice <= 0 {
-			return nil, fmt.Errorf("для данной квартиры не установлена цена за сутки")
+		if err := u.validateBookingStartDate(stay.CheckIn); err != nil {
+			return nil, err
 		}
+		startDate, endDate = stay.CheckIn, stay.CheckOut
+		request.Duration = stay.Hours()
 	} else {
-		if !apartment.RentalTypeHourly {
-			return nil, fmt.Errorf("данная квартира не поддерживает почасовую аренду")
-		}
-		if apartment.Price <= 0 {
-			return nil, fmt.Errorf("для данной квартиры не установлена почасовая цена")
-		}
-	}
-
-	if !utils.ValidateRentalTime(startDate, request.Duration, apartment.RentalTypeHourly, apartment.RentalTypeDaily) {
-		timeInfo := utils.GetRentalTimeInfo(startDate)
-		if timeInfo["is_daytime"].(bool) {
-			return nil, fmt.Errorf("в дневное время (10:00-22:00) можно бронировать на %d, %d, %d или %d часа", utils.RentalDuration3Hours, utils.RentalDuration6Hours, utils.RentalDuration12Hours, utils.RentalDuration24Hours)
-		} else {
-			return nil, fmt.Errorf("в ночное время (22:00-10:00) доступна только посуточная аренда (24 часа)")
-		}
-	}
-
-	endDate := startDate.Add(time.Duration(request.Duration) * time.Hour)
-
-	if request.Duration < 24 && apartment.RentalTypeHourly {
-		endTimeLocal := utils.ConvertOutputFromUTC(endDate)
-		if endTimeLocal.Hour() < 10 || endTimeLocal.Hour() > 22 || (endTimeLocal.Hour() == 22 && endTimeLocal.Minute() > 0) {
-			maxStartHour := 22 - request.Duration
-			if maxStartHour < 10 {
-				return nil, fmt.Errorf("продолжительность %d часов слишком велика для почасовой аренды. Максимальная продолжительность: 12 часов (10:00-22:00)", request.Duration)
-			}
-			return nil, fmt.Errorf("почасовая аренда должна заканчиваться до 22:00. Для продолжительности %d ч. максимальное время начала: %02d:00",
-				request.Duration, maxStartHour)
+		startDate, endDate, err = u.resolveHourlyPeriod(apartment, request)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -246,18 +210,27 @@ func (u *bookingUseCase) CreateBooking(userID int, request *domain.CreateBooking
 	}
 
 	if !isAvailable {
-		return nil, fmt.Errorf("квартира недоступна в указанный период")
+		return nil, apperrors.BookingApartmentUnavailablePeriod.New(nil).WithDetails(map[string]interface{}{
+			"apartment_id": request.ApartmentID,
+			"start_date":   startDate,
+			"end_date":     endDate,
+		})
 	}
 
-	var totalPrice int
-	if request.Duration == 24 && apartment.RentalTypeDaily {
-		totalPrice = apartment.DailyPrice
+	var priceBreakdown *domain.PriceBreakdown
+	if stay != nil {
+		priceBreakdown, err = u.pricingUseCase.CalculateStayPrice(apartment, stay)
 	} else {
-		totalPrice = utils.CalculateHourlyPrice(apartment.Price, request.Duration)
+		priceBreakdown, err = u.pricingUseCase.CalculateBookingPrice(apartment, startDate, request.Duration)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расчета стоимости: %w", err)
 	}
 
-	serviceFee := u.calculateServiceFee(totalPrice, request.Duration)
-	finalPrice := totalPrice + serviceFee
+	nights := 0
+	if stay != nil {
+		nights = stay.Nights
+	}
 
 	booking := &domain.Booking{
 		RenterID:           renter.ID,
@@ -265,11 +238,13 @@ func (u *bookingUseCase) CreateBooking(userID int, request *domain.CreateBooking
 		StartDate:          startDate,
 		EndDate:            endDate,
 		Duration:           request.Duration,
+		Nights:             nights,
 		CleaningDuration:   u.getDefaultCleaningDuration(),
 		Status:             domain.BookingStatusCreated,
-		TotalPrice:         totalPrice,
-		ServiceFee:         serviceFee,
-		FinalPrice:         finalPrice,
+		TotalPrice:         priceBreakdown.TotalPrice,
+		ServiceFee:         priceBreakdown.ServiceFee,
+		FinalPrice:         priceBreakdown.FinalPrice,
+		PriceBreakdown:     priceBreakdown,
 		IsContractAccepted: false,
 		DoorStatus:         domain.DoorStatusClosed,
 		CanExtend:          true,
@@ -278,8 +253,12 @@ func (u *bookingUseCase) CreateBooking(userID int, request *domain.CreateBooking
 
 	err = u.bookingRepo.Create(booking)
 	if err != nil {
-		if strings.Contains(err.Error(), "Apartment is not available for the selected period") {
-			return nil, fmt.Errorf("квартира недоступна в указанный период - найдено пересекающееся бронирование")
+		if appErr, ok := apperrors.AsAppError(err); ok && apperrors.BookingApartmentUnavailableOverlap.Is(appErr) {
+			return nil, appErr.WithDetails(map[string]interface{}{
+				"apartment_id": request.ApartmentID,
+				"start_date":   startDate,
+				"end_date":     endDate,
+			})
 		}
 		return nil, fmt.Errorf("ошибка создания бронирования: %w", err)
 	}
@@ -350,7 +329,7 @@ func (u *bookingUseCase) GetRenterBookings(userID int, status []domain.BookingSt
 	}
 
 	if renter.VerificationStatus == domain.VerificationRejected {
-		return nil, 0, fmt.Errorf("ваша верификация была отклонена. Для создания новых бронирований обратитесь в поддержку или повторите процедуру верификации")
+		return nil, 0, apperrors.BookingVerificationRejectedNew.New(nil)
 	}
 
 	bookings, total, err := u.bookingRepo.GetByRenterID(renter.ID, status, dateFrom, dateTo, page, pageSize)
@@ -371,7 +350,7 @@ func (u *bookingUseCase) GetOwnerBookings(userID int, status []domain.BookingSta
 	}
 
 	if owner == nil {
-		return nil, 0, fmt.Errorf("пользователь не является владельцем недвижимости")
+		return nil, 0, apperrors.BookingNotOwner.New(nil)
 	}
 
 	filteredStatuses := make([]domain.BookingStatus, 0, len(status))
@@ -406,29 +385,29 @@ func (u *bookingUseCase) GetOwnerBookings(userID int, status []domain.BookingSta
 func (u *bookingUseCase) ApproveBooking(bookingID, userID int) error {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return fmt.Errorf("квартира с ID %d не найдена", booking.ApartmentID)
+		return apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(booking.ApartmentID)})
 	}
 
 	propertyOwner, err := u.propertyOwnerRepo.GetByUserID(userID)
 	if err != nil || propertyOwner == nil {
-		return fmt.Errorf("пользователь не является владельцем недвижимости")
+		return apperrors.BookingNotOwner.New(nil)
 	}
 
 	if apartment.OwnerID != propertyOwner.ID {
-		return fmt.Errorf("нет прав для подтверждения этого бронирования")
+		return apperrors.BookingForbiddenApprove.New(nil)
 	}
 
 	if booking.Status != domain.BookingStatusPending {
-		return fmt.Errorf("можно подтвердить только ожидающие бронирования")
+		return apperrors.BookingApproveOnlyPending.New(nil)
 	}
 
 	booking.Status = domain.BookingStatusApproved
@@ -493,7 +472,7 @@ func (u *bookingUseCase) ApproveBooking(bookingID, userID int) error {
 func (u *bookingUseCase) RejectBooking(bookingID, userID int, comment string) error {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	owner, err := u.propertyOwnerRepo.GetByUserID(userID)
@@ -502,24 +481,24 @@ func (u *bookingUseCase) RejectBooking(bookingID, userID int, comment string) er
 	}
 
 	if owner == nil {
-		return fmt.Errorf("пользователь не является владельцем недвижимости")
+		return apperrors.BookingNotOwner.New(nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return fmt.Errorf("квартира не найдена")
+		return apperrors.BookingApartmentNotFound.New(nil)
 	}
 
 	if apartment.OwnerID != owner.ID {
-		return fmt.Errorf("нет прав для отклонения этого бронирования")
+		return apperrors.BookingForbiddenReject.New(nil)
 	}
 
 	if booking.Status != domain.BookingStatusPending {
-		return fmt.Errorf("можно отклонить только ожидающие бронирования")
+		return apperrors.BookingRejectOnlyPending.New(nil)
 	}
 
 	booking.Status = domain.BookingStatusRejected
@@ -561,7 +540,7 @@ func (u *bookingUseCase) RejectBooking(bookingID, userID int, comment string) er
 func (u *bookingUseCase) CancelBooking(bookingID, userID int, reason string) error {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
@@ -570,33 +549,38 @@ func (u *bookingUseCase) CancelBooking(bookingID, userID int, reason string) err
 	}
 
 	if booking.RenterID != renter.ID {
-		return fmt.Errorf("нет прав для отмены этого бронирования")
+		return apperrors.BookingForbiddenCancel.New(nil)
 	}
 
 	if booking.Status == domain.BookingStatusCompleted ||
 		booking.Status == domain.BookingStatusCanceled ||
 		booking.Status == domain.BookingStatusActive {
-		return fmt.Errorf("нельзя отменить завершенное, уже отмененное или активное бронирование")
+		return apperrors.BookingCannotCancel.New(nil)
 	}
 
-	now := time.Now()
-	hoursUntilStart := booking.StartDate.Sub(now).Hours()
-
-	shouldRefund := true
-	if (booking.Status == domain.BookingStatusApproved || booking.Status == domain.BookingStatusPending) && hoursUntilStart < 6 {
-		shouldRefund = false
+	quote, err := u.cancellationUseCase.QuoteCancellation(booking, time.Now())
+	if err != nil {
+		return fmt.Errorf("ошибка расчета возврата: %w", err)
 	}
 
 	wasActive := booking.Status == domain.BookingStatusActive
 
-	if shouldRefund && booking.PaymentID != nil {
+	if quote.RefundAmount > 0 && booking.PaymentID != nil {
 		paymentRecord, err := u.paymentRepo.GetByID(*booking.PaymentID)
 		if err == nil && paymentRecord != nil && u.paymentUseCase != nil {
 			logger.Info("attempting to refund payment for cancelled booking",
 				slog.Int("booking_id", bookingID),
-				slog.String("payment_id", paymentRecord.PaymentID))
+				slog.String("payment_id", paymentRecord.PaymentID),
+				slog.String("policy", string(quote.Policy.Code)),
+				slog.Int("refund_amount", quote.RefundAmount))
 
-			refundResponse, refundErr := u.paymentUseCase.RefundPayment(paymentRecord.PaymentID, nil)
+			// Полный возврат выполняется без указания суммы
+			var refundAmount *int
+			if quote.RefundAmount < paymentRecord.Amount {
+				refundAmount = &quote.RefundAmount
+			}
+
+			refundResponse, refundErr := u.paymentUseCase.RefundPayment(paymentRecord.PaymentID, refundAmount)
 			if refundErr != nil {
 				logger.Error("failed to refund payment for cancelled booking",
 					slog.Int("booking_id", bookingID),
@@ -605,14 +589,16 @@ func (u *bookingUseCase) CancelBooking(bookingID, userID int, reason string) err
 			} else if refundResponse.Success {
 				logger.Info("payment refunded successfully for cancelled booking",
 					slog.Int("booking_id", bookingID),
-					slog.String("payment_id", paymentRecord.PaymentID))
+					slog.String("payment_id", paymentRecord.PaymentID),
+					slog.Int("refund_amount", quote.RefundAmount))
 			}
 		}
-	} else if !shouldRefund && booking.PaymentID != nil {
-		logger.Info("booking cancelled without refund due to late cancellation policy",
+	} else if booking.PaymentID != nil {
+		logger.Info("booking cancelled without refund due to cancellation policy",
 			slog.Int("booking_id", bookingID),
 			slog.String("status", string(booking.Status)),
-			slog.Float64("hours_until_start", hoursUntilStart))
+			slog.String("policy", string(quote.Policy.Code)),
+			slog.Float64("hours_until_start", quote.HoursUntilStart))
 	}
 
 	booking.Status = domain.BookingStatusCanceled
@@ -665,14 +651,38 @@ func (u *bookingUseCase) CancelBooking(bookingID, userID int, reason string) err
 	return nil
 }
 
+func (u *bookingUseCase) GetCancellationQuote(bookingID, userID int) (*domain.CancellationQuote, error) {
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
+	}
+
+	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.RenterID != renter.ID {
+		return nil, apperrors.BookingForbiddenCancellationTerms.New(nil)
+	}
+
+	if booking.Status == domain.BookingStatusCompleted ||
+		booking.Status == domain.BookingStatusCanceled ||
+		booking.Status == domain.BookingStatusActive {
+		return nil, apperrors.BookingCannotCancel.New(nil)
+	}
+
+	return u.cancellationUseCase.QuoteCancellation(booking, time.Now())
+}
+
 func (u *bookingUseCase) CompleteBooking(bookingID int) error {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	if booking.Status != domain.BookingStatusActive {
-		return fmt.Errorf("можно завершить только активные бронирования")
+		return apperrors.BookingCompleteOnlyActive.New(nil)
 	}
 
 	booking.Status = domain.BookingStatusCompleted
@@ -689,6 +699,11 @@ func (u *bookingUseCase) CompleteBooking(bookingID int) error {
 		}
 	}
 
+	if u.schedulerService != nil {
+		u.schedulerService.ScheduleReviewReminder(bookingID, booking.EndDate)
+		u.schedulerService.ScheduleCleaningDispatch(bookingID, booking.EndDate)
+	}
+
 	if u.notificationUseCase != nil {
 		apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 		if err == nil && apartment != nil {
@@ -711,7 +726,7 @@ func (u *bookingUseCase) CompleteBooking(bookingID int) error {
 func (u *bookingUseCase) FinishSession(bookingID, userID int) error {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
@@ -720,20 +735,20 @@ func (u *bookingUseCase) FinishSession(bookingID, userID int) error {
 	}
 
 	if booking.RenterID != renter.ID {
-		return fmt.Errorf("нет прав для завершения этого бронирования")
+		return apperrors.BookingForbiddenComplete.New(nil)
 	}
 
 	if booking.Status != domain.BookingStatusActive {
-		return fmt.Errorf("завершить можно только активные бронирования")
+		return apperrors.BookingCompleteOnlyActive.New(nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return fmt.Errorf("квартира с ID %d не найдена", booking.ApartmentID)
+		return apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(booking.ApartmentID)})
 	}
 
 	propertyOwner, err := u.propertyOwnerRepo.GetByID(apartment.OwnerID)
@@ -767,6 +782,9 @@ func (u *bookingUseCase) FinishSession(bookingID, userID int) error {
 				slog.Int("booking_id", bookingID),
 				slog.String("error", err.Error()))
 		}
+
+		u.schedulerService.ScheduleReviewReminder(bookingID, time.Now())
+		u.schedulerService.ScheduleCleaningDispatch(bookingID, time.Now())
 	}
 
 	if u.notificationUseCase != nil && propertyOwner != nil {
@@ -811,7 +829,7 @@ func (u *bookingUseCase) FinishSession(bookingID, userID int) error {
 func (u *bookingUseCase) RequestExtension(bookingID, userID int, request *domain.ExtendBookingRequest) error {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
@@ -820,15 +838,15 @@ func (u *bookingUseCase) RequestExtension(bookingID, userID int, request *domain
 	}
 
 	if booking.RenterID != renter.ID {
-		return fmt.Errorf("нет прав для продления этого бронирования")
+		return apperrors.BookingForbiddenExtend.New(nil)
 	}
 
 	if !booking.CanExtend {
-		return fmt.Errorf("продление данного бронирования недоступно")
+		return apperrors.BookingExtensionUnavailable.New(nil)
 	}
 
 	if booking.Status != domain.BookingStatusActive {
-		return fmt.Errorf("можно продлить только активные бронирования")
+		return apperrors.BookingExtendOnlyActive.New(nil)
 	}
 
 	newEndDate := booking.EndDate.Add(time.Duration(request.Duration) * time.Hour)
@@ -844,25 +862,29 @@ func (u *bookingUseCase) RequestExtension(bookingID, userID int, request *domain
 	}
 
 	if !isAvailable {
-		return fmt.Errorf("квартира недоступна для продления в указанный период")
+		return apperrors.BookingApartmentUnavailableExtension.New(nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return fmt.Errorf("квартира с ID %d не найдена", booking.ApartmentID)
+		return apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(booking.ApartmentID)})
 	}
 
-	extensionPrice := utils.CalculateHourlyPrice(apartment.Price, request.Duration)
+	priceBreakdown, err := u.pricingUseCase.CalculateExtensionPrice(apartment, booking.EndDate, request.Duration)
+	if err != nil {
+		return fmt.Errorf("ошибка расчета стоимости продления: %w", err)
+	}
 
 	extension := &domain.BookingExtension{
-		BookingID: bookingID,
-		Duration:  request.Duration,
-		Price:     extensionPrice,
-		Status:    domain.BookingStatusAwaitingPayment,
+		BookingID:      bookingID,
+		Duration:       request.Duration,
+		Price:          priceBreakdown.TotalPrice,
+		PriceBreakdown: priceBreakdown,
+		Status:         domain.BookingStatusAwaitingPayment,
 	}
 
 	err = u.bookingRepo.CreateExtension(extension)
@@ -876,16 +898,29 @@ func (u *bookingUseCase) RequestExtension(bookingID, userID int, request *domain
 func (u *bookingUseCase) ProcessExtensionPayment(extensionID int, paymentID string) (*domain.BookingExtension, error) {
 	extension, err := u.bookingRepo.GetExtensionByID(extensionID)
 	if err != nil {
-		return nil, fmt.Errorf("продление не найдено: %w", err)
+		return nil, apperrors.BookingExtensionNotFound.Wrap(err, nil)
 	}
 
 	if extension.Status != domain.BookingStatusAwaitingPayment {
-		return nil, fmt.Errorf("можно оплатить только продления со статусом 'awaiting_payment'")
+		if extension.PaymentID != nil {
+			paidWith, paymentErr := u.paymentRepo.GetByID(*extension.PaymentID)
+			if paymentErr == nil && paidWith.PaymentID == paymentID {
+				logger.Info("extension payment already processed",
+					slog.String("payment_id", paymentID),
+					slog.Int("extension_id", extensionID))
+				return extension, nil
+			}
+		}
+		return nil, apperrors.BookingExtensionPayOnlyAwaiting.New(nil)
 	}
 
 	existingPayment, err := u.paymentRepo.GetByPaymentID(paymentID)
 	if err == nil && existingPayment != nil {
-		return nil, fmt.Errorf("платеж %s уже использован", paymentID)
+		if existingPayment.ExtensionID == nil || *existingPayment.ExtensionID != int64(extensionID) ||
+			existingPayment.Status == domain.PaymentStatusSuccess {
+			return nil, apperrors.BookingPaymentAlreadyUsed.New(i18n.Params{"payment_id": paymentID}).
+				WithDetails(map[string]interface{}{"payment_id": paymentID})
+		}
 	}
 
 	paymentStatus, err := u.paymentUseCase.CheckPaymentStatus(paymentID)
@@ -902,26 +937,55 @@ func (u *bookingUseCase) ProcessExtensionPayment(extensionID int, paymentID stri
 			slog.String("payment_id", paymentID),
 			slog.Int("extension_id", extensionID),
 			slog.String("status", paymentStatus.Status))
-		return nil, fmt.Errorf("платеж не завершен: %s", paymentStatus.Status)
+		return nil, apperrors.BookingPaymentNotCompleted.New(i18n.Params{"status": paymentStatus.Status}).
+			WithDetails(map[string]interface{}{"payment_status": paymentStatus.Status})
 	}
 
-	payment := &domain.Payment{
-		BookingID:      int64(extension.BookingID),
-		PaymentID:      paymentID,
-		Amount:         extension.Price,
-		Currency:       "KZT",
-		Status:         domain.PaymentStatusSuccess,
-		PaymentMethod:  &paymentStatus.PaymentMethod,
-		ProviderStatus: &paymentStatus.Status,
-		ProcessedAt:    &[]time.Time{time.Now()}[0],
-	}
+	var payment *domain.Payment
+	if existingPayment != nil {
+		if !paymentAmountMatches(existingPayment.Amount, paymentStatus.Amount) {
+			logger.Error("extension payment amount mismatch",
+				slog.String("payment_id", paymentID),
+				slog.Int("extension_id", extensionID),
+				slog.Int("expected_amount", existingPayment.Amount),
+				slog.String("provider_amount", paymentStatus.Amount))
+			return nil, apperrors.BookingExtensionAmountMismatch.New(nil)
+		}
 
-	if err := u.paymentRepo.Create(payment); err != nil {
-		logger.Error("failed to save extension payment record",
-			slog.String("payment_id", paymentID),
-			slog.Int("extension_id", extensionID),
-			slog.String("error", err.Error()))
-		return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+		payment = existingPayment
+		payment.Status = domain.PaymentStatusSuccess
+		payment.PaymentMethod = &paymentStatus.PaymentMethod
+		payment.ProviderStatus = &paymentStatus.Status
+		payment.ProcessedAt = &[]time.Time{time.Now()}[0]
+
+		if err := u.paymentRepo.Update(payment); err != nil {
+			logger.Error("failed to update extension payment record",
+				slog.String("payment_id", paymentID),
+				slog.Int("extension_id", extensionID),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+		}
+	} else {
+		extensionDBID := int64(extensionID)
+		payment = &domain.Payment{
+			BookingID:      int64(extension.BookingID),
+			ExtensionID:    &extensionDBID,
+			PaymentID:      paymentID,
+			Amount:         extension.Price,
+			Currency:       "KZT",
+			Status:         domain.PaymentStatusSuccess,
+			PaymentMethod:  &paymentStatus.PaymentMethod,
+			ProviderStatus: &paymentStatus.Status,
+			ProcessedAt:    &[]time.Time{time.Now()}[0],
+		}
+
+		if err := u.paymentRepo.Create(payment); err != nil {
+			logger.Error("failed to save extension payment record",
+				slog.String("payment_id", paymentID),
+				slog.Int("extension_id", extensionID),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+		}
 	}
 
 	extension.Status = domain.BookingStatusPending
@@ -938,7 +1002,7 @@ func (u *bookingUseCase) ProcessExtensionPayment(extensionID int, paymentID stri
 
 	booking, err := u.bookingRepo.GetByID(extension.BookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	newEndDate := booking.EndDate.Add(time.Duration(extension.Duration) * time.Hour)
@@ -998,7 +1062,7 @@ func (u *bookingUseCase) ProcessExtensionPayment(extensionID int, paymentID stri
 func (u *bookingUseCase) ProcessExtensionPaymentWithOrder(extensionID int, orderID string) (*domain.BookingExtension, error) {
 	extension, err := u.bookingRepo.GetExtensionByID(extensionID)
 	if err != nil {
-		return nil, fmt.Errorf("продление не найдено: %w", err)
+		return nil, apperrors.BookingExtensionNotFound.Wrap(err, nil)
 	}
 
 	paymentStatus, err := u.paymentUseCase.CheckPaymentStatusByOrderID(orderID, int64(extension.BookingID))
@@ -1012,7 +1076,7 @@ func (u *bookingUseCase) ProcessExtensionPaymentWithOrder(extensionID int, order
 	}
 
 	if !paymentStatus.Exists {
-		return nil, fmt.Errorf("платеж с order_id %s не найден: %s", orderID, paymentStatus.ErrorMessage)
+		return nil, apperrors.BookingPaymentOrderNotFound.New(i18n.Params{"order_id": orderID, "reason": paymentStatus.ErrorMessage})
 	}
 
 	logger.Info("converting order_id to payment_id for extension",
@@ -1027,16 +1091,16 @@ func (u *bookingUseCase) ProcessExtensionPaymentWithOrder(extensionID int, order
 func (u *bookingUseCase) ApproveExtension(extensionID, userID int) error {
 	extension, err := u.bookingRepo.GetExtensionByID(extensionID)
 	if err != nil {
-		return fmt.Errorf("продление не найдено: %w", err)
+		return apperrors.BookingExtensionNotFound.Wrap(err, nil)
 	}
 
 	if extension.Status != domain.BookingStatusPending {
-		return fmt.Errorf("можно подтвердить только оплаченные продления (статус: pending)")
+		return apperrors.BookingExtensionApproveOnlyPaid.New(nil)
 	}
 
 	booking, err := u.bookingRepo.GetByID(extension.BookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	owner, err := u.propertyOwnerRepo.GetByUserID(userID)
@@ -1045,20 +1109,20 @@ func (u *bookingUseCase) ApproveExtension(extensionID, userID int) error {
 	}
 
 	if owner == nil {
-		return fmt.Errorf("пользователь не является владельцем недвижимости")
+		return apperrors.BookingNotOwner.New(nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return fmt.Errorf("квартира не найдена")
+		return apperrors.BookingApartmentNotFound.New(nil)
 	}
 
 	if apartment.OwnerID != owner.ID {
-		return fmt.Errorf("нет прав для подтверждения продления")
+		return apperrors.BookingForbiddenApproveExtension.New(nil)
 	}
 
 	now := time.Now()
@@ -1071,7 +1135,7 @@ func (u *bookingUseCase) ApproveExtension(extensionID, userID int) error {
 	}
 
 	if booking.ExtensionEndDate == nil {
-		return fmt.Errorf("данные о продлении повреждены: отсутствует дата окончания")
+		return apperrors.BookingExtensionDataCorrupted.New(nil)
 	}
 
 	newEndDate := *booking.ExtensionEndDate
@@ -1081,6 +1145,9 @@ func (u *bookingUseCase) ApproveExtension(extensionID, userID int) error {
 	booking.Duration += booking.ExtensionDuration
 	booking.TotalPrice += booking.ExtensionPrice
 	booking.FinalPrice = booking.TotalPrice + booking.ServiceFee
+	if booking.PriceBreakdown != nil {
+		booking.PriceBreakdown.ApplyExtension(extension, booking.TotalPrice, booking.FinalPrice)
+	}
 	booking.ExtensionRequested = false
 
 	booking.ExtensionEndDate = nil
@@ -1138,16 +1205,16 @@ func (u *bookingUseCase) ApproveExtension(extensionID, userID int) error {
 func (u *bookingUseCase) RejectExtension(extensionID, userID int) error {
 	extension, err := u.bookingRepo.GetExtensionByID(extensionID)
 	if err != nil {
-		return fmt.Errorf("продление не найдено: %w", err)
+		return apperrors.BookingExtensionNotFound.Wrap(err, nil)
 	}
 
 	if extension.Status != domain.BookingStatusPending {
-		return fmt.Errorf("можно отклонить только ожидающие продления")
+		return apperrors.BookingExtensionRejectOnlyPending.New(nil)
 	}
 
 	booking, err := u.bookingRepo.GetByID(extension.BookingID)
 	if err != nil {
-		return fmt.Errorf("бронирование не найдено: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	owner, err := u.propertyOwnerRepo.GetByUserID(userID)
@@ -1156,20 +1223,20 @@ func (u *bookingUseCase) RejectExtension(extensionID, userID int) error {
 	}
 
 	if owner == nil {
-		return fmt.Errorf("пользователь не является владельцем недвижимости")
+		return apperrors.BookingNotOwner.New(nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return fmt.Errorf("квартира не найдена")
+		return apperrors.BookingApartmentNotFound.New(nil)
 	}
 
 	if apartment.OwnerID != owner.ID {
-		return fmt.Errorf("нет прав для отклонения продления")
+		return apperrors.BookingForbiddenRejectExtension.New(nil)
 	}
 
 	extensionDuration := booking.ExtensionDuration
@@ -1263,7 +1330,7 @@ func (u *bookingUseCase) CanUserAccessBooking(bookingID, userID int) (bool, erro
 	}
 
 	if apartment == nil {
-		return false, fmt.Errorf("квартира с ID %d не найдена", booking.ApartmentID)
+		return false, apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(booking.ApartmentID)})
 	}
 
 	owner, err := u.propertyOwnerRepo.GetByUserID(userID)
@@ -1277,7 +1344,7 @@ func (u *bookingUseCase) CanUserAccessBooking(bookingID, userID int) (bool, erro
 func (u *bookingUseCase) CanUserManageDoor(bookingID, userID int) (bool, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return false, fmt.Errorf("бронирование не найдено: %w", err)
+		return false, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	return u.lockUseCase.CanUserManageLockViaBooking(booking, userID)
@@ -1298,20 +1365,99 @@ func (u *bookingUseCase) IsBookingActive(bookingID int) (bool, error) {
 	return utils.IsTimeInRange(now, booking.StartDate, booking.EndDate, 0), nil
 }
 
-func (u *bookingUseCase) CheckApartmentAvailability(apartmentID int, startDate, endDate time.Time) (bool, error) {
-	return u.bookingRepo.CheckApartmentAvailability(apartmentID, startDate, endDate, nil)
+// resolveHourlyPeriod проверяет почасовое бронирование (или суточный слот на 24 часа) и возвращает его период
+func (u *bookingUseCase) resolveHourlyPeriod(apartment *domain.Apartment, request *domain.CreateBookingRequest) (startDate, endDate time.Time, err error) {
+	minDuration, err := u.settingsUseCase.GetMinBookingDurationHours()
+	if err != nil {
+		minDuration = 1
+	}
+	maxDuration, err := u.settingsUseCase.GetMaxBookingDurationHours()
+	if err != nil {
+		maxDuration = 720
+	}
+
+	if err := utils.ValidateRange(request.Duration, minDuration, maxDuration, "продолжительность аренды"); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	startDate, err = utils.ParseUserInput(request.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.BookingInvalidDatetime.New(i18n.Params{"value": request.StartDate})
+	}
+
+	if err := u.validateBookingStartDate(startDate); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if request.Duration == 24 {
+		if !apartment.RentalTypeDaily {
+			return time.Time{}, time.Time{}, apperrors.BookingDailyUnsupported.New(nil)
+		}
+		if apartment.DailyPrice <= 0 {
+			return time.Time{}, time.Time{}, apperrors.BookingDailyPriceMissing.New(nil)
+		}
+	} else {
+		if !apartment.RentalTypeHourly {
+			return time.Time{}, time.Time{}, apperrors.BookingHourlyUnsupported.New(nil)
+		}
+		if apartment.Price <= 0 {
+			return time.Time{}, time.Time{}, apperrors.BookingHourlyPriceMissing.New(nil)
+		}
+	}
+
+	if !utils.ValidateRentalTime(startDate, request.Duration, apartment.RentalTypeHourly, apartment.RentalTypeDaily) {
+		timeInfo := utils.GetRentalTimeInfo(startDate)
+		if timeInfo["is_daytime"].(bool) {
+			return time.Time{}, time.Time{}, apperrors.BookingDaytimeDurations.New(i18n.Params{
+				"h1": strconv.Itoa(utils.RentalDuration3Hours),
+				"h2": strconv.Itoa(utils.RentalDuration6Hours),
+				"h3": strconv.Itoa(utils.RentalDuration12Hours),
+				"h4": strconv.Itoa(utils.RentalDuration24Hours),
+			})
+		} else {
+			return time.Time{}, time.Time{}, apperrors.BookingNightDailyOnly.New(nil)
+		}
+	}
+
+	endDate = startDate.Add(time.Duration(request.Duration) * time.Hour)
+
+	if request.Duration < 24 && apartment.RentalTypeHourly {
+		endTimeLocal := utils.ConvertOutputFromUTC(endDate)
+		if endTimeLocal.Hour() < 10 || endTimeLocal.Hour() > 22 || (endTimeLocal.Hour() == 22 && endTimeLocal.Minute() > 0) {
+			maxStartHour := 22 - request.Duration
+			if maxStartHour < 10 {
+				return time.Time{}, time.Time{}, apperrors.BookingHourlyDurationTooLong.New(i18n.Params{"duration": strconv.Itoa(request.Duration)})
+			}
+			return time.Time{}, time.Time{}, apperrors.BookingHourlyMustEndBy22.New(i18n.Params{
+				"duration":  strconv.Itoa(request.Duration),
+				"max_start": fmt.Sprintf("%02d:00", maxStartHour),
+			})
+		}
+	}
+
+	return startDate, endDate, nil
 }
 
-func (u *bookingUseCase) calculateServiceFee(totalPrice, duration int) int {
-	if duration == 24 {
-		return 3000
+func (u *bookingUseCase) validateBookingStartDate(startDate time.Time) error {
+	if err := utils.ValidateFutureDate(startDate); err != nil {
+		return apperrors.BookingStartInPast.New(nil)
 	}
 
-	serviceFeePercentage, err := u.settingsUseCase.GetServiceFeePercentage()
+	maxAdvanceDays, err := u.settingsUseCase.GetMaxAdvanceBookingDays()
 	if err != nil {
-		serviceFeePercentage = 15
+		maxAdvanceDays = 90
+	}
+
+	if err := utils.ValidateDateNotTooFar(startDate, maxAdvanceDays); err != nil {
+		return apperrors.BookingTooFarInAdvance.New(i18n.Params{"days": strconv.Itoa(maxAdvanceDays)}).
+			WithDetails(map[string]interface{}{"max_advance_days": maxAdvanceDays})
 	}
-	return totalPrice * serviceFeePercentage / 100
+
+	return nil
+}
+
+func (u *bookingUseCase) CheckApartmentAvailability(apartmentID int, startDate, endDate time.Time) (bool, error) {
+	return u.bookingRepo.CheckApartmentAvailability(apartmentID, startDate, endDate, nil)
 }
 
 func (u *bookingUseCase) getDefaultCleaningDuration() int {
@@ -1329,7 +1475,7 @@ func (u *bookingUseCase) GetBookingExtensions(bookingID int) ([]*domain.BookingE
 func (u *bookingUseCase) GetAvailableExtensions(bookingID, userID int) (*domain.AvailableExtensionsResponse, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	canAccess, err := u.CanUserAccessBooking(bookingID, userID)
@@ -1337,20 +1483,20 @@ func (u *bookingUseCase) GetAvailableExtensions(bookingID, userID int) (*domain.
 		return nil, err
 	}
 	if !canAccess {
-		return nil, fmt.Errorf("нет прав для доступа к данному бронированию")
+		return nil, apperrors.BookingForbiddenAccess.New(nil)
 	}
 
 	if !booking.CanExtend {
-		return nil, fmt.Errorf("продление данного бронирования недоступно")
+		return nil, apperrors.BookingExtensionUnavailable.New(nil)
 	}
 
 	if booking.Status != domain.BookingStatusActive {
-		return nil, fmt.Errorf("можно продлить только активные бронирования")
+		return nil, apperrors.BookingExtendOnlyActive.New(nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return nil, fmt.Errorf("квартира не найдена: %w", err)
+		return nil, apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	cleaningDurationMinutes := u.getDefaultCleaningDuration()
@@ -1432,21 +1578,21 @@ func (u *bookingUseCase) GetAvailableExtensions(bookingID, userID int) (*domain.
 func (u *bookingUseCase) DebugBookingAccess(bookingID, userID int) (map[string]interface{}, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	user, err := u.userUseCase.GetByID(userID)
 	if err != nil {
-		return nil, fmt.Errorf("пользователь не найден: %w", err)
+		return nil, apperrors.BookingUserNotFound.Wrap(err, nil)
 	}
 
 	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
 	if err != nil {
-		return nil, fmt.Errorf("квартира не найдена: %w", err)
+		return nil, apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return nil, fmt.Errorf("квартира с ID %d не найдена", booking.ApartmentID)
+		return nil, apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(booking.ApartmentID)})
 	}
 
 	renter, err := u.renterRepo.GetByID(booking.RenterID)
@@ -1611,7 +1757,7 @@ func (u *bookingUseCase) createChatRoomDirect(room *domain.ChatRoom) error {
 func (u *bookingUseCase) ConfirmBooking(bookingID, userID int, request *domain.ConfirmBookingRequest) (*domain.Booking, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
@@ -1620,15 +1766,15 @@ func (u *bookingUseCase) ConfirmBooking(bookingID, userID int, request *domain.C
 	}
 
 	if booking.RenterID != renter.ID {
-		return nil, fmt.Errorf("нет прав для подтверждения этого бронирования")
+		return nil, apperrors.BookingForbiddenApprove.New(nil)
 	}
 
 	if booking.Status != domain.BookingStatusCreated {
-		return nil, fmt.Errorf("можно подтвердить только бронирования со статусом 'created'")
+		return nil, apperrors.BookingConfirmOnlyCreated.New(nil)
 	}
 
 	if !request.IsContractAccepted {
-		return nil, fmt.Errorf("необходимо принять условия договора аренды")
+		return nil, apperrors.BookingContractNotAccepted.New(nil)
 	}
 
 	booking.Status = domain.BookingStatusAwaitingPayment
@@ -1728,30 +1874,30 @@ func (u *bookingUseCase) ConfirmBooking(bookingID, userID int, request *domain.C
 func (u *bookingUseCase) GetAvailableTimeSlots(apartmentID int, date string, duration int) ([]string, error) {
 	apartment, err := u.apartmentRepo.GetByID(apartmentID)
 	if err != nil {
-		return nil, fmt.Errorf("квартира не найдена: %w", err)
+		return nil, apperrors.BookingApartmentNotFound.Wrap(err, nil)
 	}
 
 	if apartment == nil {
-		return nil, fmt.Errorf("квартира с ID %d не найдена", apartmentID)
+		return nil, apperrors.BookingApartmentNotFoundID.New(i18n.Params{"id": strconv.Itoa(apartmentID)})
 	}
 
 	if apartment.Status != domain.AptStatusApproved {
-		return nil, fmt.Errorf("квартира недоступна для бронирования")
+		return nil, apperrors.BookingApartmentUnavailable.New(nil)
 	}
 
 	if duration == 24 {
 		if !apartment.RentalTypeDaily {
-			return nil, fmt.Errorf("данная квартира не поддерживает посуточную аренду")
+			return nil, apperrors.BookingDailyUnsupported.New(nil)
 		}
 	} else {
 		if !apartment.RentalTypeHourly {
-			return nil, fmt.Errorf("данная квартира не поддерживает почасовую аренду")
+			return nil, apperrors.BookingHourlyUnsupported.New(nil)
 		}
 	}
 
 	targetDate, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		return nil, fmt.Errorf("неверный формат даты: %s (ожидается 2006-01-02)", date)
+		return nil, apperrors.BookingInvalidDate.New(i18n.Params{"value": date})
 	}
 
 	nowUTC := utils.GetCurrentTimeUTC()
@@ -1760,7 +1906,7 @@ func (u *bookingUseCase) GetAvailableTimeSlots(apartmentID int, date string, dur
 
 	targetDateLocal := time.Date(targetDate.Year(), targetDate.Month(), targetDate.Day(), 0, 0, 0, 0, utils.KazakhstanTZ)
 	if targetDateLocal.Before(todayLocal) {
-		return nil, fmt.Errorf("дата не может быть в прошлом")
+		return nil, apperrors.BookingDateInPast.New(nil)
 	}
 
 	var availableSlots []string
@@ -1829,18 +1975,18 @@ func (u *bookingUseCase) AdminUpdateBookingStatus(bookingID int, status domain.B
 		return fmt.Errorf("failed to get admin: %w", err)
 	}
 	if admin == nil {
-		return fmt.Errorf("admin with id %d not found", adminID)
+		return apperrors.BookingUserNotFound.New(nil)
 	}
 	if admin.Role != domain.RoleAdmin {
-		return fmt.Errorf("only admins can update booking status")
+		return apperrors.BookingAdminOnly.New(nil)
 	}
 
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("booking not found: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 	if booking == nil {
-		return fmt.Errorf("booking with id %d not found", bookingID)
+		return apperrors.BookingNotFound.New(nil).WithDetails(map[string]interface{}{"booking_id": bookingID})
 	}
 
 	oldStatus := booking.Status
@@ -1909,25 +2055,25 @@ func (u *bookingUseCase) AdminCancelBooking(bookingID int, reason string, adminI
 		return fmt.Errorf("failed to get admin: %w", err)
 	}
 	if admin == nil {
-		return fmt.Errorf("admin with id %d not found", adminID)
+		return apperrors.BookingUserNotFound.New(nil)
 	}
 	if admin.Role != domain.RoleAdmin {
-		return fmt.Errorf("only admins can cancel bookings")
+		return apperrors.BookingAdminOnly.New(nil)
 	}
 
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return fmt.Errorf("booking not found: %w", err)
+		return apperrors.BookingNotFound.Wrap(err, nil)
 	}
 	if booking == nil {
-		return fmt.Errorf("booking with id %d not found", bookingID)
+		return apperrors.BookingNotFound.New(nil).WithDetails(map[string]interface{}{"booking_id": bookingID})
 	}
 
 	if booking.Status == domain.BookingStatusCanceled {
-		return fmt.Errorf("booking is already canceled")
+		return apperrors.BookingAlreadyCanceled.New(nil)
 	}
 	if booking.Status == domain.BookingStatusCompleted {
-		return fmt.Errorf("cannot cancel completed booking")
+		return apperrors.BookingCannotCancelCompleted.New(nil)
 	}
 
 	if booking.PaymentID != nil {
@@ -2057,16 +2203,16 @@ func (u *bookingUseCase) GetMyBookingsLockAccess(userID int) (*domain.MyBookings
 func (u *bookingUseCase) GetBookingLockAccess(bookingID, userID int) (*domain.BookingLockAccessResponse, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	renter, err := u.renterRepo.GetByUserID(userID)
 	if err != nil {
-		return nil, fmt.Errorf("профиль арендатора не найден: %w", err)
+		return nil, apperrors.BookingRenterProfileNotFound.Wrap(err, nil)
 	}
 
 	if booking.RenterID != renter.ID {
-		return nil, fmt.Errorf("нет прав для доступа к этому бронированию")
+		return nil, apperrors.BookingForbiddenAccess.New(nil)
 	}
 
 	lockAccess, err := u.calculateLockAccessForBooking(booking, userID)
@@ -2223,11 +2369,21 @@ func (u *bookingUseCase) AdminGetBookingStatistics() (map[string]interface{}, er
 func (u *bookingUseCase) ProcessPayment(bookingID int, paymentID string) (*domain.Booking, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	if booking.Status != domain.BookingStatusAwaitingPayment {
-		return nil, fmt.Errorf("можно оплатить только бронирования со статусом 'awaiting_payment'")
+		if u.isBookingPaidWith(booking, paymentID) {
+			logger.Info("payment already processed for this booking",
+				slog.String("payment_id", paymentID),
+				slog.Int("booking_id", bookingID),
+				slog.String("status", string(booking.Status)))
+
+			utils.LoadBookingRelatedData(booking, u.apartmentRepo, u.renterRepo, u.propertyOwnerRepo)
+			u.loadContractID(booking)
+			return booking, nil
+		}
+		return nil, apperrors.BookingPayOnlyAwaiting.New(nil)
 	}
 
 	existingPayment, err := u.paymentRepo.GetByPaymentID(paymentID)
@@ -2250,15 +2406,13 @@ func (u *bookingUseCase) ProcessPayment(bookingID int, paymentID string) (*domai
 			}
 			u.paymentLogRepo.Create(duplicateLog)
 
-			return nil, fmt.Errorf("платеж %s уже использован для бронирования #%d", paymentID, existingPayment.BookingID)
+			return nil, apperrors.BookingPaymentUsedForBooking.New(i18n.Params{"payment_id": paymentID, "booking_id": strconv.FormatInt(existingPayment.BookingID, 10)}).
+				WithDetails(map[string]interface{}{"payment_id": paymentID, "booking_id": existingPayment.BookingID})
 		}
 
-		if booking.Status == domain.BookingStatusApproved || booking.Status == domain.BookingStatusActive {
-			logger.Info("payment already processed for this booking",
-				slog.String("payment_id", paymentID),
-				slog.Int("booking_id", bookingID),
-				slog.String("status", string(booking.Status)))
-			return booking, nil
+		if existingPayment.ExtensionID != nil || existingPayment.Status == domain.PaymentStatusSuccess {
+			return nil, apperrors.BookingPaymentAlreadyUsed.New(i18n.Params{"payment_id": paymentID}).
+				WithDetails(map[string]interface{}{"payment_id": paymentID})
 		}
 	}
 
@@ -2277,28 +2431,56 @@ func (u *bookingUseCase) ProcessPayment(bookingID int, paymentID string) (*domai
 			slog.Int("booking_id", bookingID),
 			slog.String("status", paymentStatus.Status),
 			slog.String("error_message", paymentStatus.ErrorMessage))
-		return nil, fmt.Errorf("платеж не завершен: %s", paymentStatus.Status)
+		return nil, apperrors.BookingPaymentNotCompleted.New(i18n.Params{"status": paymentStatus.Status}).
+			WithDetails(map[string]interface{}{"payment_status": paymentStatus.Status})
 	}
 
-	payment := &domain.Payment{
-		BookingID:      int64(bookingID),
-		PaymentID:      paymentID,
-		Amount:         booking.FinalPrice,
-		Currency:       "KZT",
-		Status:         domain.PaymentStatusSuccess,
-		PaymentMethod:  &paymentStatus.PaymentMethod,
-		ProviderStatus: &paymentStatus.Status,
-		ProcessedAt:    &[]time.Time{time.Now()}[0],
-	}
+	var payment *domain.Payment
+	if existingPayment != nil {
+		// Платеж был инициализирован сервером - сверяем сумму с сохраненной
+		if !paymentAmountMatches(existingPayment.Amount, paymentStatus.Amount) {
+			logger.Error("payment amount mismatch",
+				slog.String("payment_id", paymentID),
+				slog.Int("booking_id", bookingID),
+				slog.Int("expected_amount", existingPayment.Amount),
+				slog.String("provider_amount", paymentStatus.Amount))
+			return nil, apperrors.BookingPaymentAmountMismatch.New(nil)
+		}
 
-	if err := u.paymentRepo.Create(payment); err != nil {
-		logger.Error("failed to save payment record",
-			slog.String("payment_id", paymentID),
-			slog.Int("booking_id", bookingID),
-			slog.String("error", err.Error()))
+		payment = existingPayment
+		payment.Status = domain.PaymentStatusSuccess
+		payment.PaymentMethod = &paymentStatus.PaymentMethod
+		payment.ProviderStatus = &paymentStatus.Status
+		payment.ProcessedAt = &[]time.Time{time.Now()}[0]
+
+		if err := u.paymentRepo.Update(payment); err != nil {
+			logger.Error("failed to update payment record",
+				slog.String("payment_id", paymentID),
+				slog.Int("booking_id", bookingID),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+		}
+	} else {
+		payment = &domain.Payment{
+			BookingID:      int64(bookingID),
+			PaymentID:      paymentID,
+			Amount:         booking.FinalPrice,
+			Currency:       "KZT",
+			Status:         domain.PaymentStatusSuccess,
+			PaymentMethod:  &paymentStatus.PaymentMethod,
+			ProviderStatus: &paymentStatus.Status,
+			ProcessedAt:    &[]time.Time{time.Now()}[0],
+		}
+
+		if err := u.paymentRepo.Create(payment); err != nil {
+			logger.Error("failed to save payment record",
+				slog.String("payment_id", paymentID),
+				slog.Int("booking_id", bookingID),
+				slog.String("error", err.Error()))
 
-		// КРИТИЧНО: если не можем сохранить платеж - останавливаем процесс
-		return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+			// КРИТИЧНО: если не можем сохранить платеж - останавливаем процесс
+			return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+		}
 	}
 
 	logger.Info("payment record created successfully",
@@ -2439,7 +2621,7 @@ func (u *bookingUseCase) ProcessPaymentWithOrder(bookingID int, orderID string)
 	}
 
 	if !paymentStatus.Exists {
-		return nil, fmt.Errorf("платеж с order_id %s не найден: %s", orderID, paymentStatus.ErrorMessage)
+		return nil, apperrors.BookingPaymentOrderNotFound.New(i18n.Params{"order_id": orderID, "reason": paymentStatus.ErrorMessage})
 	}
 
 	logger.Info("converting order_id to payment_id",
@@ -2450,15 +2632,345 @@ func (u *bookingUseCase) ProcessPaymentWithOrder(bookingID int, orderID string)
 	return u.ProcessPayment(bookingID, paymentStatus.PaymentID)
 }
 
+func (u *bookingUseCase) InitBookingPayment(bookingID, userID int) (*domain.InitPaymentResponse, error) {
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
+	}
+
+	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.RenterID != renter.ID {
+		return nil, apperrors.BookingForbiddenPay.New(nil)
+	}
+
+	if booking.Status != domain.BookingStatusAwaitingPayment {
+		return nil, apperrors.BookingPayOnlyAwaiting.New(nil)
+	}
+
+	existingPayments, err := u.paymentRepo.GetByBookingID(int64(bookingID))
+	if err == nil {
+		for _, payment := range existingPayments {
+			if payment.ExtensionID == nil && u.isReusablePendingPayment(payment, booking.FinalPrice) {
+				return buildInitPaymentResponse(payment), nil
+			}
+		}
+	}
+
+	orderID := domain.NewPaymentOrderID(domain.BookingPaymentOrderPrefix, booking.ID)
+	userDBID := int64(userID)
+	request := &domain.InitPaymentRequest{
+		BookingID:   int64(booking.ID),
+		UserID:      &userDBID,
+		OrderID:     orderID,
+		Amount:      booking.FinalPrice,
+		Description: fmt.Sprintf("Оплата бронирования %s", booking.BookingNumber),
+	}
+	u.fillPayerContacts(request, userID)
+
+	payment, err := u.paymentUseCase.InitPayment(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildInitPaymentResponse(payment), nil
+}
+
+func (u *bookingUseCase) InitExtensionPayment(bookingID, extensionID, userID int) (*domain.InitPaymentResponse, error) {
+	extension, err := u.bookingRepo.GetExtensionByID(extensionID)
+	if err != nil {
+		return nil, apperrors.BookingExtensionNotFound.Wrap(err, nil)
+	}
+
+	if extension.BookingID != bookingID {
+		return nil, apperrors.BookingExtensionNotForBooking.New(nil)
+	}
+
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
+	}
+
+	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.RenterID != renter.ID {
+		return nil, apperrors.BookingForbiddenPayExtension.New(nil)
+	}
+
+	if extension.Status != domain.BookingStatusAwaitingPayment {
+		return nil, apperrors.BookingExtensionPayOnlyAwaiting.New(nil)
+	}
+
+	existingPayments, err := u.paymentRepo.GetByExtensionID(int64(extensionID))
+	if err == nil {
+		for _, payment := range existingPayments {
+			if u.isReusablePendingPayment(payment, extension.Price) {
+				return buildInitPaymentResponse(payment), nil
+			}
+		}
+	}
+
+	extensionDBID := int64(extension.ID)
+	userDBID := int64(userID)
+	request := &domain.InitPaymentRequest{
+		BookingID:   int64(booking.ID),
+		ExtensionID: &extensionDBID,
+		UserID:      &userDBID,
+		OrderID:     domain.NewPaymentOrderID(domain.ExtensionPaymentOrderPrefix, extension.ID),
+		Amount:      extension.Price,
+		Description: fmt.Sprintf("Продление бронирования %s на %d ч.", booking.BookingNumber, extension.Duration),
+	}
+	u.fillPayerContacts(request, userID)
+
+	payment, err := u.paymentUseCase.InitPayment(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildInitPaymentResponse(payment), nil
+}
+
+// isReusablePendingPayment позволяет вернуть уже созданную платежную ссылку
+// при повторном нажатии "Оплатить", пока она не истекла
+func (u *bookingUseCase) isReusablePendingPayment(payment *domain.Payment, amount int) bool {
+	if payment.Status != domain.PaymentStatusPending || payment.RedirectURL == nil || *payment.RedirectURL == "" {
+		return false
+	}
+	if payment.Amount != amount {
+		return false
+	}
+	return time.Since(payment.CreatedAt) < pendingPaymentReuseWindow
+}
+
+func (u *bookingUseCase) fillPayerContacts(request *domain.InitPaymentRequest, userID int) {
+	user, err := u.userUseCase.GetByID(userID)
+	if err != nil || user == nil {
+		return
+	}
+	request.UserPhone = user.Phone
+	request.UserEmail = user.Email
+}
+
+func buildInitPaymentResponse(payment *domain.Payment) *domain.InitPaymentResponse {
+	response := &domain.InitPaymentResponse{
+		PaymentID:   payment.ID,
+		FPPaymentID: payment.PaymentID,
+		BookingID:   payment.BookingID,
+		ExtensionID: payment.ExtensionID,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
+		Status:      payment.Status,
+	}
+	if payment.OrderID != nil {
+		response.OrderID = *payment.OrderID
+	}
+	if payment.RedirectURL != nil {
+		response.RedirectURL = *payment.RedirectURL
+	}
+	return response
+}
+
+func paymentAmountMatches(expected int, providerAmount string) bool {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(providerAmount), 64)
+	if err != nil {
+		return false
+	}
+	return int(math.Round(amount)) == expected
+}
+
+func (u *bookingUseCase) HandlePaymentCallback(callback *domain.FreedomPayCallback) (string, string) {
+	var status, description string
+
+	switch {
+	case strings.HasPrefix(callback.OrderID, domain.BookingPaymentOrderPrefix):
+		bookingID, ok := domain.ParsePaymentOrderID(callback.OrderID, domain.BookingPaymentOrderPrefix)
+		if !ok {
+			return domain.FreedomPayResponseRejected, "неизвестный заказ"
+		}
+		status, description = u.handleBookingPaymentCallback(bookingID, callback)
+	case strings.HasPrefix(callback.OrderID, domain.ExtensionPaymentOrderPrefix):
+		extensionID, ok := domain.ParsePaymentOrderID(callback.OrderID, domain.ExtensionPaymentOrderPrefix)
+		if !ok {
+			return domain.FreedomPayResponseRejected, "неизвестный заказ"
+		}
+		status, description = u.handleExtensionPaymentCallback(extensionID, callback)
+	default:
+		logger.Warn("payment callback with unknown order id",
+			slog.String("order_id", callback.OrderID),
+			slog.String("payment_id", callback.PaymentID))
+		return domain.FreedomPayResponseRejected, "неизвестный заказ"
+	}
+
+	logger.Info("payment callback handled",
+		slog.String("type", string(callback.Type)),
+		slog.String("order_id", callback.OrderID),
+		slog.String("payment_id", callback.PaymentID),
+		slog.String("result", callback.Result),
+		slog.String("response_status", status))
+
+	return status, description
+}
+
+func (u *bookingUseCase) handleBookingPaymentCallback(bookingID int, callback *domain.FreedomPayCallback) (string, string) {
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil || booking == nil {
+		return domain.FreedomPayResponseRejected, "бронирование не найдено"
+	}
+
+	if callback.Type == domain.FreedomPayCallbackCheck {
+		if booking.Status != domain.BookingStatusAwaitingPayment {
+			return domain.FreedomPayResponseRejected, "бронирование не ожидает оплаты"
+		}
+		if !paymentAmountMatches(booking.FinalPrice, callback.Amount) {
+			return domain.FreedomPayResponseRejected, "сумма не совпадает с суммой бронирования"
+		}
+		return domain.FreedomPayResponseOK, ""
+	}
+
+	if !callback.IsSuccessful() {
+		u.markCallbackPaymentFailed(int64(bookingID), callback)
+		return domain.FreedomPayResponseOK, ""
+	}
+
+	if booking.Status != domain.BookingStatusAwaitingPayment && !u.isBookingPaidWith(booking, callback.PaymentID) {
+		// Бронирование уже отменено или удалено очисткой - FreedomPay вернет деньги при rejected
+		u.logPaymentCallback(int64(bookingID), nil, callback, false, "бронирование не ожидает оплаты")
+		return domain.FreedomPayResponseRejected, "бронирование не ожидает оплаты"
+	}
+
+	processed, err := u.ProcessPayment(bookingID, callback.PaymentID)
+	if err != nil {
+		u.logPaymentCallback(int64(bookingID), nil, callback, false, err.Error())
+		return domain.FreedomPayResponseError, "ошибка обработки платежа"
+	}
+
+	u.logPaymentCallback(int64(bookingID), processed.PaymentID, callback, true, "")
+	return domain.FreedomPayResponseOK, ""
+}
+
+func (u *bookingUseCase) handleExtensionPaymentCallback(extensionID int, callback *domain.FreedomPayCallback) (string, string) {
+	extension, err := u.bookingRepo.GetExtensionByID(extensionID)
+	if err != nil || extension == nil {
+		return domain.FreedomPayResponseRejected, "продление не найдено"
+	}
+
+	if callback.Type == domain.FreedomPayCallbackCheck {
+		if extension.Status != domain.BookingStatusAwaitingPayment {
+			return domain.FreedomPayResponseRejected, "продление не ожидает оплаты"
+		}
+		if !paymentAmountMatches(extension.Price, callback.Amount) {
+			return domain.FreedomPayResponseRejected, "сумма не совпадает с суммой продления"
+		}
+		return domain.FreedomPayResponseOK, ""
+	}
+
+	if !callback.IsSuccessful() {
+		u.markCallbackPaymentFailed(int64(extension.BookingID), callback)
+		return domain.FreedomPayResponseOK, ""
+	}
+
+	processed, err := u.ProcessExtensionPayment(extensionID, callback.PaymentID)
+	if err != nil {
+		u.logPaymentCallback(int64(extension.BookingID), nil, callback, false, err.Error())
+		if extension.Status != domain.BookingStatusAwaitingPayment {
+			return domain.FreedomPayResponseRejected, "продление не ожидает оплаты"
+		}
+		return domain.FreedomPayResponseError, "ошибка обработки платежа"
+	}
+
+	u.logPaymentCallback(int64(extension.BookingID), processed.PaymentID, callback, true, "")
+	return domain.FreedomPayResponseOK, ""
+}
+
+func (u *bookingUseCase) markCallbackPaymentFailed(bookingID int64, callback *domain.FreedomPayCallback) {
+	errorMessage := fmt.Sprintf("%s %s", callback.FailureCode, callback.FailureDescription)
+
+	payment, err := u.paymentRepo.GetByPaymentID(callback.PaymentID)
+	if err != nil || payment == nil {
+		u.logPaymentCallback(bookingID, nil, callback, false, errorMessage)
+		return
+	}
+
+	if payment.Status == domain.PaymentStatusPending || payment.Status == domain.PaymentStatusProcessing {
+		payment.Status = domain.PaymentStatusFailed
+		payment.ProviderStatus = &callback.Result
+		if updateErr := u.paymentRepo.Update(payment); updateErr != nil {
+			logger.Warn("failed to mark payment as failed",
+				slog.String("payment_id", callback.PaymentID),
+				slog.String("error", updateErr.Error()))
+		}
+	}
+
+	u.logPaymentCallback(bookingID, &payment.ID, callback, false, errorMessage)
+}
+
+func (u *bookingUseCase) logPaymentCallback(bookingID int64, paymentDBID *int64, callback *domain.FreedomPayCallback, success bool, errorMessage string) {
+	logEntry := &domain.PaymentLog{
+		PaymentID:   paymentDBID,
+		BookingID:   bookingID,
+		FPPaymentID: callback.PaymentID,
+		Action:      domain.PaymentLogActionWebhookNotification,
+		FPResponse: &domain.FreedomPayStatusResponse{
+			PaymentID:     callback.PaymentID,
+			OrderID:       callback.OrderID,
+			Amount:        callback.Amount,
+			Currency:      callback.Currency,
+			PaymentMethod: callback.PaymentMethod,
+			CardPan:       callback.CardPan,
+			Captured:      callback.Captured,
+			CreateDate:    callback.PaymentDate,
+			ErrorCode:     callback.FailureCode,
+		},
+		Source:  domain.PaymentLogSourceWebhook,
+		Success: success,
+	}
+
+	if errorMessage != "" {
+		logEntry.ErrorMessage = &errorMessage
+	}
+	if callback.IPAddress != "" {
+		logEntry.IPAddress = &callback.IPAddress
+	}
+	if callback.UserAgent != "" {
+		logEntry.UserAgent = &callback.UserAgent
+	}
+
+	if logErr := u.paymentLogRepo.Create(logEntry); logErr != nil {
+		logger.Warn("failed to save payment callback log",
+			slog.String("payment_id", callback.PaymentID),
+			slog.Int64("booking_id", bookingID),
+			slog.String("error", logErr.Error()))
+	}
+}
+
+func (u *bookingUseCase) isBookingPaidWith(booking *domain.Booking, paymentID string) bool {
+	if booking.PaymentID == nil {
+		return false
+	}
+
+	payment, err := u.paymentRepo.GetByID(*booking.PaymentID)
+	if err != nil || payment == nil {
+		return false
+	}
+
+	return payment.PaymentID == paymentID && payment.Status == domain.PaymentStatusSuccess
+}
+
 func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.PaymentReceipt, error) {
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return nil, fmt.Errorf("бронирование не найдено: %w", err)
+		return nil, apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	user, userErr := u.userUseCase.GetByID(userID)
 	if userErr != nil {
-		return nil, fmt.Errorf("пользователь не найден: %w", userErr)
+		return nil, apperrors.BookingUserNotFound.Wrap(userErr, nil)
 	}
 
 	if user.Role == domain.RoleAdmin {
@@ -2473,19 +2985,19 @@ func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.Payme
 			return nil, fmt.Errorf("данные владельца не найдены: %w", ownerErr)
 		}
 		if owner == nil {
-			return nil, fmt.Errorf("пользователь не является зарегистрированным владельцем")
+			return nil, apperrors.BookingNotRegisteredOwner.New(nil)
 		}
 		if apartment.OwnerID != owner.ID {
-			return nil, fmt.Errorf("отказано в доступе: вы не являетесь владельцем данной квартиры")
+			return nil, apperrors.BookingReceiptNotApartmentOwner.New(nil)
 		}
 	} else {
 		renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
 		if err != nil {
-			return nil, fmt.Errorf("отказано в доступе: недостаточно прав для просмотра чека (роль: %s)", user.Role)
+			return nil, apperrors.BookingReceiptRoleForbidden.New(i18n.Params{"role": string(user.Role)})
 		}
 
 		if booking.RenterID != renter.ID {
-			return nil, fmt.Errorf("отказано в доступе: вы можете просматривать только свои чеки об оплате")
+			return nil, apperrors.BookingReceiptOnlyOwn.New(nil)
 		}
 	}
 
@@ -2495,13 +3007,13 @@ func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.Payme
 	if booking.PaymentID != nil {
 		paymentRecord, err = u.paymentRepo.GetByID(*booking.PaymentID)
 		if err != nil {
-			return nil, fmt.Errorf("платеж не найден: %w", err)
+			return nil, apperrors.BookingPaymentNotFound.Wrap(err, nil)
 		}
 		fpPaymentID = paymentRecord.PaymentID
 	} else {
 		paymentRecords, err := u.paymentRepo.GetByBookingID(int64(bookingID))
 		if err != nil || len(paymentRecords) == 0 {
-			return nil, fmt.Errorf("платеж для данного бронирования не найден")
+			return nil, apperrors.BookingReceiptPaymentNotFound.New(nil)
 		}
 		for i := len(paymentRecords) - 1; i >= 0; i-- {
 			if paymentRecords[i].Status == domain.PaymentStatusSuccess {
@@ -2510,7 +3022,7 @@ func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.Payme
 			}
 		}
 		if paymentRecord == nil {
-			return nil, fmt.Errorf("успешный платеж для данного бронирования не найден")
+			return nil, apperrors.BookingReceiptSuccessfulPaymentNotFound.New(nil)
 		}
 		fpPaymentID = paymentRecord.PaymentID
 	}
@@ -2521,11 +3033,11 @@ func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.Payme
 			slog.Int("booking_id", bookingID),
 			slog.String("payment_id", fpPaymentID),
 			slog.String("error", err.Error()))
-		return nil, fmt.Errorf("ошибка получения данных платежа")
+		return nil, apperrors.BookingReceiptPaymentDataError.New(nil)
 	}
 
 	if !paymentStatus.Exists {
-		return nil, fmt.Errorf("платеж не найден в системе")
+		return nil, apperrors.BookingReceiptPaymentNotInSystem.New(nil)
 	}
 
 	var cardPan string = "****-****-****-****"
@@ -2539,7 +3051,9 @@ func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.Payme
 	utils.LoadBookingRelatedData(booking, u.apartmentRepo, u.renterRepo, u.propertyOwnerRepo)
 
 	rentalType := "hourly"
-	if booking.Duration == 24 {
+	if booking.Nights > 0 {
+		rentalType = "nightly"
+	} else if booking.Duration == 24 {
 		rentalType = "daily"
 	}
 
@@ -2551,26 +3065,33 @@ func (u *bookingUseCase) GetPaymentReceipt(bookingID, userID int) (*domain.Payme
 
 	receiptID := fmt.Sprintf("RECEIPT-%s", fpPaymentID)
 
+	orderID := fmt.Sprintf("%s%d", domain.BookingPaymentOrderPrefix, booking.ID)
+	if paymentRecord.OrderID != nil && *paymentRecord.OrderID != "" {
+		orderID = *paymentRecord.OrderID
+	}
+
 	receipt := &domain.PaymentReceipt{
 		ReceiptID:     receiptID,
 		BookingNumber: booking.BookingNumber,
 		PaymentID:     fpPaymentID,
-		OrderID:       fmt.Sprintf("booking_%d", booking.ID),
+		OrderID:       orderID,
 		Status:        "paid",
 		PaymentDate:   paymentStatus.CreateDate,
 		PaymentMethod: paymentStatus.PaymentMethod,
 		CardPan:       cardPan,
 		Amounts: domain.ReceiptAmounts{
-			TotalPrice: booking.TotalPrice,
-			ServiceFee: booking.ServiceFee,
-			FinalPrice: booking.FinalPrice,
-			Currency:   paymentStatus.Currency,
+			TotalPrice:     booking.TotalPrice,
+			ServiceFee:     booking.ServiceFee,
+			FinalPrice:     booking.FinalPrice,
+			Currency:       paymentStatus.Currency,
+			PriceBreakdown: booking.PriceBreakdown,
 		},
 		BookingDetails: domain.ReceiptBookingDetails{
 			ApartmentAddress: apartmentAddress,
 			StartDate:        booking.StartDate.Format(time.RFC3339),
 			EndDate:          booking.EndDate.Format(time.RFC3339),
 			DurationHours:    booking.Duration,
+			Nights:           booking.Nights,
 			RentalType:       rentalType,
 		},
 		CreatedAt: time.Now().Format(time.RFC3339),
@@ -0,0 +1,627 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/ical"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+const (
+	calendarProductID         = "-//renti.kz//Apartment availability//RU"
+	calendarEventSummary      = "Renti.kz: занято"
+	calendarExportPath        = "/api/apartments/calendar/"
+	calendarExportHistoryDays = 30
+	calendarExportBlocksDays  = 730
+	maxCalendarFeedBytes      = 5 << 20
+	maxExternalCalendars      = 10
+	maxCalendarTextLength     = 512
+	maxCalendarRedirects      = 5
+	calendarDialTimeout       = 10 * time.Second
+	calendarLookupTimeout     = 5 * time.Second
+)
+
+var errCalendarAddressForbidden = errors.New("адрес календаря указывает на внутреннюю сеть")
+
+// calendarReservedNetworks диапазоны, которые не покрываются методами net.IP, но тоже не являются публичными
+var calendarReservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"100.64.0.0/10",
+	"192.0.0.0/24",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"64:ff9b::/96",
+)
+
+// exportedBookingStatuses бронирования, которые блокируют квартиру на других площадках
+var exportedBookingStatuses = []domain.BookingStatus{
+	domain.BookingStatusPending,
+	domain.BookingStatusApproved,
+	domain.BookingStatusActive,
+}
+
+type calendarUseCase struct {
+	calendarRepo        domain.CalendarRepository
+	bookingRepo         domain.BookingRepository
+	blockRepo           domain.AvailabilityBlockRepository
+	apartmentRepo       domain.ApartmentRepository
+	availabilityService domain.ApartmentAvailabilityService
+	httpClient          *http.Client
+	publicBaseURL       string
+	// allowAddress решает, можно ли ходить на адрес за внешним календарем. Проверяется и до запроса,
+	// и при каждом подключении, чтобы подмена DNS после проверки не открывала доступ во внутреннюю сеть.
+	allowAddress func(ip net.IP) bool
+}
+
+func NewCalendarUseCase(
+	calendarRepo domain.CalendarRepository,
+	bookingRepo domain.BookingRepository,
+	blockRepo domain.AvailabilityBlockRepository,
+	apartmentRepo domain.ApartmentRepository,
+	availabilityService domain.ApartmentAvailabilityService,
+	fetchTimeout time.Duration,
+	publicBaseURL string,
+) domain.CalendarUseCase {
+	useCase := &calendarUseCase{
+		calendarRepo:        calendarRepo,
+		bookingRepo:         bookingRepo,
+		blockRepo:           blockRepo,
+		apartmentRepo:       apartmentRepo,
+		availabilityService: availabilityService,
+		publicBaseURL:       strings.TrimRight(publicBaseURL, "/"),
+		allowAddress:        isPublicAddress,
+	}
+	useCase.httpClient = useCase.newFeedClient(fetchTimeout)
+
+	return useCase
+}
+
+func (u *calendarUseCase) GetExportInfo(apartmentID int) (*domain.CalendarExportInfo, error) {
+	token, err := u.calendarRepo.GetExportToken(apartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if token == nil {
+		return u.RegenerateExportToken(apartmentID)
+	}
+
+	return u.exportInfo(apartmentID, *token), nil
+}
+
+// RegenerateExportToken выпускает новый секретный токен фида, старая ссылка перестает работать.
+func (u *calendarUseCase) RegenerateExportToken(apartmentID int) (*domain.CalendarExportInfo, error) {
+	token, err := generateCalendarToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.calendarRepo.SetExportToken(apartmentID, token); err != nil {
+		return nil, err
+	}
+
+	return u.exportInfo(apartmentID, token), nil
+}
+
+func (u *calendarUseCase) exportInfo(apartmentID int, token string) *domain.CalendarExportInfo {
+	return &domain.CalendarExportInfo{
+		ApartmentID: apartmentID,
+		Token:       token,
+		URL:         u.publicBaseURL + calendarExportPath + token + ".ics",
+	}
+}
+
+// RenderExportFeed строит iCal-фид занятых периодов квартиры по секретному токену.
+func (u *calendarUseCase) RenderExportFeed(token string) ([]byte, error) {
+	token = strings.TrimSuffix(token, ".ics")
+	if token == "" {
+		return nil, nil
+	}
+
+	apartmentID, err := u.calendarRepo.GetApartmentIDByExportToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if apartmentID == 0 {
+		return nil, nil
+	}
+
+	bookings, err := u.bookingRepo.GetByApartmentID(apartmentID, exportedBookingStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирований: %w", err)
+	}
+
+	now := utils.GetCurrentTimeUTC()
+	since := now.AddDate(0, 0, -calendarExportHistoryDays)
+
+	calendar := &ical.Calendar{
+		ProductID: calendarProductID,
+		Name:      fmt.Sprintf("Renti.kz - квартира %d", apartmentID),
+	}
+	for _, booking := range bookings {
+		if booking.EndDate.Before(since) {
+			continue
+		}
+		calendar.Events = append(calendar.Events, bookingToCalendarEvent(booking, now))
+	}
+
+	// Блокировки владельца выгружаются без причины и комментария
+	blocks, err := u.blockRepo.GetByApartmentID(apartmentID, since, now.AddDate(0, 0, calendarExportBlocksDays))
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range blocks {
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:     fmt.Sprintf("block-%d@renti.kz", block.ID),
+			Summary: calendarEventSummary,
+			Start:   block.StartDate,
+			End:     block.EndDate,
+			Stamp:   now,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := calendar.Write(&buf); err != nil {
+		return nil, fmt.Errorf("ошибка формирования календаря: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// bookingToCalendarEvent посуточные бронирования выгружаются целыми днями (как их ожидают
+// другие площадки), почасовые - точным периодом вместе со временем уборки.
+func bookingToCalendarEvent(booking *domain.Booking, now time.Time) ical.Event {
+	event := ical.Event{
+		UID:     fmt.Sprintf("booking-%d@renti.kz", booking.ID),
+		Summary: calendarEventSummary,
+		Stamp:   now,
+	}
+
+	if booking.Nights > 0 {
+		checkIn := utils.ConvertOutputFromUTC(booking.StartDate)
+		checkOut := utils.ConvertOutputFromUTC(booking.EndDate)
+		event.AllDay = true
+		event.Start = time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, utils.KazakhstanTZ)
+		event.End = time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 0, 0, 0, 0, utils.KazakhstanTZ)
+		return event
+	}
+
+	event.Start = booking.StartDate
+	event.End = booking.EndDate.Add(time.Duration(booking.CleaningDuration) * time.Minute)
+	return event
+}
+
+func (u *calendarUseCase) GetExternalCalendars(apartmentID int) ([]*domain.ExternalCalendar, error) {
+	return u.calendarRepo.GetExternalCalendarsByApartmentID(apartmentID)
+}
+
+func (u *calendarUseCase) AddExternalCalendar(apartmentID int, request *domain.CreateExternalCalendarRequest) (*domain.ExternalCalendar, *domain.CalendarSyncResult, error) {
+	feedURL, err := normalizeCalendarURL(request.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.checkFeedURL(feedURL); err != nil {
+		return nil, nil, err
+	}
+
+	existing, err := u.calendarRepo.GetExternalCalendarsByApartmentID(apartmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(existing) >= maxExternalCalendars {
+		return nil, nil, fmt.Errorf("к квартире можно подключить не более %d внешних календарей", maxExternalCalendars)
+	}
+	for _, calendar := range existing {
+		if calendar.URL == feedURL {
+			return nil, nil, fmt.Errorf("этот календарь уже подключен")
+		}
+	}
+
+	calendar := &domain.ExternalCalendar{
+		ApartmentID: apartmentID,
+		Name:        strings.TrimSpace(request.Name),
+		URL:         feedURL,
+		IsActive:    true,
+	}
+	if err := u.calendarRepo.CreateExternalCalendar(calendar); err != nil {
+		return nil, nil, err
+	}
+
+	result := u.syncCalendar(calendar)
+
+	return calendar, result, nil
+}
+
+func (u *calendarUseCase) UpdateExternalCalendar(apartmentID, calendarID int, request *domain.UpdateExternalCalendarRequest) (*domain.ExternalCalendar, error) {
+	calendar, err := u.getApartmentCalendar(apartmentID, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		calendar.Name = strings.TrimSpace(*request.Name)
+	}
+	if request.IsActive != nil {
+		calendar.IsActive = *request.IsActive
+	}
+
+	if err := u.calendarRepo.UpdateExternalCalendar(calendar); err != nil {
+		return nil, err
+	}
+
+	u.recalculateAvailability(apartmentID)
+
+	return calendar, nil
+}
+
+func (u *calendarUseCase) DeleteExternalCalendar(apartmentID, calendarID int) error {
+	if _, err := u.getApartmentCalendar(apartmentID, calendarID); err != nil {
+		return err
+	}
+
+	if err := u.calendarRepo.DeleteExternalCalendar(calendarID); err != nil {
+		return err
+	}
+
+	u.recalculateAvailability(apartmentID)
+
+	return nil
+}
+
+func (u *calendarUseCase) GetExternalEvents(apartmentID int) ([]*domain.ExternalCalendarEvent, error) {
+	return u.calendarRepo.GetExternalEventsByApartmentID(apartmentID, utils.GetCurrentTimeUTC())
+}
+
+func (u *calendarUseCase) SyncExternalCalendar(apartmentID, calendarID int) (*domain.CalendarSyncResult, error) {
+	calendar, err := u.getApartmentCalendar(apartmentID, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.syncCalendar(calendar), nil
+}
+
+// SyncAllExternalCalendars импортирует все активные внешние календари, ошибки одного календаря не прерывают остальные.
+func (u *calendarUseCase) SyncAllExternalCalendars() []*domain.CalendarSyncResult {
+	calendars, err := u.calendarRepo.GetActiveExternalCalendars()
+	if err != nil {
+		logger.Error("failed to load external calendars for sync", slog.String("error", err.Error()))
+		return nil
+	}
+
+	results := make([]*domain.CalendarSyncResult, 0, len(calendars))
+	for _, calendar := range calendars {
+		results = append(results, u.syncCalendar(calendar))
+	}
+
+	return results
+}
+
+func (u *calendarUseCase) getApartmentCalendar(apartmentID, calendarID int) (*domain.ExternalCalendar, error) {
+	calendar, err := u.calendarRepo.GetExternalCalendarByID(calendarID)
+	if err != nil {
+		return nil, err
+	}
+	if calendar == nil || calendar.ApartmentID != apartmentID {
+		return nil, fmt.Errorf("внешний календарь с ID %d не найден", calendarID)
+	}
+
+	return calendar, nil
+}
+
+// syncCalendar загружает фид и заменяет импортированные события. При ошибке загрузки
+// ранее импортированные события сохраняются, чтобы временный сбой площадки не открывал даты.
+func (u *calendarUseCase) syncCalendar(calendar *domain.ExternalCalendar) *domain.CalendarSyncResult {
+	now := utils.GetCurrentTimeUTC()
+	result := &domain.CalendarSyncResult{
+		CalendarID:  calendar.ID,
+		ApartmentID: calendar.ApartmentID,
+		SyncedAt:    now,
+	}
+
+	events, err := u.importEvents(calendar, now)
+	if err == nil {
+		err = u.calendarRepo.ReplaceExternalEvents(calendar.ID, events)
+	}
+
+	calendar.LastSyncedAt = &now
+	if err != nil {
+		message := err.Error()
+		calendar.LastSyncStatus = domain.ExternalCalendarSyncFailed
+		calendar.LastSyncError = &message
+		result.Status = domain.ExternalCalendarSyncFailed
+		result.Error = message
+		result.EventsCount = calendar.EventsCount
+
+		logger.Warn("external calendar sync failed",
+			slog.Int("calendar_id", calendar.ID),
+			slog.Int("apartment_id", calendar.ApartmentID),
+			slog.String("error", message))
+	} else {
+		calendar.LastSyncStatus = domain.ExternalCalendarSyncSuccess
+		calendar.LastSyncError = nil
+		calendar.EventsCount = len(events)
+		result.Status = domain.ExternalCalendarSyncSuccess
+		result.EventsCount = len(events)
+
+		logger.Info("external calendar synced",
+			slog.Int("calendar_id", calendar.ID),
+			slog.Int("apartment_id", calendar.ApartmentID),
+			slog.Int("events", len(events)))
+	}
+
+	if err := u.calendarRepo.UpdateSyncStatus(calendar); err != nil {
+		logger.Warn("failed to save external calendar sync status",
+			slog.Int("calendar_id", calendar.ID),
+			slog.String("error", err.Error()))
+	}
+
+	if result.Status == domain.ExternalCalendarSyncSuccess {
+		u.recalculateAvailability(calendar.ApartmentID)
+	}
+
+	return result
+}
+
+func (u *calendarUseCase) importEvents(calendar *domain.ExternalCalendar, now time.Time) ([]*domain.ExternalCalendarEvent, error) {
+	apartment, err := u.apartmentRepo.GetByID(calendar.ApartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения квартиры: %w", err)
+	}
+	if apartment == nil {
+		return nil, fmt.Errorf("квартира с ID %d не найдена", calendar.ApartmentID)
+	}
+
+	feed, err := u.fetchFeed(calendar.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := ical.Parse(bytes.NewReader(feed), utils.KazakhstanTZ)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.ExternalCalendarEvent, 0, len(parsed))
+	for _, item := range parsed {
+		if item.Status == ical.StatusCancelled {
+			continue
+		}
+
+		start, end := item.Start, item.End
+		if item.AllDay {
+			// Целые дни других площадок переводим во время заезда/выезда квартиры,
+			// чтобы день выезда гостя оставался доступным для заезда после уборки
+			start, end = allDayStayPeriod(apartment, item.Start, item.End)
+		}
+		if !end.After(start) || !end.After(now) {
+			continue
+		}
+
+		uid := item.UID
+		if uid == "" {
+			uid = fmt.Sprintf("%s-%s", start.UTC().Format("20060102T150405Z"), end.UTC().Format("20060102T150405Z"))
+		}
+
+		events = append(events, &domain.ExternalCalendarEvent{
+			CalendarID:  calendar.ID,
+			ApartmentID: calendar.ApartmentID,
+			UID:         truncateCalendarText(uid),
+			Summary:     truncateCalendarText(item.Summary),
+			StartDate:   start.UTC(),
+			EndDate:     end.UTC(),
+		})
+	}
+
+	return events, nil
+}
+
+func allDayStayPeriod(apartment *domain.Apartment, startDate, endDate time.Time) (time.Time, time.Time) {
+	start, err := utils.WithClockTime(startDate, apartment.CheckInTime, utils.DefaultCheckInTime)
+	if err != nil {
+		return startDate, endDate
+	}
+	end, err := utils.WithClockTime(endDate, apartment.CheckOutTime, utils.DefaultCheckOutTime)
+	if err != nil || !end.After(start) {
+		return startDate, endDate
+	}
+
+	return start, end
+}
+
+func (u *calendarUseCase) fetchFeed(feedURL string) ([]byte, error) {
+	if err := u.checkFeedURL(feedURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("некорректный адрес календаря: %w", err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+	req.Header.Set("User-Agent", "renti.kz-calendar-sync/1.0")
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, errCalendarAddressForbidden) {
+			return nil, errCalendarAddressForbidden
+		}
+		return nil, fmt.Errorf("ошибка загрузки календаря: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("площадка вернула статус %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCalendarFeedBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения календаря: %w", err)
+	}
+	if len(body) > maxCalendarFeedBytes {
+		return nil, fmt.Errorf("размер календаря превышает %d МБ", maxCalendarFeedBytes>>20)
+	}
+
+	return body, nil
+}
+
+// newFeedClient HTTP-клиент для загрузки внешних календарей. Адрес проверяется в момент подключения,
+// прокси не используется, а каждое перенаправление проверяется заново.
+func (u *calendarUseCase) newFeedClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: calendarDialTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !u.allowAddress(ip) {
+				return errCalendarAddressForbidden
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxCalendarRedirects {
+				return fmt.Errorf("слишком много перенаправлений при загрузке календаря")
+			}
+			if scheme := strings.ToLower(req.URL.Scheme); scheme != "http" && scheme != "https" {
+				return fmt.Errorf("недопустимое перенаправление календаря на %s://", scheme)
+			}
+			return u.checkFeedURL(req.URL.String())
+		},
+	}
+}
+
+// checkFeedURL проверяет, что все адреса хоста календаря публичные.
+func (u *calendarUseCase) checkFeedURL(feedURL string) error {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Hostname() == "" {
+		return fmt.Errorf("некорректный адрес календаря")
+	}
+
+	host := parsed.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !u.allowAddress(ip) {
+			return errCalendarAddressForbidden
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), calendarLookupTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("не удалось найти адрес календаря %s", host)
+	}
+	for _, addr := range addrs {
+		if !u.allowAddress(addr.IP) {
+			return errCalendarAddressForbidden
+		}
+	}
+
+	return nil
+}
+
+// isPublicAddress отсекает loopback, частные, link-local (в том числе метаданные облака 169.254.169.254),
+// multicast, неуказанные и зарезервированные адреса.
+func isPublicAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range calendarReservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func (u *calendarUseCase) recalculateAvailability(apartmentID int) {
+	if u.availabilityService == nil {
+		return
+	}
+
+	if err := u.availabilityService.RecalculateApartmentAvailability(apartmentID); err != nil {
+		logger.Warn("failed to recalculate apartment availability after calendar sync",
+			slog.Int("apartment_id", apartmentID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// normalizeCalendarURL принимает http(s) и webcal ссылки, webcal заменяется на https.
+func normalizeCalendarURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("некорректный адрес календаря")
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+	case "webcal", "webcals":
+		parsed.Scheme = "https"
+	default:
+		return "", fmt.Errorf("адрес календаря должен начинаться с http://, https:// или webcal://")
+	}
+
+	return parsed.String(), nil
+}
+
+func truncateCalendarText(value string) string {
+	if utf8.RuneCountInString(value) <= maxCalendarTextLength {
+		return value
+	}
+	return string([]rune(value)[:maxCalendarTextLength])
+}
+
+func generateCalendarToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("ошибка генерации токена календаря: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
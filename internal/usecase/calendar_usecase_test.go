@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+)
+
+type calendarApartmentRepoStub struct {
+	domain.ApartmentRepository
+	apartment *domain.Apartment
+}
+
+func (r *calendarApartmentRepoStub) GetByID(id int) (*domain.Apartment, error) {
+	return r.apartment, nil
+}
+
+// newTestCalendarUseCase поднимает use case, которому разрешен loopback: только так можно
+// проверить импорт на локальном HTTP-сервере, изображающем площадку.
+func newTestCalendarUseCase(allowLoopback bool) *calendarUseCase {
+	useCase := &calendarUseCase{
+		apartmentRepo: &calendarApartmentRepoStub{apartment: &domain.Apartment{
+			ID:           7,
+			CheckInTime:  "14:00",
+			CheckOutTime: "12:00",
+		}},
+		allowAddress: isPublicAddress,
+	}
+	if allowLoopback {
+		useCase.allowAddress = func(ip net.IP) bool {
+			return ip.IsLoopback() || isPublicAddress(ip)
+		}
+	}
+	useCase.httpClient = useCase.newFeedClient(5 * time.Second)
+
+	return useCase
+}
+
+func testCalendarFeed(now time.Time) string {
+	start := now.AddDate(0, 0, 3)
+	end := start.AddDate(0, 0, 2)
+	return strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//stand-in//EN",
+		"BEGIN:VEVENT",
+		"UID:reserved-1@stand-in",
+		"SUMMARY:Reserved",
+		"DTSTART;VALUE=DATE:" + start.Format("20060102"),
+		"DTEND;VALUE=DATE:" + end.Format("20060102"),
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:cancelled-1@stand-in",
+		"STATUS:CANCELLED",
+		"DTSTART;VALUE=DATE:" + start.Format("20060102"),
+		"DTEND;VALUE=DATE:" + end.Format("20060102"),
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+}
+
+func TestImportEventsFromLocalFeed(t *testing.T) {
+	now := time.Now().UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar")
+		fmt.Fprint(w, testCalendarFeed(now))
+	}))
+	defer server.Close()
+
+	useCase := newTestCalendarUseCase(true)
+	calendar := &domain.ExternalCalendar{ID: 1, ApartmentID: 7, URL: server.URL + "/feed.ics"}
+
+	events, err := useCase.importEvents(calendar, now)
+	if err != nil {
+		t.Fatalf("importEvents: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.UID != "reserved-1@stand-in" || event.CalendarID != 1 || event.ApartmentID != 7 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	// Целые дни переводятся во время заезда 14:00 и выезда 12:00 по Алматы (UTC+5)
+	if event.StartDate.Hour() != 9 || event.EndDate.Hour() != 7 {
+		t.Fatalf("unexpected stay period: %s - %s", event.StartDate, event.EndDate)
+	}
+}
+
+func TestFetchFeedRejectsInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal address must not be requested")
+	}))
+	defer server.Close()
+
+	useCase := newTestCalendarUseCase(false)
+
+	urls := []string{
+		server.URL,
+		"http://127.0.0.1:6379/",
+		"http://[::1]:5432/",
+		"http://10.0.0.5/feed.ics",
+		"http://172.16.3.4/feed.ics",
+		"http://192.168.1.10/feed.ics",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://0.0.0.0/",
+		"http://[::ffff:127.0.0.1]/",
+		"http://localhost:8080/",
+	}
+	for _, feedURL := range urls {
+		if _, err := useCase.fetchFeed(feedURL); !errors.Is(err, errCalendarAddressForbidden) {
+			t.Errorf("%s: expected forbidden address error, got %v", feedURL, err)
+		}
+	}
+}
+
+func TestFetchFeedRejectsRedirectToInternalAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	useCase := newTestCalendarUseCase(true)
+
+	if _, err := useCase.fetchFeed(server.URL); !errors.Is(err, errCalendarAddressForbidden) {
+		t.Fatalf("expected forbidden address error, got %v", err)
+	}
+}
+
+func TestFeedClientChecksAddressOnConnect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal address must not be requested")
+	}))
+	defer server.Close()
+
+	// Проверка URL пропускает адрес, как после подмены DNS, но подключение все равно запрещено
+	useCase := newTestCalendarUseCase(false)
+
+	_, err := useCase.httpClient.Get(server.URL)
+	if !errors.Is(err, errCalendarAddressForbidden) {
+		t.Fatalf("expected forbidden address error on connect, got %v", err)
+	}
+}
@@ -1,18 +1,27 @@
 package usecase
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/russo2642/renti_kz/internal/domain"
 )
 
 type cancellationRuleUseCase struct {
-	cancellationRuleRepo domain.CancellationRuleRepository
+	cancellationRuleRepo   domain.CancellationRuleRepository
+	cancellationPolicyRepo domain.CancellationPolicyRepository
+	settingsUseCase        domain.PlatformSettingsUseCase
 }
 
 func NewCancellationRuleUseCase(
 	cancellationRuleRepo domain.CancellationRuleRepository,
+	cancellationPolicyRepo domain.CancellationPolicyRepository,
+	settingsUseCase domain.PlatformSettingsUseCase,
 ) domain.CancellationRuleUseCase {
 	return &cancellationRuleUseCase{
-		cancellationRuleRepo: cancellationRuleRepo,
+		cancellationRuleRepo:   cancellationRuleRepo,
+		cancellationPolicyRepo: cancellationPolicyRepo,
+		settingsUseCase:        settingsUseCase,
 	}
 }
 
@@ -23,3 +32,91 @@ func (u *cancellationRuleUseCase) GetActiveCancellationRules() ([]*domain.Cancel
 func (u *cancellationRuleUseCase) GetCancellationRulesByType(ruleType domain.CancellationRuleType) ([]*domain.CancellationRule, error) {
 	return u.cancellationRuleRepo.GetByType(ruleType)
 }
+
+func (u *cancellationRuleUseCase) GetCancellationPolicies() ([]*domain.CancellationPolicy, error) {
+	return u.cancellationPolicyRepo.GetAll()
+}
+
+func (u *cancellationRuleUseCase) GetApartmentCancellationPolicy(apartmentID int) (*domain.CancellationPolicy, error) {
+	code, err := u.cancellationPolicyRepo.GetApartmentPolicyCode(apartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if code == nil {
+		defaultCode, _ := u.settingsUseCase.GetDefaultCancellationPolicy()
+		code = &defaultCode
+	}
+
+	policy, err := u.cancellationPolicyRepo.GetByCode(*code)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения политики отмены: %w", err)
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("политика отмены %s не найдена", *code)
+	}
+
+	return policy, nil
+}
+
+func (u *cancellationRuleUseCase) SetApartmentCancellationPolicy(apartmentID int, code *domain.CancellationPolicyCode) error {
+	if code != nil {
+		if !code.IsValid() {
+			return fmt.Errorf("политика отмены должна быть одной из: flexible, moderate, strict")
+		}
+
+		policy, err := u.cancellationPolicyRepo.GetByCode(*code)
+		if err != nil {
+			return fmt.Errorf("ошибка получения политики отмены: %w", err)
+		}
+		if policy == nil || !policy.IsActive {
+			return fmt.Errorf("политика отмены %s недоступна", *code)
+		}
+	}
+
+	return u.cancellationPolicyRepo.SetApartmentPolicyCode(apartmentID, code)
+}
+
+func (u *cancellationRuleUseCase) QuoteCancellation(booking *domain.Booking, at time.Time) (*domain.CancellationQuote, error) {
+	policy, err := u.GetApartmentCancellationPolicy(booking.ApartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &domain.CancellationQuote{
+		BookingID:       booking.ID,
+		Policy:          policy,
+		HoursUntilStart: booking.StartDate.Sub(at).Hours(),
+		Currency:        "KZT",
+	}
+
+	if booking.PaymentID == nil {
+		return quote, nil
+	}
+
+	quote.PaidAmount = booking.FinalPrice
+
+	// Политика применяется только к подтвержденным бронированиям, остальные возвращаются полностью
+	if booking.Status != domain.BookingStatusApproved && booking.Status != domain.BookingStatusPending {
+		quote.RefundPercentage = 100
+		quote.ServiceFeeRefunded = true
+		quote.RefundAmount = booking.FinalPrice
+		return quote, nil
+	}
+
+	quote.RefundPercentage = policy.RefundPercentage(quote.HoursUntilStart)
+	quote.ServiceFeeRefunded = policy.ServiceFeeRefundable && quote.RefundPercentage > 0
+
+	refundBase := booking.TotalPrice
+	if quote.ServiceFeeRefunded {
+		refundBase += booking.ServiceFee
+	}
+
+	quote.RefundAmount = refundBase * quote.RefundPercentage / 100
+	if quote.RefundAmount > booking.FinalPrice {
+		quote.RefundAmount = booking.FinalPrice
+	}
+	quote.PenaltyAmount = booking.FinalPrice - quote.RefundAmount
+
+	return quote, nil
+}
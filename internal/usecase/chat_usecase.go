@@ -1,12 +1,44 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/imaging"
+	"github.com/russo2642/renti_kz/pkg/logger"
+	"github.com/russo2642/renti_kz/pkg/storage/s3"
 )
 
+const (
+	chatThumbnailMaxSide    = 320
+	maxChatCaptionLength    = 1000
+	maxChatFileNameLength   = 255
+	chatAttachmentKeyPrefix = "chat"
+)
+
+type chatAttachmentKind struct {
+	messageType domain.MessageType
+	extension   string
+}
+
+// chatAttachmentKinds допустимые вложения. Тип определяется по сигнатуре содержимого,
+// а не по расширению или заголовку Content-Type от клиента.
+var chatAttachmentKinds = map[string]chatAttachmentKind{
+	"image/jpeg":      {messageType: domain.MessageTypeImage, extension: "jpg"},
+	"image/png":       {messageType: domain.MessageTypeImage, extension: "png"},
+	"image/gif":       {messageType: domain.MessageTypeImage, extension: "gif"},
+	"image/webp":      {messageType: domain.MessageTypeImage, extension: "webp"},
+	"application/pdf": {messageType: domain.MessageTypeFile, extension: "pdf"},
+}
+
 type chatUseCase struct {
 	chatRoomRepo        domain.ChatRoomRepository
 	chatMessageRepo     domain.ChatMessageRepository
@@ -16,6 +48,7 @@ type chatUseCase struct {
 	renterRepo          domain.RenterRepository
 	wsService           domain.ChatWebSocketService
 	notificationRepo    domain.NotificationRepository
+	s3Storage           *s3.Storage
 }
 
 func NewChatUseCase(
@@ -27,6 +60,7 @@ func NewChatUseCase(
 	renterRepo domain.RenterRepository,
 	wsService domain.ChatWebSocketService,
 	notificationRepo domain.NotificationRepository,
+	s3Storage *s3.Storage,
 ) domain.ChatUseCase {
 	return &chatUseCase{
 		chatRoomRepo:        chatRoomRepo,
@@ -37,6 +71,7 @@ func NewChatUseCase(
 		renterRepo:          renterRepo,
 		wsService:           wsService,
 		notificationRepo:    notificationRepo,
+		s3Storage:           s3Storage,
 	}
 }
 
@@ -153,18 +188,12 @@ func (uc *chatUseCase) CanUserAccessRoom(roomID, userID int) (bool, error) {
 }
 
 func (uc *chatUseCase) SendMessage(roomID int, request *domain.SendMessageRequest, userID int) (*domain.ChatMessage, error) {
-	canAccess, err := uc.CanUserAccessRoom(roomID, userID)
-	if err != nil || !canAccess {
-		return nil, fmt.Errorf("access denied to chat room")
+	if request.Type == domain.MessageTypeImage || request.Type == domain.MessageTypeFile {
+		return nil, fmt.Errorf("attachments must be uploaded via the attachments endpoint")
 	}
 
-	room, err := uc.chatRoomRepo.GetByID(roomID)
-	if err != nil {
-		return nil, fmt.Errorf("chat room not found: %w", err)
-	}
-
-	if room.Status != domain.ChatRoomStatusActive {
-		return nil, fmt.Errorf("chat room is not active")
+	if err := uc.checkActiveRoomSender(roomID, userID); err != nil {
+		return nil, err
 	}
 
 	message := &domain.ChatMessage{
@@ -176,23 +205,168 @@ func (uc *chatUseCase) SendMessage(roomID int, request *domain.SendMessageReques
 		ReplyToID:  request.ReplyToID,
 	}
 
-	err = uc.chatMessageRepo.Create(message)
+	err := uc.chatMessageRepo.Create(message)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create message: %w", err)
 	}
 
+	uc.publishNewMessage(message)
+
+	return message, nil
+}
+
+func (uc *chatUseCase) SendAttachment(roomID int, upload *domain.ChatAttachmentUpload, userID int) (*domain.ChatMessage, error) {
+	if err := uc.checkActiveRoomSender(roomID, userID); err != nil {
+		return nil, err
+	}
+
+	size := int64(len(upload.Data))
+	if size == 0 {
+		return nil, fmt.Errorf("attachment is empty")
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(upload.Data), ";")
+	kind, ok := chatAttachmentKinds[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported attachment type: %s", mimeType)
+	}
+
+	maxSize := int64(domain.ChatFileMaxSize)
+	if kind.messageType == domain.MessageTypeImage {
+		maxSize = domain.ChatImageMaxSize
+	}
+	if size > maxSize {
+		return nil, fmt.Errorf("attachment exceeds maximum size of %d MB", maxSize>>20)
+	}
+
+	caption := strings.TrimSpace(upload.Caption)
+	if utf8.RuneCountInString(caption) > maxChatCaptionLength {
+		return nil, fmt.Errorf("caption exceeds maximum length of %d characters", maxChatCaptionLength)
+	}
+
+	fileName := normalizeAttachmentFileName(upload.FileName, kind.extension)
+	if caption == "" {
+		caption = fileName
+	}
+
+	message := &domain.ChatMessage{
+		ChatRoomID: roomID,
+		SenderID:   userID,
+		Type:       kind.messageType,
+		Content:    caption,
+		FileName:   &fileName,
+		FileSize:   &size,
+		MimeType:   &mimeType,
+		Status:     domain.MessageStatusSent,
+		ReplyToID:  upload.ReplyToID,
+	}
+
+	var thumbnail *imaging.Thumbnail
+	if kind.messageType == domain.MessageTypeImage {
+		width, height, err := imaging.DecodeConfig(upload.Data)
+		switch {
+		case errors.Is(err, imaging.ErrUnsupportedFormat):
+			// WebP не декодируется стандартной библиотекой: сохраняем без размеров и превью
+		case errors.Is(err, imaging.ErrImageTooLarge):
+			return nil, fmt.Errorf("image is too large: at most %d megapixels allowed", imaging.MaxPixels/1_000_000)
+		case err != nil:
+			return nil, fmt.Errorf("invalid image: %w", err)
+		default:
+			message.ImageWidth = &width
+			message.ImageHeight = &height
+
+			thumbnail, err = imaging.MakeThumbnail(upload.Data, chatThumbnailMaxSide)
+			if err != nil {
+				logger.Warn("failed to generate chat attachment thumbnail",
+					slog.Int("room_id", roomID),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+
+	baseKey := fmt.Sprintf("%s/%d/%s/%s", chatAttachmentKeyPrefix, roomID, time.Now().Format("2006-01-02"), uuid.NewString())
+	objectKeys := make([]string, 0, 2)
+
+	fileURL, err := uc.s3Storage.UploadFile(baseKey+"."+kind.extension, upload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+	objectKeys = append(objectKeys, baseKey+"."+kind.extension)
+	message.FileURL = &fileURL
+
+	if thumbnail != nil {
+		thumbnailURL, err := uc.s3Storage.UploadFile(baseKey+"_thumb.jpg", thumbnail.Data)
+		if err != nil {
+			uc.deleteAttachmentObjects(objectKeys)
+			return nil, fmt.Errorf("failed to upload attachment thumbnail: %w", err)
+		}
+		objectKeys = append(objectKeys, baseKey+"_thumb.jpg")
+		message.ThumbnailURL = &thumbnailURL
+	}
+
+	if err := uc.chatMessageRepo.Create(message); err != nil {
+		uc.deleteAttachmentObjects(objectKeys)
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	uc.publishNewMessage(message)
+
+	return message, nil
+}
+
+func (uc *chatUseCase) checkActiveRoomSender(roomID, userID int) error {
+	canAccess, err := uc.CanUserAccessRoom(roomID, userID)
+	if err != nil || !canAccess {
+		return fmt.Errorf("access denied to chat room")
+	}
+
+	room, err := uc.chatRoomRepo.GetByID(roomID)
+	if err != nil {
+		return fmt.Errorf("chat room not found: %w", err)
+	}
+
+	if room.Status != domain.ChatRoomStatusActive {
+		return fmt.Errorf("chat room is not active")
+	}
+
+	return nil
+}
+
+func (uc *chatUseCase) publishNewMessage(message *domain.ChatMessage) {
 	wsMessage := &domain.WSMessage{
 		Type:      domain.WSEventNewMessage,
 		Data:      message,
 		Timestamp: time.Now(),
-		UserID:    userID,
-		RoomID:    roomID,
+		UserID:    message.SenderID,
+		RoomID:    message.ChatRoomID,
 	}
 
-	uc.wsService.BroadcastToRoom(roomID, wsMessage)
+	uc.wsService.BroadcastToRoom(message.ChatRoomID, wsMessage)
 	go uc.NotifyNewMessage(message)
+}
 
-	return message, nil
+func (uc *chatUseCase) deleteAttachmentObjects(objectKeys []string) {
+	for _, objectKey := range objectKeys {
+		if err := uc.s3Storage.DeleteFile(objectKey); err != nil {
+			logger.Warn("failed to delete chat attachment from storage",
+				slog.String("object_key", objectKey),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+func normalizeAttachmentFileName(fileName, extension string) string {
+	fileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(fileName, "\\", "/")))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return "attachment." + extension
+	}
+
+	if utf8.RuneCountInString(fileName) > maxChatFileNameLength {
+		runes := []rune(fileName)
+		fileName = string(runes[:maxChatFileNameLength])
+	}
+
+	return fileName
 }
 
 func (uc *chatUseCase) GetMessages(roomID, userID int, page, pageSize int) ([]*domain.ChatMessage, int, error) {
@@ -216,6 +390,89 @@ func (uc *chatUseCase) GetMessages(roomID, userID int, page, pageSize int) ([]*d
 	return messages, total, nil
 }
 
+func (uc *chatUseCase) GetMessagesByCursor(roomID, userID int, cursor domain.ChatMessageCursor) (*domain.ChatMessagesPage, error) {
+	canAccess, err := uc.CanUserAccessRoom(roomID, userID)
+	if err != nil || !canAccess {
+		return nil, fmt.Errorf("access denied to chat room")
+	}
+
+	if cursor.Limit <= 0 {
+		cursor.Limit = domain.ChatMessagesCursorDefaultLimit
+	}
+	if cursor.Limit > domain.ChatMessagesCursorMaxLimit {
+		cursor.Limit = domain.ChatMessagesCursorMaxLimit
+	}
+
+	messages, hasMore, err := uc.chatMessageRepo.GetByRoomIDCursor(roomID, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+
+	page := &domain.ChatMessagesPage{
+		Messages: messages,
+		HasMore:  hasMore,
+	}
+	if len(messages) > 0 {
+		page.FirstID = &messages[0].ID
+		page.LastID = &messages[len(messages)-1].ID
+	}
+
+	return page, nil
+}
+
+func (uc *chatUseCase) SyncMessages(roomID, userID int, request *domain.ChatSyncRequest) (*domain.ChatSyncResponse, error) {
+	canAccess, err := uc.CanUserAccessRoom(roomID, userID)
+	if err != nil || !canAccess {
+		return nil, fmt.Errorf("access denied to chat room")
+	}
+
+	if request.LastMessageID < 0 {
+		return nil, fmt.Errorf("invalid last message ID")
+	}
+
+	messages, hasMore, err := uc.chatMessageRepo.GetByRoomIDCursor(roomID, domain.ChatMessageCursor{
+		AfterID: &request.LastMessageID,
+		Limit:   domain.ChatSyncMaxMessages,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missed messages: %w", err)
+	}
+
+	response := &domain.ChatSyncResponse{
+		Messages:      messages,
+		ReadReceipts:  []domain.ChatReadReceipt{},
+		HasMore:       hasMore,
+		LastMessageID: request.LastMessageID,
+	}
+	if len(messages) > 0 {
+		response.LastMessageID = messages[len(messages)-1].ID
+	}
+
+	if request.LastMessageID == 0 {
+		return response, nil
+	}
+
+	// Без времени последнего события считаем, что клиент знает статусы на момент
+	// получения своего последнего сообщения
+	var readSince time.Time
+	if request.LastEventAt != nil {
+		readSince = *request.LastEventAt
+	} else {
+		lastSeen, err := uc.chatMessageRepo.GetByID(request.LastMessageID)
+		if err == nil && lastSeen.ChatRoomID == roomID {
+			readSince = lastSeen.CreatedAt
+		}
+	}
+
+	receipts, err := uc.chatMessageRepo.GetReadReceipts(roomID, readSince, request.LastMessageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get read receipts: %w", err)
+	}
+	response.ReadReceipts = receipts
+
+	return response, nil
+}
+
 func (uc *chatUseCase) UpdateMessage(messageID int, request *domain.UpdateMessageRequest, userID int) error {
 	message, err := uc.chatMessageRepo.GetByID(messageID)
 	if err != nil {
@@ -254,6 +511,14 @@ func (uc *chatUseCase) DeleteMessage(messageID, userID int) error {
 		return fmt.Errorf("failed to delete message: %w", err)
 	}
 
+	var objectKeys []string
+	for _, url := range []*string{message.FileURL, message.ThumbnailURL} {
+		if url != nil && *url != "" {
+			objectKeys = append(objectKeys, uc.s3Storage.ExtractObjectKey(*url))
+		}
+	}
+	uc.deleteAttachmentObjects(objectKeys)
+
 	return nil
 }
 
@@ -268,6 +533,20 @@ func (uc *chatUseCase) MarkMessagesAsRead(roomID int, request *domain.MarkMessag
 		return fmt.Errorf("failed to mark messages as read: %w", err)
 	}
 
+	if len(request.MessageIDs) > 0 {
+		now := time.Now()
+		uc.wsService.BroadcastToRoom(roomID, &domain.WSMessage{
+			Type: domain.WSEventMessageRead,
+			Data: map[string]interface{}{
+				"message_ids": request.MessageIDs,
+				"read_at":     now,
+			},
+			Timestamp: now,
+			UserID:    userID,
+			RoomID:    roomID,
+		})
+	}
+
 	return nil
 }
 
@@ -362,7 +641,7 @@ func (uc *chatUseCase) NotifyNewMessage(message *domain.ChatMessage) error {
 			UserID:  recipientUserID,
 			Type:    "chat_message",
 			Title:   fmt.Sprintf("Новое сообщение от: %s", senderTitle),
-			Message: message.Content,
+			Message: chatNotificationText(message),
 		}
 
 		return uc.notificationRepo.CreateNotification(notification)
@@ -371,6 +650,17 @@ func (uc *chatUseCase) NotifyNewMessage(message *domain.ChatMessage) error {
 	return nil
 }
 
+func chatNotificationText(message *domain.ChatMessage) string {
+	switch message.Type {
+	case domain.MessageTypeImage:
+		return "📷 " + message.Content
+	case domain.MessageTypeFile:
+		return "📎 " + message.Content
+	default:
+		return message.Content
+	}
+}
+
 func (uc *chatUseCase) validateUserBookingAccess(booking *domain.Booking, userID int) error {
 	renter, err := uc.renterRepo.GetByID(booking.RenterID)
 	if err == nil && renter.UserID == userID {
@@ -1,28 +1,55 @@
 package usecase
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/storage/s3"
 )
 
+// cleaningAccessCodeGrace запас сверх окна уборки, в течение которого код уборщицы еще действует
+const cleaningAccessCodeGrace = 30 * time.Minute
+
 type cleanerUseCase struct {
-	cleanerRepo   domain.CleanerRepository
-	userUseCase   domain.UserUseCase
-	apartmentRepo domain.ApartmentRepository
+	cleanerRepo         domain.CleanerRepository
+	userUseCase         domain.UserUseCase
+	apartmentRepo       domain.ApartmentRepository
+	ownerRepo           domain.PropertyOwnerRepository
+	bookingRepo         domain.BookingRepository
+	lockUseCase         domain.LockUseCase
+	notificationUseCase domain.NotificationUseCase
+	settingsUseCase     domain.PlatformSettingsUseCase
+	s3Storage           *s3.Storage
+	scheduler           domain.CleaningScheduler
 }
 
 func NewCleanerUseCase(
 	cleanerRepo domain.CleanerRepository,
 	userUseCase domain.UserUseCase,
 	apartmentRepo domain.ApartmentRepository,
+	ownerRepo domain.PropertyOwnerRepository,
+	bookingRepo domain.BookingRepository,
+	lockUseCase domain.LockUseCase,
+	notificationUseCase domain.NotificationUseCase,
+	settingsUseCase domain.PlatformSettingsUseCase,
+	s3Storage *s3.Storage,
 	logger interface{},
 ) domain.CleanerUseCase {
 	return &cleanerUseCase{
-		cleanerRepo:   cleanerRepo,
-		userUseCase:   userUseCase,
-		apartmentRepo: apartmentRepo,
+		cleanerRepo:         cleanerRepo,
+		userUseCase:         userUseCase,
+		apartmentRepo:       apartmentRepo,
+		ownerRepo:           ownerRepo,
+		bookingRepo:         bookingRepo,
+		lockUseCase:         lockUseCase,
+		notificationUseCase: notificationUseCase,
+		settingsUseCase:     settingsUseCase,
+		s3Storage:           s3Storage,
 	}
 }
 
@@ -291,40 +318,33 @@ func (uc *cleanerUseCase) UpdateCleanerSchedulePatch(userID int, schedulePatch *
 	return nil
 }
 
-func (uc *cleanerUseCase) StartCleaning(userID int, request *domain.StartCleaningRequest) error {
-	cleaner, err := uc.cleanerRepo.GetByUserID(userID)
+func (uc *cleanerUseCase) StartCleaning(userID int, request *domain.StartCleaningRequest, actor *domain.LockCodeRevealActor) (*domain.CleaningLog, error) {
+	cleaner, err := uc.getAssignedCleaner(userID, request.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("уборщица не найдена: %w", err)
+		return nil, err
 	}
 
-	if cleaner == nil {
-		return fmt.Errorf("пользователь не является уборщицей")
+	activeLog, err := uc.cleanerRepo.GetActiveCleaningLog(request.ApartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки текущей уборки: %w", err)
 	}
 
-	if !cleaner.IsActive {
-		return fmt.Errorf("уборщица неактивна")
+	if activeLog != nil {
+		return nil, fmt.Errorf("уборка квартиры уже начата")
 	}
 
-	cleanerApartments, err := uc.cleanerRepo.GetCleanerApartments(cleaner.ID)
+	task, err := uc.cleanerRepo.GetOpenCleaningTask(request.ApartmentID)
 	if err != nil {
-		return fmt.Errorf("ошибка получения квартир уборщицы: %w", err)
-	}
-
-	var hasAccess bool
-	for _, ca := range cleanerApartments {
-		if ca.ApartmentID == request.ApartmentID {
-			hasAccess = true
-			break
-		}
+		return nil, fmt.Errorf("ошибка проверки заявки на уборку: %w", err)
 	}
 
-	if !hasAccess {
-		return fmt.Errorf("квартира не назначена данной уборщице")
+	if task != nil && task.Status == domain.CleaningTaskStatusAccepted && task.CleanerID != nil && *task.CleanerID != cleaner.ID {
+		return nil, fmt.Errorf("уборка квартиры назначена другой уборщице")
 	}
 
 	apartmentsForCleaning, err := uc.cleanerRepo.GetApartmentsForCleaning(cleaner.ID)
 	if err != nil {
-		return fmt.Errorf("ошибка получения квартир для уборки: %w", err)
+		return nil, fmt.Errorf("ошибка получения квартир для уборки: %w", err)
 	}
 
 	var needsCleaning bool
@@ -336,67 +356,338 @@ func (uc *cleanerUseCase) StartCleaning(userID int, request *domain.StartCleanin
 	}
 
 	if !needsCleaning {
-		return fmt.Errorf("квартира не нуждается в уборке")
+		return nil, fmt.Errorf("квартира не нуждается в уборке")
+	}
+
+	cleaningLog := &domain.CleaningLog{
+		ApartmentID: request.ApartmentID,
+		CleanerID:   cleaner.ID,
+		StartNotes:  optionalString(request.Notes),
 	}
 
-	// TODO: Здесь можно добавить логирование начала уборки
-	// Например, создать запись в таблице cleaning_logs
-	log.Printf("Уборщица %d начала уборку квартиры %d. Заметки: %s", userID, request.ApartmentID, request.Notes)
+	if err := uc.cleanerRepo.CreateCleaningLog(cleaningLog); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения начала уборки: %w", err)
+	}
 
-	return nil
+	log.Printf("🧹 Уборщица %d начала уборку квартиры %d (запись %d)", cleaner.ID, request.ApartmentID, cleaningLog.ID)
+
+	uc.syncCleaningTaskStarted(cleaningLog)
+
+	// Без кода уборка все равно начинается: сбой Tuya не должен блокировать работу,
+	// код можно запросить повторно через GetCleaningAccessCode
+	accessCode, err := uc.issueCleaningAccessCode(cleaningLog, actor)
+	if err != nil {
+		log.Printf("⚠️ Не удалось выдать код замка для уборки %d: %v", cleaningLog.ID, err)
+	}
+	cleaningLog.AccessCode = accessCode
+
+	return cleaningLog, nil
+}
+
+func (uc *cleanerUseCase) GetCleaningAccessCode(userID, apartmentID int, actor *domain.LockCodeRevealActor) (*domain.CleaningAccessCode, error) {
+	cleaner, err := uc.getAssignedCleaner(userID, apartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaningLog, err := uc.getCleanerActiveLog(cleaner.ID, apartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessCode, err := uc.issueCleaningAccessCode(cleaningLog, actor)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка выдачи кода замка: %w", err)
+	}
+
+	if accessCode == nil {
+		return nil, fmt.Errorf("в квартире не установлен замок")
+	}
+
+	return accessCode, nil
+}
+
+func (uc *cleanerUseCase) CompleteCleaning(userID int, request *domain.CompleteCleaningRequest, photos [][]byte) (*domain.CleaningLog, error) {
+	if len(photos) < domain.MinCleaningPhotos {
+		return nil, fmt.Errorf("необходимо приложить фотографии квартиры после уборки")
+	}
+
+	if len(photos) > domain.MaxCleaningPhotos {
+		return nil, fmt.Errorf("можно приложить не более %d фотографий", domain.MaxCleaningPhotos)
+	}
+
+	cleaner, err := uc.getAssignedCleaner(userID, request.ApartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaningLog, err := uc.getCleanerActiveLog(cleaner.ID, request.ApartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	photosURLs, err := uc.s3Storage.UploadCleaningPhotosParallel(request.ApartmentID, photos)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка загрузки фотографий: %w", err)
+	}
+
+	cleaningLog.CompletionNotes = optionalString(request.Notes)
+	cleaningLog.PhotosURLs = photosURLs
+
+	if err := uc.cleanerRepo.CompleteCleaningLog(cleaningLog); err != nil {
+		uc.deleteCleaningPhotos(photosURLs)
+		return nil, fmt.Errorf("ошибка сохранения завершения уборки: %w", err)
+	}
+
+	err = uc.apartmentRepo.UpdateIsFree(request.ApartmentID, true)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка обновления статуса квартиры: %w", err)
+	}
+
+	log.Printf("✅ Уборщица %d завершила уборку квартиры %d (запись %d, фото: %d)", cleaner.ID, request.ApartmentID, cleaningLog.ID, len(photosURLs))
+
+	uc.revokeCleaningAccessCode(cleaningLog)
+	uc.syncCleaningTaskCompleted(cleaningLog)
+
+	go uc.notifyOwnerCleaningCompleted(cleaningLog)
+
+	return cleaningLog, nil
+}
+
+func (uc *cleanerUseCase) CancelCleaning(userID int, request *domain.CancelCleaningRequest) (*domain.CleaningLog, error) {
+	cleaner, err := uc.getAssignedCleaner(userID, request.ApartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaningLog, err := uc.getCleanerActiveLog(cleaner.ID, request.ApartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cleaningLog.CancelReason = optionalString(request.Reason)
+	if err := uc.cleanerRepo.CancelCleaningLog(cleaningLog); err != nil {
+		return nil, fmt.Errorf("ошибка отмены уборки: %w", err)
+	}
+
+	log.Printf("⚠️ Уборщица %d отменила уборку квартиры %d (запись %d)", cleaner.ID, request.ApartmentID, cleaningLog.ID)
+
+	uc.revokeCleaningAccessCode(cleaningLog)
+	uc.syncCleaningTaskCancelled(cleaningLog)
+
+	return cleaningLog, nil
+}
+
+func (uc *cleanerUseCase) AdminCancelCleaning(logID int, reason string) (*domain.CleaningLog, error) {
+	cleaningLog, err := uc.cleanerRepo.GetCleaningLogByID(logID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения записи уборки: %w", err)
+	}
+
+	if cleaningLog == nil {
+		return nil, fmt.Errorf("запись уборки с ID %d не найдена", logID)
+	}
+
+	if cleaningLog.Status != domain.CleaningLogStatusInProgress {
+		return nil, fmt.Errorf("отменить можно только уборку в процессе")
+	}
+
+	cleaningLog.CancelReason = optionalString(reason)
+	if err := uc.cleanerRepo.CancelCleaningLog(cleaningLog); err != nil {
+		return nil, fmt.Errorf("ошибка отмены уборки: %w", err)
+	}
+
+	log.Printf("⚠️ Администратор отменил уборку квартиры %d (запись %d)", cleaningLog.ApartmentID, cleaningLog.ID)
+
+	uc.revokeCleaningAccessCode(cleaningLog)
+	uc.syncCleaningTaskCancelled(cleaningLog)
+
+	return cleaningLog, nil
 }
 
-func (uc *cleanerUseCase) CompleteCleaning(userID int, request *domain.CompleteCleaningRequest) error {
+func (uc *cleanerUseCase) GetCleaningHistory(userID int, filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningLog, int, error) {
 	cleaner, err := uc.cleanerRepo.GetByUserID(userID)
 	if err != nil {
-		return fmt.Errorf("уборщица не найдена: %w", err)
+		return nil, 0, fmt.Errorf("уборщица не найдена: %w", err)
 	}
 
 	if cleaner == nil {
-		return fmt.Errorf("пользователь не является уборщицей")
+		return nil, 0, fmt.Errorf("пользователь не является уборщицей")
+	}
+
+	return uc.cleanerRepo.GetCleaningLogs(withFilter(filters, "cleaner_id", cleaner.ID), page, pageSize)
+}
+
+func (uc *cleanerUseCase) GetOwnerCleaningHistory(ownerUserID int, filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningLog, int, error) {
+	owner, err := uc.ownerRepo.GetByUserID(ownerUserID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка получения владельца: %w", err)
+	}
+
+	if owner == nil {
+		return nil, 0, fmt.Errorf("пользователь не является владельцем")
+	}
+
+	return uc.cleanerRepo.GetCleaningLogs(withFilter(filters, "owner_id", owner.ID), page, pageSize)
+}
+
+func (uc *cleanerUseCase) GetAllCleaningLogs(filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningLog, int, error) {
+	return uc.cleanerRepo.GetCleaningLogs(filters, page, pageSize)
+}
+
+// withFilter возвращает копию фильтров с дополнительным условием: карта принадлежит вызывающему
+// и не должна меняться.
+func withFilter(filters map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	scoped := make(map[string]interface{}, len(filters)+1)
+	for k, v := range filters {
+		scoped[k] = v
+	}
+	scoped[key] = value
+	return scoped
+}
+
+// getAssignedCleaner возвращает активную уборщицу пользователя, если квартира назначена ей.
+func (uc *cleanerUseCase) getAssignedCleaner(userID, apartmentID int) (*domain.Cleaner, error) {
+	cleaner, err := uc.cleanerRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("уборщица не найдена: %w", err)
+	}
+
+	if cleaner == nil {
+		return nil, fmt.Errorf("пользователь не является уборщицей")
 	}
 
 	if !cleaner.IsActive {
-		return fmt.Errorf("уборщица неактивна")
+		return nil, fmt.Errorf("уборщица неактивна")
 	}
 
 	cleanerApartments, err := uc.cleanerRepo.GetCleanerApartments(cleaner.ID)
 	if err != nil {
-		return fmt.Errorf("ошибка получения квартир уборщицы: %w", err)
+		return nil, fmt.Errorf("ошибка получения квартир уборщицы: %w", err)
 	}
 
-	var hasAccess bool
 	for _, ca := range cleanerApartments {
-		if ca.ApartmentID == request.ApartmentID {
-			hasAccess = true
-			break
+		if ca.ApartmentID == apartmentID {
+			return cleaner, nil
 		}
 	}
 
-	if !hasAccess {
-		return fmt.Errorf("квартира не назначена данной уборщице")
+	return nil, fmt.Errorf("квартира не назначена данной уборщице")
+}
+
+func (uc *cleanerUseCase) getCleanerActiveLog(cleanerID, apartmentID int) (*domain.CleaningLog, error) {
+	cleaningLog, err := uc.cleanerRepo.GetActiveCleaningLog(apartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения текущей уборки: %w", err)
+	}
+
+	if cleaningLog == nil {
+		return nil, fmt.Errorf("уборка квартиры не начата")
 	}
 
-	err = uc.apartmentRepo.UpdateIsFree(request.ApartmentID, true)
+	if cleaningLog.CleanerID != cleanerID {
+		return nil, fmt.Errorf("уборку квартиры ведет другая уборщица")
+	}
+
+	return cleaningLog, nil
+}
+
+// issueCleaningAccessCode выдает уборщице временный пароль замка квартиры на окно уборки, чтобы
+// не передавать ей постоянный пароль владельца. Для квартиры без замка возвращает nil без ошибки.
+func (uc *cleanerUseCase) issueCleaningAccessCode(cleaningLog *domain.CleaningLog, actor *domain.LockCodeRevealActor) (*domain.CleaningAccessCode, error) {
+	if uc.lockUseCase == nil {
+		return nil, nil
+	}
+
+	lock, err := uc.lockUseCase.GetLockByApartmentID(cleaningLog.ApartmentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
-		return fmt.Errorf("ошибка обновления статуса квартиры: %w", err)
+		return nil, fmt.Errorf("ошибка получения замка квартиры: %w", err)
+	}
+	if lock == nil {
+		return nil, nil
 	}
 
-	// TODO: Здесь можно добавить:
-	// 1. Сохранение фотографий после уборки
-	// 2. Логирование завершения уборки
-	// 3. Отправка уведомлений владельцу
-	// 4. Создание записи в таблице cleaning_logs
+	password, err := uc.lockUseCase.GeneratePasswordForCleaning(lock.UniqueID, actor, cleaningLog.ID, uc.cleaningWindowEnd(cleaningLog))
+	if err != nil {
+		return nil, err
+	}
 
-	log.Printf("Уборщица %d завершила уборку квартиры %d. Заметки: %s", userID, request.ApartmentID, request.Notes)
+	return &domain.CleaningAccessCode{
+		Code:       password.Password.Reveal(),
+		ValidFrom:  password.ValidFrom,
+		ValidUntil: password.ValidUntil,
+	}, nil
+}
 
-	return nil
+// cleaningWindowEnd окончание окна уборки: начало уборки плюс длительность из заявки (или по умолчанию
+// из настроек платформы), но не раньше текущего момента, и запас на случай задержки.
+func (uc *cleanerUseCase) cleaningWindowEnd(cleaningLog *domain.CleaningLog) time.Time {
+	cleaningDuration := 0
+	task, err := uc.cleanerRepo.GetOpenCleaningTask(cleaningLog.ApartmentID)
+	if err == nil && task != nil {
+		cleaningDuration = task.CleaningDuration
+	}
+	if cleaningDuration <= 0 {
+		cleaningDuration, _ = uc.settingsUseCase.GetDefaultCleaningDurationMinutes()
+	}
+
+	windowEnd := cleaningLog.StartedAt.Add(time.Duration(cleaningDuration) * time.Minute)
+	if now := time.Now(); windowEnd.Before(now) {
+		windowEnd = now
+	}
+
+	return windowEnd.Add(cleaningAccessCodeGrace)
 }
 
-func (uc *cleanerUseCase) GetCleaningHistory(userID int, filters map[string]interface{}, page, pageSize int) ([]map[string]interface{}, int, error) {
-	// TODO: Реализовать получение истории уборки
-	// Пока возвращаем пустой результат
-	return []map[string]interface{}{}, 0, nil
+// revokeCleaningAccessCode отзывает коды замка, выданные на уборку. Ошибка не прерывает завершение
+// уборки: код все равно перестанет действовать по окончании окна.
+func (uc *cleanerUseCase) revokeCleaningAccessCode(cleaningLog *domain.CleaningLog) {
+	if uc.lockUseCase == nil {
+		return
+	}
+
+	if err := uc.lockUseCase.DeactivatePasswordForCleaning(cleaningLog.ID); err != nil {
+		log.Printf("⚠️ Не удалось отозвать код замка для уборки %d: %v", cleaningLog.ID, err)
+	}
+}
+
+func (uc *cleanerUseCase) deleteCleaningPhotos(photosURLs []string) {
+	for _, url := range photosURLs {
+		if err := uc.s3Storage.DeleteFile(uc.s3Storage.ExtractObjectKey(url)); err != nil {
+			log.Printf("⚠️ Не удалось удалить фото уборки %s: %v", url, err)
+		}
+	}
+}
+
+func (uc *cleanerUseCase) notifyOwnerCleaningCompleted(cleaningLog *domain.CleaningLog) {
+	if uc.notificationUseCase == nil || cleaningLog.Apartment == nil {
+		return
+	}
+
+	owner, err := uc.ownerRepo.GetByID(cleaningLog.Apartment.OwnerID)
+	if err != nil || owner == nil {
+		log.Printf("⚠️ Владелец квартиры %d не найден для уведомления об уборке: %v", cleaningLog.ApartmentID, err)
+		return
+	}
+
+	apartment := cleaningLog.Apartment
+	apartmentTitle := fmt.Sprintf("%s, д. %s, кв. %d", apartment.Street, apartment.Building, apartment.ApartmentNumber)
+
+	err = uc.notificationUseCase.NotifyCleaningCompleted(owner.UserID, cleaningLog.ApartmentID, cleaningLog.ID, apartmentTitle, len(cleaningLog.PhotosURLs))
+	if err != nil {
+		log.Printf("⚠️ Ошибка уведомления владельца об уборке квартиры %d: %v", cleaningLog.ApartmentID, err)
+	}
+}
+
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
 }
 
 func (uc *cleanerUseCase) GetApartmentsNeedingCleaning() ([]*domain.ApartmentForCleaning, error) {
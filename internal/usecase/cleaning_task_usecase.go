@@ -0,0 +1,581 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+)
+
+// Заявки на уборку: создаются при выезде гостя, предлагаются уборщицам квартиры по очереди
+// (раньше всех - той, что раньше может приступить по расписанию) и передаются администраторам,
+// если подходящей уборщицы нет или все отказались.
+
+// cleaningTaskUpdateAttempts сколько раз перечитывается заявка, если ее одновременно изменили.
+const cleaningTaskUpdateAttempts = 3
+
+func (uc *cleanerUseCase) SetCleaningScheduler(scheduler domain.CleaningScheduler) {
+	uc.scheduler = scheduler
+}
+
+func (uc *cleanerUseCase) DispatchCleaningForBooking(bookingID int, checkoutAt time.Time) error {
+	booking, err := uc.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения бронирования: %w", err)
+	}
+
+	if booking == nil {
+		return fmt.Errorf("бронирование с ID %d не найдено", bookingID)
+	}
+
+	// Квартиру без уборщиц убирает сам владелец: заявка ушла бы администраторам на каждом выезде,
+	// а по сроку еще и пришло бы уведомление о просрочке
+	cleaners, err := uc.cleanerRepo.GetByApartmentIDActive(booking.ApartmentID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения уборщиц квартиры: %w", err)
+	}
+	if len(cleaners) == 0 {
+		log.Printf("⏭️ На квартиру %d не назначены уборщицы, заявка на уборку после бронирования %d не создается", booking.ApartmentID, bookingID)
+		return nil
+	}
+
+	cleaningDuration := booking.CleaningDuration
+	if cleaningDuration <= 0 {
+		cleaningDuration, _ = uc.settingsUseCase.GetDefaultCleaningDurationMinutes()
+	}
+
+	task := &domain.CleaningTask{
+		ApartmentID:      booking.ApartmentID,
+		BookingID:        &booking.ID,
+		Status:           domain.CleaningTaskStatusPending,
+		CheckoutAt:       checkoutAt,
+		CleaningDuration: cleaningDuration,
+		DeadlineAt:       checkoutAt.Add(time.Duration(cleaningDuration) * time.Minute),
+	}
+
+	nextBooking, err := uc.bookingRepo.GetNextBookingAfterDate(booking.ApartmentID, checkoutAt, &booking.ID)
+	if err != nil {
+		log.Printf("⚠️ Не удалось получить следующее бронирование квартиры %d: %v", booking.ApartmentID, err)
+	} else if nextBooking != nil {
+		task.NextBookingID = &nextBooking.ID
+		if nextBooking.StartDate.Before(task.DeadlineAt) {
+			task.DeadlineAt = nextBooking.StartDate
+		}
+	}
+
+	replace, err := uc.closePreviousCleaningTask(booking.ApartmentID, bookingID)
+	if err != nil || !replace {
+		return err
+	}
+
+	created, err := uc.cleanerRepo.CreateCleaningTask(task)
+	if err != nil {
+		return fmt.Errorf("ошибка создания заявки на уборку: %w", err)
+	}
+
+	if !created {
+		log.Printf("⏭️ Заявка на уборку после бронирования %d уже создана", bookingID)
+		return nil
+	}
+
+	log.Printf("🧹 Создана заявка на уборку %d квартиры %d (срок %s)", task.ID, task.ApartmentID, task.DeadlineAt.Format(time.RFC3339))
+
+	if uc.scheduler != nil {
+		uc.scheduler.ScheduleCleaningDeadline(task.ID, task.DeadlineAt)
+	}
+
+	// Уборщица могла начать уборку сразу после выезда, до создания заявки
+	activeLog, err := uc.cleanerRepo.GetActiveCleaningLog(task.ApartmentID)
+	if err != nil {
+		return fmt.Errorf("ошибка проверки текущей уборки: %w", err)
+	}
+
+	if activeLog != nil {
+		uc.syncCleaningTaskStarted(activeLog)
+		return nil
+	}
+
+	return uc.offerCleaningTask(task)
+}
+
+// closePreviousCleaningTask закрывает незакрытую заявку квартиры по прошлому выезду перед созданием новой.
+// Возвращает false, если новую заявку создавать не нужно: заявка по этому выезду уже есть или квартиру
+// уже убирают. Если заявку одновременно изменили, она перечитывается; открытая после всех попыток
+// заявка - ошибка, иначе новая заявка молча не создалась бы из-за уникального индекса.
+func (uc *cleanerUseCase) closePreviousCleaningTask(apartmentID, bookingID int) (bool, error) {
+	for attempt := 1; attempt <= cleaningTaskUpdateAttempts; attempt++ {
+		previous, err := uc.cleanerRepo.GetOpenCleaningTask(apartmentID)
+		if err != nil {
+			return false, fmt.Errorf("ошибка проверки текущей заявки на уборку: %w", err)
+		}
+
+		if previous == nil {
+			return true, nil
+		}
+
+		// Повторный вызов по тому же выезду (перезапуск планировщика, дубль события) не трогает уже созданную заявку
+		if previous.BookingID != nil && *previous.BookingID == bookingID {
+			log.Printf("⏭️ Заявка на уборку после бронирования %d уже создана", bookingID)
+			return false, nil
+		}
+
+		if previous.Status == domain.CleaningTaskStatusAccepted || previous.Status == domain.CleaningTaskStatusInProgress {
+			log.Printf("⏭️ Квартира %d уже убирается по заявке %d, новая заявка не создается", apartmentID, previous.ID)
+			return false, nil
+		}
+
+		// Заявку по прошлому выезду так и не взяли - ее заменяет новая с актуальным сроком
+		previous.Status = domain.CleaningTaskStatusCancelled
+		updated, err := uc.cleanerRepo.UpdateCleaningTask(previous)
+		if err != nil {
+			return false, fmt.Errorf("ошибка закрытия предыдущей заявки на уборку: %w", err)
+		}
+
+		if updated {
+			return true, nil
+		}
+	}
+
+	return false, fmt.Errorf("не удалось закрыть предыдущую заявку на уборку квартиры %d: заявка изменялась %d раз подряд", apartmentID, cleaningTaskUpdateAttempts)
+}
+
+func (uc *cleanerUseCase) HandleCleaningOfferTimeout(taskID, attempt int) error {
+	task, err := uc.getCleaningTask(taskID)
+	if err != nil {
+		return err
+	}
+
+	// Уборщица успела ответить или заявку уже предложили другой
+	if task.Status != domain.CleaningTaskStatusOffered || task.OfferAttempt != attempt {
+		return nil
+	}
+
+	if task.CleanerID != nil {
+		log.Printf("⏰ Уборщица %d не ответила на заявку %d", *task.CleanerID, task.ID)
+		task.DeclinedCleanerIDs = append(task.DeclinedCleanerIDs, *task.CleanerID)
+	}
+
+	return uc.offerCleaningTask(task)
+}
+
+func (uc *cleanerUseCase) CheckCleaningDeadline(taskID int) error {
+	for attempt := 1; attempt <= cleaningTaskUpdateAttempts; attempt++ {
+		task, err := uc.getCleaningTask(taskID)
+		if err != nil {
+			return err
+		}
+
+		if !task.Status.IsOpen() || task.IsLate {
+			return nil
+		}
+
+		task.IsLate = true
+		updated, err := uc.cleanerRepo.UpdateCleaningTask(task)
+		if err != nil {
+			return fmt.Errorf("ошибка обновления заявки на уборку: %w", err)
+		}
+
+		// Заявку одновременно изменили (принятие, начало уборки) - перечитываем и проверяем заново
+		if !updated {
+			continue
+		}
+
+		log.Printf("⚠️ Уборка квартиры %d по заявке %d не завершена к сроку", task.ApartmentID, task.ID)
+
+		go uc.notifyCleaningLate(task)
+
+		return nil
+	}
+
+	return fmt.Errorf("не удалось отметить просрочку заявки на уборку %d: заявка изменялась %d раз подряд", taskID, cleaningTaskUpdateAttempts)
+}
+
+func (uc *cleanerUseCase) GetMyCleaningTasks(userID int, filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningTask, int, error) {
+	cleaner, err := uc.cleanerRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("уборщица не найдена: %w", err)
+	}
+
+	if cleaner == nil {
+		return nil, 0, fmt.Errorf("пользователь не является уборщицей")
+	}
+
+	return uc.cleanerRepo.GetCleaningTasks(withFilter(filters, "cleaner_id", cleaner.ID), page, pageSize)
+}
+
+func (uc *cleanerUseCase) AcceptCleaningTask(userID, taskID int) (*domain.CleaningTask, error) {
+	cleaner, task, err := uc.getOfferedCleaningTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	task.Status = domain.CleaningTaskStatusAccepted
+	task.AcceptedAt = &now
+
+	updated, err := uc.cleanerRepo.UpdateCleaningTask(task)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка принятия заявки на уборку: %w", err)
+	}
+
+	if !updated {
+		return nil, fmt.Errorf("заявка на уборку уже не ожидает ответа")
+	}
+
+	log.Printf("✅ Уборщица %d приняла заявку на уборку %d", cleaner.ID, task.ID)
+
+	return task, nil
+}
+
+func (uc *cleanerUseCase) DeclineCleaningTask(userID, taskID int, reason string) (*domain.CleaningTask, error) {
+	cleaner, task, err := uc.getOfferedCleaningTask(userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("🙅 Уборщица %d отказалась от заявки на уборку %d: %s", cleaner.ID, task.ID, reason)
+
+	task.DeclinedCleanerIDs = append(task.DeclinedCleanerIDs, cleaner.ID)
+	if err := uc.offerCleaningTask(task); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (uc *cleanerUseCase) GetCleaningTasks(filters map[string]interface{}, page, pageSize int) ([]*domain.CleaningTask, int, error) {
+	return uc.cleanerRepo.GetCleaningTasks(filters, page, pageSize)
+}
+
+// AdminAssignCleaningTask предлагает заявку выбранной уборщице вне очереди, в том числе после эскалации
+// и отказа этой уборщицы. Если она не ответит вовремя, заявка пойдет дальше по очереди.
+func (uc *cleanerUseCase) AdminAssignCleaningTask(taskID, cleanerID int) (*domain.CleaningTask, error) {
+	task, err := uc.getCleaningTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch task.Status {
+	case domain.CleaningTaskStatusPending, domain.CleaningTaskStatusOffered, domain.CleaningTaskStatusEscalated:
+	default:
+		return nil, fmt.Errorf("назначить уборщицу можно только на заявку, которую еще не приняли")
+	}
+
+	cleaners, err := uc.cleanerRepo.GetByApartmentIDActive(task.ApartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения уборщиц квартиры: %w", err)
+	}
+
+	var cleaner *domain.Cleaner
+	for _, candidate := range cleaners {
+		if candidate.ID == cleanerID {
+			cleaner = candidate
+			break
+		}
+	}
+
+	if cleaner == nil {
+		return nil, fmt.Errorf("уборщица %d не назначена на квартиру или неактивна", cleanerID)
+	}
+
+	declined := task.DeclinedCleanerIDs[:0]
+	for _, id := range task.DeclinedCleanerIDs {
+		if id != cleanerID {
+			declined = append(declined, id)
+		}
+	}
+	task.DeclinedCleanerIDs = declined
+	task.EscalatedAt = nil
+	task.EscalationReason = nil
+
+	if err := uc.saveCleaningOffer(task, cleaner); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// offerCleaningTask предлагает заявку следующей подходящей уборщице или передает ее администраторам.
+func (uc *cleanerUseCase) offerCleaningTask(task *domain.CleaningTask) error {
+	cleaners, err := uc.cleanerRepo.GetByApartmentIDActive(task.ApartmentID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения уборщиц квартиры: %w", err)
+	}
+
+	if len(cleaners) == 0 {
+		return uc.escalateCleaningTask(task, "на квартиру не назначено ни одной активной уборщицы")
+	}
+
+	cleaner := uc.pickCleaner(task, cleaners)
+	if cleaner == nil {
+		return uc.escalateCleaningTask(task, "нет уборщиц, которые могут приступить до срока по расписанию")
+	}
+
+	return uc.saveCleaningOffer(task, cleaner)
+}
+
+// pickCleaner выбирает уборщицу, которая раньше всех может приступить к уборке по расписанию.
+// Уборщицы, отказавшиеся от заявки, и те, чье рабочее время начинается после срока, пропускаются.
+func (uc *cleanerUseCase) pickCleaner(task *domain.CleaningTask, cleaners []*domain.Cleaner) *domain.Cleaner {
+	from := time.Now()
+	if task.CheckoutAt.After(from) {
+		from = task.CheckoutAt
+	}
+
+	type candidate struct {
+		cleaner     *domain.Cleaner
+		availableAt time.Time
+	}
+
+	var candidates []candidate
+	for _, cleaner := range cleaners {
+		if task.HasDeclined(cleaner.ID) {
+			continue
+		}
+
+		// Уборщица, которая работает прямо сейчас, подходит даже при уже прошедшем сроке
+		availableAt, ok := cleaner.Schedule.NextWorkingTime(from, utils.KazakhstanTZ)
+		if !ok || (availableAt.After(from) && !availableAt.Before(task.DeadlineAt)) {
+			continue
+		}
+
+		candidates = append(candidates, candidate{cleaner: cleaner, availableAt: availableAt})
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].availableAt.Equal(candidates[j].availableAt) {
+			return candidates[i].availableAt.Before(candidates[j].availableAt)
+		}
+		return candidates[i].cleaner.ID < candidates[j].cleaner.ID
+	})
+
+	return candidates[0].cleaner
+}
+
+func (uc *cleanerUseCase) saveCleaningOffer(task *domain.CleaningTask, cleaner *domain.Cleaner) error {
+	timeoutMinutes, _ := uc.settingsUseCase.GetCleaningOfferTimeoutMinutes()
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(timeoutMinutes) * time.Minute)
+
+	task.CleanerID = &cleaner.ID
+	task.Cleaner = cleaner
+	task.Status = domain.CleaningTaskStatusOffered
+	task.OfferAttempt++
+	task.OfferedAt = &now
+	task.OfferExpiresAt = &expiresAt
+
+	updated, err := uc.cleanerRepo.UpdateCleaningTask(task)
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения предложения заявки на уборку: %w", err)
+	}
+
+	if !updated {
+		return fmt.Errorf("заявка на уборку изменилась, обновите данные")
+	}
+
+	log.Printf("📨 Заявка на уборку %d предложена уборщице %d (попытка %d)", task.ID, cleaner.ID, task.OfferAttempt)
+
+	if uc.scheduler != nil {
+		uc.scheduler.ScheduleCleaningOfferTimeout(task.ID, task.OfferAttempt, expiresAt)
+	}
+
+	if uc.notificationUseCase != nil {
+		err = uc.notificationUseCase.NotifyCleaningTaskOffered(cleaner.UserID, task.ApartmentID, task.ID, cleaningTaskApartmentTitle(task), task.DeadlineAt, timeoutMinutes)
+		if err != nil {
+			log.Printf("⚠️ Ошибка уведомления уборщицы %d о заявке на уборку %d: %v", cleaner.ID, task.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *cleanerUseCase) escalateCleaningTask(task *domain.CleaningTask, reason string) error {
+	now := time.Now()
+	task.Status = domain.CleaningTaskStatusEscalated
+	task.CleanerID = nil
+	task.Cleaner = nil
+	task.OfferExpiresAt = nil
+	task.EscalatedAt = &now
+	task.EscalationReason = &reason
+
+	updated, err := uc.cleanerRepo.UpdateCleaningTask(task)
+	if err != nil {
+		return fmt.Errorf("ошибка эскалации заявки на уборку: %w", err)
+	}
+
+	if !updated {
+		return fmt.Errorf("заявка на уборку изменилась, обновите данные")
+	}
+
+	log.Printf("🚨 Заявка на уборку %d передана администраторам: %s", task.ID, reason)
+
+	go uc.notifyAdminsCleaningEscalated(task)
+
+	return nil
+}
+
+// syncCleaningTaskStarted привязывает начатую уборку к незакрытой заявке квартиры.
+func (uc *cleanerUseCase) syncCleaningTaskStarted(cleaningLog *domain.CleaningLog) {
+	task, err := uc.cleanerRepo.GetOpenCleaningTask(cleaningLog.ApartmentID)
+	if err != nil || task == nil {
+		return
+	}
+
+	now := time.Now()
+	task.CleanerID = &cleaningLog.CleanerID
+	task.CleaningLogID = &cleaningLog.ID
+	task.Status = domain.CleaningTaskStatusInProgress
+	task.StartedAt = &now
+	if task.AcceptedAt == nil {
+		task.AcceptedAt = &now
+	}
+
+	uc.saveCleaningTaskProgress(task)
+}
+
+func (uc *cleanerUseCase) syncCleaningTaskCompleted(cleaningLog *domain.CleaningLog) {
+	task, err := uc.cleanerRepo.GetOpenCleaningTask(cleaningLog.ApartmentID)
+	if err != nil || task == nil || task.CleaningLogID != nil && *task.CleaningLogID != cleaningLog.ID {
+		return
+	}
+
+	now := time.Now()
+	task.Status = domain.CleaningTaskStatusCompleted
+	task.CleaningLogID = &cleaningLog.ID
+	task.CompletedAt = &now
+	if now.After(task.DeadlineAt) {
+		task.IsLate = true
+	}
+
+	uc.saveCleaningTaskProgress(task)
+}
+
+// syncCleaningTaskCancelled возвращает заявку уборщице: уборка отменена, но квартира все еще не убрана.
+func (uc *cleanerUseCase) syncCleaningTaskCancelled(cleaningLog *domain.CleaningLog) {
+	task, err := uc.cleanerRepo.GetOpenCleaningTask(cleaningLog.ApartmentID)
+	if err != nil || task == nil || task.CleaningLogID == nil || *task.CleaningLogID != cleaningLog.ID {
+		return
+	}
+
+	task.Status = domain.CleaningTaskStatusAccepted
+	task.CleaningLogID = nil
+	task.StartedAt = nil
+
+	uc.saveCleaningTaskProgress(task)
+}
+
+func (uc *cleanerUseCase) saveCleaningTaskProgress(task *domain.CleaningTask) {
+	updated, err := uc.cleanerRepo.UpdateCleaningTask(task)
+	if err != nil || !updated {
+		log.Printf("⚠️ Не удалось обновить заявку на уборку %d (статус %s): %v", task.ID, task.Status, err)
+	}
+}
+
+func (uc *cleanerUseCase) getCleaningTask(taskID int) (*domain.CleaningTask, error) {
+	task, err := uc.cleanerRepo.GetCleaningTaskByID(taskID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заявки на уборку: %w", err)
+	}
+
+	if task == nil {
+		return nil, fmt.Errorf("заявка на уборку с ID %d не найдена", taskID)
+	}
+
+	return task, nil
+}
+
+// getOfferedCleaningTask возвращает заявку, которая сейчас предложена уборщице пользователя.
+func (uc *cleanerUseCase) getOfferedCleaningTask(userID, taskID int) (*domain.Cleaner, *domain.CleaningTask, error) {
+	cleaner, err := uc.cleanerRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("уборщица не найдена: %w", err)
+	}
+
+	if cleaner == nil {
+		return nil, nil, fmt.Errorf("пользователь не является уборщицей")
+	}
+
+	task, err := uc.getCleaningTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if task.CleanerID == nil || *task.CleanerID != cleaner.ID {
+		return nil, nil, fmt.Errorf("заявка на уборку не предложена данной уборщице")
+	}
+
+	if task.Status != domain.CleaningTaskStatusOffered {
+		return nil, nil, fmt.Errorf("заявка на уборку уже не ожидает ответа")
+	}
+
+	return cleaner, task, nil
+}
+
+func (uc *cleanerUseCase) notifyAdminsCleaningEscalated(task *domain.CleaningTask) {
+	if uc.notificationUseCase == nil {
+		return
+	}
+
+	for _, adminID := range uc.getAdminUserIDs() {
+		err := uc.notificationUseCase.NotifyCleaningTaskEscalated(adminID, task.ApartmentID, task.ID, cleaningTaskApartmentTitle(task), task.DeadlineAt)
+		if err != nil {
+			log.Printf("⚠️ Ошибка уведомления администратора %d о заявке на уборку %d: %v", adminID, task.ID, err)
+		}
+	}
+}
+
+// notifyCleaningLate сообщает о просроченной уборке администраторам и владельцу квартиры.
+func (uc *cleanerUseCase) notifyCleaningLate(task *domain.CleaningTask) {
+	if uc.notificationUseCase == nil {
+		return
+	}
+
+	userIDs := uc.getAdminUserIDs()
+	if task.Apartment != nil {
+		owner, err := uc.ownerRepo.GetByID(task.Apartment.OwnerID)
+		if err != nil || owner == nil {
+			log.Printf("⚠️ Владелец квартиры %d не найден для уведомления о просроченной уборке: %v", task.ApartmentID, err)
+		} else {
+			userIDs = append(userIDs, owner.UserID)
+		}
+	}
+
+	for _, userID := range userIDs {
+		err := uc.notificationUseCase.NotifyCleaningLate(userID, task.ApartmentID, task.ID, cleaningTaskApartmentTitle(task), task.DeadlineAt)
+		if err != nil {
+			log.Printf("⚠️ Ошибка уведомления пользователя %d о просроченной уборке %d: %v", userID, task.ID, err)
+		}
+	}
+}
+
+func (uc *cleanerUseCase) getAdminUserIDs() []int {
+	admins, _, err := uc.userUseCase.GetAllUsers(map[string]interface{}{"role": domain.RoleAdmin}, 1, 100)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения администраторов: %v", err)
+		return nil
+	}
+
+	userIDs := make([]int, 0, len(admins))
+	for _, admin := range admins {
+		if admin.IsActive {
+			userIDs = append(userIDs, admin.ID)
+		}
+	}
+	return userIDs
+}
+
+func cleaningTaskApartmentTitle(task *domain.CleaningTask) string {
+	if task.Apartment == nil {
+		return fmt.Sprintf("#%d", task.ApartmentID)
+	}
+
+	return fmt.Sprintf("%s, д. %s, кв. %d", task.Apartment.Street, task.Apartment.Building, task.Apartment.ApartmentNumber)
+}
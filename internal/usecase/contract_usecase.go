@@ -178,13 +178,13 @@ func (u *contractUseCase) GetApartmentContract(apartmentID int) (*domain.Contrac
 	return contract, nil
 }
 
-func (u *contractUseCase) GetContractHTML(contractID int) (string, error) {
+func (u *contractUseCase) GetContractHTML(contractID int, lang string) (string, error) {
 	_, err := u.contractRepo.GetByID(contractID)
 	if err != nil {
 		return "", fmt.Errorf("договор не найден: %w", err)
 	}
 
-	html, err := u.contractService.GetOrGenerateContractHTML(contractID)
+	html, err := u.contractService.GetOrGenerateContractHTML(contractID, lang)
 	if err != nil {
 		return "", fmt.Errorf("ошибка генерации HTML: %w", err)
 	}
@@ -345,9 +345,11 @@ func (u *contractUseCase) createRentalSnapshot(booking *domain.Booking) (*domain
 		StartDate:        booking.StartDate,
 		EndDate:          booking.EndDate,
 		Duration:         booking.Duration,
+		Nights:           booking.Nights,
 		TotalPrice:       booking.TotalPrice,
 		ServiceFee:       booking.ServiceFee,
 		FinalPrice:       booking.FinalPrice,
+		PriceBreakdown:   booking.PriceBreakdown,
 		RenterName:       fmt.Sprintf("%s %s", renterUser.FirstName, renterUser.LastName),
 		RenterIIN:        renterUser.IIN,
 		OwnerName:        fmt.Sprintf("%s %s", ownerUser.FirstName, ownerUser.LastName),
@@ -1,14 +1,45 @@
 package usecase
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/internal/utils"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/i18n"
+)
+
+const (
+	// deviceRequestMaxSkew допустимое расхождение часов устройства и сервера
+	deviceRequestMaxSkew = 5 * time.Minute
+	// deviceNonceTTL nonce хранится дольше окна времени, чтобы повтор нельзя было отправить в пределах окна
+	deviceNonceTTL = 2 * deviceRequestMaxSkew
+	// deviceSecretGracePeriod сколько по умолчанию принимается предыдущий секрет после ротации
+	deviceSecretGracePeriod = 24 * time.Hour
+	deviceSecretBytes       = 32
+	deviceNonceMinLength    = 8
+	deviceNonceMaxLength    = 64
+
+	// tuyaWebhookMaxAge сколько принимается сообщение Tuya после отправки. Повторная доставка сохраняет
+	// исходное t и идет в течение нескольких часов, поэтому окно покрывает весь период повторов,
+	// а повтор перехваченного сообщения внутри окна отсекает дедупликация событий
+	tuyaWebhookMaxAge = 12 * time.Hour
+	// tuyaWebhookFutureSkew допустимое опережение часов Tuya относительно сервера
+	tuyaWebhookFutureSkew = 5 * time.Minute
+	// tuyaWebhookEventTTL событие помнится дольше окна приема, чтобы повтор нельзя было провести в пределах окна
+	tuyaWebhookEventTTL = 2 * tuyaWebhookMaxAge
 )
 
 type LockAutoUpdateService interface {
@@ -27,6 +58,7 @@ type lockUseCase struct {
 	tuyaService         domain.TuyaLockService
 	autoUpdateService   LockAutoUpdateService
 	notificationUseCase domain.NotificationUseCase
+	nonceStore          domain.NonceStore
 }
 
 func NewLockUseCase(
@@ -57,6 +89,10 @@ func (u *lockUseCase) SetNotificationUseCase(notificationUseCase domain.Notifica
 	u.notificationUseCase = notificationUseCase
 }
 
+func (u *lockUseCase) SetNonceStore(nonceStore domain.NonceStore) {
+	u.nonceStore = nonceStore
+}
+
 func (u *lockUseCase) generateNumericPassword() (string, error) {
 	min := int64(1000000)
 	max := int64(9999999)
@@ -79,7 +115,7 @@ func (u *lockUseCase) CreateLock(request *domain.CreateLockRequest) (*domain.Loc
 	if request.ApartmentID != nil {
 		_, err := u.apartmentRepo.GetByID(*request.ApartmentID)
 		if err != nil {
-			return nil, fmt.Errorf("квартира не найдена: %w", err)
+			return nil, apperrors.LockApartmentNotFound.Wrap(err, nil)
 		}
 	}
 
@@ -92,7 +128,7 @@ func (u *lockUseCase) CreateLock(request *domain.CreateLockRequest) (*domain.Loc
 		FirmwareVersion: request.FirmwareVersion,
 		IsOnline:        false,
 		TuyaDeviceID:    request.TuyaDeviceID,
-		OwnerPassword:   request.OwnerPassword,
+		OwnerPassword:   domain.Secret(request.OwnerPassword),
 	}
 
 	err = u.lockRepo.Create(lock)
@@ -100,6 +136,20 @@ func (u *lockUseCase) CreateLock(request *domain.CreateLockRequest) (*domain.Loc
 		return nil, fmt.Errorf("не удалось создать замок: %w", err)
 	}
 
+	secret, err := generateDeviceSecret()
+	if err == nil {
+		err = u.lockRepo.CreateDeviceCredentials(&domain.LockDeviceCredentials{
+			LockID: lock.ID,
+			Secret: domain.Secret(secret),
+		})
+	}
+	if err != nil {
+		// Замок уже создан: секрет можно выдать повторно через ротацию
+		log.Printf("⚠️ Не удалось выдать секрет устройства замку %s: %v", lock.UniqueID, err)
+	} else {
+		lock.DeviceSecret = secret
+	}
+
 	return lock, nil
 }
 
@@ -138,7 +188,7 @@ func (u *lockUseCase) GetAllWithFilters(filters map[string]interface{}, page, pa
 func (u *lockUseCase) UpdateLock(id int, request *domain.UpdateLockRequest) error {
 	lock, err := u.lockRepo.GetByID(id)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	if request.Name != "" {
@@ -157,7 +207,7 @@ func (u *lockUseCase) UpdateLock(id int, request *domain.UpdateLockRequest) erro
 func (u *lockUseCase) DeleteLock(id int) error {
 	_, err := u.lockRepo.GetByID(id)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	return u.lockRepo.Delete(id)
@@ -166,7 +216,7 @@ func (u *lockUseCase) DeleteLock(id int) error {
 func (u *lockUseCase) UpdateLockStatus(request *domain.LockStatusUpdateRequest) error {
 	lock, err := u.lockRepo.GetByUniqueID(request.UniqueID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	oldStatus := lock.CurrentStatus
@@ -205,7 +255,7 @@ func (u *lockUseCase) UpdateLockStatus(request *domain.LockStatusUpdateRequest)
 func (u *lockUseCase) ProcessHeartbeat(request *domain.LockHeartbeatRequest) error {
 	lock, err := u.lockRepo.GetByUniqueID(request.UniqueID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	timestamp := request.Timestamp
@@ -237,15 +287,135 @@ func (u *lockUseCase) ProcessHeartbeat(request *domain.LockHeartbeatRequest) err
 	return nil
 }
 
-func (u *lockUseCase) GeneratePasswordForBooking(uniqueID string, userID int, bookingID int) (string, error) {
+// AuthenticateDeviceRequest проверяет подпись запроса устройства, окно времени и одноразовость nonce.
+// Отклоненные запросы к известным замкам записываются в журнал статусов замка.
+func (u *lockUseCase) AuthenticateDeviceRequest(request *domain.LockDeviceRequest) (*domain.Lock, error) {
+	if request.UniqueID == "" {
+		log.Printf("🚫 Запрос устройства %s %s с %s без %s отклонен", request.Method, request.Path, request.IPAddress, domain.LockDeviceIDHeader)
+		return nil, apperrors.LockDeviceSignatureMissing.New(nil)
+	}
+
+	lock, err := u.lockRepo.GetByUniqueID(request.UniqueID)
+	if err != nil {
+		log.Printf("🚫 Запрос устройства для неизвестного замка %s с %s отклонен", request.UniqueID, request.IPAddress)
+		return nil, apperrors.LockDeviceSignatureInvalid.New(nil)
+	}
+
+	if request.Timestamp == "" || request.Nonce == "" || request.Signature == "" {
+		return nil, u.rejectDeviceRequest(lock, request, apperrors.LockDeviceSignatureMissing.New(nil), "запрос не подписан")
+	}
+
+	unixTime, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return nil, u.rejectDeviceRequest(lock, request, apperrors.LockDeviceSignatureInvalid.New(nil), "некорректное время запроса")
+	}
+
+	skew := time.Since(time.Unix(unixTime, 0))
+	if skew > deviceRequestMaxSkew || skew < -deviceRequestMaxSkew {
+		appErr := apperrors.LockDeviceTimestampSkew.New(i18n.Params{"seconds": strconv.Itoa(int(deviceRequestMaxSkew.Seconds()))})
+		return nil, u.rejectDeviceRequest(lock, request, appErr, fmt.Sprintf("время запроса расходится на %s", skew.Round(time.Second)))
+	}
+
+	if len(request.Nonce) < deviceNonceMinLength || len(request.Nonce) > deviceNonceMaxLength {
+		return nil, u.rejectDeviceRequest(lock, request, apperrors.LockDeviceSignatureInvalid.New(nil), "некорректный nonce")
+	}
+
+	credentials, err := u.lockRepo.GetDeviceCredentials(lock.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения секрета устройства: %w", err)
+	}
+	if credentials == nil {
+		return nil, u.rejectDeviceRequest(lock, request, apperrors.LockDeviceNotProvisioned.New(nil), "секрет устройства не выдан")
+	}
+
+	if !deviceSignatureValid(credentials, request) {
+		return nil, u.rejectDeviceRequest(lock, request, apperrors.LockDeviceSignatureInvalid.New(nil), "неверная подпись")
+	}
+
+	if u.nonceStore == nil {
+		return nil, fmt.Errorf("хранилище nonce не настроено")
+	}
+
+	// nonce занимается только после проверки подписи, иначе чужие запросы могли бы расходовать nonce устройства
+	fresh, err := u.nonceStore.Claim(fmt.Sprintf("lock:device:nonce:%s:%s", lock.UniqueID, request.Nonce), deviceNonceTTL)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки nonce: %w", err)
+	}
+	if !fresh {
+		return nil, u.rejectDeviceRequest(lock, request, apperrors.LockDeviceNonceReused.New(nil), "повтор nonce")
+	}
+
+	return lock, nil
+}
+
+// rejectDeviceRequest записывает отклоненный запрос устройства в журнал статусов замка.
+func (u *lockUseCase) rejectDeviceRequest(lock *domain.Lock, request *domain.LockDeviceRequest, appErr *apperrors.AppError, reason string) error {
+	log.Printf("🚫 Запрос устройства %s %s для замка %s с %s отклонен: %s", request.Method, request.Path, lock.UniqueID, request.IPAddress, reason)
+
+	statusLog := &domain.LockStatusLog{
+		LockID:       lock.ID,
+		OldStatus:    &lock.CurrentStatus,
+		NewStatus:    lock.CurrentStatus,
+		ChangeSource: domain.LockChangeSourceDevice,
+		Notes:        fmt.Sprintf("Отклонен запрос устройства %s %s с %s: %s", request.Method, request.Path, request.IPAddress, reason),
+	}
+	if err := u.lockRepo.CreateStatusLog(statusLog); err != nil {
+		log.Printf("❌ Ошибка записи отклоненного запроса устройства в журнал: %v", err)
+	}
+
+	return appErr
+}
+
+// deviceSignatureValid сверяет подпись с текущим секретом и с предыдущим, пока не истек льготный период.
+func deviceSignatureValid(credentials *domain.LockDeviceCredentials, request *domain.LockDeviceRequest) bool {
+	provided := []byte(strings.ToLower(request.Signature))
+
+	if hmac.Equal([]byte(deviceSignature(credentials.Secret, request)), provided) {
+		return true
+	}
+
+	if credentials.PreviousSecret.IsEmpty() || credentials.PreviousExpiresAt == nil || time.Now().After(*credentials.PreviousExpiresAt) {
+		return false
+	}
+
+	return hmac.Equal([]byte(deviceSignature(credentials.PreviousSecret, request)), provided)
+}
+
+// deviceSignature подпись запроса, формат описан у domain.LockDeviceSignatureHeader.
+func deviceSignature(secret domain.Secret, request *domain.LockDeviceRequest) string {
+	bodyHash := sha256.Sum256(request.Body)
+	payload := strings.Join([]string{
+		strings.ToUpper(request.Method),
+		request.Path,
+		request.Timestamp,
+		request.Nonce,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret.Reveal()))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateDeviceSecret() (string, error) {
+	secret := make([]byte, deviceSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("ошибка генерации секрета устройства: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func (u *lockUseCase) GeneratePasswordForBooking(uniqueID string, actor *domain.LockCodeRevealActor, bookingID int) (string, error) {
+	userID := actor.UserID
+
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return "", fmt.Errorf("замок не найден: %w", err)
+		return "", lockLookupError(err)
 	}
 
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return "", fmt.Errorf("бронирование не найдено: %w", err)
+		return "", apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	if lock.ApartmentID == nil || *lock.ApartmentID != booking.ApartmentID {
@@ -264,7 +434,10 @@ func (u *lockUseCase) GeneratePasswordForBooking(uniqueID string, userID int, bo
 	if err == nil && len(existingPasswords) > 0 {
 		for _, p := range existingPasswords {
 			if p.IsActive && p.ValidUntil.After(time.Now()) {
-				return p.Password, nil
+				if err := u.recordCodeReveal(p, actor, domain.LockCodeRevealReasonBooking); err != nil {
+					return "", err
+				}
+				return p.Password.Reveal(), nil
 			}
 		}
 	}
@@ -299,7 +472,7 @@ func (u *lockUseCase) GeneratePasswordForBooking(uniqueID string, userID int, bo
 		LockID:         lock.ID,
 		BookingID:      &bookingID,
 		UserID:         &userID,
-		Password:       tuyaPassword,
+		Password:       domain.Secret(tuyaPassword),
 		TuyaPasswordID: tuyaPasswordID,
 		Name:           passwordName,
 		ValidFrom:      validFrom,
@@ -320,10 +493,14 @@ func (u *lockUseCase) GeneratePasswordForBooking(uniqueID string, userID int, bo
 		ChangeSource: domain.LockChangeSourceAPI,
 		UserID:       &userID,
 		BookingID:    &bookingID,
-		Notes:        fmt.Sprintf("Создан временный пароль для бронирования: %s", password),
+		Notes:        fmt.Sprintf("Создан временный пароль для бронирования %d", bookingID),
 	}
 	u.lockRepo.CreateStatusLog(statusLog)
 
+	if err := u.recordCodeReveal(tempPassword, actor, domain.LockCodeRevealReasonBooking); err != nil {
+		return "", err
+	}
+
 	if u.notificationUseCase != nil {
 		apartment, _ := u.apartmentRepo.GetByID(booking.ApartmentID)
 		apartmentTitle := "квартира"
@@ -336,10 +513,12 @@ func (u *lockUseCase) GeneratePasswordForBooking(uniqueID string, userID int, bo
 	return tuyaPassword, nil
 }
 
-func (u *lockUseCase) GetOwnerPassword(uniqueID string, userID int) (string, error) {
+func (u *lockUseCase) GetOwnerPassword(uniqueID string, actor *domain.LockCodeRevealActor) (string, error) {
+	userID := actor.UserID
+
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return "", fmt.Errorf("замок не найден: %w", err)
+		return "", lockLookupError(err)
 	}
 
 	if lock.ApartmentID == nil {
@@ -348,18 +527,29 @@ func (u *lockUseCase) GetOwnerPassword(uniqueID string, userID int) (string, err
 
 	apartment, err := u.apartmentRepo.GetByID(*lock.ApartmentID)
 	if err != nil {
-		return "", fmt.Errorf("квартира не найдена: %w", err)
+		return "", apperrors.LockApartmentNotFound.Wrap(err, nil)
 	}
 
 	user, err := u.userUseCase.GetByID(userID)
 	if err != nil {
-		return "", fmt.Errorf("пользователь не найден: %w", err)
+		return "", fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil {
+		return "", apperrors.UserNotFound.New(nil)
 	}
 
 	if user.Role == domain.RoleOwner {
 		propertyOwner, err := u.propertyOwnerRepo.GetByUserID(userID)
 		if err == nil && propertyOwner.ID == apartment.OwnerID {
-			return lock.OwnerPassword, nil
+			reveal := &domain.LockCodeReveal{
+				LockID:   lock.ID,
+				CodeType: domain.LockCodeTypeOwner,
+				Reason:   domain.LockCodeRevealReasonOwner,
+			}
+			if err := u.saveCodeReveal(reveal, actor); err != nil {
+				return "", err
+			}
+			return lock.OwnerPassword.Reveal(), nil
 		}
 	}
 
@@ -421,7 +611,7 @@ func (u *lockUseCase) ExtendPasswordForBooking(bookingID int, newEndDate time.Ti
 			_, newTuyaPasswordID, err := u.tuyaService.GenerateTemporaryPasswordWithTimes(
 				lock.TuyaDeviceID,
 				password.Name,
-				password.Password,
+				password.Password.Reveal(),
 				password.ValidFrom,
 				newValidUntil,
 			)
@@ -452,6 +642,117 @@ func (u *lockUseCase) ExtendPasswordForBooking(bookingID int, newEndDate time.Ti
 	return nil
 }
 
+func (u *lockUseCase) GeneratePasswordForCleaning(uniqueID string, actor *domain.LockCodeRevealActor, cleaningLogID int, validUntil time.Time) (*domain.LockTempPassword, error) {
+	userID := actor.UserID
+
+	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
+	if err != nil {
+		return nil, lockLookupError(err)
+	}
+
+	if lock.TuyaDeviceID == "" {
+		return nil, fmt.Errorf("у замка отсутствует TuyaDeviceID")
+	}
+
+	now := utils.GetCurrentTimeUTC()
+	if !validUntil.After(now) {
+		return nil, fmt.Errorf("окно уборки уже закончилось")
+	}
+
+	existingPasswords, err := u.lockRepo.GetTempPasswordsByCleaningLogID(cleaningLogID)
+	if err == nil && len(existingPasswords) > 0 {
+		for _, p := range existingPasswords {
+			if p.IsActive && p.LockID == lock.ID && p.ValidUntil.After(now) {
+				if err := u.recordCodeReveal(p, actor, domain.LockCodeRevealReasonCleaning); err != nil {
+					return nil, err
+				}
+				return p, nil
+			}
+		}
+	}
+
+	password, err := u.generateNumericPassword()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка генерации пароля: %w", err)
+	}
+
+	validFrom := now.Add(-5 * time.Minute)
+	passwordName := fmt.Sprintf("Cleaning_%d", cleaningLogID)
+	tuyaPassword, tuyaPasswordID, err := u.tuyaService.GenerateTemporaryPasswordWithTimes(
+		lock.TuyaDeviceID,
+		passwordName,
+		password,
+		validFrom,
+		validUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания пароля в Tuya: %w", err)
+	}
+
+	tempPassword := &domain.LockTempPassword{
+		LockID:         lock.ID,
+		CleaningLogID:  &cleaningLogID,
+		UserID:         &userID,
+		Password:       domain.Secret(tuyaPassword),
+		TuyaPasswordID: tuyaPasswordID,
+		Name:           passwordName,
+		ValidFrom:      validFrom,
+		ValidUntil:     validUntil,
+		IsActive:       true,
+	}
+
+	err = u.lockRepo.CreateTempPassword(tempPassword)
+	if err != nil {
+		u.tuyaService.DeleteTempPassword(lock.TuyaDeviceID, tuyaPasswordID)
+		return nil, fmt.Errorf("ошибка сохранения пароля в БД: %w", err)
+	}
+
+	statusLog := &domain.LockStatusLog{
+		LockID:       lock.ID,
+		OldStatus:    &lock.CurrentStatus,
+		NewStatus:    lock.CurrentStatus,
+		ChangeSource: domain.LockChangeSourceAPI,
+		UserID:       &userID,
+		Notes:        fmt.Sprintf("Создан временный пароль для уборки %d", cleaningLogID),
+	}
+	u.lockRepo.CreateStatusLog(statusLog)
+
+	if err := u.recordCodeReveal(tempPassword, actor, domain.LockCodeRevealReasonCleaning); err != nil {
+		return nil, err
+	}
+
+	return tempPassword, nil
+}
+
+func (u *lockUseCase) DeactivatePasswordForCleaning(cleaningLogID int) error {
+	passwords, err := u.lockRepo.GetTempPasswordsByCleaningLogID(cleaningLogID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения паролей: %w", err)
+	}
+
+	for _, password := range passwords {
+		if password.IsActive {
+			err = u.lockRepo.DeactivateTempPassword(password.ID)
+			if err != nil {
+				log.Printf("❌ Ошибка деактивации пароля уборки %d в БД: %v", password.ID, err)
+				continue
+			}
+			log.Printf("✅ Пароль уборки %d деактивирован в БД", password.ID)
+
+			lock, err := u.lockRepo.GetByID(password.LockID)
+			if err != nil {
+				log.Printf("❌ Ошибка получения замка %d: %v", password.LockID, err)
+				continue
+			}
+			if err := u.tuyaService.DeleteTempPassword(lock.TuyaDeviceID, password.TuyaPasswordID); err != nil {
+				log.Printf("❌ Ошибка удаления пароля уборки из Tuya: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (u *lockUseCase) GetLockStatus(uniqueID string) (*domain.Lock, error) {
 	return u.lockRepo.GetByUniqueID(uniqueID)
 }
@@ -466,7 +767,7 @@ func (u *lockUseCase) GetLockHistory(uniqueID string, limit int) ([]*domain.Lock
 func (u *lockUseCase) CanUserControlLock(uniqueID string, userID int) (bool, error) {
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return false, fmt.Errorf("замок не найден: %w", err)
+		return false, lockLookupError(err)
 	}
 
 	if lock.ApartmentID == nil {
@@ -475,12 +776,15 @@ func (u *lockUseCase) CanUserControlLock(uniqueID string, userID int) (bool, err
 
 	apartment, err := u.apartmentRepo.GetByID(*lock.ApartmentID)
 	if err != nil {
-		return false, fmt.Errorf("квартира не найдена: %w", err)
+		return false, apperrors.LockApartmentNotFound.Wrap(err, nil)
 	}
 
 	user, err := u.userUseCase.GetByID(userID)
 	if err != nil {
-		return false, fmt.Errorf("пользователь не найден: %w", err)
+		return false, fmt.Errorf("ошибка получения пользователя: %w", err)
+	}
+	if user == nil {
+		return false, apperrors.UserNotFound.New(nil)
 	}
 
 	if user.Role == domain.RoleOwner {
@@ -602,12 +906,12 @@ func (u *lockUseCase) CanUserManageLockViaBooking(booking *domain.Booking, userI
 func (u *lockUseCase) BindLockToApartment(lockID, apartmentID int) error {
 	lock, err := u.lockRepo.GetByID(lockID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	_, err = u.apartmentRepo.GetByID(apartmentID)
 	if err != nil {
-		return fmt.Errorf("квартира не найдена: %w", err)
+		return apperrors.LockApartmentNotFound.Wrap(err, nil)
 	}
 
 	existingLock, err := u.lockRepo.GetByApartmentID(apartmentID)
@@ -628,7 +932,7 @@ func (u *lockUseCase) BindLockToApartment(lockID, apartmentID int) error {
 func (u *lockUseCase) UnbindLockFromApartment(lockID int) error {
 	lock, err := u.lockRepo.GetByID(lockID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	if lock.ApartmentID == nil {
@@ -647,7 +951,7 @@ func (u *lockUseCase) UnbindLockFromApartment(lockID int) error {
 func (u *lockUseCase) EmergencyResetLock(lockID int) error {
 	lock, err := u.lockRepo.GetByID(lockID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	tempPasswords, err := u.lockRepo.GetTempPasswordsByLockID(lockID)
@@ -685,6 +989,63 @@ func (u *lockUseCase) EmergencyResetLock(lockID int) error {
 	return nil
 }
 
+// ReceiveTuyaWebhook проверяет подпись и время сообщения Tuya, расшифровывает событие и обрабатывает его
+// один раз: повторные доставки того же события возвращаются с Duplicate и не обрабатываются.
+func (u *lockUseCase) ReceiveTuyaWebhook(message *domain.TuyaWebhookMessage) (*domain.TuyaWebhookResult, error) {
+	if u.tuyaService == nil {
+		return nil, fmt.Errorf("сервис Tuya не инициализирован")
+	}
+
+	event, err := u.tuyaService.OpenWebhookMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	// Время входит в подпись, поэтому проверяется после нее
+	skew := time.Since(time.UnixMilli(message.T))
+	if skew > tuyaWebhookMaxAge || skew < -tuyaWebhookFutureSkew {
+		return nil, apperrors.TuyaWebhookExpired.New(nil)
+	}
+
+	if u.nonceStore == nil {
+		return nil, fmt.Errorf("хранилище обработанных событий не настроено")
+	}
+
+	eventID := tuyaWebhookEventID(event)
+	key := "tuya:webhook:event:" + eventID
+
+	fresh, err := u.nonceStore.Claim(key, tuyaWebhookEventTTL)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка проверки повторной доставки: %w", err)
+	}
+	if !fresh {
+		log.Printf("🔁 Tuya событие %s (%s, устройство %s) уже обработано, повтор пропущен", eventID, event.BizCode, event.DevID)
+		return &domain.TuyaWebhookResult{EventID: eventID, Duplicate: true}, nil
+	}
+
+	if err := u.ProcessTuyaWebhookEvent(event); err != nil {
+		// Событие не обработано: повторная доставка от Tuya должна пройти
+		if releaseErr := u.nonceStore.Release(key); releaseErr != nil {
+			log.Printf("❌ Не удалось снять отметку с Tuya события %s: %v", eventID, releaseErr)
+		}
+		return nil, err
+	}
+
+	return &domain.TuyaWebhookResult{EventID: eventID}, nil
+}
+
+// tuyaWebhookEventID идентификатор события для дедупликации. Если Tuya не передала uuid, он строится
+// из содержимого события, а не из шифротекста, который при повторной доставке может отличаться.
+func tuyaWebhookEventID(event *domain.TuyaWebhookEvent) string {
+	if event.UUID != "" {
+		return event.UUID
+	}
+
+	bizData, _ := json.Marshal(event.BizData)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%d|%s", event.DevID, event.BizCode, event.Ts, bizData)))
+	return hex.EncodeToString(sum[:])
+}
+
 func (u *lockUseCase) ProcessTuyaWebhookEvent(event *domain.TuyaWebhookEvent) error {
 	log.Printf("🔄 Обработка Tuya webhook события: %s для устройства %s", event.BizCode, event.DevID)
 
@@ -713,7 +1074,7 @@ func (u *lockUseCase) SyncLockWithTuya(uniqueID string) error {
 
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	if u.autoUpdateService == nil {
@@ -733,7 +1094,7 @@ func (u *lockUseCase) EnableAutoUpdate(uniqueID string) error {
 
 	_, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	if err := u.lockRepo.EnableAutoUpdate(uniqueID, true); err != nil {
@@ -749,7 +1110,7 @@ func (u *lockUseCase) DisableAutoUpdate(uniqueID string) error {
 
 	_, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	if err := u.lockRepo.EnableAutoUpdate(uniqueID, false); err != nil {
@@ -765,7 +1126,7 @@ func (u *lockUseCase) ConfigureTuyaWebhooks(uniqueID string) error {
 
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	if lock.TuyaDeviceID == "" {
@@ -807,7 +1168,7 @@ func (u *lockUseCase) UpdateTuyaSync(uniqueID string, syncTime time.Time) error
 func (u *lockUseCase) AdminGeneratePassword(uniqueID string, request *domain.AdminGeneratePasswordRequest) (*domain.LockTempPassword, error) {
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return nil, fmt.Errorf("замок не найден: %w", err)
+		return nil, lockLookupError(err)
 	}
 
 	validFrom, err := time.Parse(time.RFC3339, request.ValidFrom)
@@ -848,7 +1209,7 @@ func (u *lockUseCase) AdminGeneratePassword(uniqueID string, request *domain.Adm
 		LockID:         lock.ID,
 		BookingID:      nil,
 		UserID:         request.UserID,
-		Password:       tuyaPassword,
+		Password:       domain.Secret(tuyaPassword),
 		TuyaPasswordID: tuyaPasswordID,
 		Name:           request.Name,
 		ValidFrom:      validFrom,
@@ -879,7 +1240,7 @@ func (u *lockUseCase) AdminGeneratePassword(uniqueID string, request *domain.Adm
 func (u *lockUseCase) AdminGetAllLockPasswords(uniqueID string) ([]*domain.LockTempPassword, error) {
 	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
 	if err != nil {
-		return nil, fmt.Errorf("замок не найден: %w", err)
+		return nil, lockLookupError(err)
 	}
 
 	return u.lockRepo.GetTempPasswordsByLockID(lock.ID)
@@ -888,7 +1249,7 @@ func (u *lockUseCase) AdminGetAllLockPasswords(uniqueID string) ([]*domain.LockT
 func (u *lockUseCase) AdminDeactivatePassword(passwordID int) error {
 	password, err := u.lockRepo.GetTempPasswordByID(passwordID)
 	if err != nil {
-		return fmt.Errorf("пароль не найден: %w", err)
+		return lockPasswordLookupError(err)
 	}
 
 	if !password.IsActive {
@@ -902,7 +1263,7 @@ func (u *lockUseCase) AdminDeactivatePassword(passwordID int) error {
 
 	lock, err := u.lockRepo.GetByID(password.LockID)
 	if err != nil {
-		return fmt.Errorf("замок не найден: %w", err)
+		return lockLookupError(err)
 	}
 
 	err = u.tuyaService.DeleteTempPassword(lock.TuyaDeviceID, password.TuyaPasswordID)
@@ -913,10 +1274,12 @@ func (u *lockUseCase) AdminDeactivatePassword(passwordID int) error {
 	return u.lockRepo.DeactivateTempPassword(passwordID)
 }
 
-func (u *lockUseCase) GeneratePasswordForBookingByID(bookingID, userID int) (string, error) {
+func (u *lockUseCase) GeneratePasswordForBookingByID(bookingID int, actor *domain.LockCodeRevealActor) (string, error) {
+	userID := actor.UserID
+
 	booking, err := u.bookingRepo.GetByID(bookingID)
 	if err != nil {
-		return "", fmt.Errorf("бронирование не найдено: %w", err)
+		return "", apperrors.BookingNotFound.Wrap(err, nil)
 	}
 
 	canManage, err := u.CanUserManageLockViaBooking(booking, userID)
@@ -929,8 +1292,175 @@ func (u *lockUseCase) GeneratePasswordForBookingByID(bookingID, userID int) (str
 
 	lock, err := u.GetLockByApartmentID(booking.ApartmentID)
 	if err != nil {
-		return "", fmt.Errorf("замок не найден для квартиры: %w", err)
+		return "", lockLookupError(err)
+	}
+
+	return u.GeneratePasswordForBooking(lock.UniqueID, actor, bookingID)
+}
+
+func (u *lockUseCase) AdminRevealPassword(passwordID int, actor *domain.LockCodeRevealActor) (string, error) {
+	password, err := u.lockRepo.GetTempPasswordByID(passwordID)
+	if err != nil {
+		return "", lockPasswordLookupError(err)
+	}
+
+	if err := u.recordCodeReveal(password, actor, domain.LockCodeRevealReasonAdmin); err != nil {
+		return "", err
+	}
+
+	return password.Password.Reveal(), nil
+}
+
+func (u *lockUseCase) AdminGetCodeReveals(uniqueID string, limit int) ([]*domain.LockCodeReveal, error) {
+	lock, err := u.lockRepo.GetByUniqueID(uniqueID)
+	if err != nil {
+		return nil, lockLookupError(err)
 	}
 
-	return u.GeneratePasswordForBooking(lock.UniqueID, userID, bookingID)
+	if limit <= 0 {
+		limit = 50
+	}
+
+	return u.lockRepo.GetCodeRevealsByLockID(lock.ID, limit)
+}
+
+func (u *lockUseCase) RotateCodeEncryption() (int, error) {
+	rotated, err := u.lockRepo.RotateCodeEncryption()
+	if err != nil {
+		return rotated, fmt.Errorf("ошибка перешифровки кодов замков: %w", err)
+	}
+
+	if rotated > 0 {
+		log.Printf("🔐 Перешифровано кодов замков: %d", rotated)
+	}
+
+	return rotated, nil
+}
+
+// recordCodeReveal записывает в журнал раскрытие временного пароля.
+func (u *lockUseCase) recordCodeReveal(password *domain.LockTempPassword, actor *domain.LockCodeRevealActor, reason string) error {
+	reveal := &domain.LockCodeReveal{
+		LockID:         password.LockID,
+		TempPasswordID: &password.ID,
+		CodeType:       domain.LockCodeTypeTemp,
+		BookingID:      password.BookingID,
+		Reason:         reason,
+	}
+
+	return u.saveCodeReveal(reveal, actor)
+}
+
+// saveCodeReveal сохраняет запись о раскрытии кода. Если запись не удалась, код не выдается.
+func (u *lockUseCase) saveCodeReveal(reveal *domain.LockCodeReveal, actor *domain.LockCodeRevealActor) error {
+	if actor != nil {
+		reveal.UserID = &actor.UserID
+		reveal.IPAddress = actor.IPAddress
+		reveal.UserAgent = actor.UserAgent
+	}
+
+	if err := u.lockRepo.CreateCodeReveal(reveal); err != nil {
+		return fmt.Errorf("ошибка записи в журнал раскрытия кодов: %w", err)
+	}
+
+	return nil
+}
+
+func (u *lockUseCase) AdminRotateDeviceSecret(lockID int, request *domain.RotateLockDeviceSecretRequest) (*domain.LockDeviceSecretResponse, error) {
+	lock, err := u.lockRepo.GetByID(lockID)
+	if err != nil {
+		return nil, lockLookupError(err)
+	}
+
+	gracePeriod := deviceSecretGracePeriod
+	if request != nil && request.GracePeriodMinutes != nil {
+		if *request.GracePeriodMinutes < 0 {
+			return nil, apperrors.LockGracePeriodNegative.New(nil)
+		}
+		gracePeriod = time.Duration(*request.GracePeriodMinutes) * time.Minute
+	}
+
+	secret, err := generateDeviceSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := u.lockRepo.GetDeviceCredentials(lock.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения секрета устройства: %w", err)
+	}
+
+	if credentials == nil {
+		credentials = &domain.LockDeviceCredentials{
+			LockID: lock.ID,
+			Secret: domain.Secret(secret),
+		}
+		if err := u.lockRepo.CreateDeviceCredentials(credentials); err != nil {
+			return nil, fmt.Errorf("ошибка сохранения секрета устройства: %w", err)
+		}
+	} else {
+		now := time.Now()
+		credentials.PreviousSecret = ""
+		credentials.PreviousExpiresAt = nil
+		if gracePeriod > 0 {
+			expiresAt := now.Add(gracePeriod)
+			credentials.PreviousSecret = credentials.Secret
+			credentials.PreviousExpiresAt = &expiresAt
+		}
+		credentials.Secret = domain.Secret(secret)
+		credentials.RotatedAt = now
+
+		if err := u.lockRepo.UpdateDeviceCredentials(credentials); err != nil {
+			return nil, fmt.Errorf("ошибка сохранения секрета устройства: %w", err)
+		}
+	}
+
+	log.Printf("🔑 Секрет устройства замка %s обновлен", lock.UniqueID)
+
+	return &domain.LockDeviceSecretResponse{
+		LockID:            lock.ID,
+		UniqueID:          lock.UniqueID,
+		DeviceSecret:      secret,
+		PreviousExpiresAt: credentials.PreviousExpiresAt,
+		RotatedAt:         credentials.RotatedAt,
+	}, nil
+}
+
+func (u *lockUseCase) AdminRevokePreviousDeviceSecret(lockID int) error {
+	lock, err := u.lockRepo.GetByID(lockID)
+	if err != nil {
+		return lockLookupError(err)
+	}
+
+	credentials, err := u.lockRepo.GetDeviceCredentials(lock.ID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения секрета устройства: %w", err)
+	}
+	if credentials == nil {
+		return apperrors.LockDeviceSecretNotFound.New(nil)
+	}
+
+	credentials.PreviousSecret = ""
+	credentials.PreviousExpiresAt = nil
+	if err := u.lockRepo.UpdateDeviceCredentials(credentials); err != nil {
+		return fmt.Errorf("ошибка отзыва предыдущего секрета: %w", err)
+	}
+
+	log.Printf("🔑 Предыдущий секрет устройства замка %s отозван", lock.UniqueID)
+
+	return nil
+}
+
+// lockLookupError отличает отсутствующий замок от сбоя базы: только первый становится ошибкой каталога.
+func lockLookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperrors.LockNotFound.Wrap(err, nil)
+	}
+	return fmt.Errorf("ошибка получения замка: %w", err)
+}
+
+func lockPasswordLookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperrors.LockPasswordNotFound.Wrap(err, nil)
+	}
+	return fmt.Errorf("ошибка получения пароля замка: %w", err)
 }
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+)
+
+// webhookTuyaServiceStub считает подпись любого сообщения верной: здесь проверяется окно приема и дедупликация
+type webhookTuyaServiceStub struct {
+	domain.TuyaLockService
+}
+
+func (s *webhookTuyaServiceStub) OpenWebhookMessage(message *domain.TuyaWebhookMessage) (*domain.TuyaWebhookEvent, error) {
+	return &domain.TuyaWebhookEvent{BizCode: "online", DevID: "lock-1", UUID: message.Data}, nil
+}
+
+type webhookAutoUpdateStub struct {
+	LockAutoUpdateService
+	processed int
+}
+
+func (s *webhookAutoUpdateStub) ProcessWebhookEvent(event *domain.TuyaWebhookEvent) error {
+	s.processed++
+	return nil
+}
+
+type memoryNonceStore struct {
+	claimed map[string]time.Duration
+}
+
+func (s *memoryNonceStore) Claim(key string, ttl time.Duration) (bool, error) {
+	if _, ok := s.claimed[key]; ok {
+		return false, nil
+	}
+	s.claimed[key] = ttl
+	return true, nil
+}
+
+func (s *memoryNonceStore) Release(key string) error {
+	delete(s.claimed, key)
+	return nil
+}
+
+func newTestWebhookLockUseCase() (*lockUseCase, *webhookAutoUpdateStub, *memoryNonceStore) {
+	autoUpdate := &webhookAutoUpdateStub{}
+	nonceStore := &memoryNonceStore{claimed: make(map[string]time.Duration)}
+	useCase := &lockUseCase{
+		tuyaService:       &webhookTuyaServiceStub{},
+		autoUpdateService: autoUpdate,
+		nonceStore:        nonceStore,
+	}
+	return useCase, autoUpdate, nonceStore
+}
+
+func testWebhookMessage(eventID string, sentAt time.Time) *domain.TuyaWebhookMessage {
+	return &domain.TuyaWebhookMessage{Data: eventID, Sign: "sign", PV: "2.0", T: sentAt.UnixMilli()}
+}
+
+func TestReceiveTuyaWebhookAcceptsDelayedRetry(t *testing.T) {
+	useCase, autoUpdate, nonceStore := newTestWebhookLockUseCase()
+
+	// Повторная доставка приходит через несколько часов с исходным t
+	message := testWebhookMessage("event-1", time.Now().Add(-3*time.Hour))
+	result, err := useCase.ReceiveTuyaWebhook(message)
+	if err != nil {
+		t.Fatalf("expected delayed retry to be accepted, got %v", err)
+	}
+	if result.Duplicate || autoUpdate.processed != 1 {
+		t.Fatalf("expected event to be processed once, got duplicate=%v processed=%d", result.Duplicate, autoUpdate.processed)
+	}
+	if ttl := nonceStore.claimed["tuya:webhook:event:event-1"]; ttl <= tuyaWebhookMaxAge {
+		t.Fatalf("expected event to be remembered longer than the acceptance window, got %s", ttl)
+	}
+
+	result, err = useCase.ReceiveTuyaWebhook(message)
+	if err != nil {
+		t.Fatalf("replay: unexpected error: %v", err)
+	}
+	if !result.Duplicate || autoUpdate.processed != 1 {
+		t.Fatalf("replay: expected duplicate without processing, got duplicate=%v processed=%d", result.Duplicate, autoUpdate.processed)
+	}
+}
+
+func TestReceiveTuyaWebhookRejectsMessagesOutsideWindow(t *testing.T) {
+	useCase, autoUpdate, _ := newTestWebhookLockUseCase()
+
+	messages := map[string]*domain.TuyaWebhookMessage{
+		"expired": testWebhookMessage("event-expired", time.Now().Add(-tuyaWebhookMaxAge-time.Minute)),
+		"future":  testWebhookMessage("event-future", time.Now().Add(tuyaWebhookFutureSkew+time.Minute)),
+	}
+	for name, message := range messages {
+		if _, err := useCase.ReceiveTuyaWebhook(message); !apperrors.TuyaWebhookExpired.Is(err) {
+			t.Fatalf("%s: expected expired error, got %v", name, err)
+		}
+	}
+	if autoUpdate.processed != 0 {
+		t.Fatalf("expected no events to be processed, got %d", autoUpdate.processed)
+	}
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"strings"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/pkg/i18n"
+)
+
+// notificationFallbacks каналы, через которые уведомление доставляется, если push недоступен:
+// у пользователя нет активных устройств или Expo отклонил все токены. Каналы пробуются по порядку
+// до первой успешной отправки.
+var notificationFallbacks = map[domain.NotificationType][]domain.NotificationChannel{
+	domain.NotificationPasswordReady:   {domain.NotificationChannelSMS, domain.NotificationChannelEmail},
+	domain.NotificationLockIssue:       {domain.NotificationChannelSMS},
+	domain.NotificationBookingApproved: {domain.NotificationChannelSMS, domain.NotificationChannelEmail},
+	domain.NotificationBookingRejected: {domain.NotificationChannelEmail},
+	domain.NotificationBookingCanceled: {domain.NotificationChannelEmail, domain.NotificationChannelSMS},
+	domain.NotificationPaymentRequired: {domain.NotificationChannelSMS, domain.NotificationChannelEmail},
+	domain.NotificationNewBooking:      {domain.NotificationChannelEmail},
+}
+
+// externalNotificationChannels каналы, доставка по которым идет через очередь уведомлений.
+var externalNotificationChannels = []domain.NotificationChannel{
+	domain.NotificationChannelPush,
+	domain.NotificationChannelSMS,
+	domain.NotificationChannelEmail,
+}
+
+type notificationEmailData struct {
+	Lang      string
+	Title     string
+	Greeting  string
+	Message   string
+	Signature string
+}
+
+var emailHTMLTemplate = template.Must(template.New("email").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head><meta charset="UTF-8"><title>{{.Title}}</title></head>
+<body style="margin:0;padding:24px;background:#f5f5f5;font-family:Arial,sans-serif;color:#222;">
+  <div style="max-width:560px;margin:0 auto;background:#fff;border-radius:8px;padding:24px;">
+    <h2 style="margin-top:0;">{{.Title}}</h2>
+    <p>{{.Greeting}}</p>
+    <p>{{.Message}}</p>
+    <p style="margin-top:32px;color:#888;font-size:12px;">{{.Signature}}</p>
+  </div>
+</body>
+</html>`))
+
+// notificationTemplateKeys тексты SMS и темы писем отдельных типов в каталоге pkg/i18n.
+// Для остальных типов используются notification.sms.default и notification.email.subject.default.
+var notificationTemplateKeys = map[domain.NotificationType]struct {
+	sms          string
+	emailSubject string
+}{
+	domain.NotificationPasswordReady: {
+		sms:          "notification.sms.password_ready",
+		emailSubject: "notification.email.subject.password_ready",
+	},
+	domain.NotificationLockIssue: {
+		sms:          "notification.sms.lock_issue",
+		emailSubject: "notification.email.subject.lock_issue",
+	},
+	domain.NotificationBookingApproved: {
+		sms:          "notification.sms.booking_approved",
+		emailSubject: "notification.email.subject.booking_approved",
+	},
+	domain.NotificationPaymentRequired: {
+		sms: "notification.sms.payment_required",
+	},
+}
+
+func notificationTemplateKey(key, fallback string) string {
+	if key == "" {
+		return fallback
+	}
+	return key
+}
+
+// renderNotificationDelivery готовит уведомление для канала на языке пользователя.
+func renderNotificationDelivery(channel domain.NotificationChannel, message *domain.PushMessage, user *domain.User) (*domain.NotificationDelivery, error) {
+	lang := i18n.Normalize(user.PreferredLanguage)
+	keys := notificationTemplateKeys[message.NotificationType]
+	params := i18n.Params{
+		"title":   message.Title,
+		"message": strings.TrimSuffix(strings.TrimSpace(message.Body), "."),
+	}
+
+	delivery := &domain.NotificationDelivery{
+		UserID:           user.ID,
+		NotificationType: message.NotificationType,
+	}
+
+	switch channel {
+	case domain.NotificationChannelSMS:
+		delivery.Recipient = user.Phone
+		delivery.Body = i18n.T(lang, notificationTemplateKey(keys.sms, "notification.sms.default"), params)
+
+	case domain.NotificationChannelEmail:
+		greeting := i18n.T(lang, "notification.email.greeting_anonymous", nil)
+		if user.FirstName != "" {
+			greeting = i18n.T(lang, "notification.email.greeting", i18n.Params{"name": user.FirstName})
+		}
+
+		data := notificationEmailData{
+			Lang:      string(lang),
+			Title:     message.Title,
+			Greeting:  greeting,
+			Message:   strings.TrimSpace(message.Body),
+			Signature: i18n.T(lang, "notification.email.signature", nil),
+		}
+
+		delivery.Recipient = user.Email
+		delivery.Subject = i18n.T(lang, notificationTemplateKey(keys.emailSubject, "notification.email.subject.default"), params)
+		delivery.Body = i18n.T(lang, "notification.email.text", i18n.Params{
+			"greeting":  data.Greeting,
+			"message":   data.Message,
+			"signature": data.Signature,
+		})
+
+		var html bytes.Buffer
+		if err := emailHTMLTemplate.Execute(&html, data); err != nil {
+			return nil, fmt.Errorf("ошибка HTML-шаблона письма: %w", err)
+		}
+		delivery.HTMLBody = html.String()
+
+	default:
+		return nil, fmt.Errorf("канал %s не поддерживает шаблоны", channel)
+	}
+
+	if delivery.Recipient == "" {
+		return nil, fmt.Errorf("у пользователя %d не указан получатель для канала %s", user.ID, channel)
+	}
+
+	return delivery, nil
+}
+
+// sendToChannel отправляет уведомление через драйвер внешнего канала.
+func (uc *notificationUseCase) sendToChannel(channel domain.NotificationChannel, message *domain.PushMessage) error {
+	sender, ok := uc.senders[channel]
+	if !ok {
+		return fmt.Errorf("канал %s не настроен", channel)
+	}
+
+	user, err := uc.userRepo.GetByID(message.UserID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения пользователя %d: %w", message.UserID, err)
+	}
+	if user == nil {
+		return fmt.Errorf("пользователь %d не найден", message.UserID)
+	}
+
+	delivery, err := renderNotificationDelivery(channel, message, user)
+	if err != nil {
+		return err
+	}
+
+	return sender.Send(delivery)
+}
+
+// sendPush отправляет push, если у пользователя есть активные устройства.
+func (uc *notificationUseCase) sendPush(message *domain.PushMessage) (bool, error) {
+	devices, err := uc.notificationRepo.GetDevicesByUserID(message.UserID)
+	if err != nil {
+		return false, fmt.Errorf("ошибка получения устройств пользователя %d: %w", message.UserID, err)
+	}
+	if len(devices) == 0 {
+		return false, nil
+	}
+
+	if err := uc.pushService.SendPush(message.UserID, message); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// sendFallback пробует резервные каналы типа уведомления, пропуская уже использованные.
+func (uc *notificationUseCase) sendFallback(message *domain.PushMessage, tried map[domain.NotificationChannel]bool) bool {
+	for _, channel := range notificationFallbacks[message.NotificationType] {
+		if tried[channel] {
+			continue
+		}
+
+		if err := uc.sendToChannel(channel, message); err != nil {
+			log.Printf("⚠️ Резервный канал %s для %s пользователя %d: %v", channel, message.NotificationType, message.UserID, err)
+			continue
+		}
+
+		log.Printf("📨 Уведомление %s доставлено пользователю %d через резервный канал %s", message.NotificationType, message.UserID, channel)
+		return true
+	}
+
+	return false
+}
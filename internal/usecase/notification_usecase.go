@@ -3,53 +3,55 @@ package usecase
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/i18n"
 )
 
 type notificationUseCase struct {
 	notificationRepo domain.NotificationRepository
 	pushService      domain.PushNotificationService
 	queueService     domain.MessageQueueService
+	userRepo         domain.UserRepository
+	senders          map[domain.NotificationChannel]domain.NotificationSender
 }
 
 func NewNotificationUseCase(
 	notificationRepo domain.NotificationRepository,
 	pushService domain.PushNotificationService,
 	queueService domain.MessageQueueService,
+	userRepo domain.UserRepository,
+	senders []domain.NotificationSender,
 ) domain.NotificationUseCase {
+	senderByChannel := make(map[domain.NotificationChannel]domain.NotificationSender, len(senders))
+	for _, sender := range senders {
+		senderByChannel[sender.Channel()] = sender
+	}
+
 	return &notificationUseCase{
 		notificationRepo: notificationRepo,
 		pushService:      pushService,
 		queueService:     queueService,
+		userRepo:         userRepo,
+		senders:          senderByChannel,
 	}
 }
 
 func (uc *notificationUseCase) CreateNotification(notification *domain.Notification) error {
-	err := uc.notificationRepo.CreateNotification(notification)
-	if err != nil {
-		return fmt.Errorf("ошибка создания уведомления: %w", err)
-	}
-
-	pushMessage := &domain.PushMessage{
-		UserID:           notification.UserID,
-		Title:            notification.Title,
-		Body:             notification.Message,
-		NotificationType: notification.Type,
-		Priority:         notification.Priority,
-		Data: map[string]interface{}{
-			"notification_id": notification.ID,
-			"type":            string(notification.Type),
-		},
+	pushMessage, err := uc.prepareNotification(notification)
+	if err != nil || pushMessage == nil {
+		return err
 	}
 
 	err = uc.queueService.PublishNotification(pushMessage)
 	if err != nil {
 		log.Printf("❌ Ошибка добавления в очередь: %v", err)
-		err = uc.pushService.SendPush(notification.UserID, pushMessage)
+		err = uc.deliver(pushMessage)
 		if err != nil {
-			log.Printf("❌ Ошибка прямой отправки push: %v", err)
+			log.Printf("❌ Ошибка прямой отправки уведомления: %v", err)
 		}
 	}
 
@@ -57,9 +59,41 @@ func (uc *notificationUseCase) CreateNotification(notification *domain.Notificat
 }
 
 func (uc *notificationUseCase) CreateDelayedNotification(notification *domain.Notification, delay time.Duration) error {
-	err := uc.notificationRepo.CreateNotification(notification)
-	if err != nil {
-		return fmt.Errorf("ошибка создания отложенного уведомления: %w", err)
+	pushMessage, err := uc.prepareNotification(notification)
+	if err != nil || pushMessage == nil {
+		return err
+	}
+
+	return uc.queueService.PublishDelayedNotification(pushMessage, delay)
+}
+
+// prepareNotification применяет настройки пользователя: сохраняет уведомление в ленту, если включен
+// канал in_app, и возвращает сообщение для очереди, если включен хотя бы один внешний канал
+// (push, SMS, email). Срочные уведомления проходят всегда. Текст для ленты формируется на языке пользователя.
+func (uc *notificationUseCase) prepareNotification(notification *domain.Notification) (*domain.PushMessage, error) {
+	urgent := notification.Priority == domain.NotificationPriorityUrgent
+	preferences := uc.loadPreferences(notification.UserID)
+
+	if !urgent && preferences.IsMuted(notification.Type) {
+		log.Printf("🔕 Уведомление %s отключено пользователем %d", notification.Type, notification.UserID)
+		return nil, nil
+	}
+
+	if notification.TitleKey != "" {
+		lang := uc.userLanguage(notification.UserID)
+		notification.Title = i18n.T(lang, notification.TitleKey, notification.Params)
+		notification.Message = i18n.T(lang, notification.MessageKey, notification.Params)
+	}
+
+	if urgent || preferences.HasChannel(domain.NotificationChannelInApp) {
+		err := uc.notificationRepo.CreateNotification(notification)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка создания уведомления: %w", err)
+		}
+	}
+
+	if !urgent && !hasAnyChannel(preferences, externalNotificationChannels) {
+		return nil, nil
 	}
 
 	pushMessage := &domain.PushMessage{
@@ -68,13 +102,207 @@ func (uc *notificationUseCase) CreateDelayedNotification(notification *domain.No
 		Body:             notification.Message,
 		NotificationType: notification.Type,
 		Priority:         notification.Priority,
+		TitleKey:         notification.TitleKey,
+		BodyKey:          notification.MessageKey,
+		Params:           notification.Params,
 		Data: map[string]interface{}{
-			"notification_id": notification.ID,
-			"type":            string(notification.Type),
+			"type": string(notification.Type),
 		},
 	}
+	if notification.ID > 0 {
+		pushMessage.Data["notification_id"] = notification.ID
+	}
 
-	return uc.queueService.PublishDelayedNotification(pushMessage, delay)
+	return pushMessage, nil
+}
+
+// deliver доставляет сообщение из очереди по каналам с учетом текущих настроек пользователя: настройки
+// могли измениться, пока сообщение ждало в очереди. В тихие часы несрочные уведомления откладываются
+// до их окончания. Если push недоступен и выбранные каналы не сработали, используются резервные каналы типа.
+// Текст переводится на язык, выбранный пользователем на момент отправки.
+func (uc *notificationUseCase) deliver(message *domain.PushMessage) error {
+	urgent := message.Priority == domain.NotificationPriorityUrgent
+	preferences := uc.loadPreferences(message.UserID)
+
+	if !urgent {
+		if preferences.IsMuted(message.NotificationType) {
+			log.Printf("🔕 Уведомление %s пропущено по настройкам пользователя %d", message.NotificationType, message.UserID)
+			return nil
+		}
+
+		if delay := preferences.QuietHoursDelay(time.Now()); delay > 0 {
+			log.Printf("🌙 Тихие часы пользователя %d: уведомление %s отложено на %v", message.UserID, message.NotificationType, delay.Round(time.Minute))
+			return uc.queueService.PublishDelayedNotification(message, delay)
+		}
+	}
+
+	uc.localizeMessage(message)
+
+	tried := make(map[domain.NotificationChannel]bool)
+	delivered := false
+
+	var pushErr error
+	pushEnabled := urgent || preferences.HasChannel(domain.NotificationChannelPush)
+	if pushEnabled {
+		tried[domain.NotificationChannelPush] = true
+		delivered, pushErr = uc.sendPush(message)
+		if pushErr != nil {
+			log.Printf("⚠️ Ошибка отправки push пользователю %d: %v", message.UserID, pushErr)
+		}
+	}
+
+	for _, channel := range []domain.NotificationChannel{domain.NotificationChannelSMS, domain.NotificationChannelEmail} {
+		if !preferences.HasChannel(channel) {
+			continue
+		}
+
+		tried[channel] = true
+		if err := uc.sendToChannel(channel, message); err != nil {
+			log.Printf("⚠️ Ошибка отправки %s пользователю %d: %v", channel, message.UserID, err)
+			continue
+		}
+		delivered = true
+	}
+
+	if delivered || !pushEnabled {
+		return nil
+	}
+
+	if uc.sendFallback(message, tried) {
+		return nil
+	}
+
+	if pushErr != nil {
+		return pushErr
+	}
+
+	log.Printf("📭 Уведомление %s не доставлено пользователю %d: нет доступных каналов", message.NotificationType, message.UserID)
+	return nil
+}
+
+func hasAnyChannel(preferences *domain.NotificationPreferences, channels []domain.NotificationChannel) bool {
+	for _, channel := range channels {
+		if preferences.HasChannel(channel) {
+			return true
+		}
+	}
+	return false
+}
+
+// userLanguage возвращает язык уведомлений пользователя. Если пользователя не удалось загрузить,
+// используется язык по умолчанию.
+func (uc *notificationUseCase) userLanguage(userID int) i18n.Language {
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil || user == nil {
+		return i18n.Default
+	}
+	return i18n.Normalize(user.PreferredLanguage)
+}
+
+func (uc *notificationUseCase) localizeMessage(message *domain.PushMessage) {
+	if message.TitleKey == "" {
+		return
+	}
+
+	lang := uc.userLanguage(message.UserID)
+	message.Title = i18n.T(lang, message.TitleKey, message.Params)
+	message.Body = i18n.T(lang, message.BodyKey, message.Params)
+}
+
+func (uc *notificationUseCase) loadPreferences(userID int) *domain.NotificationPreferences {
+	preferences, err := uc.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		log.Printf("⚠️ Ошибка получения настроек уведомлений пользователя %d: %v", userID, err)
+	}
+	if preferences == nil {
+		return domain.DefaultNotificationPreferences(userID)
+	}
+	return preferences
+}
+
+func (uc *notificationUseCase) GetPreferences(userID int) (*domain.NotificationPreferences, error) {
+	preferences, err := uc.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения настроек уведомлений: %w", err)
+	}
+	if preferences == nil {
+		return domain.DefaultNotificationPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+func (uc *notificationUseCase) UpdatePreferences(userID int, request *domain.UpdateNotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	preferences, err := uc.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.MutedTypes != nil {
+		mutedTypes := make([]domain.NotificationType, 0, len(*request.MutedTypes))
+		seen := make(map[domain.NotificationType]bool)
+		for _, mutedType := range *request.MutedTypes {
+			if !domain.IsValidNotificationType(mutedType) {
+				return nil, fmt.Errorf("неизвестный тип уведомления: %s", mutedType)
+			}
+			if !seen[mutedType] {
+				seen[mutedType] = true
+				mutedTypes = append(mutedTypes, mutedType)
+			}
+		}
+		preferences.MutedTypes = mutedTypes
+	}
+
+	if request.Channels != nil {
+		channels := make([]domain.NotificationChannel, 0, len(*request.Channels))
+		seen := make(map[domain.NotificationChannel]bool)
+		for _, channel := range *request.Channels {
+			if !domain.IsValidNotificationChannel(channel) {
+				return nil, fmt.Errorf("неизвестный канал уведомлений: %s", channel)
+			}
+			if !seen[channel] {
+				seen[channel] = true
+				channels = append(channels, channel)
+			}
+		}
+		preferences.Channels = channels
+	}
+
+	if request.QuietHoursEnabled != nil {
+		preferences.QuietHoursEnabled = *request.QuietHoursEnabled
+	}
+
+	if request.QuietHoursStart != nil {
+		minutes, err := domain.ParseClockMinutes(*request.QuietHoursStart)
+		if err != nil {
+			return nil, fmt.Errorf("начало тихих часов: %w", err)
+		}
+		preferences.QuietHoursStart = fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	}
+
+	if request.QuietHoursEnd != nil {
+		minutes, err := domain.ParseClockMinutes(*request.QuietHoursEnd)
+		if err != nil {
+			return nil, fmt.Errorf("окончание тихих часов: %w", err)
+		}
+		preferences.QuietHoursEnd = fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+	}
+
+	if preferences.QuietHoursEnabled && preferences.QuietHoursStart == preferences.QuietHoursEnd {
+		return nil, fmt.Errorf("начало и окончание тихих часов не должны совпадать")
+	}
+
+	if request.Timezone != nil {
+		if _, err := time.LoadLocation(*request.Timezone); err != nil || *request.Timezone == "" {
+			return nil, fmt.Errorf("неизвестный часовой пояс: %s", *request.Timezone)
+		}
+		preferences.Timezone = *request.Timezone
+	}
+
+	if err := uc.notificationRepo.UpsertPreferences(preferences); err != nil {
+		return nil, fmt.Errorf("ошибка сохранения настроек уведомлений: %w", err)
+	}
+
+	return preferences, nil
 }
 
 func (uc *notificationUseCase) GetUserNotifications(userID int, limit, offset int) ([]*domain.Notification, error) {
@@ -119,13 +347,14 @@ func (uc *notificationUseCase) GetDevicesByUserID(userID int) ([]*domain.UserDev
 
 func (uc *notificationUseCase) NotifyBookingApproved(userID int, bookingID int, apartmentTitle string) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationBookingApproved,
-		Title:     "Бронирование одобрено!",
-		Message:   fmt.Sprintf("Ваше бронирование квартиры '%s' было одобрено владельцем", apartmentTitle),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationBookingApproved,
+		TitleKey:   "notification.booking_approved.title",
+		MessageKey: "notification.booking_approved.message",
+		Params:     map[string]string{"apartment": apartmentTitle},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -135,19 +364,20 @@ func (uc *notificationUseCase) NotifyBookingApproved(userID int, bookingID int,
 }
 
 func (uc *notificationUseCase) NotifyBookingRejected(userID int, bookingID int, apartmentTitle, reason string) error {
-	message := fmt.Sprintf("Ваше бронирование квартиры '%s' было отклонено", apartmentTitle)
+	messageKey := "notification.booking_rejected.message"
 	if reason != "" {
-		message += fmt.Sprintf(". Причина: %s", reason)
+		messageKey = "notification.booking_rejected.message_reason"
 	}
 
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationBookingRejected,
-		Title:     "Бронирование отклонено",
-		Message:   message,
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationBookingRejected,
+		TitleKey:   "notification.booking_rejected.title",
+		MessageKey: messageKey,
+		Params:     map[string]string{"apartment": apartmentTitle, "reason": reason},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -158,13 +388,14 @@ func (uc *notificationUseCase) NotifyBookingRejected(userID int, bookingID int,
 
 func (uc *notificationUseCase) NotifyPasswordReady(userID int, bookingID int, apartmentTitle string) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationPasswordReady,
-		Title:     "Пароль для замка готов!",
-		Message:   fmt.Sprintf("Временный пароль для квартиры '%s' создан и готов к использованию", apartmentTitle),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationPasswordReady,
+		TitleKey:   "notification.password_ready.title",
+		MessageKey: "notification.password_ready.message",
+		Params:     map[string]string{"apartment": apartmentTitle},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -174,23 +405,25 @@ func (uc *notificationUseCase) NotifyPasswordReady(userID int, bookingID int, ap
 }
 
 func (uc *notificationUseCase) NotifyBookingStartingSoon(userID int, bookingID int, apartmentTitle string, startsIn time.Duration) error {
-	timeStr := "скоро"
+	messageKey := "notification.booking_starting_soon.message_minutes"
+	count := int(startsIn.Minutes())
 	if startsIn.Hours() >= 24 {
-		timeStr = fmt.Sprintf("через %d дней", int(startsIn.Hours()/24))
+		messageKey = "notification.booking_starting_soon.message_days"
+		count = int(startsIn.Hours() / 24)
 	} else if startsIn.Hours() >= 1 {
-		timeStr = fmt.Sprintf("через %d часов", int(startsIn.Hours()))
-	} else {
-		timeStr = fmt.Sprintf("через %d минут", int(startsIn.Minutes()))
+		messageKey = "notification.booking_starting_soon.message_hours"
+		count = int(startsIn.Hours())
 	}
 
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationBookingStartingSoon,
-		Title:     "Бронирование начинается скоро!",
-		Message:   fmt.Sprintf("Ваше бронирование квартиры '%s' начинается %s", apartmentTitle, timeStr),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationBookingStartingSoon,
+		TitleKey:   "notification.booking_starting_soon.title",
+		MessageKey: messageKey,
+		Params:     map[string]string{"apartment": apartmentTitle, "count": strconv.Itoa(count)},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -206,13 +439,14 @@ func (uc *notificationUseCase) NotifyBookingStartingSoon(userID int, bookingID i
 
 func (uc *notificationUseCase) NotifyBookingEnding(userID int, bookingID int, apartmentTitle string) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationBookingEnding,
-		Title:     "Бронирование заканчивается",
-		Message:   fmt.Sprintf("Ваше бронирование квартиры '%s' заканчивается через час", apartmentTitle),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationBookingEnding,
+		TitleKey:   "notification.booking_ending.title",
+		MessageKey: "notification.booking_ending.message",
+		Params:     map[string]string{"apartment": apartmentTitle},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -223,13 +457,14 @@ func (uc *notificationUseCase) NotifyBookingEnding(userID int, bookingID int, ap
 
 func (uc *notificationUseCase) NotifyLockIssue(userID int, bookingID int, apartmentTitle string, issue string) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationLockIssue,
-		Title:     "⚠️ Проблема с замком",
-		Message:   fmt.Sprintf("Обнаружена проблема с замком в квартире '%s': %s", apartmentTitle, issue),
-		Priority:  domain.NotificationPriorityUrgent,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationLockIssue,
+		TitleKey:   "notification.lock_issue.title",
+		MessageKey: "notification.lock_issue.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "issue": issue},
+		Priority:   domain.NotificationPriorityUrgent,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"issue":      issue,
@@ -241,13 +476,14 @@ func (uc *notificationUseCase) NotifyLockIssue(userID int, bookingID int, apartm
 
 func (uc *notificationUseCase) NotifyPaymentRequired(userID int, bookingID int, apartmentTitle string, amount float64) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationPaymentRequired,
-		Title:     "Требуется оплата",
-		Message:   fmt.Sprintf("Для завершения бронирования квартиры '%s' необходимо оплатить %.0f тенге", apartmentTitle, amount),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationPaymentRequired,
+		TitleKey:   "notification.payment_required.title",
+		MessageKey: "notification.payment_required.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "amount": fmt.Sprintf("%.0f", amount)},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"amount":     amount,
@@ -259,13 +495,14 @@ func (uc *notificationUseCase) NotifyPaymentRequired(userID int, bookingID int,
 
 func (uc *notificationUseCase) NotifySessionFinished(ownerUserID int, bookingID int, apartmentTitle string, renterName string) error {
 	notification := &domain.Notification{
-		UserID:    ownerUserID,
-		Type:      domain.NotificationSessionFinished,
-		Title:     "Сеанс завершен досрочно",
-		Message:   fmt.Sprintf("Арендатор %s завершил сеанс в квартире '%s' раньше запланированного времени", renterName, apartmentTitle),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     ownerUserID,
+		Type:       domain.NotificationSessionFinished,
+		TitleKey:   "notification.session_finished.title",
+		MessageKey: "notification.session_finished.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "renter": renterName},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -276,13 +513,14 @@ func (uc *notificationUseCase) NotifySessionFinished(ownerUserID int, bookingID
 
 func (uc *notificationUseCase) NotifyExtensionRequested(ownerUserID int, bookingID int, apartmentTitle string, renterName string, duration int) error {
 	notification := &domain.Notification{
-		UserID:    ownerUserID,
-		Type:      domain.NotificationExtensionRequest,
-		Title:     "Запрос на продление",
-		Message:   fmt.Sprintf("Арендатор %s запросил продление бронирования квартиры '%s' на %d часов", renterName, apartmentTitle, duration),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     ownerUserID,
+		Type:       domain.NotificationExtensionRequest,
+		TitleKey:   "notification.extension_requested.title",
+		MessageKey: "notification.extension_requested.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "renter": renterName, "hours": strconv.Itoa(duration)},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"duration":   duration,
@@ -294,13 +532,14 @@ func (uc *notificationUseCase) NotifyExtensionRequested(ownerUserID int, booking
 
 func (uc *notificationUseCase) NotifyExtensionApproved(renterUserID int, bookingID int, apartmentTitle string, duration int) error {
 	notification := &domain.Notification{
-		UserID:    renterUserID,
-		Type:      domain.NotificationExtensionApproved,
-		Title:     "Продление одобрено",
-		Message:   fmt.Sprintf("Ваш запрос на продление бронирования квартиры '%s' на %d часов был одобрен владельцем", apartmentTitle, duration),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     renterUserID,
+		Type:       domain.NotificationExtensionApproved,
+		TitleKey:   "notification.extension_approved.title",
+		MessageKey: "notification.extension_approved.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "hours": strconv.Itoa(duration)},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"duration":   duration,
@@ -312,13 +551,14 @@ func (uc *notificationUseCase) NotifyExtensionApproved(renterUserID int, booking
 
 func (uc *notificationUseCase) NotifyExtensionRejected(renterUserID int, bookingID int, apartmentTitle string, duration int) error {
 	notification := &domain.Notification{
-		UserID:    renterUserID,
-		Type:      domain.NotificationExtensionRejected,
-		Title:     "Продление отклонено",
-		Message:   fmt.Sprintf("Ваш запрос на продление бронирования квартиры '%s' на %d часов был отклонен владельцем", apartmentTitle, duration),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     renterUserID,
+		Type:       domain.NotificationExtensionRejected,
+		TitleKey:   "notification.extension_rejected.title",
+		MessageKey: "notification.extension_rejected.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "hours": strconv.Itoa(duration)},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"duration":   duration,
@@ -330,13 +570,14 @@ func (uc *notificationUseCase) NotifyExtensionRejected(renterUserID int, booking
 
 func (uc *notificationUseCase) NotifyExtensionTimeoutRefund(renterUserID int, bookingID int, apartmentTitle string, duration int) error {
 	notification := &domain.Notification{
-		UserID:    renterUserID,
-		Type:      domain.NotificationExtensionRejected,
-		Title:     "Возврат за продление",
-		Message:   fmt.Sprintf("Владелец не ответил на запрос продления квартиры '%s' на %d часов. Средства возвращены на ваш счет", apartmentTitle, duration),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     renterUserID,
+		Type:       domain.NotificationExtensionRejected,
+		TitleKey:   "notification.extension_refund.title",
+		MessageKey: "notification.extension_refund.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "hours": strconv.Itoa(duration)},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"duration":   duration,
@@ -349,13 +590,14 @@ func (uc *notificationUseCase) NotifyExtensionTimeoutRefund(renterUserID int, bo
 
 func (uc *notificationUseCase) NotifyNewBookingRequest(ownerUserID int, bookingID int, apartmentTitle string, renterName string) error {
 	notification := &domain.Notification{
-		UserID:    ownerUserID,
-		Type:      domain.NotificationNewBooking,
-		Title:     "Новый запрос на бронирование",
-		Message:   fmt.Sprintf("Пользователь %s хочет забронировать вашу квартиру '%s'", renterName, apartmentTitle),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     ownerUserID,
+		Type:       domain.NotificationNewBooking,
+		TitleKey:   "notification.new_booking.title",
+		MessageKey: "notification.new_booking.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "renter": renterName},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -366,13 +608,14 @@ func (uc *notificationUseCase) NotifyNewBookingRequest(ownerUserID int, bookingI
 
 func (uc *notificationUseCase) NotifyBookingStarted(ownerUserID int, bookingID int, apartmentTitle string, renterName string) error {
 	notification := &domain.Notification{
-		UserID:    ownerUserID,
-		Type:      domain.NotificationBookingStartingSoon,
-		Title:     "Аренда началась",
-		Message:   fmt.Sprintf("Началась аренда вашей квартиры '%s' пользователем %s", apartmentTitle, renterName),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     ownerUserID,
+		Type:       domain.NotificationBookingStartingSoon,
+		TitleKey:   "notification.booking_started.title",
+		MessageKey: "notification.booking_started.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "renter": renterName},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -383,13 +626,14 @@ func (uc *notificationUseCase) NotifyBookingStarted(ownerUserID int, bookingID i
 
 func (uc *notificationUseCase) NotifyRenterBookingStarted(renterUserID int, bookingID int, apartmentTitle string) error {
 	notification := &domain.Notification{
-		UserID:    renterUserID,
-		Type:      domain.NotificationBookingStartingSoon,
-		Title:     "Аренда началась",
-		Message:   fmt.Sprintf("Ваша аренда квартиры '%s' началась. Добро пожаловать!", apartmentTitle),
-		Priority:  domain.NotificationPriorityHigh,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     renterUserID,
+		Type:       domain.NotificationBookingStartingSoon,
+		TitleKey:   "notification.booking_started.title",
+		MessageKey: "notification.renter_booking_started.message",
+		Params:     map[string]string{"apartment": apartmentTitle},
+		Priority:   domain.NotificationPriorityHigh,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -414,7 +658,7 @@ func (uc *notificationUseCase) StartNotificationConsumer() {
 	log.Println("🚀 Запуск notification consumer...")
 
 	handler := func(message *domain.PushMessage) error {
-		return uc.pushService.SendPush(message.UserID, message)
+		return uc.deliver(message)
 	}
 
 	go func() {
@@ -427,13 +671,14 @@ func (uc *notificationUseCase) StartNotificationConsumer() {
 
 func (uc *notificationUseCase) NotifyBookingCanceled(userID int, bookingID int, apartmentTitle string, reason string) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationBookingCanceled,
-		Title:     "Бронирование отменено",
-		Message:   fmt.Sprintf("Ваше бронирование квартиры '%s' было отменено. Причина: %s", apartmentTitle, reason),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationBookingCanceled,
+		TitleKey:   "notification.booking_canceled.title",
+		MessageKey: "notification.booking_canceled.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "reason": reason},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 			"reason":     reason,
@@ -445,13 +690,14 @@ func (uc *notificationUseCase) NotifyBookingCanceled(userID int, bookingID int,
 
 func (uc *notificationUseCase) NotifyBookingCompleted(userID int, bookingID int, apartmentTitle string) error {
 	notification := &domain.Notification{
-		UserID:    userID,
-		Type:      domain.NotificationBookingCompleted,
-		Title:     "Бронирование завершено",
-		Message:   fmt.Sprintf("Ваше бронирование квартиры '%s' успешно завершено. Спасибо за использование нашего сервиса!", apartmentTitle),
-		Priority:  domain.NotificationPriorityNormal,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		Type:       domain.NotificationBookingCompleted,
+		TitleKey:   "notification.booking_completed.title",
+		MessageKey: "notification.booking_completed.message",
+		Params:     map[string]string{"apartment": apartmentTitle},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
 		Data: map[string]interface{}{
 			"booking_id": bookingID,
 		},
@@ -464,8 +710,9 @@ func (uc *notificationUseCase) NotifyApartmentCreated(ownerUserID int, apartment
 	notification := &domain.Notification{
 		UserID:      ownerUserID,
 		Type:        domain.NotificationApartmentCreated,
-		Title:       "Квартира добавлена",
-		Message:     fmt.Sprintf("Ваша квартира '%s' успешно добавлена и отправлена на модерацию", apartmentTitle),
+		TitleKey:    "notification.apartment_created.title",
+		MessageKey:  "notification.apartment_created.message",
+		Params:      map[string]string{"apartment": apartmentTitle},
 		Priority:    domain.NotificationPriorityNormal,
 		IsRead:      false,
 		CreatedAt:   time.Now(),
@@ -482,8 +729,9 @@ func (uc *notificationUseCase) NotifyApartmentApproved(ownerUserID int, apartmen
 	notification := &domain.Notification{
 		UserID:      ownerUserID,
 		Type:        domain.NotificationApartmentApproved,
-		Title:       "Квартира одобрена!",
-		Message:     fmt.Sprintf("Ваша квартира '%s' прошла модерацию и опубликована на платформе", apartmentTitle),
+		TitleKey:    "notification.apartment_approved.title",
+		MessageKey:  "notification.apartment_approved.message",
+		Params:      map[string]string{"apartment": apartmentTitle},
 		Priority:    domain.NotificationPriorityHigh,
 		IsRead:      false,
 		CreatedAt:   time.Now(),
@@ -497,16 +745,17 @@ func (uc *notificationUseCase) NotifyApartmentApproved(ownerUserID int, apartmen
 }
 
 func (uc *notificationUseCase) NotifyApartmentRejected(ownerUserID int, apartmentID int, apartmentTitle string, reason string) error {
-	message := fmt.Sprintf("Ваша квартира '%s' была отклонена модератором", apartmentTitle)
+	messageKey := "notification.apartment_rejected.message"
 	if reason != "" {
-		message += fmt.Sprintf(". Причина: %s", reason)
+		messageKey = "notification.apartment_rejected.message_reason"
 	}
 
 	notification := &domain.Notification{
 		UserID:      ownerUserID,
 		Type:        domain.NotificationApartmentRejected,
-		Title:       "Квартира отклонена",
-		Message:     message,
+		TitleKey:    "notification.apartment_rejected.title",
+		MessageKey:  messageKey,
+		Params:      map[string]string{"apartment": apartmentTitle, "reason": reason},
 		Priority:    domain.NotificationPriorityNormal,
 		IsRead:      false,
 		CreatedAt:   time.Now(),
@@ -524,8 +773,9 @@ func (uc *notificationUseCase) NotifyApartmentUpdated(ownerUserID int, apartment
 	notification := &domain.Notification{
 		UserID:      ownerUserID,
 		Type:        domain.NotificationApartmentUpdated,
-		Title:       "Квартира обновлена",
-		Message:     fmt.Sprintf("Информация о квартире '%s' обновлена и отправлена на повторную модерацию", apartmentTitle),
+		TitleKey:    "notification.apartment_updated.title",
+		MessageKey:  "notification.apartment_updated.message",
+		Params:      map[string]string{"apartment": apartmentTitle},
 		Priority:    domain.NotificationPriorityNormal,
 		IsRead:      false,
 		CreatedAt:   time.Now(),
@@ -539,38 +789,43 @@ func (uc *notificationUseCase) NotifyApartmentUpdated(ownerUserID int, apartment
 }
 
 func (uc *notificationUseCase) NotifyApartmentStatusChanged(ownerUserID int, apartmentID int, apartmentTitle string, oldStatus, newStatus string) error {
-	var title string
-	var message string
+	var titleKey string
+	var messageKey string
 	var priority domain.NotificationPriority
 
 	switch newStatus {
 	case "approved":
-		title = "Квартира одобрена!"
-		message = fmt.Sprintf("Ваша квартира '%s' одобрена и опубликована", apartmentTitle)
+		titleKey = "notification.apartment_approved.title"
+		messageKey = "notification.apartment_published.message"
 		priority = domain.NotificationPriorityHigh
 	case "rejected":
-		title = "Квартира отклонена"
-		message = fmt.Sprintf("Ваша квартира '%s' была отклонена модератором", apartmentTitle)
+		titleKey = "notification.apartment_rejected.title"
+		messageKey = "notification.apartment_rejected.message"
 		priority = domain.NotificationPriorityNormal
 	case "blocked":
-		title = "Квартира заблокирована"
-		message = fmt.Sprintf("Ваша квартира '%s' была заблокирована администратором", apartmentTitle)
+		titleKey = "notification.apartment_blocked.title"
+		messageKey = "notification.apartment_blocked.message"
 		priority = domain.NotificationPriorityHigh
 	case "inactive":
-		title = "Квартира деактивирована"
-		message = fmt.Sprintf("Ваша квартира '%s' была деактивирована", apartmentTitle)
+		titleKey = "notification.apartment_deactivated.title"
+		messageKey = "notification.apartment_deactivated.message"
 		priority = domain.NotificationPriorityNormal
 	default:
-		title = "Статус квартиры изменен"
-		message = fmt.Sprintf("Статус вашей квартиры '%s' изменен с '%s' на '%s'", apartmentTitle, oldStatus, newStatus)
+		titleKey = "notification.apartment_status_changed.title"
+		messageKey = "notification.apartment_status_changed.message"
 		priority = domain.NotificationPriorityNormal
 	}
 
 	notification := &domain.Notification{
-		UserID:      ownerUserID,
-		Type:        domain.NotificationApartmentStatusChanged,
-		Title:       title,
-		Message:     message,
+		UserID:     ownerUserID,
+		Type:       domain.NotificationApartmentStatusChanged,
+		TitleKey:   titleKey,
+		MessageKey: messageKey,
+		Params: map[string]string{
+			"apartment":  apartmentTitle,
+			"old_status": oldStatus,
+			"new_status": newStatus,
+		},
 		Priority:    priority,
 		IsRead:      false,
 		CreatedAt:   time.Now(),
@@ -584,3 +839,171 @@ func (uc *notificationUseCase) NotifyApartmentStatusChanged(ownerUserID int, apa
 
 	return uc.CreateNotification(notification)
 }
+
+func (uc *notificationUseCase) NotifyApartmentReviewRequested(renterUserID int, bookingID int, apartmentTitle string, deadline time.Time) error {
+	notification := &domain.Notification{
+		UserID:     renterUserID,
+		Type:       domain.NotificationReviewRequest,
+		TitleKey:   "notification.apartment_review_requested.title",
+		MessageKey: "notification.apartment_review_requested.message",
+		Params:     map[string]string{"apartment": apartmentTitle, "deadline": utils.ConvertOutputFromUTC(deadline).Format("02.01.2006")},
+		Priority:   domain.NotificationPriorityLow,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
+		BookingID:  &bookingID,
+		Data: map[string]interface{}{
+			"booking_id":  bookingID,
+			"review_type": "apartment",
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyRenterReviewRequested(ownerUserID int, bookingID int, renterName string, deadline time.Time) error {
+	notification := &domain.Notification{
+		UserID:     ownerUserID,
+		Type:       domain.NotificationReviewRequest,
+		TitleKey:   "notification.renter_review_requested.title",
+		MessageKey: "notification.renter_review_requested.message",
+		Params:     map[string]string{"renter": renterName, "deadline": utils.ConvertOutputFromUTC(deadline).Format("02.01.2006")},
+		Priority:   domain.NotificationPriorityLow,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
+		BookingID:  &bookingID,
+		Data: map[string]interface{}{
+			"booking_id":  bookingID,
+			"review_type": "renter",
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyReviewPublished(userID int, apartmentID int, title string, rating float64) error {
+	notification := &domain.Notification{
+		UserID:      userID,
+		Type:        domain.NotificationReviewPublished,
+		TitleKey:    "notification.review_published.title",
+		MessageKey:  "notification.review_published.message",
+		Params:      map[string]string{"apartment": title, "rating": fmt.Sprintf("%.1f", rating)},
+		Priority:    domain.NotificationPriorityNormal,
+		IsRead:      false,
+		CreatedAt:   time.Now(),
+		ApartmentID: &apartmentID,
+		Data: map[string]interface{}{
+			"apartment_id": apartmentID,
+			"rating":       rating,
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyReviewRejected(userID int, bookingID int, reason string) error {
+	messageKey := "notification.review_rejected.message"
+	if reason != "" {
+		messageKey = "notification.review_rejected.message_reason"
+	}
+
+	notification := &domain.Notification{
+		UserID:     userID,
+		Type:       domain.NotificationReviewRejected,
+		TitleKey:   "notification.review_rejected.title",
+		MessageKey: messageKey,
+		Params:     map[string]string{"reason": reason},
+		Priority:   domain.NotificationPriorityNormal,
+		IsRead:     false,
+		CreatedAt:  time.Now(),
+		BookingID:  &bookingID,
+		Data: map[string]interface{}{
+			"booking_id": bookingID,
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyCleaningCompleted(ownerUserID int, apartmentID int, cleaningLogID int, apartmentTitle string, photosCount int) error {
+	notification := &domain.Notification{
+		UserID:      ownerUserID,
+		Type:        domain.NotificationCleaningCompleted,
+		TitleKey:    "notification.cleaning_completed.title",
+		MessageKey:  "notification.cleaning_completed.message",
+		Params:      map[string]string{"apartment": apartmentTitle, "photos": strconv.Itoa(photosCount)},
+		Priority:    domain.NotificationPriorityNormal,
+		IsRead:      false,
+		CreatedAt:   time.Now(),
+		ApartmentID: &apartmentID,
+		Data: map[string]interface{}{
+			"apartment_id":    apartmentID,
+			"cleaning_log_id": cleaningLogID,
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyCleaningTaskOffered(cleanerUserID int, apartmentID int, cleaningTaskID int, apartmentTitle string, deadline time.Time, timeoutMinutes int) error {
+	notification := &domain.Notification{
+		UserID:     cleanerUserID,
+		Type:       domain.NotificationCleaningTaskOffered,
+		TitleKey:   "notification.cleaning_task_offered.title",
+		MessageKey: "notification.cleaning_task_offered.message",
+		Params: map[string]string{
+			"apartment": apartmentTitle,
+			"deadline":  utils.ConvertOutputFromUTC(deadline).Format("02.01.2006 15:04"),
+			"minutes":   strconv.Itoa(timeoutMinutes),
+		},
+		Priority:    domain.NotificationPriorityUrgent,
+		IsRead:      false,
+		CreatedAt:   time.Now(),
+		ApartmentID: &apartmentID,
+		Data: map[string]interface{}{
+			"apartment_id":     apartmentID,
+			"cleaning_task_id": cleaningTaskID,
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyCleaningTaskEscalated(adminUserID int, apartmentID int, cleaningTaskID int, apartmentTitle string, deadline time.Time) error {
+	notification := &domain.Notification{
+		UserID:      adminUserID,
+		Type:        domain.NotificationCleaningTaskEscalated,
+		TitleKey:    "notification.cleaning_task_escalated.title",
+		MessageKey:  "notification.cleaning_task_escalated.message",
+		Params:      map[string]string{"apartment": apartmentTitle, "deadline": utils.ConvertOutputFromUTC(deadline).Format("02.01.2006 15:04")},
+		Priority:    domain.NotificationPriorityHigh,
+		IsRead:      false,
+		CreatedAt:   time.Now(),
+		ApartmentID: &apartmentID,
+		Data: map[string]interface{}{
+			"apartment_id":     apartmentID,
+			"cleaning_task_id": cleaningTaskID,
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
+
+func (uc *notificationUseCase) NotifyCleaningLate(userID int, apartmentID int, cleaningTaskID int, apartmentTitle string, deadline time.Time) error {
+	notification := &domain.Notification{
+		UserID:      userID,
+		Type:        domain.NotificationCleaningLate,
+		TitleKey:    "notification.cleaning_late.title",
+		MessageKey:  "notification.cleaning_late.message",
+		Params:      map[string]string{"apartment": apartmentTitle, "deadline": utils.ConvertOutputFromUTC(deadline).Format("02.01.2006 15:04")},
+		Priority:    domain.NotificationPriorityHigh,
+		IsRead:      false,
+		CreatedAt:   time.Now(),
+		ApartmentID: &apartmentID,
+		Data: map[string]interface{}{
+			"apartment_id":     apartmentID,
+			"cleaning_task_id": cleaningTaskID,
+		},
+	}
+
+	return uc.CreateNotification(notification)
+}
@@ -5,27 +5,26 @@ import (
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
-	"github.com/russo2642/renti_kz/pkg/auth"
 )
 
 type OTPUseCase struct {
-	otpService   domain.OTPService
-	otpRepo      domain.OTPRepository
-	userRepo     domain.UserRepository
-	tokenManager auth.TokenManager
+	otpService  domain.OTPService
+	otpRepo     domain.OTPRepository
+	userRepo    domain.UserRepository
+	authUseCase domain.AuthUseCase
 }
 
 func NewOTPUseCase(
 	otpService domain.OTPService,
 	otpRepo domain.OTPRepository,
 	userRepo domain.UserRepository,
-	tokenManager auth.TokenManager,
+	authUseCase domain.AuthUseCase,
 ) *OTPUseCase {
 	return &OTPUseCase{
-		otpService:   otpService,
-		otpRepo:      otpRepo,
-		userRepo:     userRepo,
-		tokenManager: tokenManager,
+		otpService:  otpService,
+		otpRepo:     otpRepo,
+		userRepo:    userRepo,
+		authUseCase: authUseCase,
 	}
 }
 
@@ -103,7 +102,7 @@ func (uc *OTPUseCase) VerifyOTP(phone, code string) (bool, error) {
 	return true, nil
 }
 
-func (uc *OTPUseCase) VerifyOTPAndAuthenticate(id, phone, code string) (*domain.OTPAuthResponse, error) {
+func (uc *OTPUseCase) VerifyOTPAndAuthenticate(id, phone, code string, client domain.SessionClient) (*domain.OTPAuthResponse, error) {
 	session, err := uc.otpRepo.GetSessionByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка получения OTP сессии: %w", err)
@@ -177,7 +176,7 @@ func (uc *OTPUseCase) VerifyOTPAndAuthenticate(id, phone, code string) (*domain.
 		}, nil
 	}
 
-	accessToken, refreshToken, err := uc.tokenManager.GenerateTokenPair(user.ID, string(user.Role))
+	tokens, err := uc.authUseCase.IssueTokens(user, client)
 	if err != nil {
 		return nil, fmt.Errorf("ошибка генерации токенов: %w", err)
 	}
@@ -186,8 +185,8 @@ func (uc *OTPUseCase) VerifyOTPAndAuthenticate(id, phone, code string) (*domain.
 
 	return &domain.OTPAuthResponse{
 		RequiresRegistration: false,
-		AccessToken:          &accessToken,
-		RefreshToken:         &refreshToken,
+		AccessToken:          &tokens.AccessToken,
+		RefreshToken:         &tokens.RefreshToken,
 		User:                 user,
 		Message:              "Аутентификация успешна",
 	}, nil
@@ -1,12 +1,14 @@
 package usecase
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"time"
 
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/russo2642/renti_kz/internal/domain"
 	"github.com/russo2642/renti_kz/pkg/logger"
 )
@@ -29,6 +31,102 @@ func NewPaymentUseCase(
 	}
 }
 
+func (uc *paymentUseCase) InitPayment(request *domain.InitPaymentRequest) (*domain.Payment, error) {
+	startTime := time.Now()
+
+	fpResponse, err := uc.freedomPayService.InitPayment(&domain.FreedomPayInitPaymentRequest{
+		OrderID:     request.OrderID,
+		Amount:      request.Amount,
+		Description: request.Description,
+		UserPhone:   request.UserPhone,
+		UserEmail:   request.UserEmail,
+	})
+	processingDuration := int(time.Since(startTime).Milliseconds())
+
+	logEntry := &domain.PaymentLog{
+		BookingID:          request.BookingID,
+		FPPaymentID:        request.OrderID,
+		Action:             domain.PaymentLogActionCreatePayment,
+		ProcessingDuration: &processingDuration,
+		UserID:             request.UserID,
+		Source:             domain.PaymentLogSourceAPI,
+		Success:            err == nil && fpResponse != nil && fpResponse.Status == "ok",
+	}
+
+	if err != nil {
+		errorMessage := err.Error()
+		logEntry.ErrorMessage = &errorMessage
+		logger.Error("failed to init payment in FreedomPay",
+			slog.String("order_id", request.OrderID),
+			slog.Int64("booking_id", request.BookingID),
+			slog.String("error", err.Error()),
+			slog.Int("duration_ms", processingDuration))
+	} else if fpResponse.Status != "ok" {
+		errorMessage := fmt.Sprintf("%s - %s", fpResponse.ErrorCode, fpResponse.ErrorDescription)
+		logEntry.ErrorMessage = &errorMessage
+	}
+
+	if err != nil || fpResponse.Status != "ok" {
+		if logErr := uc.paymentLogRepo.Create(logEntry); logErr != nil {
+			logger.Warn("failed to save payment log",
+				slog.String("order_id", request.OrderID),
+				slog.Int64("booking_id", request.BookingID),
+				slog.String("error", logErr.Error()))
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("ошибка инициализации платежа: %w", err)
+		}
+		return nil, fmt.Errorf("FreedomPay отклонил инициализацию платежа: %s", *logEntry.ErrorMessage)
+	}
+
+	orderID := request.OrderID
+	redirectURL := fpResponse.RedirectURL
+	providerStatus := fpResponse.Status
+
+	payment := &domain.Payment{
+		BookingID:      request.BookingID,
+		ExtensionID:    request.ExtensionID,
+		PaymentID:      fpResponse.PaymentID,
+		OrderID:        &orderID,
+		Amount:         request.Amount,
+		Currency:       "KZT",
+		Status:         domain.PaymentStatusPending,
+		ProviderStatus: &providerStatus,
+		RedirectURL:    &redirectURL,
+	}
+
+	if err := uc.paymentRepo.Create(payment); err != nil {
+		logger.Error("failed to save pending payment record",
+			slog.String("order_id", request.OrderID),
+			slog.String("payment_id", fpResponse.PaymentID),
+			slog.Int64("booking_id", request.BookingID),
+			slog.String("error", err.Error()))
+		return nil, fmt.Errorf("ошибка сохранения платежа в БД: %w", err)
+	}
+
+	newStatus := string(domain.PaymentStatusPending)
+	logEntry.PaymentID = &payment.ID
+	logEntry.FPPaymentID = fpResponse.PaymentID
+	logEntry.NewStatus = &newStatus
+
+	if logErr := uc.paymentLogRepo.Create(logEntry); logErr != nil {
+		logger.Warn("failed to save payment log",
+			slog.String("payment_id", fpResponse.PaymentID),
+			slog.Int64("booking_id", request.BookingID),
+			slog.String("error", logErr.Error()))
+	}
+
+	logger.Info("payment initialized",
+		slog.String("order_id", request.OrderID),
+		slog.String("payment_id", fpResponse.PaymentID),
+		slog.Int64("booking_id", request.BookingID),
+		slog.Int("amount", request.Amount),
+		slog.Int("duration_ms", processingDuration))
+
+	return payment, nil
+}
+
 func (uc *paymentUseCase) CheckPaymentStatus(paymentID string) (*domain.PaymentStatusResponse, error) {
 	startTime := time.Now()
 
@@ -278,3 +376,34 @@ func (uc *paymentUseCase) RefundPayment(paymentID string, refundAmount *int) (*d
 		Message:   "Платеж успешно возвращен",
 	}, nil
 }
+
+func (uc *paymentUseCase) VerifyCallbackSignature(scriptName string, params map[string]string) bool {
+	signature, exists := params["pg_sig"]
+	if !exists || signature == "" {
+		return false
+	}
+
+	expected := uc.freedomPayService.GenerateParamsSignature(scriptName, params)
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) == 1
+}
+
+func (uc *paymentUseCase) BuildCallbackResponse(scriptName, status, description string) *domain.FreedomPayCallbackResponse {
+	response := &domain.FreedomPayCallbackResponse{
+		Status:      status,
+		Description: description,
+		Salt:        uuid.New().String(),
+	}
+
+	data := map[string]string{
+		"pg_status": response.Status,
+		"pg_salt":   response.Salt,
+	}
+	if response.Description != "" {
+		data["pg_description"] = response.Description
+	}
+
+	response.Signature = uc.freedomPayService.GenerateParamsSignature(scriptName, data)
+
+	return response
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
 )
@@ -138,6 +139,85 @@ func (u *platformSettingsUseCase) GetMaxAdvanceBookingDays() (int, error) {
 	return value, nil
 }
 
+func (u *platformSettingsUseCase) GetDefaultCancellationPolicy() (domain.CancellationPolicyCode, error) {
+	setting, err := u.settingsRepo.GetByKey(domain.SettingKeyDefaultCancellationPolicy)
+	if err != nil {
+		return domain.CancellationPolicyFlexible, nil
+	}
+
+	code := domain.CancellationPolicyCode(setting.SettingValue)
+	if !code.IsValid() {
+		return domain.CancellationPolicyFlexible, fmt.Errorf("некорректная политика отмены по умолчанию: %s", setting.SettingValue)
+	}
+
+	return code, nil
+}
+
+func (u *platformSettingsUseCase) GetReviewWindowDays() (int, error) {
+	setting, err := u.settingsRepo.GetByKey(domain.SettingKeyReviewWindowDays)
+	if err != nil {
+		return 14, nil
+	}
+
+	value, err := strconv.Atoi(setting.SettingValue)
+	if err != nil || value < 1 {
+		return 14, fmt.Errorf("некорректное значение срока для отзывов: %s", setting.SettingValue)
+	}
+
+	return value, nil
+}
+
+// GetRateLimit возвращает лимит правила из настроек или лимит по умолчанию, если настройка не задана.
+func (u *platformSettingsUseCase) GetRateLimit(policy domain.RateLimitPolicy) (domain.RateLimit, error) {
+	defaultLimit := domain.DefaultRateLimits[policy]
+
+	setting, err := u.settingsRepo.GetByKey(domain.RateLimitSettingKey(policy))
+	if err != nil {
+		return defaultLimit, nil
+	}
+
+	limit, err := domain.ParseRateLimit(setting.SettingValue)
+	if err != nil {
+		return defaultLimit, fmt.Errorf("некорректный лимит %s: %w", setting.SettingKey, err)
+	}
+
+	return limit, nil
+}
+
+func (u *platformSettingsUseCase) GetLockoutPolicy() (domain.LockoutPolicy, error) {
+	policy := domain.LockoutPolicy{
+		Threshold:    u.getPositiveInt(domain.SettingKeyAuthLockoutThreshold, 5),
+		BaseDuration: time.Duration(u.getPositiveInt(domain.SettingKeyAuthLockoutBaseMinutes, 1)) * time.Minute,
+		MaxDuration:  time.Duration(u.getPositiveInt(domain.SettingKeyAuthLockoutMaxMinutes, 60)) * time.Minute,
+	}
+
+	if policy.MaxDuration < policy.BaseDuration {
+		policy.MaxDuration = policy.BaseDuration
+	}
+
+	return policy, nil
+}
+
+// GetCleaningOfferTimeoutMinutes сколько минут уборщица может думать над заявкой на уборку,
+// прежде чем заявка уйдет следующей уборщице.
+func (u *platformSettingsUseCase) GetCleaningOfferTimeoutMinutes() (int, error) {
+	return u.getPositiveInt(domain.SettingKeyCleaningOfferTimeoutMinutes, 15), nil
+}
+
+func (u *platformSettingsUseCase) getPositiveInt(key string, defaultValue int) int {
+	setting, err := u.settingsRepo.GetByKey(key)
+	if err != nil {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(setting.SettingValue)
+	if err != nil || value < 1 {
+		return defaultValue
+	}
+
+	return value
+}
+
 func (u *platformSettingsUseCase) validateSetting(setting *domain.PlatformSetting) error {
 	if setting.SettingKey == "" {
 		return fmt.Errorf("ключ настройки не может быть пустым")
@@ -174,6 +254,22 @@ func (u *platformSettingsUseCase) validateSetting(setting *domain.PlatformSettin
 		if value < 0 || value > 50 {
 			return fmt.Errorf("комиссия платформы должна быть от 0 до 50 процентов")
 		}
+	case domain.SettingKeyDefaultCancellationPolicy:
+		if !domain.CancellationPolicyCode(setting.SettingValue).IsValid() {
+			return fmt.Errorf("политика отмены должна быть одной из: flexible, moderate, strict")
+		}
+	case domain.SettingKeyAuthLockoutThreshold, domain.SettingKeyAuthLockoutBaseMinutes, domain.SettingKeyAuthLockoutMaxMinutes,
+		domain.SettingKeyCleaningOfferTimeoutMinutes:
+		value, _ := strconv.Atoi(setting.SettingValue)
+		if value < 1 {
+			return fmt.Errorf("значение должно быть положительным числом")
+		}
+	}
+
+	if domain.IsRateLimitSettingKey(setting.SettingKey) {
+		if _, err := domain.ParseRateLimit(setting.SettingValue); err != nil {
+			return err
+		}
 	}
 
 	return nil
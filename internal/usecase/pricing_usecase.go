@@ -0,0 +1,452 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+)
+
+type pricingUseCase struct {
+	pricingRuleRepo domain.PricingRuleRepository
+	settingsUseCase domain.PlatformSettingsUseCase
+}
+
+func NewPricingUseCase(
+	pricingRuleRepo domain.PricingRuleRepository,
+	settingsUseCase domain.PlatformSettingsUseCase,
+) domain.PricingUseCase {
+	return &pricingUseCase{
+		pricingRuleRepo: pricingRuleRepo,
+		settingsUseCase: settingsUseCase,
+	}
+}
+
+func (u *pricingUseCase) GetApartmentPricingRules(apartmentID int) ([]*domain.PricingRule, error) {
+	return u.pricingRuleRepo.GetByApartmentID(apartmentID, false)
+}
+
+func (u *pricingUseCase) CreatePricingRule(apartmentID int, request *domain.PricingRuleRequest) (*domain.PricingRule, error) {
+	rule := &domain.PricingRule{
+		ApartmentID: apartmentID,
+		IsActive:    true,
+	}
+
+	if err := applyPricingRuleRequest(rule, request); err != nil {
+		return nil, err
+	}
+
+	if err := u.pricingRuleRepo.Create(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (u *pricingUseCase) UpdatePricingRule(apartmentID, ruleID int, request *domain.PricingRuleRequest) (*domain.PricingRule, error) {
+	rule, err := u.getApartmentRule(apartmentID, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyPricingRuleRequest(rule, request); err != nil {
+		return nil, err
+	}
+
+	if err := u.pricingRuleRepo.Update(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (u *pricingUseCase) DeletePricingRule(apartmentID, ruleID int) error {
+	if _, err := u.getApartmentRule(apartmentID, ruleID); err != nil {
+		return err
+	}
+
+	return u.pricingRuleRepo.Delete(ruleID)
+}
+
+func (u *pricingUseCase) GetPublicHolidays(year int) ([]*domain.PublicHoliday, error) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	return u.pricingRuleRepo.GetPublicHolidays(from, to)
+}
+
+func (u *pricingUseCase) CalculateBookingPrice(apartment *domain.Apartment, startDate time.Time, duration int) (*domain.PriceBreakdown, error) {
+	days := 0
+	if isDailyRental(apartment, duration) {
+		days = duration / 24
+	}
+
+	breakdown, err := u.calculate(apartment, startDate, duration, days)
+	if err != nil {
+		return nil, err
+	}
+
+	if duration == 24 {
+		breakdown.ServiceFee = domain.DailyServiceFee
+	} else {
+		serviceFeePercentage, err := u.settingsUseCase.GetServiceFeePercentage()
+		if err != nil {
+			serviceFeePercentage = domain.ServiceFeePercentage
+		}
+		breakdown.ServiceFeePercentage = serviceFeePercentage
+		breakdown.ServiceFee = breakdown.TotalPrice * serviceFeePercentage / 100
+	}
+	breakdown.FinalPrice = breakdown.TotalPrice + breakdown.ServiceFee
+
+	return breakdown, nil
+}
+
+// CalculateStayPrice рассчитывает стоимость посуточного проживания по ночам с фиксированным сбором за каждую ночь.
+func (u *pricingUseCase) CalculateStayPrice(apartment *domain.Apartment, stay *domain.StayPeriod) (*domain.PriceBreakdown, error) {
+	breakdown, err := u.calculate(apartment, stay.CheckIn, stay.Hours(), stay.Nights)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown.RentalType = "nightly"
+	breakdown.ServiceFee = domain.DailyServiceFee * stay.Nights
+	breakdown.FinalPrice = breakdown.TotalPrice + breakdown.ServiceFee
+
+	return breakdown, nil
+}
+
+// CalculateExtensionPrice рассчитывает стоимость продления: всегда по почасовой цене и без сервисного сбора.
+func (u *pricingUseCase) CalculateExtensionPrice(apartment *domain.Apartment, startDate time.Time, duration int) (*domain.PriceBreakdown, error) {
+	breakdown, err := u.calculate(apartment, startDate, duration, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown.FinalPrice = breakdown.TotalPrice
+	return breakdown, nil
+}
+
+// calculate считает базовую цену и корректировки: при days > 0 - по суткам, иначе - почасово за duration часов
+func (u *pricingUseCase) calculate(apartment *domain.Apartment, startDate time.Time, duration, days int) (*domain.PriceBreakdown, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("продолжительность должна быть больше нуля")
+	}
+
+	rules, err := u.pricingRuleRepo.GetByApartmentID(apartment.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения правил ценообразования: %w", err)
+	}
+
+	localStart := utils.ConvertOutputFromUTC(startDate)
+
+	breakdown := &domain.PriceBreakdown{
+		Duration:     duration,
+		Adjustments:  []domain.PriceAdjustment{},
+		Currency:     domain.PricingCurrency,
+		CalculatedAt: utils.GetCurrentTimeUTC(),
+	}
+
+	// Суточные цены рассчитываются по каждым суткам, почасовая аренда - по дате начала
+	daily := days > 0
+	var dates []time.Time
+	if daily {
+		breakdown.RentalType = "daily"
+		breakdown.Units = days
+		breakdown.UnitPrice = apartment.DailyPrice
+		breakdown.BasePrice = apartment.DailyPrice * days
+		for i := 0; i < days; i++ {
+			dates = append(dates, localStart.AddDate(0, 0, i))
+		}
+	} else {
+		breakdown.RentalType = "hourly"
+		breakdown.Units = duration
+		breakdown.UnitPrice = apartment.Price
+		breakdown.BasePrice = utils.CalculateHourlyPrice(apartment.Price, duration)
+		dates = []time.Time{localStart}
+	}
+
+	holidays := map[string]bool{}
+	if hasPricingRuleType(rules, domain.PricingRuleTypeHoliday) {
+		publicHolidays, err := u.pricingRuleRepo.GetPublicHolidays(dates[0], dates[len(dates)-1])
+		if err != nil {
+			return nil, err
+		}
+		for _, holiday := range publicHolidays {
+			holidays[holiday.Date.Format(domain.PricingDateLayout)] = true
+		}
+	}
+
+	adjustments := newAdjustmentCollector()
+
+	for _, day := range dates {
+		dayKey := day.Format(domain.PricingDateLayout)
+
+		unitPrice := breakdown.BasePrice
+		if daily {
+			unitPrice = apartment.DailyPrice
+		}
+
+		if rule := findDateRangeRule(rules, day); rule != nil {
+			if overridePrice := dateRangeOverride(rule, daily, duration); overridePrice > 0 {
+				adjustments.add(rule, rule.Name, 0, overridePrice-unitPrice, dayKey)
+				unitPrice = overridePrice
+			}
+			if rule.AdjustmentPercentage != 0 {
+				amount := unitPrice * rule.AdjustmentPercentage / 100
+				adjustments.add(rule, rule.Name, rule.AdjustmentPercentage, amount, dayKey)
+				unitPrice += amount
+			}
+		}
+
+		// Праздничная надбавка имеет приоритет над надбавкой по дню недели
+		if holidays[dayKey] {
+			if rule := findPricingRuleByType(rules, domain.PricingRuleTypeHoliday); rule != nil {
+				adjustments.add(rule, rule.Name, rule.AdjustmentPercentage, unitPrice*rule.AdjustmentPercentage/100, dayKey)
+				continue
+			}
+		}
+
+		if rule := findWeekdayRule(rules, day); rule != nil {
+			adjustments.add(rule, rule.Name, rule.AdjustmentPercentage, unitPrice*rule.AdjustmentPercentage/100, dayKey)
+		}
+	}
+
+	subtotal := breakdown.BasePrice + adjustments.total()
+
+	if daily {
+		if rule := findLengthOfStayRule(rules, breakdown.Units); rule != nil {
+			adjustments.add(rule, rule.Name, rule.AdjustmentPercentage, subtotal*rule.AdjustmentPercentage/100, "")
+		}
+	}
+
+	breakdown.Adjustments = adjustments.items
+	breakdown.AdjustmentsTotal = adjustments.total()
+	breakdown.TotalPrice = breakdown.BasePrice + breakdown.AdjustmentsTotal
+	if breakdown.TotalPrice < 0 {
+		breakdown.TotalPrice = 0
+	}
+
+	return breakdown, nil
+}
+
+func (u *pricingUseCase) getApartmentRule(apartmentID, ruleID int) (*domain.PricingRule, error) {
+	rule, err := u.pricingRuleRepo.GetByID(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil || rule.ApartmentID != apartmentID {
+		return nil, fmt.Errorf("правило ценообразования с ID %d не найдено", ruleID)
+	}
+	return rule, nil
+}
+
+func applyPricingRuleRequest(rule *domain.PricingRule, request *domain.PricingRuleRequest) error {
+	if !request.Type.IsValid() {
+		return fmt.Errorf("тип правила должен быть одним из: date_range, weekday, holiday, length_of_stay")
+	}
+
+	if request.AdjustmentPercentage < domain.MinPricingAdjustmentPercentage || request.AdjustmentPercentage > domain.MaxPricingAdjustmentPercentage {
+		return fmt.Errorf("процент корректировки должен быть от %d до %d",
+			domain.MinPricingAdjustmentPercentage, domain.MaxPricingAdjustmentPercentage)
+	}
+
+	rule.Type = request.Type
+	rule.Name = strings.TrimSpace(request.Name)
+	rule.AdjustmentPercentage = request.AdjustmentPercentage
+	rule.StartDate = nil
+	rule.EndDate = nil
+	rule.Weekdays = nil
+	rule.MinDays = nil
+	rule.HourlyPrice = nil
+	rule.DailyPrice = nil
+	if request.IsActive != nil {
+		rule.IsActive = *request.IsActive
+	}
+
+	switch request.Type {
+	case domain.PricingRuleTypeDateRange:
+		if request.StartDate == nil || request.EndDate == nil {
+			return fmt.Errorf("для периода необходимо указать start_date и end_date")
+		}
+		startDate, err := time.Parse(domain.PricingDateLayout, *request.StartDate)
+		if err != nil {
+			return fmt.Errorf("некорректный формат start_date. Используйте формат: 2006-01-02")
+		}
+		endDate, err := time.Parse(domain.PricingDateLayout, *request.EndDate)
+		if err != nil {
+			return fmt.Errorf("некорректный формат end_date. Используйте формат: 2006-01-02")
+		}
+		if endDate.Before(startDate) {
+			return fmt.Errorf("end_date не может быть раньше start_date")
+		}
+		if request.HourlyPrice != nil && *request.HourlyPrice <= 0 || request.DailyPrice != nil && *request.DailyPrice <= 0 {
+			return fmt.Errorf("цена на период должна быть больше нуля")
+		}
+		if request.HourlyPrice == nil && request.DailyPrice == nil && request.AdjustmentPercentage == 0 {
+			return fmt.Errorf("для периода необходимо указать цену или процент корректировки")
+		}
+		rule.StartDate = &startDate
+		rule.EndDate = &endDate
+		rule.HourlyPrice = request.HourlyPrice
+		rule.DailyPrice = request.DailyPrice
+
+	case domain.PricingRuleTypeWeekday:
+		if len(request.Weekdays) == 0 {
+			return fmt.Errorf("необходимо указать дни недели")
+		}
+		for _, weekday := range request.Weekdays {
+			if weekday < 0 || weekday > 6 {
+				return fmt.Errorf("день недели должен быть от 0 (воскресенье) до 6 (суббота)")
+			}
+		}
+		if request.AdjustmentPercentage == 0 {
+			return fmt.Errorf("необходимо указать процент корректировки")
+		}
+		rule.Weekdays = request.Weekdays
+
+	case domain.PricingRuleTypeHoliday:
+		if request.AdjustmentPercentage == 0 {
+			return fmt.Errorf("необходимо указать процент корректировки")
+		}
+
+	case domain.PricingRuleTypeLengthOfStay:
+		if request.MinDays == nil || *request.MinDays < 2 {
+			return fmt.Errorf("минимальное количество суток для скидки - 2")
+		}
+		if request.AdjustmentPercentage >= 0 {
+			return fmt.Errorf("скидка за длительное проживание должна быть отрицательным процентом")
+		}
+		rule.MinDays = request.MinDays
+	}
+
+	return nil
+}
+
+func isDailyRental(apartment *domain.Apartment, duration int) bool {
+	return duration >= 24 && duration%24 == 0 && apartment.RentalTypeDaily && apartment.DailyPrice > 0
+}
+
+func dateRangeOverride(rule *domain.PricingRule, daily bool, duration int) int {
+	if daily {
+		if rule.DailyPrice != nil {
+			return *rule.DailyPrice
+		}
+		return 0
+	}
+	if rule.HourlyPrice != nil {
+		return utils.CalculateHourlyPrice(*rule.HourlyPrice, duration)
+	}
+	return 0
+}
+
+func hasPricingRuleType(rules []*domain.PricingRule, ruleType domain.PricingRuleType) bool {
+	return findPricingRuleByType(rules, ruleType) != nil
+}
+
+func findPricingRuleByType(rules []*domain.PricingRule, ruleType domain.PricingRuleType) *domain.PricingRule {
+	for _, rule := range rules {
+		if rule.Type == ruleType {
+			return rule
+		}
+	}
+	return nil
+}
+
+// findDateRangeRule выбирает самый короткий период, покрывающий дату
+func findDateRangeRule(rules []*domain.PricingRule, day time.Time) *domain.PricingRule {
+	var matched *domain.PricingRule
+	for _, rule := range rules {
+		if rule.Type != domain.PricingRuleTypeDateRange || !rule.CoversDate(day) {
+			continue
+		}
+		if matched == nil || rule.EndDate.Sub(*rule.StartDate) < matched.EndDate.Sub(*matched.StartDate) {
+			matched = rule
+		}
+	}
+	return matched
+}
+
+func findWeekdayRule(rules []*domain.PricingRule, day time.Time) *domain.PricingRule {
+	for _, rule := range rules {
+		if rule.Type == domain.PricingRuleTypeWeekday && rule.MatchesWeekday(day) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// findLengthOfStayRule выбирает скидку с наибольшим порогом, который достигнут
+func findLengthOfStayRule(rules []*domain.PricingRule, days int) *domain.PricingRule {
+	var matched *domain.PricingRule
+	for _, rule := range rules {
+		if rule.Type != domain.PricingRuleTypeLengthOfStay || rule.MinDays == nil || *rule.MinDays > days {
+			continue
+		}
+		if matched == nil || *rule.MinDays > *matched.MinDays {
+			matched = rule
+		}
+	}
+	return matched
+}
+
+var defaultAdjustmentNames = map[domain.PricingRuleType]string{
+	domain.PricingRuleTypeDateRange:    "Сезонная цена",
+	domain.PricingRuleTypeWeekday:      "Надбавка по дням недели",
+	domain.PricingRuleTypeHoliday:      "Праздничные дни",
+	domain.PricingRuleTypeLengthOfStay: "Скидка за длительное проживание",
+}
+
+type adjustmentCollector struct {
+	items   []domain.PriceAdjustment
+	indexes map[int]int
+}
+
+func newAdjustmentCollector() *adjustmentCollector {
+	return &adjustmentCollector{
+		items:   []domain.PriceAdjustment{},
+		indexes: map[int]int{},
+	}
+}
+
+// add объединяет корректировки одного правила по всем датам в одну строку расчета
+func (c *adjustmentCollector) add(rule *domain.PricingRule, name string, percentage, amount int, day string) {
+	if amount == 0 {
+		return
+	}
+
+	key := rule.ID
+	if percentage == 0 {
+		// Переопределение цены и процентная надбавка одного периода показываются отдельно
+		key = -rule.ID
+	}
+
+	index, exists := c.indexes[key]
+	if !exists {
+		if name == "" {
+			name = defaultAdjustmentNames[rule.Type]
+		}
+		ruleID := rule.ID
+		c.items = append(c.items, domain.PriceAdjustment{
+			RuleID:     &ruleID,
+			Type:       rule.Type,
+			Name:       name,
+			Percentage: percentage,
+		})
+		index = len(c.items) - 1
+		c.indexes[key] = index
+	}
+
+	c.items[index].Amount += amount
+	if day != "" {
+		c.items[index].Dates = append(c.items[index].Dates, day)
+	}
+}
+
+func (c *adjustmentCollector) total() int {
+	total := 0
+	for _, item := range c.items {
+		total += item.Amount
+	}
+	return total
+}
@@ -0,0 +1,570 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/russo2642/renti_kz/internal/domain"
+	"github.com/russo2642/renti_kz/internal/utils"
+	"github.com/russo2642/renti_kz/pkg/logger"
+)
+
+const (
+	maxReviewCommentLength = 2000
+	maxReviewReplyLength   = 1000
+	defaultReviewWindow    = 14
+	defaultReviewsPageSize = 20
+	maxReviewsPageSize     = 100
+)
+
+type reviewUseCase struct {
+	reviewRepo          domain.ReviewRepository
+	bookingRepo         domain.BookingRepository
+	apartmentRepo       domain.ApartmentRepository
+	renterRepo          domain.RenterRepository
+	propertyOwnerRepo   domain.PropertyOwnerRepository
+	notificationUseCase domain.NotificationUseCase
+	settingsUseCase     domain.PlatformSettingsUseCase
+}
+
+func NewReviewUseCase(
+	reviewRepo domain.ReviewRepository,
+	bookingRepo domain.BookingRepository,
+	apartmentRepo domain.ApartmentRepository,
+	renterRepo domain.RenterRepository,
+	propertyOwnerRepo domain.PropertyOwnerRepository,
+	notificationUseCase domain.NotificationUseCase,
+	settingsUseCase domain.PlatformSettingsUseCase,
+) domain.ReviewUseCase {
+	return &reviewUseCase{
+		reviewRepo:          reviewRepo,
+		bookingRepo:         bookingRepo,
+		apartmentRepo:       apartmentRepo,
+		renterRepo:          renterRepo,
+		propertyOwnerRepo:   propertyOwnerRepo,
+		notificationUseCase: notificationUseCase,
+		settingsUseCase:     settingsUseCase,
+	}
+}
+
+func (u *reviewUseCase) CreateApartmentReview(userID int, request *domain.CreateApartmentReviewRequest) (*domain.ApartmentReview, error) {
+	renter, err := utils.GetRenterByUserID(u.renterRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := u.getReviewableBooking(request.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.RenterID != renter.ID {
+		return nil, fmt.Errorf("оставить отзыв может только арендатор этого бронирования")
+	}
+
+	existing, err := u.reviewRepo.GetApartmentReviewByBookingID(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("отзыв по этому бронированию уже оставлен")
+	}
+
+	ratings := []int{request.CleanlinessRating, request.AccuracyRating, request.LocationRating, request.CheckInRating}
+	for _, rating := range ratings {
+		if !isValidReviewRating(rating) {
+			return nil, fmt.Errorf("оценка должна быть от %d до %d", domain.MinReviewRating, domain.MaxReviewRating)
+		}
+	}
+
+	comment, err := normalizeReviewText(request.Comment, maxReviewCommentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &domain.ApartmentReview{
+		BookingID:         booking.ID,
+		ApartmentID:       booking.ApartmentID,
+		RenterID:          renter.ID,
+		CleanlinessRating: request.CleanlinessRating,
+		AccuracyRating:    request.AccuracyRating,
+		LocationRating:    request.LocationRating,
+		CheckInRating:     request.CheckInRating,
+		Rating:            averageReviewRating(ratings),
+		Comment:           comment,
+		Status:            domain.ReviewStatusPending,
+	}
+
+	if err := u.reviewRepo.CreateApartmentReview(review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// ReplyToApartmentReview сохраняет публичный ответ владельца. Повторный ответ заменяет предыдущий.
+func (u *reviewUseCase) ReplyToApartmentReview(userID, reviewID int, request *domain.ReviewReplyRequest) (*domain.ApartmentReview, error) {
+	owner, err := utils.GetPropertyOwnerByUserID(u.propertyOwnerRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := u.reviewRepo.GetApartmentReviewByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, fmt.Errorf("отзыв не найден")
+	}
+
+	apartment, err := u.apartmentRepo.GetByID(review.ApartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения квартиры: %w", err)
+	}
+	if apartment == nil || apartment.OwnerID != owner.ID {
+		return nil, fmt.Errorf("ответить на отзыв может только владелец квартиры")
+	}
+
+	if review.Status != domain.ReviewStatusPublished {
+		return nil, fmt.Errorf("ответить можно только на опубликованный отзыв")
+	}
+
+	reply, err := normalizeReviewText(request.Reply, maxReviewReplyLength)
+	if err != nil {
+		return nil, err
+	}
+	if reply == "" {
+		return nil, fmt.Errorf("ответ не может быть пустым")
+	}
+
+	now := utils.GetCurrentTimeUTC()
+	review.OwnerReply = &reply
+	review.OwnerRepliedAt = &now
+
+	if err := u.reviewRepo.UpdateOwnerReply(review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (u *reviewUseCase) GetApartmentReviews(apartmentID, page, pageSize int) ([]*domain.ApartmentReview, int, error) {
+	page, pageSize = normalizeReviewsPage(page, pageSize)
+	return u.reviewRepo.GetApartmentReviews(apartmentID, domain.ReviewStatusPublished, page, pageSize)
+}
+
+func (u *reviewUseCase) GetApartmentRating(apartmentID int) (*domain.ApartmentRating, error) {
+	apartment, err := u.apartmentRepo.GetByID(apartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения квартиры: %w", err)
+	}
+	if apartment == nil {
+		return nil, fmt.Errorf("квартира не найдена")
+	}
+
+	return &domain.ApartmentRating{
+		Rating:       apartment.Rating,
+		ReviewsCount: apartment.ReviewsCount,
+		Cleanliness:  apartment.RatingCleanliness,
+		Accuracy:     apartment.RatingAccuracy,
+		Location:     apartment.RatingLocation,
+		CheckIn:      apartment.RatingCheckIn,
+	}, nil
+}
+
+func (u *reviewUseCase) CreateRenterReview(userID int, request *domain.CreateRenterReviewRequest) (*domain.RenterReview, error) {
+	owner, err := utils.GetPropertyOwnerByUserID(u.propertyOwnerRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := u.getReviewableBooking(request.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения квартиры: %w", err)
+	}
+	if apartment == nil || apartment.OwnerID != owner.ID {
+		return nil, fmt.Errorf("оставить отзыв может только владелец квартиры")
+	}
+
+	existing, err := u.reviewRepo.GetRenterReviewByBookingID(booking.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("отзыв по этому бронированию уже оставлен")
+	}
+
+	if !isValidReviewRating(request.Rating) {
+		return nil, fmt.Errorf("оценка должна быть от %d до %d", domain.MinReviewRating, domain.MaxReviewRating)
+	}
+
+	comment, err := normalizeReviewText(request.Comment, maxReviewCommentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &domain.RenterReview{
+		BookingID: booking.ID,
+		RenterID:  booking.RenterID,
+		OwnerID:   owner.ID,
+		Rating:    request.Rating,
+		Comment:   comment,
+		Status:    domain.ReviewStatusPending,
+	}
+
+	if err := u.reviewRepo.CreateRenterReview(review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (u *reviewUseCase) GetRenterReviews(renterID, page, pageSize int) ([]*domain.RenterReview, int, error) {
+	page, pageSize = normalizeReviewsPage(page, pageSize)
+	return u.reviewRepo.GetRenterReviews(renterID, domain.ReviewStatusPublished, page, pageSize)
+}
+
+func (u *reviewUseCase) GetRenterRating(renterID int) (*domain.RenterRating, error) {
+	return u.reviewRepo.GetRenterRating(renterID)
+}
+
+// GetBookingReviewState показывает участнику бронирования, какие отзывы он еще может оставить.
+func (u *reviewUseCase) GetBookingReviewState(userID, bookingID int) (*domain.BookingReviewState, error) {
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирования: %w", err)
+	}
+	if booking == nil {
+		return nil, fmt.Errorf("бронирование не найдено")
+	}
+
+	isRenter := false
+	if renter, _ := u.renterRepo.GetByUserID(userID); renter != nil && renter.ID == booking.RenterID {
+		isRenter = true
+	}
+
+	isOwner := false
+	if owner, _ := u.propertyOwnerRepo.GetByUserID(userID); owner != nil {
+		apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
+		if err != nil {
+			return nil, fmt.Errorf("ошибка получения квартиры: %w", err)
+		}
+		isOwner = apartment != nil && apartment.OwnerID == owner.ID
+	}
+
+	if !isRenter && !isOwner {
+		return nil, fmt.Errorf("нет доступа к этому бронированию")
+	}
+
+	deadline := u.reviewDeadline(booking)
+	windowOpen := booking.Status == domain.BookingStatusCompleted && !utils.GetCurrentTimeUTC().After(deadline)
+
+	state := &domain.BookingReviewState{
+		BookingID:      booking.ID,
+		ReviewDeadline: deadline,
+	}
+
+	if isRenter {
+		state.ApartmentReview, err = u.reviewRepo.GetApartmentReviewByBookingID(booking.ID)
+		if err != nil {
+			return nil, err
+		}
+		state.CanReviewApartment = windowOpen && state.ApartmentReview == nil
+	}
+
+	if isOwner {
+		state.RenterReview, err = u.reviewRepo.GetRenterReviewByBookingID(booking.ID)
+		if err != nil {
+			return nil, err
+		}
+		state.CanReviewRenter = windowOpen && state.RenterReview == nil
+	}
+
+	return state, nil
+}
+
+func (u *reviewUseCase) GetApartmentReviewsForModeration(status domain.ReviewStatus, page, pageSize int) ([]*domain.ApartmentReview, int, error) {
+	if status == "" {
+		status = domain.ReviewStatusPending
+	}
+	page, pageSize = normalizeReviewsPage(page, pageSize)
+	return u.reviewRepo.GetApartmentReviewsByStatus(status, page, pageSize)
+}
+
+func (u *reviewUseCase) GetRenterReviewsForModeration(status domain.ReviewStatus, page, pageSize int) ([]*domain.RenterReview, int, error) {
+	if status == "" {
+		status = domain.ReviewStatusPending
+	}
+	page, pageSize = normalizeReviewsPage(page, pageSize)
+	return u.reviewRepo.GetRenterReviewsByStatus(status, page, pageSize)
+}
+
+// ModerateApartmentReview публикует или отклоняет отзыв о квартире. Рейтинг квартиры пересчитывается,
+// когда отзыв попадает в опубликованные или снимается с публикации.
+func (u *reviewUseCase) ModerateApartmentReview(moderatorID, reviewID int, request *domain.ModerateReviewRequest) (*domain.ApartmentReview, error) {
+	if err := validateModerationStatus(request.Status); err != nil {
+		return nil, err
+	}
+
+	review, err := u.reviewRepo.GetApartmentReviewByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, fmt.Errorf("отзыв не найден")
+	}
+
+	previousStatus := review.Status
+	applyModeration(&review.Status, &review.ModeratorID, &review.ModeratorComment, &review.ModeratedAt, moderatorID, request)
+
+	if err := u.reviewRepo.UpdateApartmentReviewModeration(review); err != nil {
+		return nil, err
+	}
+
+	if previousStatus == domain.ReviewStatusPublished || review.Status == domain.ReviewStatusPublished {
+		if err := u.reviewRepo.RecalculateApartmentRating(review.ApartmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if previousStatus != review.Status {
+		u.notifyApartmentReviewModerated(review)
+	}
+
+	return review, nil
+}
+
+func (u *reviewUseCase) ModerateRenterReview(moderatorID, reviewID int, request *domain.ModerateReviewRequest) (*domain.RenterReview, error) {
+	if err := validateModerationStatus(request.Status); err != nil {
+		return nil, err
+	}
+
+	review, err := u.reviewRepo.GetRenterReviewByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, fmt.Errorf("отзыв не найден")
+	}
+
+	previousStatus := review.Status
+	applyModeration(&review.Status, &review.ModeratorID, &review.ModeratorComment, &review.ModeratedAt, moderatorID, request)
+
+	if err := u.reviewRepo.UpdateRenterReviewModeration(review); err != nil {
+		return nil, err
+	}
+
+	if previousStatus != review.Status && review.Status == domain.ReviewStatusRejected && u.notificationUseCase != nil {
+		if owner, err := u.propertyOwnerRepo.GetByID(review.OwnerID); err == nil && owner != nil {
+			if err := u.notificationUseCase.NotifyReviewRejected(owner.UserID, review.BookingID, request.Comment); err != nil {
+				logger.Warn("failed to send review rejected notification",
+					slog.Int("review_id", review.ID),
+					slog.String("error", err.Error()))
+			}
+		}
+	}
+
+	return review, nil
+}
+
+// SendReviewReminders напоминает арендатору и владельцу оставить отзыв, если они еще этого не сделали.
+func (u *reviewUseCase) SendReviewReminders(bookingID int) error {
+	if u.notificationUseCase == nil {
+		return nil
+	}
+
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения бронирования: %w", err)
+	}
+	if booking == nil || booking.Status != domain.BookingStatusCompleted {
+		return nil
+	}
+
+	deadline := u.reviewDeadline(booking)
+	if utils.GetCurrentTimeUTC().After(deadline) {
+		return nil
+	}
+
+	apartment, err := u.apartmentRepo.GetByID(booking.ApartmentID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения квартиры: %w", err)
+	}
+	if apartment == nil {
+		return nil
+	}
+
+	apartmentReview, err := u.reviewRepo.GetApartmentReviewByBookingID(booking.ID)
+	if err != nil {
+		return err
+	}
+	renter, err := u.renterRepo.GetByIDWithUser(booking.RenterID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения арендатора: %w", err)
+	}
+
+	if apartmentReview == nil && renter != nil {
+		apartmentTitle := fmt.Sprintf("%s, кв. %d", apartment.Street, apartment.ApartmentNumber)
+		if err := u.notificationUseCase.NotifyApartmentReviewRequested(renter.UserID, booking.ID, apartmentTitle, deadline); err != nil {
+			logger.Warn("failed to send apartment review reminder",
+				slog.Int("booking_id", booking.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	renterReview, err := u.reviewRepo.GetRenterReviewByBookingID(booking.ID)
+	if err != nil {
+		return err
+	}
+	owner, err := u.propertyOwnerRepo.GetByID(apartment.OwnerID)
+	if err != nil {
+		return fmt.Errorf("ошибка получения владельца: %w", err)
+	}
+
+	if renterReview == nil && owner != nil {
+		renterName := "арендатор"
+		if renter != nil && renter.User != nil {
+			renterName = strings.TrimSpace(renter.User.FirstName + " " + renter.User.LastName)
+		}
+		if err := u.notificationUseCase.NotifyRenterReviewRequested(owner.UserID, booking.ID, renterName, deadline); err != nil {
+			logger.Warn("failed to send renter review reminder",
+				slog.Int("booking_id", booking.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// getReviewableBooking возвращает бронирование, если по нему еще можно оставить отзыв:
+// проживание завершено и срок для отзывов после выезда не истек.
+func (u *reviewUseCase) getReviewableBooking(bookingID int) (*domain.Booking, error) {
+	booking, err := u.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения бронирования: %w", err)
+	}
+	if booking == nil {
+		return nil, fmt.Errorf("бронирование не найдено")
+	}
+
+	if booking.Status != domain.BookingStatusCompleted {
+		return nil, fmt.Errorf("отзыв можно оставить только по завершенному бронированию")
+	}
+
+	deadline := u.reviewDeadline(booking)
+	if utils.GetCurrentTimeUTC().After(deadline) {
+		return nil, fmt.Errorf("срок для отзыва истек %s", utils.FormatForUser(deadline))
+	}
+
+	return booking, nil
+}
+
+func (u *reviewUseCase) reviewDeadline(booking *domain.Booking) time.Time {
+	windowDays := defaultReviewWindow
+	if u.settingsUseCase != nil {
+		if days, err := u.settingsUseCase.GetReviewWindowDays(); err == nil {
+			windowDays = days
+		}
+	}
+
+	return booking.EndDate.AddDate(0, 0, windowDays)
+}
+
+func (u *reviewUseCase) notifyApartmentReviewModerated(review *domain.ApartmentReview) {
+	if u.notificationUseCase == nil {
+		return
+	}
+
+	var err error
+	switch review.Status {
+	case domain.ReviewStatusPublished:
+		apartment, getErr := u.apartmentRepo.GetByID(review.ApartmentID)
+		if getErr != nil || apartment == nil {
+			return
+		}
+		owner, getErr := u.propertyOwnerRepo.GetByID(apartment.OwnerID)
+		if getErr != nil || owner == nil {
+			return
+		}
+		apartmentTitle := fmt.Sprintf("%s, кв. %d", apartment.Street, apartment.ApartmentNumber)
+		err = u.notificationUseCase.NotifyReviewPublished(owner.UserID, apartment.ID, apartmentTitle, review.Rating)
+	case domain.ReviewStatusRejected:
+		renter, getErr := u.renterRepo.GetByID(review.RenterID)
+		if getErr != nil || renter == nil {
+			return
+		}
+		reason := ""
+		if review.ModeratorComment != nil {
+			reason = *review.ModeratorComment
+		}
+		err = u.notificationUseCase.NotifyReviewRejected(renter.UserID, review.BookingID, reason)
+	}
+
+	if err != nil {
+		logger.Warn("failed to send review moderation notification",
+			slog.Int("review_id", review.ID),
+			slog.String("status", string(review.Status)),
+			slog.String("error", err.Error()))
+	}
+}
+
+func validateModerationStatus(status domain.ReviewStatus) error {
+	if status != domain.ReviewStatusPublished && status != domain.ReviewStatusRejected {
+		return fmt.Errorf("некорректный статус модерации: допустимы published, rejected")
+	}
+	return nil
+}
+
+func applyModeration(status *domain.ReviewStatus, moderatorID **int, moderatorComment **string, moderatedAt **time.Time,
+	moderator int, request *domain.ModerateReviewRequest) {
+	now := utils.GetCurrentTimeUTC()
+	*status = request.Status
+	*moderatorID = &moderator
+	*moderatedAt = &now
+
+	*moderatorComment = nil
+	if comment := strings.TrimSpace(request.Comment); comment != "" {
+		*moderatorComment = &comment
+	}
+}
+
+func isValidReviewRating(rating int) bool {
+	return rating >= domain.MinReviewRating && rating <= domain.MaxReviewRating
+}
+
+func averageReviewRating(ratings []int) float64 {
+	sum := 0
+	for _, rating := range ratings {
+		sum += rating
+	}
+	return math.Round(float64(sum)/float64(len(ratings))*100) / 100
+}
+
+func normalizeReviewText(text string, maxLength int) (string, error) {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > maxLength {
+		return "", fmt.Errorf("текст не может быть длиннее %d символов", maxLength)
+	}
+	return text, nil
+}
+
+func normalizeReviewsPage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultReviewsPageSize
+	}
+	if pageSize > maxReviewsPageSize {
+		pageSize = maxReviewsPageSize
+	}
+	return page, pageSize
+}
@@ -2,16 +2,23 @@ package usecase
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/russo2642/renti_kz/internal/domain"
+	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/i18n"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// minAdminSetPasswordLength минимальная длина пароля, который администратор задает сотруднику.
+const minAdminSetPasswordLength = 6
+
 type UserUseCase struct {
 	userRepo     domain.UserRepository
 	roleRepo     domain.RoleRepository
 	passwordSalt string
+	authUseCase  domain.AuthUseCase
 }
 
 func NewUserUseCase(
@@ -26,13 +33,17 @@ func NewUserUseCase(
 	}
 }
 
+func (uc *UserUseCase) SetAuthUseCase(authUseCase domain.AuthUseCase) {
+	uc.authUseCase = authUseCase
+}
+
 func (uc *UserUseCase) Register(user *domain.User, password string) error {
 	existingUser, err := uc.userRepo.GetByPhone(user.Phone)
 	if err != nil {
 		return fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if existingUser != nil {
-		return fmt.Errorf("user with phone %s already exists", user.Phone)
+		return apperrors.UserPhoneTaken.New(nil)
 	}
 
 	existingUser, err = uc.userRepo.GetByEmail(user.Email)
@@ -40,7 +51,7 @@ func (uc *UserUseCase) Register(user *domain.User, password string) error {
 		return fmt.Errorf("failed to check email existence: %w", err)
 	}
 	if existingUser != nil {
-		return fmt.Errorf("user with email %s already exists", user.Email)
+		return apperrors.UserEmailTaken.New(nil)
 	}
 
 	hashedPassword, err := uc.hashPassword(password)
@@ -70,7 +81,7 @@ func (uc *UserUseCase) RegisterWithoutPassword(user *domain.User) error {
 		return fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if existingUser != nil {
-		return fmt.Errorf("user with phone %s already exists", user.Phone)
+		return apperrors.UserPhoneTaken.New(nil)
 	}
 
 	existingUser, err = uc.userRepo.GetByEmail(user.Email)
@@ -78,7 +89,7 @@ func (uc *UserUseCase) RegisterWithoutPassword(user *domain.User) error {
 		return fmt.Errorf("failed to check email existence: %w", err)
 	}
 	if existingUser != nil {
-		return fmt.Errorf("user with email %s already exists", user.Email)
+		return apperrors.UserEmailTaken.New(nil)
 	}
 
 	user.PasswordHash = ""
@@ -135,6 +146,7 @@ func (uc *UserUseCase) UpdateProfile(user *domain.User) error {
 	existingUser.LastName = user.LastName
 	existingUser.Email = user.Email
 	existingUser.CityID = user.CityID
+	existingUser.PreferredLanguage = user.PreferredLanguage
 	existingUser.UpdatedAt = time.Now()
 
 	if err := uc.userRepo.Update(existingUser); err != nil {
@@ -150,7 +162,7 @@ func (uc *UserUseCase) ChangePassword(userID int, oldPassword, newPassword strin
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user with id %d not found", userID)
+		return apperrors.UserNotFound.New(nil)
 	}
 
 	if !uc.checkPassword(oldPassword, user.PasswordHash) {
@@ -177,11 +189,16 @@ func (uc *UserUseCase) DeleteUser(userID int, adminID int) error {
 	if err != nil {
 		return fmt.Errorf("failed to get admin: %w", err)
 	}
-	if admin == nil {
-		return fmt.Errorf("admin with id %d not found", adminID)
+	if admin == nil || admin.Role != domain.RoleAdmin {
+		return apperrors.UserAdminRequired.New(nil)
 	}
-	if admin.Role != domain.RoleAdmin {
-		return fmt.Errorf("only admins can delete users")
+
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return apperrors.UserNotFound.New(nil)
 	}
 
 	if err := uc.userRepo.Delete(userID); err != nil {
@@ -197,7 +214,7 @@ func (uc *UserUseCase) DeleteOwnAccount(userID int) error {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user with id %d not found", userID)
+		return apperrors.UserNotFound.New(nil)
 	}
 
 	if err := uc.userRepo.Delete(userID); err != nil {
@@ -220,11 +237,8 @@ func (uc *UserUseCase) UpdateUserRole(userID int, role domain.UserRole, adminID
 	if err != nil {
 		return fmt.Errorf("failed to get admin: %w", err)
 	}
-	if admin == nil {
-		return fmt.Errorf("admin with id %d not found", adminID)
-	}
-	if admin.Role != domain.RoleAdmin {
-		return fmt.Errorf("only admins can change user roles")
+	if admin == nil || admin.Role != domain.RoleAdmin {
+		return apperrors.UserAdminRequired.New(nil)
 	}
 
 	user, err := uc.userRepo.GetByID(userID)
@@ -232,7 +246,7 @@ func (uc *UserUseCase) UpdateUserRole(userID int, role domain.UserRole, adminID
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user with id %d not found", userID)
+		return apperrors.UserNotFound.New(nil)
 	}
 
 	roleEntity, err := uc.roleRepo.GetByName(string(role))
@@ -240,7 +254,7 @@ func (uc *UserUseCase) UpdateUserRole(userID int, role domain.UserRole, adminID
 		return fmt.Errorf("failed to get role: %w", err)
 	}
 	if roleEntity == nil {
-		return fmt.Errorf("role %s not found", role)
+		return apperrors.UserRoleNotFound.New(i18n.Params{"role": string(role)})
 	}
 
 	if err := uc.userRepo.UpdateRole(userID, role); err != nil {
@@ -255,11 +269,8 @@ func (uc *UserUseCase) UpdateUserStatus(userID int, isActive bool, reason string
 	if err != nil {
 		return fmt.Errorf("failed to get admin: %w", err)
 	}
-	if admin == nil {
-		return fmt.Errorf("admin with id %d not found", adminID)
-	}
-	if admin.Role != domain.RoleAdmin {
-		return fmt.Errorf("only admins can change user status")
+	if admin == nil || admin.Role != domain.RoleAdmin {
+		return apperrors.UserAdminRequired.New(nil)
 	}
 
 	user, err := uc.userRepo.GetByID(userID)
@@ -267,17 +278,23 @@ func (uc *UserUseCase) UpdateUserStatus(userID int, isActive bool, reason string
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user with id %d not found", userID)
+		return apperrors.UserNotFound.New(nil)
 	}
 
 	if userID == adminID {
-		return fmt.Errorf("admin cannot change their own status")
+		return apperrors.UserOwnStatusChange.New(nil)
 	}
 
 	if err := uc.userRepo.UpdateStatus(userID, isActive); err != nil {
 		return fmt.Errorf("failed to update user status: %w", err)
 	}
 
+	if !isActive && uc.authUseCase != nil {
+		if err := uc.authUseCase.RevokeAllUserSessions(userID, domain.SessionRevokeReasonUserBlocked); err != nil {
+			return fmt.Errorf("failed to revoke user sessions: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -286,11 +303,8 @@ func (uc *UserUseCase) AdminSetPassword(userID int, newPassword string, adminID
 	if err != nil {
 		return fmt.Errorf("failed to get admin: %w", err)
 	}
-	if admin == nil {
-		return fmt.Errorf("admin with id %d not found", adminID)
-	}
-	if admin.Role != domain.RoleAdmin {
-		return fmt.Errorf("only admins can set user passwords")
+	if admin == nil || admin.Role != domain.RoleAdmin {
+		return apperrors.UserAdminRequired.New(nil)
 	}
 
 	user, err := uc.userRepo.GetByID(userID)
@@ -298,15 +312,15 @@ func (uc *UserUseCase) AdminSetPassword(userID int, newPassword string, adminID
 		return fmt.Errorf("failed to get user: %w", err)
 	}
 	if user == nil {
-		return fmt.Errorf("user with id %d not found", userID)
+		return apperrors.UserNotFound.New(nil)
 	}
 
 	if user.Role == domain.RoleUser {
-		return fmt.Errorf("password cannot be set for regular users")
+		return apperrors.UserPasswordForRegularUser.New(nil)
 	}
 
-	if len(newPassword) < 6 {
-		return fmt.Errorf("password must be at least 6 characters")
+	if len(newPassword) < minAdminSetPasswordLength {
+		return apperrors.UserPasswordTooShort.New(i18n.Params{"min": strconv.Itoa(minAdminSetPasswordLength)})
 	}
 
 	hashedPassword, err := uc.hashPassword(newPassword)
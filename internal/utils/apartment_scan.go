@@ -13,7 +13,15 @@ const ApartmentSelectFields = `
 	a.condition_id, a.price, a.daily_price, a.rental_type_hourly, a.rental_type_daily,
 	a.is_free, a.status, a.moderator_comment, a.description, a.listing_type,
 	a.is_agreement_accepted, a.agreement_accepted_at, a.contract_id, a.apartment_type_id,
-	a.view_count, a.booking_count, a.created_at, a.updated_at`
+	a.view_count, a.booking_count, a.created_at, a.updated_at,
+	` + ApartmentStaySelectFields + `,
+	` + ApartmentRatingSelectFields
+
+// ApartmentStaySelectFields параметры посуточного проживания: время заезда/выезда и ограничения по ночам
+const ApartmentStaySelectFields = `to_char(a.check_in_time, 'HH24:MI'), to_char(a.check_out_time, 'HH24:MI'), a.min_nights, a.max_nights`
+
+// ApartmentRatingSelectFields агрегированный рейтинг квартиры по опубликованным отзывам
+const ApartmentRatingSelectFields = `a.rating, a.reviews_count, a.rating_cleanliness, a.rating_accuracy, a.rating_location, a.rating_check_in`
 
 const ApartmentWithConditionSelectFields = ApartmentSelectFields + `,
 	c.name as condition_name, c.description as condition_description,
@@ -61,6 +69,8 @@ func ScanApartment(scanner interface {
 		&apartment.IsFree, &apartment.Status, &moderatorComment, &description, &apartment.ListingType,
 		&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartmentTypeID,
 		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt,
+		&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+		&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn,
 	)
 
 	if err != nil {
@@ -123,7 +133,9 @@ func ScanApartmentWithCondition(scanner interface {
 		&apartment.ConditionID, &apartment.Price, &apartment.DailyPrice, &apartment.RentalTypeHourly, &apartment.RentalTypeDaily,
 		&apartment.IsFree, &apartment.Status, &moderatorComment, &description, &apartment.ListingType,
 		&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartment.ApartmentTypeID,
-		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt, &condition.Name, &condition.Description,
+		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt,
+		&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+		&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn, &condition.Name, &condition.Description,
 		&apartmentTypeIDScan, &apartmentTypeName, &apartmentTypeDescription,
 	)
 
@@ -197,7 +209,9 @@ func ScanApartmentWithOwner(scanner interface {
 		&apartment.ConditionID, &apartment.Price, &apartment.DailyPrice, &apartment.RentalTypeHourly, &apartment.RentalTypeDaily,
 		&apartment.IsFree, &apartment.Status, &moderatorComment, &description, &apartment.ListingType,
 		&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartment.ApartmentTypeID,
-		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt, &owner.ID, &owner.UserID, &owner.CreatedAt, &owner.UpdatedAt,
+		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt,
+		&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+		&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn, &owner.ID, &owner.UserID, &owner.CreatedAt, &owner.UpdatedAt,
 		&user.ID, &user.Phone, &user.FirstName, &user.LastName,
 		&user.Email, &user.CityID, &user.IIN, &user.RoleID,
 		&user.CreatedAt, &user.UpdatedAt,
@@ -278,7 +292,9 @@ func ScanApartmentWithConditionAndOwner(scanner interface {
 		&apartment.ConditionID, &apartment.Price, &apartment.DailyPrice, &apartment.RentalTypeHourly, &apartment.RentalTypeDaily,
 		&apartment.IsFree, &apartment.Status, &moderatorComment, &description, &apartment.ListingType,
 		&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartment.ApartmentTypeID,
-		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt, &condition.Name, &condition.Description,
+		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt,
+		&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+		&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn, &condition.Name, &condition.Description,
 		&owner.ID, &owner.UserID, &owner.CreatedAt, &owner.UpdatedAt,
 		&user.ID, &user.Phone, &user.FirstName, &user.LastName,
 		&user.Email, &user.CityID, &user.IIN, &user.RoleID,
@@ -364,7 +380,9 @@ func ScanApartmentWithConditionOwnerAndFavorite(scanner interface {
 		&apartment.ConditionID, &apartment.Price, &apartment.DailyPrice, &apartment.RentalTypeHourly, &apartment.RentalTypeDaily,
 		&apartment.IsFree, &apartment.Status, &moderatorComment, &description, &apartment.ListingType,
 		&apartment.IsAgreementAccepted, &agreementAcceptedAt, &contractID, &apartment.ApartmentTypeID,
-		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt, &condition.Name, &condition.Description,
+		&apartment.ViewCount, &apartment.BookingCount, &apartment.CreatedAt, &apartment.UpdatedAt,
+		&apartment.CheckInTime, &apartment.CheckOutTime, &apartment.MinNights, &apartment.MaxNights,
+		&apartment.Rating, &apartment.ReviewsCount, &apartment.RatingCleanliness, &apartment.RatingAccuracy, &apartment.RatingLocation, &apartment.RatingCheckIn, &condition.Name, &condition.Description,
 		&owner.ID, &owner.UserID, &owner.CreatedAt, &owner.UpdatedAt,
 		&user.ID, &user.Phone, &user.FirstName, &user.LastName,
 		&user.Email, &user.CityID, &user.IIN, &user.RoleID,
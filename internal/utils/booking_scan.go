@@ -2,17 +2,18 @@ package utils
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/russo2642/renti_kz/internal/domain"
 )
 
 const BookingSelectFields = `
-	b.id, b.renter_id, b.apartment_id, b.start_date, b.end_date, b.duration, b.cleaning_duration,
+	b.id, b.renter_id, b.apartment_id, b.start_date, b.end_date, b.duration, b.nights, b.cleaning_duration,
 	b.status, b.total_price, b.service_fee, b.final_price, b.is_contract_accepted,
 	b.cancellation_reason, b.owner_comment, b.booking_number, b.door_status,
 	b.last_door_action, b.can_extend, b.extension_requested, b.extension_end_date,
-	b.extension_duration, b.extension_price, b.payment_id, b.created_at, b.updated_at`
+	b.extension_duration, b.extension_price, b.payment_id, b.price_breakdown, b.created_at, b.updated_at`
 
 func ScanBooking(rows *sql.Rows) (*domain.Booking, error) {
 	var booking domain.Booking
@@ -21,6 +22,7 @@ func ScanBooking(rows *sql.Rows) (*domain.Booking, error) {
 	var lastDoorAction sql.NullTime
 	var extensionEndDate sql.NullTime
 	var paymentID sql.NullInt64
+	var priceBreakdown []byte
 
 	err := rows.Scan(
 		&booking.ID,
@@ -29,6 +31,7 @@ func ScanBooking(rows *sql.Rows) (*domain.Booking, error) {
 		&booking.StartDate,
 		&booking.EndDate,
 		&booking.Duration,
+		&booking.Nights,
 		&booking.CleaningDuration,
 		&booking.Status,
 		&booking.TotalPrice,
@@ -46,6 +49,7 @@ func ScanBooking(rows *sql.Rows) (*domain.Booking, error) {
 		&booking.ExtensionDuration,
 		&booking.ExtensionPrice,
 		&paymentID,
+		&priceBreakdown,
 		&booking.CreatedAt,
 		&booking.UpdatedAt,
 	)
@@ -73,6 +77,11 @@ func ScanBooking(rows *sql.Rows) (*domain.Booking, error) {
 		booking.PaymentID = &paymentID.Int64
 	}
 
+	booking.PriceBreakdown, err = ParsePriceBreakdown(priceBreakdown)
+	if err != nil {
+		return nil, err
+	}
+
 	return &booking, nil
 }
 
@@ -135,18 +144,20 @@ func BuildBookingQuery(baseWhere string, status []domain.BookingStatus, orderBy
 }
 
 const BookingExtensionSelectFields = `
-	id, booking_id, duration, price, status, payment_id, requested_at, approved_at, created_at, updated_at`
+	id, booking_id, duration, price, price_breakdown, status, payment_id, requested_at, approved_at, created_at, updated_at`
 
 func ScanBookingExtension(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*domain.BookingExtension, error) {
 	extension := &domain.BookingExtension{}
+	var priceBreakdown []byte
 
 	err := scanner.Scan(
 		&extension.ID,
 		&extension.BookingID,
 		&extension.Duration,
 		&extension.Price,
+		&priceBreakdown,
 		&extension.Status,
 		&extension.PaymentID,
 		&extension.RequestedAt,
@@ -159,6 +170,11 @@ func ScanBookingExtension(scanner interface {
 		return nil, err
 	}
 
+	extension.PriceBreakdown, err = ParsePriceBreakdown(priceBreakdown)
+	if err != nil {
+		return nil, err
+	}
+
 	return extension, nil
 }
 
@@ -180,6 +196,31 @@ func ScanBookingExtensions(rows *sql.Rows) ([]*domain.BookingExtension, error) {
 	return extensions, nil
 }
 
+// PriceBreakdownToJSON сериализует расчет стоимости для JSONB колонки, nil сохраняется как NULL
+func PriceBreakdownToJSON(breakdown *domain.PriceBreakdown) (interface{}, error) {
+	if breakdown == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(breakdown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal price breakdown: %w", err)
+	}
+	return data, nil
+}
+
+func ParsePriceBreakdown(data []byte) (*domain.PriceBreakdown, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var breakdown domain.PriceBreakdown
+	if err := json.Unmarshal(data, &breakdown); err != nil {
+		return nil, fmt.Errorf("failed to parse price breakdown: %w", err)
+	}
+	return &breakdown, nil
+}
+
 const DoorActionSelectFields = `
 	id, booking_id, user_id, action, success, error, created_at`
 
@@ -118,11 +118,13 @@ func ConvertBookingToResponse(booking *domain.Booking) *domain.BookingResponse {
 		StartDate:          FormatForUser(booking.StartDate),
 		EndDate:            FormatForUser(booking.EndDate),
 		Duration:           booking.Duration,
+		Nights:             booking.Nights,
 		CleaningDuration:   booking.CleaningDuration,
 		Status:             booking.Status,
 		TotalPrice:         booking.TotalPrice,
 		ServiceFee:         booking.ServiceFee,
 		FinalPrice:         booking.FinalPrice,
+		PriceBreakdown:     booking.PriceBreakdown,
 		IsContractAccepted: booking.IsContractAccepted,
 		CancellationReason: booking.CancellationReason,
 		OwnerComment:       booking.OwnerComment,
@@ -152,6 +154,13 @@ func ValidateCreateBookingRequest(request *domain.CreateBookingRequest) error {
 		return fmt.Errorf("некорректный ID квартиры")
 	}
 
+	if request.IsNightly() {
+		if request.CheckInDate == "" || request.CheckOutDate == "" {
+			return fmt.Errorf("необходимо указать даты заезда и выезда")
+		}
+		return nil
+	}
+
 	if request.Duration <= 0 {
 		return fmt.Errorf("продолжительность должна быть больше 0")
 	}
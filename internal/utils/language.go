@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/russo2642/renti_kz/pkg/i18n"
+)
+
+const languageContextKey = "language"
+
+func SetLanguage(c *gin.Context, lang i18n.Language) {
+	c.Set(languageContextKey, lang)
+}
+
+// GetLanguage возвращает язык ответа, выбранный LanguageMiddleware.
+func GetLanguage(c *gin.Context) i18n.Language {
+	if lang, exists := c.Get(languageContextKey); exists {
+		return lang.(i18n.Language)
+	}
+	return i18n.Default
+}
+
+// ErrorMessage возвращает текст ошибки на языке запроса.
+func ErrorMessage(c *gin.Context, err error) string {
+	return i18n.Localize(err, GetLanguage(c))
+}
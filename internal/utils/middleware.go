@@ -96,7 +96,7 @@ func SetCORSHeaders(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	}
 
-	c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+	c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Accept-Language")
 	c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
-	c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type")
+	c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type, Content-Language, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining")
 }
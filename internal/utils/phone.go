@@ -0,0 +1,20 @@
+package utils
+
+import "strings"
+
+// NormalizePhone приводит номер к виду 7XXXXXXXXXX: оставляет только цифры и заменяет ведущую 8 на 7.
+// Используется для ключей ограничений, чтобы "+7 701 ...", "8701..." и "7701..." считались одним номером.
+func NormalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+
+	if len(digits) == 11 && digits[0] == '8' {
+		digits = "7" + digits[1:]
+	}
+
+	return digits
+}
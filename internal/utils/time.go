@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/russo2642/renti_kz/internal/domain"
 )
 
 var KazakhstanTZ = time.FixedZone("Asia/Almaty", 5*60*60)
@@ -184,3 +187,80 @@ func CalculateHourlyPrice(hourlyPrice, duration int) int {
 		return basePrice
 	}
 }
+
+const (
+	StayDateLayout      = "2006-01-02"
+	StayTimeLayout      = "15:04"
+	DefaultCheckInTime  = "14:00"
+	DefaultCheckOutTime = "12:00"
+	MaxStayNights       = 365
+)
+
+// ResolveStayPeriod переводит даты заезда и выезда (местное время) в период проживания
+// по времени заезда/выезда квартиры и проверяет ограничения по количеству ночей.
+func ResolveStayPeriod(apartment *domain.Apartment, checkInDate, checkOutDate string) (*domain.StayPeriod, error) {
+	if !apartment.RentalTypeDaily {
+		return nil, fmt.Errorf("данная квартира не поддерживает посуточную аренду")
+	}
+	if apartment.DailyPrice <= 0 {
+		return nil, fmt.Errorf("для данной квартиры не установлена цена за сутки")
+	}
+
+	inDate, err := time.ParseInLocation(StayDateLayout, checkInDate, KazakhstanTZ)
+	if err != nil {
+		return nil, fmt.Errorf("неверный формат даты заезда: %s (ожидается 2006-01-02)", checkInDate)
+	}
+	outDate, err := time.ParseInLocation(StayDateLayout, checkOutDate, KazakhstanTZ)
+	if err != nil {
+		return nil, fmt.Errorf("неверный формат даты выезда: %s (ожидается 2006-01-02)", checkOutDate)
+	}
+
+	nights := int(outDate.Sub(inDate).Hours() / 24)
+	if nights < 1 {
+		return nil, fmt.Errorf("дата выезда должна быть позже даты заезда")
+	}
+
+	minNights, maxNights := apartment.MinNights, apartment.MaxNights
+	if minNights < 1 {
+		minNights = 1
+	}
+	if nights < minNights {
+		return nil, fmt.Errorf("минимальный срок проживания в этой квартире - %d ноч.", minNights)
+	}
+	if maxNights > 0 && nights > maxNights {
+		return nil, fmt.Errorf("максимальный срок проживания в этой квартире - %d ноч.", maxNights)
+	}
+
+	checkIn, err := WithClockTime(inDate, apartment.CheckInTime, DefaultCheckInTime)
+	if err != nil {
+		return nil, err
+	}
+	checkOut, err := WithClockTime(outDate, apartment.CheckOutTime, DefaultCheckOutTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if !checkOut.After(checkIn) {
+		return nil, fmt.Errorf("время выезда должно быть позже времени заезда")
+	}
+
+	return &domain.StayPeriod{
+		CheckIn:  checkIn.UTC(),
+		CheckOut: checkOut.UTC(),
+		Nights:   nights,
+	}, nil
+}
+
+// WithClockTime возвращает дату в местном времени с указанным временем суток (HH:MM), по умолчанию fallback
+func WithClockTime(date time.Time, clock, fallback string) (time.Time, error) {
+	if clock == "" {
+		clock = fallback
+	}
+
+	parsed, err := time.Parse(StayTimeLayout, clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("некорректное время заезда/выезда квартиры: %s", clock)
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), parsed.Hour(), parsed.Minute(), 0, 0, KazakhstanTZ), nil
+}
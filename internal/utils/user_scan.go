@@ -6,11 +6,11 @@ import (
 
 const UserSelectFields = `
 	u.id, u.phone, u.first_name, u.last_name, u.email, u.city_id, u.iin, 
-	u.role_id, u.is_active, u.password_hash, u.created_at, u.updated_at, r.name`
+	u.role_id, u.is_active, u.preferred_language, u.password_hash, u.created_at, u.updated_at, r.name`
 
 const UserSelectFieldsNoRole = `
 	id, phone, first_name, last_name, email, city_id, iin, 
-	role_id, is_active, password_hash, created_at, updated_at`
+	role_id, is_active, preferred_language, password_hash, created_at, updated_at`
 
 func ScanUser(scanner interface {
 	Scan(dest ...interface{}) error
@@ -19,7 +19,7 @@ func ScanUser(scanner interface {
 
 	err := scanner.Scan(
 		&user.ID, &user.Phone, &user.FirstName, &user.LastName, &user.Email,
-		&user.CityID, &user.IIN, &user.RoleID, &user.IsActive, &user.PasswordHash,
+		&user.CityID, &user.IIN, &user.RoleID, &user.IsActive, &user.PreferredLanguage, &user.PasswordHash,
 		&user.CreatedAt, &user.UpdatedAt, &user.Role,
 	)
 
@@ -37,7 +37,7 @@ func ScanUserNoRole(scanner interface {
 
 	err := scanner.Scan(
 		&user.ID, &user.Phone, &user.FirstName, &user.LastName, &user.Email,
-		&user.CityID, &user.IIN, &user.RoleID, &user.IsActive, &user.PasswordHash,
+		&user.CityID, &user.IIN, &user.RoleID, &user.IsActive, &user.PreferredLanguage, &user.PasswordHash,
 		&user.CreatedAt, &user.UpdatedAt,
 	)
 
@@ -0,0 +1,9 @@
+-- Откат полей для серверной инициализации платежей
+
+DROP INDEX IF EXISTS idx_payments_extension_id;
+DROP INDEX IF EXISTS idx_payments_order_id;
+
+ALTER TABLE payments
+DROP COLUMN IF EXISTS redirect_url,
+DROP COLUMN IF EXISTS extension_id,
+DROP COLUMN IF EXISTS order_id;
@@ -0,0 +1,13 @@
+-- Поля для серверной инициализации платежей через FreedomPay (init_payment.php)
+
+ALTER TABLE payments
+ADD COLUMN order_id VARCHAR(255),
+ADD COLUMN extension_id BIGINT REFERENCES booking_extensions(id) ON DELETE CASCADE,
+ADD COLUMN redirect_url TEXT;
+
+CREATE INDEX idx_payments_order_id ON payments(order_id) WHERE order_id IS NOT NULL;
+CREATE INDEX idx_payments_extension_id ON payments(extension_id) WHERE extension_id IS NOT NULL;
+
+COMMENT ON COLUMN payments.order_id IS 'pg_order_id, переданный в FreedomPay при инициализации платежа';
+COMMENT ON COLUMN payments.extension_id IS 'ID продления, если платеж относится к продлению бронирования';
+COMMENT ON COLUMN payments.redirect_url IS 'URL платежной страницы FreedomPay (pg_redirect_url)';
@@ -0,0 +1,12 @@
+-- Откат политик отмены
+
+DELETE FROM payment_logs WHERE action = 'refund_payment';
+ALTER TABLE payment_logs DROP CONSTRAINT IF EXISTS chk_payment_logs_action;
+ALTER TABLE payment_logs ADD CONSTRAINT chk_payment_logs_action
+CHECK (action IN ('create_payment', 'check_status', 'process_payment', 'webhook_notification'));
+
+DELETE FROM platform_settings WHERE setting_key = 'default_cancellation_policy';
+
+ALTER TABLE apartments DROP COLUMN IF EXISTS cancellation_policy_code;
+
+DROP TABLE IF EXISTS cancellation_policies;
@@ -0,0 +1,52 @@
+-- Машиночитаемые политики отмены: ступени возврата в зависимости от времени до заезда
+CREATE TABLE cancellation_policies (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    tiers JSONB NOT NULL DEFAULT '[]', -- [{"min_hours_before_start": 24, "refund_percentage": 100}, ...]
+    service_fee_refundable BOOLEAN NOT NULL DEFAULT true,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_cancellation_policies_code CHECK (code IN ('flexible', 'moderate', 'strict'))
+);
+
+INSERT INTO cancellation_policies (code, name, description, tiers, service_fee_refundable) VALUES
+(
+    'flexible',
+    'Гибкая',
+    'Полный возврат, включая сервисный сбор, при отмене не позднее чем за 6 часов до заезда.',
+    '[{"min_hours_before_start": 6, "refund_percentage": 100}]',
+    true
+),
+(
+    'moderate',
+    'Умеренная',
+    'Полный возврат стоимости проживания при отмене более чем за 24 часа, 50% — от 6 до 24 часов, менее 6 часов — без возврата. Сервисный сбор не возвращается.',
+    '[{"min_hours_before_start": 24, "refund_percentage": 100}, {"min_hours_before_start": 6, "refund_percentage": 50}]',
+    false
+),
+(
+    'strict',
+    'Строгая',
+    'Полный возврат стоимости проживания при отмене более чем за 72 часа, 50% — от 24 до 72 часов, менее 24 часов — без возврата. Сервисный сбор не возвращается.',
+    '[{"min_hours_before_start": 72, "refund_percentage": 100}, {"min_hours_before_start": 24, "refund_percentage": 50}]',
+    false
+);
+
+-- Политика квартиры; NULL - используется политика платформы по умолчанию
+ALTER TABLE apartments ADD COLUMN cancellation_policy_code VARCHAR(20) REFERENCES cancellation_policies(code) ON UPDATE CASCADE;
+
+COMMENT ON COLUMN apartments.cancellation_policy_code IS 'Политика отмены квартиры (flexible, moderate, strict), NULL - политика платформы';
+
+-- Политика платформы по умолчанию совпадает с прежним правилом (без возврата менее чем за 6 часов)
+INSERT INTO platform_settings (setting_key, setting_value, description, data_type, is_active) VALUES
+('default_cancellation_policy', 'flexible', 'Политика отмены по умолчанию для квартир без собственной политики (flexible, moderate, strict)', 'string', true)
+ON CONFLICT (setting_key) DO NOTHING;
+
+-- Логи частичных и полных возвратов пишутся с action = refund_payment
+ALTER TABLE payment_logs DROP CONSTRAINT IF EXISTS chk_payment_logs_action;
+ALTER TABLE payment_logs ADD CONSTRAINT chk_payment_logs_action
+CHECK (action IN ('create_payment', 'check_status', 'process_payment', 'webhook_notification', 'refund_payment'));
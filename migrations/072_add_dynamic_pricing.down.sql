@@ -0,0 +1,7 @@
+-- Откат динамического ценообразования
+
+ALTER TABLE booking_extensions DROP COLUMN IF EXISTS price_breakdown;
+ALTER TABLE bookings DROP COLUMN IF EXISTS price_breakdown;
+
+DROP TABLE IF EXISTS public_holidays;
+DROP TABLE IF EXISTS apartment_pricing_rules;
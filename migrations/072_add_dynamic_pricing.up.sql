@@ -0,0 +1,67 @@
+-- Правила динамического ценообразования квартир
+CREATE TABLE apartment_pricing_rules (
+    id SERIAL PRIMARY KEY,
+    apartment_id INTEGER NOT NULL REFERENCES apartments(id) ON DELETE CASCADE,
+    rule_type VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    start_date DATE,                  -- date_range: первый день периода
+    end_date DATE,                    -- date_range: последний день периода (включительно)
+    weekdays SMALLINT[],              -- weekday: дни недели (0 - воскресенье ... 6 - суббота)
+    min_days INTEGER,                 -- length_of_stay: минимальное количество суток
+    hourly_price INTEGER,             -- date_range: почасовая цена на период
+    daily_price INTEGER,              -- date_range: цена за сутки на период
+    adjustment_percentage INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_pricing_rules_type CHECK (rule_type IN ('date_range', 'weekday', 'holiday', 'length_of_stay')),
+    CONSTRAINT chk_pricing_rules_dates CHECK (rule_type <> 'date_range' OR (start_date IS NOT NULL AND end_date IS NOT NULL AND end_date >= start_date)),
+    CONSTRAINT chk_pricing_rules_weekdays CHECK (rule_type <> 'weekday' OR cardinality(weekdays) > 0),
+    CONSTRAINT chk_pricing_rules_min_days CHECK (rule_type <> 'length_of_stay' OR min_days >= 2),
+    CONSTRAINT chk_pricing_rules_prices CHECK ((hourly_price IS NULL OR hourly_price > 0) AND (daily_price IS NULL OR daily_price > 0)),
+    CONSTRAINT chk_pricing_rules_percentage CHECK (adjustment_percentage BETWEEN -90 AND 300)
+);
+
+CREATE INDEX idx_apartment_pricing_rules_apartment ON apartment_pricing_rules(apartment_id) WHERE is_active = true;
+
+-- Государственные праздники Республики Казахстан
+CREATE TABLE public_holidays (
+    id SERIAL PRIMARY KEY,
+    holiday_date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    country_code CHAR(2) NOT NULL DEFAULT 'KZ',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_public_holidays_date UNIQUE (country_code, holiday_date)
+);
+
+-- Праздники с фиксированной датой на 2025-2030 годы
+INSERT INTO public_holidays (holiday_date, name)
+SELECT make_date(y, h.month, h.day), h.name
+FROM generate_series(2025, 2030) AS y
+CROSS JOIN (VALUES
+    (1, 1, 'Новый год'),
+    (1, 2, 'Новый год'),
+    (1, 7, 'Православное Рождество'),
+    (3, 8, 'Международный женский день'),
+    (3, 21, 'Наурыз мейрамы'),
+    (3, 22, 'Наурыз мейрамы'),
+    (3, 23, 'Наурыз мейрамы'),
+    (5, 1, 'Праздник единства народа Казахстана'),
+    (5, 7, 'День защитника Отечества'),
+    (5, 9, 'День Победы'),
+    (7, 6, 'День Столицы'),
+    (8, 30, 'День Конституции'),
+    (10, 25, 'День Республики'),
+    (12, 16, 'День Независимости')
+) AS h(month, day, name);
+
+-- Курбан айт определяется по лунному календарю
+INSERT INTO public_holidays (holiday_date, name) VALUES
+('2025-06-06', 'Курбан айт'),
+('2026-05-27', 'Курбан айт');
+
+-- Расчет стоимости, сохраняемый вместе с бронированием и продлением
+ALTER TABLE bookings ADD COLUMN price_breakdown JSONB;
+ALTER TABLE booking_extensions ADD COLUMN price_breakdown JSONB;
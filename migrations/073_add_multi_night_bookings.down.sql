@@ -0,0 +1,11 @@
+-- Откат посуточных бронирований на несколько ночей
+
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS chk_bookings_nights;
+ALTER TABLE bookings DROP COLUMN IF EXISTS nights;
+
+ALTER TABLE apartments DROP CONSTRAINT IF EXISTS chk_apartments_nights;
+ALTER TABLE apartments
+    DROP COLUMN IF EXISTS max_nights,
+    DROP COLUMN IF EXISTS min_nights,
+    DROP COLUMN IF EXISTS check_out_time,
+    DROP COLUMN IF EXISTS check_in_time;
@@ -0,0 +1,14 @@
+-- Параметры посуточного проживания квартиры
+ALTER TABLE apartments
+    ADD COLUMN check_in_time TIME NOT NULL DEFAULT '14:00',
+    ADD COLUMN check_out_time TIME NOT NULL DEFAULT '12:00',
+    ADD COLUMN min_nights INTEGER NOT NULL DEFAULT 1,
+    ADD COLUMN max_nights INTEGER NOT NULL DEFAULT 30;
+
+ALTER TABLE apartments ADD CONSTRAINT chk_apartments_nights
+CHECK (min_nights >= 1 AND max_nights >= min_nights);
+
+-- Количество ночей для посуточных бронирований (0 - почасовое бронирование или суточный слот)
+ALTER TABLE bookings ADD COLUMN nights INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bookings ADD CONSTRAINT chk_bookings_nights CHECK (nights >= 0);
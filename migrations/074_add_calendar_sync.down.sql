@@ -0,0 +1,7 @@
+-- Откат синхронизации календарей
+
+DROP TABLE IF EXISTS apartment_external_events;
+DROP TABLE IF EXISTS apartment_external_calendars;
+
+DROP INDEX IF EXISTS idx_apartments_ical_export_token;
+ALTER TABLE apartments DROP COLUMN IF EXISTS ical_export_token;
@@ -0,0 +1,42 @@
+-- Секретный токен публичного iCal-фида квартиры
+ALTER TABLE apartments ADD COLUMN ical_export_token VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_apartments_ical_export_token ON apartments(ical_export_token) WHERE ical_export_token IS NOT NULL;
+
+-- Внешние iCal-календари квартиры (другие площадки), периодически импортируемые как занятые периоды
+CREATE TABLE apartment_external_calendars (
+    id SERIAL PRIMARY KEY,
+    apartment_id INTEGER NOT NULL REFERENCES apartments(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    url TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    last_sync_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    last_sync_error TEXT,
+    events_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_apartment_external_calendars_url UNIQUE (apartment_id, url),
+    CONSTRAINT chk_external_calendars_status CHECK (last_sync_status IN ('pending', 'success', 'failed'))
+);
+
+CREATE INDEX idx_apartment_external_calendars_apartment ON apartment_external_calendars(apartment_id);
+
+-- Занятые периоды, импортированные из внешних календарей
+CREATE TABLE apartment_external_events (
+    id SERIAL PRIMARY KEY,
+    calendar_id INTEGER NOT NULL REFERENCES apartment_external_calendars(id) ON DELETE CASCADE,
+    apartment_id INTEGER NOT NULL REFERENCES apartments(id) ON DELETE CASCADE,
+    uid VARCHAR(512) NOT NULL,
+    summary VARCHAR(512) NOT NULL DEFAULT '',
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_apartment_external_events_uid UNIQUE (calendar_id, uid),
+    CONSTRAINT chk_external_events_dates CHECK (end_date > start_date)
+);
+
+-- Проверка пересечений при бронировании и пересчете is_free
+CREATE INDEX idx_apartment_external_events_period ON apartment_external_events(apartment_id, start_date, end_date);
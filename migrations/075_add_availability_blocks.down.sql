@@ -0,0 +1,3 @@
+-- Откат блокировок доступности
+
+DROP TABLE IF EXISTS availability_blocks;
@@ -0,0 +1,18 @@
+-- Периоды, закрытые владельцем для бронирования (ремонт, уборка, личное использование)
+CREATE TABLE availability_blocks (
+    id SERIAL PRIMARY KEY,
+    apartment_id INTEGER NOT NULL REFERENCES apartments(id) ON DELETE CASCADE,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_availability_blocks_reason CHECK (reason IN ('repair', 'cleaning', 'personal')),
+    CONSTRAINT chk_availability_blocks_dates CHECK (end_date > start_date)
+);
+
+-- Проверка пересечений при бронировании и пересчете is_free
+CREATE INDEX idx_availability_blocks_period ON availability_blocks(apartment_id, start_date, end_date);
@@ -0,0 +1,16 @@
+-- Откат отзывов и рейтингов
+
+DELETE FROM platform_settings WHERE setting_key = 'review_window_days';
+
+DROP INDEX IF EXISTS idx_apartments_rating;
+
+ALTER TABLE apartments
+    DROP COLUMN IF EXISTS rating,
+    DROP COLUMN IF EXISTS reviews_count,
+    DROP COLUMN IF EXISTS rating_cleanliness,
+    DROP COLUMN IF EXISTS rating_accuracy,
+    DROP COLUMN IF EXISTS rating_location,
+    DROP COLUMN IF EXISTS rating_check_in;
+
+DROP TABLE IF EXISTS renter_reviews;
+DROP TABLE IF EXISTS apartment_reviews;
@@ -0,0 +1,67 @@
+-- Отзывы арендаторов о квартирах по завершенным бронированиям
+CREATE TABLE apartment_reviews (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    apartment_id INTEGER NOT NULL REFERENCES apartments(id) ON DELETE CASCADE,
+    renter_id INTEGER NOT NULL REFERENCES renters(id) ON DELETE CASCADE,
+    cleanliness_rating SMALLINT NOT NULL,
+    accuracy_rating SMALLINT NOT NULL,
+    location_rating SMALLINT NOT NULL,
+    check_in_rating SMALLINT NOT NULL,
+    rating NUMERIC(3, 2) NOT NULL, -- среднее по категориям
+    comment TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    moderator_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    moderator_comment TEXT,
+    moderated_at TIMESTAMP WITH TIME ZONE,
+    owner_reply TEXT,
+    owner_replied_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_apartment_reviews_ratings CHECK (
+        cleanliness_rating BETWEEN 1 AND 5 AND accuracy_rating BETWEEN 1 AND 5 AND
+        location_rating BETWEEN 1 AND 5 AND check_in_rating BETWEEN 1 AND 5
+    ),
+    CONSTRAINT chk_apartment_reviews_status CHECK (status IN ('pending', 'published', 'rejected'))
+);
+
+CREATE INDEX idx_apartment_reviews_apartment ON apartment_reviews(apartment_id, status, created_at DESC);
+CREATE INDEX idx_apartment_reviews_status ON apartment_reviews(status, created_at);
+
+-- Отзывы владельцев об арендаторах
+CREATE TABLE renter_reviews (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    renter_id INTEGER NOT NULL REFERENCES renters(id) ON DELETE CASCADE,
+    owner_id INTEGER NOT NULL REFERENCES property_owners(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    moderator_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    moderator_comment TEXT,
+    moderated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_renter_reviews_rating CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT chk_renter_reviews_status CHECK (status IN ('pending', 'published', 'rejected'))
+);
+
+CREATE INDEX idx_renter_reviews_renter ON renter_reviews(renter_id, status, created_at DESC);
+CREATE INDEX idx_renter_reviews_status ON renter_reviews(status, created_at);
+
+-- Агрегированный рейтинг квартиры по опубликованным отзывам
+ALTER TABLE apartments
+    ADD COLUMN rating NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN reviews_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN rating_cleanliness NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_accuracy NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_location NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_check_in NUMERIC(3, 2) NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_apartments_rating ON apartments(rating DESC, reviews_count DESC);
+
+INSERT INTO platform_settings (setting_key, setting_value, description, data_type, is_active) VALUES
+('review_window_days', '14', 'Количество дней после выезда, в течение которых можно оставить отзыв', 'integer', true)
+ON CONFLICT (setting_key) DO NOTHING;
@@ -0,0 +1,12 @@
+-- Откат полнотекстового поиска по квартирам
+
+DROP INDEX IF EXISTS idx_apartments_price;
+DROP INDEX IF EXISTS idx_apartment_house_rules_rule_apartment;
+DROP INDEX IF EXISTS idx_apartment_amenities_amenity_apartment;
+DROP INDEX IF EXISTS idx_apartments_residential_complex_trgm;
+DROP INDEX IF EXISTS idx_apartments_street_trgm;
+DROP INDEX IF EXISTS idx_apartments_search_vector;
+
+ALTER TABLE apartments DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS kazakh;
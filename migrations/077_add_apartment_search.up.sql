@@ -0,0 +1,32 @@
+-- Полнотекстовый и нечеткий поиск по квартирам
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Для казахского языка в PostgreSQL нет стеммера: используем конфигурацию без морфологии
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'kazakh') THEN
+        CREATE TEXT SEARCH CONFIGURATION kazakh (COPY = pg_catalog.simple);
+    END IF;
+END
+$$;
+
+-- Адрес и ЖК весят больше описания
+ALTER TABLE apartments ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian'::regconfig, COALESCE(street, '') || ' ' || COALESCE(residential_complex, '')), 'A') ||
+    setweight(to_tsvector('kazakh'::regconfig, COALESCE(street, '') || ' ' || COALESCE(residential_complex, '')), 'A') ||
+    setweight(to_tsvector('russian'::regconfig, COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('kazakh'::regconfig, COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_apartments_search_vector ON apartments USING GIN (search_vector);
+
+-- Триграммы для поиска с опечатками по улице и названию ЖК
+CREATE INDEX idx_apartments_street_trgm ON apartments USING GIN (street gin_trgm_ops);
+CREATE INDEX idx_apartments_residential_complex_trgm ON apartments USING GIN (residential_complex gin_trgm_ops);
+
+-- Фильтр по набору удобств и правил проживания
+CREATE INDEX IF NOT EXISTS idx_apartment_amenities_amenity_apartment ON apartment_amenities(amenity_id, apartment_id);
+CREATE INDEX IF NOT EXISTS idx_apartment_house_rules_rule_apartment ON apartment_house_rules(house_rule_id, apartment_id);
+
+-- Сортировка по цене
+CREATE INDEX IF NOT EXISTS idx_apartments_price ON apartments(price);
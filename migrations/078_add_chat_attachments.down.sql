@@ -0,0 +1,9 @@
+-- Откат вложений сообщений чата
+
+ALTER TABLE chat_messages DROP CONSTRAINT IF EXISTS check_attachment_metadata;
+
+ALTER TABLE chat_messages
+    DROP COLUMN IF EXISTS image_height,
+    DROP COLUMN IF EXISTS image_width,
+    DROP COLUMN IF EXISTS thumbnail_url,
+    DROP COLUMN IF EXISTS mime_type;
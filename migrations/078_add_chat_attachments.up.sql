@@ -0,0 +1,12 @@
+-- Метаданные вложений сообщений чата (фото и файлы)
+ALTER TABLE chat_messages
+    ADD COLUMN mime_type VARCHAR(100),
+    ADD COLUMN thumbnail_url VARCHAR(500),
+    ADD COLUMN image_width INTEGER,
+    ADD COLUMN image_height INTEGER;
+
+-- Для вложений обязательны тип содержимого и размер
+ALTER TABLE chat_messages
+    ADD CONSTRAINT check_attachment_metadata CHECK (
+        type NOT IN ('image', 'file') OR (mime_type IS NOT NULL AND file_size IS NOT NULL AND file_size > 0)
+    );
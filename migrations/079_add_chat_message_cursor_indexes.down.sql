@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_chat_messages_room_read_at;
+DROP INDEX IF EXISTS idx_chat_messages_room_id_id;
@@ -0,0 +1,5 @@
+-- Индексы для курсорной пагинации истории чата и выборки отметок о прочтении при переподключении
+CREATE INDEX IF NOT EXISTS idx_chat_messages_room_id_id ON chat_messages(chat_room_id, id);
+
+CREATE INDEX IF NOT EXISTS idx_chat_messages_room_read_at ON chat_messages(chat_room_id, read_at)
+    WHERE status = 'read';
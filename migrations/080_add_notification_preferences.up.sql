@@ -0,0 +1,17 @@
+-- Настройки уведомлений пользователя: отключенные типы, тихие часы и каналы доставки
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    muted_types TEXT[] NOT NULL DEFAULT '{}',
+    quiet_hours_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    quiet_hours_start VARCHAR(5) NOT NULL DEFAULT '22:00',
+    quiet_hours_end VARCHAR(5) NOT NULL DEFAULT '08:00',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Almaty',
+    channels TEXT[] NOT NULL DEFAULT '{push,in_app}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT check_quiet_hours_format CHECK (
+        quiet_hours_start ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$' AND
+        quiet_hours_end ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'
+    )
+);
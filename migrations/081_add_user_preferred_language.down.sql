@@ -0,0 +1,3 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS check_users_preferred_language;
+
+ALTER TABLE users DROP COLUMN IF EXISTS preferred_language;
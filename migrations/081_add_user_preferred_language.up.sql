@@ -0,0 +1,5 @@
+-- Язык пользователя для уведомлений, писем и SMS
+ALTER TABLE users ADD COLUMN IF NOT EXISTS preferred_language VARCHAR(2) NOT NULL DEFAULT 'ru';
+
+ALTER TABLE users ADD CONSTRAINT check_users_preferred_language
+    CHECK (preferred_language IN ('ru', 'kk', 'en'));
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS lock_code_reveals;
+
+-- Значения остаются зашифрованными: шифротекст коротких паролей помещается в VARCHAR(255)
+ALTER TABLE lock_temp_passwords ALTER COLUMN password TYPE VARCHAR(255);
+ALTER TABLE locks ALTER COLUMN owner_password TYPE VARCHAR(255);
+
+COMMENT ON COLUMN locks.owner_password IS 'Постоянный пароль владельца';
@@ -0,0 +1,22 @@
+-- Пароли замков хранятся зашифрованными (конвертное шифрование), шифротекст длиннее исходного пароля
+ALTER TABLE locks ALTER COLUMN owner_password TYPE TEXT;
+ALTER TABLE lock_temp_passwords ALTER COLUMN password TYPE TEXT;
+
+COMMENT ON COLUMN locks.owner_password IS 'Постоянный пароль владельца (зашифрован)';
+COMMENT ON COLUMN lock_temp_passwords.password IS 'Временный пароль (зашифрован)';
+
+-- Журнал раскрытия паролей замков: каждая выдача пароля в открытом виде
+CREATE TABLE IF NOT EXISTS lock_code_reveals (
+    id SERIAL PRIMARY KEY,
+    lock_id INTEGER NOT NULL REFERENCES locks(id) ON DELETE CASCADE,
+    temp_password_id INTEGER REFERENCES lock_temp_passwords(id) ON DELETE SET NULL,
+    code_type VARCHAR(20) NOT NULL CHECK (code_type IN ('owner', 'temp')),
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    booking_id INTEGER REFERENCES bookings(id) ON DELETE SET NULL,
+    reason VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_lock_code_reveals_lock_created ON lock_code_reveals(lock_id, created_at DESC);
@@ -0,0 +1,7 @@
+DELETE FROM lock_status_logs WHERE change_source = 'device';
+
+ALTER TABLE lock_status_logs DROP CONSTRAINT lock_status_logs_change_source_check;
+ALTER TABLE lock_status_logs ADD CONSTRAINT lock_status_logs_change_source_check
+CHECK (change_source IN ('api', 'manual', 'system', 'tuya', 'webhook'));
+
+DROP TABLE IF EXISTS lock_device_credentials;
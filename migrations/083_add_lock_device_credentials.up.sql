@@ -0,0 +1,15 @@
+-- Секреты устройств замков для подписи запросов к /device/locks (хранятся зашифрованными)
+CREATE TABLE IF NOT EXISTS lock_device_credentials (
+    id SERIAL PRIMARY KEY,
+    lock_id INTEGER NOT NULL UNIQUE REFERENCES locks(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    previous_secret TEXT, -- принимается до previous_expires_at после ротации
+    previous_expires_at TIMESTAMP,
+    rotated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Отклоненные запросы устройств пишутся в журнал статусов с источником device
+ALTER TABLE lock_status_logs DROP CONSTRAINT lock_status_logs_change_source_check;
+ALTER TABLE lock_status_logs ADD CONSTRAINT lock_status_logs_change_source_check
+CHECK (change_source IN ('api', 'manual', 'system', 'tuya', 'webhook', 'device'));
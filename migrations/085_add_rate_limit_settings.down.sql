@@ -0,0 +1,12 @@
+DELETE FROM platform_settings WHERE setting_key IN (
+    'rate_limit_otp_request_phone',
+    'rate_limit_otp_request_ip',
+    'rate_limit_otp_verify_ip',
+    'rate_limit_login_ip',
+    'rate_limit_check_phone_ip',
+    'rate_limit_booking_create_user',
+    'rate_limit_refresh_token_ip',
+    'auth_lockout_threshold',
+    'auth_lockout_base_minutes',
+    'auth_lockout_max_minutes'
+);
@@ -0,0 +1,14 @@
+-- Лимиты частоты запросов (<запросов>/<период>) и прогрессивная блокировка перебора для входа и OTP.
+-- Значения перечитываются сервером раз в минуту, менять можно без перезапуска
+INSERT INTO platform_settings (setting_key, setting_value, description, data_type, is_active) VALUES
+('rate_limit_otp_request_phone', '3/10m', 'Запросы OTP на один номер телефона', 'string', true),
+('rate_limit_otp_request_ip', '10/1h', 'Запросы OTP с одного IP-адреса', 'string', true),
+('rate_limit_otp_verify_ip', '20/10m', 'Проверки OTP кода с одного IP-адреса', 'string', true),
+('rate_limit_login_ip', '20/10m', 'Попытки входа с одного IP-адреса', 'string', true),
+('rate_limit_check_phone_ip', '30/1m', 'Проверки существования телефона с одного IP-адреса', 'string', true),
+('rate_limit_booking_create_user', '10/1h', 'Создание бронирований одним пользователем', 'string', true),
+('rate_limit_refresh_token_ip', '60/10m', 'Обновления токенов с одного IP-адреса', 'string', true),
+('auth_lockout_threshold', '5', 'Количество неудачных попыток входа или проверки OTP подряд до блокировки', 'integer', true),
+('auth_lockout_base_minutes', '1', 'Длительность первой блокировки в минутах, каждая следующая в течение суток вдвое длиннее', 'integer', true),
+('auth_lockout_max_minutes', '60', 'Максимальная длительность блокировки в минутах', 'integer', true)
+ON CONFLICT (setting_key) DO NOTHING;
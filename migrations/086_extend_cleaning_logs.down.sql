@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_cleaning_logs_apartment_started_at;
+DROP INDEX IF EXISTS idx_cleaning_logs_cleaner_started_at;
+DROP INDEX IF EXISTS idx_cleaning_logs_apartment_in_progress;
+
+ALTER TABLE cleaning_logs DROP COLUMN IF EXISTS cancel_reason;
+ALTER TABLE cleaning_logs DROP COLUMN IF EXISTS cancelled_at;
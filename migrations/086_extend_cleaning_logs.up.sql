@@ -0,0 +1,14 @@
+-- Отмена уборки: время и причина
+ALTER TABLE cleaning_logs ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ NULL;
+ALTER TABLE cleaning_logs ADD COLUMN IF NOT EXISTS cancel_reason TEXT;
+
+-- Одновременно в квартире может идти только одна уборка
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cleaning_logs_apartment_in_progress
+    ON cleaning_logs(apartment_id) WHERE status = 'in_progress';
+
+-- История уборок уборщицы и квартиры с сортировкой по времени начала
+CREATE INDEX IF NOT EXISTS idx_cleaning_logs_cleaner_started_at ON cleaning_logs(cleaner_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_cleaning_logs_apartment_started_at ON cleaning_logs(apartment_id, started_at DESC);
+
+COMMENT ON COLUMN cleaning_logs.cancelled_at IS 'Время отмены уборки';
+COMMENT ON COLUMN cleaning_logs.cancel_reason IS 'Причина отмены уборки';
@@ -0,0 +1,3 @@
+DELETE FROM platform_settings WHERE setting_key = 'cleaning_offer_timeout_minutes';
+
+DROP TABLE IF EXISTS cleaning_tasks;
@@ -0,0 +1,54 @@
+-- Заявки на уборку, создаваемые автоматически при выезде гостя
+CREATE TABLE cleaning_tasks (
+    id BIGSERIAL PRIMARY KEY,
+    apartment_id INTEGER NOT NULL REFERENCES apartments(id) ON DELETE CASCADE,
+    booking_id INTEGER REFERENCES bookings(id) ON DELETE SET NULL,
+    next_booking_id INTEGER REFERENCES bookings(id) ON DELETE SET NULL,
+    cleaner_id INTEGER REFERENCES cleaners(id) ON DELETE SET NULL,
+    cleaning_log_id BIGINT REFERENCES cleaning_logs(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'offered', 'accepted', 'in_progress', 'completed', 'escalated', 'cancelled')),
+    checkout_at TIMESTAMPTZ NOT NULL,
+    cleaning_duration INTEGER NOT NULL DEFAULT 60,
+    deadline_at TIMESTAMPTZ NOT NULL,
+    offer_attempt INTEGER NOT NULL DEFAULT 0,
+    offered_at TIMESTAMPTZ NULL,
+    offer_expires_at TIMESTAMPTZ NULL,
+    declined_cleaner_ids INTEGER[] NOT NULL DEFAULT '{}',
+    accepted_at TIMESTAMPTZ NULL,
+    started_at TIMESTAMPTZ NULL,
+    completed_at TIMESTAMPTZ NULL,
+    escalated_at TIMESTAMPTZ NULL,
+    escalation_reason TEXT,
+    is_late BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Одна заявка на выезд и одна незакрытая заявка на квартиру
+CREATE UNIQUE INDEX idx_cleaning_tasks_booking_id ON cleaning_tasks(booking_id) WHERE booking_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_cleaning_tasks_apartment_open
+    ON cleaning_tasks(apartment_id) WHERE status IN ('pending', 'offered', 'accepted', 'in_progress', 'escalated');
+
+CREATE INDEX idx_cleaning_tasks_cleaner_status ON cleaning_tasks(cleaner_id, status);
+CREATE INDEX idx_cleaning_tasks_status_deadline ON cleaning_tasks(status, deadline_at);
+
+CREATE TRIGGER update_cleaning_tasks_updated_at
+    BEFORE UPDATE ON cleaning_tasks
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE cleaning_tasks IS 'Заявки на уборку после выезда гостя';
+COMMENT ON COLUMN cleaning_tasks.booking_id IS 'Бронирование, после которого нужна уборка';
+COMMENT ON COLUMN cleaning_tasks.next_booking_id IS 'Следующее бронирование квартиры, к началу которого уборка должна быть завершена';
+COMMENT ON COLUMN cleaning_tasks.cleaner_id IS 'Уборщица, которой предложена или назначена заявка';
+COMMENT ON COLUMN cleaning_tasks.cleaning_log_id IS 'Запись журнала уборки, начатой по заявке';
+COMMENT ON COLUMN cleaning_tasks.cleaning_duration IS 'Время на уборку из бронирования в минутах';
+COMMENT ON COLUMN cleaning_tasks.deadline_at IS 'Срок завершения уборки: выезд + время уборки, но не позже начала следующего бронирования';
+COMMENT ON COLUMN cleaning_tasks.offer_attempt IS 'Номер текущего предложения заявки уборщице';
+COMMENT ON COLUMN cleaning_tasks.declined_cleaner_ids IS 'Уборщицы, отказавшиеся от заявки или не ответившие вовремя';
+COMMENT ON COLUMN cleaning_tasks.is_late IS 'Уборка не завершена к сроку';
+
+INSERT INTO platform_settings (setting_key, setting_value, description, data_type, is_active) VALUES
+('cleaning_offer_timeout_minutes', '15', 'Сколько минут уборщица может принять заявку на уборку, прежде чем она уйдет следующей уборщице', 'integer', true)
+ON CONFLICT (setting_key) DO NOTHING;
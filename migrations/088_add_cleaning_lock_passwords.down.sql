@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_lock_temp_passwords_cleaning_log_id;
+
+ALTER TABLE lock_temp_passwords DROP COLUMN IF EXISTS cleaning_log_id;
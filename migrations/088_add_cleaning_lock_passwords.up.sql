@@ -0,0 +1,6 @@
+-- Временные пароли замка для уборки: выдаются уборщице на время уборки вместо постоянного пароля владельца
+ALTER TABLE lock_temp_passwords ADD COLUMN IF NOT EXISTS cleaning_log_id BIGINT REFERENCES cleaning_logs(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_lock_temp_passwords_cleaning_log_id ON lock_temp_passwords(cleaning_log_id) WHERE cleaning_log_id IS NOT NULL;
+
+COMMENT ON COLUMN lock_temp_passwords.cleaning_log_id IS 'Уборка, на время которой выдан пароль';
@@ -0,0 +1,4 @@
+DELETE FROM platform_settings WHERE setting_key IN (
+    'rate_limit_otp_verify_phone',
+    'rate_limit_login_phone'
+);
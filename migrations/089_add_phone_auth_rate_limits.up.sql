@@ -0,0 +1,6 @@
+-- Лимиты попыток входа и проверки OTP на один номер телефона: блокировка перебора действует
+-- для пары телефон + IP, поэтому перебор одного номера с разных адресов ограничивается отдельно
+INSERT INTO platform_settings (setting_key, setting_value, description, data_type, is_active) VALUES
+('rate_limit_otp_verify_phone', '10/10m', 'Проверки OTP кода для одного номера телефона', 'string', true),
+('rate_limit_login_phone', '10/30m', 'Попытки входа для одного номера телефона', 'string', true)
+ON CONFLICT (setting_key) DO NOTHING;
@@ -6,17 +6,23 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type TokenClaims struct {
 	UserID int    `json:"user_id"`
 	Role   string `json:"role"`
+	// SessionID идентификатор серверной сессии (claim sid). Пустой у токенов, выданных до появления сессий.
+	SessionID string `json:"sid,omitempty"`
+	// TokenID уникальный идентификатор refresh-токена (claim jti), чтобы два токена одной сессии никогда не совпадали.
+	TokenID string `json:"jti,omitempty"`
 }
 
 type TokenManager interface {
-	GenerateTokenPair(userID int, role string) (string, string, error)
+	GenerateTokenPair(userID int, role, sessionID string) (string, string, error)
 	ParseAccessToken(accessToken string) (*TokenClaims, error)
 	ParseRefreshToken(refreshToken string) (*TokenClaims, error)
+	GetAccessTokenTTL() time.Duration
 	GetRefreshTokenTTL() time.Duration
 }
 
@@ -36,10 +42,11 @@ func NewJWTManager(accessSecret, refreshSecret string, accessTTL, refreshTTL tim
 	}
 }
 
-func (m *JWTManager) GenerateTokenPair(userID int, role string) (string, string, error) {
+func (m *JWTManager) GenerateTokenPair(userID int, role, sessionID string) (string, string, error) {
 	accessClaims := jwt.MapClaims{
 		"user_id": userID,
 		"role":    role,
+		"sid":     sessionID,
 		"exp":     time.Now().Add(m.accessTTL).Unix(),
 	}
 
@@ -52,6 +59,8 @@ func (m *JWTManager) GenerateTokenPair(userID int, role string) (string, string,
 	refreshClaims := jwt.MapClaims{
 		"user_id": userID,
 		"role":    role,
+		"sid":     sessionID,
+		"jti":     uuid.NewString(),
 		"exp":     time.Now().Add(m.refreshTTL).Unix(),
 	}
 
@@ -72,6 +81,10 @@ func (m *JWTManager) ParseRefreshToken(refreshToken string) (*TokenClaims, error
 	return m.parseToken(refreshToken, m.refreshSecret)
 }
 
+func (m *JWTManager) GetAccessTokenTTL() time.Duration {
+	return m.accessTTL
+}
+
 func (m *JWTManager) GetRefreshTokenTTL() time.Duration {
 	return m.refreshTTL
 }
@@ -108,8 +121,13 @@ func (m *JWTManager) parseToken(token, secret string) (*TokenClaims, error) {
 		return nil, errors.New("invalid role claim")
 	}
 
+	sessionID, _ := claims["sid"].(string)
+	tokenID, _ := claims["jti"].(string)
+
 	return &TokenClaims{
-		UserID: userID,
-		Role:   role,
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		TokenID:   tokenID,
 	}, nil
 }
@@ -0,0 +1,250 @@
+// Package envelope реализует конвертное шифрование коротких секретов (кодов замков и т.п.).
+// Каждое значение шифруется собственным ключом данных (DEK, AES-256-GCM), а DEK - мастер-ключом (KEK)
+// из конфигурации. В шифротексте хранится идентификатор мастер-ключа, поэтому при ротации старые
+// значения продолжают расшифровываться, а Rotate перешифровывает только DEK новым ключом.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	prefix  = "env1"
+	keySize = 32
+)
+
+var (
+	ErrUnknownKey       = errors.New("мастер-ключ шифротекста не найден")
+	ErrMalformed        = errors.New("некорректный формат шифротекста")
+	ErrNoActiveKey      = errors.New("активный мастер-ключ не задан")
+	ErrInvalidKeyLength = errors.New("мастер-ключ должен быть длиной 32 байта")
+)
+
+var encoding = base64.RawStdEncoding
+
+// Keyring набор мастер-ключей: активным ключом шифруются новые значения, остальные нужны для расшифровки.
+type Keyring struct {
+	keys     map[string][]byte
+	activeID string
+}
+
+// NewKeyring создает набор ключей. activeID должен присутствовать в keys.
+func NewKeyring(keys map[string][]byte, activeID string) (*Keyring, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, ErrNoActiveKey
+	}
+
+	ring := &Keyring{keys: make(map[string][]byte, len(keys)), activeID: activeID}
+	for id, key := range keys {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("%w: ключ %q", ErrInvalidKeyLength, id)
+		}
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("некорректный идентификатор мастер-ключа %q", id)
+		}
+		ring.keys[id] = key
+	}
+
+	return ring, nil
+}
+
+// ParseKeys разбирает список ключей вида "2:<base64>,1:<base64>".
+func ParseKeys(spec string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		id, encoded, found := strings.Cut(item, ":")
+		if !found {
+			return nil, fmt.Errorf("ключ %q должен быть в формате <id>:<base64>", item)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("ошибка декодирования ключа %q: %w", id, err)
+		}
+		keys[strings.TrimSpace(id)] = key
+	}
+
+	return keys, nil
+}
+
+// ActiveKeyID идентификатор ключа, которым шифруются новые значения.
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// ActivePrefix префикс шифротекстов активного ключа. Позволяет отобрать записи для ротации
+// запросом к БД, не расшифровывая их.
+func (k *Keyring) ActivePrefix() string {
+	return prefix + ":" + k.activeID + ":"
+}
+
+// IsEncrypted сообщает, что значение сохранено в формате пакета (а не открытым текстом).
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix+":")
+}
+
+// Encrypt шифрует значение активным мастер-ключом. Пустая строка остается пустой.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", fmt.Errorf("ошибка генерации ключа данных: %w", err)
+	}
+
+	payload, err := seal(dek, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return k.wrap(dek, payload)
+}
+
+// Decrypt расшифровывает значение. Значения, сохраненные до включения шифрования открытым текстом,
+// возвращаются как есть - их перешифровывает Rotate.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	dek, payload, err := k.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dek, payload, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// NeedsRotation сообщает, что значение хранится открытым текстом или зашифровано не активным ключом.
+func (k *Keyring) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+
+	return !strings.HasPrefix(value, k.ActivePrefix())
+}
+
+// Rotate приводит значение к активному ключу: открытый текст шифруется, у шифротекста
+// перешифровывается только ключ данных, сами данные не трогаются.
+func (k *Keyring) Rotate(value string) (string, error) {
+	if !k.NeedsRotation(value) {
+		return value, nil
+	}
+	if !IsEncrypted(value) {
+		return k.Encrypt(value)
+	}
+
+	dek, payload, err := k.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+
+	return k.wrap(dek, payload)
+}
+
+// wrap шифрует DEK активным ключом. Идентификатор ключа входит в AAD, чтобы его нельзя было подменить.
+func (k *Keyring) wrap(dek, payload []byte) (string, error) {
+	wrappedKey, err := seal(k.keys[k.activeID], dek, []byte(k.activeID))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		prefix,
+		k.activeID,
+		encoding.EncodeToString(wrappedKey),
+		encoding.EncodeToString(payload),
+	}, ":"), nil
+}
+
+func (k *Keyring) unwrap(value string) ([]byte, []byte, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 4 || parts[0] != prefix {
+		return nil, nil, ErrMalformed
+	}
+
+	kek, ok := k.keys[parts[1]]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownKey, parts[1])
+	}
+
+	wrappedKey, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, ErrMalformed
+	}
+	payload, err := encoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, nil, ErrMalformed
+	}
+
+	dek, err := open(kek, wrappedKey, []byte(parts[1]))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dek, payload, nil
+}
+
+// seal шифрует data ключом key в AES-GCM; результат - nonce, за которым идет шифротекст.
+func seal(key, data, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("ошибка генерации nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, data, additionalData), nil
+}
+
+func open(key, sealed, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	data, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка расшифровки: %w", err)
+	}
+
+	return data, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка инициализации AES: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,12 @@
+package errors
+
+import "net/http"
+
+// Ошибки входа, сессий и обновления токенов. Тексты - в pkg/i18n/messages_auth.go.
+var (
+	AuthInvalidCredentials  = define("auth_invalid_credentials", http.StatusUnauthorized, "auth.invalid_credentials")
+	AuthRefreshTokenInvalid = define("auth_refresh_token_invalid", http.StatusUnauthorized, "auth.refresh_token_invalid")
+	AuthRefreshTokenReused  = define("auth_refresh_token_reused", http.StatusUnauthorized, "auth.refresh_token_reused")
+	AuthSessionRevoked      = define("auth_session_revoked", http.StatusUnauthorized, "auth.session_revoked")
+	AuthSessionNotFound     = define("auth_session_not_found", http.StatusNotFound, "auth.session_not_found")
+)
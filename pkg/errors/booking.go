@@ -0,0 +1,81 @@
+package errors
+
+import "net/http"
+
+// Ошибки бронирований. Тексты - в pkg/i18n/messages_booking.go.
+var (
+	BookingNotFound              = define("booking_not_found", http.StatusNotFound, "booking.not_found")
+	BookingExtensionNotFound     = define("booking_extension_not_found", http.StatusNotFound, "booking.extension_not_found")
+	BookingUserNotFound          = define("booking_user_not_found", http.StatusUnauthorized, "booking.user_not_found")
+	BookingRenterProfileNotFound = define("booking_renter_profile_not_found", http.StatusNotFound, "booking.renter_profile_not_found")
+	BookingAdminOnly             = define("booking_admin_only", http.StatusForbidden, "booking.admin_only")
+	BookingAlreadyCanceled       = define("booking_already_canceled", http.StatusConflict, "booking.already_canceled")
+	BookingCannotCancelCompleted = define("booking_cannot_cancel_completed", http.StatusConflict, "booking.cannot_cancel_completed")
+	BookingPaymentNotFound       = define("booking_payment_not_found", http.StatusNotFound, "booking.payment_not_found")
+
+	BookingVerificationRequired             = define("booking_verification_required", http.StatusForbidden, "booking.verification_required")
+	BookingVerificationPending              = define("booking_verification_pending", http.StatusForbidden, "booking.verification_pending")
+	BookingVerificationRejected             = define("booking_verification_rejected", http.StatusForbidden, "booking.verification_rejected")
+	BookingVerificationRejectedNew          = define("booking_verification_rejected_new", http.StatusForbidden, "booking.verification_rejected_new")
+	BookingVerificationUnknown              = define("booking_verification_unknown", http.StatusForbidden, "booking.verification_unknown")
+	BookingOwnerVerificationRequired        = define("booking_owner_verification_required", http.StatusConflict, "booking.owner_verification_required")
+	BookingApartmentNotFoundID              = define("booking_apartment_not_found_id", http.StatusNotFound, "booking.apartment_not_found_id")
+	BookingApartmentNotFound                = define("booking_apartment_not_found", http.StatusNotFound, "booking.apartment_not_found")
+	BookingApartmentUnavailable             = define("booking_apartment_unavailable", http.StatusConflict, "booking.apartment_unavailable")
+	BookingApartmentUnavailablePeriod       = define("booking_apartment_unavailable_period", http.StatusConflict, "booking.apartment_unavailable_period")
+	BookingApartmentUnavailableOverlap      = define("booking_apartment_unavailable_overlap", http.StatusConflict, "booking.apartment_unavailable_overlap")
+	BookingApartmentUnavailableExtension    = define("booking_apartment_unavailable_extension", http.StatusConflict, "booking.apartment_unavailable_extension")
+	BookingNotOwner                         = define("booking_not_owner", http.StatusForbidden, "booking.not_owner")
+	BookingNotRegisteredOwner               = define("booking_not_registered_owner", http.StatusForbidden, "booking.not_registered_owner")
+	BookingForbiddenApprove                 = define("booking_forbidden_approve", http.StatusForbidden, "booking.forbidden_approve")
+	BookingForbiddenReject                  = define("booking_forbidden_reject", http.StatusForbidden, "booking.forbidden_reject")
+	BookingForbiddenCancel                  = define("booking_forbidden_cancel", http.StatusForbidden, "booking.forbidden_cancel")
+	BookingForbiddenCancellationTerms       = define("booking_forbidden_cancellation_terms", http.StatusForbidden, "booking.forbidden_cancellation_terms")
+	BookingForbiddenComplete                = define("booking_forbidden_complete", http.StatusForbidden, "booking.forbidden_complete")
+	BookingForbiddenExtend                  = define("booking_forbidden_extend", http.StatusForbidden, "booking.forbidden_extend")
+	BookingForbiddenAccess                  = define("booking_forbidden_access", http.StatusForbidden, "booking.forbidden_access")
+	BookingForbiddenPay                     = define("booking_forbidden_pay", http.StatusForbidden, "booking.forbidden_pay")
+	BookingForbiddenApproveExtension        = define("booking_forbidden_approve_extension", http.StatusForbidden, "booking.forbidden_approve_extension")
+	BookingForbiddenRejectExtension         = define("booking_forbidden_reject_extension", http.StatusForbidden, "booking.forbidden_reject_extension")
+	BookingForbiddenPayExtension            = define("booking_forbidden_pay_extension", http.StatusForbidden, "booking.forbidden_pay_extension")
+	BookingApproveOnlyPending               = define("booking_approve_only_pending", http.StatusConflict, "booking.approve_only_pending")
+	BookingRejectOnlyPending                = define("booking_reject_only_pending", http.StatusConflict, "booking.reject_only_pending")
+	BookingConfirmOnlyCreated               = define("booking_confirm_only_created", http.StatusConflict, "booking.confirm_only_created")
+	BookingCannotCancel                     = define("booking_cannot_cancel", http.StatusConflict, "booking.cannot_cancel")
+	BookingCompleteOnlyActive               = define("booking_complete_only_active", http.StatusConflict, "booking.complete_only_active")
+	BookingExtendOnlyActive                 = define("booking_extend_only_active", http.StatusConflict, "booking.extend_only_active")
+	BookingExtensionUnavailable             = define("booking_extension_unavailable", http.StatusConflict, "booking.extension_unavailable")
+	BookingPayOnlyAwaiting                  = define("booking_pay_only_awaiting", http.StatusConflict, "booking.pay_only_awaiting")
+	BookingExtensionPayOnlyAwaiting         = define("booking_extension_pay_only_awaiting", http.StatusConflict, "booking.extension_pay_only_awaiting")
+	BookingExtensionApproveOnlyPaid         = define("booking_extension_approve_only_paid", http.StatusConflict, "booking.extension_approve_only_paid")
+	BookingExtensionRejectOnlyPending       = define("booking_extension_reject_only_pending", http.StatusConflict, "booking.extension_reject_only_pending")
+	BookingExtensionNotForBooking           = define("booking_extension_not_for_booking", http.StatusBadRequest, "booking.extension_not_for_booking")
+	BookingExtensionDataCorrupted           = define("booking_extension_data_corrupted", http.StatusInternalServerError, "booking.extension_data_corrupted")
+	BookingPaymentAlreadyUsed               = define("booking_payment_already_used", http.StatusConflict, "booking.payment_already_used")
+	BookingPaymentUsedForBooking            = define("booking_payment_used_for_booking", http.StatusConflict, "booking.payment_used_for_booking")
+	BookingPaymentNotCompleted              = define("booking_payment_not_completed", http.StatusPaymentRequired, "booking.payment_not_completed")
+	BookingPaymentAmountMismatch            = define("booking_payment_amount_mismatch", http.StatusBadRequest, "booking.payment_amount_mismatch")
+	BookingExtensionAmountMismatch          = define("booking_extension_amount_mismatch", http.StatusBadRequest, "booking.extension_amount_mismatch")
+	BookingPaymentOrderNotFound             = define("booking_payment_order_not_found", http.StatusNotFound, "booking.payment_order_not_found")
+	BookingInvalidDatetime                  = define("booking_invalid_datetime", http.StatusBadRequest, "booking.invalid_datetime")
+	BookingInvalidDate                      = define("booking_invalid_date", http.StatusBadRequest, "booking.invalid_date")
+	BookingDailyUnsupported                 = define("booking_daily_unsupported", http.StatusBadRequest, "booking.daily_unsupported")
+	BookingDailyPriceMissing                = define("booking_daily_price_missing", http.StatusBadRequest, "booking.daily_price_missing")
+	BookingHourlyUnsupported                = define("booking_hourly_unsupported", http.StatusBadRequest, "booking.hourly_unsupported")
+	BookingHourlyPriceMissing               = define("booking_hourly_price_missing", http.StatusBadRequest, "booking.hourly_price_missing")
+	BookingDaytimeDurations                 = define("booking_daytime_durations", http.StatusBadRequest, "booking.daytime_durations")
+	BookingNightDailyOnly                   = define("booking_night_daily_only", http.StatusBadRequest, "booking.night_daily_only")
+	BookingHourlyDurationTooLong            = define("booking_hourly_duration_too_long", http.StatusBadRequest, "booking.hourly_duration_too_long")
+	BookingHourlyMustEndBy22                = define("booking_hourly_must_end_by_22", http.StatusBadRequest, "booking.hourly_must_end_by_22")
+	BookingStartInPast                      = define("booking_start_in_past", http.StatusBadRequest, "booking.start_in_past")
+	BookingDateInPast                       = define("booking_date_in_past", http.StatusBadRequest, "booking.date_in_past")
+	BookingTooFarInAdvance                  = define("booking_too_far_in_advance", http.StatusBadRequest, "booking.too_far_in_advance")
+	BookingContractNotAccepted              = define("booking_contract_not_accepted", http.StatusBadRequest, "booking.contract_not_accepted")
+	BookingReceiptNotApartmentOwner         = define("booking_receipt_not_apartment_owner", http.StatusForbidden, "booking.receipt_not_apartment_owner")
+	BookingReceiptRoleForbidden             = define("booking_receipt_role_forbidden", http.StatusForbidden, "booking.receipt_role_forbidden")
+	BookingReceiptOnlyOwn                   = define("booking_receipt_only_own", http.StatusForbidden, "booking.receipt_only_own")
+	BookingReceiptPaymentNotFound           = define("booking_receipt_payment_not_found", http.StatusNotFound, "booking.receipt_payment_not_found")
+	BookingReceiptSuccessfulPaymentNotFound = define("booking_receipt_successful_payment_not_found", http.StatusNotFound, "booking.receipt_successful_payment_not_found")
+	BookingReceiptPaymentDataError          = define("booking_receipt_payment_data_error", http.StatusInternalServerError, "booking.receipt_payment_data_error")
+	BookingReceiptPaymentNotInSystem        = define("booking_receipt_payment_not_in_system", http.StatusBadRequest, "booking.receipt_payment_not_in_system")
+)
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/russo2642/renti_kz/pkg/i18n"
+)
+
+// Definition запись каталога ошибок. Code - стабильный машиночитаемый код, на который ориентируются
+// клиенты; текст ошибки берется из pkg/i18n по MessageKey и может меняться без изменения кода.
+type Definition struct {
+	Code       string `json:"code"`
+	StatusCode int    `json:"status"`
+	MessageKey string `json:"message_key"`
+}
+
+var definitions = make(map[string]*Definition)
+
+func define(code string, statusCode int, messageKey string) *Definition {
+	if _, exists := definitions[code]; exists {
+		panic(fmt.Sprintf("errors: код %q зарегистрирован дважды", code))
+	}
+	if !i18n.Has(messageKey) {
+		panic(fmt.Sprintf("errors: для кода %q нет сообщения %q", code, messageKey))
+	}
+
+	definition := &Definition{
+		Code:       code,
+		StatusCode: statusCode,
+		MessageKey: messageKey,
+	}
+	definitions[code] = definition
+
+	return definition
+}
+
+// New создает ошибку каталога с параметрами сообщения.
+func (d *Definition) New(params i18n.Params) *AppError {
+	return d.Wrap(nil, params)
+}
+
+// Wrap создает ошибку каталога, сохраняя исходную ошибку для логов и errors.Is/As.
+func (d *Definition) Wrap(err error, params i18n.Params) *AppError {
+	return &AppError{
+		Type:       d.Code,
+		Message:    i18n.T(i18n.Default, d.MessageKey, params),
+		StatusCode: d.StatusCode,
+		Err:        err,
+		MessageKey: d.MessageKey,
+		Params:     params,
+	}
+}
+
+// Is сообщает, что err (или одна из обернутых ошибок) создана по этой записи каталога.
+func (d *Definition) Is(err error) bool {
+	return Is(err, d.Code)
+}
+
+// Lookup возвращает запись каталога по коду.
+func Lookup(code string) (*Definition, bool) {
+	definition, ok := definitions[code]
+	return definition, ok
+}
+
+// Definitions возвращает все записи каталога, отсортированные по коду.
+func Definitions() []*Definition {
+	result := make([]*Definition, 0, len(definitions))
+	for _, definition := range definitions {
+		result = append(result, definition)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/russo2642/renti_kz/pkg/i18n"
 )
 
 const (
@@ -28,19 +30,44 @@ const (
 	ErrInternal     = "internal_error"
 	ErrForbidden    = "forbidden"
 	ErrUnauthorized = "unauthorized"
+	ErrConflict     = "conflict"
 )
 
+// AppError ошибка приложения. Type - машиночитаемый код ошибки; у ошибок из каталога (см. Definition)
+// заполнен MessageKey, и текст переводится на язык клиента, у остальных Message отдается как есть.
 type AppError struct {
-	Type       string `json:"type"`
-	Message    string `json:"message"`
-	StatusCode int    `json:"-"`
-	Err        error  `json:"-"`
+	Type       string                 `json:"type"`
+	Message    string                 `json:"message"`
+	StatusCode int                    `json:"-"`
+	Err        error                  `json:"-"`
+	MessageKey string                 `json:"-"`
+	Params     i18n.Params            `json:"-"`
+	Details    map[string]interface{} `json:"details,omitempty"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+// Localize возвращает текст ошибки на указанном языке.
+func (e *AppError) Localize(lang i18n.Language) string {
+	if e.MessageKey == "" {
+		return e.Message
+	}
+	return i18n.T(lang, e.MessageKey, e.Params)
+}
+
+// WithDetails добавляет к ошибке структурированные данные для клиента.
+func (e *AppError) WithDetails(details map[string]interface{}) *AppError {
+	if e.Details == nil {
+		e.Details = make(map[string]interface{}, len(details))
+	}
+	for key, value := range details {
+		e.Details[key] = value
+	}
+	return e
+}
+
 func (e *AppError) Unwrap() error {
 	return e.Err
 }
@@ -118,6 +145,28 @@ func NewUnauthorizedError(message string) *AppError {
 	return New(ErrUnauthorized, message, http.StatusUnauthorized, nil)
 }
 
+// FromStatus оборачивает ошибку вне каталога в AppError с общим кодом для HTTP-статуса.
+func FromStatus(statusCode int, err error) *AppError {
+	return New(typeForStatus(statusCode), err.Error(), statusCode, err)
+}
+
+func typeForStatus(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict:
+		return ErrConflict
+	case statusCode >= http.StatusInternalServerError:
+		return ErrInternal
+	default:
+		return ErrBadRequest
+	}
+}
+
 func AsAppError(err error) (*AppError, bool) {
 	var appErr *AppError
 	if errors.As(err, &appErr) {
@@ -0,0 +1,24 @@
+package errors
+
+import "net/http"
+
+// Ошибки замков, API устройств и webhook Tuya. Тексты - в pkg/i18n/messages_lock.go.
+var (
+	LockNotFound             = define("lock_not_found", http.StatusNotFound, "lock.not_found")
+	LockApartmentNotFound    = define("lock_apartment_not_found", http.StatusNotFound, "lock.apartment_not_found")
+	LockPasswordNotFound     = define("lock_password_not_found", http.StatusNotFound, "lock.password_not_found")
+	LockDeviceSecretNotFound = define("lock_device_secret_not_found", http.StatusNotFound, "lock.device_secret_not_found")
+	LockGracePeriodNegative  = define("lock_grace_period_negative", http.StatusBadRequest, "lock.grace_period_negative")
+
+	LockDeviceSignatureMissing = define("lock_device_signature_missing", http.StatusUnauthorized, "lock.device_signature_missing")
+	LockDeviceSignatureInvalid = define("lock_device_signature_invalid", http.StatusUnauthorized, "lock.device_signature_invalid")
+	LockDeviceTimestampSkew    = define("lock_device_timestamp_skew", http.StatusUnauthorized, "lock.device_timestamp_skew")
+	LockDeviceNonceReused      = define("lock_device_nonce_reused", http.StatusUnauthorized, "lock.device_nonce_reused")
+	LockDeviceNotProvisioned   = define("lock_device_not_provisioned", http.StatusUnauthorized, "lock.device_not_provisioned")
+	LockDeviceMismatch         = define("lock_device_mismatch", http.StatusForbidden, "lock.device_mismatch")
+
+	TuyaWebhookMalformed        = define("tuya_webhook_malformed", http.StatusBadRequest, "lock.tuya_webhook_malformed")
+	TuyaWebhookSignatureInvalid = define("tuya_webhook_signature_invalid", http.StatusUnauthorized, "lock.tuya_webhook_signature_invalid")
+	TuyaWebhookExpired          = define("tuya_webhook_expired", http.StatusUnauthorized, "lock.tuya_webhook_expired")
+	TuyaWebhookDecryptFailed    = define("tuya_webhook_decrypt_failed", http.StatusBadRequest, "lock.tuya_webhook_decrypt_failed")
+)
@@ -0,0 +1,10 @@
+package errors
+
+import "net/http"
+
+// Ошибки ограничения частоты запросов и блокировки перебора. Тексты - в pkg/i18n/messages_rate_limit.go.
+var (
+	RateLimitExceeded    = define("rate_limit_exceeded", http.StatusTooManyRequests, "rate_limit.exceeded")
+	RateLimitLoginLocked = define("login_temporarily_locked", http.StatusTooManyRequests, "rate_limit.login_locked")
+	RateLimitOTPLocked   = define("otp_verify_temporarily_locked", http.StatusTooManyRequests, "rate_limit.otp_locked")
+)
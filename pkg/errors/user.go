@@ -0,0 +1,15 @@
+package errors
+
+import "net/http"
+
+// Ошибки регистрации и управления пользователями. Тексты - в pkg/i18n/messages_user.go.
+var (
+	UserNotFound               = define("user_not_found", http.StatusNotFound, "user.not_found")
+	UserAdminRequired          = define("user_admin_required", http.StatusForbidden, "user.admin_required")
+	UserRoleNotFound           = define("user_role_not_found", http.StatusBadRequest, "user.role_not_found")
+	UserOwnStatusChange        = define("user_own_status_change", http.StatusBadRequest, "user.own_status_change")
+	UserPasswordForRegularUser = define("user_password_regular_user", http.StatusBadRequest, "user.password_regular_user")
+	UserPasswordTooShort       = define("user_password_too_short", http.StatusBadRequest, "user.password_too_short")
+	UserPhoneTaken             = define("user_phone_taken", http.StatusConflict, "user.phone_taken")
+	UserEmailTaken             = define("user_email_taken", http.StatusConflict, "user.email_taken")
+)
@@ -0,0 +1,17 @@
+package i18n
+
+import "errors"
+
+// Localizer ошибка, текст которой можно получить на языке клиента (например, ошибки каталога pkg/errors).
+type Localizer interface {
+	Localize(lang Language) string
+}
+
+// Localize возвращает текст ошибки на указанном языке. Прочие ошибки возвращаются как есть.
+func Localize(err error, lang Language) string {
+	var localizer Localizer
+	if errors.As(err, &localizer) {
+		return localizer.Localize(lang)
+	}
+	return err.Error()
+}
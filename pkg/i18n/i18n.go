@@ -0,0 +1,147 @@
+// Package i18n содержит каталог пользовательских сообщений на русском, казахском и английском языках
+// и выбор языка по заголовку Accept-Language. Сообщения адресуются кодом вида "booking.apartment_unavailable",
+// параметры подставляются по именам: "{title}".
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	Russian Language = "ru"
+	Kazakh  Language = "kk"
+	English Language = "en"
+
+	// Default язык, на который откатываются непереведенные сообщения и неизвестные языки.
+	Default = Russian
+)
+
+// Languages поддерживаемые языки.
+var Languages = []Language{Russian, Kazakh, English}
+
+// Params именованные параметры сообщения.
+type Params map[string]string
+
+// Messages переводы одного сообщения.
+type Messages map[Language]string
+
+var catalog = make(map[string]Messages)
+
+// register добавляет сообщения в каталог. Вызывается из init файлов с сообщениями.
+func register(messages map[string]Messages) {
+	for key, translations := range messages {
+		if _, exists := catalog[key]; exists {
+			panic(fmt.Sprintf("i18n: сообщение %q зарегистрировано дважды", key))
+		}
+		if translations[Default] == "" {
+			panic(fmt.Sprintf("i18n: у сообщения %q нет текста на языке по умолчанию", key))
+		}
+		catalog[key] = translations
+	}
+}
+
+// Parse разбирает код языка: "kk", "kk-KZ", "EN_us". Казахский также принимается как "kz".
+func Parse(code string) (Language, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i >= 0 {
+		code = code[:i]
+	}
+
+	switch code {
+	case "ru":
+		return Russian, true
+	case "kk", "kz":
+		return Kazakh, true
+	case "en":
+		return English, true
+	}
+
+	return "", false
+}
+
+// Normalize возвращает поддерживаемый язык или язык по умолчанию.
+func Normalize(code string) Language {
+	if lang, ok := Parse(code); ok {
+		return lang
+	}
+	return Default
+}
+
+// Negotiate выбирает язык по заголовку Accept-Language с учетом весов q.
+// Если ни один язык из заголовка не поддерживается, возвращается язык по умолчанию.
+func Negotiate(acceptLanguage string) Language {
+	type candidate struct {
+		lang   Language
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, options, _ := strings.Cut(part, ";")
+		lang, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+
+		weight := 1.0
+		for _, option := range strings.Split(options, ";") {
+			name, value, found := strings.Cut(strings.TrimSpace(option), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				weight = q
+			}
+		}
+
+		if weight > 0 {
+			candidates = append(candidates, candidate{lang: lang, weight: weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return Default
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+
+	return candidates[0].lang
+}
+
+// Has сообщает, есть ли сообщение в каталоге.
+func Has(key string) bool {
+	_, ok := catalog[key]
+	return ok
+}
+
+// T возвращает сообщение на указанном языке с подставленными параметрами. Если перевода нет,
+// используется язык по умолчанию; неизвестный код возвращается как есть.
+func T(lang Language, key string, params Params) string {
+	translations, ok := catalog[key]
+	if !ok {
+		return key
+	}
+
+	text := translations[lang]
+	if text == "" {
+		text = translations[Default]
+	}
+
+	if len(params) == 0 {
+		return text
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+
+	return strings.NewReplacer(pairs...).Replace(text)
+}
@@ -0,0 +1,32 @@
+package i18n
+
+// Ошибки входа, сессий и обновления токенов.
+func init() {
+	register(map[string]Messages{
+		"auth.refresh_token_invalid": {
+			Russian: "недействительный или истекший токен",
+			Kazakh:  "токен жарамсыз немесе мерзімі өткен",
+			English: "invalid or expired token",
+		},
+		"auth.refresh_token_reused": {
+			Russian: "refresh-токен уже был использован, сессия завершена в целях безопасности. Войдите заново",
+			Kazakh:  "refresh-токен бұрын пайдаланылған, қауіпсіздік үшін сессия аяқталды. Қайта кіріңіз",
+			English: "refresh token has already been used, the session was terminated for security reasons. Please sign in again",
+		},
+		"auth.session_revoked": {
+			Russian: "сессия завершена, войдите заново",
+			Kazakh:  "сессия аяқталды, қайта кіріңіз",
+			English: "session has been terminated, please sign in again",
+		},
+		"auth.session_not_found": {
+			Russian: "сессия не найдена",
+			Kazakh:  "сессия табылмады",
+			English: "session not found",
+		},
+		"auth.invalid_credentials": {
+			Russian: "неверные учетные данные",
+			Kazakh:  "тіркелгі деректері қате",
+			English: "invalid credentials",
+		},
+	})
+}
@@ -0,0 +1,372 @@
+package i18n
+
+// Ошибки бронирований, которые видит арендатор или владелец.
+func init() {
+	register(map[string]Messages{
+		"booking.verification_required": {
+			Russian: "для создания бронирования необходимо завершить верификацию личности",
+			Kazakh:  "брондау жасау үшін жеке басыңызды верификациялаудан өтуіңіз қажет",
+			English: "identity verification must be completed before creating a booking",
+		},
+		"booking.verification_pending": {
+			Russian: "ваша верификация находится на рассмотрении. Дождитесь подтверждения для создания бронирования",
+			Kazakh:  "верификацияңыз қаралуда. Брондау жасау үшін растауды күтіңіз",
+			English: "your verification is under review. Please wait for approval before creating a booking",
+		},
+		"booking.verification_rejected": {
+			Russian: "ваша верификация была отклонена. Обратитесь в поддержку или повторите процедуру верификации",
+			Kazakh:  "верификацияңыз қабылданбады. Қолдау қызметіне жүгініңіз немесе верификациядан қайта өтіңіз",
+			English: "your verification was rejected. Contact support or go through verification again",
+		},
+		"booking.verification_rejected_new": {
+			Russian: "ваша верификация была отклонена. Для создания новых бронирований обратитесь в поддержку или повторите процедуру верификации",
+			Kazakh:  "верификацияңыз қабылданбады. Жаңа брондау жасау үшін қолдау қызметіне жүгініңіз немесе верификациядан қайта өтіңіз",
+			English: "your verification was rejected. To create new bookings, contact support or go through verification again",
+		},
+		"booking.verification_unknown": {
+			Russian: "статус верификации неизвестен. Обратитесь в поддержку",
+			Kazakh:  "верификация мәртебесі белгісіз. Қолдау қызметіне жүгініңіз",
+			English: "verification status is unknown. Please contact support",
+		},
+		"booking.owner_verification_required": {
+			Russian: "для создания бронирования владелец недвижимости должен пройти верификацию как арендатор",
+			Kazakh:  "брондау жасау үшін мүлік иесі жалға алушы ретінде верификациядан өтуі керек",
+			English: "a property owner must be verified as a renter to create a booking",
+		},
+		"booking.apartment_not_found_id": {
+			Russian: "квартира с ID {id} не найдена",
+			Kazakh:  "ID {id} пәтер табылмады",
+			English: "apartment with ID {id} not found",
+		},
+		"booking.apartment_not_found": {
+			Russian: "квартира не найдена",
+			Kazakh:  "пәтер табылмады",
+			English: "apartment not found",
+		},
+		"booking.apartment_unavailable": {
+			Russian: "квартира недоступна для бронирования",
+			Kazakh:  "пәтерді брондау мүмкін емес",
+			English: "the apartment is not available for booking",
+		},
+		"booking.apartment_unavailable_period": {
+			Russian: "квартира недоступна в указанный период",
+			Kazakh:  "пәтер көрсетілген кезеңде бос емес",
+			English: "the apartment is not available for the selected period",
+		},
+		"booking.apartment_unavailable_overlap": {
+			Russian: "квартира недоступна в указанный период - найдено пересекающееся бронирование",
+			Kazakh:  "пәтер көрсетілген кезеңде бос емес — уақыты қиылысатын брондау бар",
+			English: "the apartment is not available for the selected period: it overlaps with another booking",
+		},
+		"booking.apartment_unavailable_extension": {
+			Russian: "квартира недоступна для продления в указанный период",
+			Kazakh:  "пәтерді көрсетілген кезеңге ұзарту мүмкін емес",
+			English: "the apartment is not available for an extension in the selected period",
+		},
+		"booking.not_owner": {
+			Russian: "пользователь не является владельцем недвижимости",
+			Kazakh:  "пайдаланушы мүлік иесі емес",
+			English: "the user is not a property owner",
+		},
+		"booking.not_registered_owner": {
+			Russian: "пользователь не является зарегистрированным владельцем",
+			Kazakh:  "пайдаланушы тіркелген мүлік иесі емес",
+			English: "the user is not a registered owner",
+		},
+		"booking.forbidden_approve": {
+			Russian: "нет прав для подтверждения этого бронирования",
+			Kazakh:  "бұл брондауды растауға құқығыңыз жоқ",
+			English: "you are not allowed to approve this booking",
+		},
+		"booking.forbidden_reject": {
+			Russian: "нет прав для отклонения этого бронирования",
+			Kazakh:  "бұл брондаудан бас тартуға құқығыңыз жоқ",
+			English: "you are not allowed to reject this booking",
+		},
+		"booking.forbidden_cancel": {
+			Russian: "нет прав для отмены этого бронирования",
+			Kazakh:  "бұл брондаудың күшін жоюға құқығыңыз жоқ",
+			English: "you are not allowed to cancel this booking",
+		},
+		"booking.forbidden_cancellation_terms": {
+			Russian: "нет прав для просмотра условий отмены этого бронирования",
+			Kazakh:  "бұл брондаудың күшін жою шарттарын көруге құқығыңыз жоқ",
+			English: "you are not allowed to view the cancellation terms of this booking",
+		},
+		"booking.forbidden_complete": {
+			Russian: "нет прав для завершения этого бронирования",
+			Kazakh:  "бұл брондауды аяқтауға құқығыңыз жоқ",
+			English: "you are not allowed to complete this booking",
+		},
+		"booking.forbidden_extend": {
+			Russian: "нет прав для продления этого бронирования",
+			Kazakh:  "бұл брондауды ұзартуға құқығыңыз жоқ",
+			English: "you are not allowed to extend this booking",
+		},
+		"booking.forbidden_access": {
+			Russian: "нет прав для доступа к этому бронированию",
+			Kazakh:  "бұл брондауға қол жеткізуге құқығыңыз жоқ",
+			English: "you do not have access to this booking",
+		},
+		"booking.forbidden_pay": {
+			Russian: "нет прав для оплаты этого бронирования",
+			Kazakh:  "бұл брондауды төлеуге құқығыңыз жоқ",
+			English: "you are not allowed to pay for this booking",
+		},
+		"booking.forbidden_approve_extension": {
+			Russian: "нет прав для подтверждения продления",
+			Kazakh:  "ұзартуды растауға құқығыңыз жоқ",
+			English: "you are not allowed to approve this extension",
+		},
+		"booking.forbidden_reject_extension": {
+			Russian: "нет прав для отклонения продления",
+			Kazakh:  "ұзартудан бас тартуға құқығыңыз жоқ",
+			English: "you are not allowed to reject this extension",
+		},
+		"booking.forbidden_pay_extension": {
+			Russian: "нет прав для оплаты этого продления",
+			Kazakh:  "бұл ұзартуды төлеуге құқығыңыз жоқ",
+			English: "you are not allowed to pay for this extension",
+		},
+		"booking.approve_only_pending": {
+			Russian: "можно подтвердить только ожидающие бронирования",
+			Kazakh:  "тек күтудегі брондауларды растауға болады",
+			English: "only pending bookings can be approved",
+		},
+		"booking.reject_only_pending": {
+			Russian: "можно отклонить только ожидающие бронирования",
+			Kazakh:  "тек күтудегі брондаулардан бас тартуға болады",
+			English: "only pending bookings can be rejected",
+		},
+		"booking.confirm_only_created": {
+			Russian: "можно подтвердить только бронирования со статусом 'created'",
+			Kazakh:  "тек 'created' мәртебесіндегі брондауларды растауға болады",
+			English: "only bookings with status 'created' can be confirmed",
+		},
+		"booking.cannot_cancel": {
+			Russian: "нельзя отменить завершенное, уже отмененное или активное бронирование",
+			Kazakh:  "аяқталған, күші бұрын жойылған немесе белсенді брондаудың күшін жоюға болмайды",
+			English: "a completed, already canceled or active booking cannot be canceled",
+		},
+		"booking.complete_only_active": {
+			Russian: "можно завершить только активные бронирования",
+			Kazakh:  "тек белсенді брондауларды аяқтауға болады",
+			English: "only active bookings can be completed",
+		},
+		"booking.extend_only_active": {
+			Russian: "можно продлить только активные бронирования",
+			Kazakh:  "тек белсенді брондауларды ұзартуға болады",
+			English: "only active bookings can be extended",
+		},
+		"booking.extension_unavailable": {
+			Russian: "продление данного бронирования недоступно",
+			Kazakh:  "бұл брондауды ұзарту мүмкін емес",
+			English: "this booking cannot be extended",
+		},
+		"booking.pay_only_awaiting": {
+			Russian: "можно оплатить только бронирования со статусом 'awaiting_payment'",
+			Kazakh:  "тек 'awaiting_payment' мәртебесіндегі брондауларды төлеуге болады",
+			English: "only bookings with status 'awaiting_payment' can be paid",
+		},
+		"booking.extension_pay_only_awaiting": {
+			Russian: "можно оплатить только продления со статусом 'awaiting_payment'",
+			Kazakh:  "тек 'awaiting_payment' мәртебесіндегі ұзартуларды төлеуге болады",
+			English: "only extensions with status 'awaiting_payment' can be paid",
+		},
+		"booking.extension_approve_only_paid": {
+			Russian: "можно подтвердить только оплаченные продления (статус: pending)",
+			Kazakh:  "тек төленген ұзартуларды растауға болады (мәртебесі: pending)",
+			English: "only paid extensions can be approved (status: pending)",
+		},
+		"booking.extension_reject_only_pending": {
+			Russian: "можно отклонить только ожидающие продления",
+			Kazakh:  "тек күтудегі ұзартулардан бас тартуға болады",
+			English: "only pending extensions can be rejected",
+		},
+		"booking.extension_not_for_booking": {
+			Russian: "продление не относится к данному бронированию",
+			Kazakh:  "ұзарту бұл брондауға қатысты емес",
+			English: "the extension does not belong to this booking",
+		},
+		"booking.extension_data_corrupted": {
+			Russian: "данные о продлении повреждены: отсутствует дата окончания",
+			Kazakh:  "ұзарту деректері бүлінген: аяқталу күні жоқ",
+			English: "extension data is corrupted: the end date is missing",
+		},
+		"booking.payment_already_used": {
+			Russian: "платеж {payment_id} уже использован",
+			Kazakh:  "{payment_id} төлемі бұрын пайдаланылған",
+			English: "payment {payment_id} has already been used",
+		},
+		"booking.payment_used_for_booking": {
+			Russian: "платеж {payment_id} уже использован для бронирования #{booking_id}",
+			Kazakh:  "{payment_id} төлемі #{booking_id} брондауы үшін бұрын пайдаланылған",
+			English: "payment {payment_id} has already been used for booking #{booking_id}",
+		},
+		"booking.payment_not_completed": {
+			Russian: "платеж не завершен: {status}",
+			Kazakh:  "төлем аяқталмаған: {status}",
+			English: "payment is not completed: {status}",
+		},
+		"booking.payment_amount_mismatch": {
+			Russian: "сумма платежа не совпадает с суммой бронирования",
+			Kazakh:  "төлем сомасы брондау сомасына сәйкес келмейді",
+			English: "the payment amount does not match the booking amount",
+		},
+		"booking.extension_amount_mismatch": {
+			Russian: "сумма платежа не совпадает с суммой продления",
+			Kazakh:  "төлем сомасы ұзарту сомасына сәйкес келмейді",
+			English: "the payment amount does not match the extension amount",
+		},
+		"booking.payment_order_not_found": {
+			Russian: "платеж с order_id {order_id} не найден: {reason}",
+			Kazakh:  "order_id {order_id} төлемі табылмады: {reason}",
+			English: "payment with order_id {order_id} not found: {reason}",
+		},
+		"booking.invalid_datetime": {
+			Russian: "неверный формат даты: {value} (ожидается 2006-01-02T15:04:05)",
+			Kazakh:  "күн пішімі қате: {value} (күтілетін пішім: 2006-01-02T15:04:05)",
+			English: "invalid date format: {value} (expected 2006-01-02T15:04:05)",
+		},
+		"booking.invalid_date": {
+			Russian: "неверный формат даты: {value} (ожидается 2006-01-02)",
+			Kazakh:  "күн пішімі қате: {value} (күтілетін пішім: 2006-01-02)",
+			English: "invalid date format: {value} (expected 2006-01-02)",
+		},
+		"booking.daily_unsupported": {
+			Russian: "данная квартира не поддерживает посуточную аренду",
+			Kazakh:  "бұл пәтер тәулік бойынша жалға берілмейді",
+			English: "this apartment is not available for daily rent",
+		},
+		"booking.daily_price_missing": {
+			Russian: "для данной квартиры не установлена цена за сутки",
+			Kazakh:  "бұл пәтер үшін тәуліктік баға белгіленбеген",
+			English: "no daily price is set for this apartment",
+		},
+		"booking.hourly_unsupported": {
+			Russian: "данная квартира не поддерживает почасовую аренду",
+			Kazakh:  "бұл пәтер сағат бойынша жалға берілмейді",
+			English: "this apartment is not available for hourly rent",
+		},
+		"booking.hourly_price_missing": {
+			Russian: "для данной квартиры не установлена почасовая цена",
+			Kazakh:  "бұл пәтер үшін сағаттық баға белгіленбеген",
+			English: "no hourly price is set for this apartment",
+		},
+		"booking.daytime_durations": {
+			Russian: "в дневное время (10:00-22:00) можно бронировать на {h1}, {h2}, {h3} или {h4} часа",
+			Kazakh:  "күндізгі уақытта (10:00-22:00) {h1}, {h2}, {h3} немесе {h4} сағатқа брондауға болады",
+			English: "during the day (10:00-22:00) bookings are available for {h1}, {h2}, {h3} or {h4} hours",
+		},
+		"booking.night_daily_only": {
+			Russian: "в ночное время (22:00-10:00) доступна только посуточная аренда (24 часа)",
+			Kazakh:  "түнгі уақытта (22:00-10:00) тек тәуліктік жалдау (24 сағат) қолжетімді",
+			English: "at night (22:00-10:00) only daily rent (24 hours) is available",
+		},
+		"booking.hourly_duration_too_long": {
+			Russian: "продолжительность {duration} часов слишком велика для почасовой аренды. Максимальная продолжительность: 12 часов (10:00-22:00)",
+			Kazakh:  "{duration} сағат сағаттық жалдау үшін тым ұзақ. Ең ұзақ мерзімі: 12 сағат (10:00-22:00)",
+			English: "{duration} hours is too long for hourly rent. The maximum duration is 12 hours (10:00-22:00)",
+		},
+		"booking.hourly_must_end_by_22": {
+			Russian: "почасовая аренда должна заканчиваться до 22:00. Для продолжительности {duration} ч. максимальное время начала: {max_start}",
+			Kazakh:  "сағаттық жалдау 22:00-ге дейін аяқталуы керек. {duration} сағаттық мерзім үшін ең кеш басталу уақыты: {max_start}",
+			English: "hourly rent must end by 22:00. For {duration} hours the latest start time is {max_start}",
+		},
+		"booking.start_in_past": {
+			Russian: "время бронирования не может быть в прошлом",
+			Kazakh:  "брондау уақыты өткен уақытта болмауы керек",
+			English: "the booking time cannot be in the past",
+		},
+		"booking.date_in_past": {
+			Russian: "дата не может быть в прошлом",
+			Kazakh:  "күн өткен уақытта болмауы керек",
+			English: "the date cannot be in the past",
+		},
+		"booking.too_far_in_advance": {
+			Russian: "бронирование нельзя создать более чем на {days} дней вперед",
+			Kazakh:  "брондауды {days} күннен артық алдын ала жасауға болмайды",
+			English: "bookings cannot be made more than {days} days in advance",
+		},
+		"booking.contract_not_accepted": {
+			Russian: "необходимо принять условия договора аренды",
+			Kazakh:  "жалдау шартының талаптарын қабылдау қажет",
+			English: "you must accept the terms of the rental agreement",
+		},
+		"booking.receipt_not_apartment_owner": {
+			Russian: "отказано в доступе: вы не являетесь владельцем данной квартиры",
+			Kazakh:  "қол жеткізуге тыйым салынған: сіз бұл пәтердің иесі емессіз",
+			English: "access denied: you are not the owner of this apartment",
+		},
+		"booking.receipt_role_forbidden": {
+			Russian: "отказано в доступе: недостаточно прав для просмотра чека (роль: {role})",
+			Kazakh:  "қол жеткізуге тыйым салынған: түбіртекті көруге құқық жеткіліксіз (рөл: {role})",
+			English: "access denied: insufficient rights to view the receipt (role: {role})",
+		},
+		"booking.receipt_only_own": {
+			Russian: "отказано в доступе: вы можете просматривать только свои чеки об оплате",
+			Kazakh:  "қол жеткізуге тыйым салынған: тек өз төлем түбіртектеріңізді көре аласыз",
+			English: "access denied: you can only view your own payment receipts",
+		},
+		"booking.receipt_payment_not_found": {
+			Russian: "платеж для данного бронирования не найден",
+			Kazakh:  "бұл брондау бойынша төлем табылмады",
+			English: "no payment found for this booking",
+		},
+		"booking.receipt_successful_payment_not_found": {
+			Russian: "успешный платеж для данного бронирования не найден",
+			Kazakh:  "бұл брондау бойынша сәтті төлем табылмады",
+			English: "no successful payment found for this booking",
+		},
+		"booking.receipt_payment_data_error": {
+			Russian: "ошибка получения данных платежа",
+			Kazakh:  "төлем деректерін алу қатесі",
+			English: "failed to get payment data",
+		},
+		"booking.receipt_payment_not_in_system": {
+			Russian: "платеж не найден в системе",
+			Kazakh:  "төлем жүйеде табылмады",
+			English: "payment not found in the system",
+		},
+		"booking.not_found": {
+			Russian: "бронирование не найдено",
+			Kazakh:  "брондау табылмады",
+			English: "booking not found",
+		},
+		"booking.extension_not_found": {
+			Russian: "продление не найдено",
+			Kazakh:  "ұзарту табылмады",
+			English: "booking extension not found",
+		},
+		"booking.user_not_found": {
+			Russian: "пользователь не найден",
+			Kazakh:  "пайдаланушы табылмады",
+			English: "user not found",
+		},
+		"booking.renter_profile_not_found": {
+			Russian: "профиль арендатора не найден",
+			Kazakh:  "жалға алушы профилі табылмады",
+			English: "renter profile not found",
+		},
+		"booking.payment_not_found": {
+			Russian: "платеж не найден",
+			Kazakh:  "төлем табылмады",
+			English: "payment not found",
+		},
+		"booking.admin_only": {
+			Russian: "недостаточно прав",
+			Kazakh:  "құқықтар жеткіліксіз",
+			English: "insufficient permissions",
+		},
+		"booking.already_canceled": {
+			Russian: "бронирование уже отменено",
+			Kazakh:  "брондаудың күші бұрын жойылған",
+			English: "the booking is already canceled",
+		},
+		"booking.cannot_cancel_completed": {
+			Russian: "нельзя отменить завершенное бронирование",
+			Kazakh:  "аяқталған брондаудың күшін жоюға болмайды",
+			English: "a completed booking cannot be canceled",
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package i18n
+
+// Ошибки замков, API устройств и webhook Tuya.
+func init() {
+	register(map[string]Messages{
+		"lock.device_signature_missing": {
+			Russian: "запрос устройства не подписан: нужны заголовки X-Lock-ID, X-Lock-Timestamp, X-Lock-Nonce и X-Lock-Signature",
+			Kazakh:  "құрылғы сұрауына қол қойылмаған: X-Lock-ID, X-Lock-Timestamp, X-Lock-Nonce және X-Lock-Signature тақырыптары қажет",
+			English: "device request is not signed: X-Lock-ID, X-Lock-Timestamp, X-Lock-Nonce and X-Lock-Signature headers are required",
+		},
+		"lock.device_signature_invalid": {
+			Russian: "неверная подпись запроса устройства",
+			Kazakh:  "құрылғы сұрауының қолтаңбасы жарамсыз",
+			English: "invalid device request signature",
+		},
+		"lock.device_timestamp_skew": {
+			Russian: "время запроса устройства отличается от времени сервера больше чем на {seconds} секунд",
+			Kazakh:  "құрылғы сұрауының уақыты сервер уақытынан {seconds} секундтан артық ерекшеленеді",
+			English: "device request time differs from server time by more than {seconds} seconds",
+		},
+		"lock.device_nonce_reused": {
+			Russian: "запрос устройства уже был обработан (повтор nonce)",
+			Kazakh:  "құрылғы сұрауы бұрын өңделген (nonce қайталанды)",
+			English: "device request has already been processed (nonce reused)",
+		},
+		"lock.device_not_provisioned": {
+			Russian: "для замка не выдан секрет устройства",
+			Kazakh:  "құлыпқа құрылғы құпиясы берілмеген",
+			English: "no device secret has been issued for the lock",
+		},
+		"lock.device_mismatch": {
+			Russian: "подпись выдана для другого замка",
+			Kazakh:  "қолтаңба басқа құлып үшін берілген",
+			English: "signature was issued for a different lock",
+		},
+		"lock.tuya_webhook_malformed": {
+			Russian: "неверный формат сообщения Tuya",
+			Kazakh:  "Tuya хабарламасының пішімі қате",
+			English: "malformed Tuya message",
+		},
+		"lock.tuya_webhook_signature_invalid": {
+			Russian: "неверная подпись сообщения Tuya",
+			Kazakh:  "Tuya хабарламасының қолтаңбасы жарамсыз",
+			English: "invalid Tuya message signature",
+		},
+		"lock.tuya_webhook_expired": {
+			Russian: "сообщение Tuya устарело или пришло из будущего",
+			Kazakh:  "Tuya хабарламасы ескірген немесе болашақ уақытпен келген",
+			English: "Tuya message is outside the allowed time window",
+		},
+		"lock.tuya_webhook_decrypt_failed": {
+			Russian: "не удалось расшифровать сообщение Tuya",
+			Kazakh:  "Tuya хабарламасын шифрдан шығару мүмкін болмады",
+			English: "failed to decrypt Tuya message",
+		},
+		"lock.not_found": {
+			Russian: "замок не найден",
+			Kazakh:  "құлып табылмады",
+			English: "lock not found",
+		},
+		"lock.apartment_not_found": {
+			Russian: "квартира не найдена",
+			Kazakh:  "пәтер табылмады",
+			English: "apartment not found",
+		},
+		"lock.password_not_found": {
+			Russian: "пароль замка не найден",
+			Kazakh:  "құлып құпиясөзі табылмады",
+			English: "lock password not found",
+		},
+		"lock.device_secret_not_found": {
+			Russian: "секрет устройства замка не найден",
+			Kazakh:  "құлып құрылғысының құпиясы табылмады",
+			English: "lock device secret not found",
+		},
+		"lock.grace_period_negative": {
+			Russian: "льготный период не может быть отрицательным",
+			Kazakh:  "жеңілдік кезеңі теріс бола алмайды",
+			English: "grace period cannot be negative",
+		},
+	})
+}
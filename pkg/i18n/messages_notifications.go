@@ -0,0 +1,427 @@
+package i18n
+
+// Заголовки и тексты уведомлений, шаблоны SMS и писем. Параметры подставляются в момент отправки.
+func init() {
+	register(map[string]Messages{
+		"notification.booking_approved.title": {
+			Russian: "Бронирование одобрено!",
+			Kazakh:  "Брондау мақұлданды!",
+			English: "Booking approved!",
+		},
+		"notification.booking_approved.message": {
+			Russian: "Ваше бронирование квартиры '{apartment}' было одобрено владельцем",
+			Kazakh:  "'{apartment}' пәтерін брондауыңызды иесі мақұлдады",
+			English: "Your booking of '{apartment}' has been approved by the owner",
+		},
+		"notification.booking_rejected.title": {
+			Russian: "Бронирование отклонено",
+			Kazakh:  "Брондау қабылданбады",
+			English: "Booking rejected",
+		},
+		"notification.booking_rejected.message": {
+			Russian: "Ваше бронирование квартиры '{apartment}' было отклонено",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз қабылданбады",
+			English: "Your booking of '{apartment}' has been rejected",
+		},
+		"notification.booking_rejected.message_reason": {
+			Russian: "Ваше бронирование квартиры '{apartment}' было отклонено. Причина: {reason}",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз қабылданбады. Себебі: {reason}",
+			English: "Your booking of '{apartment}' has been rejected. Reason: {reason}",
+		},
+		"notification.password_ready.title": {
+			Russian: "Пароль для замка готов!",
+			Kazakh:  "Құлып құпиясөзі дайын!",
+			English: "Your door code is ready!",
+		},
+		"notification.password_ready.message": {
+			Russian: "Временный пароль для квартиры '{apartment}' создан и готов к использованию",
+			Kazakh:  "'{apartment}' пәтеріне уақытша құпиясөз жасалды және пайдалануға дайын",
+			English: "A temporary door code for '{apartment}' has been created and is ready to use",
+		},
+		"notification.booking_starting_soon.title": {
+			Russian: "Бронирование начинается скоро!",
+			Kazakh:  "Брондау жақында басталады!",
+			English: "Your booking starts soon!",
+		},
+		"notification.booking_starting_soon.message_days": {
+			Russian: "Ваше бронирование квартиры '{apartment}' начинается через {count} дней",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз {count} күннен кейін басталады",
+			English: "Your booking of '{apartment}' starts in {count} days",
+		},
+		"notification.booking_starting_soon.message_hours": {
+			Russian: "Ваше бронирование квартиры '{apartment}' начинается через {count} часов",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз {count} сағаттан кейін басталады",
+			English: "Your booking of '{apartment}' starts in {count} hours",
+		},
+		"notification.booking_starting_soon.message_minutes": {
+			Russian: "Ваше бронирование квартиры '{apartment}' начинается через {count} минут",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз {count} минуттан кейін басталады",
+			English: "Your booking of '{apartment}' starts in {count} minutes",
+		},
+		"notification.booking_ending.title": {
+			Russian: "Бронирование заканчивается",
+			Kazakh:  "Брондау аяқталуда",
+			English: "Your booking is ending",
+		},
+		"notification.booking_ending.message": {
+			Russian: "Ваше бронирование квартиры '{apartment}' заканчивается через час",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз бір сағаттан кейін аяқталады",
+			English: "Your booking of '{apartment}' ends in an hour",
+		},
+		"notification.lock_issue.title": {
+			Russian: "⚠️ Проблема с замком",
+			Kazakh:  "⚠️ Құлыпта ақау бар",
+			English: "⚠️ Lock problem",
+		},
+		"notification.lock_issue.message": {
+			Russian: "Обнаружена проблема с замком в квартире '{apartment}': {issue}",
+			Kazakh:  "'{apartment}' пәтеріндегі құлыпта ақау анықталды: {issue}",
+			English: "A problem with the lock in '{apartment}' was detected: {issue}",
+		},
+		"notification.payment_required.title": {
+			Russian: "Требуется оплата",
+			Kazakh:  "Төлем қажет",
+			English: "Payment required",
+		},
+		"notification.payment_required.message": {
+			Russian: "Для завершения бронирования квартиры '{apartment}' необходимо оплатить {amount} тенге",
+			Kazakh:  "'{apartment}' пәтерін брондауды аяқтау үшін {amount} теңге төлеу қажет",
+			English: "To complete your booking of '{apartment}', please pay {amount} KZT",
+		},
+		"notification.session_finished.title": {
+			Russian: "Сеанс завершен досрочно",
+			Kazakh:  "Сеанс мерзімінен бұрын аяқталды",
+			English: "Stay ended early",
+		},
+		"notification.session_finished.message": {
+			Russian: "Арендатор {renter} завершил сеанс в квартире '{apartment}' раньше запланированного времени",
+			Kazakh:  "{renter} жалға алушы '{apartment}' пәтеріндегі сеансты жоспарланған уақыттан бұрын аяқтады",
+			English: "Guest {renter} ended the stay in '{apartment}' earlier than planned",
+		},
+		"notification.extension_requested.title": {
+			Russian: "Запрос на продление",
+			Kazakh:  "Ұзартуға сұраныс",
+			English: "Extension request",
+		},
+		"notification.extension_requested.message": {
+			Russian: "Арендатор {renter} запросил продление бронирования квартиры '{apartment}' на {hours} часов",
+			Kazakh:  "{renter} жалға алушы '{apartment}' пәтерін брондауды {hours} сағатқа ұзартуды сұрады",
+			English: "Guest {renter} requested to extend the booking of '{apartment}' by {hours} hours",
+		},
+		"notification.extension_approved.title": {
+			Russian: "Продление одобрено",
+			Kazakh:  "Ұзарту мақұлданды",
+			English: "Extension approved",
+		},
+		"notification.extension_approved.message": {
+			Russian: "Ваш запрос на продление бронирования квартиры '{apartment}' на {hours} часов был одобрен владельцем",
+			Kazakh:  "'{apartment}' пәтерін брондауды {hours} сағатқа ұзарту туралы сұранысыңызды иесі мақұлдады",
+			English: "Your request to extend the booking of '{apartment}' by {hours} hours has been approved by the owner",
+		},
+		"notification.extension_rejected.title": {
+			Russian: "Продление отклонено",
+			Kazakh:  "Ұзарту қабылданбады",
+			English: "Extension rejected",
+		},
+		"notification.extension_rejected.message": {
+			Russian: "Ваш запрос на продление бронирования квартиры '{apartment}' на {hours} часов был отклонен владельцем",
+			Kazakh:  "'{apartment}' пәтерін брондауды {hours} сағатқа ұзарту туралы сұранысыңызды иесі қабылдамады",
+			English: "Your request to extend the booking of '{apartment}' by {hours} hours has been rejected by the owner",
+		},
+		"notification.extension_refund.title": {
+			Russian: "Возврат за продление",
+			Kazakh:  "Ұзарту үшін қаражатты қайтару",
+			English: "Extension refund",
+		},
+		"notification.extension_refund.message": {
+			Russian: "Владелец не ответил на запрос продления квартиры '{apartment}' на {hours} часов. Средства возвращены на ваш счет",
+			Kazakh:  "Иесі '{apartment}' пәтерін {hours} сағатқа ұзарту сұранысына жауап бермеді. Қаражат шотыңызға қайтарылды",
+			English: "The owner did not respond to your request to extend '{apartment}' by {hours} hours. The funds have been returned to your account",
+		},
+		"notification.new_booking.title": {
+			Russian: "Новый запрос на бронирование",
+			Kazakh:  "Брондауға жаңа сұраныс",
+			English: "New booking request",
+		},
+		"notification.new_booking.message": {
+			Russian: "Пользователь {renter} хочет забронировать вашу квартиру '{apartment}'",
+			Kazakh:  "{renter} пайдаланушысы '{apartment}' пәтеріңізді брондағысы келеді",
+			English: "{renter} wants to book your apartment '{apartment}'",
+		},
+		"notification.booking_started.title": {
+			Russian: "Аренда началась",
+			Kazakh:  "Жалдау басталды",
+			English: "Stay started",
+		},
+		"notification.booking_started.message": {
+			Russian: "Началась аренда вашей квартиры '{apartment}' пользователем {renter}",
+			Kazakh:  "{renter} пайдаланушысы '{apartment}' пәтеріңізді жалдауды бастады",
+			English: "{renter}'s stay in your apartment '{apartment}' has started",
+		},
+		"notification.renter_booking_started.message": {
+			Russian: "Ваша аренда квартиры '{apartment}' началась. Добро пожаловать!",
+			Kazakh:  "'{apartment}' пәтерін жалдауыңыз басталды. Қош келдіңіз!",
+			English: "Your stay in '{apartment}' has started. Welcome!",
+		},
+		"notification.booking_canceled.title": {
+			Russian: "Бронирование отменено",
+			Kazakh:  "Брондаудың күші жойылды",
+			English: "Booking canceled",
+		},
+		"notification.booking_canceled.message": {
+			Russian: "Ваше бронирование квартиры '{apartment}' было отменено. Причина: {reason}",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыздың күші жойылды. Себебі: {reason}",
+			English: "Your booking of '{apartment}' has been canceled. Reason: {reason}",
+		},
+		"notification.booking_completed.title": {
+			Russian: "Бронирование завершено",
+			Kazakh:  "Брондау аяқталды",
+			English: "Booking completed",
+		},
+		"notification.booking_completed.message": {
+			Russian: "Ваше бронирование квартиры '{apartment}' успешно завершено. Спасибо за использование нашего сервиса!",
+			Kazakh:  "'{apartment}' пәтерін брондауыңыз сәтті аяқталды. Сервисімізді пайдаланғаныңызға рахмет!",
+			English: "Your booking of '{apartment}' has been completed. Thank you for using our service!",
+		},
+		"notification.apartment_created.title": {
+			Russian: "Квартира добавлена",
+			Kazakh:  "Пәтер қосылды",
+			English: "Apartment added",
+		},
+		"notification.apartment_created.message": {
+			Russian: "Ваша квартира '{apartment}' успешно добавлена и отправлена на модерацию",
+			Kazakh:  "'{apartment}' пәтеріңіз сәтті қосылып, модерацияға жіберілді",
+			English: "Your apartment '{apartment}' has been added and sent for moderation",
+		},
+		"notification.apartment_approved.title": {
+			Russian: "Квартира одобрена!",
+			Kazakh:  "Пәтер мақұлданды!",
+			English: "Apartment approved!",
+		},
+		"notification.apartment_approved.message": {
+			Russian: "Ваша квартира '{apartment}' прошла модерацию и опубликована на платформе",
+			Kazakh:  "'{apartment}' пәтеріңіз модерациядан өтіп, платформада жарияланды",
+			English: "Your apartment '{apartment}' has passed moderation and is now published",
+		},
+		"notification.apartment_published.message": {
+			Russian: "Ваша квартира '{apartment}' одобрена и опубликована",
+			Kazakh:  "'{apartment}' пәтеріңіз мақұлданып, жарияланды",
+			English: "Your apartment '{apartment}' has been approved and published",
+		},
+		"notification.apartment_rejected.title": {
+			Russian: "Квартира отклонена",
+			Kazakh:  "Пәтер қабылданбады",
+			English: "Apartment rejected",
+		},
+		"notification.apartment_rejected.message": {
+			Russian: "Ваша квартира '{apartment}' была отклонена модератором",
+			Kazakh:  "'{apartment}' пәтеріңізді модератор қабылдамады",
+			English: "Your apartment '{apartment}' has been rejected by a moderator",
+		},
+		"notification.apartment_rejected.message_reason": {
+			Russian: "Ваша квартира '{apartment}' была отклонена модератором. Причина: {reason}",
+			Kazakh:  "'{apartment}' пәтеріңізді модератор қабылдамады. Себебі: {reason}",
+			English: "Your apartment '{apartment}' has been rejected by a moderator. Reason: {reason}",
+		},
+		"notification.apartment_updated.title": {
+			Russian: "Квартира обновлена",
+			Kazakh:  "Пәтер жаңартылды",
+			English: "Apartment updated",
+		},
+		"notification.apartment_updated.message": {
+			Russian: "Информация о квартире '{apartment}' обновлена и отправлена на повторную модерацию",
+			Kazakh:  "'{apartment}' пәтері туралы ақпарат жаңартылып, қайта модерацияға жіберілді",
+			English: "The details of '{apartment}' have been updated and sent for moderation again",
+		},
+		"notification.apartment_blocked.title": {
+			Russian: "Квартира заблокирована",
+			Kazakh:  "Пәтер бұғатталды",
+			English: "Apartment blocked",
+		},
+		"notification.apartment_blocked.message": {
+			Russian: "Ваша квартира '{apartment}' была заблокирована администратором",
+			Kazakh:  "'{apartment}' пәтеріңізді әкімші бұғаттады",
+			English: "Your apartment '{apartment}' has been blocked by an administrator",
+		},
+		"notification.apartment_deactivated.title": {
+			Russian: "Квартира деактивирована",
+			Kazakh:  "Пәтер өшірілді",
+			English: "Apartment deactivated",
+		},
+		"notification.apartment_deactivated.message": {
+			Russian: "Ваша квартира '{apartment}' была деактивирована",
+			Kazakh:  "'{apartment}' пәтеріңіз өшірілді",
+			English: "Your apartment '{apartment}' has been deactivated",
+		},
+		"notification.apartment_status_changed.title": {
+			Russian: "Статус квартиры изменен",
+			Kazakh:  "Пәтер мәртебесі өзгерді",
+			English: "Apartment status changed",
+		},
+		"notification.apartment_status_changed.message": {
+			Russian: "Статус вашей квартиры '{apartment}' изменен с '{old_status}' на '{new_status}'",
+			Kazakh:  "'{apartment}' пәтеріңіздің мәртебесі '{old_status}' мәнінен '{new_status}' мәніне өзгерді",
+			English: "The status of your apartment '{apartment}' has changed from '{old_status}' to '{new_status}'",
+		},
+		"notification.apartment_deleted.title": {
+			Russian: "Квартира удалена",
+			Kazakh:  "Пәтер жойылды",
+			English: "Apartment deleted",
+		},
+		"notification.apartment_deleted.message": {
+			Russian: "Ваша квартира \"{address}\" была удалена администратором",
+			Kazakh:  "\"{address}\" пәтеріңізді әкімші жойды",
+			English: "Your apartment \"{address}\" has been deleted by an administrator",
+		},
+		"notification.apartment_review_requested.title": {
+			Russian: "Оцените проживание",
+			Kazakh:  "Тұруыңызды бағалаңыз",
+			English: "Rate your stay",
+		},
+		"notification.apartment_review_requested.message": {
+			Russian: "Как вам квартира '{apartment}'? Оставьте отзыв до {deadline} — он поможет другим гостям",
+			Kazakh:  "'{apartment}' пәтері ұнады ма? {deadline} дейін пікір қалдырыңыз — ол басқа қонақтарға көмектеседі",
+			English: "How was '{apartment}'? Leave a review by {deadline} — it will help other guests",
+		},
+		"notification.renter_review_requested.title": {
+			Russian: "Оцените гостя",
+			Kazakh:  "Қонақты бағалаңыз",
+			English: "Rate your guest",
+		},
+		"notification.renter_review_requested.message": {
+			Russian: "Гость {renter} выехал. Оставьте отзыв об арендаторе до {deadline}",
+			Kazakh:  "{renter} қонақ шығып кетті. {deadline} дейін жалға алушы туралы пікір қалдырыңыз",
+			English: "Guest {renter} has checked out. Leave a review of the guest by {deadline}",
+		},
+		"notification.review_published.title": {
+			Russian: "Новый отзыв",
+			Kazakh:  "Жаңа пікір",
+			English: "New review",
+		},
+		"notification.review_published.message": {
+			Russian: "О квартире '{apartment}' опубликован новый отзыв с оценкой {rating}",
+			Kazakh:  "'{apartment}' пәтері туралы {rating} бағасымен жаңа пікір жарияланды",
+			English: "A new review with a rating of {rating} has been published for '{apartment}'",
+		},
+		"notification.review_rejected.title": {
+			Russian: "Отзыв отклонен",
+			Kazakh:  "Пікір қабылданбады",
+			English: "Review rejected",
+		},
+		"notification.review_rejected.message": {
+			Russian: "Ваш отзыв не прошел модерацию",
+			Kazakh:  "Пікіріңіз модерациядан өтпеді",
+			English: "Your review did not pass moderation",
+		},
+		"notification.review_rejected.message_reason": {
+			Russian: "Ваш отзыв не прошел модерацию. Причина: {reason}",
+			Kazakh:  "Пікіріңіз модерациядан өтпеді. Себебі: {reason}",
+			English: "Your review did not pass moderation. Reason: {reason}",
+		},
+		"notification.cleaning_completed.title": {
+			Russian: "Уборка завершена",
+			Kazakh:  "Тазалау аяқталды",
+			English: "Cleaning completed",
+		},
+		"notification.cleaning_completed.message": {
+			Russian: "Квартира '{apartment}' убрана. Фотографий после уборки: {photos}",
+			Kazakh:  "'{apartment}' пәтері тазаланды. Тазалаудан кейінгі фотосуреттер: {photos}",
+			English: "'{apartment}' has been cleaned. Photos after cleaning: {photos}",
+		},
+		"notification.cleaning_task_offered.title": {
+			Russian: "Новая уборка",
+			Kazakh:  "Жаңа тазалау",
+			English: "New cleaning task",
+		},
+		"notification.cleaning_task_offered.message": {
+			Russian: "Нужна уборка квартиры '{apartment}' до {deadline}. Подтвердите заявку в течение {minutes} мин.",
+			Kazakh:  "'{apartment}' пәтерін {deadline} дейін тазалау керек. Өтінімді {minutes} мин. ішінде растаңыз",
+			English: "'{apartment}' needs cleaning by {deadline}. Please accept the task within {minutes} min.",
+		},
+		"notification.cleaning_task_escalated.title": {
+			Russian: "Уборка не назначена",
+			Kazakh:  "Тазалау тағайындалмады",
+			English: "Cleaning not assigned",
+		},
+		"notification.cleaning_task_escalated.message": {
+			Russian: "Уборку квартиры '{apartment}' (до {deadline}) не приняла ни одна уборщица. Назначьте уборщицу вручную",
+			Kazakh:  "'{apartment}' пәтерін тазалауды ({deadline} дейін) бірде-бір тазалаушы қабылдамады. Тазалаушыны қолмен тағайындаңыз",
+			English: "No cleaner accepted the cleaning of '{apartment}' (due {deadline}). Please assign a cleaner manually",
+		},
+		"notification.cleaning_late.title": {
+			Russian: "Уборка задерживается",
+			Kazakh:  "Тазалау кешігуде",
+			English: "Cleaning is late",
+		},
+		"notification.cleaning_late.message": {
+			Russian: "Уборка квартиры '{apartment}' не завершена к {deadline}",
+			Kazakh:  "'{apartment}' пәтерін тазалау {deadline} дейін аяқталмады",
+			English: "Cleaning of '{apartment}' was not completed by {deadline}",
+		},
+		"notification.sms.default": {
+			Russian: "renti.kz: {title}. {message}",
+			Kazakh:  "renti.kz: {title}. {message}",
+			English: "renti.kz: {title}. {message}",
+		},
+		"notification.sms.password_ready": {
+			Russian: "renti.kz: {message}. Код доступа доступен в приложении и личном кабинете.",
+			Kazakh:  "renti.kz: {message}. Кіру коды қосымшада және жеке кабинетте қолжетімді.",
+			English: "renti.kz: {message}. The access code is available in the app and your account.",
+		},
+		"notification.sms.lock_issue": {
+			Russian: "renti.kz: ВНИМАНИЕ! {message}",
+			Kazakh:  "renti.kz: НАЗАР АУДАРЫҢЫЗ! {message}",
+			English: "renti.kz: ATTENTION! {message}",
+		},
+		"notification.sms.booking_approved": {
+			Russian: "renti.kz: {message}.",
+			Kazakh:  "renti.kz: {message}.",
+			English: "renti.kz: {message}.",
+		},
+		"notification.sms.payment_required": {
+			Russian: "renti.kz: {message}. Оплатите бронирование в приложении.",
+			Kazakh:  "renti.kz: {message}. Брондауды қосымшада төлеңіз.",
+			English: "renti.kz: {message}. Please pay for the booking in the app.",
+		},
+		"notification.email.subject.default": {
+			Russian: "{title} — renti.kz",
+			Kazakh:  "{title} — renti.kz",
+			English: "{title} — renti.kz",
+		},
+		"notification.email.subject.password_ready": {
+			Russian: "Код доступа к квартире готов — renti.kz",
+			Kazakh:  "Пәтерге кіру коды дайын — renti.kz",
+			English: "Your apartment access code is ready — renti.kz",
+		},
+		"notification.email.subject.lock_issue": {
+			Russian: "Проблема с замком — renti.kz",
+			Kazakh:  "Құлыпта ақау бар — renti.kz",
+			English: "Lock problem — renti.kz",
+		},
+		"notification.email.subject.booking_approved": {
+			Russian: "Бронирование подтверждено — renti.kz",
+			Kazakh:  "Брондау расталды — renti.kz",
+			English: "Booking confirmed — renti.kz",
+		},
+		"notification.email.greeting": {
+			Russian: "Здравствуйте, {name}!",
+			Kazakh:  "Сәлеметсіз бе, {name}!",
+			English: "Hello, {name}!",
+		},
+		"notification.email.greeting_anonymous": {
+			Russian: "Здравствуйте!",
+			Kazakh:  "Сәлеметсіз бе!",
+			English: "Hello!",
+		},
+		"notification.email.signature": {
+			Russian: "Команда renti.kz",
+			Kazakh:  "renti.kz командасы",
+			English: "The renti.kz team",
+		},
+		"notification.email.text": {
+			Russian: "{greeting}\n\n{message}\n\n—\n{signature}",
+			Kazakh:  "{greeting}\n\n{message}\n\n—\n{signature}",
+			English: "{greeting}\n\n{message}\n\n—\n{signature}",
+		},
+	})
+}
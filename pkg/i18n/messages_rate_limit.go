@@ -0,0 +1,22 @@
+package i18n
+
+// Ограничение частоты запросов и блокировка перебора.
+func init() {
+	register(map[string]Messages{
+		"rate_limit.exceeded": {
+			Russian: "слишком много запросов, повторите через {seconds} с",
+			Kazakh:  "сұраулар тым көп, {seconds} секундтан кейін қайталаңыз",
+			English: "too many requests, try again in {seconds} s",
+		},
+		"rate_limit.login_locked": {
+			Russian: "слишком много неудачных попыток входа, вход временно заблокирован. Повторите через {minutes} мин",
+			Kazakh:  "кіру әрекеттері тым көп сәтсіз болды, кіру уақытша бұғатталды. {minutes} минуттан кейін қайталаңыз",
+			English: "too many failed sign-in attempts, sign-in is temporarily locked. Try again in {minutes} min",
+		},
+		"rate_limit.otp_locked": {
+			Russian: "слишком много неверных кодов, проверка временно заблокирована. Повторите через {minutes} мин",
+			Kazakh:  "қате кодтар тым көп енгізілді, тексеру уақытша бұғатталды. {minutes} минуттан кейін қайталаңыз",
+			English: "too many invalid codes, verification is temporarily locked. Try again in {minutes} min",
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package i18n
+
+// Ошибки управления пользователями.
+func init() {
+	register(map[string]Messages{
+		"user.not_found": {
+			Russian: "пользователь не найден",
+			Kazakh:  "пайдаланушы табылмады",
+			English: "user not found",
+		},
+		"user.admin_required": {
+			Russian: "действие доступно только администраторам",
+			Kazakh:  "әрекет тек әкімшілерге қолжетімді",
+			English: "only administrators can perform this action",
+		},
+		"user.role_not_found": {
+			Russian: "роль {role} не найдена",
+			Kazakh:  "{role} рөлі табылмады",
+			English: "role {role} not found",
+		},
+		"user.own_status_change": {
+			Russian: "администратор не может изменить собственный статус",
+			Kazakh:  "әкімші өз мәртебесін өзгерте алмайды",
+			English: "administrators cannot change their own status",
+		},
+		"user.password_regular_user": {
+			Russian: "пароль нельзя установить обычному пользователю",
+			Kazakh:  "қарапайым пайдаланушыға құпиясөз орнатуға болмайды",
+			English: "password cannot be set for regular users",
+		},
+		"user.password_too_short": {
+			Russian: "пароль должен содержать не менее {min} символов",
+			Kazakh:  "құпиясөз кемінде {min} таңбадан тұруы керек",
+			English: "password must be at least {min} characters",
+		},
+		"user.phone_taken": {
+			Russian: "пользователь с таким номером телефона уже зарегистрирован",
+			Kazakh:  "осы телефон нөмірімен пайдаланушы тіркелген",
+			English: "a user with this phone number already exists",
+		},
+		"user.email_taken": {
+			Russian: "пользователь с таким email уже зарегистрирован",
+			Kazakh:  "осы email-мен пайдаланушы тіркелген",
+			English: "a user with this email already exists",
+		},
+	})
+}
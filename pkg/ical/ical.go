@@ -0,0 +1,324 @@
+// Package ical реализует минимальное подмножество RFC 5545, достаточное для обмена
+// занятыми периодами с внешними площадками: генерацию VCALENDAR с событиями VEVENT
+// и разбор событий из чужих календарей.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout      = "20060102"
+	dateTimeLayout  = "20060102T150405"
+	utcLayout       = "20060102T150405Z"
+	maxLineOctets   = 75
+	crlf            = "\r\n"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event событие календаря. AllDay - даты без времени (VALUE=DATE), End не включается в период.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Status      string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Stamp       time.Time
+}
+
+// Calendar календарь для экспорта.
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []Event
+}
+
+// Write сериализует календарь в формате text/calendar.
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:" + escapeText(c.ProductID),
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+	if c.Name != "" {
+		lines = append(lines, "X-WR-CALNAME:"+escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		stamp := event.Stamp
+		if stamp.IsZero() {
+			stamp = time.Now()
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeText(event.UID),
+			"DTSTAMP:"+stamp.UTC().Format(utcLayout),
+		)
+		if event.AllDay {
+			lines = append(lines,
+				"DTSTART;VALUE=DATE:"+event.Start.Format(dateLayout),
+				"DTEND;VALUE=DATE:"+event.End.Format(dateLayout),
+			)
+		} else {
+			lines = append(lines,
+				"DTSTART:"+event.Start.UTC().Format(utcLayout),
+				"DTEND:"+event.End.UTC().Format(utcLayout),
+			)
+		}
+		if event.Summary != "" {
+			lines = append(lines, "SUMMARY:"+escapeText(event.Summary))
+		}
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		if event.Status != "" {
+			lines = append(lines, "STATUS:"+event.Status)
+		}
+		lines = append(lines, "TRANSP:OPAQUE", "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := bw.WriteString(foldLine(line)); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// foldLine переносит строки длиннее 75 октетов, не разрывая UTF-8 символы.
+func foldLine(line string) string {
+	if len(line) <= maxLineOctets {
+		return line + crlf
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	width := 0
+	for _, r := range line {
+		size := utf8.RuneLen(r)
+		if width+size > limit {
+			b.WriteString(crlf + " ")
+			width = 0
+			// продолжение строки начинается с пробела, который тоже занимает октет
+			limit = maxLineOctets - 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString(crlf)
+
+	return b.String()
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+var textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescapeText(value string) string {
+	return textUnescaper.Replace(value)
+}
+
+// Parse разбирает события VEVENT из календаря. Время без часового пояса и даты
+// без времени интерпретируются в location.
+func Parse(r io.Reader, location *time.Location) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	var duration string
+	hasCalendar := false
+
+	for _, line := range lines {
+		name, params, value, ok := parseContentLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			hasCalendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+			duration = ""
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				continue
+			}
+			if current.End.IsZero() {
+				current.End = defaultEnd(current, duration)
+			}
+			if !current.Start.IsZero() {
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = unescapeText(value)
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "STATUS":
+			current.Status = strings.ToUpper(value)
+		case name == "DURATION":
+			duration = value
+		case name == "DTSTART":
+			start, allDay, err := parseDateValue(value, params, location)
+			if err != nil {
+				return nil, fmt.Errorf("некорректный DTSTART %q: %w", value, err)
+			}
+			current.Start = start
+			current.AllDay = allDay
+		case name == "DTEND":
+			end, _, err := parseDateValue(value, params, location)
+			if err != nil {
+				return nil, fmt.Errorf("некорректный DTEND %q: %w", value, err)
+			}
+			current.End = end
+		}
+	}
+
+	if !hasCalendar {
+		return nil, fmt.Errorf("данные не являются календарем iCalendar")
+	}
+
+	return events, nil
+}
+
+func defaultEnd(event *Event, duration string) time.Time {
+	if duration != "" {
+		if d, days, err := parseDuration(duration); err == nil {
+			return event.Start.AddDate(0, 0, days).Add(d)
+		}
+	}
+	if event.AllDay {
+		return event.Start.AddDate(0, 0, 1)
+	}
+	return event.Start
+}
+
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения календаря: %w", err)
+	}
+
+	return lines, nil
+}
+
+func parseContentLine(line string) (name string, params map[string]string, value string, ok bool) {
+	colon := indexOutsideQuotes(line, ':')
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	head := line[:colon]
+	value = line[colon+1:]
+
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, part := range parts[1:] {
+		if eq := strings.IndexByte(part, '='); eq > 0 {
+			params[strings.ToUpper(part[:eq])] = strings.Trim(part[eq+1:], `"`)
+		}
+	}
+
+	return name, params, value, true
+}
+
+func indexOutsideQuotes(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseDateValue(value string, params map[string]string, location *time.Location) (time.Time, bool, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, location)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcLayout, value)
+		return t, false, err
+	}
+
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			location = tz
+		}
+	}
+
+	t, err := time.ParseInLocation(dateTimeLayout, value, location)
+	return t, false, err
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration разбирает DURATION (RFC 5545 3.3.6) на дни и время.
+func parseDuration(value string) (time.Duration, int, error) {
+	match := durationPattern.FindStringSubmatch(strings.ToUpper(value))
+	if match == nil {
+		return 0, 0, fmt.Errorf("некорректный DURATION %q", value)
+	}
+
+	number := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	days := number(match[2])*7 + number(match[3])
+	d := time.Duration(number(match[4]))*time.Hour +
+		time.Duration(number(match[5]))*time.Minute +
+		time.Duration(number(match[6]))*time.Second
+
+	if match[1] == "-" {
+		return -d, -days, nil
+	}
+	return d, days, nil
+}
@@ -0,0 +1,17 @@
+package imaging
+
+import "net/http"
+
+// photoExtensions расширения файлов для форматов фотографий, которые принимаются на загрузку.
+var photoExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// PhotoExtension определяет формат фотографии по содержимому, а не по имени или заголовкам клиента,
+// и возвращает расширение файла. Для всего, кроме JPEG, PNG и WebP, возвращает false.
+func PhotoExtension(data []byte) (string, bool) {
+	extension, ok := photoExtensions[http.DetectContentType(data)]
+	return extension, ok
+}
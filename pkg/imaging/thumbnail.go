@@ -0,0 +1,143 @@
+// Package imaging содержит обработку загружаемых изображений без внешних зависимостей:
+// декодирование JPEG/PNG/GIF и построение уменьшенных превью.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Регистрация декодеров форматов для image.Decode
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	thumbnailQuality = 80
+
+	// MaxPixels предел размера изображения в пикселях. Небольшой PNG или GIF может объявить огромные
+	// размеры и при декодировании занять гигабайты памяти, поэтому размеры проверяются до декодирования.
+	MaxPixels = 40_000_000
+)
+
+var (
+	// ErrUnsupportedFormat формат изображения не поддерживается стандартной библиотекой (например, WebP).
+	ErrUnsupportedFormat = errors.New("неподдерживаемый формат изображения")
+	// ErrImageTooLarge изображение больше MaxPixels.
+	ErrImageTooLarge = errors.New("изображение слишком большое")
+)
+
+// Thumbnail превью изображения в формате JPEG и размеры исходного изображения.
+type Thumbnail struct {
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// DecodeConfig возвращает размеры изображения, не декодируя его целиком.
+// Изображения больше MaxPixels отклоняются с ErrImageTooLarge.
+func DecodeConfig(data []byte) (int, int, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return 0, 0, ErrUnsupportedFormat
+		}
+		return 0, 0, fmt.Errorf("ошибка чтения размеров изображения: %w", err)
+	}
+
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return 0, 0, ErrImageTooLarge
+	}
+
+	return cfg.Width, cfg.Height, nil
+}
+
+// MakeThumbnail строит JPEG-превью, вписанное в квадрат maxSide x maxSide.
+// Изображения меньше квадрата не увеличиваются.
+func MakeThumbnail(data []byte, maxSide int) (*Thumbnail, error) {
+	if _, _, err := DecodeConfig(data); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, fmt.Errorf("ошибка декодирования изображения: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("изображение не содержит пикселей")
+	}
+
+	dstWidth, dstHeight := fitInto(width, height, maxSide)
+	dst := downscale(src, dstWidth, dstHeight)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("ошибка кодирования превью: %w", err)
+	}
+
+	return &Thumbnail{
+		Data:   buf.Bytes(),
+		Width:  width,
+		Height: height,
+	}, nil
+}
+
+func fitInto(width, height, maxSide int) (int, int) {
+	if maxSide <= 0 || (width <= maxSide && height <= maxSide) {
+		return width, height
+	}
+
+	if width >= height {
+		return maxSide, max(1, height*maxSide/width)
+	}
+
+	return max(1, width*maxSide/height), maxSide
+}
+
+// downscale уменьшает изображение усреднением пикселей исходной области (box filter).
+// Прозрачные области накладываются на белый фон, так как JPEG не хранит альфа-канал.
+func downscale(src image.Image, dstWidth, dstHeight int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < dstHeight; y++ {
+		y0 := bounds.Min.Y + y*srcHeight/dstHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcHeight/dstHeight)
+
+		for x := 0; x < dstWidth; x++ {
+			x0 := bounds.Min.X + x*srcWidth/dstWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcWidth/dstWidth)
+
+			var r, g, b, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					background := 0xffff - uint64(ca)
+					r += uint64(cr) + background
+					g += uint64(cg) + background
+					b += uint64(cb) + background
+					count++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / count >> 8),
+				G: uint8(g / count >> 8),
+				B: uint8(b / count >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
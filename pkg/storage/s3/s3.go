@@ -13,6 +13,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
 	apperrors "github.com/russo2642/renti_kz/pkg/errors"
+	"github.com/russo2642/renti_kz/pkg/imaging"
 )
 
 type Storage struct {
@@ -180,6 +181,27 @@ func (s *Storage) UploadApartmentPhotosParallel(apartmentID int, photosData [][]
 	return s.UploadMultipleFiles(files)
 }
 
+// UploadCleaningPhotosParallel загружает фотографии после уборки. Расширение файла выбирается
+// по содержимому фотографии; файлы, которые не являются JPEG, PNG или WebP, не загружаются.
+func (s *Storage) UploadCleaningPhotosParallel(apartmentID int, photosData [][]byte) ([]string, error) {
+	date := time.Now().Format("2006-01-02")
+
+	files := make([]FileUpload, len(photosData))
+	for i, data := range photosData {
+		fileExt, ok := imaging.PhotoExtension(data)
+		if !ok {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("файл %d не является фотографией JPEG, PNG или WebP", i+1))
+		}
+		objectKey := fmt.Sprintf("apartments/%d/cleaning/%s/%s.%s", apartmentID, date, uuid.NewString(), fileExt)
+		files[i] = FileUpload{
+			ObjectKey: objectKey,
+			Data:      data,
+		}
+	}
+
+	return s.UploadMultipleFiles(files)
+}
+
 type FileUpload struct {
 	ObjectKey string
 	Data      []byte